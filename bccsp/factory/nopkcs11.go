@@ -19,12 +19,14 @@ package factory
 
 import (
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/vault"
 	"github.com/pkg/errors"
 )
 
 type FactoryOpts struct {
-	ProviderName string  `mapstructure:"default" json:"default" yaml:"Default"`
-	SwOpts       *SwOpts `mapstructure:"SW,omitempty" json:"SW,omitempty" yaml:"SwOpts"`
+	ProviderName string           `mapstructure:"default" json:"default" yaml:"Default"`
+	SwOpts       *SwOpts          `mapstructure:"SW,omitempty" json:"SW,omitempty" yaml:"SwOpts"`
+	VaultOpts    *vault.VaultOpts `mapstructure:"VAULT,omitempty" json:"VAULT,omitempty" yaml:"VAULT"`
 }
 
 // InitFactories must be called before using factory interfaces
@@ -58,6 +60,15 @@ func InitFactories(config *FactoryOpts) error {
 			}
 		}
 
+		// Vault-Based BCCSP
+		if config.VaultOpts != nil {
+			f := &VaultFactory{}
+			err := initBCCSP(f, config)
+			if err != nil {
+				factoriesInitError = errors.Wrapf(err, "Failed initializing VAULT.BCCSP %s", factoriesInitError)
+			}
+		}
+
 		var ok bool
 		defaultBCCSP, ok = bccspMap[config.ProviderName]
 		if !ok {
@@ -74,6 +85,8 @@ func GetBCCSPFromOpts(config *FactoryOpts) (bccsp.BCCSP, error) {
 	switch config.ProviderName {
 	case "SW":
 		f = &SWFactory{}
+	case "VAULT":
+		f = &VaultFactory{}
 	default:
 		return nil, errors.Errorf("Could not find BCCSP, no '%s' provider", config.ProviderName)
 	}
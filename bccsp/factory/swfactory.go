@@ -16,6 +16,8 @@ limitations under the License.
 package factory
 
 import (
+	"os"
+
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/pkg/errors"
@@ -24,6 +26,10 @@ import (
 const (
 	// SoftwareBasedFactoryName is the name of the factory of the software-based BCCSP implementation
 	SoftwareBasedFactoryName = "SW"
+
+	// KeyStorePassphraseEnv is the environment variable holding the passphrase
+	// used to encrypt the file-based key store, when none is set in the options
+	KeyStorePassphraseEnv = "FABRIC_BCCSP_KEYSTORE_PASSPHRASE"
 )
 
 // SWFactory is the factory of the software-based BCCSP.
@@ -47,7 +53,17 @@ func (f *SWFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
 	if swOpts.Ephemeral == true {
 		ks = sw.NewDummyKeyStore()
 	} else if swOpts.FileKeystore != nil {
-		fks, err := sw.NewFileBasedKeyStore(nil, swOpts.FileKeystore.KeyStorePath, false)
+		passphrase := swOpts.FileKeystore.Passphrase
+		if passphrase == "" {
+			passphrase = os.Getenv(KeyStorePassphraseEnv)
+		}
+
+		var pwd []byte
+		if passphrase != "" {
+			pwd = []byte(passphrase)
+		}
+
+		fks, err := sw.NewFileBasedKeyStore(pwd, swOpts.FileKeystore.KeyStorePath, false)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Failed to initialize software key store")
 		}
@@ -75,6 +91,9 @@ type SwOpts struct {
 // Pluggable Keystores, could add JKS, P12, etc..
 type FileKeystoreOpts struct {
 	KeyStorePath string `mapstructure:"keystore" yaml:"KeyStore"`
+	// Passphrase, if set, is used to encrypt the private keys in the key store.
+	// If empty, the FABRIC_BCCSP_KEYSTORE_PASSPHRASE environment variable is used.
+	Passphrase string `mapstructure:"passphrase,omitempty" json:"passphrase,omitempty" yaml:"Passphrase"`
 }
 
 type DummyKeystoreOpts struct{}
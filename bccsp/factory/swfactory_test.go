@@ -16,9 +16,14 @@ limitations under the License.
 package factory
 
 import (
+	"encoding/hex"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -68,3 +73,39 @@ func TestSWFactoryGet(t *testing.T) {
 	assert.NotNil(t, csp)
 
 }
+
+func TestSWFactoryGetWithPassphrase(t *testing.T) {
+	f := &SWFactory{}
+
+	dir, err := ioutil.TempDir("", "swfactory")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := &FactoryOpts{
+		SwOpts: &SwOpts{
+			SecLevel:     256,
+			HashFamily:   "SHA2",
+			FileKeystore: &FileKeystoreOpts{KeyStorePath: dir, Passphrase: "passphrase"},
+		},
+	}
+	csp, err := f.Get(opts)
+	assert.NoError(t, err)
+
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+
+	raw, err := ioutil.ReadFile(filepath.Join(dir, hex.EncodeToString(k.SKI())+"_sk"))
+	assert.NoError(t, err)
+	assert.True(t, utils.IsPKCS8EncryptedPEM(raw))
+
+	// The passphrase can also be provided through the environment
+	os.Setenv(KeyStorePassphraseEnv, "passphrase")
+	defer os.Unsetenv(KeyStorePassphraseEnv)
+	opts.SwOpts.FileKeystore.Passphrase = ""
+	csp, err = f.Get(opts)
+	assert.NoError(t, err)
+
+	k2, err := csp.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.True(t, k2.Private())
+}
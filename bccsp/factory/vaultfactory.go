@@ -0,0 +1,38 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/bccsp/vault"
+	"github.com/pkg/errors"
+)
+
+const (
+	// VaultBasedFactoryName is the name of the factory of the Vault-based BCCSP implementation
+	VaultBasedFactoryName = "VAULT"
+)
+
+// VaultFactory is the factory of the Vault-based BCCSP.
+type VaultFactory struct{}
+
+// Name returns the name of this factory
+func (f *VaultFactory) Name() string {
+	return VaultBasedFactoryName
+}
+
+// Get returns an instance of BCCSP using Opts.
+func (f *VaultFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
+	// Validate arguments
+	if config == nil || config.VaultOpts == nil {
+		return nil, errors.New("Invalid config. It must not be nil.")
+	}
+
+	// Private keys are held by Vault, keys handled in software are never persisted
+	return vault.New(*config.VaultOpts, sw.NewDummyKeyStore())
+}
@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp/vault"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVaultFactoryName(t *testing.T) {
+	f := &VaultFactory{}
+	assert.Equal(t, f.Name(), VaultBasedFactoryName)
+}
+
+func TestVaultFactoryGetInvalidArgs(t *testing.T) {
+	f := &VaultFactory{}
+
+	_, err := f.Get(nil)
+	assert.Error(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{})
+	assert.Error(t, err, "Invalid config. It must not be nil.")
+
+	opts := &FactoryOpts{
+		VaultOpts: &vault.VaultOpts{},
+	}
+	_, err = f.Get(opts)
+	assert.Error(t, err)
+}
+
+func TestVaultFactoryGet(t *testing.T) {
+	f := &VaultFactory{}
+
+	opts := &FactoryOpts{
+		VaultOpts: &vault.VaultOpts{
+			SecLevel:   256,
+			HashFamily: "SHA2",
+			Address:    "http://127.0.0.1:8200",
+			Token:      "token",
+		},
+	}
+	csp, err := f.Get(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, csp)
+}
+
+func TestGetBCCSPFromOptsVault(t *testing.T) {
+	opts := &FactoryOpts{
+		ProviderName: "VAULT",
+		VaultOpts: &vault.VaultOpts{
+			SecLevel:   256,
+			HashFamily: "SHA2",
+			Address:    "http://127.0.0.1:8200",
+		},
+	}
+	csp, err := GetBCCSPFromOpts(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, csp)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ed25519"
+)
+
+func TestED25519PrivateKey(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	k := &ed25519PrivateKey{priv}
+
+	assert.False(t, k.Symmetric())
+	assert.True(t, k.Private())
+
+	_, err = k.Bytes()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Not supported.")
+
+	k.privKey = nil
+	assert.Nil(t, k.SKI())
+
+	k.privKey = priv
+	hash := sha256.Sum256(pub)
+	assert.Equal(t, hash[:], k.SKI(), "SKI is not computed in the right way.")
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	ed25519PK, ok := pk.(*ed25519PublicKey)
+	assert.True(t, ok)
+	assert.Equal(t, pub, ed25519PK.pubKey)
+	assert.Equal(t, k.SKI(), pk.SKI())
+}
+
+func TestED25519PublicKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	k := &ed25519PublicKey{pub}
+
+	assert.False(t, k.Symmetric())
+	assert.False(t, k.Private())
+
+	k.pubKey = nil
+	assert.Nil(t, k.SKI())
+
+	k.pubKey = pub
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	assert.Equal(t, k, pk)
+
+	raw, err := k.Bytes()
+	assert.NoError(t, err)
+	pub2, err := utils.DERToEd25519PublicKey(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, pub, pub2)
+}
+
+func TestED25519SignVerify(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	k := &ed25519PrivateKey{priv}
+	pk, _ := k.PublicKey()
+
+	digest := sha256.Sum256([]byte("Hello World"))
+
+	sigma, err := (&ed25519Signer{}).Sign(k, digest[:], nil)
+	assert.NoError(t, err)
+
+	valid, err := (&ed25519PrivateKeyVerifier{}).Verify(k, sigma, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = (&ed25519PublicKeyKeyVerifier{}).Verify(pk, sigma, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = (&ed25519PublicKeyKeyVerifier{}).Verify(pk, sigma, []byte("another digest"), nil)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	_, err = (&ed25519PublicKeyKeyVerifier{}).Verify(pk, sigma[1:], digest[:], nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid signature length")
+
+	_, err = (&ed25519Signer{}).Sign(k, digest[:], crypto.SHA256)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Ed25519 does not support hash function")
+}
+
+func TestED25519KeyGenSignVerify(t *testing.T) {
+	k, err := currentBCCSP.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	assert.True(t, k.Private())
+	assert.False(t, k.Symmetric())
+
+	// The key must have been stored
+	k2, err := currentBCCSP.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.True(t, k2.Private())
+	assert.Equal(t, k.SKI(), k2.SKI())
+
+	digest, err := currentBCCSP.Hash([]byte("Hello World"), &bccsp.SHAOpts{})
+	assert.NoError(t, err)
+
+	signature, err := currentBCCSP.Sign(k2, digest, nil)
+	assert.NoError(t, err)
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	valid, err := currentBCCSP.Verify(pk, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestED25519KeyImport(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	pemKey, err := utils.PrivateKeyToPEM(priv, nil)
+	assert.NoError(t, err)
+	block, _ := pem.Decode(pemKey)
+	der := block.Bytes
+	sk, err := currentBCCSP.KeyImport(der, &bccsp.ED25519PrivateKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.True(t, sk.Private())
+
+	der, err = utils.PublicKeyToDER(pub)
+	assert.NoError(t, err)
+	pk, err := currentBCCSP.KeyImport(der, &bccsp.ED25519PKIXPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.False(t, pk.Private())
+	assert.Equal(t, sk.SKI(), pk.SKI())
+
+	pk2, err := currentBCCSP.KeyImport(pub, &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), pk2.SKI())
+
+	// Only the raw public key info is needed to recognize an Ed25519 certificate
+	cert := &x509.Certificate{RawSubjectPublicKeyInfo: der}
+	pk3, err := currentBCCSP.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), pk3.SKI())
+
+	_, err = currentBCCSP.KeyImport(der, &bccsp.ED25519PrivateKeyImportOpts{Temporary: true})
+	assert.Error(t, err)
+	_, err = currentBCCSP.KeyImport([]byte{}, &bccsp.ED25519PKIXPublicKeyImportOpts{Temporary: true})
+	assert.Error(t, err)
+	_, err = currentBCCSP.KeyImport("Hello World", &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true})
+	assert.Error(t, err)
+}
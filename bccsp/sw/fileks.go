@@ -32,10 +32,13 @@ import (
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/utils"
+	"golang.org/x/crypto/ed25519"
 )
 
 // NewFileBasedKeyStore instantiated a file-based key store at a given position.
 // The key store can be encrypted if a non-empty password is specifiec.
+// In that case, private keys are stored as PKCS#8 encrypted PEMs, and
+// existing unencrypted private keys are encrypted when the key store is opened.
 // It can be also be set as read only. In this case, any store operation
 // will be forbidden
 func NewFileBasedKeyStore(pwd []byte, path string, readOnly bool) (bccsp.KeyStore, error) {
@@ -89,6 +92,7 @@ func (ks *fileBasedKeyStore) Init(pwd []byte, path string, readOnly bool) error
 
 	ks.path = path
 	ks.pwd = utils.Clone(pwd)
+	ks.readOnly = readOnly
 
 	err := ks.createKeyStoreIfNotExists()
 	if err != nil {
@@ -100,8 +104,6 @@ func (ks *fileBasedKeyStore) Init(pwd []byte, path string, readOnly bool) error
 		return err
 	}
 
-	ks.readOnly = readOnly
-
 	return nil
 }
 
@@ -141,6 +143,8 @@ func (ks *fileBasedKeyStore) GetKey(ski []byte) (k bccsp.Key, err error) {
 			return &ecdsaPrivateKey{key.(*ecdsa.PrivateKey)}, nil
 		case *rsa.PrivateKey:
 			return &rsaPrivateKey{key.(*rsa.PrivateKey)}, nil
+		case ed25519.PrivateKey:
+			return &ed25519PrivateKey{key.(ed25519.PrivateKey)}, nil
 		default:
 			return nil, errors.New("Secret key type not recognized")
 		}
@@ -156,6 +160,8 @@ func (ks *fileBasedKeyStore) GetKey(ski []byte) (k bccsp.Key, err error) {
 			return &ecdsaPublicKey{key.(*ecdsa.PublicKey)}, nil
 		case *rsa.PublicKey:
 			return &rsaPublicKey{key.(*rsa.PublicKey)}, nil
+		case ed25519.PublicKey:
+			return &ed25519PublicKey{key.(ed25519.PublicKey)}, nil
 		default:
 			return nil, errors.New("Public key type not recognized")
 		}
@@ -207,6 +213,22 @@ func (ks *fileBasedKeyStore) StoreKey(k bccsp.Key) (err error) {
 			return fmt.Errorf("Failed storing RSA public key [%s]", err)
 		}
 
+	case *ed25519PrivateKey:
+		kk := k.(*ed25519PrivateKey)
+
+		err = ks.storePrivateKey(hex.EncodeToString(k.SKI()), kk.privKey)
+		if err != nil {
+			return fmt.Errorf("Failed storing Ed25519 private key [%s]", err)
+		}
+
+	case *ed25519PublicKey:
+		kk := k.(*ed25519PublicKey)
+
+		err = ks.storePublicKey(hex.EncodeToString(k.SKI()), kk.pubKey)
+		if err != nil {
+			return fmt.Errorf("Failed storing Ed25519 public key [%s]", err)
+		}
+
 	case *aesPrivateKey:
 		kk := k.(*aesPrivateKey)
 
@@ -244,6 +266,8 @@ func (ks *fileBasedKeyStore) searchKeystoreForSKI(ski []byte) (k bccsp.Key, err
 			k = &ecdsaPrivateKey{key.(*ecdsa.PrivateKey)}
 		case *rsa.PrivateKey:
 			k = &rsaPrivateKey{key.(*rsa.PrivateKey)}
+		case ed25519.PrivateKey:
+			k = &ed25519PrivateKey{key.(ed25519.PrivateKey)}
 		default:
 			continue
 		}
@@ -277,7 +301,7 @@ func (ks *fileBasedKeyStore) getSuffix(alias string) string {
 }
 
 func (ks *fileBasedKeyStore) storePrivateKey(alias string, privateKey interface{}) error {
-	rawKey, err := utils.PrivateKeyToPEM(privateKey, ks.pwd)
+	rawKey, err := ks.privateKeyToPEM(privateKey)
 	if err != nil {
 		logger.Errorf("Failed converting private key to PEM [%s]: [%s]", alias, err)
 		return err
@@ -292,6 +316,13 @@ func (ks *fileBasedKeyStore) storePrivateKey(alias string, privateKey interface{
 	return nil
 }
 
+func (ks *fileBasedKeyStore) privateKeyToPEM(privateKey interface{}) ([]byte, error) {
+	if len(ks.pwd) == 0 {
+		return utils.PrivateKeyToPEM(privateKey, nil)
+	}
+	return utils.PrivateKeyToPKCS8EncryptedPEM(privateKey, ks.pwd)
+}
+
 func (ks *fileBasedKeyStore) storePublicKey(alias string, publicKey interface{}) error {
 	rawKey, err := utils.PublicKeyToPEM(publicKey, ks.pwd)
 	if err != nil {
@@ -421,11 +452,64 @@ func (ks *fileBasedKeyStore) openKeyStore() error {
 		return nil
 	}
 
+	if len(ks.pwd) != 0 && !ks.readOnly {
+		if err := ks.encryptPrivateKeys(); err != nil {
+			return err
+		}
+	}
+
 	logger.Debugf("KeyStore opened at [%s]...done", ks.path)
 
 	return nil
 }
 
+// encryptPrivateKeys rewrites, as PKCS#8 encrypted PEMs, the private keys
+// in the key store which are either unencrypted or encrypted with the
+// legacy PEM encryption. Files which cannot be parsed as private keys
+// with the key store password are left untouched.
+func (ks *fileBasedKeyStore) encryptPrivateKeys() error {
+	files, err := ioutil.ReadDir(ks.path)
+	if err != nil {
+		return fmt.Errorf("Failed listing the private keys of the key store [%s]: [%s]", ks.path, err)
+	}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(ks.path, f.Name())
+		raw, err := ioutil.ReadFile(path)
+		if err != nil || utils.IsPKCS8EncryptedPEM(raw) {
+			continue
+		}
+
+		key, err := utils.PEMtoPrivateKey(raw, ks.pwd)
+		if err != nil {
+			continue
+		}
+
+		encrypted, err := ks.privateKeyToPEM(key)
+		if err != nil {
+			logger.Warningf("Failed encrypting private key [%s]: [%s]", path, err)
+			continue
+		}
+
+		tmpPath := path + ".tmp"
+		err = ioutil.WriteFile(tmpPath, encrypted, f.Mode())
+		if err == nil {
+			err = os.Rename(tmpPath, path)
+		}
+		if err != nil {
+			logger.Warningf("Failed storing encrypted private key [%s]: [%s]", path, err)
+			os.Remove(tmpPath)
+			continue
+		}
+
+		logger.Infof("Private key [%s] is now encrypted", path)
+	}
+	return nil
+}
+
 func (ks *fileBasedKeyStore) getPathForAlias(alias, suffix string) string {
 	return filepath.Join(ks.path, alias+"_"+suffix)
 }
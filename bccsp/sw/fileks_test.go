@@ -16,10 +16,18 @@ limitations under the License.
 package sw
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestInvalidStoreKey(t *testing.T) {
@@ -64,3 +72,87 @@ func TestInvalidStoreKey(t *testing.T) {
 		t.Fatal("Error should be different from nil in this case")
 	}
 }
+
+func TestEncryptedStoreKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bccspks")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ks, err := NewFileBasedKeyStore([]byte("passphrase"), dir, false)
+	assert.NoError(t, err)
+
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	k := &ecdsaPrivateKey{privKey}
+	assert.NoError(t, ks.StoreKey(k))
+
+	raw, err := ioutil.ReadFile(filepath.Join(dir, hex.EncodeToString(k.SKI())+"_sk"))
+	assert.NoError(t, err)
+	assert.True(t, utils.IsPKCS8EncryptedPEM(raw), "private key must be encrypted at rest")
+
+	k2, err := ks.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.Equal(t, k, k2)
+
+	// Without the passphrase the key cannot be loaded
+	ks, err = NewFileBasedKeyStore(nil, dir, true)
+	assert.NoError(t, err)
+	_, err = ks.GetKey(k.SKI())
+	assert.Error(t, err)
+}
+
+func TestPlaintextKeysMigration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bccspks")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ks, err := NewFileBasedKeyStore(nil, dir, false)
+	assert.NoError(t, err)
+
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	k := &ecdsaPrivateKey{privKey}
+	assert.NoError(t, ks.StoreKey(k))
+
+	// A key which does not follow the naming convention of the key store
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	otherPEM, err := utils.PrivateKeyToPEM(otherKey, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "priv_sk"), otherPEM, 0600))
+
+	skPath := filepath.Join(dir, hex.EncodeToString(k.SKI())+"_sk")
+	raw, err := ioutil.ReadFile(skPath)
+	assert.NoError(t, err)
+	assert.False(t, utils.IsPKCS8EncryptedPEM(raw))
+
+	// A read only key store leaves the files untouched
+	_, err = NewFileBasedKeyStore([]byte("passphrase"), dir, true)
+	assert.NoError(t, err)
+	raw, err = ioutil.ReadFile(skPath)
+	assert.NoError(t, err)
+	assert.False(t, utils.IsPKCS8EncryptedPEM(raw))
+
+	ks, err = NewFileBasedKeyStore([]byte("passphrase"), dir, false)
+	assert.NoError(t, err)
+
+	for _, name := range []string{hex.EncodeToString(k.SKI()) + "_sk", "priv_sk"} {
+		raw, err = ioutil.ReadFile(filepath.Join(dir, name))
+		assert.NoError(t, err)
+		assert.True(t, utils.IsPKCS8EncryptedPEM(raw), "%s should have been encrypted", name)
+	}
+
+	k2, err := ks.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.Equal(t, k, k2)
+
+	other := &ecdsaPrivateKey{otherKey}
+	other2, err := ks.GetKey(other.SKI())
+	assert.NoError(t, err)
+	assert.Equal(t, other, other2)
+}
+
+func TestEncryptPrivateKeysMissingKeyStore(t *testing.T) {
+	ks := &fileBasedKeyStore{path: filepath.Join(os.TempDir(), "bccspks-missing"), pwd: []byte("passphrase")}
+	assert.Error(t, ks.encryptPrivateKeys())
+}
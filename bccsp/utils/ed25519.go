@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// crypto/x509 handles Ed25519 keys only from Go 1.13 on, hence Ed25519 keys
+// are encoded and decoded here, following RFC 8410.
+
+// OIDPublicKeyEd25519 identifies Ed25519 keys and signatures (RFC 8410, section 3)
+var OIDPublicKeyEd25519 = asn1.ObjectIdentifier{1, 3, 101, 112}
+
+// RFC 5280, section 4.1
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// ed25519PrivateKeyToPKCS8 marshals an Ed25519 private key to the PKCS#8 DER format.
+// As specified by RFC 8410, section 7, the private key is the 32-byte seed.
+func ed25519PrivateKeyToPKCS8(k ed25519.PrivateKey) ([]byte, error) {
+	if len(k) != ed25519.PrivateKeySize {
+		return nil, errors.New("Invalid ed25519 private key. Wrong length.")
+	}
+	seed, err := asn1.Marshal(k[:32])
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs8{
+		Algo:       pkix.AlgorithmIdentifier{Algorithm: OIDPublicKeyEd25519},
+		PrivateKey: seed,
+	})
+}
+
+// pkcs8ToEd25519PrivateKey unmarshals an Ed25519 private key from the PKCS#8 DER format
+func pkcs8ToEd25519PrivateKey(der []byte) (ed25519.PrivateKey, error) {
+	var privKey pkcs8
+	if rest, err := asn1.Unmarshal(der, &privKey); err != nil {
+		return nil, err
+	} else if len(rest) != 0 {
+		return nil, errors.New("Trailing data after PKCS#8 private key")
+	}
+	if !privKey.Algo.Algorithm.Equal(OIDPublicKeyEd25519) {
+		return nil, fmt.Errorf("Unexpected private key algorithm [%v]", privKey.Algo.Algorithm)
+	}
+	var seed []byte
+	if _, err := asn1.Unmarshal(privKey.PrivateKey, &seed); err != nil {
+		return nil, err
+	}
+	if len(seed) != 32 {
+		return nil, fmt.Errorf("Invalid ed25519 private key seed length [%d]", len(seed))
+	}
+	_, k, err := ed25519.GenerateKey(bytes.NewReader(seed))
+	return k, err
+}
+
+// Ed25519PublicKeyToDER marshals an Ed25519 public key to the PKIX DER format
+func Ed25519PublicKeyToDER(k ed25519.PublicKey) ([]byte, error) {
+	if len(k) != ed25519.PublicKeySize {
+		return nil, errors.New("Invalid ed25519 public key. Wrong length.")
+	}
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: OIDPublicKeyEd25519},
+		PublicKey: asn1.BitString{Bytes: k, BitLength: 8 * len(k)},
+	})
+}
+
+// DERToEd25519PublicKey unmarshals an Ed25519 public key from the PKIX DER format,
+// for instance the RawSubjectPublicKeyInfo of an x509 certificate
+func DERToEd25519PublicKey(der []byte) (ed25519.PublicKey, error) {
+	var pki subjectPublicKeyInfo
+	if rest, err := asn1.Unmarshal(der, &pki); err != nil {
+		return nil, err
+	} else if len(rest) != 0 {
+		return nil, errors.New("Trailing data after PKIX public key")
+	}
+	if !pki.Algorithm.Algorithm.Equal(OIDPublicKeyEd25519) {
+		return nil, fmt.Errorf("Unexpected public key algorithm [%v]", pki.Algorithm.Algorithm)
+	}
+	if len(pki.PublicKey.Bytes) != ed25519.PublicKeySize || pki.PublicKey.BitLength != 8*ed25519.PublicKeySize {
+		return nil, errors.New("Invalid ed25519 public key. Wrong length.")
+	}
+	return ed25519.PublicKey(pki.PublicKey.Bytes), nil
+}
@@ -0,0 +1,268 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// PKCS8EncryptedPEMType is the PEM block type of PKCS#8 encrypted private keys
+const PKCS8EncryptedPEMType = "ENCRYPTED PRIVATE KEY"
+
+// pbkdf2Iterations is the PBKDF2 iteration count used when encrypting
+const pbkdf2Iterations = 100000
+
+// maxPBKDF2Iterations bounds the PBKDF2 iteration count accepted when
+// decrypting, so that a crafted key file can't keep the peer busy for hours
+const maxPBKDF2Iterations = 10000000
+
+var (
+	oidPublicKeyRSA = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidAES128CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// RFC 5208, section 5
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// RFC 5208, section 6
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+// RFC 8018, appendix A.4
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// RFC 8018, appendix A.2
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// PrivateKeyToPKCS8EncryptedPEM converts a private key to a PKCS#8 encrypted PEM,
+// using PBES2 with PBKDF2-HMAC-SHA256 and AES-256-CBC, as described in RFC 8018.
+// The result can be read by standard tools, for instance openssl pkcs8.
+func PrivateKeyToPKCS8EncryptedPEM(privateKey interface{}, pwd []byte) ([]byte, error) {
+	if len(pwd) == 0 {
+		return nil, errors.New("Invalid password. It must be different from nil.")
+	}
+
+	der, err := privateKeyToPKCS8(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("Failed generating salt [%s]", err)
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("Failed generating IV [%s]", err)
+	}
+
+	key := pbkdf2.Key(pwd, salt, pbkdf2Iterations, 32, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	padLen := aes.BlockSize - len(der)%aes.BlockSize
+	ciphertext := append(der, bytes.Repeat([]byte{byte(padLen)}, padLen)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, ciphertext)
+
+	kdfParams, err := asn1.Marshal(pbkdf2Params{
+		Salt:           salt,
+		IterationCount: pbkdf2Iterations,
+		PRF: pkix.AlgorithmIdentifier{
+			Algorithm:  oidHMACWithSHA256,
+			Parameters: asn1.RawValue{Tag: asn1.TagNull},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ivParam, err := asn1.Marshal(iv)
+	if err != nil {
+		return nil, err
+	}
+	params, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPBKDF2,
+			Parameters: asn1.RawValue{FullBytes: kdfParams},
+		},
+		EncryptionScheme: pkix.AlgorithmIdentifier{
+			Algorithm:  oidAES256CBC,
+			Parameters: asn1.RawValue{FullBytes: ivParam},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := asn1.Marshal(encryptedPrivateKeyInfo{
+		EncryptionAlgorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPBES2,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		EncryptedData: ciphertext,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: PKCS8EncryptedPEMType, Bytes: raw}), nil
+}
+
+// IsPKCS8EncryptedPEM returns true if raw is a PEM encoded PKCS#8
+// encrypted private key
+func IsPKCS8EncryptedPEM(raw []byte) bool {
+	block, _ := pem.Decode(raw)
+	return block != nil && block.Type == PKCS8EncryptedPEMType
+}
+
+// decryptPKCS8 decrypts the DER of a PKCS#8 EncryptedPrivateKeyInfo
+// and returns the DER of the enclosed PrivateKeyInfo
+func decryptPKCS8(der []byte, pwd []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("Failed unmarshaling PKCS#8 encrypted key [%s]", err)
+	}
+	if !info.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("Unsupported PKCS#8 encryption scheme [%s]", info.EncryptionAlgorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("Failed unmarshaling PBES2 parameters [%s]", err)
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("Unsupported key derivation function [%s]", params.KeyDerivationFunc.Algorithm)
+	}
+
+	var kdfParams pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdfParams); err != nil {
+		return nil, fmt.Errorf("Failed unmarshaling PBKDF2 parameters [%s]", err)
+	}
+	if len(kdfParams.Salt) == 0 {
+		return nil, errors.New("Invalid PBKDF2 salt. It must be different from nil.")
+	}
+	if kdfParams.IterationCount <= 0 || kdfParams.IterationCount > maxPBKDF2Iterations {
+		return nil, fmt.Errorf("Invalid PBKDF2 iteration count [%d]. It must be between 1 and %d", kdfParams.IterationCount, maxPBKDF2Iterations)
+	}
+
+	var prf func() hash.Hash
+	switch {
+	case len(kdfParams.PRF.Algorithm) == 0, kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA1):
+		prf = sha1.New
+	case kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA256):
+		prf = sha256.New
+	default:
+		return nil, fmt.Errorf("Unsupported PBKDF2 PRF [%s]", kdfParams.PRF.Algorithm)
+	}
+
+	var keyLen int
+	switch {
+	case params.EncryptionScheme.Algorithm.Equal(oidAES128CBC):
+		keyLen = 16
+	case params.EncryptionScheme.Algorithm.Equal(oidAES256CBC):
+		keyLen = 32
+	default:
+		return nil, fmt.Errorf("Unsupported encryption scheme [%s]", params.EncryptionScheme.Algorithm)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("Failed unmarshaling IV [%s]", err)
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, errors.New("Invalid IV length")
+	}
+
+	ciphertext := info.EncryptedData
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, errors.New("Invalid ciphertext length")
+	}
+
+	block, err := aes.NewCipher(pbkdf2.Key(pwd, kdfParams.Salt, kdfParams.IterationCount, keyLen, prf))
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+
+	// A wrong password is detected by an invalid padding
+	padLen := int(plaintext[len(plaintext)-1])
+	if padLen == 0 || padLen > aes.BlockSize {
+		return nil, errors.New("Failed decrypting PKCS#8 key. Wrong password?")
+	}
+	for _, b := range plaintext[len(plaintext)-padLen:] {
+		if int(b) != padLen {
+			return nil, errors.New("Failed decrypting PKCS#8 key. Wrong password?")
+		}
+	}
+
+	return plaintext[:len(plaintext)-padLen], nil
+}
+
+// privateKeyToPKCS8 marshals an ECDSA, RSA or Ed25519 private key to the PKCS#8 DER format
+func privateKeyToPKCS8(privateKey interface{}) ([]byte, error) {
+	switch k := privateKey.(type) {
+	case *ecdsa.PrivateKey:
+		if k == nil {
+			return nil, errors.New("Invalid ecdsa private key. It must be different from nil.")
+		}
+		return ecPrivateKeyToPKCS8(k)
+	case *rsa.PrivateKey:
+		if k == nil {
+			return nil, errors.New("Invalid rsa private key. It must be different from nil.")
+		}
+		return asn1.Marshal(pkcs8{
+			Algo: pkix.AlgorithmIdentifier{
+				Algorithm:  oidPublicKeyRSA,
+				Parameters: asn1.RawValue{Tag: asn1.TagNull},
+			},
+			PrivateKey: x509.MarshalPKCS1PrivateKey(k),
+		})
+	case ed25519.PrivateKey:
+		return ed25519PrivateKeyToPKCS8(k)
+	default:
+		return nil, errors.New("Invalid key type. It must be *ecdsa.PrivateKey, *rsa.PrivateKey or ed25519.PrivateKey")
+	}
+}
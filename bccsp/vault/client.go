@@ -0,0 +1,212 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vault
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const requestTimeout = 10 * time.Second
+
+// errNotFound is returned when Vault answers a read with 404
+var errNotFound = errors.New("not found")
+
+// client is a minimal client of the Vault HTTP API, covering the
+// transit secrets engine (key creation, public key retrieval, signing)
+// and the key/value secrets engine (read and write of a secret).
+type client struct {
+	address     string
+	token       string
+	transitPath string
+	httpClient  *http.Client
+}
+
+func newClient(address, token, transitPath, tlsCACert string) (*client, error) {
+	if address == "" {
+		return nil, errors.New("Invalid Vault address. It must not be empty.")
+	}
+
+	transport := &http.Transport{}
+	if tlsCACert != "" {
+		caPEM, err := ioutil.ReadFile(tlsCACert)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed reading Vault TLS CA certificate [%s]", tlsCACert)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, errors.Errorf("No valid certificates found in [%s]", tlsCACert)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	return &client{
+		address:     strings.TrimRight(address, "/"),
+		token:       token,
+		transitPath: strings.Trim(transitPath, "/"),
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   requestTimeout,
+		},
+	}, nil
+}
+
+// do performs a request against the Vault API at path, marshaling in as the
+// JSON body (if not nil) and unmarshaling the JSON response into out (if not nil)
+func (c *client) do(method, path string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return errors.Wrap(err, "Failed marshaling request")
+		}
+	}
+
+	req, err := http.NewRequest(method, c.address+"/v1/"+path, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "Failed creating request")
+	}
+	req.Header.Set("X-Vault-Token", c.token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "Failed contacting Vault at [%s]", c.address)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "Failed reading Vault response")
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		vaultErr := &struct {
+			Errors []string `json:"errors"`
+		}{}
+		json.Unmarshal(respBody, vaultErr)
+		return errors.Errorf("Vault returned status %d for %s %s: %s", resp.StatusCode, method, path, strings.Join(vaultErr.Errors, ", "))
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return errors.Wrap(err, "Failed unmarshaling Vault response")
+	}
+	return nil
+}
+
+// createKey creates a new named key of type keyType in the transit engine
+func (c *client) createKey(name, keyType string) error {
+	return c.do("POST", fmt.Sprintf("%s/keys/%s", c.transitPath, name), map[string]interface{}{
+		"type": keyType,
+	}, nil)
+}
+
+// publicKey returns the public key of the latest version of the named transit key
+func (c *client) publicKey(name string) (*ecdsa.PublicKey, error) {
+	resp := &struct {
+		Data struct {
+			Type          string `json:"type"`
+			LatestVersion int    `json:"latest_version"`
+			Keys          map[string]struct {
+				PublicKey string `json:"public_key"`
+			} `json:"keys"`
+		} `json:"data"`
+	}{}
+
+	if err := c.do("GET", fmt.Sprintf("%s/keys/%s", c.transitPath, name), nil, resp); err != nil {
+		return nil, err
+	}
+
+	version, ok := resp.Data.Keys[strconv.Itoa(resp.Data.LatestVersion)]
+	if !ok {
+		return nil, errors.Errorf("Vault key [%s] has no version %d", name, resp.Data.LatestVersion)
+	}
+
+	block, _ := pem.Decode([]byte(version.PublicKey))
+	if block == nil {
+		return nil, errors.Errorf("Failed decoding PEM public key of Vault key [%s]", name)
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed parsing public key of Vault key [%s]", name)
+	}
+
+	ecdsaPub, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("Vault key [%s] is not an ECDSA key", name)
+	}
+	return ecdsaPub, nil
+}
+
+// sign asks the transit engine to sign the already hashed digest with the named key
+// and returns the ASN.1 encoded signature
+func (c *client) sign(name string, digest []byte) ([]byte, error) {
+	resp := &struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}{}
+
+	err := c.do("POST", fmt.Sprintf("%s/sign/%s", c.transitPath, name), map[string]interface{}{
+		"input":                base64.StdEncoding.EncodeToString(digest),
+		"prehashed":            true,
+		"marshaling_algorithm": "asn1",
+	}, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Signatures are of the form vault:v<version>:<base64 signature>
+	parts := strings.SplitN(resp.Data.Signature, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, errors.Errorf("Unexpected signature format from Vault [%s]", resp.Data.Signature)
+	}
+
+	return base64.StdEncoding.DecodeString(parts[2])
+}
+
+// readSecret reads the key/value secret at path
+func (c *client) readSecret(path string) (map[string]string, error) {
+	resp := &struct {
+		Data map[string]string `json:"data"`
+	}{}
+
+	if err := c.do("GET", path, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// writeSecret writes data as the key/value secret at path
+func (c *client) writeSecret(path string, data map[string]string) error {
+	return c.do("PUT", path, data, nil)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vault
+
+import (
+	"crypto/elliptic"
+	"fmt"
+)
+
+const (
+	// DefaultTransitPath is the mount path of the transit secrets engine
+	// used when none is configured
+	DefaultTransitPath = "transit"
+
+	// DefaultKeyStorePath is the path, in the key/value secrets engine,
+	// under which the SKI to transit key name mappings are stored when
+	// none is configured
+	DefaultKeyStorePath = "secret/fabric/keys"
+)
+
+type config struct {
+	ellipticCurve elliptic.Curve
+}
+
+func (conf *config) setSecurityLevel(securityLevel int, hashFamily string) (err error) {
+	if hashFamily != "SHA2" && hashFamily != "SHA3" {
+		return fmt.Errorf("Hash Family not supported [%s]", hashFamily)
+	}
+
+	switch securityLevel {
+	case 256:
+		conf.ellipticCurve = elliptic.P256()
+	case 384:
+		conf.ellipticCurve = elliptic.P384()
+	default:
+		err = fmt.Errorf("Security level not supported [%d]", securityLevel)
+	}
+	return
+}
+
+// VaultOpts contains options for the VaultFactory
+type VaultOpts struct {
+	// Default algorithms when not specified (Deprecated?)
+	SecLevel   int    `mapstructure:"security" json:"security"`
+	HashFamily string `mapstructure:"hash" json:"hash"`
+
+	// Address is the base URL of the Vault server, for instance
+	// https://vault.example.com:8200. If empty, VAULT_ADDR is used.
+	Address string `mapstructure:"address" json:"address"`
+	// Token is the Vault token used to authenticate requests.
+	// If empty, VAULT_TOKEN is used.
+	Token string `mapstructure:"token" json:"token"`
+	// TransitPath is the mount path of the transit secrets engine
+	// which holds the private keys and performs the signing.
+	TransitPath string `mapstructure:"transitpath,omitempty" json:"transitpath,omitempty"`
+	// KeyStorePath is the key/value secrets engine path under which
+	// the mapping from SKI to transit key name is kept.
+	KeyStorePath string `mapstructure:"keystorepath,omitempty" json:"keystorepath,omitempty"`
+	// TLSCACert is an optional PEM file used to verify the Vault
+	// server certificate when Address is an https URL.
+	TLSCACert string `mapstructure:"tlscacert,omitempty" json:"tlscacert,omitempty"`
+}
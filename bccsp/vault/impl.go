@@ -0,0 +1,219 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vault
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("bccsp_vault")
+
+// New returns a new instance of a BCCSP whose ECDSA private keys are
+// generated, stored and used for signing by a Vault-compatible transit
+// secrets engine. Every other operation is delegated to a software-based
+// BCCSP set at the passed security level, hash family and KeyStore.
+func New(opts VaultOpts, keyStore bccsp.KeyStore) (bccsp.BCCSP, error) {
+	// Init config
+	conf := &config{}
+	err := conf.setSecurityLevel(opts.SecLevel, opts.HashFamily)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed initializing configuration")
+	}
+
+	// Check KeyStore
+	if keyStore == nil {
+		return nil, errors.New("Invalid bccsp.KeyStore instance. It must be different from nil.")
+	}
+
+	swCSP, err := sw.New(opts.SecLevel, opts.HashFamily, keyStore)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed initializing fallback SW BCCSP")
+	}
+
+	address := opts.Address
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	token := opts.Token
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	transitPath := opts.TransitPath
+	if transitPath == "" {
+		transitPath = DefaultTransitPath
+	}
+	keyStorePath := opts.KeyStorePath
+	if keyStorePath == "" {
+		keyStorePath = DefaultKeyStorePath
+	}
+
+	c, err := newClient(address, token, transitPath, opts.TLSCACert)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed initializing Vault client")
+	}
+
+	csp := &impl{BCCSP: swCSP, conf: conf, client: c}
+	csp.ks = newKeyStore(c, keyStorePath, csp.importPublicKey)
+	return csp, nil
+}
+
+type impl struct {
+	bccsp.BCCSP
+
+	conf   *config
+	client *client
+	ks     *keyStore
+}
+
+// KeyGen generates a key using opts.
+// Non-ephemeral ECDSA keys are generated by Vault, everything
+// else is generated in software.
+func (csp *impl) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
+	// Validate arguments
+	if opts == nil {
+		return nil, errors.New("Invalid Opts parameter. It must not be nil.")
+	}
+
+	if opts.Ephemeral() {
+		return csp.BCCSP.KeyGen(opts)
+	}
+
+	switch opts.(type) {
+	case *bccsp.ECDSAKeyGenOpts:
+		k, err = csp.generateECKey(csp.conf.ellipticCurve)
+	case *bccsp.ECDSAP256KeyGenOpts:
+		k, err = csp.generateECKey(elliptic.P256())
+	case *bccsp.ECDSAP384KeyGenOpts:
+		k, err = csp.generateECKey(elliptic.P384())
+	default:
+		return csp.BCCSP.KeyGen(opts)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed generating ECDSA key in Vault")
+	}
+
+	return k, nil
+}
+
+// KeyDeriv derives a key from k using opts.
+// Keys held by Vault cannot be derived.
+func (csp *impl) KeyDeriv(k bccsp.Key, opts bccsp.KeyDerivOpts) (dk bccsp.Key, err error) {
+	if _, ok := k.(*ecdsaPrivateKey); ok {
+		return nil, errors.New("Key derivation is not supported for keys held by Vault")
+	}
+	return csp.BCCSP.KeyDeriv(k, opts)
+}
+
+// GetKey returns the key this CSP associates to
+// the Subject Key Identifier ski.
+func (csp *impl) GetKey(ski []byte) (k bccsp.Key, err error) {
+	k, err = csp.ks.GetKey(ski)
+	if err == nil {
+		return k, nil
+	}
+	if errors.Cause(err) != errNotFound {
+		return nil, errors.Wrapf(err, "Failed looking up key [%x] in Vault", ski)
+	}
+	// Only keys unknown to Vault are looked up in the software key store
+	return csp.BCCSP.GetKey(ski)
+}
+
+// Sign signs digest using key k.
+// The opts argument should be appropriate for the primitive used.
+//
+// Note that when a signature of a hash of a larger message is needed,
+// the caller is responsible for hashing the larger message and passing
+// the hash (as digest).
+func (csp *impl) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) (signature []byte, err error) {
+	// Validate arguments
+	if k == nil {
+		return nil, errors.New("Invalid Key. It must not be nil.")
+	}
+	if len(digest) == 0 {
+		return nil, errors.New("Invalid digest. Cannot be empty.")
+	}
+
+	vk, ok := k.(*ecdsaPrivateKey)
+	if !ok {
+		return csp.BCCSP.Sign(k, digest, opts)
+	}
+
+	signature, err = csp.client.sign(vk.name, digest)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed signing with Vault")
+	}
+
+	// Vault does not produce low-S signatures, which are required by fabric
+	return sw.SignatureToLowS(vk.pub, signature)
+}
+
+// Verify verifies signature against key k and digest
+func (csp *impl) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
+	if vk, ok := k.(*ecdsaPrivateKey); ok {
+		return csp.BCCSP.Verify(vk.pubKey, signature, digest, opts)
+	}
+	return csp.BCCSP.Verify(k, signature, digest, opts)
+}
+
+func (csp *impl) generateECKey(curve elliptic.Curve) (*ecdsaPrivateKey, error) {
+	var keyType string
+	switch curve {
+	case elliptic.P256():
+		keyType = "ecdsa-p256"
+	case elliptic.P384():
+		keyType = "ecdsa-p384"
+	default:
+		return nil, errors.Errorf("Curve not supported by Vault [%s]", curve.Params().Name)
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "Failed generating key name")
+	}
+	name := "fabric-" + hex.EncodeToString(nonce)
+
+	if err := csp.client.createKey(name, keyType); err != nil {
+		return nil, err
+	}
+
+	pub, err := csp.client.publicKey(name)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &ecdsaPrivateKey{name: name, pub: pub}
+	if err := csp.importPublicKey(k); err != nil {
+		return nil, err
+	}
+	k.ski = k.pubKey.SKI()
+
+	if err := csp.ks.StoreKey(k); err != nil {
+		return nil, errors.WithMessage(err, "Failed storing key mapping in Vault")
+	}
+
+	logger.Debugf("Generated Vault key [%s] with SKI [%x]", name, k.ski)
+	return k, nil
+}
+
+// importPublicKey sets the software public key of k, used for
+// verification and for computing the SKI
+func (csp *impl) importPublicKey(k *ecdsaPrivateKey) error {
+	pubKey, err := csp.BCCSP.KeyImport(k.pub, &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: true})
+	if err != nil {
+		return errors.Wrapf(err, "Failed importing public key of Vault key [%s]", k.name)
+	}
+	k.pubKey = pubKey
+	return nil
+}
@@ -0,0 +1,275 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vault
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const testToken = "s.testtoken"
+
+// stubVault implements the subset of the Vault transit and
+// key/value APIs used by this package
+type stubVault struct {
+	sync.Mutex
+	keys    map[string]*ecdsa.PrivateKey
+	secrets map[string]map[string]string
+	signs   int
+}
+
+func newStubVault() *stubVault {
+	return &stubVault{
+		keys:    map[string]*ecdsa.PrivateKey{},
+		secrets: map[string]map[string]string{},
+	}
+}
+
+func (s *stubVault) reply(w http.ResponseWriter, status int, body interface{}) {
+	w.WriteHeader(status)
+	if body != nil {
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+func (s *stubVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	if r.Header.Get("X-Vault-Token") != testToken {
+		s.reply(w, http.StatusForbidden, map[string][]string{"errors": {"permission denied"}})
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	switch {
+	case strings.HasPrefix(path, "transit/keys/") && r.Method == "POST":
+		req := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&req)
+		curve := elliptic.P256()
+		if req["type"] == "ecdsa-p384" {
+			curve = elliptic.P384()
+		}
+		k, _ := ecdsa.GenerateKey(curve, rand.Reader)
+		s.keys[strings.TrimPrefix(path, "transit/keys/")] = k
+		s.reply(w, http.StatusNoContent, nil)
+
+	case strings.HasPrefix(path, "transit/keys/") && r.Method == "GET":
+		k, ok := s.keys[strings.TrimPrefix(path, "transit/keys/")]
+		if !ok {
+			s.reply(w, http.StatusNotFound, map[string][]string{"errors": {}})
+			return
+		}
+		der, _ := x509.MarshalPKIXPublicKey(&k.PublicKey)
+		s.reply(w, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"latest_version": 1,
+				"keys": map[string]interface{}{
+					"1": map[string]string{
+						"public_key": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+					},
+				},
+			},
+		})
+
+	case strings.HasPrefix(path, "transit/sign/"):
+		k, ok := s.keys[strings.TrimPrefix(path, "transit/sign/")]
+		if !ok {
+			s.reply(w, http.StatusBadRequest, map[string][]string{"errors": {"encryption key not found"}})
+			return
+		}
+		req := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&req)
+		digest, _ := base64.StdEncoding.DecodeString(req["input"].(string))
+		r, sig, _ := ecdsa.Sign(rand.Reader, k, digest)
+		der, _ := asn1.Marshal(struct{ R, S *big.Int }{r, sig})
+		s.signs++
+		s.reply(w, http.StatusOK, map[string]interface{}{
+			"data": map[string]string{"signature": "vault:v1:" + base64.StdEncoding.EncodeToString(der)},
+		})
+
+	case r.Method == "PUT":
+		data := map[string]string{}
+		json.NewDecoder(r.Body).Decode(&data)
+		s.secrets[path] = data
+		s.reply(w, http.StatusNoContent, nil)
+
+	case r.Method == "GET":
+		data, ok := s.secrets[path]
+		if !ok {
+			s.reply(w, http.StatusNotFound, map[string][]string{"errors": {}})
+			return
+		}
+		s.reply(w, http.StatusOK, map[string]interface{}{"data": data})
+
+	default:
+		s.reply(w, http.StatusBadRequest, map[string][]string{"errors": {"unsupported"}})
+	}
+}
+
+func newTestCSP(t *testing.T) (bccsp.BCCSP, *stubVault, func()) {
+	stub := newStubVault()
+	server := httptest.NewServer(stub)
+
+	csp, err := New(VaultOpts{
+		SecLevel:   256,
+		HashFamily: "SHA2",
+		Address:    server.URL,
+		Token:      testToken,
+	}, sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+
+	return csp, stub, server.Close
+}
+
+func TestNewInvalidOpts(t *testing.T) {
+	_, err := New(VaultOpts{SecLevel: 256, HashFamily: "SHA2", Address: "http://localhost:8200"}, nil)
+	assert.Error(t, err)
+
+	_, err = New(VaultOpts{SecLevel: 128, HashFamily: "SHA2", Address: "http://localhost:8200"}, sw.NewDummyKeyStore())
+	assert.Error(t, err)
+
+	_, err = New(VaultOpts{SecLevel: 256, HashFamily: "SHA8", Address: "http://localhost:8200"}, sw.NewDummyKeyStore())
+	assert.Error(t, err)
+
+	_, err = New(VaultOpts{SecLevel: 256, HashFamily: "SHA2", TLSCACert: "/no/such/file"}, sw.NewDummyKeyStore())
+	assert.Error(t, err)
+}
+
+func TestKeyGenSignVerify(t *testing.T) {
+	csp, stub, cleanup := newTestCSP(t)
+	defer cleanup()
+
+	for _, opts := range []bccsp.KeyGenOpts{
+		&bccsp.ECDSAKeyGenOpts{},
+		&bccsp.ECDSAP256KeyGenOpts{},
+		&bccsp.ECDSAP384KeyGenOpts{},
+	} {
+		k, err := csp.KeyGen(opts)
+		assert.NoError(t, err)
+		assert.True(t, k.Private())
+		assert.False(t, k.Symmetric())
+		_, err = k.Bytes()
+		assert.Error(t, err, "private key material must not be exportable")
+
+		pk, err := k.PublicKey()
+		assert.NoError(t, err)
+		assert.Equal(t, k.SKI(), pk.SKI())
+
+		digest := sha256.Sum256([]byte("hello world"))
+		signs := stub.signs
+		sig, err := csp.Sign(k, digest[:], nil)
+		assert.NoError(t, err)
+		assert.Equal(t, signs+1, stub.signs, "signing must happen in Vault")
+
+		valid, err := csp.Verify(k, sig, digest[:], nil)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+
+		valid, err = csp.Verify(pk, sig, digest[:], nil)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+
+		_, err = csp.KeyDeriv(k, &bccsp.ECDSAReRandKeyOpts{Temporary: true, Expansion: []byte{1}})
+		assert.Error(t, err)
+	}
+}
+
+func TestGetKey(t *testing.T) {
+	csp, stub, cleanup := newTestCSP(t)
+	defer cleanup()
+
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	assert.NoError(t, err)
+
+	k2, err := csp.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.True(t, k2.Private())
+	assert.Equal(t, k.SKI(), k2.SKI())
+
+	digest := sha256.Sum256([]byte("hello world"))
+	sig, err := csp.Sign(k2, digest[:], nil)
+	assert.NoError(t, err)
+	valid, err := csp.Verify(k, sig, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = csp.GetKey([]byte("unknown ski"))
+	assert.Error(t, err)
+
+	// A transit key missing from Vault is reported as not found, even though the error is wrapped
+	stub.Lock()
+	stub.keys = map[string]*ecdsa.PrivateKey{}
+	stub.Unlock()
+	_, err = csp.(*impl).ks.GetKey(k.SKI())
+	assert.Error(t, err)
+	assert.Equal(t, errNotFound, errors.Cause(err))
+}
+
+func TestEphemeralKeysStayInSoftware(t *testing.T) {
+	csp, stub, cleanup := newTestCSP(t)
+	defer cleanup()
+
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Len(t, stub.keys, 0)
+
+	digest := sha256.Sum256([]byte("hello world"))
+	sig, err := csp.Sign(k, digest[:], nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, stub.signs)
+
+	valid, err := csp.Verify(k, sig, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestVaultErrors(t *testing.T) {
+	stub := newStubVault()
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	csp, err := New(VaultOpts{
+		SecLevel:   256,
+		HashFamily: "SHA2",
+		Address:    server.URL,
+		Token:      "wrong token",
+	}, sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+
+	_, err = csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "permission denied")
+
+	k := &ecdsaPrivateKey{name: "missing"}
+	_, err = csp.Sign(k, []byte{1, 2, 3}, nil)
+	assert.Error(t, err)
+
+	// Errors other than not found aren't hidden by a lookup in the software key store
+	_, err = csp.GetKey([]byte("some ski"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed looking up key")
+	assert.Contains(t, err.Error(), "permission denied")
+}
@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vault
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/pkg/errors"
+)
+
+const transitKeyNameField = "transit_key"
+
+// keyStore is a bccsp.KeyStore which keeps, in the Vault key/value
+// secrets engine, the mapping from a key's SKI to the name of the
+// transit key holding the private key material.
+type keyStore struct {
+	client *client
+	path   string
+
+	// importPub turns the public key of a transit key into a bccsp.Key
+	importPub func(k *ecdsaPrivateKey) error
+}
+
+func newKeyStore(c *client, path string, importPub func(k *ecdsaPrivateKey) error) *keyStore {
+	return &keyStore{
+		client:    c,
+		path:      strings.Trim(path, "/"),
+		importPub: importPub,
+	}
+}
+
+// ReadOnly returns true if this KeyStore is read only, false otherwise.
+// If ReadOnly is true then StoreKey will fail.
+func (ks *keyStore) ReadOnly() bool {
+	return false
+}
+
+// GetKey returns a key object whose SKI is the one passed.
+func (ks *keyStore) GetKey(ski []byte) (bccsp.Key, error) {
+	if len(ski) == 0 {
+		return nil, errors.New("Invalid SKI. Cannot be of zero length.")
+	}
+
+	data, err := ks.client.readSecret(ks.secretPath(ski))
+	if err != nil {
+		return nil, err
+	}
+
+	name := data[transitKeyNameField]
+	if name == "" {
+		return nil, errors.Errorf("Vault secret for SKI [%x] does not reference a transit key", ski)
+	}
+
+	pub, err := ks.client.publicKey(name)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed retrieving public key from Vault")
+	}
+
+	k := &ecdsaPrivateKey{ski: ski, name: name, pub: pub}
+	if err := ks.importPub(k); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// StoreKey stores the key k in this KeyStore.
+// Only keys whose private part is held by the Vault transit engine
+// can be stored.
+func (ks *keyStore) StoreKey(k bccsp.Key) error {
+	vk, ok := k.(*ecdsaPrivateKey)
+	if !ok {
+		return errors.Errorf("Key type not supported by the Vault key store [%T]", k)
+	}
+
+	return ks.client.writeSecret(ks.secretPath(vk.ski), map[string]string{
+		transitKeyNameField: vk.name,
+	})
+}
+
+func (ks *keyStore) secretPath(ski []byte) string {
+	return ks.path + "/" + hex.EncodeToString(ski)
+}
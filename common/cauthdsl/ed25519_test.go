@@ -0,0 +1,159 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cauthdsl
+
+import (
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ed25519"
+)
+
+// RFC 5280, section 4.1
+type ed25519TBSCertificate struct {
+	Version            int `asn1:"explicit,tag:0"`
+	SerialNumber       *big.Int
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Issuer             asn1.RawValue
+	Validity           struct{ NotBefore, NotAfter time.Time }
+	Subject            asn1.RawValue
+	PublicKey          asn1.RawValue
+	Extensions         []pkix.Extension `asn1:"optional,explicit,tag:3"`
+}
+
+type ed25519Certificate struct {
+	TBSCertificate     asn1.RawValue
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+// newEd25519MSP returns an MSP whose CA and signing identity use Ed25519 keys
+func newEd25519MSP(t *testing.T, mspID string) msp.MSP {
+	// newCert returns the PEM of a certificate assembled by hand, since
+	// crypto/x509 cannot create Ed25519 certificates before Go 1.13
+	newCert := func(subject, issuer string, isCA bool, pub ed25519.PublicKey, parentKey ed25519.PrivateKey) []byte {
+		name := func(cn string) asn1.RawValue {
+			raw, err := asn1.Marshal(pkix.Name{CommonName: cn}.ToRDNSequence())
+			assert.NoError(t, err)
+			return asn1.RawValue{FullBytes: raw}
+		}
+		spki, err := utils.PublicKeyToDER(pub)
+		assert.NoError(t, err)
+
+		tbs := ed25519TBSCertificate{
+			Version:            2,
+			SerialNumber:       big.NewInt(time.Now().UnixNano()),
+			SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: utils.OIDPublicKeyEd25519},
+			Issuer:             name(issuer),
+			Subject:            name(subject),
+			PublicKey:          asn1.RawValue{FullBytes: spki},
+		}
+		now := time.Now().UTC().Truncate(time.Second)
+		tbs.Validity.NotBefore, tbs.Validity.NotAfter = now.Add(-time.Hour), now.Add(time.Hour)
+		if isCA {
+			basicConstraints, err := asn1.Marshal(struct{ IsCA bool }{true})
+			assert.NoError(t, err)
+			ski, err := asn1.Marshal([]byte(subject))
+			assert.NoError(t, err)
+			tbs.Extensions = []pkix.Extension{
+				{Id: asn1.ObjectIdentifier{2, 5, 29, 19}, Critical: true, Value: basicConstraints},
+				{Id: asn1.ObjectIdentifier{2, 5, 29, 14}, Value: ski},
+			}
+		}
+		tbsRaw, err := asn1.Marshal(tbs)
+		assert.NoError(t, err)
+
+		signature := ed25519.Sign(parentKey, tbsRaw)
+		raw, err := asn1.Marshal(ed25519Certificate{
+			TBSCertificate:     asn1.RawValue{FullBytes: tbsRaw},
+			SignatureAlgorithm: tbs.SignatureAlgorithm,
+			SignatureValue:     asn1.BitString{Bytes: signature, BitLength: 8 * len(signature)},
+		})
+		assert.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw})
+	}
+
+	caPub, caKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	caCert := newCert(mspID+"-ca", mspID+"-ca", true, caPub, caKey)
+
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	cert := newCert(mspID+"-signer", mspID+"-ca", false, pub, caKey)
+	keyPEM, err := utils.PrivateKeyToPEM(key, nil)
+	assert.NoError(t, err)
+
+	conf, err := proto.Marshal(&mb.FabricMSPConfig{
+		Name:      mspID,
+		RootCerts: [][]byte{caCert},
+		Admins:    [][]byte{cert},
+		SigningIdentity: &mb.SigningIdentityInfo{
+			PublicSigner:  cert,
+			PrivateSigner: &mb.KeyInfo{KeyMaterial: keyPEM},
+		},
+		CryptoConfig: &mb.FabricCryptoConfig{
+			SignatureHashFamily:            bccsp.SHA2,
+			IdentityIdentifierHashFunction: bccsp.SHA256,
+		},
+	})
+	assert.NoError(t, err)
+
+	m, err := msp.NewBccspMsp()
+	assert.NoError(t, err)
+	assert.NoError(t, m.Setup(&mb.MSPConfig{Type: int32(msp.FABRIC), Config: conf}))
+	return m
+}
+
+func TestEd25519SignedByMspMember(t *testing.T) {
+	org1 := newEd25519MSP(t, "Org1MSP")
+	org2 := newEd25519MSP(t, "Org2MSP")
+
+	mgr := msp.NewMSPManager()
+	assert.NoError(t, mgr.Setup([]msp.MSP{org1, org2}))
+
+	signedData := func(m msp.MSP, data []byte) *cb.SignedData {
+		id, err := m.GetDefaultSigningIdentity()
+		assert.NoError(t, err)
+		sid, err := id.Serialize()
+		assert.NoError(t, err)
+		sig, err := id.Sign(data)
+		assert.NoError(t, err)
+		return &cb.SignedData{Data: data, Identity: sid, Signature: sig}
+	}
+
+	policy := SignedByMspMember("Org1MSP")
+	spe, err := compile(policy.Rule, policy.Identities, mgr)
+	assert.NoError(t, err)
+
+	data := []byte("payload")
+	assert.True(t, spe([]*cb.SignedData{signedData(org1, data)}, []bool{false}))
+	assert.False(t, spe([]*cb.SignedData{signedData(org2, data)}, []bool{false}), "Org2MSP is not authorized by the policy")
+
+	tampered := signedData(org1, data)
+	tampered.Data = []byte("tampered payload")
+	assert.False(t, spe([]*cb.SignedData{tampered}, []bool{false}), "the signature does not match the data")
+
+	// Both identities are required when they are the policy principals
+	sd1, sd2 := signedData(org1, data), signedData(org2, data)
+	policy = Envelope(And(SignedBy(0), SignedBy(1)), [][]byte{sd1.Identity, sd2.Identity})
+	spe, err = compile(policy.Rule, policy.Identities, mgr)
+	assert.NoError(t, err)
+	assert.True(t, spe([]*cb.SignedData{sd1, sd2}, []bool{false, false}))
+	assert.False(t, spe([]*cb.SignedData{sd1}, []bool{false}))
+}
@@ -52,6 +52,9 @@ type Channel interface {
 
 	// OrdererAddresses returns the list of valid orderer addresses to connect to to invoke Broadcast/Deliver
 	OrdererAddresses() []string
+
+	// Capabilities returns the capabilities of the channel
+	Capabilities() ChannelCapabilities
 }
 
 // Consortiums represents the set of consortiums serviced by an ordering service
@@ -90,6 +93,9 @@ type Orderer interface {
 
 	// Organizations returns the organizations for the ordering service
 	Organizations() map[string]Org
+
+	// Capabilities returns the capabilities of the orderer portion of the channel
+	Capabilities() OrdererCapabilities
 }
 
 // Resources is the common set of config resources for all channels
@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelconfig
+
+import (
+	"strings"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// TLSBindingCapability is the capability which requires the messages sent to
+	// the endorsers and orderers of a channel to carry the hash of the TLS client
+	// certificate of the connection they were sent over
+	TLSBindingCapability = "V1_1_TLS_BINDING"
+
+	// BlockValidationCheckCapability is the orderer capability which requires the
+	// block validation policy of the orderer group to be satisfiable by the
+	// signature of the single orderer which cuts a block
+	BlockValidationCheckCapability = "V1_1_BLOCK_VALIDATION_CHECK"
+)
+
+// ChannelCapabilities defines the capabilities for the channel
+type ChannelCapabilities interface {
+	// Supported returns an error if there are required capabilities which this binary cannot satisfy
+	Supported() error
+
+	// TLSBinding returns true if messages must be bound to the TLS client certificate
+	TLSBinding() bool
+}
+
+type channelCapabilities struct {
+	capabilities map[string]*cb.Capability
+}
+
+func newChannelCapabilities(capabilities *cb.Capabilities) *channelCapabilities {
+	return &channelCapabilities{
+		capabilities: capabilities.GetCapabilities(),
+	}
+}
+
+// Supported returns an error if there are required capabilities which this binary cannot satisfy
+func (cc *channelCapabilities) Supported() error {
+	return checkSupported("channel", cc.capabilities, TLSBindingCapability)
+}
+
+// TLSBinding returns true if messages must be bound to the TLS client certificate
+func (cc *channelCapabilities) TLSBinding() bool {
+	_, ok := cc.capabilities[TLSBindingCapability]
+	return ok
+}
+
+// OrdererCapabilities defines the capabilities for the orderer portion of a channel
+type OrdererCapabilities interface {
+	// Supported returns an error if there are required capabilities which this binary cannot satisfy
+	Supported() error
+
+	// BlockValidationCheck returns true if the block validation policy must be
+	// satisfiable by the signature of a single orderer
+	BlockValidationCheck() bool
+}
+
+type ordererCapabilities struct {
+	capabilities map[string]*cb.Capability
+}
+
+func newOrdererCapabilities(capabilities *cb.Capabilities) *ordererCapabilities {
+	return &ordererCapabilities{
+		capabilities: capabilities.GetCapabilities(),
+	}
+}
+
+// Supported returns an error if there are required capabilities which this binary cannot satisfy
+func (oc *ordererCapabilities) Supported() error {
+	return checkSupported("orderer", oc.capabilities, BlockValidationCheckCapability)
+}
+
+// BlockValidationCheck returns true if the block validation policy must be
+// satisfiable by the signature of a single orderer
+func (oc *ordererCapabilities) BlockValidationCheck() bool {
+	_, ok := oc.capabilities[BlockValidationCheckCapability]
+	return ok
+}
+
+// checkSupported returns an error if one of the given capabilities is required
+// but isn't one of the supported ones, and ignores the optional ones it doesn't support
+func checkSupported(scope string, capabilities map[string]*cb.Capability, supported ...string) error {
+NextCapability:
+	for name, capability := range capabilities {
+		for _, s := range supported {
+			if name == s {
+				continue NextCapability
+			}
+		}
+		if capability.GetRequired() {
+			return errors.Errorf("%s capability %s is required but not supported", scope, name)
+		}
+		logger.Warningf("%s capability %s is not supported, ignoring it", strings.Title(scope), name)
+	}
+	return nil
+}
@@ -32,6 +32,9 @@ const (
 	// OrdererAddressesKey is the cb.ConfigItem type key name for the OrdererAddresses message
 	OrdererAddressesKey = "OrdererAddresses"
 
+	// CapabilitiesKey is the cb.ConfigItem type key name for the Capabilities message
+	CapabilitiesKey = "Capabilities"
+
 	// GroupKey is the name of the channel group
 	ChannelGroupKey = "Channel"
 )
@@ -48,6 +51,9 @@ type ChannelValues interface {
 
 	// OrdererAddresses returns the list of valid orderer addresses to connect to to invoke Broadcast/Deliver
 	OrdererAddresses() []string
+
+	// Capabilities returns the capabilities of the channel
+	Capabilities() ChannelCapabilities
 }
 
 // ChannelProtos is where the proposed configuration is unmarshaled into
@@ -56,6 +62,7 @@ type ChannelProtos struct {
 	BlockDataHashingStructure *cb.BlockDataHashingStructure
 	OrdererAddresses          *cb.OrdererAddresses
 	Consortium                *cb.Consortium
+	Capabilities              *cb.Capabilities
 }
 
 // ChannelConfig stores the channel configuration
@@ -64,6 +71,8 @@ type ChannelConfig struct {
 
 	hashingAlgorithm func(input []byte) []byte
 
+	capabilities *channelCapabilities
+
 	mspManager msp.MSPManager
 
 	appConfig         *ApplicationConfig
@@ -146,6 +155,11 @@ func (cc *ChannelConfig) OrdererAddresses() []string {
 	return cc.protos.OrdererAddresses.Addresses
 }
 
+// Capabilities returns the capabilities of the channel
+func (cc *ChannelConfig) Capabilities() ChannelCapabilities {
+	return cc.capabilities
+}
+
 // ConsortiumName returns the name of the consortium this channel was created under
 func (cc *ChannelConfig) ConsortiumName() string {
 	return cc.protos.Consortium.Name
@@ -157,6 +171,7 @@ func (cc *ChannelConfig) Validate() error {
 		cc.validateHashingAlgorithm,
 		cc.validateBlockDataHashingStructure,
 		cc.validateOrdererAddresses,
+		cc.validateCapabilities,
 	} {
 		if err := validator(); err != nil {
 			return err
@@ -192,3 +207,8 @@ func (cc *ChannelConfig) validateOrdererAddresses() error {
 	}
 	return nil
 }
+
+func (cc *ChannelConfig) validateCapabilities() error {
+	cc.capabilities = newChannelCapabilities(cc.protos.Capabilities)
+	return cc.capabilities.Supported()
+}
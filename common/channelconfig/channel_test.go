@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -86,3 +87,31 @@ func TestConsortiumName(t *testing.T) {
 	cc := &ChannelConfig{protos: &ChannelProtos{Consortium: &cb.Consortium{Name: "TestConsortium"}}}
 	assert.Equal(t, "TestConsortium", cc.ConsortiumName(), "Unexpected consortium name returned")
 }
+
+func TestChannelCapabilities(t *testing.T) {
+	cc := &ChannelConfig{protos: &ChannelProtos{Capabilities: &cb.Capabilities{}}}
+	assert.NoError(t, cc.validateCapabilities())
+	assert.False(t, cc.Capabilities().TLSBinding())
+
+	cc = &ChannelConfig{protos: &ChannelProtos{Capabilities: &cb.Capabilities{
+		Capabilities: map[string]*cb.Capability{
+			TLSBindingCapability: {Required: true},
+			"UnknownOptional":    {},
+		},
+	}}}
+	assert.NoError(t, cc.validateCapabilities())
+	assert.True(t, cc.Capabilities().TLSBinding())
+
+	cc = &ChannelConfig{protos: &ChannelProtos{Capabilities: &cb.Capabilities{
+		Capabilities: map[string]*cb.Capability{
+			"UnknownRequired": {Required: true},
+		},
+	}}}
+	assert.Error(t, cc.validateCapabilities(), "Unknown required capability")
+
+	group := TemplateChannelCapabilities(map[string]bool{TLSBindingCapability: false})
+	capabilities := &cb.Capabilities{}
+	assert.NoError(t, proto.Unmarshal(group.Values[CapabilitiesKey].Value, capabilities))
+	assert.False(t, newChannelCapabilities(capabilities).capabilities[TLSBindingCapability].Required)
+	assert.True(t, newChannelCapabilities(capabilities).TLSBinding())
+}
@@ -62,3 +62,13 @@ func TemplateOrdererAddresses(addresses []string) *cb.ConfigGroup {
 func DefaultOrdererAddresses() *cb.ConfigGroup {
 	return TemplateOrdererAddresses(defaultOrdererAddresses)
 }
+
+// TemplateChannelCapabilities creates a headerless config item representing the channel capabilities,
+// where each capability is mapped to whether it is required
+func TemplateChannelCapabilities(capabilities map[string]bool) *cb.ConfigGroup {
+	result := &cb.Capabilities{Capabilities: make(map[string]*cb.Capability)}
+	for name, required := range capabilities {
+		result.Capabilities[name] = &cb.Capability{Required: required}
+	}
+	return configGroup(CapabilitiesKey, utils.MarshalOrPanic(result))
+}
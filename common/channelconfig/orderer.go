@@ -8,12 +8,17 @@ package channelconfig
 
 import (
 	"fmt"
+	"math"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 
 	"github.com/pkg/errors"
@@ -39,6 +44,9 @@ const (
 
 	// KafkaBrokersKey is the cb.ConfigItem type key name for the KafkaBrokers message
 	KafkaBrokersKey = "KafkaBrokers"
+
+	// BlockValidationPolicyKey is the key of the policy which the signatures of the blocks must satisfy
+	BlockValidationPolicyKey = "BlockValidation"
 )
 
 // OrdererProtos is used as the source of the OrdererConfig
@@ -48,6 +56,7 @@ type OrdererProtos struct {
 	BatchTimeout        *ab.BatchTimeout
 	KafkaBrokers        *ab.KafkaBrokers
 	ChannelRestrictions *ab.ChannelRestrictions
+	Capabilities        *cb.Capabilities
 }
 
 // OrdererConfig holds the orderer configuration information
@@ -56,6 +65,7 @@ type OrdererConfig struct {
 	orgs   map[string]Org
 
 	batchTimeout time.Duration
+	capabilities *ordererCapabilities
 }
 
 // NewOrdererConfig creates a new instance of the orderer config
@@ -73,6 +83,15 @@ func NewOrdererConfig(ordererGroup *cb.ConfigGroup, mspConfig *MSPConfigHandler)
 		return nil, err
 	}
 
+	// Rejecting block validation policies which used to be accepted requires all
+	// the orderers and peers of the channel to agree, hence the capability
+	configPolicy := ordererGroup.Policies[BlockValidationPolicyKey]
+	if oc.capabilities.BlockValidationCheck() && configPolicy != nil && configPolicy.Policy != nil {
+		if err := ValidateBlockValidationPolicy(configPolicy.Policy, ordererGroup.Groups); err != nil {
+			return nil, err
+		}
+	}
+
 	for orgName, orgGroup := range ordererGroup.Groups {
 		var err error
 		if oc.orgs[orgName], err = NewOrganizationConfig(orgName, orgGroup, mspConfig); err != nil {
@@ -114,11 +133,17 @@ func (oc *OrdererConfig) Organizations() map[string]Org {
 	return oc.orgs
 }
 
+// Capabilities returns the capabilities of the orderer portion of the channel
+func (oc *OrdererConfig) Capabilities() OrdererCapabilities {
+	return oc.capabilities
+}
+
 func (oc *OrdererConfig) Validate() error {
 	for _, validator := range []func() error{
 		oc.validateBatchSize,
 		oc.validateBatchTimeout,
 		oc.validateKafkaBrokers,
+		oc.validateCapabilities,
 	} {
 		if err := validator(); err != nil {
 			return err
@@ -165,6 +190,180 @@ func (oc *OrdererConfig) validateKafkaBrokers() error {
 	return nil
 }
 
+func (oc *OrdererConfig) validateCapabilities() error {
+	oc.capabilities = newOrdererCapabilities(oc.protos.Capabilities)
+	return oc.capabilities.Supported()
+}
+
+// ValidateBlockValidationPolicy checks that the given block validation policy of an orderer group with the
+// given organizations can be satisfied by the signature of a single orderer, as every orderer signs the
+// blocks it cuts by itself, with an identity which is a member, but not an admin, of the MSP of its
+// organization. A policy which requires the signatures of several orderers, or which only accepts the
+// signatures of admins or of identities of other MSPs, is rejected, since no block would satisfy it.
+// The orderer config only enforces it when the orderer capability BlockValidationCheckCapability is enabled
+func ValidateBlockValidationPolicy(policy *cb.Policy, orgGroups map[string]*cb.ConfigGroup) error {
+	switch policy.Type {
+	case int32(cb.Policy_IMPLICIT_META):
+		imp := &cb.ImplicitMetaPolicy{}
+		if err := proto.Unmarshal(policy.Value, imp); err != nil {
+			return errors.Wrap(err, "failed to unmarshal the block validation policy")
+		}
+		required := 0
+		switch imp.Rule {
+		case cb.ImplicitMetaPolicy_ANY:
+			required = 1
+		case cb.ImplicitMetaPolicy_ALL:
+			required = len(orgGroups)
+		case cb.ImplicitMetaPolicy_MAJORITY:
+			required = len(orgGroups)/2 + 1
+		}
+		if required > 1 {
+			return requiredSignaturesError(required)
+		}
+		if required == 0 {
+			return nil
+		}
+
+		mspIDs, err := ordererMSPIDs(orgGroups)
+		if err != nil {
+			return err
+		}
+		for _, orgGroup := range orgGroups {
+			subPolicy := orgGroup.Policies[imp.SubPolicy]
+			if subPolicy == nil || subPolicy.Policy == nil || subPolicy.Policy.Type != int32(cb.Policy_SIGNATURE) {
+				continue
+			}
+			sigPolicy := &cb.SignaturePolicyEnvelope{}
+			if err := proto.Unmarshal(subPolicy.Policy.Value, sigPolicy); err != nil {
+				return errors.Wrapf(err, "failed to unmarshal the %s policy of an orderer organization", imp.SubPolicy)
+			}
+			if ordererSignaturesRequired(sigPolicy, mspIDs) <= 1 {
+				return nil
+			}
+		}
+		return fmt.Errorf("Attempted to set a block validation policy whose %s policy of no orderer organization "+
+			"is satisfied by the signature of an orderer", imp.SubPolicy)
+
+	case int32(cb.Policy_SIGNATURE):
+		sigPolicy := &cb.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(policy.Value, sigPolicy); err != nil {
+			return errors.Wrap(err, "failed to unmarshal the block validation policy")
+		}
+		if required := requiredSignatures(sigPolicy.Rule); required > 1 {
+			return requiredSignaturesError(required)
+		}
+		mspIDs, err := ordererMSPIDs(orgGroups)
+		if err != nil {
+			return err
+		}
+		if ordererSignaturesRequired(sigPolicy, mspIDs) > 1 {
+			return fmt.Errorf("Attempted to set a block validation policy which is not satisfied by the signature of an orderer, " +
+				"while blocks are signed by members of the orderer organizations")
+		}
+	}
+	return nil
+}
+
+func requiredSignaturesError(required int) error {
+	return fmt.Errorf("Attempted to set a block validation policy which requires %d signatures, "+
+		"while blocks are signed by the single orderer which cuts them", required)
+}
+
+// ordererMSPIDs returns the set of the MSP IDs of the given orderer organizations
+func ordererMSPIDs(orgGroups map[string]*cb.ConfigGroup) (map[string]bool, error) {
+	mspIDs := make(map[string]bool)
+	for orgName, orgGroup := range orgGroups {
+		orgProtos := &OrganizationProtos{}
+		if err := DeserializeProtoValuesFromGroup(orgGroup, orgProtos); err != nil {
+			return nil, errors.Wrapf(err, "failed to deserialize the values of orderer organization %s", orgName)
+		}
+		if orgProtos.MSP == nil || orgProtos.MSP.Type != int32(msp.FABRIC) {
+			return nil, fmt.Errorf("orderer organization %s has no fabric MSP configuration", orgName)
+		}
+		fabricConfig := &mspprotos.FabricMSPConfig{}
+		if err := proto.Unmarshal(orgProtos.MSP.Config, fabricConfig); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal the MSP configuration of orderer organization %s", orgName)
+		}
+		mspIDs[fabricConfig.Name] = true
+	}
+	return mspIDs, nil
+}
+
+// ordererSignaturesRequired returns the minimum number of signatures of orderers, that is of members of
+// the given MSPs, which satisfy the given signature policy, or math.MaxInt32 if no such signatures do
+func ordererSignaturesRequired(sigPolicy *cb.SignaturePolicyEnvelope, mspIDs map[string]bool) int {
+	signers := make([]bool, len(sigPolicy.Identities))
+	for i, principal := range sigPolicy.Identities {
+		signers[i] = isOrdererPrincipal(principal, mspIDs)
+	}
+	return minSignatures(sigPolicy.Rule, func(index int32) bool {
+		return index >= 0 && int(index) < len(signers) && signers[index]
+	})
+}
+
+// isOrdererPrincipal returns true if the given principal may be satisfied by the signing identity of an
+// orderer, which is a member, but not an admin, of one of the given MSPs
+func isOrdererPrincipal(principal *mspprotos.MSPPrincipal, mspIDs map[string]bool) bool {
+	switch principal.PrincipalClassification {
+	case mspprotos.MSPPrincipal_ROLE:
+		role := &mspprotos.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			return false
+		}
+		return role.Role == mspprotos.MSPRole_MEMBER && mspIDs[role.MspIdentifier]
+	case mspprotos.MSPPrincipal_ORGANIZATION_UNIT:
+		ou := &mspprotos.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+			return false
+		}
+		return mspIDs[ou.MspIdentifier]
+	case mspprotos.MSPPrincipal_IDENTITY:
+		identity := &mspprotos.SerializedIdentity{}
+		if err := proto.Unmarshal(principal.Principal, identity); err != nil {
+			return false
+		}
+		return mspIDs[identity.Mspid]
+	}
+	return false
+}
+
+// requiredSignatures returns the minimum number of signatures which satisfy the given signature policy
+func requiredSignatures(rule *cb.SignaturePolicy) int {
+	return minSignatures(rule, func(int32) bool { return true })
+}
+
+// minSignatures returns the minimum number of signatures which satisfy the given signature policy,
+// when only the identities for which canSign returns true sign, or math.MaxInt32 if they can't satisfy it
+func minSignatures(rule *cb.SignaturePolicy, canSign func(index int32) bool) int {
+	switch t := rule.GetType().(type) {
+	case *cb.SignaturePolicy_SignedBy:
+		if !canSign(t.SignedBy) {
+			return math.MaxInt32
+		}
+		return 1
+	case *cb.SignaturePolicy_NOutOf_:
+		n := int(t.NOutOf.N)
+		if n > len(t.NOutOf.Rules) {
+			// the policy can't be satisfied, and requires more signatures than it has rules
+			return n
+		}
+		var required []int
+		for _, subRule := range t.NOutOf.Rules {
+			required = append(required, minSignatures(subRule, canSign))
+		}
+		sort.Ints(required)
+		total := 0
+		for i := 0; i < n; i++ {
+			if required[i] == math.MaxInt32 {
+				return math.MaxInt32
+			}
+			total += required[i]
+		}
+		return total
+	}
+	return 0
+}
+
 // This does just a barebones sanity check.
 func brokerEntrySeemsValid(broker string) bool {
 	if !strings.Contains(broker, ":") {
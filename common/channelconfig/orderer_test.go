@@ -9,7 +9,12 @@ package channelconfig
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 
 	logging "github.com/op/go-logging"
 	"github.com/stretchr/testify/assert"
@@ -55,3 +60,121 @@ func TestKafkaBrokers(t *testing.T) {
 	oc = &OrdererConfig{protos: &OrdererProtos{KafkaBrokers: &ab.KafkaBrokers{Brokers: []string{"127.0.0.1", "foo.bar", "127.0.0.1:-1", "localhost:65536", "foo.bar.:9092", ".127.0.0.1:9092", "-foo.bar:9092"}}}}
 	assert.Error(t, oc.validateKafkaBrokers(), "Invalid kafka brokers")
 }
+
+// ordererOrgGroups returns the config groups of orderer organizations with the given MSP IDs,
+// whose Writers policy is satisfied by their members, and whose Admins policy by their admins
+func ordererOrgGroups(mspIDs ...string) map[string]*cb.ConfigGroup {
+	orgGroups := make(map[string]*cb.ConfigGroup)
+	for _, mspID := range mspIDs {
+		group := cb.NewConfigGroup()
+		group.Values[MSPKey] = &cb.ConfigValue{Value: utils.MarshalOrPanic(&mspprotos.MSPConfig{
+			Type:   int32(msp.FABRIC),
+			Config: utils.MarshalOrPanic(&mspprotos.FabricMSPConfig{Name: mspID}),
+		})}
+		group.Policies[WritersPolicyKey] = &cb.ConfigPolicy{Policy: &cb.Policy{
+			Type:  int32(cb.Policy_SIGNATURE),
+			Value: utils.MarshalOrPanic(cauthdsl.SignedByMspMember(mspID)),
+		}}
+		group.Policies[AdminsPolicyKey] = &cb.ConfigPolicy{Policy: &cb.Policy{
+			Type:  int32(cb.Policy_SIGNATURE),
+			Value: utils.MarshalOrPanic(cauthdsl.SignedByMspAdmin(mspID)),
+		}}
+		orgGroups[mspID+"Org"] = group
+	}
+	return orgGroups
+}
+
+func TestBlockValidationPolicy(t *testing.T) {
+	implicitMetaPolicy := func(rule cb.ImplicitMetaPolicy_Rule, subPolicy string) *cb.Policy {
+		return &cb.Policy{
+			Type:  int32(cb.Policy_IMPLICIT_META),
+			Value: utils.MarshalOrPanic(&cb.ImplicitMetaPolicy{Rule: rule, SubPolicy: subPolicy}),
+		}
+	}
+	signaturePolicy := func(rule string) *cb.Policy {
+		sigPolicy, err := cauthdsl.FromString(rule)
+		assert.NoError(t, err)
+		return &cb.Policy{Type: int32(cb.Policy_SIGNATURE), Value: utils.MarshalOrPanic(sigPolicy)}
+	}
+
+	one := ordererOrgGroups("Org1")
+	two := ordererOrgGroups("Org1", "Org2")
+	three := ordererOrgGroups("Org1", "Org2", "Org3")
+
+	assert.NoError(t, ValidateBlockValidationPolicy(implicitMetaPolicy(cb.ImplicitMetaPolicy_ANY, WritersPolicyKey), three))
+	assert.NoError(t, ValidateBlockValidationPolicy(implicitMetaPolicy(cb.ImplicitMetaPolicy_MAJORITY, WritersPolicyKey), one))
+	assert.NoError(t, ValidateBlockValidationPolicy(implicitMetaPolicy(cb.ImplicitMetaPolicy_ALL, WritersPolicyKey), one))
+	assert.NoError(t, ValidateBlockValidationPolicy(signaturePolicy("OR('Org1.member', 'Org2.member')"), two))
+	assert.NoError(t, ValidateBlockValidationPolicy(signaturePolicy("OR(AND('Org1.member', 'Org2.member'), 'Org3.member')"), three))
+	assert.NoError(t, ValidateBlockValidationPolicy(signaturePolicy("OR('Org1.admin', 'Org2.member')"), two))
+
+	assert.EqualError(t, ValidateBlockValidationPolicy(implicitMetaPolicy(cb.ImplicitMetaPolicy_MAJORITY, WritersPolicyKey), three),
+		"Attempted to set a block validation policy which requires 2 signatures, while blocks are signed by the single orderer which cuts them")
+	assert.Error(t, ValidateBlockValidationPolicy(implicitMetaPolicy(cb.ImplicitMetaPolicy_ALL, WritersPolicyKey), two))
+	assert.Error(t, ValidateBlockValidationPolicy(signaturePolicy("AND('Org1.member', 'Org2.member')"), two))
+	assert.Error(t, ValidateBlockValidationPolicy(signaturePolicy("OR(AND('Org1.member', 'Org2.member'), AND('Org2.member', 'Org3.member'))"), three))
+	assert.Error(t, ValidateBlockValidationPolicy(&cb.Policy{Type: int32(cb.Policy_SIGNATURE), Value: []byte("garbage")}, one))
+
+	// The orderers sign blocks as members of their organizations, not as admins,
+	// and only the orderer organizations sign blocks
+	assert.EqualError(t, ValidateBlockValidationPolicy(implicitMetaPolicy(cb.ImplicitMetaPolicy_ANY, AdminsPolicyKey), three),
+		"Attempted to set a block validation policy whose Admins policy of no orderer organization is satisfied by the signature of an orderer")
+	assert.Error(t, ValidateBlockValidationPolicy(implicitMetaPolicy(cb.ImplicitMetaPolicy_ANY, "Unknown"), three))
+	assert.Error(t, ValidateBlockValidationPolicy(signaturePolicy("OR('Org1.admin', 'Org2.admin')"), two))
+	assert.Error(t, ValidateBlockValidationPolicy(signaturePolicy("OR('Org3.member', 'Org4.member')"), two))
+	assert.Error(t, ValidateBlockValidationPolicy(signaturePolicy("OR('Org1.member')"), map[string]*cb.ConfigGroup{"Org1": cb.NewConfigGroup()}))
+}
+
+func TestOrdererCapabilities(t *testing.T) {
+	oc := &OrdererConfig{protos: &OrdererProtos{Capabilities: &cb.Capabilities{}}}
+	assert.NoError(t, oc.validateCapabilities())
+	assert.False(t, oc.Capabilities().BlockValidationCheck())
+
+	oc = &OrdererConfig{protos: &OrdererProtos{Capabilities: &cb.Capabilities{
+		Capabilities: map[string]*cb.Capability{
+			BlockValidationCheckCapability: {Required: true},
+			"UnknownOptional":              {},
+		},
+	}}}
+	assert.NoError(t, oc.validateCapabilities())
+	assert.True(t, oc.Capabilities().BlockValidationCheck())
+
+	oc = &OrdererConfig{protos: &OrdererProtos{Capabilities: &cb.Capabilities{
+		Capabilities: map[string]*cb.Capability{
+			TLSBindingCapability: {Required: true},
+		},
+	}}}
+	assert.EqualError(t, oc.validateCapabilities(), "orderer capability V1_1_TLS_BINDING is required but not supported")
+}
+
+func TestNewOrdererConfigBlockValidationCheck(t *testing.T) {
+	ordererGroup := func(capabilities map[string]bool) *cb.ConfigGroup {
+		group := cb.NewConfigGroup()
+		for _, template := range []*cb.ConfigGroup{
+			TemplateBatchSize(&ab.BatchSize{MaxMessageCount: 10, AbsoluteMaxBytes: 1000, PreferredMaxBytes: 500}),
+			TemplateBatchTimeout("1s"),
+			TemplateOrdererCapabilities(capabilities),
+		} {
+			for key, value := range template.Groups[OrdererGroupKey].Values {
+				group.Values[key] = value
+			}
+		}
+		// MAJORITY of two organizations requires the signatures of two orderers
+		group.Groups["Org1"], group.Groups["Org2"] = cb.NewConfigGroup(), cb.NewConfigGroup()
+		group.Policies[BlockValidationPolicyKey] = &cb.ConfigPolicy{Policy: &cb.Policy{
+			Type:  int32(cb.Policy_IMPLICIT_META),
+			Value: utils.MarshalOrPanic(&cb.ImplicitMetaPolicy{Rule: cb.ImplicitMetaPolicy_MAJORITY, SubPolicy: WritersPolicyKey}),
+		}}
+		return group
+	}
+
+	// Without the capability, the policy is accepted, as it always used to be,
+	// and the config is only rejected later on for its organizations without MSP
+	_, err := NewOrdererConfig(ordererGroup(nil), nil)
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "block validation policy")
+
+	_, err = NewOrdererConfig(ordererGroup(map[string]bool{BlockValidationCheckCapability: true}), nil)
+	assert.EqualError(t, err, "Attempted to set a block validation policy which requires 2 signatures, "+
+		"while blocks are signed by the single orderer which cuts them")
+}
@@ -45,3 +45,13 @@ func TemplateChannelRestrictions(maxChannels uint64) *cb.ConfigGroup {
 func TemplateKafkaBrokers(brokers []string) *cb.ConfigGroup {
 	return ordererConfigGroup(KafkaBrokersKey, utils.MarshalOrPanic(&ab.KafkaBrokers{Brokers: brokers}))
 }
+
+// TemplateOrdererCapabilities creates a config group representing the orderer capabilities,
+// where each capability is mapped to whether it is required
+func TemplateOrdererCapabilities(capabilities map[string]bool) *cb.ConfigGroup {
+	result := &cb.Capabilities{Capabilities: make(map[string]*cb.Capability)}
+	for name, required := range capabilities {
+		result.Capabilities[name] = &cb.Capability{Required: required}
+	}
+	return ordererConfigGroup(CapabilitiesKey, utils.MarshalOrPanic(result))
+}
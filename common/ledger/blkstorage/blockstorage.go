@@ -47,6 +47,9 @@ var (
 	ErrNotFoundInIndex = errors.New("Entry not found in index")
 	// ErrAttrNotIndexed is used to indicate that an attribute is not indexed
 	ErrAttrNotIndexed = errors.New("Attribute not indexed")
+	// ErrBlockArchived is used to indicate that a block was moved to the archive of the ledger
+	// and is not available locally
+	ErrBlockArchived = errors.New("Block is archived and not available locally")
 )
 
 // BlockStoreProvider provides an handle to a BlockStore
@@ -71,5 +74,8 @@ type BlockStore interface {
 	RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error)
 	RetrieveBlockByTxID(txID string) (*common.Block, error)
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// BootstrapFromSnapshot adds the last block of a ledger snapshot as the first block of an empty store,
+	// blocks prior to it are not available, except for the config block which is retained as well
+	BootstrapFromSnapshot(lastBlock *common.Block, configBlock *common.Block) error
 	Shutdown()
 }
@@ -0,0 +1,203 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/util"
+)
+
+// localArchiveTarget stores the archived block files in a directory of the local file system,
+// which is typically a mount of a network or a cold storage
+type localArchiveTarget struct {
+	dir string
+}
+
+// NewLocalArchiveTarget constructs an `ArchiveTarget` which stores the objects as files under the given directory
+func NewLocalArchiveTarget(dir string) ArchiveTarget {
+	return &localArchiveTarget{dir}
+}
+
+// Put implements method in ArchiveTarget interface.
+// The object is written to a temporary file first, so that a partially written object is never visible.
+// Content past the given size isn't written, and fails the Put
+func (t *localArchiveTarget) Put(name string, content io.Reader, size int64) error {
+	filePath := filepath.Join(t.dir, filepath.FromSlash(name))
+	if _, err := util.CreateDirIfMissing(filepath.Dir(filePath)); err != nil {
+		return err
+	}
+	tempFilePath := filePath + archiveTempFileSuffix
+	file, err := os.OpenFile(tempFilePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0660)
+	if err != nil {
+		return err
+	}
+	written, err := io.Copy(file, io.LimitReader(content, size+1))
+	if err == nil && written != size {
+		err = fmt.Errorf("wrote [%d] bytes of object [%s] instead of [%d]", written, name, size)
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	file.Close()
+	if err == nil {
+		err = os.Rename(tempFilePath, filePath)
+	}
+	if err != nil {
+		os.Remove(tempFilePath)
+	}
+	return err
+}
+
+// Get implements method in ArchiveTarget interface
+func (t *localArchiveTarget) Get(name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(t.dir, filepath.FromSlash(name)))
+}
+
+// defaultS3Timeout is the time limit of a request to an S3 compatible object storage,
+// unless configured otherwise. It is long enough to transfer a block file on a slow link
+const defaultS3Timeout = 10 * time.Minute
+
+// S3Conf encapsulates the configuration of an S3 compatible object storage
+type S3Conf struct {
+	// Endpoint is the URL of the object storage, such as https://s3.us-east-1.amazonaws.com
+	Endpoint        string
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// Timeout is the time limit of a request, which includes the transfer of the object.
+	// It defaults to defaultS3Timeout when it isn't positive
+	Timeout time.Duration
+}
+
+// s3ArchiveTarget stores the archived block files in a bucket of an S3 compatible object storage.
+// The requests address the bucket in the path of the URL, and are signed with the AWS signature version 4
+type s3ArchiveTarget struct {
+	conf   S3Conf
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3ArchiveTarget constructs an `ArchiveTarget` which stores the objects in a bucket of an S3 compatible object storage
+func NewS3ArchiveTarget(conf S3Conf) ArchiveTarget {
+	timeout := conf.Timeout
+	if timeout <= 0 {
+		timeout = defaultS3Timeout
+	}
+	return &s3ArchiveTarget{conf: conf, client: &http.Client{Timeout: timeout}, now: time.Now}
+}
+
+// Put implements method in ArchiveTarget interface
+func (t *s3ArchiveTarget) Put(name string, content io.Reader, size int64) error {
+	req, err := t.newRequest(http.MethodPut, name, content)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(http.MethodPut, name, resp)
+	}
+	return nil
+}
+
+// Get implements method in ArchiveTarget interface
+func (t *s3ArchiveTarget) Get(name string) (io.ReadCloser, error) {
+	req, err := t.newRequest(http.MethodGet, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, s3Error(http.MethodGet, name, resp)
+	}
+	return resp.Body, nil
+}
+
+func (t *s3ArchiveTarget) newRequest(method, name string, body io.Reader) (*http.Request, error) {
+	objectURL := strings.TrimSuffix(t.conf.Endpoint, "/") + "/" + t.conf.Bucket + "/" + name
+	req, err := http.NewRequest(method, objectURL, body)
+	if err != nil {
+		return nil, err
+	}
+	t.sign(req)
+	return req, nil
+}
+
+// sign adds to the request the authorization header of the AWS signature version 4.
+// The payload isn't signed, so that the block files can be streamed
+func (t *s3ArchiveTarget) sign(req *http.Request) {
+	const (
+		algorithm      = "AWS4-HMAC-SHA256"
+		service        = "s3"
+		payloadHash    = "UNSIGNED-PAYLOAD"
+		requestDateFmt = "20060102T150405Z"
+		scopeDateFmt   = "20060102"
+	)
+	now := t.now().UTC()
+	requestDate := now.Format(requestDateFmt)
+	req.Header.Set("x-amz-date", requestDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + requestDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := strings.Join([]string{now.Format(scopeDateFmt), t.conf.Region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{algorithm, requestDate, scope, hexSHA256([]byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+t.conf.SecretAccessKey), now.Format(scopeDateFmt))
+	signingKey = hmacSHA256(signingKey, t.conf.Region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm, t.conf.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func s3Error(method, name string, resp *http.Response) error {
+	message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("%s of object [%s] failed with status [%s]: %s", method, name, resp.Status, strings.TrimSpace(string(message)))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+)
+
+// Compression is the algorithm the blocks are compressed with in the block files
+type Compression byte
+
+const (
+	// NoCompression stores the blocks uncompressed
+	NoCompression Compression = iota
+	// SnappyCompression compresses the blocks with snappy
+	SnappyCompression
+)
+
+// A record of a block file is the varint encoded record header, followed by the block bytes.
+// The record header of an uncompressed block is the length of the block bytes, which is how
+// the blocks were recorded before they could be compressed. The record header of a compressed
+// block has the compressedRecordFlag bit set, the compression in the bits above recordLengthBits,
+// and the length of the compressed block bytes in the lower bits
+const (
+	compressedRecordFlag = uint64(1) << 40
+	recordLengthBits     = 32
+	recordLengthMask     = uint64(1)<<recordLengthBits - 1
+	compressionMask      = uint64(0xff)
+)
+
+var compressionNames = map[Compression]string{
+	NoCompression:     "none",
+	SnappyCompression: "snappy",
+}
+
+// ParseCompression returns the compression with the given name, either "none" or "snappy".
+// An empty name stands for no compression
+func ParseCompression(name string) (Compression, error) {
+	if name == "" {
+		return NoCompression, nil
+	}
+	for compression, compressionName := range compressionNames {
+		if compressionName == name {
+			return compression, nil
+		}
+	}
+	return NoCompression, fmt.Errorf("unsupported block compression [%s]", name)
+}
+
+func (c Compression) String() string {
+	if name, ok := compressionNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", byte(c))
+}
+
+// encodeBlockRecord returns the record header and the recorded bytes of the given block bytes.
+// The block is recorded uncompressed if the compression does not make it smaller
+func encodeBlockRecord(blockBytes []byte, compression Compression) ([]byte, []byte, bool) {
+	if compression == SnappyCompression {
+		compressedBytes := snappy.Encode(nil, blockBytes)
+		if len(compressedBytes) < len(blockBytes) && uint64(len(compressedBytes)) <= recordLengthMask {
+			header := compressedRecordFlag | uint64(compression)<<recordLengthBits | uint64(len(compressedBytes))
+			return proto.EncodeVarint(header), compressedBytes, true
+		}
+	}
+	return proto.EncodeVarint(uint64(len(blockBytes))), blockBytes, false
+}
+
+// decodeRecordHeader returns the length of the recorded bytes and the compression of the block
+func decodeRecordHeader(header uint64) (uint64, Compression) {
+	if header&compressedRecordFlag == 0 {
+		return header, NoCompression
+	}
+	return header & recordLengthMask, Compression(header >> recordLengthBits & compressionMask)
+}
+
+// decompressBlockBytes returns the block bytes of the given recorded bytes
+func decompressBlockBytes(recordedBytes []byte, compression Compression) ([]byte, error) {
+	switch compression {
+	case NoCompression:
+		return recordedBytes, nil
+	case SnappyCompression:
+		blockBytes, err := snappy.Decode(nil, recordedBytes)
+		if err != nil {
+			return nil, fmt.Errorf("Error while decompressing block: %s", err)
+		}
+		return blockBytes, nil
+	default:
+		return nil, fmt.Errorf("Block is recorded with unsupported compression [%s]", compression)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	putil "github.com/hyperledger/fabric/protos/utils"
+)
+
+func TestParseCompression(t *testing.T) {
+	for name, expected := range map[string]Compression{"": NoCompression, "none": NoCompression, "snappy": SnappyCompression} {
+		compression, err := ParseCompression(name)
+		testutil.AssertNoError(t, err, "")
+		testutil.AssertEquals(t, compression, expected)
+	}
+	_, err := ParseCompression("zstd")
+	testutil.AssertEquals(t, err.Error(), "unsupported block compression [zstd]")
+	testutil.AssertEquals(t, SnappyCompression.String(), "snappy")
+}
+
+func TestBlockRecordEncoding(t *testing.T) {
+	blockBytes := bytes.Repeat([]byte(`{"key":"value"}`), 100)
+
+	header, recordedBytes, compressed := encodeBlockRecord(blockBytes, NoCompression)
+	testutil.AssertEquals(t, compressed, false)
+	testutil.AssertEquals(t, header, proto.EncodeVarint(uint64(len(blockBytes))))
+	testutil.AssertEquals(t, recordedBytes, blockBytes)
+
+	header, recordedBytes, compressed = encodeBlockRecord(blockBytes, SnappyCompression)
+	testutil.AssertEquals(t, compressed, true)
+	testutil.AssertEquals(t, len(recordedBytes) < len(blockBytes), true)
+	decodedHeader, _ := proto.DecodeVarint(header)
+	length, compression := decodeRecordHeader(decodedHeader)
+	testutil.AssertEquals(t, length, uint64(len(recordedBytes)))
+	testutil.AssertEquals(t, compression, SnappyCompression)
+	decompressedBytes, err := decompressBlockBytes(recordedBytes, compression)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, decompressedBytes, blockBytes)
+
+	// blocks which do not compress are recorded uncompressed
+	_, _, compressed = encodeBlockRecord([]byte{1, 2, 3}, SnappyCompression)
+	testutil.AssertEquals(t, compressed, false)
+
+	_, err = decompressBlockBytes(recordedBytes, Compression(7))
+	testutil.AssertEquals(t, err.Error(), "Block is recorded with unsupported compression [unknown(7)]")
+}
+
+func TestBlockfileMgrCompression(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0).WithCompression(SnappyCompression))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	blkfileMgrWrapper.addBlocks(blocks)
+	// the transactions are located within their compressed blocks
+	txID, err := extractTxID(blocks[1].Data.Data[0])
+	testutil.AssertNoError(t, err, "")
+	txLoc, err := blkfileMgrWrapper.blockfileMgr.index.getTxLoc(txID)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNotNil(t, txLoc.blockTxLoc)
+	blkfileMgrWrapper.testGetBlockByHash(blocks)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0)
+	testGetTransactions(t, blkfileMgrWrapper, blocks)
+	testRetrieveBlocks(t, blkfileMgrWrapper, blocks)
+	blkfileMgrWrapper.close()
+
+	// the index is rebuilt from the compressed blocks
+	env.provider.leveldbProvider.GetDBHandle("testLedger").Delete(indexCheckpointKey, true)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.testGetBlockByHash(blocks)
+	testGetTransactions(t, blkfileMgrWrapper, blocks)
+}
+
+func TestBlockfileMgrMixedCompression(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks[:10])
+	blkfileMgrWrapper.close()
+
+	// blocks added uncompressed remain readable once compression is turned on
+	env.provider.conf.WithCompression(SnappyCompression)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks[10:])
+	blkfileMgrWrapper.testGetBlockByHash(blocks)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0)
+	testGetTransactions(t, blkfileMgrWrapper, blocks)
+	testRetrieveBlocks(t, blkfileMgrWrapper, blocks)
+	blkfileMgrWrapper.close()
+
+	// and vice versa
+	env.provider.conf.WithCompression(NoCompression)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.testGetBlockByHash(blocks)
+	testGetTransactions(t, blkfileMgrWrapper, blocks)
+}
+
+func testGetTransactions(t *testing.T, w *testBlockfileMgrWrapper, blocks []*common.Block) {
+	for blockIndex, block := range blocks {
+		for tranIndex, txEnvelopeBytes := range block.Data.Data {
+			txEnvelope, err := putil.GetEnvelopeFromBlock(txEnvelopeBytes)
+			testutil.AssertNoError(t, err, "Error while unmarshalling tx")
+			txEnvelopeFromFileMgr, err := w.blockfileMgr.retrieveTransactionByBlockNumTranNum(uint64(blockIndex), uint64(tranIndex))
+			testutil.AssertNoError(t, err, "Error while retrieving tx from blkfileMgr")
+			testutil.AssertEquals(t, txEnvelopeFromFileMgr, txEnvelope)
+			txID, err := extractTxID(txEnvelopeBytes)
+			testutil.AssertNoError(t, err, "")
+			txEnvelopeFromFileMgr, err = w.blockfileMgr.retrieveTransactionByID(txID)
+			testutil.AssertNoError(t, err, "Error while retrieving tx from blkfileMgr")
+			testutil.AssertEquals(t, txEnvelopeFromFileMgr, txEnvelope)
+		}
+	}
+}
+
+func testRetrieveBlocks(t *testing.T, w *testBlockfileMgrWrapper, blocks []*common.Block) {
+	itr, err := w.blockfileMgr.retrieveBlocks(0)
+	testutil.AssertNoError(t, err, "")
+	defer itr.Close()
+	for _, block := range blocks {
+		result, err := itr.Next()
+		testutil.AssertNoError(t, err, "")
+		testutil.AssertEquals(t, result, block)
+	}
+}
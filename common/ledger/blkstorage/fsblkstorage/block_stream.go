@@ -44,7 +44,7 @@ type blockfileStream struct {
 // it starts from a given file offset and continues with the next
 // file segment until the end of the last segment (`endFileNum`)
 type blockStream struct {
-	rootDir           string
+	openBlockfile     blockfileOpener
 	currentFileNum    int
 	endFileNum        int
 	currentFileStream *blockfileStream
@@ -56,26 +56,39 @@ type blockPlacementInfo struct {
 	fileNum          int
 	blockStartOffset int64
 	blockBytesOffset int64
+	// compressed tells whether the block is compressed in the file, in which case
+	// the offsets within the block bytes do not map to offsets in the file
+	compressed bool
+}
+
+// blockfileOpener opens the block file with the given number for reading
+type blockfileOpener func(fileNum int) (*os.File, error)
+
+// localBlockfileOpener opens the block files which are stored in the given directory
+func localBlockfileOpener(rootDir string) blockfileOpener {
+	return func(fileNum int) (*os.File, error) {
+		return os.OpenFile(deriveBlockfilePath(rootDir, fileNum), os.O_RDONLY, 0600)
+	}
 }
 
 ///////////////////////////////////
 // blockfileStream functions
 ////////////////////////////////////
-func newBlockfileStream(rootDir string, fileNum int, startOffset int64) (*blockfileStream, error) {
-	filePath := deriveBlockfilePath(rootDir, fileNum)
-	logger.Debugf("newBlockfileStream(): filePath=[%s], startOffset=[%d]", filePath, startOffset)
+func newBlockfileStream(openBlockfile blockfileOpener, fileNum int, startOffset int64) (*blockfileStream, error) {
 	var file *os.File
 	var err error
-	if file, err = os.OpenFile(filePath, os.O_RDONLY, 0600); err != nil {
+	if file, err = openBlockfile(fileNum); err != nil {
 		return nil, err
 	}
+	logger.Debugf("newBlockfileStream(): filePath=[%s], startOffset=[%d]", file.Name(), startOffset)
 	var newPosition int64
 	if newPosition, err = file.Seek(startOffset, 0); err != nil {
+		file.Close()
 		return nil, err
 	}
 	if newPosition != startOffset {
 		panic(fmt.Sprintf("Could not seek file [%s] to given startOffset [%d]. New position = [%d]",
-			filePath, startOffset, newPosition))
+			file.Name(), startOffset, newPosition))
 	}
 	s := &blockfileStream{fileNum, file, bufio.NewReader(file), startOffset}
 	return s, nil
@@ -88,6 +101,7 @@ func (s *blockfileStream) nextBlockBytes() ([]byte, error) {
 
 // nextBlockBytesAndPlacementInfo returns bytes for the next block
 // along with the offset information in the block file.
+// The bytes of a compressed block are returned decompressed
 // An error `ErrUnexpectedEndOfBlockfile` is returned if a partial written data is detected
 // which is possible towards the tail of the file if a crash had taken place during appending of a block
 func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacementInfo, error) {
@@ -115,7 +129,8 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 	if lenBytes, err = s.reader.Peek(peekBytes); err != nil {
 		return nil, nil, err
 	}
-	length, n := proto.DecodeVarint(lenBytes)
+	header, n := proto.DecodeVarint(lenBytes)
+	length, compression := decodeRecordHeader(header)
 	if n == 0 {
 		// proto.DecodeVarint did not consume any byte at all which means that the bytes
 		// representing the size of the block are partial bytes
@@ -134,15 +149,20 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 	if _, err = s.reader.Discard(n); err != nil {
 		return nil, nil, err
 	}
-	blockBytes := make([]byte, length)
-	if _, err = io.ReadAtLeast(s.reader, blockBytes, int(length)); err != nil {
+	recordedBytes := make([]byte, length)
+	if _, err = io.ReadAtLeast(s.reader, recordedBytes, int(length)); err != nil {
 		logger.Debugf("Error while trying to read [%d] bytes from fileNum [%d]: %s", length, s.fileNum, err)
 		return nil, nil, err
 	}
+	blockBytes, err := decompressBlockBytes(recordedBytes, compression)
+	if err != nil {
+		return nil, nil, err
+	}
 	blockPlacementInfo := &blockPlacementInfo{
 		fileNum:          s.fileNum,
 		blockStartOffset: s.currentOffset,
-		blockBytesOffset: s.currentOffset + int64(n)}
+		blockBytesOffset: s.currentOffset + int64(n),
+		compressed:       compression != NoCompression}
 	s.currentOffset += int64(n) + int64(length)
 	logger.Debugf("Returning blockbytes - length=[%d], placementInfo={%s}", len(blockBytes), blockPlacementInfo)
 	return blockBytes, blockPlacementInfo, nil
@@ -155,12 +175,12 @@ func (s *blockfileStream) close() error {
 ///////////////////////////////////
 // blockStream functions
 ////////////////////////////////////
-func newBlockStream(rootDir string, startFileNum int, startOffset int64, endFileNum int) (*blockStream, error) {
-	startFileStream, err := newBlockfileStream(rootDir, startFileNum, startOffset)
+func newBlockStream(openBlockfile blockfileOpener, startFileNum int, startOffset int64, endFileNum int) (*blockStream, error) {
+	startFileStream, err := newBlockfileStream(openBlockfile, startFileNum, startOffset)
 	if err != nil {
 		return nil, err
 	}
-	return &blockStream{rootDir, startFileNum, endFileNum, startFileStream}, nil
+	return &blockStream{openBlockfile, startFileNum, endFileNum, startFileStream}, nil
 }
 
 func (s *blockStream) moveToNextBlockfileStream() error {
@@ -169,7 +189,7 @@ func (s *blockStream) moveToNextBlockfileStream() error {
 		return err
 	}
 	s.currentFileNum++
-	if s.currentFileStream, err = newBlockfileStream(s.rootDir, s.currentFileNum, 0); err != nil {
+	if s.currentFileStream, err = newBlockfileStream(s.openBlockfile, s.currentFileNum, 0); err != nil {
 		return err
 	}
 	return nil
@@ -204,6 +224,6 @@ func (s *blockStream) close() error {
 }
 
 func (i *blockPlacementInfo) String() string {
-	return fmt.Sprintf("fileNum=[%d], startOffset=[%d], bytesOffset=[%d]",
-		i.fileNum, i.blockStartOffset, i.blockBytesOffset)
+	return fmt.Sprintf("fileNum=[%d], startOffset=[%d], bytesOffset=[%d], compressed=[%t]",
+		i.fileNum, i.blockStartOffset, i.blockBytesOffset, i.compressed)
 }
@@ -39,7 +39,7 @@ func testBlockfileStream(t *testing.T, numBlocks int) {
 	w.addBlocks(blocks)
 	w.close()
 
-	s, err := newBlockfileStream(w.blockfileMgr.rootDir, 0, 0)
+	s, err := newBlockfileStream(localBlockfileOpener(w.blockfileMgr.rootDir), 0, 0)
 	defer s.close()
 	testutil.AssertNoError(t, err, "Error in constructing blockfile stream")
 
@@ -80,7 +80,7 @@ func testBlockFileStreamUnexpectedEOF(t *testing.T, numBlocks int, partialBlockB
 	w.addBlocks(blocks)
 	blockfileMgr.currentFileWriter.append(partialBlockBytes, true)
 	w.close()
-	s, err := newBlockfileStream(blockfileMgr.rootDir, 0, 0)
+	s, err := newBlockfileStream(localBlockfileOpener(blockfileMgr.rootDir), 0, 0)
 	defer s.close()
 	testutil.AssertNoError(t, err, "Error in constructing blockfile stream")
 
@@ -121,7 +121,7 @@ func testBlockStream(t *testing.T, numFiles int) {
 		w.addBlocks(blocks)
 		blockfileMgr.moveToNextFile()
 	}
-	s, err := newBlockStream(blockfileMgr.rootDir, 0, 0, numFiles-1)
+	s, err := newBlockStream(localBlockfileOpener(blockfileMgr.rootDir), 0, 0, numFiles-1)
 	defer s.close()
 	testutil.AssertNoError(t, err, "Error in constructing new block stream")
 	blockCount := 0
@@ -0,0 +1,563 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+)
+
+const (
+	archiveCacheDir         = "archived"
+	archiveManifestName     = "manifest.json"
+	defaultArchiveCacheSize = 4
+	archiveTempFileSuffix   = ".tmp"
+	archiveObjectNameJoiner = "/"
+	archiveManifestVersion  = 1
+)
+
+var archiveManifestKey = []byte("archiveManifest")
+
+// ArchiveConf encapsulates the configuration of the archiving of the block files of a ledger.
+// A block file is moved to the archive target once all its blocks are below the retention
+// height, that is, once they precede the latest RetentionHeight blocks of the ledger
+type ArchiveConf struct {
+	// Target is the external storage the block files are moved to
+	Target ArchiveTarget
+	// RetentionHeight is the number of latest blocks that are kept locally
+	RetentionHeight uint64
+	// FetchArchivedBlocks tells whether archived blocks are fetched from the target on demand.
+	// Otherwise, retrieving an archived block fails with blkstorage.ErrBlockArchived
+	FetchArchivedBlocks bool
+	// CacheSize is the number of fetched block files that are cached locally
+	CacheSize int
+}
+
+// ArchiveTarget is an external storage of the archived block files of the ledgers.
+// The objects stored by a ledger are named after the ledger id, followed by a '/' and the name of the file
+type ArchiveTarget interface {
+	// Put stores an object with the given name and content, replacing any existing object with the same name
+	Put(name string, content io.Reader, size int64) error
+	// Get returns the content of the object with the given name
+	Get(name string) (io.ReadCloser, error)
+}
+
+// archiveManifest lists the archived block files of a ledger, in the order they were archived.
+// Its entries form a hash chain, so that the archive can be verified from the hash of the last entry
+type archiveManifest struct {
+	Version  int                     `json:"version"`
+	LedgerID string                  `json:"ledger_id"`
+	Entries  []*archiveManifestEntry `json:"entries"`
+}
+
+// archiveManifestEntry describes an archived block file. Its hash covers the other fields,
+// including the hash of the previous entry
+type archiveManifestEntry struct {
+	FileNum           int    `json:"file_num"`
+	FirstBlockNum     uint64 `json:"first_block_num"`
+	LastBlockNum      uint64 `json:"last_block_num"`
+	LastBlockHash     []byte `json:"last_block_hash"`
+	FileSize          int64  `json:"file_size"`
+	FileHash          []byte `json:"file_hash"`
+	PreviousEntryHash []byte `json:"previous_entry_hash"`
+	EntryHash         []byte `json:"entry_hash"`
+}
+
+func (e *archiveManifestEntry) computeHash() []byte {
+	buffer := proto.NewBuffer([]byte{})
+	buffer.EncodeVarint(uint64(e.FileNum))
+	buffer.EncodeVarint(e.FirstBlockNum)
+	buffer.EncodeVarint(e.LastBlockNum)
+	buffer.EncodeRawBytes(e.LastBlockHash)
+	buffer.EncodeVarint(uint64(e.FileSize))
+	buffer.EncodeRawBytes(e.FileHash)
+	buffer.EncodeRawBytes(e.PreviousEntryHash)
+	hash := sha256.Sum256(buffer.Bytes())
+	return hash[:]
+}
+
+// verify checks that the entries of the manifest are chained by their hashes
+func (m *archiveManifest) verify() error {
+	var previousEntryHash []byte
+	for i, entry := range m.Entries {
+		if entry.FileNum != i {
+			return fmt.Errorf("entry [%d] of the archive manifest is for block file [%d]", i, entry.FileNum)
+		}
+		if !bytes.Equal(entry.PreviousEntryHash, previousEntryHash) || !bytes.Equal(entry.EntryHash, entry.computeHash()) {
+			return fmt.Errorf("entry [%d] of the archive manifest breaks the hash chain", i)
+		}
+		previousEntryHash = entry.EntryHash
+	}
+	return nil
+}
+
+// blockfileArchiver moves the block files of a ledger below the retention height to the archive target,
+// and opens the archived block files for the readers of the blockfileMgr
+type blockfileArchiver struct {
+	mgr      *blockfileMgr
+	ledgerID string
+	conf     *ArchiveConf
+	cacheDir string
+
+	// archiveLock serializes the archiving of the block files
+	archiveLock      sync.Mutex
+	manifestUploaded bool
+	// manifestLock guards the manifest, which the readers of the block files consult
+	manifestLock sync.RWMutex
+	manifest     *archiveManifest
+	// fetchLock guards the cache of the fetched block files and the block files being downloaded,
+	// which are downloaded once while the readers of these block files wait for the download
+	fetchLock sync.Mutex
+	downloads map[int]*blockfileDownload
+	// triggerLock guards the state of the background archiving
+	triggerLock sync.Mutex
+	archiving   bool
+	rerun       bool
+	// nextArchiveHeight is the height at which the next block file becomes archivable,
+	// or 0 if that block file is still being written to
+	nextArchiveHeight uint64
+	running           sync.WaitGroup
+}
+
+func newBlockfileArchiver(mgr *blockfileMgr, ledgerID string, conf *ArchiveConf) (*blockfileArchiver, error) {
+	a := &blockfileArchiver{
+		mgr:              mgr,
+		ledgerID:         ledgerID,
+		conf:             conf,
+		cacheDir:         filepath.Join(mgr.rootDir, archiveCacheDir),
+		manifestUploaded: true,
+		manifest:         &archiveManifest{Version: archiveManifestVersion, LedgerID: ledgerID},
+		downloads:        make(map[int]*blockfileDownload),
+	}
+	if _, err := util.CreateDirIfMissing(a.cacheDir); err != nil {
+		return nil, err
+	}
+	if err := a.loadManifest(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// loadManifest loads the manifest of the archived block files from the db, if any block file was archived
+func (a *blockfileArchiver) loadManifest() error {
+	manifestBytes, err := a.mgr.db.Get(archiveManifestKey)
+	if err != nil {
+		return err
+	}
+	if manifestBytes != nil {
+		if err = json.Unmarshal(manifestBytes, a.manifest); err != nil {
+			return fmt.Errorf("Error while unmarshaling archive manifest: %s", err)
+		}
+	}
+	if err = a.manifest.verify(); err != nil {
+		return err
+	}
+	logger.Debugf("Loaded archive manifest of ledger [%s] with [%d] archived block files", a.ledgerID, len(a.manifest.Entries))
+	return nil
+}
+
+// blockAdded is invoked when a block is added to the ledger, and archives the block files
+// which became archivable, that is, when a block file was completed or the next block file
+// to archive went below the retention height
+func (a *blockfileArchiver) blockAdded(height uint64, fileCompleted bool) {
+	a.triggerLock.Lock()
+	trigger := fileCompleted || (a.nextArchiveHeight != 0 && height >= a.nextArchiveHeight)
+	a.triggerLock.Unlock()
+	if trigger {
+		a.archiveInBackground()
+	}
+}
+
+// archiveInBackground archives the block files below the retention height without blocking the caller.
+// If an archiving is in progress, another one is run once it completes
+func (a *blockfileArchiver) archiveInBackground() {
+	a.triggerLock.Lock()
+	defer a.triggerLock.Unlock()
+	if a.archiving {
+		a.rerun = true
+		return
+	}
+	a.archiving = true
+	a.running.Add(1)
+	go func() {
+		defer a.running.Done()
+		for {
+			if err := a.archiveBlockfiles(); err != nil {
+				logger.Errorf("Error while archiving block files of ledger [%s]: %s", a.ledgerID, err)
+			}
+			a.triggerLock.Lock()
+			if !a.rerun {
+				a.archiving = false
+				a.triggerLock.Unlock()
+				return
+			}
+			a.rerun = false
+			a.triggerLock.Unlock()
+		}
+	}()
+}
+
+// close waits for the archiving in progress
+func (a *blockfileArchiver) close() {
+	a.running.Wait()
+}
+
+// archiveBlockfiles archives, in order, the block files whose blocks are all below the retention height
+func (a *blockfileArchiver) archiveBlockfiles() error {
+	a.archiveLock.Lock()
+	defer a.archiveLock.Unlock()
+
+	// a crash may have happened after a block file was archived but before the manifest was
+	// uploaded or the local block file was removed
+	if !a.manifestUploaded {
+		if err := a.uploadManifest(); err != nil {
+			return err
+		}
+	}
+	for _, entry := range a.manifestEntries() {
+		if err := os.Remove(deriveBlockfilePath(a.mgr.rootDir, entry.FileNum)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	for {
+		fileNum := len(a.manifestEntries())
+		archivable, err := a.isArchivable(fileNum)
+		if err != nil || !archivable {
+			return err
+		}
+		if err = a.archiveBlockfile(fileNum); err != nil {
+			return err
+		}
+	}
+}
+
+// isArchivable tells whether the given block file is complete and all its blocks are below the retention height
+func (a *blockfileArchiver) isArchivable(fileNum int) (bool, error) {
+	a.mgr.cpInfoCond.L.Lock()
+	latestFileNum := a.mgr.cpInfo.latestFileChunkSuffixNum
+	a.mgr.cpInfoCond.L.Unlock()
+	if fileNum >= latestFileNum {
+		a.setNextArchiveHeight(0)
+		return false, nil
+	}
+	// the last block of the file precedes the first block of the next file
+	stream, err := newBlockfileStream(localBlockfileOpener(a.mgr.rootDir), fileNum+1, 0)
+	if err != nil {
+		return false, err
+	}
+	defer stream.close()
+	blockBytes, err := stream.nextBlockBytes()
+	if err != nil || blockBytes == nil {
+		return false, err
+	}
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		return false, err
+	}
+	lastBlockNum := info.blockHeader.Number - 1
+	if lastBlockNum+a.conf.RetentionHeight >= a.mgr.getBlockchainInfo().Height {
+		a.setNextArchiveHeight(lastBlockNum + a.conf.RetentionHeight + 1)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (a *blockfileArchiver) setNextArchiveHeight(height uint64) {
+	a.triggerLock.Lock()
+	defer a.triggerLock.Unlock()
+	a.nextArchiveHeight = height
+}
+
+// archiveBlockfile uploads a block file to the archive target, records it in the manifest and removes it locally
+func (a *blockfileArchiver) archiveBlockfile(fileNum int) error {
+	entries := a.manifestEntries()
+	entry := &archiveManifestEntry{FileNum: fileNum}
+	var previousBlockHash []byte
+	if len(entries) > 0 {
+		entry.PreviousEntryHash = entries[len(entries)-1].EntryHash
+		previousBlockHash = entries[len(entries)-1].LastBlockHash
+	}
+
+	// scan the blocks of the file, which are expected to extend the chain of the archived blocks
+	stream, err := newBlockfileStream(localBlockfileOpener(a.mgr.rootDir), fileNum, 0)
+	if err != nil {
+		return err
+	}
+	numBlocks := 0
+	for {
+		blockBytes, err := stream.nextBlockBytes()
+		if err != nil {
+			stream.close()
+			return err
+		}
+		if blockBytes == nil {
+			break
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			stream.close()
+			return err
+		}
+		if previousBlockHash != nil && !bytes.Equal(info.blockHeader.PreviousHash, previousBlockHash) {
+			stream.close()
+			return fmt.Errorf("Block [%d] in block file [%d] does not extend the chain of the archived blocks",
+				info.blockHeader.Number, fileNum)
+		}
+		if numBlocks == 0 {
+			entry.FirstBlockNum = info.blockHeader.Number
+		}
+		entry.LastBlockNum = info.blockHeader.Number
+		previousBlockHash = info.blockHeader.Hash()
+		numBlocks++
+	}
+	stream.close()
+	if numBlocks == 0 {
+		return fmt.Errorf("Block file [%d] to archive contains no blocks", fileNum)
+	}
+	entry.LastBlockHash = previousBlockHash
+
+	filePath := deriveBlockfilePath(a.mgr.rootDir, fileNum)
+	if entry.FileSize, entry.FileHash, err = hashFile(filePath); err != nil {
+		return err
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	err = a.conf.Target.Put(a.objectName(filepath.Base(filePath)), file, entry.FileSize)
+	file.Close()
+	if err != nil {
+		return fmt.Errorf("Error while uploading block file [%d] to the archive: %s", fileNum, err)
+	}
+	entry.EntryHash = entry.computeHash()
+
+	// the manifest is saved before the local file is removed, so that a crash in between
+	// leaves a block file which is known to be archived and gets removed by the next archiving
+	if err = a.saveManifest(entry); err != nil {
+		return err
+	}
+	if err = a.uploadManifest(); err != nil {
+		return err
+	}
+	if err = os.Remove(filePath); err != nil {
+		return err
+	}
+	logger.Infof("Archived block file [%d] of ledger [%s] with blocks [%d] to [%d]",
+		fileNum, a.ledgerID, entry.FirstBlockNum, entry.LastBlockNum)
+	return nil
+}
+
+func (a *blockfileArchiver) saveManifest(entry *archiveManifestEntry) error {
+	a.manifestLock.Lock()
+	defer a.manifestLock.Unlock()
+	manifest := *a.manifest
+	manifest.Entries = append(append([]*archiveManifestEntry{}, a.manifest.Entries...), entry)
+	manifestBytes, err := json.Marshal(&manifest)
+	if err != nil {
+		return err
+	}
+	if err = a.mgr.db.Put(archiveManifestKey, manifestBytes, true); err != nil {
+		return fmt.Errorf("Error while saving archive manifest to db: %s", err)
+	}
+	a.manifest = &manifest
+	a.manifestUploaded = false
+	return nil
+}
+
+// uploadManifest uploads the manifest to the archive target, next to the archived block files
+func (a *blockfileArchiver) uploadManifest() error {
+	a.manifestLock.RLock()
+	manifestBytes, err := json.MarshalIndent(a.manifest, "", "  ")
+	a.manifestLock.RUnlock()
+	if err != nil {
+		return err
+	}
+	err = a.conf.Target.Put(a.objectName(archiveManifestName), bytes.NewReader(manifestBytes), int64(len(manifestBytes)))
+	if err != nil {
+		return fmt.Errorf("Error while uploading archive manifest: %s", err)
+	}
+	a.manifestUploaded = true
+	return nil
+}
+
+func (a *blockfileArchiver) manifestEntries() []*archiveManifestEntry {
+	a.manifestLock.RLock()
+	defer a.manifestLock.RUnlock()
+	return a.manifest.Entries
+}
+
+func (a *blockfileArchiver) manifestEntry(fileNum int) *archiveManifestEntry {
+	entries := a.manifestEntries()
+	if fileNum < 0 || fileNum >= len(entries) {
+		return nil
+	}
+	return entries[fileNum]
+}
+
+// isBlockArchived tells whether the given block is in an archived block file
+func (a *blockfileArchiver) isBlockArchived(blockNum uint64) bool {
+	entries := a.manifestEntries()
+	return len(entries) > 0 && blockNum <= entries[len(entries)-1].LastBlockNum
+}
+
+// openBlockfile implements blockfileOpener. An archived block file is fetched from the archive target
+// to the cache, unless fetching archived blocks is disabled
+func (a *blockfileArchiver) openBlockfile(fileNum int) (*os.File, error) {
+	file, err := os.OpenFile(deriveBlockfilePath(a.mgr.rootDir, fileNum), os.O_RDONLY, 0600)
+	if err == nil || !os.IsNotExist(err) {
+		return file, err
+	}
+	// the manifest is checked after the local file is found missing, since the file is removed after it is archived
+	entry := a.manifestEntry(fileNum)
+	if entry == nil {
+		return nil, err
+	}
+	if !a.conf.FetchArchivedBlocks {
+		return nil, blkstorage.ErrBlockArchived
+	}
+	return a.fetchBlockfile(entry)
+}
+
+// blockfileDownload is the download of an archived block file to the cache,
+// whose done channel is closed once the download completed
+type blockfileDownload struct {
+	done chan struct{}
+	err  error
+}
+
+// fetchBlockfile opens the cached copy of an archived block file, which is downloaded if it isn't cached yet.
+// The fetchLock isn't held during the download, so that the block files which are cached or downloaded by
+// other readers can be opened meanwhile
+func (a *blockfileArchiver) fetchBlockfile(entry *archiveManifestEntry) (*os.File, error) {
+	cachedFilePath := deriveBlockfilePath(a.cacheDir, entry.FileNum)
+	a.fetchLock.Lock()
+	if file, err := os.OpenFile(cachedFilePath, os.O_RDONLY, 0600); err == nil {
+		a.fetchLock.Unlock()
+		return file, nil
+	}
+	if download, exists := a.downloads[entry.FileNum]; exists {
+		a.fetchLock.Unlock()
+		<-download.done
+		if download.err != nil {
+			return nil, download.err
+		}
+		// the block file may have been evicted from the cache since then, in which case it is downloaded again
+		return a.fetchBlockfile(entry)
+	}
+	download := &blockfileDownload{done: make(chan struct{})}
+	a.downloads[entry.FileNum] = download
+	a.fetchLock.Unlock()
+
+	download.err = a.downloadBlockfile(entry, cachedFilePath)
+
+	a.fetchLock.Lock()
+	defer a.fetchLock.Unlock()
+	delete(a.downloads, entry.FileNum)
+	close(download.done)
+	if download.err != nil {
+		return nil, download.err
+	}
+	a.evictCachedBlockfiles(cachedFilePath)
+	return os.OpenFile(cachedFilePath, os.O_RDONLY, 0600)
+}
+
+// downloadBlockfile downloads an archived block file from the archive target to the given path of the cache,
+// and verifies it matches its entry of the manifest
+func (a *blockfileArchiver) downloadBlockfile(entry *archiveManifestEntry, cachedFilePath string) error {
+	logger.Debugf("Fetching archived block file [%d] of ledger [%s]", entry.FileNum, a.ledgerID)
+	content, err := a.conf.Target.Get(a.objectName(filepath.Base(cachedFilePath)))
+	if err != nil {
+		return fmt.Errorf("Error while fetching archived block file [%d]: %s", entry.FileNum, err)
+	}
+	defer content.Close()
+
+	tempFilePath := cachedFilePath + archiveTempFileSuffix
+	tempFile, err := os.OpenFile(tempFilePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0660)
+	if err != nil {
+		return err
+	}
+	// the download stops a byte past the size recorded in the manifest, so that an oversized object
+	// is rejected without filling the cache, and the hash is verified before the file enters the cache
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tempFile, hash), io.LimitReader(content, entry.FileSize+1))
+	if err == nil {
+		err = tempFile.Sync()
+	}
+	tempFile.Close()
+	if err == nil && (size != entry.FileSize || !bytes.Equal(hash.Sum(nil), entry.FileHash)) {
+		err = fmt.Errorf("Archived block file [%d] does not match the archive manifest", entry.FileNum)
+	}
+	if err == nil {
+		err = os.Rename(tempFilePath, cachedFilePath)
+	}
+	if err != nil {
+		os.Remove(tempFilePath)
+	}
+	return err
+}
+
+// evictCachedBlockfiles removes the least recently fetched block files from the cache,
+// but the given one, so that the cache holds no more than the configured number of files.
+// The readers which opened an evicted file can still read it
+func (a *blockfileArchiver) evictCachedBlockfiles(keptFilePath string) {
+	cacheSize := a.conf.CacheSize
+	if cacheSize <= 0 {
+		cacheSize = defaultArchiveCacheSize
+	}
+	fileInfos, err := ioutil.ReadDir(a.cacheDir)
+	if err != nil {
+		logger.Warningf("Could not list cached block files of ledger [%s]: %s", a.ledgerID, err)
+		return
+	}
+	var cachedFiles []os.FileInfo
+	for _, fileInfo := range fileInfos {
+		if strings.HasPrefix(fileInfo.Name(), blockfilePrefix) && !strings.HasSuffix(fileInfo.Name(), archiveTempFileSuffix) {
+			cachedFiles = append(cachedFiles, fileInfo)
+		}
+	}
+	sort.Slice(cachedFiles, func(i, j int) bool {
+		return cachedFiles[i].ModTime().Before(cachedFiles[j].ModTime())
+	})
+	for i := 0; i < len(cachedFiles)-cacheSize; i++ {
+		filePath := filepath.Join(a.cacheDir, cachedFiles[i].Name())
+		if filePath != keptFilePath {
+			os.Remove(filePath)
+		}
+	}
+}
+
+func (a *blockfileArchiver) objectName(fileName string) string {
+	return a.ledgerID + archiveObjectNameJoiner + fileName
+}
+
+func hashFile(filePath string) (int64, []byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return 0, nil, err
+	}
+	return size, hash.Sum(nil), nil
+}
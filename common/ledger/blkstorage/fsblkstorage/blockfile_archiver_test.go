@@ -0,0 +1,372 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	putil "github.com/hyperledger/fabric/protos/utils"
+)
+
+const archiveTestLedgerID = "testLedger"
+
+// newArchiveTestEnv returns a test env whose block files hold about 10 blocks each,
+// and which archives the block files of the test ledger to the given target
+func newArchiveTestEnv(t *testing.T, blocks []*common.Block, archiveConf *ArchiveConf) *testEnv {
+	conf := NewConfWithArchiving(testPath(), blockfileSizeFor(t, blocks[:10]), map[string]*ArchiveConf{archiveTestLedgerID: archiveConf})
+	return newTestEnv(t, conf)
+}
+
+// waitForArchiving waits for the background archiving, and archives the block files which are archivable
+func waitForArchiving(t *testing.T, w *testBlockfileMgrWrapper) {
+	w.blockfileMgr.archiver.close()
+	testutil.AssertNoError(t, w.blockfileMgr.archiver.archiveBlockfiles(), "Error while archiving block files")
+}
+
+func TestBlockfileArchiving(t *testing.T) {
+	archiveDir := testPath()
+	defer os.RemoveAll(archiveDir)
+	allBlocks := testutil.ConstructTestBlocks(t, 150)
+	blocks, moreBlocks := allBlocks[:100], allBlocks[100:]
+	archiveConf := &ArchiveConf{Target: NewLocalArchiveTarget(archiveDir), RetentionHeight: 30, FetchArchivedBlocks: true, CacheSize: 2}
+	env := newArchiveTestEnv(t, blocks, archiveConf)
+	defer env.Cleanup()
+
+	w := newTestBlockfileWrapper(env, archiveTestLedgerID)
+	w.addBlocks(blocks)
+	waitForArchiving(t, w)
+
+	entries := w.blockfileMgr.archiver.manifestEntries()
+	testutil.AssertEquals(t, len(entries) > 3, true)
+	lastEntry := entries[len(entries)-1]
+	testutil.AssertEquals(t, lastEntry.LastBlockNum+30 < 100, true)
+	testutil.AssertEquals(t, entries[0].FirstBlockNum, uint64(0))
+	for i, entry := range entries {
+		// the archived block files are removed locally and are available from the archive
+		_, err := os.Stat(deriveBlockfilePath(w.blockfileMgr.rootDir, entry.FileNum))
+		testutil.AssertEquals(t, os.IsNotExist(err), true)
+		_, err = os.Stat(deriveBlockfilePath(filepath.Join(archiveDir, archiveTestLedgerID), entry.FileNum))
+		testutil.AssertNoError(t, err, "")
+		if i > 0 {
+			testutil.AssertEquals(t, entry.FirstBlockNum, entries[i-1].LastBlockNum+1)
+			testutil.AssertEquals(t, entry.PreviousEntryHash, entries[i-1].EntryHash)
+		}
+		testutil.AssertEquals(t, entry.LastBlockHash, blocks[entry.LastBlockNum].Header.Hash())
+	}
+
+	// the manifest in the archive matches the one in the db
+	manifestBytes, err := ioutil.ReadFile(filepath.Join(archiveDir, archiveTestLedgerID, archiveManifestName))
+	testutil.AssertNoError(t, err, "")
+	manifest := &archiveManifest{}
+	testutil.AssertNoError(t, json.Unmarshal(manifestBytes, manifest), "")
+	testutil.AssertNoError(t, manifest.verify(), "")
+	testutil.AssertEquals(t, manifest.Entries, entries)
+
+	// the archived blocks are fetched transparently
+	w.testGetBlockByHash(blocks)
+	w.testGetBlockByNumber(blocks, 0)
+	tx, err := w.blockfileMgr.retrieveTransactionByBlockNumTranNum(1, 0)
+	testutil.AssertNoError(t, err, "")
+	txEnvelope, err := putil.GetEnvelopeFromBlock(blocks[1].Data.Data[0])
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, tx, txEnvelope)
+	itr, err := w.blockfileMgr.retrieveBlocks(0)
+	testutil.AssertNoError(t, err, "")
+	for i := 0; i < len(blocks); i++ {
+		block, err := itr.Next()
+		testutil.AssertNoError(t, err, "")
+		testutil.AssertEquals(t, block, blocks[i])
+	}
+	itr.Close()
+
+	// the cache holds no more than the configured number of fetched block files
+	cachedFiles, err := ioutil.ReadDir(w.blockfileMgr.archiver.cacheDir)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, len(cachedFiles) <= 2, true)
+	w.close()
+
+	// the archiving resumes after a restart
+	w = newTestBlockfileWrapper(env, archiveTestLedgerID)
+	defer w.close()
+	testutil.AssertEquals(t, w.blockfileMgr.archiver.manifestEntries(), entries)
+	w.addBlocks(moreBlocks)
+	waitForArchiving(t, w)
+	testutil.AssertEquals(t, len(w.blockfileMgr.archiver.manifestEntries()) > len(entries), true)
+	w.testGetBlockByNumber(allBlocks, 0)
+}
+
+func TestArchivedBlocksNotFetched(t *testing.T) {
+	archiveDir := testPath()
+	defer os.RemoveAll(archiveDir)
+	blocks := testutil.ConstructTestBlocks(t, 60)
+	archiveConf := &ArchiveConf{Target: NewLocalArchiveTarget(archiveDir), RetentionHeight: 20}
+	env := newArchiveTestEnv(t, blocks, archiveConf)
+	defer env.Cleanup()
+
+	w := newTestBlockfileWrapper(env, archiveTestLedgerID)
+	defer w.close()
+	w.addBlocks(blocks)
+	waitForArchiving(t, w)
+	testutil.AssertEquals(t, len(w.blockfileMgr.archiver.manifestEntries()) > 0, true)
+
+	_, err := w.blockfileMgr.retrieveBlockByNumber(0)
+	testutil.AssertEquals(t, err, blkstorage.ErrBlockArchived)
+	_, err = w.blockfileMgr.retrieveBlockByHash(blocks[1].Header.Hash())
+	testutil.AssertEquals(t, err, blkstorage.ErrBlockArchived)
+	_, err = w.blockfileMgr.retrieveBlocks(0)
+	testutil.AssertEquals(t, err, blkstorage.ErrBlockArchived)
+
+	// the blocks above the retention height are still available
+	block, err := w.blockfileMgr.retrieveBlockByNumber(59)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, block, blocks[59])
+	itr, err := w.blockfileMgr.retrieveBlocks(40)
+	testutil.AssertNoError(t, err, "")
+	defer itr.Close()
+	result, err := itr.Next()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, result, blocks[40])
+}
+
+func TestTamperedArchiveDetected(t *testing.T) {
+	archiveDir := testPath()
+	defer os.RemoveAll(archiveDir)
+	blocks := testutil.ConstructTestBlocks(t, 60)
+	archiveConf := &ArchiveConf{Target: NewLocalArchiveTarget(archiveDir), RetentionHeight: 20, FetchArchivedBlocks: true}
+	env := newArchiveTestEnv(t, blocks, archiveConf)
+	defer env.Cleanup()
+
+	w := newTestBlockfileWrapper(env, archiveTestLedgerID)
+	defer w.close()
+	w.addBlocks(blocks)
+	waitForArchiving(t, w)
+
+	archivedFilePath := deriveBlockfilePath(filepath.Join(archiveDir, archiveTestLedgerID), 0)
+	content, err := ioutil.ReadFile(archivedFilePath)
+	testutil.AssertNoError(t, err, "")
+	content[len(content)-1] ^= 0xff
+	testutil.AssertNoError(t, ioutil.WriteFile(archivedFilePath, content, 0660), "")
+
+	_, err = w.blockfileMgr.retrieveBlockByNumber(0)
+	testutil.AssertError(t, err, "Expected an error for a tampered archived block file")
+	testutil.AssertEquals(t, strings.Contains(err.Error(), "does not match the archive manifest"), true)
+}
+
+// oversizedArchiveTarget serves the objects of an archive target followed by endless zeros
+type oversizedArchiveTarget struct {
+	ArchiveTarget
+}
+
+func (t *oversizedArchiveTarget) Get(name string) (io.ReadCloser, error) {
+	content, err := t.ArchiveTarget.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(io.MultiReader(content, zeroReader{})), nil
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestOversizedArchiveDetected(t *testing.T) {
+	archiveDir := testPath()
+	defer os.RemoveAll(archiveDir)
+	blocks := testutil.ConstructTestBlocks(t, 60)
+	archiveConf := &ArchiveConf{Target: NewLocalArchiveTarget(archiveDir), RetentionHeight: 20, FetchArchivedBlocks: true}
+	env := newArchiveTestEnv(t, blocks, archiveConf)
+	defer env.Cleanup()
+
+	w := newTestBlockfileWrapper(env, archiveTestLedgerID)
+	defer w.close()
+	w.addBlocks(blocks)
+	waitForArchiving(t, w)
+
+	archiveConf.Target = &oversizedArchiveTarget{archiveConf.Target}
+	_, err := w.blockfileMgr.retrieveBlockByNumber(0)
+	testutil.AssertError(t, err, "Expected an error for an oversized archived block file")
+	testutil.AssertEquals(t, strings.Contains(err.Error(), "does not match the archive manifest"), true)
+	cachedFiles, err := ioutil.ReadDir(w.blockfileMgr.archiver.cacheDir)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, len(cachedFiles), 0)
+
+	// the local archive target doesn't store content past the size of the object
+	err = NewLocalArchiveTarget(archiveDir).Put("oversized", io.MultiReader(bytes.NewReader([]byte("content")), zeroReader{}), 7)
+	testutil.AssertError(t, err, "Expected an error for content past the size of the object")
+	_, err = os.Stat(filepath.Join(archiveDir, "oversized"))
+	testutil.AssertEquals(t, os.IsNotExist(err), true)
+}
+
+// blockingArchiveTarget counts the downloads of the objects of an archive target,
+// and blocks the downloads of the first block file until it is released
+type blockingArchiveTarget struct {
+	ArchiveTarget
+	lock    sync.Mutex
+	gets    map[string]int
+	release chan struct{}
+}
+
+func (t *blockingArchiveTarget) Get(name string) (io.ReadCloser, error) {
+	t.lock.Lock()
+	t.gets[name]++
+	t.lock.Unlock()
+	if strings.HasSuffix(name, deriveBlockfilePath("", 0)) {
+		<-t.release
+	}
+	return t.ArchiveTarget.Get(name)
+}
+
+func (t *blockingArchiveTarget) getCount(name string) int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.gets[name]
+}
+
+func TestConcurrentFetchOfArchivedBlocks(t *testing.T) {
+	archiveDir := testPath()
+	defer os.RemoveAll(archiveDir)
+	blocks := testutil.ConstructTestBlocks(t, 60)
+	target := &blockingArchiveTarget{ArchiveTarget: NewLocalArchiveTarget(archiveDir), gets: map[string]int{}, release: make(chan struct{})}
+	archiveConf := &ArchiveConf{Target: target, RetentionHeight: 20, FetchArchivedBlocks: true}
+	env := newArchiveTestEnv(t, blocks, archiveConf)
+	defer env.Cleanup()
+
+	w := newTestBlockfileWrapper(env, archiveTestLedgerID)
+	defer w.close()
+	w.addBlocks(blocks)
+	waitForArchiving(t, w)
+	entries := w.blockfileMgr.archiver.manifestEntries()
+	testutil.AssertEquals(t, len(entries) > 1, true)
+
+	// two readers of the first block file wait for its download
+	firstObjectName := w.blockfileMgr.archiver.objectName(filepath.Base(deriveBlockfilePath("", 0)))
+	var readers sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			block, err := w.blockfileMgr.retrieveBlockByNumber(0)
+			testutil.AssertNoError(t, err, "")
+			testutil.AssertEquals(t, block, blocks[0])
+		}()
+	}
+	for target.getCount(firstObjectName) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the blocks of another archived block file are fetched meanwhile
+	fetched := make(chan struct{})
+	go func() {
+		block, err := w.blockfileMgr.retrieveBlockByNumber(entries[1].FirstBlockNum)
+		testutil.AssertNoError(t, err, "")
+		testutil.AssertEquals(t, block, blocks[entries[1].FirstBlockNum])
+		close(fetched)
+	}()
+	select {
+	case <-fetched:
+	case <-time.After(10 * time.Second):
+		t.Fatal("The download of a block file blocked the fetching of another one")
+	}
+
+	close(target.release)
+	readers.Wait()
+	testutil.AssertEquals(t, target.getCount(firstObjectName), 1)
+}
+
+func TestArchiveManifestVerification(t *testing.T) {
+	manifest := &archiveManifest{}
+	var previousEntryHash []byte
+	for i := 0; i < 3; i++ {
+		entry := &archiveManifestEntry{FileNum: i, FirstBlockNum: uint64(10 * i), LastBlockNum: uint64(10*i + 9),
+			FileHash: []byte{byte(i)}, PreviousEntryHash: previousEntryHash}
+		entry.EntryHash = entry.computeHash()
+		previousEntryHash = entry.EntryHash
+		manifest.Entries = append(manifest.Entries, entry)
+	}
+	testutil.AssertNoError(t, manifest.verify(), "")
+
+	manifest.Entries[1].LastBlockNum++
+	testutil.AssertEquals(t, manifest.verify().Error(), "entry [1] of the archive manifest breaks the hash chain")
+	manifest.Entries[1].LastBlockNum--
+	manifest.Entries = manifest.Entries[1:]
+	testutil.AssertEquals(t, manifest.verify().Error(), "entry [0] of the archive manifest is for block file [1]")
+}
+
+func TestS3ArchiveTarget(t *testing.T) {
+	var lock sync.Mutex
+	objects := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=keyID/") ||
+			r.Header.Get("x-amz-content-sha256") != "UNSIGNED-PAYLOAD" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			content, _ := ioutil.ReadAll(r.Body)
+			objects[r.URL.Path] = content
+		case http.MethodGet:
+			content, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte("NoSuchKey"))
+				return
+			}
+			w.Write(content)
+		}
+	}))
+	defer server.Close()
+
+	target := NewS3ArchiveTarget(S3Conf{Endpoint: server.URL, Bucket: "blocks", Region: "us-east-1", AccessKeyID: "keyID", SecretAccessKey: "secret"})
+	content := []byte("block file content")
+	testutil.AssertNoError(t, target.Put("ledger/blockfile_000000", bytes.NewReader(content), int64(len(content))), "")
+	testutil.AssertEquals(t, string(objects["/blocks/ledger/blockfile_000000"]), string(content))
+
+	reader, err := target.Get("ledger/blockfile_000000")
+	testutil.AssertNoError(t, err, "")
+	fetched, err := ioutil.ReadAll(reader)
+	reader.Close()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, string(fetched), string(content))
+
+	_, err = target.Get("ledger/blockfile_000001")
+	testutil.AssertError(t, err, "Expected an error for a missing object")
+	testutil.AssertEquals(t, strings.Contains(err.Error(), "NoSuchKey"), true)
+
+	// a request to an unresponsive object storage times out
+	unresponsive := make(chan struct{})
+	unresponsiveServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unresponsive
+	}))
+	defer unresponsiveServer.Close()
+	defer close(unresponsive)
+	target = NewS3ArchiveTarget(S3Conf{Endpoint: unresponsiveServer.URL, Bucket: "blocks", Region: "us-east-1",
+		AccessKeyID: "keyID", SecretAccessKey: "secret", Timeout: 100 * time.Millisecond})
+	_, err = target.Get("ledger/blockfile_000000")
+	testutil.AssertError(t, err, "Expected an error for an unresponsive object storage")
+}
@@ -0,0 +1,209 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+)
+
+const (
+	// compactionDir is the directory, under the block storage directory, where the block files
+	// of a ledger are rewritten, so that the rewritten files are never mistaken for a ledger
+	compactionDir          = "compaction"
+	compactedBlockfilesDir = "compacted"
+	originalBlockfilesDir  = "original"
+)
+
+// compactionKey marks a compaction whose rewritten block files are complete but may not have
+// replaced the original block files yet. Its value is the checkpoint of the rewritten block files
+var compactionKey = []byte("blockfileCompaction")
+
+// CompactBlockfiles rewrites the block files of the given ledger, compressing all their blocks with the
+// compression of the `Conf`, and packing them in block files of the maximum size of the `Conf`.
+// It can also decompress the blocks of a ledger, when the `Conf` has no compression.
+// The block store of the ledger must not be open. The index of the block files is rebuilt when the
+// block store is opened next. An interrupted compaction is either discarded or completed, when this
+// function is invoked again or when the block store is opened
+func CompactBlockfiles(conf *Conf, ledgerid string) error {
+	rootDir := conf.getLedgerBlockDir(ledgerid)
+	exists, _, err := util.FileExists(rootDir)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("Block store of ledger [%s] does not exist", ledgerid)
+	}
+	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
+	defer p.Close()
+	db := p.GetDBHandle(ledgerid)
+	if err = recoverCompaction(conf, ledgerid, db); err != nil {
+		return err
+	}
+	manifestBytes, err := db.Get(archiveManifestKey)
+	if err != nil {
+		return err
+	}
+	if manifestBytes != nil {
+		return fmt.Errorf("Cannot compact the block files of ledger [%s], some of which are archived", ledgerid)
+	}
+
+	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: db}
+	cpInfo, err := mgr.loadCurrentInfo()
+	if err != nil {
+		return err
+	}
+	if cpInfo == nil || cpInfo.isChainEmpty {
+		logger.Infof("No blocks to compact in ledger [%s]", ledgerid)
+		return nil
+	}
+	if mgr.bootstrapInfo, err = mgr.loadBootstrapInfo(); err != nil {
+		return err
+	}
+	syncCPInfoFromFS(rootDir, cpInfo, mgr.firstBlockNumber())
+
+	compactedDir := filepath.Join(conf.blockStorageDir, compactionDir, ledgerid, compactedBlockfilesDir)
+	if err = os.RemoveAll(compactedDir); err != nil {
+		return err
+	}
+	if _, err = util.CreateDirIfMissing(compactedDir); err != nil {
+		return err
+	}
+	compactedCPInfo, err := rewriteBlockfiles(conf, rootDir, compactedDir, cpInfo)
+	if err != nil {
+		return err
+	}
+	compactedCPInfoBytes, err := compactedCPInfo.marshal()
+	if err != nil {
+		return err
+	}
+	if err = db.Put(compactionKey, compactedCPInfoBytes, true); err != nil {
+		return err
+	}
+	return completeCompaction(conf, ledgerid, db, compactedCPInfo)
+}
+
+// rewriteBlockfiles writes the blocks of the block files in rootDir, up to the last block of the given
+// checkpoint, to block files in compactedDir, and returns the checkpoint of the rewritten block files
+func rewriteBlockfiles(conf *Conf, rootDir, compactedDir string, cpInfo *checkpointInfo) (*checkpointInfo, error) {
+	stream, err := newBlockStream(localBlockfileOpener(rootDir), 0, 0, cpInfo.latestFileChunkSuffixNum)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.close()
+
+	compactedCPInfo := &checkpointInfo{lastBlockNumber: cpInfo.lastBlockNumber}
+	writer, err := newBlockfileWriter(deriveBlockfilePath(compactedDir, 0))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { writer.close() }()
+	for {
+		blockBytes, err := stream.nextBlockBytes()
+		if err != nil {
+			return nil, err
+		}
+		if blockBytes == nil {
+			return nil, fmt.Errorf("Block [%d] is missing from the block files", cpInfo.lastBlockNumber)
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return nil, err
+		}
+		blockRecordHeader, recordedBytes, _ := encodeBlockRecord(blockBytes, conf.compression)
+		recordSize := len(blockRecordHeader) + len(recordedBytes)
+		if compactedCPInfo.latestFileChunksize > 0 && compactedCPInfo.latestFileChunksize+recordSize > conf.maxBlockfileSize {
+			if err = writer.file.Sync(); err != nil {
+				return nil, err
+			}
+			writer.close()
+			compactedCPInfo.latestFileChunkSuffixNum++
+			compactedCPInfo.latestFileChunksize = 0
+			if writer, err = newBlockfileWriter(deriveBlockfilePath(compactedDir, compactedCPInfo.latestFileChunkSuffixNum)); err != nil {
+				return nil, err
+			}
+		}
+		if err = writer.append(blockRecordHeader, false); err != nil {
+			return nil, err
+		}
+		if err = writer.append(recordedBytes, false); err != nil {
+			return nil, err
+		}
+		compactedCPInfo.latestFileChunksize += recordSize
+		if info.blockHeader.Number == cpInfo.lastBlockNumber {
+			// the blocks past the checkpoint, if any, were partially written and are discarded
+			return compactedCPInfo, writer.file.Sync()
+		}
+	}
+}
+
+// recoverCompaction completes a compaction of the block files of the ledger whose rewritten
+// block files are complete, and otherwise discards the rewritten block files
+func recoverCompaction(conf *Conf, ledgerid string, db *leveldbhelper.DBHandle) error {
+	compactedCPInfoBytes, err := db.Get(compactionKey)
+	if err != nil {
+		return err
+	}
+	if compactedCPInfoBytes == nil {
+		return os.RemoveAll(filepath.Join(conf.blockStorageDir, compactionDir, ledgerid))
+	}
+	compactedCPInfo := &checkpointInfo{}
+	if err = compactedCPInfo.unmarshal(compactedCPInfoBytes); err != nil {
+		return err
+	}
+	logger.Infof("Completing the interrupted compaction of the block files of ledger [%s]", ledgerid)
+	return completeCompaction(conf, ledgerid, db, compactedCPInfo)
+}
+
+// completeCompaction replaces the block files of the ledger with the rewritten ones, and resets the index
+// of the block files, which is rebuilt by syncIndex. Each step can be repeated if the compaction is interrupted
+func completeCompaction(conf *Conf, ledgerid string, db *leveldbhelper.DBHandle, compactedCPInfo *checkpointInfo) error {
+	rootDir := conf.getLedgerBlockDir(ledgerid)
+	ledgerCompactionDir := filepath.Join(conf.blockStorageDir, compactionDir, ledgerid)
+	compactedDir := filepath.Join(ledgerCompactionDir, compactedBlockfilesDir)
+	originalDir := filepath.Join(ledgerCompactionDir, originalBlockfilesDir)
+
+	compactedDirExists, _, err := util.FileExists(compactedDir)
+	if err != nil {
+		return err
+	}
+	if compactedDirExists {
+		originalDirExists, _, err := util.FileExists(originalDir)
+		if err != nil {
+			return err
+		}
+		if !originalDirExists {
+			if err = os.Rename(rootDir, originalDir); err != nil {
+				return err
+			}
+		}
+		if err = os.Rename(compactedDir, rootDir); err != nil {
+			return err
+		}
+	}
+
+	compactedCPInfoBytes, err := compactedCPInfo.marshal()
+	if err != nil {
+		return err
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Put(blkMgrInfoKey, compactedCPInfoBytes)
+	batch.Delete(indexCheckpointKey)
+	batch.Delete(compactionKey)
+	if err = db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	if err = os.RemoveAll(ledgerCompactionDir); err != nil {
+		return err
+	}
+	logger.Infof("Compacted the block files of ledger [%s] to [%d] block files", ledgerid, compactedCPInfo.latestFileChunkSuffixNum+1)
+	return nil
+}
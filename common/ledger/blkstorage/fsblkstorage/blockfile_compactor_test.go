@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+func TestCompactBlockfiles(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 50)
+	conf := NewConf(testPath(), blockfileSizeFor(t, blocks[:10]))
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	uncompressedSize := blockfilesSize(t, blkfileMgrWrapper.blockfileMgr.rootDir)
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	compactionConf := NewConf(conf.blockStorageDir, conf.maxBlockfileSize).WithCompression(SnappyCompression)
+	testutil.AssertNoError(t, CompactBlockfiles(compactionConf, "testLedger"), "")
+	testutil.AssertNoError(t, CompactBlockfiles(compactionConf, "testLedger"), "")
+	err := CompactBlockfiles(compactionConf, "missingLedger")
+	testutil.AssertEquals(t, err.Error(), "Block store of ledger [missingLedger] does not exist")
+
+	env = newTestEnv(t, compactionConf)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	compressedSize := blockfilesSize(t, blkfileMgrWrapper.blockfileMgr.rootDir)
+	testutil.AssertEquals(t, compressedSize < uncompressedSize, true)
+	blkfileMgrWrapper.testGetBlockByHash(blocks)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0)
+	testGetTransactions(t, blkfileMgrWrapper, blocks)
+	testRetrieveBlocks(t, blkfileMgrWrapper, blocks)
+	moreBlocks := testutil.ConstructTestBlocks(t, 51)[50:]
+	moreBlocks[0].Header.PreviousHash = blocks[49].Header.Hash()
+	blkfileMgrWrapper.addBlocks(moreBlocks)
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// the blocks can be decompressed back
+	testutil.AssertNoError(t, CompactBlockfiles(conf, "testLedger"), "")
+	env = newTestEnv(t, conf)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.testGetBlockByNumber(append(blocks, moreBlocks...), 0)
+	testGetTransactions(t, blkfileMgrWrapper, blocks)
+}
+
+func TestCompactBlockfilesRecovery(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	conf := NewConf(testPath(), blockfileSizeFor(t, blocks[:10])).WithCompression(SnappyCompression)
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	rootDir := blkfileMgrWrapper.blockfileMgr.rootDir
+	cpInfo := blkfileMgrWrapper.blockfileMgr.cpInfo
+	blkfileMgrWrapper.close()
+
+	// the compaction is interrupted once the rewritten block files are complete,
+	// and after the original block files were moved aside
+	compactionDir := conf.blockStorageDir + "/" + compactionDir + "/testLedger"
+	testutil.AssertNoError(t, os.MkdirAll(compactionDir+"/"+compactedBlockfilesDir, 0755), "")
+	compactedCPInfo, err := rewriteBlockfiles(conf, rootDir, compactionDir+"/"+compactedBlockfilesDir, cpInfo)
+	testutil.AssertNoError(t, err, "")
+	compactedCPInfoBytes, err := compactedCPInfo.marshal()
+	testutil.AssertNoError(t, err, "")
+	db := env.provider.leveldbProvider.GetDBHandle("testLedger")
+	testutil.AssertNoError(t, db.Put(compactionKey, compactedCPInfoBytes, true), "")
+	testutil.AssertNoError(t, os.Rename(rootDir, compactionDir+"/"+originalBlockfilesDir), "")
+
+	// the compaction is completed when the block store is opened
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	testutil.AssertEquals(t, blkfileMgrWrapper.blockfileMgr.cpInfo, compactedCPInfo)
+	blkfileMgrWrapper.testGetBlockByHash(blocks)
+	testGetTransactions(t, blkfileMgrWrapper, blocks)
+	_, err = os.Stat(compactionDir)
+	testutil.AssertEquals(t, os.IsNotExist(err), true)
+}
+
+// blockfileSizeFor returns the size of the block files that the given blocks fill
+func blockfileSizeFor(t *testing.T, blocks []*common.Block) int {
+	size := 0
+	for _, block := range blocks {
+		by, _, err := serializeBlock(block)
+		testutil.AssertNoError(t, err, "Error while serializing block")
+		size += len(by) + len(proto.EncodeVarint(uint64(len(by))))
+	}
+	return size
+}
+
+func blockfilesSize(t *testing.T, rootDir string) int64 {
+	fileInfos, err := ioutil.ReadDir(rootDir)
+	testutil.AssertNoError(t, err, "")
+	size := int64(0)
+	for _, fileInfo := range fileInfos {
+		size += fileInfo.Size()
+	}
+	return size
+}
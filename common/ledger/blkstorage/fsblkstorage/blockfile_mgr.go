@@ -19,6 +19,7 @@ package fsblkstorage
 import (
 	"fmt"
 	"math"
+	"os"
 	"sync"
 	"sync/atomic"
 
@@ -39,7 +40,8 @@ const (
 )
 
 var (
-	blkMgrInfoKey = []byte("blkMgrInfo")
+	blkMgrInfoKey    = []byte("blkMgrInfo")
+	bootstrapInfoKey = []byte("bootstrapInfo")
 )
 
 type blockfileMgr struct {
@@ -51,6 +53,10 @@ type blockfileMgr struct {
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
 	bcInfo            atomic.Value
+	// bootstrapInfo is nil unless the ledger was bootstrapped from a snapshot
+	bootstrapInfo *bootstrapInfo
+	// archiver is nil unless the block files of the ledger are archived
+	archiver *blockfileArchiver
 }
 
 /*
@@ -96,6 +102,10 @@ At start up a new manager:
 */
 func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig, indexStore *leveldbhelper.DBHandle) *blockfileMgr {
 	logger.Debugf("newBlockfileMgr() initializing file-based block storage for ledger: %s ", id)
+	// Complete or discard an interrupted compaction of the block files, before the directory of the block files is looked at
+	if err := recoverCompaction(conf, id, indexStore); err != nil {
+		panic(fmt.Sprintf("Could not recover the compaction of the block files: %s", err))
+	}
 	//Determine the root directory for the blockfile storage, if it does not exist create it
 	rootDir := conf.getLedgerBlockDir(id)
 	_, err := util.CreateDirIfMissing(rootDir)
@@ -119,9 +129,16 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 			panic(fmt.Sprintf("Could not save next block file info to db: %s", err))
 		}
 	}
+	// bsInfo = bootstrapInfo, it is present only if the ledger was bootstrapped from a snapshot,
+	// in which case the first block in the block files is not the genesis block
+	bsInfo, err := mgr.loadBootstrapInfo()
+	if err != nil {
+		panic(fmt.Sprintf("Could not get bootstrap info from db: %s", err))
+	}
+	mgr.bootstrapInfo = bsInfo
 	//Verify that the checkpoint stored in db is accurate with what is actually stored in block file system
 	// If not the same, sync the cpInfo and the file system
-	syncCPInfoFromFS(rootDir, cpInfo)
+	syncCPInfoFromFS(rootDir, cpInfo, mgr.firstBlockNumber())
 	//Open a writer to the file identified by the number and truncate it to only contain the latest block
 	// that was completely saved (file system, index, cpinfo, etc)
 	currentFileWriter, err := newBlockfileWriter(deriveBlockfilePath(rootDir, cpInfo.latestFileChunkSuffixNum))
@@ -144,39 +161,108 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	// or announcing the occurrence of an event.
 	mgr.cpInfoCond = sync.NewCond(&sync.Mutex{})
 
+	// Load the manifest of the archived block files, if the block files of the ledger are archived,
+	// so that the archived block files can be read while syncing the index
+	if archiveConf := conf.getArchiveConf(id); archiveConf != nil {
+		if mgr.archiver, err = newBlockfileArchiver(mgr, id, archiveConf); err != nil {
+			panic(fmt.Sprintf("Could not initialize the archiving of the block files: %s", err))
+		}
+	}
+
 	// Verify that the index stored in db is accurate with what is actually stored in block file system
 	// If not the same, sync the index and the file system
 	mgr.syncIndex()
 
 	// init BlockchainInfo for external API's
-	bcInfo := &common.BlockchainInfo{
-		Height:            0,
-		CurrentBlockHash:  nil,
-		PreviousBlockHash: nil}
+	bcInfo, err := mgr.loadBlockchainInfo()
+	if err != nil {
+		panic(fmt.Sprintf("Could not retrieve header of the last block form file: %s", err))
+	}
+	mgr.bcInfo.Store(bcInfo)
+	// Archive the block files which became archivable while the ledger was not running
+	if mgr.archiver != nil {
+		mgr.archiver.archiveInBackground()
+	}
+	//return the new manager (blockfileMgr)
+	return mgr
+}
 
-	//If start up is a restart of an existing storage, update BlockchainInfo for external API's
+// newReadOnlyBlockfileMgr creates a manager of the existing block files of a ledger, which modifies neither the
+// block files nor the index, so that the ledger of a stopped peer can be inspected. Unlike newBlockfileMgr, it does
+// not sync the index with the block files. The blocks past the last indexed block, which are left by a crash of
+// the peer, are therefore not visible until the block store is opened for writing. The archived blocks are not fetched
+func newReadOnlyBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig, indexStore *leveldbhelper.DBHandle) (*blockfileMgr, error) {
+	logger.Debugf("newReadOnlyBlockfileMgr() opening file-based block storage for ledger: %s ", id)
+	rootDir := conf.getLedgerBlockDir(id)
+	exists, _, err := util.FileExists(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("Block store of ledger [%s] does not exist", id)
+	}
+	compactionMarker, err := indexStore.Get(compactionKey)
+	if err != nil {
+		return nil, err
+	}
+	if compactionMarker != nil {
+		return nil, fmt.Errorf("The compaction of the block files of ledger [%s] was interrupted, "+
+			"it is completed when the block store is opened for writing", id)
+	}
+	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore}
+	cpInfo, err := mgr.loadCurrentInfo()
+	if err != nil {
+		return nil, err
+	}
+	if cpInfo == nil {
+		cpInfo = &checkpointInfo{0, 0, true, 0}
+	}
+	if mgr.bootstrapInfo, err = mgr.loadBootstrapInfo(); err != nil {
+		return nil, err
+	}
+	syncCPInfoFromFS(rootDir, cpInfo, mgr.firstBlockNumber())
+	mgr.index = newBlockIndex(indexConfig, indexStore)
 	if !cpInfo.isChainEmpty {
-		lastBlockHeader, err := mgr.retrieveBlockHeaderByNumber(cpInfo.lastBlockNumber)
+		lastBlockIndexed, err := mgr.index.getLastBlockIndexed()
+		if err == errIndexEmpty {
+			return nil, fmt.Errorf("The index of the block files of ledger [%s] is empty, "+
+				"it is rebuilt when the block store is opened for writing", id)
+		}
 		if err != nil {
-			panic(fmt.Sprintf("Could not retrieve header of the last block form file: %s", err))
+			return nil, err
 		}
-		lastBlockHash := lastBlockHeader.Hash()
-		previousBlockHash := lastBlockHeader.PreviousHash
-		bcInfo = &common.BlockchainInfo{
-			Height:            cpInfo.lastBlockNumber + 1,
-			CurrentBlockHash:  lastBlockHash,
-			PreviousBlockHash: previousBlockHash}
+		if lastBlockIndexed < cpInfo.lastBlockNumber {
+			logger.Warningf("Blocks [%d] to [%d] of ledger [%s] are not indexed yet and are not visible",
+				lastBlockIndexed+1, cpInfo.lastBlockNumber, id)
+			cpInfo.lastBlockNumber = lastBlockIndexed
+		}
+	}
+	mgr.cpInfo = cpInfo
+	mgr.cpInfoCond = sync.NewCond(&sync.Mutex{})
+
+	// The archiver only tells which blocks are archived, it neither archives nor fetches block files
+	archiver := &blockfileArchiver{mgr: mgr, ledgerID: id, conf: &ArchiveConf{},
+		manifest: &archiveManifest{Version: archiveManifestVersion, LedgerID: id}}
+	if err = archiver.loadManifest(); err != nil {
+		return nil, err
+	}
+	if len(archiver.manifest.Entries) > 0 {
+		mgr.archiver = archiver
+	}
+
+	bcInfo, err := mgr.loadBlockchainInfo()
+	if err != nil {
+		return nil, err
 	}
 	mgr.bcInfo.Store(bcInfo)
-	//return the new manager (blockfileMgr)
-	return mgr
+	return mgr, nil
 }
 
 //cp = checkpointInfo, from the database gets the file suffix and the size of
 // the file of where the last block was written.  Also retrieves contains the
 // last block number that was written.  At init
 //checkpointInfo:latestFileChunkSuffixNum=[0], latestFileChunksize=[0], lastBlockNumber=[0]
-func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
+func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo, firstBlockNumber uint64) {
 	logger.Debugf("Starting checkpoint=%s", cpInfo)
 	//Checks if the file suffix of where the last block was written exists
 	filePath := deriveBlockfilePath(rootDir, cpInfo.latestFileChunkSuffixNum)
@@ -204,7 +290,7 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 	}
 	//Updates the checkpoint info for the actual last block number stored and it's end location
 	if cpInfo.isChainEmpty {
-		cpInfo.lastBlockNumber = uint64(numBlocks - 1)
+		cpInfo.lastBlockNumber = firstBlockNumber + uint64(numBlocks-1)
 	} else {
 		cpInfo.lastBlockNumber += uint64(numBlocks)
 	}
@@ -217,7 +303,22 @@ func deriveBlockfilePath(rootDir string, suffixNum int) string {
 }
 
 func (mgr *blockfileMgr) close() {
-	mgr.currentFileWriter.close()
+	if mgr.archiver != nil {
+		mgr.archiver.close()
+	}
+	// a read-only manager has no writer
+	if mgr.currentFileWriter != nil {
+		mgr.currentFileWriter.close()
+	}
+}
+
+// openBlockfile implements blockfileOpener for the block files of the ledger,
+// including the archived ones
+func (mgr *blockfileMgr) openBlockfile(fileNum int) (*os.File, error) {
+	if mgr.archiver != nil {
+		return mgr.archiver.openBlockfile(fileNum)
+	}
+	return localBlockfileOpener(mgr.rootDir)(fileNum)
 }
 
 func (mgr *blockfileMgr) moveToNextFile() {
@@ -256,21 +357,23 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	if err != nil {
 		return fmt.Errorf("Error while serializing block: %s", err)
 	}
-	blockBytesLen := len(blockBytes)
-	blockBytesEncodedLen := proto.EncodeVarint(uint64(blockBytesLen))
-	totalBytesToAppend := blockBytesLen + len(blockBytesEncodedLen)
+	//The record header encodes the length of the recorded bytes and their compression, if any
+	blockRecordHeader, recordedBytes, compressed := encodeBlockRecord(blockBytes, mgr.conf.compression)
+	totalBytesToAppend := len(recordedBytes) + len(blockRecordHeader)
 
 	//Determine if we need to start a new file since the size of this block
 	//exceeds the amount of space left in the current file
+	fileCompleted := false
 	if currentOffset+totalBytesToAppend > mgr.conf.maxBlockfileSize {
 		mgr.moveToNextFile()
 		currentOffset = 0
+		fileCompleted = true
 	}
-	//append blockBytesEncodedLen to the file
-	err = mgr.currentFileWriter.append(blockBytesEncodedLen, false)
+	//append blockRecordHeader to the file
+	err = mgr.currentFileWriter.append(blockRecordHeader, false)
 	if err == nil {
 		//append the actual block bytes to the file
-		err = mgr.currentFileWriter.append(blockBytes, true)
+		err = mgr.currentFileWriter.append(recordedBytes, true)
 	}
 	if err != nil {
 		truncateErr := mgr.currentFileWriter.truncateFile(mgr.cpInfo.latestFileChunksize)
@@ -299,21 +402,62 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	//Index block file location pointer updated with file suffex and offset for the new block
 	blockFLP := &fileLocPointer{fileSuffixNum: newCPInfo.latestFileChunkSuffixNum}
 	blockFLP.offset = currentOffset
-	// shift the txoffset because we prepend length of bytes before block bytes
-	for _, txOffset := range txOffsets {
-		txOffset.loc.offset += len(blockBytesEncodedLen)
+	// shift the txoffset because we prepend length of bytes before block bytes,
+	// unless the block is compressed, in which case the txoffsets stay relative to the block bytes
+	if !compressed {
+		for _, txOffset := range txOffsets {
+			txOffset.loc.offset += len(blockRecordHeader)
+		}
 	}
 	//save the index in the database
 	mgr.index.indexBlock(&blockIdxInfo{
 		blockNum: block.Header.Number, blockHash: blockHash,
-		flp: blockFLP, txOffsets: txOffsets, metadata: block.Metadata})
+		flp: blockFLP, txOffsets: txOffsets, metadata: block.Metadata, compressed: compressed})
 
 	//update the checkpoint info (for storage) and the blockchain info (for APIs) in the manager
 	mgr.updateCheckpoint(newCPInfo)
 	mgr.updateBlockchainInfo(blockHash, block)
+	if mgr.archiver != nil {
+		mgr.archiver.blockAdded(block.Header.Number+1, fileCompleted)
+	}
 	return nil
 }
 
+// bootstrapFromSnapshot adds the last block of a ledger snapshot as the first block
+// of an empty block store. The config block of the snapshot is kept aside, such
+// that it can still be retrieved by number even though the blocks preceding the
+// snapshot are not available
+func (mgr *blockfileMgr) bootstrapFromSnapshot(lastBlock *common.Block, configBlock *common.Block) error {
+	if !mgr.cpInfo.isChainEmpty {
+		return fmt.Errorf("Cannot bootstrap a block store which already contains blocks")
+	}
+	if configBlock.Header.Number > lastBlock.Header.Number {
+		return fmt.Errorf("Config block [%d] of the snapshot is after its last block [%d]",
+			configBlock.Header.Number, lastBlock.Header.Number)
+	}
+	configBlockBytes, err := proto.Marshal(configBlock)
+	if err != nil {
+		return fmt.Errorf("Error while marshaling config block: %s", err)
+	}
+	bsInfo := &bootstrapInfo{firstBlockNumber: lastBlock.Header.Number, configBlockBytes: configBlockBytes}
+	// The bootstrap info is persisted before the block is appended, such that a crash
+	// in between is recovered by syncCPInfoFromFS using the right first block number
+	if err = mgr.saveBootstrapInfo(bsInfo); err != nil {
+		return fmt.Errorf("Error while saving bootstrap info to db: %s", err)
+	}
+	mgr.bootstrapInfo = bsInfo
+	mgr.bcInfo.Store(&common.BlockchainInfo{Height: lastBlock.Header.Number})
+	return mgr.addBlock(lastBlock)
+}
+
+// firstBlockNumber returns the number of the first block in the block files
+func (mgr *blockfileMgr) firstBlockNumber() uint64 {
+	if mgr.bootstrapInfo == nil {
+		return 0
+	}
+	return mgr.bootstrapInfo.firstBlockNumber
+}
+
 func (mgr *blockfileMgr) syncIndex() error {
 	var lastBlockIndexed uint64
 	var indexEmpty bool
@@ -346,7 +490,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 
 	//open a blockstream to the file location that was stored in the index
 	var stream *blockStream
-	if stream, err = newBlockStream(mgr.rootDir, startFileNum, int64(startOffset), endFileNum); err != nil {
+	if stream, err = newBlockStream(mgr.openBlockfile, startFileNum, int64(startOffset), endFileNum); err != nil {
 		return err
 	}
 	var blockBytes []byte
@@ -378,10 +522,13 @@ func (mgr *blockfileMgr) syncIndex() error {
 		}
 
 		//The blockStartOffset will get applied to the txOffsets prior to indexing within indexBlock(),
-		//therefore just shift by the difference between blockBytesOffset and blockStartOffset
-		numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
-		for _, offset := range info.txOffsets {
-			offset.loc.offset += numBytesToShift
+		//therefore just shift by the difference between blockBytesOffset and blockStartOffset.
+		//The txOffsets of a compressed block stay relative to the block bytes
+		if !blockPlacementInfo.compressed {
+			numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
+			for _, offset := range info.txOffsets {
+				offset.loc.offset += numBytesToShift
+			}
 		}
 
 		//Update the blockIndexInfo with what was actually stored in file system
@@ -392,6 +539,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 			locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}}
 		blockIdxInfo.txOffsets = info.txOffsets
 		blockIdxInfo.metadata = info.metadata
+		blockIdxInfo.compressed = blockPlacementInfo.compressed
 
 		logger.Debugf("syncIndex() indexing block [%d]", blockIdxInfo.blockNum)
 		if err = mgr.index.indexBlock(blockIdxInfo); err != nil {
@@ -402,6 +550,23 @@ func (mgr *blockfileMgr) syncIndex() error {
 	return nil
 }
 
+// loadBlockchainInfo returns the BlockchainInfo of the blocks up to the checkpoint
+func (mgr *blockfileMgr) loadBlockchainInfo() (*common.BlockchainInfo, error) {
+	if mgr.cpInfo.isChainEmpty {
+		return &common.BlockchainInfo{Height: 0, CurrentBlockHash: nil, PreviousBlockHash: nil}, nil
+	}
+	// The whole block is retrieved, as the commit hash is part of its metadata
+	lastBlock, err := mgr.retrieveBlockByNumber(mgr.cpInfo.lastBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &common.BlockchainInfo{
+		Height:            mgr.cpInfo.lastBlockNumber + 1,
+		CurrentBlockHash:  lastBlock.Header.Hash(),
+		PreviousBlockHash: lastBlock.Header.PreviousHash,
+		CommitHash:        putil.GetCommitHashFromBlock(lastBlock)}, nil
+}
+
 func (mgr *blockfileMgr) getBlockchainInfo() *common.BlockchainInfo {
 	return mgr.bcInfo.Load().(*common.BlockchainInfo)
 }
@@ -419,7 +584,8 @@ func (mgr *blockfileMgr) updateBlockchainInfo(latestBlockHash []byte, latestBloc
 	newBCInfo := &common.BlockchainInfo{
 		Height:            currentBCInfo.Height + 1,
 		CurrentBlockHash:  latestBlockHash,
-		PreviousBlockHash: latestBlock.Header.PreviousHash}
+		PreviousBlockHash: latestBlock.Header.PreviousHash,
+		CommitHash:        putil.GetCommitHashFromBlock(latestBlock)}
 
 	mgr.bcInfo.Store(newBCInfo)
 }
@@ -441,6 +607,10 @@ func (mgr *blockfileMgr) retrieveBlockByNumber(blockNum uint64) (*common.Block,
 		blockNum = mgr.getBlockchainInfo().Height - 1
 	}
 
+	if blockNum < mgr.firstBlockNumber() {
+		return mgr.retrieveBootstrapConfigBlock(blockNum)
+	}
+
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
 		return nil, err
@@ -459,29 +629,33 @@ func (mgr *blockfileMgr) retrieveBlockByTxID(txID string) (*common.Block, error)
 	return mgr.fetchBlock(loc)
 }
 
+// retrieveBootstrapConfigBlock returns the config block of the snapshot
+// the ledger was bootstrapped from, which is the only block available
+// prior to the first block in the block files
+func (mgr *blockfileMgr) retrieveBootstrapConfigBlock(blockNum uint64) (*common.Block, error) {
+	block := &common.Block{}
+	if err := proto.Unmarshal(mgr.bootstrapInfo.configBlockBytes, block); err != nil {
+		return nil, err
+	}
+	if block.Header.Number != blockNum {
+		return nil, blkstorage.ErrNotFoundInIndex
+	}
+	return block, nil
+}
+
 func (mgr *blockfileMgr) retrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error) {
 	logger.Debugf("retrieveTxValidationCodeByTxID() - txID = [%s]", txID)
 	return mgr.index.getTxValidationCodeByTxID(txID)
 }
 
-func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.BlockHeader, error) {
-	logger.Debugf("retrieveBlockHeaderByNumber() - blockNum = [%d]", blockNum)
-	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
-	if err != nil {
-		return nil, err
-	}
-	blockBytes, err := mgr.fetchBlockBytes(loc)
-	if err != nil {
-		return nil, err
+func (mgr *blockfileMgr) retrieveBlocks(startNum uint64) (*blocksItr, error) {
+	if startNum < mgr.firstBlockNumber() {
+		return nil, fmt.Errorf("Blocks prior to [%d] are not available, the ledger was bootstrapped from a snapshot",
+			mgr.firstBlockNumber())
 	}
-	info, err := extractSerializedBlockInfo(blockBytes)
-	if err != nil {
-		return nil, err
+	if mgr.archiver != nil && !mgr.archiver.conf.FetchArchivedBlocks && mgr.archiver.isBlockArchived(startNum) {
+		return nil, blkstorage.ErrBlockArchived
 	}
-	return info.blockHeader, nil
-}
-
-func (mgr *blockfileMgr) retrieveBlocks(startNum uint64) (*blocksItr, error) {
 	return newBlockItr(mgr, startNum), nil
 }
 
@@ -519,7 +693,17 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 	logger.Debugf("Entering fetchTransactionEnvelope() %v\n", lp)
 	var err error
 	var txEnvelopeBytes []byte
-	if txEnvelopeBytes, err = mgr.fetchRawBytes(lp); err != nil {
+	if lp.blockTxLoc != nil {
+		// the transaction is in a compressed block, which is decompressed as a whole
+		if txEnvelopeBytes, err = mgr.fetchBlockBytes(lp); err != nil {
+			return nil, err
+		}
+		txLoc := lp.blockTxLoc
+		if txLoc.offset+txLoc.bytesLength > len(txEnvelopeBytes) {
+			return nil, fmt.Errorf("Transaction location [%s] is beyond the block bytes", lp)
+		}
+		txEnvelopeBytes = txEnvelopeBytes[txLoc.offset : txLoc.offset+txLoc.bytesLength]
+	} else if txEnvelopeBytes, err = mgr.fetchRawBytes(lp); err != nil {
 		return nil, err
 	}
 	_, n := proto.DecodeVarint(txEnvelopeBytes)
@@ -527,7 +711,7 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 }
 
 func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
-	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
+	stream, err := newBlockfileStream(mgr.openBlockfile, lp.fileSuffixNum, int64(lp.offset))
 	if err != nil {
 		return nil, err
 	}
@@ -540,8 +724,7 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
-	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
-	reader, err := newBlockfileReader(filePath)
+	reader, err := newBlockfileReader(mgr.openBlockfile, lp.fileSuffixNum)
 	if err != nil {
 		return nil, err
 	}
@@ -579,12 +762,35 @@ func (mgr *blockfileMgr) saveCurrentInfo(i *checkpointInfo, sync bool) error {
 	return nil
 }
 
+//Get the bootstrap information that is stored in the database, nil if the ledger was not bootstrapped from a snapshot
+func (mgr *blockfileMgr) loadBootstrapInfo() (*bootstrapInfo, error) {
+	var b []byte
+	var err error
+	if b, err = mgr.db.Get(bootstrapInfoKey); b == nil || err != nil {
+		return nil, err
+	}
+	i := &bootstrapInfo{}
+	if err = i.unmarshal(b); err != nil {
+		return nil, err
+	}
+	logger.Debugf("loaded bootstrapInfo:%s", i)
+	return i, nil
+}
+
+func (mgr *blockfileMgr) saveBootstrapInfo(i *bootstrapInfo) error {
+	b, err := i.marshal()
+	if err != nil {
+		return err
+	}
+	return mgr.db.Put(bootstrapInfoKey, b, true)
+}
+
 // scanForLastCompleteBlock scan a given block file and detects the last offset in the file
 // after which there may lie a block partially written (towards the end of the file in a crash scenario).
 func scanForLastCompleteBlock(rootDir string, fileNum int, startingOffset int64) (int64, int, error) {
 	//scan the passed file number suffix starting from the passed offset to find the last completed block
 	numBlocks := 0
-	blockStream, errOpen := newBlockfileStream(rootDir, fileNum, startingOffset)
+	blockStream, errOpen := newBlockfileStream(localBlockfileOpener(rootDir), fileNum, startingOffset)
 	if errOpen != nil {
 		return 0, 0, errOpen
 	}
@@ -669,3 +875,36 @@ func (i *checkpointInfo) String() string {
 	return fmt.Sprintf("latestFileChunkSuffixNum=[%d], latestFileChunksize=[%d], isChainEmpty=[%t], lastBlockNumber=[%d]",
 		i.latestFileChunkSuffixNum, i.latestFileChunksize, i.isChainEmpty, i.lastBlockNumber)
 }
+
+// bootstrapInfo
+type bootstrapInfo struct {
+	firstBlockNumber uint64
+	configBlockBytes []byte
+}
+
+func (i *bootstrapInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(i.firstBlockNumber); err != nil {
+		return nil, err
+	}
+	if err := buffer.EncodeRawBytes(i.configBlockBytes); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *bootstrapInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	var err error
+	if i.firstBlockNumber, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	if i.configBlockBytes, err = buffer.DecodeRawBytes(false); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *bootstrapInfo) String() string {
+	return fmt.Sprintf("firstBlockNumber=[%d], configBlockSize=[%d]", i.firstBlockNumber, len(i.configBlockBytes))
+}
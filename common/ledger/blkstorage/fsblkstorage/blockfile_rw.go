@@ -71,8 +71,8 @@ type blockfileReader struct {
 	file *os.File
 }
 
-func newBlockfileReader(filePath string) (*blockfileReader, error) {
-	file, err := os.OpenFile(filePath, os.O_RDONLY, 0600)
+func newBlockfileReader(openBlockfile blockfileOpener, fileNum int) (*blockfileReader, error) {
+	file, err := openBlockfile(fileNum)
 	if err != nil {
 		return nil, err
 	}
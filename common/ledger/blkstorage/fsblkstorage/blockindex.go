@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
@@ -60,6 +61,9 @@ type blockIdxInfo struct {
 	flp       *fileLocPointer
 	txOffsets []*txindexInfo
 	metadata  *common.BlockMetadata
+	// compressed tells whether the block is compressed in the block file, in which case
+	// the txOffsets are relative to the block bytes rather than to the start of the block
+	compressed bool
 }
 
 type blockIndex struct {
@@ -118,7 +122,7 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	//Index3 Used to find a transaction by it's transaction id
 	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrTxID]; ok {
 		for _, txoffset := range txOffsets {
-			txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+			txFlp := newTxFileLocationPointer(blockIdxInfo, txoffset.loc)
 			logger.Debugf("Adding txLoc [%s] for tx ID: [%s] to index", txFlp, txoffset.txID)
 			txFlpBytes, marshalErr := txFlp.marshal()
 			if marshalErr != nil {
@@ -131,7 +135,7 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	//Index4 - Store BlockNumTranNum will be used to query history data
 	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrBlockNumTranNum]; ok {
 		for txIterator, txoffset := range txOffsets {
-			txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+			txFlp := newTxFileLocationPointer(blockIdxInfo, txoffset.loc)
 			logger.Debugf("Adding txLoc [%s] for tx number:[%d] ID: [%s] to blockNumTranNum index", txFlp, txIterator, txoffset.txID)
 			txFlpBytes, marshalErr := txFlp.marshal()
 			if marshalErr != nil {
@@ -313,6 +317,10 @@ func (lp *locPointer) String() string {
 type fileLocPointer struct {
 	fileSuffixNum int
 	locPointer
+	// blockTxLoc is the location of a transaction within the bytes of its block,
+	// when the block is compressed in the block file. The locPointer is then the
+	// location of the block in the block file
+	blockTxLoc *locPointer
 }
 
 func newFileLocationPointer(fileSuffixNum int, beginningOffset int, relativeLP *locPointer) *fileLocPointer {
@@ -322,6 +330,16 @@ func newFileLocationPointer(fileSuffixNum int, beginningOffset int, relativeLP *
 	return flp
 }
 
+// newTxFileLocationPointer returns the location of a transaction of the given block
+func newTxFileLocationPointer(blockIdxInfo *blockIdxInfo, txLP *locPointer) *fileLocPointer {
+	flp := blockIdxInfo.flp
+	if !blockIdxInfo.compressed {
+		return newFileLocationPointer(flp.fileSuffixNum, flp.offset, txLP)
+	}
+	return &fileLocPointer{fileSuffixNum: flp.fileSuffixNum, locPointer: locPointer{offset: flp.offset},
+		blockTxLoc: &locPointer{offset: txLP.offset, bytesLength: txLP.bytesLength}}
+}
+
 func (flp *fileLocPointer) marshal() ([]byte, error) {
 	buffer := proto.NewBuffer([]byte{})
 	e := buffer.EncodeVarint(uint64(flp.fileSuffixNum))
@@ -336,6 +354,16 @@ func (flp *fileLocPointer) marshal() ([]byte, error) {
 	if e != nil {
 		return nil, e
 	}
+	// the location within the block is only appended for the transactions of compressed blocks,
+	// so that the pointers are encoded like they were before the blocks could be compressed
+	if flp.blockTxLoc != nil {
+		if e = buffer.EncodeVarint(uint64(flp.blockTxLoc.offset)); e != nil {
+			return nil, e
+		}
+		if e = buffer.EncodeVarint(uint64(flp.blockTxLoc.bytesLength)); e != nil {
+			return nil, e
+		}
+	}
 	return buffer.Bytes(), nil
 }
 
@@ -357,10 +385,26 @@ func (flp *fileLocPointer) unmarshal(b []byte) error {
 		return e
 	}
 	flp.bytesLength = int(i)
+	i, e = buffer.DecodeVarint()
+	if e == io.ErrUnexpectedEOF {
+		return nil
+	}
+	if e != nil {
+		return e
+	}
+	flp.blockTxLoc = &locPointer{offset: int(i)}
+	i, e = buffer.DecodeVarint()
+	if e != nil {
+		return e
+	}
+	flp.blockTxLoc.bytesLength = int(i)
 	return nil
 }
 
 func (flp *fileLocPointer) String() string {
+	if flp.blockTxLoc != nil {
+		return fmt.Sprintf("fileSuffixNum=%d, %s, blockTxLoc=[%s]", flp.fileSuffixNum, flp.locPointer.String(), flp.blockTxLoc.String())
+	}
 	return fmt.Sprintf("fileSuffixNum=%d, %s", flp.fileSuffixNum, flp.locPointer.String())
 }
 
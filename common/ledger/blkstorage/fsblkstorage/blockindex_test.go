@@ -224,3 +224,17 @@ func testBlockIndexSelectiveIndexing(t *testing.T, indexItems []blkstorage.Index
 		}
 	})
 }
+
+func TestFileLocPointerMarshaling(t *testing.T) {
+	flps := []*fileLocPointer{
+		{fileSuffixNum: 1, locPointer: locPointer{offset: 200, bytesLength: 300}},
+		{fileSuffixNum: 1, locPointer: locPointer{offset: 200}, blockTxLoc: &locPointer{offset: 20, bytesLength: 30}},
+	}
+	for _, flp := range flps {
+		flpBytes, err := flp.marshal()
+		testutil.AssertNoError(t, err, "")
+		unmarshaledFlp := &fileLocPointer{}
+		testutil.AssertNoError(t, unmarshaledFlp.unmarshal(flpBytes), "")
+		testutil.AssertEquals(t, unmarshaledFlp, flp)
+	}
+}
@@ -30,15 +30,30 @@ const (
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	archiveConfs     map[string]*ArchiveConf
+	compression      Compression
 }
 
 // NewConf constructs new `Conf`.
 // blockStorageDir is the top level folder under which `FsBlockStore` manages its data
 func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
+	return NewConfWithArchiving(blockStorageDir, maxBlockfileSize, nil)
+}
+
+// NewConfWithArchiving constructs new `Conf` which archives the block files of the ledgers
+// that archiveConfs has an `ArchiveConf` for. The block files of other ledgers are kept forever
+func NewConfWithArchiving(blockStorageDir string, maxBlockfileSize int, archiveConfs map[string]*ArchiveConf) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir, maxBlockfileSize, archiveConfs, NoCompression}
+}
+
+// WithCompression sets the compression of the blocks that are added to the block files, and returns the `Conf`.
+// The blocks already in the block files are read whatever their compression is
+func (conf *Conf) WithCompression(compression Compression) *Conf {
+	conf.compression = compression
+	return conf
 }
 
 func (conf *Conf) getIndexDir() string {
@@ -52,3 +67,7 @@ func (conf *Conf) getChainsDir() string {
 func (conf *Conf) getLedgerBlockDir(ledgerid string) string {
 	return filepath.Join(conf.getChainsDir(), ledgerid)
 }
+
+func (conf *Conf) getArchiveConf(ledgerid string) *ArchiveConf {
+	return conf.archiveConfs[ledgerid]
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"fmt"
+
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -27,19 +29,34 @@ import (
 
 // fsBlockStore - filesystem based implementation for `BlockStore`
 type fsBlockStore struct {
-	id      string
-	conf    *Conf
-	fileMgr *blockfileMgr
+	id       string
+	conf     *Conf
+	fileMgr  *blockfileMgr
+	readOnly bool
 }
 
 // NewFsBlockStore constructs a `FsBlockStore`
 func newFsBlockStore(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	dbHandle *leveldbhelper.DBHandle) *fsBlockStore {
-	return &fsBlockStore{id, conf, newBlockfileMgr(id, conf, indexConfig, dbHandle)}
+	return &fsBlockStore{id, conf, newBlockfileMgr(id, conf, indexConfig, dbHandle), false}
+}
+
+// newReadOnlyFsBlockStore constructs a `FsBlockStore` on the existing block files of a ledger,
+// which fails to add blocks
+func newReadOnlyFsBlockStore(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
+	dbHandle *leveldbhelper.DBHandle) (*fsBlockStore, error) {
+	fileMgr, err := newReadOnlyBlockfileMgr(id, conf, indexConfig, dbHandle)
+	if err != nil {
+		return nil, err
+	}
+	return &fsBlockStore{id, conf, fileMgr, true}, nil
 }
 
 // AddBlock adds a new block
 func (store *fsBlockStore) AddBlock(block *common.Block) error {
+	if store.readOnly {
+		return store.errReadOnly()
+	}
 	return store.fileMgr.addBlock(block)
 }
 
@@ -86,8 +103,20 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// BootstrapFromSnapshot adds the last block of a snapshot as the first block of the store
+func (store *fsBlockStore) BootstrapFromSnapshot(lastBlock *common.Block, configBlock *common.Block) error {
+	if store.readOnly {
+		return store.errReadOnly()
+	}
+	return store.fileMgr.bootstrapFromSnapshot(lastBlock, configBlock)
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
 	store.fileMgr.close()
 }
+
+func (store *fsBlockStore) errReadOnly() error {
+	return fmt.Errorf("Block store of ledger [%s] is opened read-only", store.id)
+}
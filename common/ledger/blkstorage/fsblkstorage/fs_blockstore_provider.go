@@ -17,6 +17,8 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"fmt"
+
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -27,12 +29,28 @@ type FsBlockstoreProvider struct {
 	conf            *Conf
 	indexConfig     *blkstorage.IndexConfig
 	leveldbProvider *leveldbhelper.Provider
+	readOnly        bool
 }
 
 // NewProvider constructs a filesystem based block store provider
 func NewProvider(conf *Conf, indexConfig *blkstorage.IndexConfig) blkstorage.BlockStoreProvider {
 	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
-	return &FsBlockstoreProvider{conf, indexConfig, p}
+	return &FsBlockstoreProvider{conf, indexConfig, p, false}
+}
+
+// NewReadOnlyProvider constructs a filesystem based block store provider on the existing block storage
+// of a stopped peer, which it does not modify. The provider only opens existing block stores, which
+// fail to add blocks
+func NewReadOnlyProvider(conf *Conf, indexConfig *blkstorage.IndexConfig) (blkstorage.BlockStoreProvider, error) {
+	exists, _, err := util.FileExists(conf.getIndexDir())
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("Block storage does not exist at [%s]", conf.blockStorageDir)
+	}
+	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir(), ReadOnly: true})
+	return &FsBlockstoreProvider{conf, indexConfig, p, true}, nil
 }
 
 // CreateBlockStore simply calls OpenBlockStore
@@ -45,6 +63,9 @@ func (p *FsBlockstoreProvider) CreateBlockStore(ledgerid string) (blkstorage.Blo
 // This method should be invoked only once for a particular ledgerid
 func (p *FsBlockstoreProvider) OpenBlockStore(ledgerid string) (blkstorage.BlockStore, error) {
 	indexStoreHandle := p.leveldbProvider.GetDBHandle(ledgerid)
+	if p.readOnly {
+		return newReadOnlyFsBlockStore(ledgerid, p.conf, p.indexConfig, indexStoreHandle)
+	}
 	return newFsBlockStore(ledgerid, p.conf, p.indexConfig, indexStoreHandle), nil
 }
 
@@ -150,3 +150,45 @@ func TestBlockStoreProvider(t *testing.T) {
 func constructLedgerid(id int) string {
 	return fmt.Sprintf("ledger_%d", id)
 }
+
+func TestReadOnlyProvider(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	store, _ := env.provider.OpenBlockStore("ledger1")
+	for _, b := range blocks {
+		testutil.AssertNoError(t, store.AddBlock(b), "")
+	}
+	store.Shutdown()
+	env.provider.Close()
+
+	readOnlyProvider, err := NewReadOnlyProvider(env.provider.conf, env.provider.indexConfig)
+	testutil.AssertNoError(t, err, "")
+	store, err = readOnlyProvider.OpenBlockStore("ledger1")
+	testutil.AssertNoError(t, err, "")
+	checkBlocks(t, blocks, store)
+	testutil.AssertEquals(t, store.AddBlock(blocks[0]).Error(), "Block store of ledger [ledger1] is opened read-only")
+	store.Shutdown()
+	_, err = readOnlyProvider.OpenBlockStore("ledger2")
+	testutil.AssertEquals(t, err.Error(), "Block store of ledger [ledger2] does not exist")
+	exists, err := env.provider.Exists("ledger2")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, exists, false)
+	readOnlyProvider.Close()
+
+	// the blocks which are not indexed yet are not visible
+	provider := NewProvider(env.provider.conf, env.provider.indexConfig)
+	provider.(*FsBlockstoreProvider).leveldbProvider.GetDBHandle("ledger1").Put(indexCheckpointKey, encodeBlockNum(5), true)
+	provider.Close()
+	readOnlyProvider, err = NewReadOnlyProvider(env.provider.conf, env.provider.indexConfig)
+	testutil.AssertNoError(t, err, "")
+	defer readOnlyProvider.Close()
+	store, err = readOnlyProvider.OpenBlockStore("ledger1")
+	testutil.AssertNoError(t, err, "")
+	defer store.Shutdown()
+	checkBlocks(t, blocks[:6], store)
+
+	missingPath := env.provider.conf.blockStorageDir + "/missing"
+	_, err = NewReadOnlyProvider(NewConf(missingPath, 0), env.provider.indexConfig)
+	testutil.AssertEquals(t, err.Error(), "Block storage does not exist at ["+missingPath+"]")
+}
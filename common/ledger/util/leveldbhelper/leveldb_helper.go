@@ -40,6 +40,9 @@ const (
 // Conf configuration for `DB`
 type Conf struct {
 	DBPath string
+	// ReadOnly opens an existing db without modifying it, so that the db of a stopped peer
+	// can be inspected. The writes to a read-only db fail
+	ReadOnly bool
 }
 
 // DB - a wrapper on an actual store
@@ -80,7 +83,9 @@ func (dbInst *DB) Open() {
 	dbPath := dbInst.conf.DBPath
 	var err error
 	var dirEmpty bool
-	if dirEmpty, err = util.CreateDirIfMissing(dbPath); err != nil {
+	if dbInst.conf.ReadOnly {
+		dbOpts.ReadOnly = true
+	} else if dirEmpty, err = util.CreateDirIfMissing(dbPath); err != nil {
 		panic(fmt.Sprintf("Error while trying to create dir if missing: %s", err))
 	}
 	dbOpts.ErrorIfMissing = !dirEmpty
@@ -112,7 +112,7 @@ func TestLevelDBHelper(t *testing.T) {
 func TestCreateDBInEmptyDir(t *testing.T) {
 	testutil.AssertNoError(t, os.RemoveAll(testDBPath), "")
 	testutil.AssertNoError(t, os.MkdirAll(testDBPath, 0775), "")
-	db := CreateDB(&Conf{testDBPath})
+	db := CreateDB(&Conf{DBPath: testDBPath})
 	defer db.Close()
 	defer func() {
 		if r := recover(); r != nil {
@@ -128,7 +128,7 @@ func TestCreateDBInNonEmptyDir(t *testing.T) {
 	file, err := os.Create(filepath.Join(testDBPath, "dummyfile.txt"))
 	testutil.AssertNoError(t, err, "")
 	file.Close()
-	db := CreateDB(&Conf{testDBPath})
+	db := CreateDB(&Conf{DBPath: testDBPath})
 	defer db.Close()
 	defer func() {
 		if r := recover(); r == nil {
@@ -137,3 +137,30 @@ func TestCreateDBInNonEmptyDir(t *testing.T) {
 	}()
 	db.Open()
 }
+
+func TestReadOnlyDB(t *testing.T) {
+	env := newTestDBEnv(t, testDBPath)
+	defer env.cleanup()
+	db := env.db
+	db.Open()
+	testutil.AssertNoError(t, db.Put([]byte("key"), []byte("value"), true), "")
+	db.Close()
+
+	readOnlyDB := CreateDB(&Conf{DBPath: testDBPath, ReadOnly: true})
+	readOnlyDB.Open()
+	defer readOnlyDB.Close()
+	value, err := readOnlyDB.Get([]byte("key"))
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, value, []byte("value"))
+	testutil.AssertError(t, readOnlyDB.Put([]byte("key"), []byte("newValue"), true), "Expected an error when writing to a read-only db")
+
+	missingDBPath := filepath.Join(testDBPath, "missing")
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("A panic is expected when opening a missing db read-only")
+		}
+		_, err := os.Stat(missingDBPath)
+		testutil.AssertEquals(t, os.IsNotExist(err), true)
+	}()
+	CreateDB(&Conf{DBPath: missingDBPath, ReadOnly: true}).Open()
+}
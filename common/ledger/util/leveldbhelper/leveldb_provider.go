@@ -96,6 +96,31 @@ func (h *DBHandle) WriteBatch(batch *UpdateBatch, sync bool) error {
 	return nil
 }
 
+// deleteAllBatchSize is the number of keys deleted at once by DeleteAll
+const deleteAllBatchSize = 10000
+
+// DeleteAll deletes all the keys of the named db. The keys are deleted in batches,
+// hence a failure may leave the named db with only a part of its keys
+func (h *DBHandle) DeleteAll() error {
+	itr := h.GetIterator(nil, nil)
+	defer itr.Release()
+	levelBatch := &leveldb.Batch{}
+	for itr.Next() {
+		// the key of the underlying iterator is only valid until the next call to Next
+		levelBatch.Delete(append([]byte{}, itr.Iterator.Key()...))
+		if levelBatch.Len() == deleteAllBatchSize {
+			if err := h.db.WriteBatch(levelBatch, true); err != nil {
+				return err
+			}
+			levelBatch.Reset()
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+	return h.db.WriteBatch(levelBatch, true)
+}
+
 // GetIterator gets an handle to iterator. The iterator should be released after the use.
 // The resultset contains all the keys that are present in the db between the startKey (inclusive) and the endKey (exclusive).
 // A nil startKey represents the first available key and a nil endKey represent a logical key after the last available key
@@ -90,6 +90,25 @@ func TestBatchedUpdates(t *testing.T) {
 	}
 }
 
+func TestDeleteAll(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+	p := env.provider
+
+	db1 := p.GetDBHandle("db1")
+	db2 := p.GetDBHandle("db2")
+	for i := 0; i < 20; i++ {
+		db1.Put([]byte(createTestKey(i)), []byte(createTestValue("db1", i)), false)
+		db2.Put([]byte(createTestKey(i)), []byte(createTestValue("db2", i)), false)
+	}
+
+	testutil.AssertNoError(t, db1.DeleteAll(), "")
+	checkItrResults(t, db1.GetIterator(nil, nil), nil, nil)
+	checkItrResults(t, db2.GetIterator(nil, nil), createTestKeys(0, 19), createTestValues("db2", 0, 19))
+
+	testutil.AssertNoError(t, db1.DeleteAll(), "")
+}
+
 func testDBBasicWriteAndReads(t *testing.T, dbNames ...string) {
 	env := newTestProviderEnv(t, testDBPath)
 	defer env.cleanup()
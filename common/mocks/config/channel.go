@@ -16,7 +16,10 @@ limitations under the License.
 
 package config
 
-import "github.com/hyperledger/fabric/common/util"
+import (
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/util"
+)
 
 func nearIdentityHash(input []byte) []byte {
 	return util.ConcatenateBytes([]byte("FakeHash("), input, []byte(""))
@@ -30,6 +33,8 @@ type Channel struct {
 	BlockDataHashingStructureWidthVal uint32
 	// OrdererAddressesVal is returned as the result of OrdererAddresses()
 	OrdererAddressesVal []string
+	// CapabilitiesVal is returned as the result of Capabilities() if set
+	CapabilitiesVal channelconfig.ChannelCapabilities
 }
 
 // HashingAlgorithm returns the HashingAlgorithmVal if set, otherwise a fake simple hash function
@@ -49,3 +54,29 @@ func (scm *Channel) BlockDataHashingStructureWidth() uint32 {
 func (scm *Channel) OrdererAddresses() []string {
 	return scm.OrdererAddressesVal
 }
+
+// Capabilities returns the CapabilitiesVal if set, otherwise capabilities with nothing enabled
+func (scm *Channel) Capabilities() channelconfig.ChannelCapabilities {
+	if scm.CapabilitiesVal == nil {
+		return &ChannelCapabilities{}
+	}
+	return scm.CapabilitiesVal
+}
+
+// ChannelCapabilities is a mock implementation of channelconfig.ChannelCapabilities
+type ChannelCapabilities struct {
+	// SupportedErr is returned as the result of Supported()
+	SupportedErr error
+	// TLSBindingVal is returned as the result of TLSBinding()
+	TLSBindingVal bool
+}
+
+// Supported returns the SupportedErr
+func (cc *ChannelCapabilities) Supported() error {
+	return cc.SupportedErr
+}
+
+// TLSBinding returns the TLSBindingVal
+func (cc *ChannelCapabilities) TLSBinding() bool {
+	return cc.TLSBindingVal
+}
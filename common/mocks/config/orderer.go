@@ -27,6 +27,8 @@ type Orderer struct {
 	MaxChannelsCountVal uint64
 	// OrganizationsVal is returned as the result of Organizations()
 	OrganizationsVal map[string]channelconfig.Org
+	// CapabilitiesVal is returned as the result of Capabilities() if set
+	CapabilitiesVal channelconfig.OrdererCapabilities
 }
 
 // ConsensusType returns the ConsensusTypeVal
@@ -58,3 +60,29 @@ func (scm *Orderer) MaxChannelsCount() uint64 {
 func (scm *Orderer) Organizations() map[string]channelconfig.Org {
 	return scm.OrganizationsVal
 }
+
+// Capabilities returns the CapabilitiesVal if set, otherwise capabilities with nothing enabled
+func (scm *Orderer) Capabilities() channelconfig.OrdererCapabilities {
+	if scm.CapabilitiesVal == nil {
+		return &OrdererCapabilities{}
+	}
+	return scm.CapabilitiesVal
+}
+
+// OrdererCapabilities is a mock implementation of channelconfig.OrdererCapabilities
+type OrdererCapabilities struct {
+	// SupportedErr is returned as the result of Supported()
+	SupportedErr error
+	// BlockValidationCheckVal is returned as the result of BlockValidationCheck()
+	BlockValidationCheckVal bool
+}
+
+// Supported returns the SupportedErr
+func (oc *OrdererCapabilities) Supported() error {
+	return oc.SupportedErr
+}
+
+// BlockValidationCheck returns the BlockValidationCheckVal
+func (oc *OrdererCapabilities) BlockValidationCheck() bool {
+	return oc.BlockValidationCheckVal
+}
@@ -0,0 +1,58 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var logger = flogging.MustGetLogger("blockfilecompactor")
+
+// command line flags
+var (
+	app = kingpin.New("blockfilecompactor", "Utility for rewriting the block files of a stopped peer with another block compression")
+
+	blockStorePath   = app.Flag("blockStorePath", "The directory of the block store of the peer, that is, the chains directory under the ledgersData directory of the peer file system path.").Default("/var/hyperledger/production/ledgersData/chains").String()
+	channelIDs       = app.Flag("channelID", "A channel whose block files are compacted. May be repeated. All the channels are compacted if none is given.").Strings()
+	compression      = app.Flag("compression", "The compression of the rewritten blocks, 'none' or 'snappy'.").Default("snappy").String()
+	maxBlockfileSize = app.Flag("maxBlockfileSize", "The maximum size of the rewritten block files, in bytes.").Default("67108864").Int()
+)
+
+func main() {
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+	if err := compact(*blockStorePath, *channelIDs, *compression, *maxBlockfileSize); err != nil {
+		app.Fatalf("Error compacting block files: %s", err)
+	}
+}
+
+// compact rewrites the block files of the given channels, or of all the channels if none is given
+func compact(blockStorePath string, channelIDs []string, compressionName string, maxBlockfileSize int) error {
+	compression, err := fsblkstorage.ParseCompression(compressionName)
+	if err != nil {
+		return err
+	}
+	if len(channelIDs) == 0 {
+		if channelIDs, err = util.ListSubdirs(filepath.Join(blockStorePath, fsblkstorage.ChainsDir)); err != nil {
+			return err
+		}
+	}
+	conf := fsblkstorage.NewConf(blockStorePath, maxBlockfileSize).WithCompression(compression)
+	for _, channelID := range channelIDs {
+		logger.Infof("Compacting the block files of channel [%s] with compression [%s]", channelID, compression)
+		if err = fsblkstorage.CompactBlockfiles(conf, channelID); err != nil {
+			return fmt.Errorf("channel [%s]: %s", channelID, err)
+		}
+	}
+	return nil
+}
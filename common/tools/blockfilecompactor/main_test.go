@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompact(t *testing.T) {
+	blockStorePath, err := ioutil.TempDir("", "blockfilecompactor")
+	assert.NoError(t, err)
+	defer os.RemoveAll(blockStorePath)
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}}
+	blocks := testutil.ConstructTestBlocks(t, 10)
+
+	provider := fsblkstorage.NewProvider(fsblkstorage.NewConf(blockStorePath, 0), indexConfig)
+	for _, channelID := range []string{"ch1", "ch2"} {
+		store, err := provider.OpenBlockStore(channelID)
+		assert.NoError(t, err)
+		for _, block := range blocks {
+			assert.NoError(t, store.AddBlock(block))
+		}
+		store.Shutdown()
+	}
+	provider.Close()
+
+	assert.EqualError(t, compact(blockStorePath, nil, "zstd", 0), "unsupported block compression [zstd]")
+	assert.EqualError(t, compact(blockStorePath, []string{"ch3"}, "snappy", 0), "channel [ch3]: Block store of ledger [ch3] does not exist")
+	assert.NoError(t, compact(blockStorePath, nil, "snappy", 0))
+
+	provider = fsblkstorage.NewProvider(fsblkstorage.NewConf(blockStorePath, 0), indexConfig)
+	defer provider.Close()
+	for _, channelID := range []string{"ch1", "ch2"} {
+		store, err := provider.OpenBlockStore(channelID)
+		assert.NoError(t, err)
+		for _, block := range blocks {
+			retrievedBlock, err := store.RetrieveBlockByNumber(block.Header.Number)
+			assert.NoError(t, err)
+			assert.Equal(t, block, retrievedBlock)
+		}
+		store.Shutdown()
+	}
+}
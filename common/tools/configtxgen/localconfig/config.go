@@ -136,13 +136,15 @@ type ApplicationOrganization struct {
 // Orderer contains configuration which is used for the
 // bootstrapping of an orderer by the provisional bootstrapper.
 type Orderer struct {
-	OrdererType   string          `yaml:"OrdererType"`
-	Addresses     []string        `yaml:"Addresses"`
-	BatchTimeout  time.Duration   `yaml:"BatchTimeout"`
-	BatchSize     BatchSize       `yaml:"BatchSize"`
-	Kafka         Kafka           `yaml:"Kafka"`
-	Organizations []*Organization `yaml:"Organizations"`
-	MaxChannels   uint64          `yaml:"MaxChannels"`
+	OrdererType     string          `yaml:"OrdererType"`
+	Addresses       []string        `yaml:"Addresses"`
+	BatchTimeout    time.Duration   `yaml:"BatchTimeout"`
+	BatchSize       BatchSize       `yaml:"BatchSize"`
+	Kafka           Kafka           `yaml:"Kafka"`
+	Organizations   []*Organization `yaml:"Organizations"`
+	MaxChannels     uint64          `yaml:"MaxChannels"`
+	BlockValidation string          `yaml:"BlockValidation"`
+	Capabilities    map[string]bool `yaml:"Capabilities"`
 }
 
 // BatchSize contains configuration affecting the size of batches.
@@ -173,8 +175,7 @@ var genesisDefaults = TopLevel{
 	},
 }
 
-// Load returns the orderer/application config combination that corresponds to a given profile.
-func Load(profile string) *Profile {
+func readConfig(profile string) (*viper.Viper, *TopLevel) {
 	config := viper.New()
 	cf.InitViper(config, configName)
 
@@ -197,6 +198,13 @@ func Load(profile string) *Profile {
 		logger.Panic("Error unmarshaling config into struct: ", err)
 	}
 
+	return config, &uconf
+}
+
+// Load returns the orderer/application config combination that corresponds to a given profile.
+func Load(profile string) *Profile {
+	config, uconf := readConfig(profile)
+
 	result, ok := uconf.Profiles[profile]
 	if !ok {
 		logger.Panic("Could not find profile: ", profile)
@@ -209,32 +217,41 @@ func Load(profile string) *Profile {
 	return result
 }
 
+// LoadTopLevel returns the whole configtx.yaml content, including the
+// top level organization definitions which are not bound to any profile.
+func LoadTopLevel() *TopLevel {
+	config, uconf := readConfig("")
+
+	uconf.completeInitialization(filepath.Dir(config.ConfigFileUsed()))
+
+	logger.Infof("Loaded configuration: %s", config.ConfigFileUsed())
+
+	return uconf
+}
+
+func (t *TopLevel) completeInitialization(configDir string) {
+	for _, org := range t.Organizations {
+		org.completeInitialization(configDir)
+	}
+}
+
 func (p *Profile) completeInitialization(configDir string) {
 	if p.Orderer != nil {
 		for _, org := range p.Orderer.Organizations {
-			if org.AdminPrincipal == "" {
-				org.AdminPrincipal = AdminRoleAdminPrincipal
-			}
-			translatePaths(configDir, org)
+			org.completeInitialization(configDir)
 		}
 	}
 
 	if p.Application != nil {
 		for _, org := range p.Application.Organizations {
-			if org.AdminPrincipal == "" {
-				org.AdminPrincipal = AdminRoleAdminPrincipal
-			}
-			translatePaths(configDir, org)
+			org.completeInitialization(configDir)
 		}
 	}
 
 	if p.Consortiums != nil {
 		for _, consortium := range p.Consortiums {
 			for _, org := range consortium.Organizations {
-				if org.AdminPrincipal == "" {
-					org.AdminPrincipal = AdminRoleAdminPrincipal
-				}
-				translatePaths(configDir, org)
+				org.completeInitialization(configDir)
 			}
 		}
 	}
@@ -273,6 +290,13 @@ func (p *Profile) completeInitialization(configDir string) {
 	}
 }
 
+func (org *Organization) completeInitialization(configDir string) {
+	if org.AdminPrincipal == "" {
+		org.AdminPrincipal = AdminRoleAdminPrincipal
+	}
+	translatePaths(configDir, org)
+}
+
 func translatePaths(configDir string, org *Organization) {
 	cf.TranslatePathInPlace(configDir, &org.MSPDir)
 }
@@ -32,17 +32,20 @@ var exitCode = 0
 var logger = flogging.MustGetLogger("common/tools/configtxgen")
 
 func doOutputBlock(config *genesisconfig.Profile, channelID string, outputBlock string) error {
-	pgen := provisional.New(config)
 	logger.Info("Generating genesis block")
 	if config.Orderer == nil {
 		return fmt.Errorf("config does not contain an Orderers section, necessary for all config blocks, aborting")
 	}
+	pgen, err := provisional.NewGenerator(config)
+	if err != nil {
+		return fmt.Errorf("Error generating genesis block: %s", err)
+	}
 	if config.Consortiums == nil {
 		logger.Warning("Genesis block does not contain a consortiums group definition.  This block cannot be used for orderer bootstrap.")
 	}
 	genesisBlock := pgen.GenesisBlockForChannel(channelID)
 	logger.Info("Writing genesis block")
-	err := ioutil.WriteFile(outputBlock, utils.MarshalOrPanic(genesisBlock), 0644)
+	err = ioutil.WriteFile(outputBlock, utils.MarshalOrPanic(genesisBlock), 0644)
 	if err != nil {
 		return fmt.Errorf("Error writing genesis block: %s", err)
 	}
@@ -200,8 +203,25 @@ func doInspectChannelCreateTx(inspectChannelCreateTx string) error {
 	return nil
 }
 
+func doPrintOrg(t *genesisconfig.TopLevel, printOrg string) error {
+	for _, org := range t.Organizations {
+		if org.Name == printOrg {
+			og, err := provisional.OrgGroup(org)
+			if err != nil {
+				return fmt.Errorf("bad org definition for org %s: %s", org.Name, err)
+			}
+
+			if err := protolator.DeepMarshalJSON(os.Stdout, og); err != nil {
+				return fmt.Errorf("malformed org definition for org %s: %s", org.Name, err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("organization %s not found", printOrg)
+}
+
 func main() {
-	var outputBlock, outputChannelCreateTx, profile, channelID, inspectBlock, inspectChannelCreateTx, outputAnchorPeersUpdate, asOrg string
+	var outputBlock, outputChannelCreateTx, profile, channelID, inspectBlock, inspectChannelCreateTx, outputAnchorPeersUpdate, asOrg, printOrg string
 
 	flag.StringVar(&outputBlock, "outputBlock", "", "The path to write the genesis block to (if set)")
 	flag.StringVar(&channelID, "channelID", provisional.TestChainID, "The channel ID to use in the configtx")
@@ -211,6 +231,7 @@ func main() {
 	flag.StringVar(&inspectChannelCreateTx, "inspectChannelCreateTx", "", "Prints the configuration contained in the transaction at the specified path")
 	flag.StringVar(&outputAnchorPeersUpdate, "outputAnchorPeersUpdate", "", "Creates an config update to update an anchor peer (works only with the default channel creation, and only for the first update)")
 	flag.StringVar(&asOrg, "asOrg", "", "Performs the config generation as a particular organization (by name), only including values in the write set that org (likely) has privilege to set")
+	flag.StringVar(&printOrg, "printOrg", "", "Prints the definition of an organization as JSON. (useful for adding an org to a channel manually)")
 
 	version := flag.Bool("version", false, "Show version information")
 
@@ -238,7 +259,16 @@ func main() {
 
 	logger.Info("Loading configuration")
 	factory.InitFactories(nil)
-	config := genesisconfig.Load(profile)
+
+	var config *genesisconfig.Profile
+	if outputBlock != "" || outputChannelCreateTx != "" || outputAnchorPeersUpdate != "" {
+		config = genesisconfig.Load(profile)
+	}
+
+	var topLevelConfig *genesisconfig.TopLevel
+	if printOrg != "" {
+		topLevelConfig = genesisconfig.LoadTopLevel()
+	}
 
 	if outputBlock != "" {
 		if err := doOutputBlock(config, channelID, outputBlock); err != nil {
@@ -269,6 +299,12 @@ func main() {
 			logger.Fatalf("Error on inspectChannelCreateTx: %s", err)
 		}
 	}
+
+	if printOrg != "" {
+		if err := doPrintOrg(topLevelConfig, printOrg); err != nil {
+			logger.Fatalf("Error on printOrg: %s", err)
+		}
+	}
 }
 
 func printVersion() {
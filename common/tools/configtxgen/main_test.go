@@ -141,3 +141,14 @@ func TestBlockFlags(t *testing.T) {
 	_, err := os.Stat(blockDest)
 	assert.NoError(t, err, "Block file is written successfully")
 }
+
+func TestPrintOrg(t *testing.T) {
+	factory.InitFactories(nil)
+	config := genesisconfig.LoadTopLevel()
+
+	assert.NoError(t, doPrintOrg(config, genesisconfig.SampleOrgName), "Good org to print")
+
+	err := doPrintOrg(config, genesisconfig.SampleOrgName+".wrong")
+	assert.Error(t, err, "Bad org name")
+	assert.Regexp(t, "organization [^ ]* not found", err.Error())
+}
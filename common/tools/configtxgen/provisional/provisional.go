@@ -8,6 +8,7 @@ package provisional
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -76,7 +77,18 @@ type bootstrapper struct {
 }
 
 // New returns a new provisional bootstrap helper.
+// It panics if the profile is invalid, see NewGenerator.
 func New(conf *genesisconfig.Profile) Generator {
+	generator, err := NewGenerator(conf)
+	if err != nil {
+		logger.Panicf("Invalid profile: %s", err)
+	}
+	return generator
+}
+
+// NewGenerator returns a new provisional bootstrap helper,
+// or an error if the profile is invalid.
+func NewGenerator(conf *genesisconfig.Profile) (Generator, error) {
 	bs := &bootstrapper{
 		channelGroups: []*cb.ConfigGroup{
 			// Chain Config Types
@@ -95,6 +107,26 @@ func New(conf *genesisconfig.Profile) Generator {
 		oa := channelconfig.TemplateOrdererAddresses(conf.Orderer.Addresses)
 		oa.Values[channelconfig.OrdererAddressesKey].ModPolicy = OrdererAdminsPolicy
 
+		orgGroups := make(map[string]*cb.ConfigGroup)
+		var orgTemplates []*cb.ConfigGroup
+		for _, org := range conf.Orderer.Organizations {
+			mspConfig, err := msp.GetVerifyingMspConfig(org.MSPDir, org.ID)
+			if err != nil {
+				return nil, fmt.Errorf("Error loading MSP configuration for orderer org %s: %s", org.Name, err)
+			}
+			orgTemplate := channelconfig.TemplateGroupMSPWithAdminRolePrincipal([]string{channelconfig.OrdererGroupKey, org.Name},
+				mspConfig, org.AdminPrincipal == genesisconfig.AdminRoleAdminPrincipal,
+			)
+			orgGroups[org.Name] = orgTemplate.Groups[channelconfig.OrdererGroupKey].Groups[org.Name]
+			orgTemplates = append(orgTemplates, orgTemplate)
+		}
+
+		blockValidationPolicy, err := templateBlockValidationPolicy(conf.Orderer.BlockValidation, orgGroups,
+			conf.Orderer.Capabilities)
+		if err != nil {
+			return nil, err
+		}
+
 		bs.ordererGroups = []*cb.ConfigGroup{
 			oa,
 
@@ -109,30 +141,24 @@ func New(conf *genesisconfig.Profile) Generator {
 			channelconfig.TemplateChannelRestrictions(conf.Orderer.MaxChannels),
 
 			// Initialize the default Reader/Writer/Admins orderer policies, as well as block validation policy
-			policies.TemplateImplicitMetaPolicyWithSubPolicy([]string{channelconfig.OrdererGroupKey}, BlockValidationPolicyKey, channelconfig.WritersPolicyKey, cb.ImplicitMetaPolicy_ANY),
+			blockValidationPolicy,
 			policies.TemplateImplicitMetaAnyPolicy([]string{channelconfig.OrdererGroupKey}, channelconfig.ReadersPolicyKey),
 			policies.TemplateImplicitMetaAnyPolicy([]string{channelconfig.OrdererGroupKey}, channelconfig.WritersPolicyKey),
 			policies.TemplateImplicitMetaMajorityPolicy([]string{channelconfig.OrdererGroupKey}, channelconfig.AdminsPolicyKey),
 		}
 
-		for _, org := range conf.Orderer.Organizations {
-			mspConfig, err := msp.GetVerifyingMspConfig(org.MSPDir, org.ID)
-			if err != nil {
-				logger.Panicf("1 - Error loading MSP configuration for org %s: %s", org.Name, err)
-			}
-			bs.ordererGroups = append(bs.ordererGroups,
-				channelconfig.TemplateGroupMSPWithAdminRolePrincipal([]string{channelconfig.OrdererGroupKey, org.Name},
-					mspConfig, org.AdminPrincipal == genesisconfig.AdminRoleAdminPrincipal,
-				),
-			)
-		}
+		bs.ordererGroups = append(bs.ordererGroups, orgTemplates...)
 
 		switch conf.Orderer.OrdererType {
 		case ConsensusTypeSolo:
 		case ConsensusTypeKafka:
 			bs.ordererGroups = append(bs.ordererGroups, channelconfig.TemplateKafkaBrokers(conf.Orderer.Kafka.Brokers))
 		default:
-			panic(fmt.Errorf("Wrong consenter type value given: %s", conf.Orderer.OrdererType))
+			return nil, fmt.Errorf("Wrong consenter type value given: %s", conf.Orderer.OrdererType)
+		}
+
+		if len(conf.Orderer.Capabilities) > 0 {
+			bs.ordererGroups = append(bs.ordererGroups, channelconfig.TemplateOrdererCapabilities(conf.Orderer.Capabilities))
 		}
 	}
 
@@ -147,7 +173,7 @@ func New(conf *genesisconfig.Profile) Generator {
 		for _, org := range conf.Application.Organizations {
 			mspConfig, err := msp.GetVerifyingMspConfig(org.MSPDir, org.ID)
 			if err != nil {
-				logger.Panicf("2- Error loading MSP configuration for org %s: %s", org.Name, err)
+				return nil, fmt.Errorf("Error loading MSP configuration for application org %s: %s", org.Name, err)
 			}
 
 			bs.applicationGroups = append(bs.applicationGroups,
@@ -201,7 +227,7 @@ func New(conf *genesisconfig.Profile) Generator {
 			for _, org := range consortium.Organizations {
 				mspConfig, err := msp.GetVerifyingMspConfig(org.MSPDir, org.ID)
 				if err != nil {
-					logger.Panicf("3 - Error loading MSP configuration for org %s: %s", org.Name, err)
+					return nil, fmt.Errorf("Error loading MSP configuration for consortium org %s: %s", org.Name, err)
 				}
 				bs.consortiumsGroups = append(bs.consortiumsGroups,
 					channelconfig.TemplateGroupMSPWithAdminRolePrincipal(
@@ -213,7 +239,7 @@ func New(conf *genesisconfig.Profile) Generator {
 		}
 	}
 
-	return bs
+	return bs, nil
 }
 
 // ChannelTemplate TODO
@@ -263,3 +289,85 @@ func (bs *bootstrapper) GenesisBlockForChannel(channelID string) *cb.Block {
 	}
 	return block
 }
+
+// OrgGroup returns the config group for a single organization, as it would
+// appear under the Application (or Orderer) group of a channel config.  This
+// is suitable for splicing into a config update which adds the org to an
+// existing channel.
+func OrgGroup(org *genesisconfig.Organization) (*cb.ConfigGroup, error) {
+	mspConfig, err := msp.GetVerifyingMspConfig(org.MSPDir, org.ID)
+	if err != nil {
+		return nil, fmt.Errorf("Error loading MSP configuration for org %s: %s", org.Name, err)
+	}
+
+	groups := []*cb.ConfigGroup{
+		channelconfig.TemplateGroupMSPWithAdminRolePrincipal([]string{channelconfig.ApplicationGroupKey, org.Name},
+			mspConfig, org.AdminPrincipal == genesisconfig.AdminRoleAdminPrincipal,
+		),
+	}
+
+	if len(org.AnchorPeers) > 0 {
+		var anchorProtos []*pb.AnchorPeer
+		for _, anchorPeer := range org.AnchorPeers {
+			anchorProtos = append(anchorProtos, &pb.AnchorPeer{
+				Host: anchorPeer.Host,
+				Port: int32(anchorPeer.Port),
+			})
+		}
+		groups = append(groups, channelconfig.TemplateAnchorPeers(org.Name, anchorProtos))
+	}
+
+	configUpdateEnv, err := configtx.NewModPolicySettingTemplate(
+		channelconfig.AdminsPolicyKey,
+		configtx.NewSimpleTemplate(groups...),
+	).Envelope(TestChainID)
+	if err != nil {
+		return nil, fmt.Errorf("Error generating config group for org %s: %s", org.Name, err)
+	}
+
+	configUpdate, err := configtx.UnmarshalConfigUpdate(configUpdateEnv.ConfigUpdate)
+	if err != nil {
+		return nil, fmt.Errorf("Error unmarshaling config update for org %s: %s", org.Name, err)
+	}
+
+	return configUpdate.WriteSet.Groups[channelconfig.ApplicationGroupKey].Groups[org.Name], nil
+}
+
+// templateBlockValidationPolicy returns the config group holding the block validation
+// policy of the orderer group. The rule is either an implicit meta rule over a policy
+// of the orderer organizations, such as "ANY Writers", or a signature policy, such as
+// "OR('Org1.member', 'Org2.member')". It defaults to "ANY Writers" when empty.
+// As every orderer signs the blocks it cuts by itself, the rule must be satisfied by
+// the signature of a single member of the given orderer organizations, when the
+// orderer capabilities enable this check
+func templateBlockValidationPolicy(rule string, orgGroups map[string]*cb.ConfigGroup, capabilities map[string]bool) (*cb.ConfigGroup, error) {
+	if rule == "" {
+		rule = cb.ImplicitMetaPolicy_ANY.String() + " " + channelconfig.WritersPolicyKey
+	}
+
+	var configGroup *cb.ConfigGroup
+	if fields := strings.Fields(rule); len(fields) == 2 {
+		if metaRule, ok := cb.ImplicitMetaPolicy_Rule_value[strings.ToUpper(fields[0])]; ok {
+			configGroup = policies.TemplateImplicitMetaPolicyWithSubPolicy([]string{channelconfig.OrdererGroupKey},
+				BlockValidationPolicyKey, fields[1], cb.ImplicitMetaPolicy_Rule(metaRule))
+		}
+	}
+
+	if configGroup == nil {
+		sigPolicyEnv, err := cauthdsl.FromString(rule)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid block validation policy '%s': it is neither an implicit meta policy "+
+				"such as 'ANY Writers' nor a signature policy: %s", rule, err)
+		}
+		configGroup = cb.NewConfigGroup()
+		configGroup.Groups[channelconfig.OrdererGroupKey] = cauthdsl.TemplatePolicy(BlockValidationPolicyKey, sigPolicyEnv)
+	}
+
+	if _, ok := capabilities[channelconfig.BlockValidationCheckCapability]; ok {
+		policy := configGroup.Groups[channelconfig.OrdererGroupKey].Policies[BlockValidationPolicyKey].Policy
+		if err := channelconfig.ValidateBlockValidationPolicy(policy, orgGroups); err != nil {
+			return nil, fmt.Errorf("Invalid block validation policy '%s': %s", rule, err)
+		}
+	}
+	return configGroup, nil
+}
@@ -20,8 +20,14 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -71,3 +77,100 @@ func TestGenesisBlockForChannelHeader(t *testing.T) {
 		assert.Nil(t, genesisBlock.Header.PreviousHash, "Case %s: Header previousHash to be nil", tc.Orderer.OrdererType)
 	}
 }
+
+func TestBlockValidationPolicy(t *testing.T) {
+	blockValidationPolicy := func(rule string) *cb.Policy {
+		configGroup, err := templateBlockValidationPolicy(rule, nil, nil)
+		assert.NoError(t, err)
+		group := configGroup.Groups[channelconfig.OrdererGroupKey]
+		assert.NotNil(t, group)
+		configPolicy := group.Policies[BlockValidationPolicyKey]
+		assert.NotNil(t, configPolicy)
+		return configPolicy.Policy
+	}
+
+	implicitMetaPolicy := func(policy *cb.Policy) *cb.ImplicitMetaPolicy {
+		assert.Equal(t, int32(cb.Policy_IMPLICIT_META), policy.Type)
+		imp := &cb.ImplicitMetaPolicy{}
+		assert.NoError(t, proto.Unmarshal(policy.Value, imp))
+		return imp
+	}
+
+	// Defaults to ANY Writers
+	imp := implicitMetaPolicy(blockValidationPolicy(""))
+	assert.Equal(t, cb.ImplicitMetaPolicy_ANY, imp.Rule)
+	assert.Equal(t, channelconfig.WritersPolicyKey, imp.SubPolicy)
+
+	imp = implicitMetaPolicy(blockValidationPolicy("MAJORITY Writers"))
+	assert.Equal(t, cb.ImplicitMetaPolicy_MAJORITY, imp.Rule)
+	assert.Equal(t, channelconfig.WritersPolicyKey, imp.SubPolicy)
+
+	policy := blockValidationPolicy("OR(AND('Org1.member', 'Org2.member'), AND('Org2.member', 'Org3.member'))")
+	assert.Equal(t, int32(cb.Policy_SIGNATURE), policy.Type)
+	sigPolicy := &cb.SignaturePolicyEnvelope{}
+	assert.NoError(t, proto.Unmarshal(policy.Value, sigPolicy))
+	assert.Len(t, sigPolicy.Identities, 4)
+	assert.Equal(t, int32(1), sigPolicy.Rule.GetNOutOf().N)
+
+	_, err := templateBlockValidationPolicy("bogus", nil, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "neither an implicit meta policy")
+
+	// With the block validation check capability, the policies which require the
+	// signatures of several orderers are rejected, as every orderer signs the blocks
+	// it cuts by itself, as well as those which require the signature of an admin
+	capabilities := map[string]bool{channelconfig.BlockValidationCheckCapability: true}
+	for _, rule := range []string{"MAJORITY Writers", "ALL Writers", "AND('Org1.member', 'Org2.member')",
+		"ANY Admins", "OR('Org1.admin', 'Org2.admin')"} {
+		_, err = templateBlockValidationPolicy(rule, ordererOrgGroups(t, "Org1", "Org2"), capabilities)
+		assert.Error(t, err, rule)
+	}
+	_, err = templateBlockValidationPolicy("MAJORITY Writers", ordererOrgGroups(t, "Org1"), capabilities)
+	assert.NoError(t, err)
+	_, err = templateBlockValidationPolicy("OR('Org1.member', 'Org2.member')", ordererOrgGroups(t, "Org1", "Org2"), capabilities)
+	assert.NoError(t, err)
+}
+
+// ordererOrgGroups returns the config groups of orderer organizations with the given MSP IDs,
+// whose admins are the admins of their MSPs
+func ordererOrgGroups(t *testing.T, mspIDs ...string) map[string]*cb.ConfigGroup {
+	mspDir, err := config.GetDevMspDir()
+	assert.NoError(t, err)
+	orgGroups := make(map[string]*cb.ConfigGroup)
+	for _, mspID := range mspIDs {
+		mspConfig, err := msp.GetVerifyingMspConfig(mspDir, mspID)
+		assert.NoError(t, err)
+		configGroup := channelconfig.TemplateGroupMSPWithAdminRolePrincipal([]string{channelconfig.OrdererGroupKey, mspID}, mspConfig, true)
+		orgGroups[mspID] = configGroup.Groups[channelconfig.OrdererGroupKey].Groups[mspID]
+	}
+	return orgGroups
+}
+
+func TestNewGeneratorInvalidProfile(t *testing.T) {
+	conf := genesisconfig.Load(genesisconfig.SampleSingleMSPSoloProfile)
+	conf.Orderer.BlockValidation = "bogus"
+	_, err := NewGenerator(conf)
+	assert.Error(t, err)
+	assert.Panics(t, func() { New(conf) })
+
+	conf = genesisconfig.Load(genesisconfig.SampleSingleMSPSoloProfile)
+	conf.Orderer.OrdererType = "bogus"
+	_, err = NewGenerator(conf)
+	assert.EqualError(t, err, "Wrong consenter type value given: bogus")
+}
+
+func TestOrdererCapabilities(t *testing.T) {
+	conf := genesisconfig.Load(genesisconfig.SampleSingleMSPSoloProfile)
+	conf.Orderer.Capabilities = map[string]bool{channelconfig.BlockValidationCheckCapability: true}
+	generator, err := NewGenerator(conf)
+	assert.NoError(t, err)
+
+	env := utils.ExtractEnvelopeOrPanic(generator.GenesisBlockForChannel("foo"), 0)
+	configEnv, err := configtx.UnmarshalConfigEnvelope(utils.UnmarshalPayloadOrPanic(env.Payload).Data)
+	assert.NoError(t, err)
+	capabilities := &cb.Capabilities{}
+	configValue := configEnv.Config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.CapabilitiesKey]
+	assert.NotNil(t, configValue)
+	assert.NoError(t, proto.Unmarshal(configValue.Value, capabilities))
+	assert.True(t, capabilities.Capabilities[channelconfig.BlockValidationCheckCapability].Required)
+}
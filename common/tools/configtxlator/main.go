@@ -18,11 +18,19 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"reflect"
 
 	"github.com/hyperledger/fabric/common/tools/configtxlator/metadata"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/rest"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/op/go-logging"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
@@ -37,6 +45,22 @@ var (
 	hostname = start.Flag("hostname", "The hostname or IP on which the REST server will listen").Default("0.0.0.0").String()
 	port     = start.Flag("port", "The port on which the REST server will listen").Default("7059").Int()
 
+	protoEncode       = app.Command("proto_encode", "Converts a JSON document to protobuf.")
+	protoEncodeType   = protoEncode.Flag("type", "The type of protobuf structure to encode to.  For example, 'common.Config'.").Required().String()
+	protoEncodeSource = protoEncode.Flag("input", "A file containing the JSON document.").Default(os.Stdin.Name()).File()
+	protoEncodeDest   = protoEncode.Flag("output", "A file to write the output to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	protoDecode       = app.Command("proto_decode", "Converts a proto message to JSON.")
+	protoDecodeType   = protoDecode.Flag("type", "The type of protobuf structure to decode from.  For example, 'common.Config'.").Required().String()
+	protoDecodeSource = protoDecode.Flag("input", "A file containing the proto message.").Default(os.Stdin.Name()).File()
+	protoDecodeDest   = protoDecode.Flag("output", "A file to write the JSON document to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	computeUpdate          = app.Command("compute_update", "Takes two marshaled common.Config messages and computes the config update which transitions between the two.")
+	computeUpdateOriginal  = computeUpdate.Flag("original", "The original config message.").Required().File()
+	computeUpdateUpdated   = computeUpdate.Flag("updated", "The updated config message.").Required().File()
+	computeUpdateChannelID = computeUpdate.Flag("channel_id", "The name of the channel for this update.").Required().String()
+	computeUpdateDest      = computeUpdate.Flag("output", "A file to write the marshaled common.ConfigUpdate to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
 	version = app.Command("version", "Show version information")
 )
 
@@ -47,6 +71,34 @@ func main() {
 	case start.FullCommand():
 		startServer(fmt.Sprintf("%s:%d", *hostname, *port))
 
+	// "proto_encode" command
+	case protoEncode.FullCommand():
+		defer (*protoEncodeSource).Close()
+		defer (*protoEncodeDest).Close()
+		err := encodeProto(*protoEncodeType, *protoEncodeSource, *protoEncodeDest)
+		if err != nil {
+			app.Fatalf("Error encoding: %s", err)
+		}
+
+	// "proto_decode" command
+	case protoDecode.FullCommand():
+		defer (*protoDecodeSource).Close()
+		defer (*protoDecodeDest).Close()
+		err := decodeProto(*protoDecodeType, *protoDecodeSource, *protoDecodeDest)
+		if err != nil {
+			app.Fatalf("Error decoding: %s", err)
+		}
+
+	// "compute_update" command
+	case computeUpdate.FullCommand():
+		defer (*computeUpdateOriginal).Close()
+		defer (*computeUpdateUpdated).Close()
+		defer (*computeUpdateDest).Close()
+		err := computeUpdt(*computeUpdateOriginal, *computeUpdateUpdated, *computeUpdateDest, *computeUpdateChannelID)
+		if err != nil {
+			app.Fatalf("Error computing update: %s", err)
+		}
+
 	// "version" command
 	case version.FullCommand():
 		printVersion()
@@ -61,6 +113,110 @@ func startServer(address string) {
 	app.Fatalf("Error starting server:[%s]\n", err)
 }
 
+// msgForName returns an empty message of the registered proto type msgName,
+// using the same names accepted by the REST API, for instance 'common.Config'.
+func msgForName(msgName string) (proto.Message, error) {
+	msgType := proto.MessageType(msgName)
+	if msgType == nil {
+		return nil, fmt.Errorf("message of type %s unknown", msgName)
+	}
+	return reflect.New(msgType.Elem()).Interface().(proto.Message), nil
+}
+
+func encodeProto(msgName string, input io.Reader, output io.Writer) error {
+	msg, err := msgForName(msgName)
+	if err != nil {
+		return err
+	}
+
+	err = protolator.DeepUnmarshalJSON(input, msg)
+	if err != nil {
+		return fmt.Errorf("error decoding input: %s", err)
+	}
+
+	out, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("error marshaling: %s", err)
+	}
+
+	_, err = output.Write(out)
+	if err != nil {
+		return fmt.Errorf("error writing output: %s", err)
+	}
+
+	return nil
+}
+
+func decodeProto(msgName string, input io.Reader, output io.Writer) error {
+	msg, err := msgForName(msgName)
+	if err != nil {
+		return err
+	}
+
+	in, err := ioutil.ReadAll(input)
+	if err != nil {
+		return fmt.Errorf("error reading input: %s", err)
+	}
+
+	err = proto.Unmarshal(in, msg)
+	if err != nil {
+		return fmt.Errorf("error unmarshaling: %s", err)
+	}
+
+	err = protolator.DeepMarshalJSON(output, msg)
+	if err != nil {
+		return fmt.Errorf("error encoding output: %s", err)
+	}
+
+	return nil
+}
+
+func readConfig(input io.Reader) (*cb.Config, error) {
+	in, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, fmt.Errorf("error reading input: %s", err)
+	}
+
+	config := &cb.Config{}
+	err = proto.Unmarshal(in, config)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling to common.Config: %s", err)
+	}
+
+	return config, nil
+}
+
+func computeUpdt(original, updated io.Reader, output io.Writer, channelID string) error {
+	origConf, err := readConfig(original)
+	if err != nil {
+		return fmt.Errorf("error with original config: %s", err)
+	}
+
+	updtConf, err := readConfig(updated)
+	if err != nil {
+		return fmt.Errorf("error with updated config: %s", err)
+	}
+
+	cu, err := update.Compute(origConf, updtConf)
+	if err != nil {
+		return fmt.Errorf("error computing config update: %s", err)
+	}
+
+	cu.ChannelId = channelID
+
+	outBytes, err := proto.Marshal(cu)
+	if err != nil {
+		return fmt.Errorf("error marshaling computed config update: %s", err)
+	}
+
+	_, err = output.Write(outBytes)
+	if err != nil {
+		return fmt.Errorf("error writing config update to output: %s", err)
+	}
+
+	return nil
+}
+
 func printVersion() {
 	fmt.Println(metadata.GetVersionInfo())
 }
@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecodeProto(t *testing.T) {
+	original := &cb.Config{
+		Sequence: 3,
+		ChannelGroup: &cb.ConfigGroup{
+			Version:   2,
+			ModPolicy: "Admins",
+		},
+	}
+
+	jsonOut := &bytes.Buffer{}
+	assert.NoError(t, decodeProto("common.Config", bytes.NewReader(utils.MarshalOrPanic(original)), jsonOut))
+
+	protoOut := &bytes.Buffer{}
+	assert.NoError(t, encodeProto("common.Config", jsonOut, protoOut))
+
+	decoded := &cb.Config{}
+	assert.NoError(t, proto.Unmarshal(protoOut.Bytes(), decoded))
+	assert.True(t, proto.Equal(original, decoded))
+}
+
+func TestUnknownMsgType(t *testing.T) {
+	assert.Error(t, encodeProto("common.NotAType", &bytes.Buffer{}, &bytes.Buffer{}))
+	assert.Error(t, decodeProto("common.NotAType", &bytes.Buffer{}, &bytes.Buffer{}))
+}
+
+func TestDecodeBadProto(t *testing.T) {
+	assert.Error(t, decodeProto("common.Config", bytes.NewReader([]byte("garbage")), &bytes.Buffer{}))
+}
+
+func TestComputeUpdate(t *testing.T) {
+	original := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Version:   7,
+			ModPolicy: "foo",
+		},
+	}
+	updated := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			ModPolicy: "bar",
+		},
+	}
+
+	out := &bytes.Buffer{}
+	err := computeUpdt(bytes.NewReader(utils.MarshalOrPanic(original)), bytes.NewReader(utils.MarshalOrPanic(updated)), out, "foo")
+	assert.NoError(t, err)
+
+	cu := &cb.ConfigUpdate{}
+	assert.NoError(t, proto.Unmarshal(out.Bytes(), cu))
+	assert.Equal(t, "foo", cu.ChannelId)
+	assert.Equal(t, "bar", cu.WriteSet.ModPolicy)
+	assert.Equal(t, uint64(8), cu.WriteSet.Version)
+}
+
+func TestComputeUpdateNoChange(t *testing.T) {
+	config := utils.MarshalOrPanic(&cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Version: 7,
+		},
+	})
+
+	err := computeUpdt(bytes.NewReader(config), bytes.NewReader(config), &bytes.Buffer{}, "foo")
+	assert.Error(t, err)
+}
+
+func TestComputeUpdateBadInput(t *testing.T) {
+	config := utils.MarshalOrPanic(&cb.Config{})
+	garbage := []byte("garbage")
+
+	assert.Error(t, computeUpdt(bytes.NewReader(garbage), bytes.NewReader(config), &bytes.Buffer{}, "foo"))
+	assert.Error(t, computeUpdt(bytes.NewReader(config), bytes.NewReader(garbage), &bytes.Buffer{}, "foo"))
+}
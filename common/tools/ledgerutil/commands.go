@@ -0,0 +1,357 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	putils "github.com/hyperledger/fabric/protos/utils"
+)
+
+// lastBlock stands for the last block of the ledger, as it does for the block store
+const lastBlock = math.MaxUint64
+
+// stateEntry is the JSON document of a key of the state database
+type stateEntry struct {
+	Namespace string `json:"namespace"`
+	Key       string `json:"key"`
+	value
+	BlockNum uint64 `json:"block_num"`
+	TxNum    uint64 `json:"tx_num"`
+}
+
+// historyEntry is the JSON document of a modification of a key
+type historyEntry struct {
+	TxID      string `json:"tx_id"`
+	Timestamp string `json:"timestamp,omitempty"`
+	IsDelete  bool   `json:"is_delete"`
+	value
+}
+
+// value holds a value as text, or as base64 if it isn't UTF-8 text
+type value struct {
+	Value    string `json:"value"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// txEntry is the JSON document of a transaction
+type txEntry struct {
+	BlockNum       uint64          `json:"block_num"`
+	TxNum          uint64          `json:"tx_num"`
+	ValidationCode string          `json:"validation_code"`
+	Envelope       json.RawMessage `json:"envelope"`
+	PvtData        json.RawMessage `json:"pvt_data,omitempty"`
+}
+
+// diffEntry is the JSON document of a key whose entries differ between two snapshots.
+// The entry of a snapshot is null if the key does not exist in it
+type diffEntry struct {
+	Namespace  string         `json:"namespace"`
+	Collection string         `json:"collection,omitempty"`
+	Key        string         `json:"key,omitempty"`
+	KeyHash    string         `json:"key_hash,omitempty"`
+	Snapshot1  *snapshotEntry `json:"snapshot1"`
+	Snapshot2  *snapshotEntry `json:"snapshot2"`
+}
+
+// snapshotEntry is the entry of a key in a snapshot. A snapshot
+// holds the hashes of the values of the private data
+type snapshotEntry struct {
+	*value
+	ValueHash string `json:"value_hash,omitempty"`
+	BlockNum  uint64 `json:"block_num"`
+	TxNum     uint64 `json:"tx_num"`
+}
+
+// dumpBlocks writes the blocks from the given block to the given block, both inclusive, as JSON documents
+func dumpBlocks(w io.Writer, r *ledgerReader, from, to uint64) error {
+	bcInfo, err := r.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if to >= bcInfo.Height {
+		to = bcInfo.Height - 1
+	}
+	if bcInfo.Height == 0 || from > to {
+		return fmt.Errorf("No blocks from block [%d], the height of the ledger is [%d]", from, bcInfo.Height)
+	}
+	itr, err := r.blockStore.RetrieveBlocks(from)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	for blockNum := from; blockNum <= to; blockNum++ {
+		result, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err = protolator.DeepMarshalJSON(w, result.(*common.Block)); err != nil {
+			return fmt.Errorf("Error while marshaling block [%d]: %s", blockNum, err)
+		}
+	}
+	return nil
+}
+
+// dumpState writes the given key of the given namespace as a JSON document or, if no key is given, the keys
+// from the start key, inclusive, to the end key, exclusive. An empty end key stands for the end of the namespace
+func dumpState(w io.Writer, r *ledgerReader, namespace, key, startKey, endKey string) error {
+	db, err := r.openStateDB()
+	if err != nil {
+		return err
+	}
+	encoder := newJSONEncoder(w)
+	if key != "" {
+		versionedValue, err := db.GetState(namespace, key)
+		if err != nil {
+			return err
+		}
+		if versionedValue == nil {
+			return fmt.Errorf("Key [%s] does not exist in namespace [%s]", key, namespace)
+		}
+		return encoder.Encode(newStateEntry(namespace, key, versionedValue))
+	}
+	itr, err := db.GetStateRangeScanIterator(namespace, startKey, endKey)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			return nil
+		}
+		kv := result.(*statedb.VersionedKV)
+		if err = encoder.Encode(newStateEntry(namespace, kv.Key, &kv.VersionedValue)); err != nil {
+			return err
+		}
+	}
+}
+
+// dumpHistory writes the modifications of the given key of the given namespace, in the order they were committed
+func dumpHistory(w io.Writer, r *ledgerReader, namespace, key string) error {
+	db, err := r.openHistoryDB()
+	if err != nil {
+		return err
+	}
+	qe, err := db.NewHistoryQueryExecutor(r.blockStore)
+	if err != nil {
+		return err
+	}
+	itr, err := qe.GetHistoryForKey(namespace, key)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	encoder := newJSONEncoder(w)
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			return nil
+		}
+		modification := result.(*queryresult.KeyModification)
+		entry := &historyEntry{TxID: modification.TxId, IsDelete: modification.IsDelete, value: newValue(modification.Value)}
+		if ts := modification.Timestamp; ts != nil {
+			entry.Timestamp = time.Unix(ts.Seconds, int64(ts.Nanos)).UTC().Format(time.RFC3339Nano)
+		}
+		if err = encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+}
+
+// dumpTx writes the transaction with the given id, along with its validation code and its private data, if any
+func dumpTx(w io.Writer, r *ledgerReader, txID string) error {
+	block, err := r.blockStore.RetrieveBlockByTxID(txID)
+	if err != nil {
+		return fmt.Errorf("Error while retrieving the block of transaction [%s]: %s", txID, err)
+	}
+	validationCode, err := r.blockStore.RetrieveTxValidationCodeByTxID(txID)
+	if err != nil {
+		return err
+	}
+	entry := &txEntry{BlockNum: block.Header.Number, ValidationCode: validationCode.String()}
+	for txNum, envelopeBytes := range block.Data.Data {
+		envelope, err := putils.GetEnvelopeFromBlock(envelopeBytes)
+		if err != nil {
+			return err
+		}
+		chdr, err := putils.ChannelHeader(envelope)
+		if err != nil {
+			return err
+		}
+		if chdr.TxId == txID {
+			entry.TxNum = uint64(txNum)
+			if entry.Envelope, err = deepMarshalJSON(envelope); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	if entry.Envelope == nil {
+		return fmt.Errorf("Transaction [%s] is not in block [%d]", txID, entry.BlockNum)
+	}
+
+	pvtdataStore, err := r.openPvtdataStore()
+	if err != nil {
+		return err
+	}
+	if pvtdataStore != nil {
+		pvtData, err := pvtdataStore.GetPvtDataByBlockNum(entry.BlockNum, nil)
+		if _, outOfRange := err.(*pvtdatastorage.ErrOutOfRange); err != nil && !outOfRange {
+			return err
+		}
+		for _, txPvtData := range pvtData {
+			if txPvtData.SeqInBlock == entry.TxNum {
+				if entry.PvtData, err = deepMarshalJSON(txPvtData.WriteSet); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return newJSONEncoder(w).Encode(entry)
+}
+
+// verifyHashchain verifies that the blocks from the given block onwards are numbered in sequence, that
+// their data match the data hashes of their headers, and that each header holds the hash of the previous header
+func verifyHashchain(w io.Writer, r *ledgerReader, from uint64) error {
+	bcInfo, err := r.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if from >= bcInfo.Height {
+		return fmt.Errorf("Block [%d] is beyond the height of the ledger [%d]", from, bcInfo.Height)
+	}
+	itr, err := r.blockStore.RetrieveBlocks(from)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	var previousHash []byte
+	for blockNum := from; blockNum < bcInfo.Height; blockNum++ {
+		result, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		block := result.(*common.Block)
+		if block.Header.Number != blockNum {
+			return fmt.Errorf("Block [%d] is numbered [%d]", blockNum, block.Header.Number)
+		}
+		if !bytes.Equal(block.Header.DataHash, block.Data.Hash()) {
+			return fmt.Errorf("The data of block [%d] does not match the data hash of its header", blockNum)
+		}
+		if previousHash != nil && !bytes.Equal(block.Header.PreviousHash, previousHash) {
+			return fmt.Errorf("The previous hash of block [%d] does not match the hash of the header of block [%d]", blockNum, blockNum-1)
+		}
+		previousHash = block.Header.Hash()
+	}
+	if !bytes.Equal(previousHash, bcInfo.CurrentBlockHash) {
+		return fmt.Errorf("The hash of the header of the last block does not match the hash of the blockchain info")
+	}
+	_, err = fmt.Fprintf(w, "Verified the hash chain of blocks [%d] to [%d] of channel [%s]\n", from, bcInfo.Height-1, r.channelID)
+	return err
+}
+
+// compareSnapshots writes the keys whose entries differ between the given snapshots of the ledger of the given channel
+func compareSnapshots(w io.Writer, channelID, snapshotDir1, snapshotDir2 string) error {
+	manifest, _, err := kvledger.ReadSnapshotManifest(snapshotDir1)
+	if err != nil {
+		return err
+	}
+	if manifest.ChannelID != channelID {
+		return fmt.Errorf("Snapshot [%s] is of channel [%s]", snapshotDir1, manifest.ChannelID)
+	}
+	encoder := newJSONEncoder(w)
+	diffs, err := kvledger.CompareSnapshots(snapshotDir1, snapshotDir2, func(kv1, kv2 *privacyenabledstate.SnapshotKV) error {
+		return encoder.Encode(newDiffEntry(kv1, kv2))
+	})
+	if err != nil {
+		return err
+	}
+	if diffs > 0 {
+		return fmt.Errorf("The state of the snapshots differs for [%d] keys", diffs)
+	}
+	_, err = fmt.Fprintf(w, "The state of the snapshots is identical at block [%d] of channel [%s]\n", manifest.LastBlockNumber, channelID)
+	return err
+}
+
+func newStateEntry(namespace, key string, versionedValue *statedb.VersionedValue) *stateEntry {
+	return &stateEntry{Namespace: namespace, Key: key, value: newValue(versionedValue.Value),
+		BlockNum: versionedValue.Version.BlockNum, TxNum: versionedValue.Version.TxNum}
+}
+
+func newValue(v []byte) value {
+	if utf8.Valid(v) {
+		return value{Value: string(v)}
+	}
+	return value{Value: base64.StdEncoding.EncodeToString(v), Encoding: "base64"}
+}
+
+// newJSONEncoder returns an encoder which indents the JSON documents as protolator does
+func newJSONEncoder(w io.Writer) *json.Encoder {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder
+}
+
+func deepMarshalJSON(msg proto.Message) (json.RawMessage, error) {
+	buf := &bytes.Buffer{}
+	if err := protolator.DeepMarshalJSON(buf, msg); err != nil {
+		return nil, err
+	}
+	return json.RawMessage(buf.Bytes()), nil
+}
+
+func newDiffEntry(kv1, kv2 *privacyenabledstate.SnapshotKV) *diffEntry {
+	kv := kv1
+	if kv == nil {
+		kv = kv2
+	}
+	entry := &diffEntry{Namespace: kv.Namespace, Collection: kv.CollectionName,
+		Snapshot1: newSnapshotEntry(kv1), Snapshot2: newSnapshotEntry(kv2)}
+	if kv.CollectionName == "" {
+		entry.Key = kv.Key
+	} else {
+		entry.KeyHash = hex.EncodeToString([]byte(kv.Key))
+	}
+	return entry
+}
+
+func newSnapshotEntry(kv *privacyenabledstate.SnapshotKV) *snapshotEntry {
+	if kv == nil {
+		return nil
+	}
+	entry := &snapshotEntry{BlockNum: kv.Version.BlockNum, TxNum: kv.Version.TxNum}
+	if kv.CollectionName == "" {
+		v := newValue(kv.Value)
+		entry.value = &v
+	} else {
+		entry.ValueHash = hex.EncodeToString(kv.Value)
+	}
+	return entry
+}
@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb/historyleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
+	"github.com/spf13/viper"
+)
+
+// ledgerReader opens the stores of the ledger of a channel read-only. The block store is opened
+// with the ledger, and the other stores are opened when they are first needed, since a peer may
+// neither have a LevelDB state database nor a history database
+type ledgerReader struct {
+	channelID  string
+	blockStore blkstorage.BlockStore
+	closers    []func()
+}
+
+// openLedger opens the block store of the ledger of the given channel, under the given file system path of a peer
+func openLedger(fileSystemPath, channelID string) (*ledgerReader, error) {
+	fileSystemPath, err := filepath.Abs(fileSystemPath)
+	if err != nil {
+		return nil, err
+	}
+	viper.Set("peer.fileSystemPath", fileSystemPath)
+
+	attrsToIndex := []blkstorage.IndexableAttr{
+		blkstorage.IndexableAttrBlockHash,
+		blkstorage.IndexableAttrBlockNum,
+		blkstorage.IndexableAttrTxID,
+		blkstorage.IndexableAttrBlockNumTranNum,
+		blkstorage.IndexableAttrBlockTxID,
+		blkstorage.IndexableAttrTxValidationCode,
+	}
+	provider, err := fsblkstorage.NewReadOnlyProvider(
+		fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize()),
+		&blkstorage.IndexConfig{AttrsToIndex: attrsToIndex})
+	if err != nil {
+		return nil, err
+	}
+	r := &ledgerReader{channelID: channelID, closers: []func(){provider.Close}}
+	if r.blockStore, err = provider.OpenBlockStore(channelID); err != nil {
+		r.close()
+		return nil, err
+	}
+	r.closers = append(r.closers, r.blockStore.Shutdown)
+	return r, nil
+}
+
+// openStateDB opens the LevelDB state database of the ledger
+func (r *ledgerReader) openStateDB() (statedb.VersionedDB, error) {
+	dbPath := ledgerconfig.GetStateLevelDBPath()
+	if err := checkDBExists("LevelDB state database", dbPath); err != nil {
+		return nil, err
+	}
+	provider := stateleveldb.NewReadOnlyVersionedDBProvider(dbPath)
+	r.closers = append(r.closers, provider.Close)
+	return provider.GetDBHandle(r.channelID)
+}
+
+// openHistoryDB opens the history database of the ledger
+func (r *ledgerReader) openHistoryDB() (historydb.HistoryDB, error) {
+	dbPath := ledgerconfig.GetHistoryLevelDBPath()
+	if err := checkDBExists("history database", dbPath); err != nil {
+		return nil, err
+	}
+	provider := historyleveldb.NewReadOnlyHistoryDBProvider(dbPath)
+	r.closers = append(r.closers, provider.Close)
+	return provider.GetDBHandle(r.channelID)
+}
+
+// openPvtdataStore opens the private data store of the ledger, or returns nil if the peer has no private data store
+func (r *ledgerReader) openPvtdataStore() (pvtdatastorage.Store, error) {
+	dbPath := ledgerconfig.GetPvtdataStorePath()
+	exists, _, err := util.FileExists(dbPath)
+	if err != nil || !exists {
+		return nil, err
+	}
+	provider := pvtdatastorage.NewReadOnlyProvider(dbPath)
+	r.closers = append(r.closers, provider.Close)
+	return provider.OpenStore(r.channelID)
+}
+
+// close closes the stores in the reverse order they were opened
+func (r *ledgerReader) close() {
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		r.closers[i]()
+	}
+	r.closers = nil
+}
+
+func checkDBExists(dbName, dbPath string) error {
+	exists, _, err := util.FileExists(dbPath)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("The %s of the peer does not exist at [%s]", dbName, dbPath)
+	}
+	return nil
+}
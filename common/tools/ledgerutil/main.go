@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// command line flags
+var (
+	app = kingpin.New("ledgerutil", "Utility for inspecting the ledger of a stopped peer. The ledger is opened read-only and every JSON document is written to the standard output.")
+
+	fileSystemPath = app.Flag("fileSystemPath", "The file system path of the peer, that is, the peer.fileSystemPath of its configuration.").Default("/var/hyperledger/production").String()
+	channelID      = app.Flag("channelID", "The channel whose ledger is inspected.").Required().String()
+
+	blocks     = app.Command("blocks", "Writes a range of blocks, one JSON document per block.")
+	blocksFrom = blocks.Flag("from", "The number of the first block.").Default("0").Uint64()
+	blocksTo   = blocks.Flag("to", "The number of the last block. The last block of the ledger if not given.").Default(strconv.FormatUint(lastBlock, 10)).PlaceHolder("LAST").Uint64()
+
+	state         = app.Command("state", "Writes a key, or a range of keys, of the LevelDB state database, one JSON document per key.")
+	stateNs       = state.Flag("ns", "The namespace of the keys, that is, the name of the chaincode.").Required().String()
+	stateKey      = state.Flag("key", "The key. The range of keys is written if not given.").String()
+	stateStartKey = state.Flag("start", "The first key of the range, inclusive. The range starts at the first key of the namespace if not given.").String()
+	stateEndKey   = state.Flag("end", "The end of the range, exclusive. The range ends at the last key of the namespace if not given.").String()
+
+	history    = app.Command("history", "Writes the modifications of a key, one JSON document per modification, in the order they were committed.")
+	historyNs  = history.Flag("ns", "The namespace of the key, that is, the name of the chaincode.").Required().String()
+	historyKey = history.Flag("key", "The key.").Required().String()
+
+	txid   = app.Command("txid", "Writes a transaction with its validation code and its private data as a JSON document.")
+	txidID = txid.Arg("id", "The id of the transaction.").Required().String()
+
+	verifyHashchainCmd  = app.Command("verify-hashchain", "Verifies the hash chain of the blocks of the ledger.")
+	verifyHashchainFrom = verifyHashchainCmd.Flag("from", "The number of the first block to verify. A ledger bootstrapped from a snapshot has no blocks prior to the snapshot.").Default("0").Uint64()
+
+	compare          = app.Command("compare", "Compares the state of two snapshots of the ledger at the same block, as exported by peers, and writes the keys whose entries differ, one JSON document per key. The ledger of the peer is not opened.")
+	compareSnapshot1 = compare.Arg("snapshot1", "The directory of the first snapshot.").Required().String()
+	compareSnapshot2 = compare.Arg("snapshot2", "The directory of the second snapshot.").Required().String()
+)
+
+func main() {
+	command := kingpin.MustParse(app.Parse(os.Args[1:]))
+	if err := run(os.Stdout, command); err != nil {
+		app.Fatalf("Error running %s: %s", command, err)
+	}
+}
+
+// run opens the ledger, unless the command compares snapshots, and runs the given command, which writes its output to w
+func run(w io.Writer, command string) error {
+	if command == compare.FullCommand() {
+		return compareSnapshots(w, *channelID, *compareSnapshot1, *compareSnapshot2)
+	}
+
+	r, err := openLedger(*fileSystemPath, *channelID)
+	if err != nil {
+		return err
+	}
+	defer r.close()
+
+	switch command {
+	case blocks.FullCommand():
+		return dumpBlocks(w, r, *blocksFrom, *blocksTo)
+	case state.FullCommand():
+		return dumpState(w, r, *stateNs, *stateKey, *stateStartKey, *stateEndKey)
+	case history.FullCommand():
+		return dumpHistory(w, r, *historyNs, *historyKey)
+	case txid.FullCommand():
+		return dumpTx(w, r, *txidID)
+	case verifyHashchainCmd.FullCommand():
+		return verifyHashchain(w, r, *verifyHashchainFrom)
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	ledgertestutil.SetupCoreYAMLConfig()
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	os.Exit(m.Run())
+}
+
+// testBlockKVs are the keys of namespace ns1 updated by the blocks of the test ledger
+var testBlockKVs = []map[string]string{{"key1": "value1", "key2": "value2"}, {"key1": "value3"}}
+
+// createTestLedger creates a ledger with a genesis block and a block per map of keys, which
+// updates the keys of namespace ns1, and returns the ids of the transactions of the blocks
+func createTestLedger(t *testing.T, path string, blockKVs []map[string]string) []string {
+	viper.Set("peer.fileSystemPath", path)
+	provider, err := kvledger.NewProvider()
+	assert.NoError(t, err)
+	defer provider.Close()
+	bg, gb := testutil.NewBlockGenerator(t, "testchannel", false)
+	l, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer l.Close()
+
+	var txIDs []string
+	for _, kvs := range blockKVs {
+		txID := util.GenerateUUID()
+		simulator, err := l.NewTxSimulator(txID)
+		assert.NoError(t, err)
+		for key, value := range kvs {
+			assert.NoError(t, simulator.SetState("ns1", key, []byte(value)))
+		}
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		assert.NoError(t, err)
+		pubSimBytes, err := simRes.GetPubSimulationBytes()
+		assert.NoError(t, err)
+		block := bg.NextBlockWithTxid([][]byte{pubSimBytes}, []string{txID})
+		assert.NoError(t, l.CommitWithPvtData(&ledger.BlockAndPvtData{Block: block}))
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs
+}
+
+// exportTestSnapshot exports a snapshot of the test ledger created under the given path
+func exportTestSnapshot(t *testing.T, path string) string {
+	viper.Set("peer.fileSystemPath", path)
+	provider, err := kvledger.NewProvider()
+	assert.NoError(t, err)
+	defer provider.Close()
+	l, err := provider.Open("testchannel")
+	assert.NoError(t, err)
+	defer l.Close()
+	snapshotDir := filepath.Join(path, "snapshot")
+	assert.NoError(t, l.ExportSnapshot(snapshotDir))
+	return snapshotDir
+}
+
+// runCommand parses the given command line and runs the command
+func runCommand(t *testing.T, args ...string) (string, error) {
+	// kingpin keeps the values of the flags which have no default when they are not given
+	*stateKey, *stateStartKey, *stateEndKey = "", "", ""
+	command, err := app.Parse(args)
+	assert.NoError(t, err)
+	buf := &bytes.Buffer{}
+	err = run(buf, command)
+	return buf.String(), err
+}
+
+// decodeDocuments decodes the JSON documents which were written one after the other
+func decodeDocuments(t *testing.T, output string) []map[string]interface{} {
+	var docs []map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(output))
+	for decoder.More() {
+		doc := map[string]interface{}{}
+		assert.NoError(t, decoder.Decode(&doc))
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+func TestLedgerutil(t *testing.T) {
+	path, err := ioutil.TempDir("", "ledgerutil")
+	assert.NoError(t, err)
+	defer os.RemoveAll(path)
+	txIDs := createTestLedger(t, path, testBlockKVs)
+	flags := []string{"--fileSystemPath", path, "--channelID", "testchannel"}
+
+	output, err := runCommand(t, append(flags, "blocks", "--from", "1")...)
+	assert.NoError(t, err)
+	docs := decodeDocuments(t, output)
+	assert.Len(t, docs, 2)
+	assert.Equal(t, "2", docs[1]["header"].(map[string]interface{})["number"])
+	output, err = runCommand(t, append(flags, "blocks", "--from", "1", "--to", "1")...)
+	assert.NoError(t, err)
+	assert.Len(t, decodeDocuments(t, output), 1)
+	_, err = runCommand(t, append(flags, "blocks", "--from", "3")...)
+	assert.EqualError(t, err, "No blocks from block [3], the height of the ledger is [3]")
+
+	output, err = runCommand(t, append(flags, "state", "--ns", "ns1", "--key", "key1")...)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"namespace": "ns1", "key": "key1", "value": "value3", "block_num": 2.0, "tx_num": 0.0}},
+		decodeDocuments(t, output))
+	output, err = runCommand(t, append(flags, "state", "--ns", "ns1")...)
+	assert.NoError(t, err)
+	docs = decodeDocuments(t, output)
+	assert.Len(t, docs, 2)
+	assert.Equal(t, "key2", docs[1]["key"])
+	output, err = runCommand(t, append(flags, "state", "--ns", "ns1", "--start", "key2")...)
+	assert.NoError(t, err)
+	assert.Len(t, decodeDocuments(t, output), 1)
+	_, err = runCommand(t, append(flags, "state", "--ns", "ns1", "--key", "key3")...)
+	assert.EqualError(t, err, "Key [key3] does not exist in namespace [ns1]")
+
+	output, err = runCommand(t, append(flags, "history", "--ns", "ns1", "--key", "key1")...)
+	assert.NoError(t, err)
+	docs = decodeDocuments(t, output)
+	assert.Len(t, docs, 2)
+	assert.Equal(t, txIDs[0], docs[0]["tx_id"])
+	assert.Equal(t, "value3", docs[1]["value"])
+
+	output, err = runCommand(t, append(flags, "txid", txIDs[1])...)
+	assert.NoError(t, err)
+	docs = decodeDocuments(t, output)
+	assert.Len(t, docs, 1)
+	assert.Equal(t, 2.0, docs[0]["block_num"])
+	assert.Equal(t, "VALID", docs[0]["validation_code"])
+	assert.NotNil(t, docs[0]["envelope"])
+	_, err = runCommand(t, append(flags, "txid", "unknown")...)
+	assert.Error(t, err)
+
+	output, err = runCommand(t, append(flags, "verify-hashchain")...)
+	assert.NoError(t, err)
+	assert.Equal(t, "Verified the hash chain of blocks [0] to [2] of channel [testchannel]\n", output)
+
+	_, err = runCommand(t, "--fileSystemPath", path, "--channelID", "otherchannel", "verify-hashchain")
+	assert.EqualError(t, err, "Block store of ledger [otherchannel] does not exist")
+
+	// the ledger was not modified by the commands, and tampering with a block breaks the hash chain
+	blockfilePath := filepath.Join(path, "ledgersData", "chains", "chains", "testchannel", "blockfile_000000")
+	content, err := ioutil.ReadFile(blockfilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, bytes.Count(content, []byte("value3")))
+	assert.NoError(t, ioutil.WriteFile(blockfilePath, bytes.Replace(content, []byte("value3"), []byte("value9"), 1), 0660))
+	_, err = runCommand(t, append(flags, "verify-hashchain")...)
+	assert.EqualError(t, err, "The data of block [2] does not match the data hash of its header")
+}
+
+func TestMissingDatabases(t *testing.T) {
+	path, err := ioutil.TempDir("", "ledgerutil")
+	assert.NoError(t, err)
+	defer os.RemoveAll(path)
+	createTestLedger(t, path, testBlockKVs)
+	assert.NoError(t, os.RemoveAll(filepath.Join(path, "ledgersData", "historyLeveldb")))
+	flags := []string{"--fileSystemPath", path, "--channelID", "testchannel"}
+
+	_, err = runCommand(t, append(flags, "history", "--ns", "ns1", "--key", "key1")...)
+	assert.EqualError(t, err, "The history database of the peer does not exist at ["+filepath.Join(path, "ledgersData", "historyLeveldb")+"]")
+	_, err = runCommand(t, "--fileSystemPath", filepath.Join(path, "missing"), "--channelID", "testchannel", "verify-hashchain")
+	assert.EqualError(t, err, "Block storage does not exist at ["+filepath.Join(path, "missing", "ledgersData", "chains")+"]")
+}
+
+func TestCompare(t *testing.T) {
+	path, err := ioutil.TempDir("", "ledgerutil")
+	assert.NoError(t, err)
+	defer os.RemoveAll(path)
+	var snapshotDirs []string
+	for i, blockKVs := range [][]map[string]string{
+		testBlockKVs,
+		testBlockKVs,
+		{{"key1": "value1", "key2": "value2"}, {"key1": "value4", "key3": "value5"}},
+	} {
+		peerPath := filepath.Join(path, "peer"+strconv.Itoa(i))
+		createTestLedger(t, peerPath, blockKVs)
+		snapshotDirs = append(snapshotDirs, exportTestSnapshot(t, peerPath))
+	}
+	flags := []string{"--channelID", "testchannel", "compare"}
+
+	// the blocks of the ledgers differ, but their state is identical
+	output, err := runCommand(t, append(flags, snapshotDirs[0], snapshotDirs[1])...)
+	assert.NoError(t, err)
+	assert.Equal(t, "The state of the snapshots is identical at block [2] of channel [testchannel]\n", output)
+
+	output, err = runCommand(t, append(flags, snapshotDirs[0], snapshotDirs[2])...)
+	assert.EqualError(t, err, "The state of the snapshots differs for [2] keys")
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"namespace": "ns1",
+			"key":       "key1",
+			"snapshot1": map[string]interface{}{"value": "value3", "block_num": 2.0, "tx_num": 0.0},
+			"snapshot2": map[string]interface{}{"value": "value4", "block_num": 2.0, "tx_num": 0.0},
+		},
+		{
+			"namespace": "ns1",
+			"key":       "key3",
+			"snapshot1": nil,
+			"snapshot2": map[string]interface{}{"value": "value5", "block_num": 2.0, "tx_num": 0.0},
+		},
+	}, decodeDocuments(t, output))
+
+	_, err = runCommand(t, "--channelID", "otherchannel", "compare", snapshotDirs[0], snapshotDirs[1])
+	assert.EqualError(t, err, "Snapshot ["+snapshotDirs[0]+"] is of channel [testchannel]")
+}
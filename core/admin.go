@@ -17,22 +17,50 @@ limitations under the License.
 package core
 
 import (
+	"time"
+
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 )
 
 var log = flogging.MustGetLogger("server")
 
+// leadershipRequestTimeWindow is how far apart from the current time the
+// timestamp of a leadership request may be
+const leadershipRequestTimeWindow = 15 * time.Minute
+
 // NewAdminServer creates and returns a Admin service instance.
 func NewAdminServer() *ServerAdmin {
-	s := new(ServerAdmin)
+	s := &ServerAdmin{
+		yieldLeadership: func(channelID string) error {
+			return service.GetGossipService().YieldLeadership(channelID)
+		},
+		membershipView: func(channelID string) []discovery.MemberState {
+			return service.GetGossipService().MembershipView(common.ChainID(channelID))
+		},
+		localMSP:        mgmt.GetLocalMSP(),
+		principalGetter: mgmt.NewLocalMSPPrincipalGetter(),
+	}
 	return s
 }
 
 // ServerAdmin implementation of the Admin service for the Peer
 type ServerAdmin struct {
+	yieldLeadership func(channelID string) error
+	membershipView  func(channelID string) []discovery.MemberState
+	localMSP        msp.IdentityDeserializer
+	principalGetter mgmt.MSPPrincipalGetter
 }
 
 // GetStatus reports the status of the server
@@ -71,3 +99,93 @@ func (*ServerAdmin) RevertLogLevels(context.Context, *empty.Empty) (*empty.Empty
 
 	return &empty.Empty{}, err
 }
+
+// YieldLeadership makes the peer yield its leadership of the specified channel
+// to another peer of its organization. The request must be signed by an admin
+// of the local MSP of the peer. The peer takes the leadership back once the
+// yield times out, if its leader election strategy prefers it.
+func (s *ServerAdmin) YieldLeadership(ctx context.Context, env *cb.Envelope) (*empty.Empty, error) {
+	request, err := s.validateLeadershipRequest(env)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.yieldLeadership(request.ChannelId); err != nil {
+		return nil, err
+	}
+	log.Infof("Yielded the leadership of channel %s", request.ChannelId)
+	return &empty.Empty{}, nil
+}
+
+// validateLeadershipRequest extracts the leadership request from the given envelope,
+// and verifies that the envelope is recent and signed by an admin of the local MSP
+func (s *ServerAdmin) validateLeadershipRequest(env *cb.Envelope) (*pb.LeadershipRequest, error) {
+	if env == nil {
+		return nil, errors.New("envelope must be provided")
+	}
+	request := &pb.LeadershipRequest{}
+	chdr, err := utils.UnmarshalEnvelopeOfType(env, cb.HeaderType_MESSAGE, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed leadership request")
+	}
+	if request.ChannelId == "" {
+		return nil, errors.New("channel ID must be provided")
+	}
+	if chdr.ChannelId != request.ChannelId {
+		return nil, errors.Errorf("leadership request for channel %s has a channel header of channel %s", request.ChannelId, chdr.ChannelId)
+	}
+	if chdr.Timestamp == nil {
+		return nil, errors.New("timestamp must be provided")
+	}
+	reqTime := time.Unix(chdr.Timestamp.Seconds, int64(chdr.Timestamp.Nanos))
+	if reqTime.Before(time.Now().Add(-leadershipRequestTimeWindow)) || reqTime.After(time.Now().Add(leadershipRequestTimeWindow)) {
+		return nil, errors.Errorf("timestamp %s of leadership request is more than %s apart from the current time", reqTime, leadershipRequestTimeWindow)
+	}
+
+	sd, err := env.AsSignedData()
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed leadership request")
+	}
+	id, err := s.localMSP.DeserializeIdentity(sd[0].Identity)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed deserializing the creator of the leadership request")
+	}
+	principal, err := s.principalGetter.Get(mgmt.Admins)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed getting the admin principal of the local MSP")
+	}
+	if err := id.SatisfiesPrincipal(principal); err != nil {
+		return nil, errors.Wrap(err, "creator of the leadership request isn't an admin of the local MSP")
+	}
+	if err := id.Verify(sd[0].Data, sd[0].Signature); err != nil {
+		return nil, errors.Wrap(err, "invalid signature of the leadership request")
+	}
+	return request, nil
+}
+
+// GetGossipMembership returns the alive and the dead members of the specified channel,
+// as viewed by the gossip layer of the peer
+func (s *ServerAdmin) GetGossipMembership(ctx context.Context, request *pb.GossipMembershipRequest) (*pb.GossipMembership, error) {
+	if request.ChannelId == "" {
+		return nil, errors.New("channel ID must be provided")
+	}
+	view := s.membershipView(request.ChannelId)
+	if view == nil {
+		return nil, errors.Errorf("peer isn't a member of channel %s", request.ChannelId)
+	}
+
+	membership := &pb.GossipMembership{}
+	for _, member := range view {
+		membership.Members = append(membership.Members, &pb.GossipMember{
+			PkiId:            member.PKIid,
+			Endpoint:         member.Endpoint,
+			InternalEndpoint: member.InternalEndpoint,
+			Alive:            member.Alive,
+			LastAlive: &timestamp.Timestamp{
+				Seconds: member.LastSeen.Unix(),
+				Nanos:   int32(member.LastSeen.Nanosecond()),
+			},
+			LedgerHeight: member.Properties.GetLedgerHeight(),
+		})
+	}
+	return membership, nil
+}
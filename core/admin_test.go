@@ -19,11 +19,21 @@ package core
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/localmsp"
 	"github.com/hyperledger/fabric/core/testutil"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/gossip"
+	mspproto "github.com/hyperledger/fabric/protos/msp"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -68,3 +78,118 @@ func TestLoggingCalls(t *testing.T) {
 	assert.Equal(t, flogging.DefaultLevel(), logResponse.LogLevel, "log level should have been the default")
 	assert.Nil(t, err, "Error should have been nil")
 }
+
+type otherMSPPrincipalGetter struct{}
+
+func (otherMSPPrincipalGetter) Get(role string) (*mspproto.MSPPrincipal, error) {
+	return &mspproto.MSPPrincipal{
+		PrincipalClassification: mspproto.MSPPrincipal_ROLE,
+		Principal:               utils.MarshalOrPanic(&mspproto.MSPRole{Role: mspproto.MSPRole_ADMIN, MspIdentifier: "OtherMSP"}),
+	}, nil
+}
+
+func leadershipRequest(t *testing.T, headerChannelID, channelID string, timestamp time.Time) *cb.Envelope {
+	signer := localmsp.NewSigner()
+	chdr := utils.MakeChannelHeader(cb.HeaderType_MESSAGE, 0, headerChannelID, 0)
+	chdr.Timestamp.Seconds = timestamp.Unix()
+	shdr, err := signer.NewSignatureHeader()
+	assert.NoError(t, err)
+	payload := &cb.Payload{
+		Header: utils.MakePayloadHeader(chdr, shdr),
+		Data:   utils.MarshalOrPanic(&pb.LeadershipRequest{ChannelId: channelID}),
+	}
+	payloadBytes := utils.MarshalOrPanic(payload)
+	sig, err := signer.Sign(payloadBytes)
+	assert.NoError(t, err)
+	return &cb.Envelope{Payload: payloadBytes, Signature: sig}
+}
+
+func TestYieldLeadership(t *testing.T) {
+	assert.NoError(t, msptesttools.LoadMSPSetupForTesting())
+
+	var yielded []string
+	server := &ServerAdmin{
+		yieldLeadership: func(channelID string) error {
+			if channelID != "mychannel" {
+				return errors.Errorf("leader election isn't used for channel %s", channelID)
+			}
+			yielded = append(yielded, channelID)
+			return nil
+		},
+		localMSP:        mgmt.GetLocalMSP(),
+		principalGetter: mgmt.NewLocalMSPPrincipalGetter(),
+	}
+
+	_, err := server.YieldLeadership(context.Background(), nil)
+	assert.EqualError(t, err, "envelope must be provided")
+	_, err = server.YieldLeadership(context.Background(), &cb.Envelope{Payload: []byte{1, 2, 3}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "malformed leadership request")
+	_, err = server.YieldLeadership(context.Background(), leadershipRequest(t, "", "", time.Now()))
+	assert.EqualError(t, err, "channel ID must be provided")
+	_, err = server.YieldLeadership(context.Background(), leadershipRequest(t, "otherchannel", "mychannel", time.Now()))
+	assert.EqualError(t, err, "leadership request for channel mychannel has a channel header of channel otherchannel")
+	_, err = server.YieldLeadership(context.Background(), leadershipRequest(t, "mychannel", "mychannel", time.Now().Add(-time.Hour)))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "apart from the current time")
+
+	env := leadershipRequest(t, "mychannel", "mychannel", time.Now())
+	env.Signature[len(env.Signature)-1]++
+	_, err = server.YieldLeadership(context.Background(), env)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid signature of the leadership request")
+
+	nonAdminServer := &ServerAdmin{localMSP: mgmt.GetLocalMSP(), principalGetter: otherMSPPrincipalGetter{}}
+	_, err = nonAdminServer.YieldLeadership(context.Background(), leadershipRequest(t, "mychannel", "mychannel", time.Now()))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "creator of the leadership request isn't an admin of the local MSP")
+
+	_, err = server.YieldLeadership(context.Background(), leadershipRequest(t, "otherchannel", "otherchannel", time.Now()))
+	assert.EqualError(t, err, "leader election isn't used for channel otherchannel")
+	_, err = server.YieldLeadership(context.Background(), leadershipRequest(t, "mychannel", "mychannel", time.Now()))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mychannel"}, yielded)
+}
+
+func TestGetGossipMembership(t *testing.T) {
+	lastSeen := time.Now()
+	server := &ServerAdmin{membershipView: func(channelID string) []discovery.MemberState {
+		if channelID != "mychannel" {
+			return nil
+		}
+		return []discovery.MemberState{
+			{
+				NetworkMember: discovery.NetworkMember{
+					Endpoint:   "peer1:7051",
+					PKIid:      []byte{1},
+					Properties: &gossip.Properties{LedgerHeight: 10},
+				},
+				Alive:    true,
+				LastSeen: lastSeen,
+			},
+			{
+				NetworkMember: discovery.NetworkMember{
+					Endpoint: "peer2:7051",
+					PKIid:    []byte{2},
+				},
+				LastSeen: lastSeen,
+			},
+		}
+	}}
+
+	_, err := server.GetGossipMembership(context.Background(), &pb.GossipMembershipRequest{})
+	assert.EqualError(t, err, "channel ID must be provided")
+	_, err = server.GetGossipMembership(context.Background(), &pb.GossipMembershipRequest{ChannelId: "otherchannel"})
+	assert.EqualError(t, err, "peer isn't a member of channel otherchannel")
+	membership, err := server.GetGossipMembership(context.Background(), &pb.GossipMembershipRequest{ChannelId: "mychannel"})
+	assert.NoError(t, err)
+	assert.Len(t, membership.Members, 2)
+	assert.Equal(t, "peer1:7051", membership.Members[0].Endpoint)
+	assert.Equal(t, []byte{1}, membership.Members[0].PkiId)
+	assert.True(t, membership.Members[0].Alive)
+	assert.Equal(t, uint64(10), membership.Members[0].LedgerHeight)
+	assert.Equal(t, lastSeen.Unix(), membership.Members[0].LastAlive.Seconds)
+	assert.Equal(t, int32(lastSeen.Nanosecond()), membership.Members[0].LastAlive.Nanos)
+	assert.False(t, membership.Members[1].Alive)
+	assert.Equal(t, uint64(0), membership.Members[1].LedgerHeight)
+}
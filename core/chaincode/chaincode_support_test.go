@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	mocklgr "github.com/hyperledger/fabric/common/mocks/ledger"
@@ -81,6 +82,18 @@ func (meqe *mockExecQuerySimulator) GetHistoryForKey(namespace, query string) (c
 	return meqe.commonQuery(namespace, query)
 }
 
+func (meqe *mockExecQuerySimulator) GetHistoryForKeyWithOptions(namespace, query string, startBlock, endBlock uint64, reverse bool, pageSize int32, bookmark string) (ledger.HistoryResultsIterator, error) {
+	return nil, fmt.Errorf("history options not supported")
+}
+
+func (meqe *mockExecQuerySimulator) GetHistoryForKeySince(namespace, query string, since *timestamp.Timestamp, reverse bool, pageSize int32, bookmark string) (ledger.HistoryResultsIterator, error) {
+	return nil, fmt.Errorf("history options not supported")
+}
+
+func (meqe *mockExecQuerySimulator) GetPrivateDataHashHistoryForKey(namespace, collection string, keyHash []byte) (commonledger.ResultsIterator, error) {
+	return nil, fmt.Errorf("private data hash history not supported")
+}
+
 func (meqe *mockExecQuerySimulator) ExecuteQuery(namespace, query string) (commonledger.ResultsIterator, error) {
 	return meqe.commonQuery(namespace, query)
 }
@@ -792,6 +792,49 @@ func getQueryResponse(handler *Handler, txContext *transactionContext, iter comm
 	return &pb.QueryResponse{Results: queryResultsBytes, HasMore: queryResult != nil, Id: iterID}, nil
 }
 
+//getPaginatedQueryResponse takes an iterator over a page of results and constructs a QueryResponse
+//with all the results of the page, whose metadata holds the bookmark of the next page
+func getPaginatedQueryResponse(iter ledger.HistoryResultsIterator) (*pb.QueryResponse, error) {
+	var queryResultsBytes []*pb.QueryResultBytes
+	for {
+		queryResult, err := iter.Next()
+		if err != nil {
+			iter.Close()
+			chaincodeLogger.Errorf("Failed to get query result from iterator")
+			return nil, err
+		}
+		if queryResult == nil {
+			break
+		}
+		resultBytes, err := proto.Marshal(queryResult.(proto.Message))
+		if err != nil {
+			iter.Close()
+			chaincodeLogger.Errorf("Failed to get encode query result as bytes")
+			return nil, err
+		}
+		queryResultsBytes = append(queryResultsBytes, &pb.QueryResultBytes{ResultBytes: resultBytes})
+	}
+
+	metadata := &pb.QueryResponseMetadata{FetchedRecordsCount: int32(len(queryResultsBytes)), Bookmark: iter.GetBookmarkAndClose()}
+	metadataBytes, err := proto.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.QueryResponse{Results: queryResultsBytes, HasMore: false, Metadata: metadataBytes}, nil
+}
+
+//getHistoryIterator returns an iterator over the history of the key of a GetHistoryForKey request,
+//which starts at the start time of the request if it is set, or at its start block otherwise
+func getHistoryIterator(historyQueryExecutor ledger.HistoryQueryExecutor, namespace string,
+	getHistoryForKey *pb.GetHistoryForKey) (ledger.HistoryResultsIterator, error) {
+	if getHistoryForKey.StartTime != nil {
+		return historyQueryExecutor.GetHistoryForKeySince(namespace, getHistoryForKey.Key, getHistoryForKey.StartTime,
+			getHistoryForKey.Reverse, getHistoryForKey.PageSize, getHistoryForKey.Bookmark)
+	}
+	return historyQueryExecutor.GetHistoryForKeyWithOptions(namespace, getHistoryForKey.Key, getHistoryForKey.StartBlock,
+		getHistoryForKey.EndBlock, getHistoryForKey.Reverse, getHistoryForKey.PageSize, getHistoryForKey.Bookmark)
+}
+
 // afterQueryStateNext handles a QUERY_STATE_NEXT request from the chaincode.
 func (handler *Handler) afterQueryStateNext(e *fsm.Event, state string) {
 	msg, ok := e.Args[0].(*pb.ChaincodeMessage)
@@ -1104,16 +1147,38 @@ func (handler *Handler) handleGetHistoryForKey(msg *pb.ChaincodeMessage) {
 		}
 		chaincodeID := handler.getCCRootName()
 
-		historyIter, err := txContext.historyQueryExecutor.GetHistoryForKey(chaincodeID, getHistoryForKey.Key)
-		if err != nil {
-			errHandler([]byte(err.Error()), nil, "Failed to get ledger history iterator. Sending %s", pb.ChaincodeMessage_ERROR)
-			return
-		}
+		var historyIter commonledger.ResultsIterator
+		var payload *pb.QueryResponse
+		var err error
+		if getHistoryForKey.PageSize > 0 {
+			// a page of the history is sent in a single response, along with the bookmark of the next page
+			var pageIter ledger.HistoryResultsIterator
+			pageIter, err = getHistoryIterator(txContext.historyQueryExecutor, chaincodeID, getHistoryForKey)
+			if err != nil {
+				errHandler([]byte(err.Error()), nil, "Failed to get ledger history iterator. Sending %s", pb.ChaincodeMessage_ERROR)
+				return
+			}
+			payload, err = getPaginatedQueryResponse(pageIter)
+			if err != nil {
+				errHandler([]byte(err.Error()), nil, "Failed to get query result. Sending %s", pb.ChaincodeMessage_ERROR)
+				return
+			}
+		} else {
+			if getHistoryForKey.StartBlock == 0 && getHistoryForKey.EndBlock == 0 && !getHistoryForKey.Reverse &&
+				getHistoryForKey.Bookmark == "" && getHistoryForKey.StartTime == nil {
+				historyIter, err = txContext.historyQueryExecutor.GetHistoryForKey(chaincodeID, getHistoryForKey.Key)
+			} else {
+				historyIter, err = getHistoryIterator(txContext.historyQueryExecutor, chaincodeID, getHistoryForKey)
+			}
+			if err != nil {
+				errHandler([]byte(err.Error()), nil, "Failed to get ledger history iterator. Sending %s", pb.ChaincodeMessage_ERROR)
+				return
+			}
 
-		handler.putQueryIterator(txContext, iterID, historyIter)
+			handler.putQueryIterator(txContext, iterID, historyIter)
 
-		var payload *pb.QueryResponse
-		payload, err = getQueryResponse(handler, txContext, historyIter, iterID)
+			payload, err = getQueryResponse(handler, txContext, historyIter, iterID)
+		}
 
 		if err != nil {
 			errHandler([]byte(err.Error()), historyIter, "Failed to get query result. Sending %s", pb.ChaincodeMessage_ERROR)
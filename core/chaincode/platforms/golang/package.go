@@ -148,3 +148,39 @@ func findSource(gopath, pkg string) (SourceMap, error) {
 
 	return sources, nil
 }
+
+// findMetadata finds the files of the META-INF directory of the package, such as the
+// definitions of the indexes of the state database, which are packaged under META-INF
+func findMetadata(gopath, pkg string) (SourceMap, error) {
+	sources := make(SourceMap)
+	tld := filepath.Join(gopath, "src", pkg, "META-INF")
+	if _, err := os.Stat(tld); os.IsNotExist(err) {
+		return sources, nil
+	}
+	walkFn := func(path string, info os.FileInfo, err error) error {
+
+		if err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(tld, path)
+		if err != nil {
+			return fmt.Errorf("error obtaining relative path for %s: %s", path, err)
+		}
+
+		name := filepath.ToSlash(filepath.Join("META-INF", rel))
+		sources[name] = SourceDescriptor{Name: name, Path: path, Info: info}
+
+		return nil
+	}
+
+	if err := filepath.Walk(tld, walkFn); err != nil {
+		return nil, fmt.Errorf("Error walking directory: %s", err)
+	}
+
+	return sources, nil
+}
@@ -139,7 +139,11 @@ func (goPlatform *Platform) ValidateDeploymentSpec(cds *pb.ChaincodeDeploymentSp
 	// the container itself needs to be the last line of defense and be configured to be
 	// resilient in enforcing constraints. However, we should still do our best to keep as much
 	// garbage out of the system as possible.
+	//
+	// The metadata of the chaincode, such as the definitions of its state database indexes,
+	// is packaged under META-INF, which is outside of the source-code and isn't compiled.
 	re := regexp.MustCompile(`(/)?src/.*`)
+	metadataRe := regexp.MustCompile(`^META-INF/.*`)
 	is := bytes.NewReader(cds.CodePackage)
 	gr, err := gzip.NewReader(is)
 	if err != nil {
@@ -157,7 +161,7 @@ func (goPlatform *Platform) ValidateDeploymentSpec(cds *pb.ChaincodeDeploymentSp
 		// --------------------------------------------------------------------------------------
 		// Check name for conforming path
 		// --------------------------------------------------------------------------------------
-		if !re.MatchString(header.Name) {
+		if !re.MatchString(header.Name) && !metadataRe.MatchString(header.Name) {
 			return fmt.Errorf("illegal file detected in payload: \"%s\"", header.Name)
 		}
 
@@ -411,6 +415,18 @@ func (goPlatform *Platform) GetDeploymentPayload(spec *pb.ChaincodeSpec) ([]byte
 	// --------------------------------------------------------------------------------------
 	vendorDependencies(code.Pkg, files)
 
+	// --------------------------------------------------------------------------------------
+	// Add the metadata found in the META-INF directory of our primary package, which
+	// isn't vendored
+	// --------------------------------------------------------------------------------------
+	metadata, err := findMetadata(code.Gopath, code.Pkg)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range metadata {
+		files = append(files, file)
+	}
+
 	// --------------------------------------------------------------------------------------
 	// Sort on the filename so the tarball at least looks sane in terms of package grouping
 	// --------------------------------------------------------------------------------------
@@ -85,6 +85,8 @@ func TestValidateCDS(t *testing.T) {
 	specs = append(specs, spec{CCName: "NoCode", Path: "path/to/nowhere", File: "/bin/warez", Mode: 0100400, SuccessExpected: false})
 	specs = append(specs, spec{CCName: "NoCode", Path: "path/to/somewhere", File: "/src/path/to/somewhere/main.go", Mode: 0100400, SuccessExpected: true})
 	specs = append(specs, spec{CCName: "NoCode", Path: "path/to/somewhere", File: "/src/path/to/somewhere/warez", Mode: 0100555, SuccessExpected: false})
+	specs = append(specs, spec{CCName: "NoCode", Path: "path/to/somewhere", File: "META-INF/statedb/couchdb/indexes/index.json", Mode: 0100400, SuccessExpected: true})
+	specs = append(specs, spec{CCName: "NoCode", Path: "path/to/somewhere", File: "/bin/META-INF/warez", Mode: 0100400, SuccessExpected: false})
 
 	for _, s := range specs {
 		cds, err := generateFakeCDS(s.CCName, s.Path, s.File, s.Mode)
@@ -135,6 +137,22 @@ func Test_findSource(t *testing.T) {
 	}
 }
 
+func Test_findMetadata(t *testing.T) {
+	gopath, err := getGopath()
+	if err != nil {
+		t.Errorf("failed to get GOPATH: %s", err)
+	}
+
+	metadata, err := findMetadata(gopath, "github.com/hyperledger/fabric/examples/chaincode/go/marbles02")
+	assert.NoError(t, err)
+	assert.Len(t, metadata, 1)
+	assert.Contains(t, metadata, "META-INF/statedb/couchdb/indexes/indexOwner.json")
+
+	metadata, err = findMetadata(gopath, "github.com/hyperledger/fabric/examples/chaincode/go/map")
+	assert.NoError(t, err)
+	assert.Empty(t, metadata)
+}
+
 func Test_DeploymentPayload(t *testing.T) {
 	platform := &Platform{}
 	spec := &pb.ChaincodeSpec{
@@ -491,6 +491,44 @@ func (stub *ChaincodeStub) GetHistoryForKey(key string) (HistoryQueryIteratorInt
 	return &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.TxID, response, 0}}, nil
 }
 
+// GetHistoryForKeyWithOptions documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyWithOptions(key string, startBlock uint64, endBlock uint64, reverse bool,
+	pageSize int32, bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	if endBlock != 0 && startBlock > endBlock {
+		return nil, nil, errors.Errorf("start block %d is after end block %d", startBlock, endBlock)
+	}
+	request := &pb.GetHistoryForKey{Key: key, StartBlock: startBlock, EndBlock: endBlock, Reverse: reverse,
+		PageSize: pageSize, Bookmark: bookmark}
+	return stub.getHistoryForKeyWithOptions(request)
+}
+
+// GetHistoryForKeySince documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeySince(key string, since *timestamp.Timestamp, reverse bool,
+	pageSize int32, bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	if since == nil {
+		return nil, nil, errors.New("the time since which to retrieve the history is not set")
+	}
+	request := &pb.GetHistoryForKey{Key: key, StartTime: since, Reverse: reverse, PageSize: pageSize, Bookmark: bookmark}
+	return stub.getHistoryForKeyWithOptions(request)
+}
+
+// getHistoryForKeyWithOptions sends a history request and returns an iterator over the response, along with
+// the metadata of the response if the request is for a page of the history
+func (stub *ChaincodeStub) getHistoryForKeyWithOptions(request *pb.GetHistoryForKey) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	response, err := stub.handler.handleGetHistoryForKeyWithOptions(request, stub.TxID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var metadata *pb.QueryResponseMetadata
+	if request.PageSize > 0 {
+		metadata = &pb.QueryResponseMetadata{}
+		if err = proto.Unmarshal(response.Metadata, metadata); err != nil {
+			return nil, nil, errors.Errorf("[%s]unmarshal error", shorttxid(stub.TxID))
+		}
+	}
+	return &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.TxID, response, 0}}, metadata, nil
+}
+
 //CreateCompositeKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
 	return createCompositeKey(objectType, attributes)
@@ -668,6 +668,10 @@ func (handler *Handler) handleGetQueryResult(query string, txid string) (*pb.Que
 }
 
 func (handler *Handler) handleGetHistoryForKey(key string, txid string) (*pb.QueryResponse, error) {
+	return handler.handleGetHistoryForKeyWithOptions(&pb.GetHistoryForKey{Key: key}, txid)
+}
+
+func (handler *Handler) handleGetHistoryForKeyWithOptions(getHistoryForKey *pb.GetHistoryForKey, txid string) (*pb.QueryResponse, error) {
 	// Create the channel on which to communicate the response from validating peer
 	var respChan chan pb.ChaincodeMessage
 	var err error
@@ -679,7 +683,7 @@ func (handler *Handler) handleGetHistoryForKey(key string, txid string) (*pb.Que
 
 	// Send GET_HISTORY_FOR_KEY message to validator chaincode support
 	//we constructed a valid object. No need to check for error
-	payloadBytes, _ := proto.Marshal(&pb.GetHistoryForKey{Key: key})
+	payloadBytes, _ := proto.Marshal(getHistoryForKey)
 
 	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY, Payload: payloadBytes, Txid: txid}
 	chaincodeLogger.Debugf("[%s]Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_HISTORY_FOR_KEY)
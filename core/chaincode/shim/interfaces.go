@@ -163,6 +163,28 @@ type ChaincodeStubInterface interface {
 	// update ledger, and should limit use to read-only chaincode operations.
 	GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error)
 
+	// GetHistoryForKeyWithOptions returns the history of key values which were
+	// written between startBlock and endBlock, both inclusive, where an endBlock
+	// of 0 means the history isn't bounded by an end block. The history is
+	// returned from the oldest to the latest value, or from the latest to the
+	// oldest value if reverse is true.
+	// If pageSize is positive, at most pageSize values are returned, and the
+	// returned metadata holds the number of fetched values along with the
+	// bookmark to pass to retrieve the next page, which is empty when there are
+	// no more values. An empty bookmark retrieves the first page. The metadata
+	// is nil if pageSize isn't positive.
+	// Like GetHistoryForKey, it requires the history database to be enabled,
+	// and should only be used as part of read-only chaincode operations.
+	GetHistoryForKeyWithOptions(key string, startBlock uint64, endBlock uint64, reverse bool,
+		pageSize int32, bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
+	// GetHistoryForKeySince returns the history of key values which were
+	// written by the transactions created at or after the given time, as given
+	// by the timestamps the clients set in the transactions. The history is
+	// ordered and paginated like the one of GetHistoryForKeyWithOptions.
+	GetHistoryForKeySince(key string, since *timestamp.Timestamp, reverse bool,
+		pageSize int32, bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
 	// GetCreator returns `SignatureHeader.Creator` (e.g. an identity)
 	// of the `SignedProposal`. This is the identity of the agent (or user)
 	// submitting the transaction.
@@ -237,6 +237,20 @@ func (stub *MockStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterfac
 	return nil, errors.New("not implemented")
 }
 
+// GetHistoryForKeyWithOptions function can be invoked by a chaincode to return a range
+// or a page of the history of key values. It is intended to be used for read-only queries.
+func (stub *MockStub) GetHistoryForKeyWithOptions(key string, startBlock uint64, endBlock uint64, reverse bool,
+	pageSize int32, bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+// GetHistoryForKeySince function can be invoked by a chaincode to return the history
+// of key values since a given time. It is intended to be used for read-only queries.
+func (stub *MockStub) GetHistoryForKeySince(key string, since *timestamp.Timestamp, reverse bool,
+	pageSize int32, bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
 //GetStateByPartialCompositeKey function can be invoked by a chaincode to query the
 //state based on a given partial composite key. This function returns an
 //iterator which can be used to iterate over all composite keys whose prefix
@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bytes"
+	"crypto/tls"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// ExtractCertificateHashFromContext extracts the hash of the TLS certificate
+// the remote client presented on the connection of the given context,
+// or nil if the client didn't present one
+func ExtractCertificateHashFromContext(ctx context.Context) []byte {
+	pr, extracted := peer.FromContext(ctx)
+	if !extracted || pr.AuthInfo == nil {
+		return nil
+	}
+	tlsInfo, isTLSConn := pr.AuthInfo.(credentials.TLSInfo)
+	if !isTLSConn {
+		return nil
+	}
+	certs := tlsInfo.State.PeerCertificates
+	if len(certs) == 0 || len(certs[0].Raw) == 0 {
+		return nil
+	}
+	return util.ComputeSHA256(certs[0].Raw)
+}
+
+// ClientCertificateHashFromContext returns the hash of the TLS certificate
+// the local client presented on the connection of the given client stream
+// context, or nil if it didn't present one. Only the certificates presented
+// with the credentials of GetDeliverServiceCredentials are known
+func ClientCertificateHashFromContext(ctx context.Context) []byte {
+	pr, extracted := peer.FromContext(ctx)
+	if !extracted {
+		return nil
+	}
+	tlsInfo, isClientTLSConn := pr.AuthInfo.(clientTLSInfo)
+	if !isClientTLSConn {
+		return nil
+	}
+	return CertificateHash(tlsInfo.clientCert)
+}
+
+// CertificateHash returns the hash of the given TLS certificate, which binds
+// the messages sent over the connections it is presented on, or nil if there
+// is no certificate
+func CertificateHash(cert *tls.Certificate) []byte {
+	if cert == nil || len(cert.Certificate) == 0 {
+		return nil
+	}
+	return util.ComputeSHA256(cert.Certificate[0])
+}
+
+// VerifyTLSBinding checks that the TLS certificate hash claimed in the channel
+// header matches the TLS client certificate of the connection the message was
+// received on, so that a signed message can't be replayed by another client
+func VerifyTLSBinding(ctx context.Context, chdr *common.ChannelHeader) error {
+	actualHash := ExtractCertificateHashFromContext(ctx)
+	if len(actualHash) == 0 {
+		return errors.New("client didn't send a TLS certificate")
+	}
+	if len(chdr.TlsCertHash) == 0 {
+		return errors.New("message doesn't contain a TLS certificate hash")
+	}
+	if !bytes.Equal(actualHash, chdr.TlsCertHash) {
+		return errors.Errorf("claimed TLS certificate hash is %x but actual TLS certificate hash is %x",
+			chdr.TlsCertHash, actualHash)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func tlsContext(certs ...*x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{PeerCertificates: certs},
+		},
+	})
+}
+
+func TestExtractCertificateHashFromContext(t *testing.T) {
+	assert.Nil(t, ExtractCertificateHashFromContext(context.Background()))
+	assert.Nil(t, ExtractCertificateHashFromContext(peer.NewContext(context.Background(), &peer.Peer{})))
+	assert.Nil(t, ExtractCertificateHashFromContext(tlsContext()))
+	assert.Nil(t, ExtractCertificateHashFromContext(tlsContext(&x509.Certificate{})))
+
+	cert := &x509.Certificate{Raw: []byte("certificate")}
+	assert.Equal(t, util.ComputeSHA256(cert.Raw), ExtractCertificateHashFromContext(tlsContext(cert)))
+}
+
+func TestClientCertificateHashFromContext(t *testing.T) {
+	assert.Nil(t, ClientCertificateHashFromContext(context.Background()))
+	// The certificates of the remote peer aren't the ones the client presented
+	assert.Nil(t, ClientCertificateHashFromContext(tlsContext(&x509.Certificate{Raw: []byte("certificate")})))
+
+	cert := &tls.Certificate{Certificate: [][]byte{[]byte("certificate")}}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: clientTLSInfo{clientCert: cert}})
+	assert.Equal(t, CertificateHash(cert), ClientCertificateHashFromContext(ctx))
+}
+
+func TestVerifyTLSBinding(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte("certificate")}
+	ctx := tlsContext(cert)
+
+	err := VerifyTLSBinding(context.Background(), &common.ChannelHeader{TlsCertHash: util.ComputeSHA256(cert.Raw)})
+	assert.EqualError(t, err, "client didn't send a TLS certificate")
+
+	err = VerifyTLSBinding(ctx, &common.ChannelHeader{})
+	assert.EqualError(t, err, "message doesn't contain a TLS certificate hash")
+
+	err = VerifyTLSBinding(ctx, &common.ChannelHeader{TlsCertHash: util.ComputeSHA256([]byte("another certificate"))})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "claimed TLS certificate hash is")
+
+	err = VerifyTLSBinding(ctx, &common.ChannelHeader{TlsCertHash: util.ComputeSHA256(cert.Raw)})
+	assert.NoError(t, err)
+}
+
+func TestCertificateHash(t *testing.T) {
+	assert.Nil(t, CertificateHash(nil))
+	assert.Nil(t, CertificateHash(&tls.Certificate{}))
+
+	cert := &tls.Certificate{Certificate: [][]byte{[]byte("certificate"), []byte("CA certificate")}}
+	assert.Equal(t, util.ComputeSHA256([]byte("certificate")), CertificateHash(cert))
+}
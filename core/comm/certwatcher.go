@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+)
+
+// CertificateWatcher keeps track of a TLS certificate and key pair stored
+// on the file system, and reloads it when the files are modified.
+// Listeners registered via OnReload are notified with the new certificate,
+// so that servers and clients can start presenting it on new connections
+// without being restarted.
+type CertificateWatcher struct {
+	certFile string
+	keyFile  string
+
+	lock      sync.RWMutex
+	cert      tls.Certificate
+	certPEM   []byte
+	keyPEM    []byte
+	listeners []func(tls.Certificate)
+
+	stopOnce sync.Once
+	stopChan chan struct{}
+}
+
+// NewCertificateWatcher creates a CertificateWatcher for the given
+// certificate and key files, and loads them
+func NewCertificateWatcher(certFile, keyFile string) (*CertificateWatcher, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("both certificate and key files must be specified")
+	}
+	w := &CertificateWatcher{
+		certFile: certFile,
+		keyFile:  keyFile,
+		stopChan: make(chan struct{}),
+	}
+	if _, err := w.Reload(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Certificate returns the TLS certificate which was last loaded
+func (w *CertificateWatcher) Certificate() tls.Certificate {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.cert
+}
+
+// OnReload registers a function which is invoked with the new
+// certificate each time it is reloaded
+func (w *CertificateWatcher) OnReload(f func(cert tls.Certificate)) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.listeners = append(w.listeners, f)
+}
+
+// Reload reads the certificate and key files, and if they changed since
+// they were last loaded, replaces the current certificate and notifies
+// the listeners. It returns whether the certificate was replaced.
+// If the files cannot be read or do not form a valid key pair, the
+// current certificate is kept.
+func (w *CertificateWatcher) Reload() (bool, error) {
+	certPEM, err := ioutil.ReadFile(w.certFile)
+	if err != nil {
+		return false, fmt.Errorf("failed reading TLS certificate: %s", err)
+	}
+	keyPEM, err := ioutil.ReadFile(w.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed reading TLS key: %s", err)
+	}
+
+	w.lock.Lock()
+	if bytes.Equal(certPEM, w.certPEM) && bytes.Equal(keyPEM, w.keyPEM) {
+		w.lock.Unlock()
+		return false, nil
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		w.lock.Unlock()
+		return false, fmt.Errorf("failed loading TLS key pair: %s", err)
+	}
+	reloaded := w.certPEM != nil
+	w.cert, w.certPEM, w.keyPEM = cert, certPEM, keyPEM
+	listeners := append([]func(tls.Certificate){}, w.listeners...)
+	w.lock.Unlock()
+
+	if reloaded {
+		commLogger.Infof("Reloaded TLS certificate from %s", w.certFile)
+	}
+	for _, listener := range listeners {
+		listener(cert)
+	}
+	return true, nil
+}
+
+// Start checks the certificate and key files for changes every interval,
+// until Stop is called
+func (w *CertificateWatcher) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := w.Reload(); err != nil {
+					// The files may be in the middle of being replaced
+					commLogger.Warningf("Failed reloading TLS certificate, keeping the current one: %s", err)
+				}
+			case <-w.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops checking the certificate and key files for changes
+func (w *CertificateWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopChan)
+	})
+}
@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func copyKeyPair(t *testing.T, dir string, server int) {
+	for _, suffix := range []string{"cert", "key"} {
+		b, err := ioutil.ReadFile(filepath.Join("testdata", "certs", fmt.Sprintf("Org1-server%d-%s.pem", server, suffix)))
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, suffix+".pem"), b, 0600))
+	}
+}
+
+func TestCertificateWatcher(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "certwatcher")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	_, err = NewCertificateWatcher("", keyFile)
+	assert.Error(t, err)
+	_, err = NewCertificateWatcher(certFile, keyFile)
+	assert.Error(t, err, "the files do not exist yet")
+
+	copyKeyPair(t, dir, 1)
+	w, err := NewCertificateWatcher(certFile, keyFile)
+	assert.NoError(t, err)
+	defer w.Stop()
+	cert1 := w.Certificate()
+	assert.NotEmpty(t, cert1.Certificate)
+
+	reloaded := make(chan tls.Certificate, 10)
+	w.OnReload(func(cert tls.Certificate) {
+		reloaded <- cert
+	})
+
+	// Nothing changed
+	changed, err := w.Reload()
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	// A certificate which doesn't match the key is not loaded
+	b, err := ioutil.ReadFile(filepath.Join("testdata", "certs", "Org1-server2-cert.pem"))
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(certFile, b, 0600))
+	changed, err = w.Reload()
+	assert.Error(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, cert1, w.Certificate())
+
+	// Once the key is replaced too, the new pair is picked up by the watcher
+	w.Start(10 * time.Millisecond)
+	copyKeyPair(t, dir, 2)
+	select {
+	case cert := <-reloaded:
+		assert.NotEqual(t, cert1.Certificate[0], cert.Certificate[0])
+		assert.Equal(t, cert, w.Certificate())
+	case <-time.After(5 * time.Second):
+		t.Fatal("Certificate wasn't reloaded")
+	}
+
+	w.Stop()
+	w.Stop()
+}
+
+func TestCASupportClientCertificate(t *testing.T) {
+	cas := &CASupport{
+		OrdererRootCAsByChain: map[string][][]byte{"testchain": {}},
+	}
+	assert.Nil(t, cas.ClientCertificate())
+	cert, err := cas.getClientCertificate(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, cert.Certificate)
+
+	clientCert, err := tls.LoadX509KeyPair(filepath.Join("testdata", "certs", "Org1-client1-cert.pem"),
+		filepath.Join("testdata", "certs", "Org1-client1-key.pem"))
+	assert.NoError(t, err)
+	cas.SetClientCertificate(clientCert)
+	assert.Equal(t, &clientCert, cas.ClientCertificate())
+	cert, err = cas.getClientCertificate(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, clientCert, *cert)
+
+	_, err = cas.GetDeliverServiceCredentials("testchain")
+	assert.NoError(t, err)
+}
@@ -38,6 +38,8 @@ type CASupport struct {
 	OrdererRootCAsByChain map[string][][]byte
 	ClientRootCAs         [][]byte
 	ServerRootCAs         [][]byte
+	// clientCert is the certificate presented when acting as a TLS client
+	clientCert *tls.Certificate
 }
 
 // GetCASupport returns the singleton CASupport instance
@@ -76,6 +78,34 @@ func (cas *CASupport) GetServerRootCAs() (appRootCAs, ordererRootCAs [][]byte) {
 	return appRootCAs, ordererRootCAs
 }
 
+// SetClientCertificate sets the certificate presented when acting as a TLS
+// client. It can be called again when the certificate is rotated, and the
+// new certificate is then presented on subsequent connections.
+func (cas *CASupport) SetClientCertificate(cert tls.Certificate) {
+	cas.Lock()
+	defer cas.Unlock()
+	cas.clientCert = &cert
+}
+
+// ClientCertificate returns the certificate presented when acting as a TLS
+// client, or nil if none was set
+func (cas *CASupport) ClientCertificate() *tls.Certificate {
+	cas.RLock()
+	defer cas.RUnlock()
+	return cas.clientCert
+}
+
+// getClientCertificate is used as the tls.Config GetClientCertificate
+// callback, so that the current client certificate is looked up on
+// each handshake
+func (cas *CASupport) getClientCertificate(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	if cert := cas.ClientCertificate(); cert != nil {
+		return cert, nil
+	}
+	// no certificate is sent
+	return &tls.Certificate{}, nil
+}
+
 // GetDeliverServiceCredentials returns GRPC transport credentials for given channel to be used by GRPC
 // clients which communicate with ordering service endpoints.
 // If the channel isn't found, error is returned.
@@ -107,7 +137,9 @@ func (cas *CASupport) GetDeliverServiceCredentials(channelID string) (credential
 		}
 	}
 	tlsConfig.RootCAs = certPool
-	creds = credentials.NewTLS(tlsConfig)
+	// the certificate presented on each connection is recorded,
+	// see ClientCertificateHashFromContext
+	creds = newClientTransportCredentials(tlsConfig, cas.getClientCertificate)
 	return creds, nil
 }
 
@@ -190,23 +222,48 @@ func NewClientConnectionWithAddress(peerAddress string, block bool, tslEnabled b
 	return conn, err
 }
 
-// InitTLSForPeer returns TLS credentials for peer
+// InitTLSForPeer returns TLS credentials for peer. The client certificate
+// configured with peer.tls.clientCert.file and peer.tls.clientKey.file, if
+// any, is presented to the peer
 func InitTLSForPeer() credentials.TransportCredentials {
 	var sn string
 	if viper.GetString("peer.tls.serverhostoverride") != "" {
 		sn = viper.GetString("peer.tls.serverhostoverride")
 	}
-	var creds credentials.TransportCredentials
+	tlsConfig := &tls.Config{ServerName: sn}
 	if config.GetPath("peer.tls.rootcert.file") != "" {
-		var err error
-		creds, err = credentials.NewClientTLSFromFile(config.GetPath("peer.tls.rootcert.file"), sn)
+		rootCert, err := ioutil.ReadFile(config.GetPath("peer.tls.rootcert.file"))
 		if err != nil {
 			grpclog.Fatalf("Failed to create TLS credentials %v", err)
 		}
-	} else {
-		creds = credentials.NewClientTLSFromCert(nil, sn)
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(rootCert) {
+			grpclog.Fatalf("Failed to create TLS credentials: failed to append certificates")
+		}
 	}
-	return creds
+	clientCert, err := GetClientCertificate()
+	if err != nil {
+		grpclog.Fatalf("Failed to create TLS credentials %v", err)
+	}
+	if clientCert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*clientCert}
+	}
+	return credentials.NewTLS(tlsConfig)
+}
+
+// GetClientCertificate returns the TLS client certificate configured with
+// peer.tls.clientCert.file and peer.tls.clientKey.file, or nil if none is
+func GetClientCertificate() (*tls.Certificate, error) {
+	certFile := config.GetPath("peer.tls.clientCert.file")
+	keyFile := config.GetPath("peer.tls.clientKey.file")
+	if certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS client certificate: %s", err)
+	}
+	return &cert, nil
 }
 
 func InitTLSForShim(key, certStr string) credentials.TransportCredentials {
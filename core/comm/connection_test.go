@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
 )
 
 const (
@@ -267,3 +268,70 @@ func testInvoke(t *testing.T, channelID string, s *srv, shouldSucceed bool) {
 	assert.NoError(t, err)
 	s.assertServiced(t)
 }
+
+func TestDeliverServiceCredentialsRecordClientCertificate(t *testing.T) {
+	// Scenario: the TLS client certificate is rotated while a connection made
+	// with the credentials of GetDeliverServiceCredentials is open.
+	// The certificate recorded for that connection is still the former one,
+	// while new connections present and record the new one.
+	readFile := func(name string) []byte {
+		raw, err := ioutil.ReadFile(filepath.Join("testdata", "certs", name))
+		assert.NoError(t, err)
+		return raw
+	}
+	serverCACert, err := ioutil.ReadFile(filepath.Join("testdata", "impersonation", "orgA", "ca.crt"))
+	assert.NoError(t, err)
+	serverCert, err := ioutil.ReadFile(filepath.Join("testdata", "impersonation", "orgA", "server.crt"))
+	assert.NoError(t, err)
+	serverKey, err := ioutil.ReadFile(filepath.Join("testdata", "impersonation", "orgA", "server.key"))
+	assert.NoError(t, err)
+	gSrv, err := NewGRPCServer("localhost:7090", SecureServerConfig{
+		UseTLS:            true,
+		ServerCertificate: serverCert,
+		ServerKey:         serverKey,
+		RequireClientCert: true,
+		ClientRootCAs:     [][]byte{readFile("Org1-cert.pem")},
+	})
+	assert.NoError(t, err)
+	testpb.RegisterTestServiceServer(gSrv.Server(), &srv{})
+	go gSrv.Start()
+	defer gSrv.Stop()
+
+	cas := GetCASupport()
+	formerCert := cas.ClientCertificate()
+	defer func() {
+		cas.Lock()
+		cas.clientCert = formerCert
+		cas.Unlock()
+	}()
+	cas.OrdererRootCAsByChain["binding"] = [][]byte{serverCACert}
+	cert1, err := tls.X509KeyPair(readFile("Org1-client1-cert.pem"), readFile("Org1-client1-key.pem"))
+	assert.NoError(t, err)
+	cert2, err := tls.X509KeyPair(readFile("Org1-client2-cert.pem"), readFile("Org1-client2-key.pem"))
+	assert.NoError(t, err)
+
+	dial := func() *grpc.ClientConn {
+		creds, err := cas.GetDeliverServiceCredentials("binding")
+		assert.NoError(t, err)
+		conn, err := grpc.Dial("localhost:7090", grpc.WithTimeout(time.Second*3), grpc.WithTransportCredentials(creds), grpc.WithBlock())
+		assert.NoError(t, err)
+		return conn
+	}
+	presentedCertHash := func(conn *grpc.ClientConn) []byte {
+		var p peer.Peer
+		_, err := testpb.NewTestServiceClient(conn).EmptyCall(context.Background(), &testpb.Empty{}, grpc.Peer(&p))
+		assert.NoError(t, err)
+		return ClientCertificateHashFromContext(peer.NewContext(context.Background(), &p))
+	}
+
+	cas.SetClientCertificate(cert1)
+	conn1 := dial()
+	defer conn1.Close()
+	assert.Equal(t, CertificateHash(&cert1), presentedCertHash(conn1))
+
+	cas.SetClientCertificate(cert2)
+	assert.Equal(t, CertificateHash(&cert1), presentedCertHash(conn1))
+	conn2 := dial()
+	defer conn2.Close()
+	assert.Equal(t, CertificateHash(&cert2), presentedCertHash(conn2))
+}
@@ -78,3 +78,67 @@ func (sc *serverCreds) Clone() credentials.TransportCredentials {
 func (sc *serverCreds) OverrideServerName(string) error {
 	return OverrrideHostnameNotSupportedError
 }
+
+// newClientTransportCredentials returns grpc/credentials.TransportCredentials
+// for TLS clients, which present the certificate returned by
+// getClientCertificate on each handshake, and record it in the AuthInfo
+// of the connection
+func newClientTransportCredentials(clientConfig *tls.Config,
+	getClientCertificate func(*tls.CertificateRequestInfo) (*tls.Certificate, error)) credentials.TransportCredentials {
+	return &clientCreds{clientConfig: clientConfig, getClientCertificate: getClientCertificate}
+}
+
+// clientCreds is an implementation of grpc/credentials.TransportCredentials.
+type clientCreds struct {
+	clientConfig         *tls.Config
+	getClientCertificate func(*tls.CertificateRequestInfo) (*tls.Certificate, error)
+}
+
+// clientTLSInfo is the AuthInfo of the connections of `clientCreds`.
+// It holds the certificate the client presented during the handshake,
+// which the TLS connection state doesn't.
+type clientTLSInfo struct {
+	credentials.TLSInfo
+	clientCert *tls.Certificate
+}
+
+// ClientHandshake does the authentication handshake for clients.
+func (cc *clientCreds) ClientHandshake(ctx context.Context,
+	authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	// the certificate is recorded per handshake, since it may be rotated
+	// between the handshakes of different connections
+	var clientCert *tls.Certificate
+	config := cc.clientConfig.Clone()
+	config.GetClientCertificate = func(info *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		cert, err := cc.getClientCertificate(info)
+		clientCert = cert
+		return cert, err
+	}
+	conn, authInfo, err := credentials.NewTLS(config).ClientHandshake(ctx, authority, rawConn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, clientTLSInfo{TLSInfo: authInfo.(credentials.TLSInfo), clientCert: clientCert}, nil
+}
+
+// ServerHandshake does the authentication handshake for servers.
+func (cc *clientCreds) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(cc.clientConfig).ServerHandshake(rawConn)
+}
+
+// Info provides the ProtocolInfo of this TransportCredentials.
+func (cc *clientCreds) Info() credentials.ProtocolInfo {
+	return credentials.NewTLS(cc.clientConfig).Info()
+}
+
+// Clone makes a copy of this TransportCredentials.
+func (cc *clientCreds) Clone() credentials.TransportCredentials {
+	return newClientTransportCredentials(cc.clientConfig.Clone(), cc.getClientCertificate)
+}
+
+// OverrideServerName overrides the server name used to verify the hostname
+// on the returned certificates from the server.
+func (cc *clientCreds) OverrideServerName(serverNameOverride string) error {
+	cc.clientConfig.ServerName = serverNameOverride
+	return nil
+}
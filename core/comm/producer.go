@@ -37,20 +37,32 @@ type ConnectionProducer interface {
 	DisableEndpoint(endpoint string)
 }
 
+// EndpointPrioritizer orders endpoints by the preference of connecting to them,
+// the most preferred endpoint first
+type EndpointPrioritizer func(endpoints []string) []string
+
 type connProducer struct {
 	sync.Mutex
 	endpoints         []string
 	disabledEndpoints map[string]time.Time
 	connect           ConnectionFactory
+	prioritize        EndpointPrioritizer
 }
 
 // NewConnectionProducer creates a new ConnectionProducer with given endpoints and connection factory.
 // It returns nil, if the given endpoints slice is empty.
 func NewConnectionProducer(factory ConnectionFactory, endpoints []string) ConnectionProducer {
+	return NewPrioritizedConnectionProducer(factory, endpoints, shuffle)
+}
+
+// NewPrioritizedConnectionProducer creates a new ConnectionProducer with given endpoints and connection
+// factory, which tries to connect to the endpoints in the order given by the prioritizer.
+// It returns nil, if the given endpoints slice is empty.
+func NewPrioritizedConnectionProducer(factory ConnectionFactory, endpoints []string, prioritize EndpointPrioritizer) ConnectionProducer {
 	if len(endpoints) == 0 {
 		return nil
 	}
-	return &connProducer{endpoints: endpoints, connect: factory, disabledEndpoints: make(map[string]time.Time)}
+	return &connProducer{endpoints: endpoints, connect: factory, disabledEndpoints: make(map[string]time.Time), prioritize: prioritize}
 }
 
 // NewConnection creates a new connection.
@@ -66,7 +78,7 @@ func (cp *connProducer) NewConnection() (*grpc.ClientConn, string, error) {
 		}
 	}
 
-	endpoints := shuffle(cp.endpoints)
+	endpoints := cp.prioritize(cp.endpoints)
 	checkedEndpoints := make([]string, 0)
 	for _, endpoint := range endpoints {
 		if _, ok := cp.disabledEndpoints[endpoint]; !ok {
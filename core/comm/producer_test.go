@@ -139,3 +139,39 @@ func TestDisableEndpoint(t *testing.T) {
 	assert.Equal(t, "b", a)
 
 }
+
+func TestPrioritizedConnectionProducer(t *testing.T) {
+	shouldConnFail := map[string]bool{}
+	connFactory := func(endpoint string) (*grpc.ClientConn, error) {
+		if shouldConnFail[endpoint] {
+			return nil, fmt.Errorf("Failed connecting to %s", endpoint)
+		}
+		return &grpc.ClientConn{}, nil
+	}
+	reverse := func(endpoints []string) []string {
+		reversed := make([]string, len(endpoints))
+		for i, endpoint := range endpoints {
+			reversed[len(endpoints)-1-i] = endpoint
+		}
+		return reversed
+	}
+	assert.Nil(t, NewPrioritizedConnectionProducer(connFactory, []string{}, reverse))
+
+	producer := NewPrioritizedConnectionProducer(connFactory, []string{"a", "b", "c"}, reverse)
+	// The most preferred endpoint is always selected
+	for i := 0; i < 10; i++ {
+		_, endpoint, err := producer.NewConnection()
+		assert.NoError(t, err)
+		assert.Equal(t, "c", endpoint)
+	}
+	// Endpoints are tried in order of preference
+	shouldConnFail["c"] = true
+	_, endpoint, err := producer.NewConnection()
+	assert.NoError(t, err)
+	assert.Equal(t, "b", endpoint)
+	// Disabled endpoints are skipped
+	producer.DisableEndpoint("b")
+	_, endpoint, err = producer.NewConnection()
+	assert.NoError(t, err)
+	assert.Equal(t, "a", endpoint)
+}
@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
 
 	"google.golang.org/grpc"
 )
@@ -50,6 +51,10 @@ type GRPCServer interface {
 	Listener() net.Listener
 	//ServerCertificate returns the tls.Certificate used by the grpc.Server
 	ServerCertificate() tls.Certificate
+	//SetServerCertificate replaces the tls.Certificate used by the grpc.Server.
+	//The new certificate is presented on new connections only, existing
+	//connections and streams are not affected
+	SetServerCertificate(cert tls.Certificate)
 	//TLSEnabled is a flag indicating whether or not TLS is enabled for this
 	//GRPCServer instance
 	TLSEnabled() bool
@@ -72,7 +77,7 @@ type grpcServerImpl struct {
 	//GRPC server
 	server *grpc.Server
 	//Certificate presented by the server for TLS communication
-	serverCertificate tls.Certificate
+	serverCertificate atomic.Value
 	//Key used by the server for TLS communication
 	serverKeyPEM []byte
 	//List of certificate authorities to optionally pass to the client during
@@ -129,14 +134,14 @@ func NewGRPCServerFromListener(listener net.Listener, secureConfig SecureServerC
 			if err != nil {
 				return nil, err
 			}
-			grpcServer.serverCertificate = cert
+			grpcServer.serverCertificate.Store(cert)
 
 			//set up our TLS config
 
-			//base server certificate
-			certificates := []tls.Certificate{grpcServer.serverCertificate}
+			//the server certificate is looked up on each handshake, so
+			//that it can be replaced without restarting the server
 			grpcServer.tlsConfig = &tls.Config{
-				Certificates:           certificates,
+				GetCertificate:         grpcServer.getCertificate,
 				SessionTicketsDisabled: true,
 			}
 			grpcServer.tlsConfig.ClientAuth = tls.RequestClientCert
@@ -193,7 +198,19 @@ func (gServer *grpcServerImpl) Server() *grpc.Server {
 
 //ServerCertificate returns the tls.Certificate used by the grpc.Server
 func (gServer *grpcServerImpl) ServerCertificate() tls.Certificate {
-	return gServer.serverCertificate
+	cert, _ := gServer.serverCertificate.Load().(tls.Certificate)
+	return cert
+}
+
+//SetServerCertificate replaces the tls.Certificate used by the grpc.Server
+func (gServer *grpcServerImpl) SetServerCertificate(cert tls.Certificate) {
+	gServer.serverCertificate.Store(cert)
+}
+
+//getCertificate returns the current server certificate during TLS handshakes
+func (gServer *grpcServerImpl) getCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert := gServer.ServerCertificate()
+	return &cert, nil
 }
 
 //TLSEnabled is a flag indicating whether or not TLS is enabled for the
@@ -11,6 +11,7 @@ package comm_test
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
@@ -1419,3 +1420,63 @@ func TestKeepaliveClientResponse(t *testing.T) {
 	_, err = clientTransport.NewStream(context.Background(), &transport.CallHdr{})
 	assert.NoError(t, err, "Unexpected error creating stream")
 }
+
+func TestSetServerCertificate(t *testing.T) {
+	t.Parallel()
+	loadPEM := func(name string) []byte {
+		b, err := ioutil.ReadFile(filepath.Join("testdata", "certs", name))
+		assert.NoError(t, err)
+		return b
+	}
+	cert1PEM := loadPEM("Org1-server1-cert.pem")
+	cert2PEM := loadPEM("Org1-server2-cert.pem")
+
+	testAddress := "localhost:9059"
+	srv, err := comm.NewGRPCServer(testAddress, comm.SecureServerConfig{
+		UseTLS:            true,
+		ServerCertificate: cert1PEM,
+		ServerKey:         loadPEM("Org1-server1-key.pem"),
+	})
+	assert.NoError(t, err)
+	testpb.RegisterTestServiceServer(srv.Server(), &testServiceServer{})
+	go srv.Start()
+	defer srv.Stop()
+
+	// Only the certificates presented by the server are compared
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+
+	// peerCert returns the certificate the server presents on a new connection
+	peerCert := func() []byte {
+		conn, err := tls.Dial("tcp", testAddress, tlsConfig)
+		assert.NoError(t, err)
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0].Raw
+	}
+	rawCert := func(certPEM []byte) []byte {
+		block, _ := pem.Decode(certPEM)
+		return block.Bytes
+	}
+
+	clientConn, err := grpc.Dial(testAddress, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithBlock(), grpc.WithTimeout(timeout))
+	if err != nil {
+		t.Fatalf("Failed to dial GRPCServer: %v", err)
+	}
+	defer clientConn.Close()
+	client := testpb.NewTestServiceClient(clientConn)
+	_, err = client.EmptyCall(context.Background(), &testpb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, rawCert(cert1PEM), peerCert())
+
+	newCert, err := tls.X509KeyPair(cert2PEM, loadPEM("Org1-server2-key.pem"))
+	assert.NoError(t, err)
+	srv.SetServerCertificate(newCert)
+	assert.Equal(t, newCert, srv.ServerCertificate())
+
+	// New connections get the new certificate, existing ones keep working
+	assert.Equal(t, rawCert(cert2PEM), peerCert())
+	_, err = client.EmptyCall(context.Background(), &testpb.Empty{})
+	assert.NoError(t, err)
+	_, err = invokeEmptyCall(testAddress, []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))})
+	assert.NoError(t, err)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/mocks/validator"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -49,7 +50,7 @@ func TestKVLedgerBlockStorage(t *testing.T) {
 
 	bcInfo, _ := ledger.GetBlockchainInfo()
 	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
-		Height: 1, CurrentBlockHash: gbHash, PreviousBlockHash: nil})
+		Height: 1, CurrentBlockHash: gbHash, PreviousBlockHash: nil, CommitHash: utils.GetCommitHashFromBlock(gb)})
 
 	txid := util.GenerateUUID()
 	simulator, _ := ledger.NewTxSimulator(txid)
@@ -76,7 +77,7 @@ func TestKVLedgerBlockStorage(t *testing.T) {
 	bcInfo, _ = ledger.GetBlockchainInfo()
 	block1Hash := block1.Header.Hash()
 	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
-		Height: 2, CurrentBlockHash: block1Hash, PreviousBlockHash: gbHash})
+		Height: 2, CurrentBlockHash: block1Hash, PreviousBlockHash: gbHash, CommitHash: utils.GetCommitHashFromBlock(block1)})
 }
 
 func TestNewLedgerCommitterReactive(t *testing.T) {
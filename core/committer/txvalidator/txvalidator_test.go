@@ -69,7 +69,7 @@ func TestBlockValidation(t *testing.T) {
 
 	bcInfo, _ := ledger.GetBlockchainInfo()
 	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
-		Height: 1, CurrentBlockHash: gbHash, PreviousBlockHash: nil})
+		Height: 1, CurrentBlockHash: gbHash, PreviousBlockHash: nil, CommitHash: utils.GetCommitHashFromBlock(gb)})
 
 	block := testutil.ConstructBlock(t, 1, gbHash, [][]byte{pubSimulationResBytes}, true)
 
@@ -471,6 +471,16 @@ func (m *mockLedger) PrivateDataMinBlockNum() (uint64, error) {
 	return 0, nil
 }
 
+// ExportSnapshot exports a snapshot of the ledger
+func (m *mockLedger) ExportSnapshot(snapshotDir string) error {
+	return nil
+}
+
+// GetStateHash returns the state hashes of the ledger
+func (m *mockLedger) GetStateHash(blockNum uint64) (*ledger.StateHash, error) {
+	return nil, nil
+}
+
 // Prune prune using policy
 func (m *mockLedger) Prune(policy ledger2.PrunePolicy) error {
 	return nil
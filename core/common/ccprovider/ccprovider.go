@@ -17,12 +17,17 @@ limitations under the License.
 package ccprovider
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
@@ -256,6 +261,51 @@ func GetCCPackage(buf []byte) (CCPackage, error) {
 	return cccdspack, nil
 }
 
+// IndexDefinitionsPath is the directory of the code package of a chaincode which holds
+// the definitions of the indexes of the state database, in the format of the CouchDB index definitions
+const IndexDefinitionsPath = "META-INF/statedb/couchdb/indexes/"
+
+// ExtractIndexDefinitions returns the index definitions held by the IndexDefinitionsPath
+// directory of the given code package, ordered by the names of their files
+func ExtractIndexDefinitions(codePackage []byte) ([][]byte, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(codePackage))
+	if err != nil {
+		return nil, fmt.Errorf("failure opening codepackage gzip stream: %s", err)
+	}
+	tr := tar.NewReader(gr)
+
+	definitions := make(map[string][]byte)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading codepackage: %s", err)
+		}
+		dir, file := path.Split(header.Name)
+		if dir != IndexDefinitionsPath || path.Ext(file) != ".json" {
+			continue
+		}
+		definition, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s from codepackage: %s", header.Name, err)
+		}
+		definitions[header.Name] = definition
+	}
+
+	names := make([]string, 0, len(definitions))
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sortedDefinitions := make([][]byte, len(names))
+	for i, name := range names {
+		sortedDefinitions[i] = definitions[name]
+	}
+	return sortedDefinitions, nil
+}
+
 // GetInstalledChaincodes returns a map whose key is the chaincode id and
 // value is the ChaincodeDeploymentSpec struct for that chaincodes that have
 // been installed (but not necessarily instantiated) on the peer by searching
@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ccprovider
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractIndexDefinitions(t *testing.T) {
+	codePackage := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(codePackage)
+	tw := tar.NewWriter(gw)
+	files := []struct{ name, content string }{
+		{"src/github.com/marbles/marbles.go", "package main"},
+		{"META-INF/statedb/couchdb/indexes/indexSize.json", `{"index":{"fields":["size"]},"name":"indexSize"}`},
+		{"META-INF/statedb/couchdb/indexes/indexColor.json", `{"index":{"fields":["color"]},"name":"indexColor"}`},
+		{"META-INF/statedb/couchdb/indexes/README.md", "indexes of marbles"},
+		{"META-INF/statedb/couchdb/collections/collectionMarbles/indexes/indexOwner.json", `{"index":{"fields":["owner"]},"name":"indexOwner"}`},
+	}
+	for _, file := range files {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: file.name, Size: int64(len(file.content)), Mode: 0100644}))
+		_, err := tw.Write([]byte(file.content))
+		assert.NoError(t, err)
+	}
+	tw.Close()
+	gw.Close()
+
+	definitions, err := ExtractIndexDefinitions(codePackage.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte(files[2].content), []byte(files[1].content)}, definitions)
+
+	_, err = ExtractIndexDefinitions([]byte("not a code package"))
+	assert.Error(t, err)
+}
@@ -166,6 +166,9 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 			}
 			if err := b.mcs.VerifyBlock(gossipcommon.ChainID(b.chainID), seqNum, marshaledBlock); err != nil {
 				logger.Errorf("[%s] Error verifying block with sequnce number %d, due to %s", b.chainID, seqNum, err)
+				// The block doesn't satisfy the block validation policy of the channel,
+				// so the orderer might be compromised. Move on to another orderer
+				b.client.Disconnect(true)
 				continue
 			}
 
@@ -405,6 +405,48 @@ func TestBlocksProvider_DeliveryServiceDisableEndpoints(t *testing.T) {
 
 }
 
+func TestBlocksProvider_VerificationFailureDisablesEndpoint(t *testing.T) {
+	bd := mocks.MockBlocksDeliverer{
+		DisconnectCalled:           make(chan struct{}, 100),
+		DisconnectAndDisableCalled: make(chan struct{}, 100),
+		CloseCalled:                make(chan struct{}, 1),
+	}
+	mcs := &mockMCS{}
+	mcs.On("VerifyBlock", mock.Anything).Return(errors.New("block signatures don't satisfy the block validation policy"))
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64, 2)}
+	provider := &blocksProviderImpl{
+		chainID:              "***TEST_CHAINID***",
+		gossip:               gossipServiceAdapter,
+		client:               &bd,
+		mcs:                  mcs,
+		wrongStatusThreshold: 5,
+	}
+	defer provider.Stop()
+
+	incomingMsgs := make(chan *orderer.DeliverResponse)
+	bd.MockRecv = func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+		inMsg := <-incomingMsgs
+		return inMsg, nil
+	}
+
+	go provider.DeliverBlocks()
+
+	// A block which fails verification makes the provider move on to another orderer
+	incomingMsgs <- &orderer.DeliverResponse{
+		Type: &orderer.DeliverResponse_Block{
+			Block: &common.Block{
+				Header: &common.BlockHeader{Number: 0},
+				Data:   &common.BlockData{Data: [][]byte{}},
+			}},
+	}
+
+	waitUntilOrFail(t, func() bool {
+		return len(bd.DisconnectAndDisableCalled) == 1
+	})
+	assert.Len(t, bd.DisconnectCalled, 0)
+	assert.Len(t, gossipServiceAdapter.GossipBlockDisseminations, 0)
+}
+
 func TestBlockFetchFailure(t *testing.T) {
 	rcvr := func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
 		return nil, errors.New("Failed fetching block")
@@ -39,6 +39,7 @@ type broadcastClient struct {
 	stopFlag int32
 	sync.Mutex
 	stopChan     chan struct{}
+	doneChan     chan struct{}
 	createClient clientFactory
 	shouldRetry  retryPolicy
 	onConnect    broadcastSetup
@@ -46,11 +47,12 @@ type broadcastClient struct {
 	blocksprovider.BlocksDeliverer
 	conn     *connection
 	endpoint string
+	health   *endpointHealth
 }
 
 // NewBroadcastClient returns a broadcastClient with the given params
 func NewBroadcastClient(prod comm.ConnectionProducer, clFactory clientFactory, onConnect broadcastSetup, bos retryPolicy) *broadcastClient {
-	return &broadcastClient{prod: prod, onConnect: onConnect, shouldRetry: bos, createClient: clFactory, stopChan: make(chan struct{}, 1)}
+	return &broadcastClient{prod: prod, onConnect: onConnect, shouldRetry: bos, createClient: clFactory, stopChan: make(chan struct{}, 1), doneChan: make(chan struct{}), health: newEndpointHealth(nil)}
 }
 
 // Recv receives a message from the ordering service
@@ -64,7 +66,11 @@ func (bc *broadcastClient) Recv() (*orderer.DeliverResponse, error) {
 	if err != nil {
 		return nil, err
 	}
-	return o.(*orderer.DeliverResponse), nil
+	resp := o.(*orderer.DeliverResponse)
+	if block := resp.GetBlock(); block != nil && block.Header != nil {
+		bc.health.recordBlock(bc.getEndpoint(), block.Header.Number)
+	}
+	return resp, nil
 }
 
 // Send sends a message to the ordering service
@@ -113,6 +119,9 @@ func (bc *broadcastClient) doAction(action func() (interface{}, error)) (interfa
 	}
 	resp, err := action()
 	if err != nil {
+		if !bc.shouldStop() {
+			bc.health.recordFailure(bc.getEndpoint())
+		}
 		bc.Disconnect(false)
 		return nil, err
 	}
@@ -127,7 +136,9 @@ func (bc *broadcastClient) sleep(duration time.Duration) {
 }
 
 func (bc *broadcastClient) connect() error {
+	bc.Lock()
 	bc.endpoint = ""
+	bc.Unlock()
 	conn, endpoint, err := bc.prod.NewConnection()
 	logger.Debug("Connected to", endpoint)
 	if err != nil {
@@ -188,6 +199,27 @@ func (bc *broadcastClient) afterConnect(conn *grpc.ClientConn, abc orderer.Atomi
 	return err
 }
 
+// tlsCertHash returns the hash of the TLS client certificate presented on
+// the connection of the current stream, rather than the certificate currently
+// configured, which may have been rotated since the connection was made
+func (bc *broadcastClient) tlsCertHash() []byte {
+	bc.Lock()
+	defer bc.Unlock()
+	stream, isStream := bc.BlocksDeliverer.(orderer.AtomicBroadcast_DeliverClient)
+	if !isStream {
+		return nil
+	}
+	return comm.ClientCertificateHashFromContext(stream.Context())
+}
+
+// getEndpoint returns the endpoint the client is connected to,
+// or an empty string if it isn't connected
+func (bc *broadcastClient) getEndpoint() string {
+	bc.Lock()
+	defer bc.Unlock()
+	return bc.endpoint
+}
+
 func (bc *broadcastClient) shouldStop() bool {
 	return atomic.LoadInt32(&bc.stopFlag) == int32(1)
 }
@@ -203,6 +235,7 @@ func (bc *broadcastClient) Close() {
 	}
 	atomic.StoreInt32(&bc.stopFlag, int32(1))
 	bc.stopChan <- struct{}{}
+	close(bc.doneChan)
 	if bc.conn == nil {
 		return
 	}
@@ -77,6 +77,10 @@ type abc struct {
 	grpc.ClientStream
 }
 
+func (a *abc) Context() context.Context {
+	return context.Background()
+}
+
 func (a *abc) Send(*common.Envelope) error {
 	if a.shouldFail {
 		return errors.New("Failed sending")
@@ -771,3 +775,40 @@ func newTestSeekInfo() *orderer.SeekInfo {
 		Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
 	}
 }
+
+// tlsBoundAbc is a BlocksDeliverer which records the envelopes sent over it,
+// and knows the TLS client certificate of its connection
+type tlsBoundAbc struct {
+	abc
+	certHash []byte
+	sent     []*common.Envelope
+}
+
+func (a *tlsBoundAbc) Send(env *common.Envelope) error {
+	a.sent = append(a.sent, env)
+	return nil
+}
+
+func (a *tlsBoundAbc) tlsCertHash() []byte {
+	return a.certHash
+}
+
+func TestRequestBlocksTLSBinding(t *testing.T) {
+	// Scenario: the seek requests are bound to the TLS client certificate
+	// of the connection they are sent over
+	for _, height := range []uint64{0, 5} {
+		client := &tlsBoundAbc{certHash: []byte("certificate hash")}
+		requester := &blocksRequester{chainID: "mychannel", client: client}
+		assert.NoError(t, requester.RequestBlocks(&mocks.MockLedgerInfo{Height: height}))
+		assert.Len(t, client.sent, 1)
+		payload, err := utils.UnmarshalPayload(client.sent[0].Payload)
+		assert.NoError(t, err)
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("certificate hash"), chdr.TlsCertHash)
+	}
+
+	// A BlocksDeliverer which doesn't know its TLS client certificate binds nothing
+	requester := &blocksRequester{chainID: "mychannel", client: &abc{}}
+	assert.Nil(t, requester.tlsCertHash())
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/gossip/api"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/op/go-logging"
 	"google.golang.org/grpc"
@@ -32,6 +33,8 @@ var (
 	reConnectTotalTimeThreshold = time.Second * 60 * 5
 	connTimeout                 = time.Second * 3
 	reConnectBackoffThreshold   = float64(time.Hour)
+	defaultStallTimeout         = time.Minute
+	stallCheckInterval          = time.Second * 5
 )
 
 // SetReconnectTotalTimeThreshold sets the total time the delivery service
@@ -84,6 +87,13 @@ type Config struct {
 	Gossip blocksprovider.GossipServiceAdapter
 	// Endpoints specifies the endpoints of the ordering service
 	Endpoints []string
+	// PreferredEndpoints specifies the endpoints of the ordering service which
+	// are preferred over the others, such as those of the peer's own organization
+	PreferredEndpoints []string
+	// StallTimeout is the time after which an orderer which doesn't deliver blocks,
+	// while peers of the channel have a greater ledger height, is switched away from.
+	// If 0, it defaults to one minute
+	StallTimeout time.Duration
 }
 
 // NewDeliverService construction function to create and initialize
@@ -145,6 +155,7 @@ func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo b
 			d.blockProviders[chainID].DeliverBlocks()
 			finalizer()
 		}()
+		go d.monitorStalls(chainID, client, ledgerInfo, stallCheckInterval)
 	}
 	return nil
 }
@@ -197,12 +208,62 @@ func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocks
 		attempt := float64(attemptNum)
 		return time.Duration(math.Min(math.Pow(2, attempt)*sleepIncrement, reConnectBackoffThreshold)), true
 	}
-	connProd := comm.NewConnectionProducer(d.conf.ConnFactory(chainID), d.conf.Endpoints)
+	health := newEndpointHealth(d.conf.PreferredEndpoints)
+	connProd := comm.NewPrioritizedConnectionProducer(health.trackConnections(d.conf.ConnFactory(chainID)), d.conf.Endpoints, health.prioritize)
 	bClient := NewBroadcastClient(connProd, d.conf.ABCFactory, broadcastSetup, backoffPolicy)
+	bClient.health = health
 	requester.client = bClient
 	return bClient
 }
 
+// monitorStalls disconnects the client from the orderer it is connected to, and
+// disables that orderer for a while, whenever the orderer delivers no blocks for
+// the stall timeout while peers of the channel report a greater ledger height.
+// It checks the orderer at the given interval, and returns once the client is closed
+func (d *deliverServiceImpl) monitorStalls(chainID string, client *broadcastClient, ledgerInfo blocksprovider.LedgerInfo, interval time.Duration) {
+	stallTimeout := d.conf.StallTimeout
+	if stallTimeout == 0 {
+		stallTimeout = defaultStallTimeout
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-client.doneChan:
+			return
+		}
+		endpoint := client.getEndpoint()
+		if endpoint == "" || client.health.sinceLastProgress(endpoint) < stallTimeout {
+			continue
+		}
+		height, err := ledgerInfo.LedgerHeight()
+		if err != nil {
+			logger.Warningf("[%s] Failed obtaining ledger height: %s", chainID, err)
+			continue
+		}
+		peersHeight := d.peersLedgerHeight(chainID)
+		if peersHeight <= height {
+			continue
+		}
+		logger.Warningf("[%s] Orderer %s delivered no blocks for %v while the ledger height is %d and peers of the channel report %d, switching to another orderer",
+			chainID, endpoint, stallTimeout, height, peersHeight)
+		client.health.recordStall(endpoint, height, peersHeight)
+		client.Disconnect(true)
+	}
+}
+
+// peersLedgerHeight returns the greatest ledger height reported by the peers of the channel
+func (d *deliverServiceImpl) peersLedgerHeight(chainID string) uint64 {
+	var height uint64
+	for _, member := range d.conf.Gossip.PeersOfChannel(gossipcommon.ChainID(chainID)) {
+		if member.Properties.GetLedgerHeight() > height {
+			height = member.Properties.GetLedgerHeight()
+		}
+	}
+	return height
+}
+
 func DefaultConnectionFactory(channelID string) func(endpoint string) (*grpc.ClientConn, error) {
 	return func(endpoint string) (*grpc.ClientConn, error) {
 		dialOpts := []grpc.DialOption{grpc.WithTimeout(connTimeout), grpc.WithBlock()}
@@ -245,6 +245,56 @@ func TestDeliverServiceFailover(t *testing.T) {
 	service.Stop()
 }
 
+func TestDeliverServiceStalledOrderer(t *testing.T) {
+	orgStallCheckInterval := stallCheckInterval
+	stallCheckInterval = time.Millisecond * 100
+	defer func() { stallCheckInterval = orgStallCheckInterval }()
+	defer ensureNoGoroutineLeak(t)()
+	// Scenario: bring up 2 ordering service instances, and prefer the first one.
+	// The client is expected to connect to the first instance, which then stops
+	// sending blocks while peers of the channel report a greater ledger height.
+	// The client is expected to switch to the second instance.
+
+	os1 := mocks.NewOrderer(5617, t)
+	os2 := mocks.NewOrderer(5618, t)
+
+	time.Sleep(time.Second)
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64)}
+
+	service, err := NewDeliverService(&Config{
+		Endpoints:          []string{"localhost:5617", "localhost:5618"},
+		PreferredEndpoints: []string{"localhost:5617"},
+		StallTimeout:       time.Second,
+		Gossip:             gossipServiceAdapter,
+		CryptoSvc:          &mockMCS{},
+		ABCFactory:         DefaultABCFactory,
+		ConnFactory:        DefaultConnectionFactory,
+	})
+	assert.NoError(t, err)
+	li := &mocks.MockLedgerInfo{Height: uint64(100)}
+	os1.SetNextExpectedSeek(uint64(100))
+	os2.SetNextExpectedSeek(uint64(100))
+
+	err = service.StartDeliverForChannel("TEST_CHAINID", li, func() {})
+	assert.NoError(t, err, "can't start delivery")
+	// The client connects to the preferred instance
+	go os1.SendBlock(uint64(100))
+	assertBlockDissemination(100, gossipServiceAdapter.GossipBlockDisseminations, t)
+
+	// The preferred instance stalls while peers of the channel are ahead
+	atomic.StoreUint64(&li.Height, uint64(101))
+	os2.SetNextExpectedSeek(uint64(101))
+	atomic.StoreUint64(&gossipServiceAdapter.LedgerHeight, uint64(103))
+	time.Sleep(time.Second * 2)
+
+	// Ensure the client asks blocks from the other ordering service node
+	go os2.SendBlock(uint64(101))
+	assertBlockDissemination(101, gossipServiceAdapter.GossipBlockDisseminations, t)
+	os1.Shutdown()
+	os2.Shutdown()
+	service.Stop()
+}
+
 func TestDeliverServiceServiceUnavailable(t *testing.T) {
 	orgEndpointDisableInterval := comm.EndpointDisableInterval
 	comm.EndpointDisableInterval = time.Millisecond * 1500
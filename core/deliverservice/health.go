@@ -0,0 +1,207 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliverclient
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/core/comm"
+	"google.golang.org/grpc"
+)
+
+var (
+	// healthHalfLife is the time after which the failures and the
+	// block lag of an endpoint weigh half as much in its score
+	healthHalfLife = time.Minute * 5
+	// failureWeight is the score penalty of a recent failure of an endpoint
+	failureWeight = 10.0
+	// lagWeight is the score penalty of every doubling of the
+	// number of blocks an endpoint recently lagged behind
+	lagWeight = 2.0
+	// latencyWeight is the score penalty of every second
+	// it takes to connect to an endpoint
+	latencyWeight = 1.0
+	// notPreferredPenalty is the score penalty of the endpoints which aren't
+	// preferred, when preferred endpoints are configured
+	notPreferredPenalty = 10.0
+)
+
+// endpointHealth scores the orderer endpoints of a channel by their recent
+// behavior, so that the delivery client connects to the healthiest ones.
+// A lower score is better
+type endpointHealth struct {
+	sync.Mutex
+	preferred map[string]struct{}
+	stats     map[string]*endpointStats
+	// height is the greatest ledger height any endpoint delivered blocks up to
+	height uint64
+	now    func() time.Time
+}
+
+// endpointStats is the recent behavior of an orderer endpoint
+type endpointStats struct {
+	// failures is the number of recent failures, decaying over time
+	failures float64
+	// lag is the number of blocks the endpoint recently lagged behind, decaying over time
+	lag float64
+	// decayed is the last time failures and lag were decayed
+	decayed time.Time
+	// latency is the moving average of the time it takes to connect to the endpoint
+	latency time.Duration
+	// height is the greatest ledger height the endpoint delivered blocks up to
+	height uint64
+	// lastProgress is the last time the endpoint was connected to or delivered a block
+	lastProgress time.Time
+}
+
+// newEndpointHealth creates an endpointHealth which prefers the given endpoints
+func newEndpointHealth(preferred []string) *endpointHealth {
+	eh := &endpointHealth{
+		preferred: make(map[string]struct{}),
+		stats:     make(map[string]*endpointStats),
+		now:       time.Now,
+	}
+	for _, endpoint := range preferred {
+		eh.preferred[endpoint] = struct{}{}
+	}
+	return eh
+}
+
+// statsOf returns the stats of the given endpoint, with its failures and lag
+// decayed up to now. It must be called with the lock held
+func (eh *endpointHealth) statsOf(endpoint string) *endpointStats {
+	now := eh.now()
+	st, exists := eh.stats[endpoint]
+	if !exists {
+		st = &endpointStats{decayed: now}
+		eh.stats[endpoint] = st
+		return st
+	}
+	factor := math.Pow(0.5, float64(now.Sub(st.decayed))/float64(healthHalfLife))
+	st.failures *= factor
+	st.lag *= factor
+	st.decayed = now
+	return st
+}
+
+// score returns the score of the given endpoint. It must be called with the lock held
+func (eh *endpointHealth) score(endpoint string) float64 {
+	st := eh.statsOf(endpoint)
+	score := st.failures*failureWeight + math.Log2(1+st.lag)*lagWeight + st.latency.Seconds()*latencyWeight
+	if _, preferred := eh.preferred[endpoint]; len(eh.preferred) > 0 && !preferred {
+		score += notPreferredPenalty
+	}
+	return score
+}
+
+// prioritize orders the given endpoints by their score, the best first.
+// Endpoints with equal scores are ordered randomly
+func (eh *endpointHealth) prioritize(endpoints []string) []string {
+	eh.Lock()
+	defer eh.Unlock()
+
+	prioritized := make([]string, len(endpoints))
+	scores := make(map[string]float64, len(endpoints))
+	for i, idx := range rand.Perm(len(endpoints)) {
+		prioritized[i] = endpoints[idx]
+		scores[endpoints[idx]] = eh.score(endpoints[idx])
+	}
+	sort.SliceStable(prioritized, func(i, j int) bool {
+		return scores[prioritized[i]] < scores[prioritized[j]]
+	})
+	return prioritized
+}
+
+// trackConnections returns a ConnectionFactory which records the failures and
+// the latency of the connections the given factory creates
+func (eh *endpointHealth) trackConnections(factory comm.ConnectionFactory) comm.ConnectionFactory {
+	return func(endpoint string) (*grpc.ClientConn, error) {
+		start := eh.now()
+		conn, err := factory(endpoint)
+		if err != nil {
+			eh.recordFailure(endpoint)
+			return nil, err
+		}
+		eh.recordConnect(endpoint, eh.now().Sub(start))
+		return conn, nil
+	}
+}
+
+// recordConnect records that a connection to the given endpoint
+// was established within the given time
+func (eh *endpointHealth) recordConnect(endpoint string, latency time.Duration) {
+	eh.Lock()
+	defer eh.Unlock()
+	st := eh.statsOf(endpoint)
+	if st.latency == 0 {
+		st.latency = latency
+	} else {
+		st.latency = (st.latency*3 + latency) / 4
+	}
+	st.lastProgress = eh.now()
+}
+
+// recordFailure records a failure to connect to, or to receive from, the given endpoint
+func (eh *endpointHealth) recordFailure(endpoint string) {
+	if endpoint == "" {
+		return
+	}
+	eh.Lock()
+	defer eh.Unlock()
+	eh.statsOf(endpoint).failures++
+}
+
+// recordBlock records that the given endpoint delivered the block with the given number
+func (eh *endpointHealth) recordBlock(endpoint string, number uint64) {
+	if endpoint == "" {
+		return
+	}
+	eh.Lock()
+	defer eh.Unlock()
+	st := eh.statsOf(endpoint)
+	st.lastProgress = eh.now()
+	if number+1 > st.height {
+		st.height = number + 1
+	}
+	if st.height > eh.height {
+		eh.height = st.height
+	}
+	if st.height >= eh.height {
+		st.lag = 0
+	}
+}
+
+// recordStall records that the given endpoint stopped delivering blocks at
+// the given ledger height, while peers of the channel report a greater height
+func (eh *endpointHealth) recordStall(endpoint string, ledgerHeight, peersHeight uint64) {
+	eh.Lock()
+	defer eh.Unlock()
+	st := eh.statsOf(endpoint)
+	st.failures++
+	if ledgerHeight > st.height {
+		st.height = ledgerHeight
+	}
+	height := eh.height
+	if peersHeight > height {
+		height = peersHeight
+	}
+	if height > st.height {
+		st.lag += float64(height - st.height)
+	}
+}
+
+// sinceLastProgress returns the time passed since the given endpoint
+// was connected to or delivered a block
+func (eh *endpointHealth) sinceLastProgress(endpoint string) time.Duration {
+	eh.Lock()
+	defer eh.Unlock()
+	return eh.now().Sub(eh.statsOf(endpoint).lastProgress)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliverclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (fc *fakeClock) Now() time.Time {
+	return fc.now
+}
+
+func (fc *fakeClock) advance(d time.Duration) {
+	fc.now = fc.now.Add(d)
+}
+
+func newTestEndpointHealth(preferred ...string) (*endpointHealth, *fakeClock) {
+	clock := &fakeClock{now: time.Now()}
+	eh := newEndpointHealth(preferred)
+	eh.now = clock.Now
+	return eh, clock
+}
+
+func TestEndpointHealthFailures(t *testing.T) {
+	eh, clock := newTestEndpointHealth()
+	endpoints := []string{"a", "b", "c"}
+
+	// Healthy endpoints are ordered randomly
+	first := make(map[string]struct{})
+	for i := 0; i < 100; i++ {
+		prioritized := eh.prioritize(endpoints)
+		assert.Len(t, prioritized, 3)
+		first[prioritized[0]] = struct{}{}
+	}
+	assert.Len(t, first, 3)
+
+	// Endpoints which failed recently are tried last
+	eh.recordFailure("a")
+	eh.recordFailure("a")
+	eh.recordFailure("b")
+	assert.Equal(t, []string{"c", "b", "a"}, eh.prioritize(endpoints))
+
+	// Failures are forgiven over time
+	clock.advance(healthHalfLife)
+	assert.InDelta(t, failureWeight, eh.score("a"), 0.001)
+	clock.advance(healthHalfLife * 20)
+	assert.InDelta(t, 0, eh.score("a"), 0.001)
+}
+
+func TestEndpointHealthPreferred(t *testing.T) {
+	eh, _ := newTestEndpointHealth("b")
+	endpoints := []string{"a", "b", "c"}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, "b", eh.prioritize(endpoints)[0])
+	}
+
+	// A preferred endpoint which keeps failing is switched away from
+	eh.recordFailure("b")
+	eh.recordFailure("b")
+	assert.NotEqual(t, "b", eh.prioritize(endpoints)[0])
+	assert.Equal(t, "b", eh.prioritize(endpoints)[2])
+}
+
+func TestEndpointHealthLatency(t *testing.T) {
+	eh, clock := newTestEndpointHealth()
+	factory := eh.trackConnections(func(endpoint string) (*grpc.ClientConn, error) {
+		switch endpoint {
+		case "slow":
+			clock.advance(time.Second * 2)
+		case "unreachable":
+			return nil, errors.New("unreachable")
+		}
+		return &grpc.ClientConn{}, nil
+	})
+
+	_, err := factory("slow")
+	assert.NoError(t, err)
+	_, err = factory("fast")
+	assert.NoError(t, err)
+	_, err = factory("unreachable")
+	assert.Error(t, err)
+	assert.Equal(t, []string{"fast", "slow", "unreachable"}, eh.prioritize([]string{"unreachable", "slow", "fast"}))
+
+	// Connecting makes progress
+	assert.Equal(t, time.Duration(0), eh.sinceLastProgress("fast"))
+	clock.advance(time.Second)
+	assert.Equal(t, time.Second, eh.sinceLastProgress("fast"))
+}
+
+func TestEndpointHealthStall(t *testing.T) {
+	eh, clock := newTestEndpointHealth()
+	eh.recordConnect("a", time.Millisecond)
+	eh.recordBlock("a", 9)
+	clock.advance(time.Minute)
+	assert.Equal(t, time.Minute, eh.sinceLastProgress("a"))
+	eh.recordBlock("a", 10)
+	assert.Equal(t, time.Duration(0), eh.sinceLastProgress("a"))
+
+	// The endpoint stalls at height 11 while peers are at height 26
+	clock.advance(time.Minute)
+	eh.recordStall("a", 11, 26)
+	stats := eh.stats["a"]
+	assert.InDelta(t, 1, stats.failures, 0.001)
+	assert.InDelta(t, 15, stats.lag, 0.001)
+	assert.InDelta(t, failureWeight+4*lagWeight+time.Millisecond.Seconds()*latencyWeight, eh.score("a"), 0.001)
+
+	// The lag is cleared once the endpoint catches up
+	eh.recordBlock("a", 25)
+	assert.Equal(t, float64(0), eh.stats["a"].lag)
+
+	// Blocks are only recorded for connected endpoints
+	eh.recordBlock("", 30)
+	eh.recordFailure("")
+	assert.NotContains(t, eh.stats, "")
+}
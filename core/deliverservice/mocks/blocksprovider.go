@@ -27,6 +27,9 @@ type MockGossipServiceAdapter struct {
 	AddPayloadsCnt int32
 
 	GossipBlockDisseminations chan uint64
+
+	// LedgerHeight is the ledger height the peers of the channel report
+	LedgerHeight uint64
 }
 
 type MockAtomicBroadcastClient struct {
@@ -41,8 +44,12 @@ func (mabc *MockAtomicBroadcastClient) Deliver(ctx context.Context, opts ...grpc
 }
 
 // PeersOfChannel returns the slice with peers participating in given channel
-func (*MockGossipServiceAdapter) PeersOfChannel(gossip_common.ChainID) []discovery.NetworkMember {
-	return []discovery.NetworkMember{}
+func (mock *MockGossipServiceAdapter) PeersOfChannel(gossip_common.ChainID) []discovery.NetworkMember {
+	height := atomic.LoadUint64(&mock.LedgerHeight)
+	if height == 0 {
+		return []discovery.NetworkMember{}
+	}
+	return []discovery.NetworkMember{{Properties: &gossip_proto.Properties{LedgerHeight: height}}}
 }
 
 // AddPayload adds gossip payload to the local state transfer buffer
@@ -68,6 +75,11 @@ type MockBlocksDeliverer struct {
 	MockRecv func(mock *MockBlocksDeliverer) (*orderer.DeliverResponse, error)
 }
 
+// Context returns the context of the stream, which carries no connection information
+func (mock *MockBlocksDeliverer) Context() context.Context {
+	return context.Background()
+}
+
 // Recv gets responses from the ordering service, currently mocked to return
 // only one response with empty block.
 func (mock *MockBlocksDeliverer) Recv() (*orderer.DeliverResponse, error) {
@@ -115,6 +127,9 @@ func (mock *MockBlocksDeliverer) Send(env *common.Envelope) error {
 }
 
 func (mock *MockBlocksDeliverer) Disconnect(disableEndpoint bool) {
+	if mock.DisconnectCalled == nil || mock.DisconnectAndDisableCalled == nil {
+		return
+	}
 	if disableEndpoint {
 		mock.DisconnectAndDisableCalled <- struct{}{}
 	} else {
@@ -63,7 +63,7 @@ func (b *blocksRequester) seekOldest() error {
 	//TODO- epoch and msgVersion may need to be obtained for nowfollowing usage in orderer/configupdate/configupdate.go
 	msgVersion := int32(0)
 	epoch := uint64(0)
-	env, err := utils.CreateSignedEnvelope(common.HeaderType_CONFIG_UPDATE, b.chainID, localmsp.NewSigner(), seekInfo, msgVersion, epoch)
+	env, err := utils.CreateSignedEnvelopeWithTLSBinding(common.HeaderType_CONFIG_UPDATE, b.chainID, localmsp.NewSigner(), seekInfo, msgVersion, epoch, b.tlsCertHash())
 	if err != nil {
 		return err
 	}
@@ -80,9 +80,25 @@ func (b *blocksRequester) seekLatestFromCommitter(height uint64) error {
 	//TODO- epoch and msgVersion may need to be obtained for nowfollowing usage in orderer/configupdate/configupdate.go
 	msgVersion := int32(0)
 	epoch := uint64(0)
-	env, err := utils.CreateSignedEnvelope(common.HeaderType_CONFIG_UPDATE, b.chainID, localmsp.NewSigner(), seekInfo, msgVersion, epoch)
+	env, err := utils.CreateSignedEnvelopeWithTLSBinding(common.HeaderType_CONFIG_UPDATE, b.chainID, localmsp.NewSigner(), seekInfo, msgVersion, epoch, b.tlsCertHash())
 	if err != nil {
 		return err
 	}
 	return b.client.Send(env)
 }
+
+// tlsBoundDeliverer is a BlocksDeliverer which knows the TLS client
+// certificate presented on the connection its messages are sent over
+type tlsBoundDeliverer interface {
+	tlsCertHash() []byte
+}
+
+// tlsCertHash returns the hash of the TLS client certificate the peer
+// presented on the connection to the ordering service, or nil if there is none
+func (b *blocksRequester) tlsCertHash() []byte {
+	deliverer, isTLSBound := b.client.(tlsBoundDeliverer)
+	if !isTLSBound {
+		return nil
+	}
+	return deliverer.tlsCertHash()
+}
@@ -10,11 +10,13 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/validation"
 	"github.com/hyperledger/fabric/core/handlers/decoration"
@@ -53,16 +55,22 @@ var endorserLogger = flogging.MustGetLogger("endorser")
 
 type privateDataDistributor func(channel string, txID string, privateData *rwset.TxPvtReadWriteSet) error
 
+// channelConfigGetter returns the configuration of a channel, or nil if
+// the peer didn't join it
+type channelConfigGetter func(channelID string) channelconfig.Resources
+
 // Endorser provides the Endorser service ProcessProposal
 type Endorser struct {
 	policyChecker         policy.PolicyChecker
 	distributePrivateData privateDataDistributor
+	channelConfig         channelConfigGetter
 }
 
 // NewEndorserServer creates and returns a new Endorser server instance.
 func NewEndorserServer(privDist privateDataDistributor) pb.EndorserServer {
 	e := &Endorser{
 		distributePrivateData: privDist,
+		channelConfig:         peer.GetChannelConfig,
 		policyChecker: policy.NewPolicyChecker(
 			peer.NewChannelPolicyManagerGetter(),
 			mgmt.GetLocalMSP(),
@@ -78,6 +86,20 @@ func (e *Endorser) checkACL(signedProp *pb.SignedProposal, chdr *common.ChannelH
 	return e.policyChecker.CheckPolicy(chdr.ChannelId, policies.ChannelApplicationWriters, signedProp)
 }
 
+// checkTLSBinding checks that the proposal is bound to the TLS client
+// certificate of the connection it was received on, if the channel
+// requires it
+func (e *Endorser) checkTLSBinding(ctx context.Context, chdr *common.ChannelHeader) error {
+	res := e.channelConfig(chdr.ChannelId)
+	if res == nil || res.ChannelConfig() == nil || !res.ChannelConfig().Capabilities().TLSBinding() {
+		return nil
+	}
+	if err := comm.VerifyTLSBinding(ctx, chdr); err != nil {
+		return errors.WithMessage(err, "TLS binding check failed")
+	}
+	return nil
+}
+
 //TODO - check for escc and vscc
 func (*Endorser) checkEsccAndVscc(prop *pb.Proposal) error {
 	return nil
@@ -435,6 +457,10 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 			return nil, errors.Errorf("duplicate transaction found [%s]. Creator [%x]", txid, shdr.Creator)
 		}
 
+		if err = e.checkTLSBinding(ctx, chdr); err != nil {
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+		}
+
 		// check ACL only for application chaincodes; ACLs
 		// for system chaincodes are checked elsewhere
 		if !syscc.IsSysCC(hdrExt.ChaincodeId.Name) {
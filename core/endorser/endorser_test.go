@@ -17,6 +17,8 @@ limitations under the License.
 package endorser
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
 	"errors"
 	"flag"
@@ -35,6 +37,8 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	mockchannelconfig "github.com/hyperledger/fabric/common/mocks/config"
 	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
@@ -57,6 +61,7 @@ import (
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	grpcpeer "google.golang.org/grpc/peer"
 )
 
 var endorserServer pb.EndorserServer
@@ -728,6 +733,37 @@ func TestInvokeSccFail(t *testing.T) {
 	}
 }
 
+func TestTLSBinding(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte("certificate")}
+	ctx := grpcpeer.NewContext(context.Background(), &grpcpeer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}},
+	})
+	capabilities := &mockchannelconfig.ChannelCapabilities{}
+	e := &Endorser{
+		channelConfig: func(channelID string) channelconfig.Resources {
+			if channelID != "testchannel" {
+				return nil
+			}
+			return &mockchannelconfig.Resources{
+				ChannelConfigVal: &mockchannelconfig.Channel{CapabilitiesVal: capabilities},
+			}
+		},
+	}
+
+	// The binding isn't required by the channel
+	assert.NoError(t, e.checkTLSBinding(context.Background(), &common.ChannelHeader{ChannelId: "testchannel"}))
+	assert.NoError(t, e.checkTLSBinding(context.Background(), &common.ChannelHeader{ChannelId: "otherchannel"}))
+
+	capabilities.TLSBindingVal = true
+	err := e.checkTLSBinding(context.Background(), &common.ChannelHeader{ChannelId: "testchannel"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "TLS binding check failed")
+	err = e.checkTLSBinding(ctx, &common.ChannelHeader{ChannelId: "testchannel", TlsCertHash: []byte{1, 2, 3}})
+	assert.Error(t, err)
+	err = e.checkTLSBinding(ctx, &common.ChannelHeader{ChannelId: "testchannel", TlsCertHash: util.ComputeSHA256(cert.Raw)})
+	assert.NoError(t, err)
+}
+
 func newTempDir() string {
 	tempDir, err := ioutil.TempDir("", "fabric-")
 	if err != nil {
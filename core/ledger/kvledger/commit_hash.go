@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"crypto/sha256"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// computeCommitHash computes the commit hash of a validated block, that is, the SHA256 hash of the commit hash of the
+// previous block, of the validation flags of the transactions of the block, and of the updates of the public data and
+// of the hashes of the private data resulting from the valid transactions of the block. The private data itself is not
+// covered, as a peer may miss it, whereas its hashes are. Peers which validated the same blocks the same way, and
+// therefore applied the same updates to their state, have the same commit hashes. A block committed by a peer of a
+// prior version has no commit hash, the previous commit hash of the block which follows it is then empty
+func computeCommitHash(previousCommitHash []byte, block *common.Block, updates *privacyenabledstate.UpdateBatch) ([]byte, error) {
+	utils.InitBlockMetadata(block)
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeRawBytes(previousCommitHash); err != nil {
+		return nil, err
+	}
+	if err := buffer.EncodeRawBytes(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]); err != nil {
+		return nil, err
+	}
+
+	// The updates are hashed in the order of the keys, as the update batches do not preserve any order
+	var kvs []*privacyenabledstate.SnapshotKV
+	for _, ns := range updates.PubUpdates.GetUpdatedNamespaces() {
+		for key, vv := range updates.PubUpdates.GetUpdates(ns) {
+			kvs = append(kvs, &privacyenabledstate.SnapshotKV{Namespace: ns, Key: key, Value: vv.Value, Version: vv.Version})
+		}
+	}
+	for ns, nsBatch := range updates.HashUpdates.UpdateMap {
+		for _, coll := range nsBatch.GetCollectionNames() {
+			for keyHash, vv := range nsBatch.GetUpdates(coll) {
+				kvs = append(kvs, &privacyenabledstate.SnapshotKV{Namespace: ns, CollectionName: coll, Key: keyHash,
+					Value: vv.Value, Version: vv.Version})
+			}
+		}
+	}
+	sort.Slice(kvs, func(i, j int) bool { return compareSnapshotKeys(kvs[i], kvs[j]) < 0 })
+
+	h := sha256.New()
+	h.Write(buffer.Bytes())
+	for _, kv := range kvs {
+		buffer.Reset()
+		// A deletion is told apart from an update to an empty value
+		isDelete := uint64(0)
+		if kv.Value == nil {
+			isDelete = 1
+		}
+		if err := buffer.EncodeVarint(isDelete); err != nil {
+			return nil, err
+		}
+		kvBytes, err := encodeSnapshotKV(kv)
+		if err != nil {
+			return nil, err
+		}
+		if err := buffer.EncodeRawBytes(kvBytes); err != nil {
+			return nil, err
+		}
+		h.Write(buffer.Bytes())
+	}
+	return h.Sum(nil), nil
+}
+
+// addCommitHash computes the commit hash of the given block, which is validated and not yet
+// committed to the block store, and records it in the metadata of the block
+func (l *kvLedger) addCommitHash(block *common.Block) error {
+	info, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	commitHash, err := computeCommitHash(info.CommitHash, block, l.txtmgmt.GetPreparedUpdates())
+	if err != nil {
+		return err
+	}
+	utils.SetCommitHashInBlock(block, commitHash)
+	logger.Debugf("Channel [%s]: Commit hash of block [%d] is [%x]", l.ledgerID, block.Header.Number, commitHash)
+	return nil
+}
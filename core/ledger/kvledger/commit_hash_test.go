@@ -0,0 +1,138 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitHash(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(proto.Clone(gb).(*common.Block))
+	assert.NoError(t, err)
+
+	// The blocks are kept as received from the orderer, for committing them to a second peer
+	var blocks []*lgr.BlockAndPvtData
+	for i, kvs := range []map[string]string{{"key1": "value1.1"}, {"key2": "value2.2"}, {"key1": "value1.3"}} {
+		block := prepareNextBlockForTest(t, ledger, bg, fmt.Sprintf("SimulateForBlk%d", i+1), kvs, nil).Block
+		blocks = append(blocks, &lgr.BlockAndPvtData{Block: proto.Clone(block).(*common.Block)})
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}))
+	}
+	commitHashes := getCommitHashesForTest(t, ledger)
+	assert.Len(t, commitHashes, 4)
+	for i, commitHash := range commitHashes {
+		assert.Len(t, commitHash, 32)
+		for _, otherCommitHash := range commitHashes[:i] {
+			assert.NotEqual(t, otherCommitHash, commitHash)
+		}
+	}
+	bcInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, commitHashes[3], bcInfo.CommitHash)
+
+	// The commit hash of the last block is loaded from the block store on restart
+	ledger.Close()
+	provider.Close()
+	provider, _ = NewProvider()
+	ledger, err = provider.Open("testLedger")
+	assert.NoError(t, err)
+	bcInfo, err = ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, commitHashes[3], bcInfo.CommitHash)
+	ledger.Close()
+	provider.Close()
+
+	// A second peer which invalidates the transaction of block 2, for instance because of an endorsement
+	// policy failure, has the same commit hash for block 1 and different commit hashes from block 2 on
+	env = createTestEnv(t, "/tmp/fabric/ledgertests/kvledger/peer2")
+	defer env.cleanup()
+	provider, _ = NewProvider()
+	defer provider.Close()
+	ledger, err = provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+	txsFilter := util.NewTxValidationFlags(1)
+	txsFilter.SetFlag(0, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+	blocks[1].Block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsFilter
+	for _, blockAndPvtdata := range blocks {
+		assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata))
+	}
+	otherCommitHashes := getCommitHashesForTest(t, ledger)
+	assert.Equal(t, commitHashes[:2], otherCommitHashes[:2])
+	assert.NotEqual(t, commitHashes[2], otherCommitHashes[2])
+	assert.NotEqual(t, commitHashes[3], otherCommitHashes[3])
+}
+
+func TestComputeCommitHash(t *testing.T) {
+	block := common.NewBlock(1, nil)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = util.NewTxValidationFlags(1)
+	updates := privacyenabledstate.NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	updates.PubUpdates.Put("ns2", "key2", []byte("value2"), version.NewHeight(1, 0))
+	updates.HashUpdates.Put("ns1", "coll", []byte("keyHash"), []byte("valueHash"), version.NewHeight(1, 0))
+	updates.PvtUpdates.Put("ns1", "coll", "key", []byte("value"), version.NewHeight(1, 0))
+	commitHash, err := computeCommitHash([]byte("previousCommitHash"), block, updates)
+	assert.NoError(t, err)
+
+	// The private data is not covered by the commit hash
+	otherUpdates := privacyenabledstate.NewUpdateBatch()
+	otherUpdates.HashUpdates.Put("ns1", "coll", []byte("keyHash"), []byte("valueHash"), version.NewHeight(1, 0))
+	otherUpdates.PubUpdates.Put("ns2", "key2", []byte("value2"), version.NewHeight(1, 0))
+	otherUpdates.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	hash, err := computeCommitHash([]byte("previousCommitHash"), block, otherUpdates)
+	assert.NoError(t, err)
+	assert.Equal(t, commitHash, hash)
+
+	hash, err = computeCommitHash([]byte("otherCommitHash"), block, updates)
+	assert.NoError(t, err)
+	assert.NotEqual(t, commitHash, hash)
+
+	otherUpdates.PubUpdates.Put("ns1", "key1", []byte{}, version.NewHeight(1, 0))
+	hash, err = computeCommitHash([]byte("previousCommitHash"), block, otherUpdates)
+	assert.NoError(t, err)
+	assert.NotEqual(t, commitHash, hash)
+	otherHash := hash
+	otherUpdates.PubUpdates.Delete("ns1", "key1", version.NewHeight(1, 0))
+	hash, err = computeCommitHash([]byte("previousCommitHash"), block, otherUpdates)
+	assert.NoError(t, err)
+	assert.NotEqual(t, otherHash, hash)
+
+	txsFilter := util.NewTxValidationFlags(1)
+	txsFilter.SetFlag(0, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsFilter
+	hash, err = computeCommitHash([]byte("previousCommitHash"), block, updates)
+	assert.NoError(t, err)
+	assert.NotEqual(t, commitHash, hash)
+}
+
+// getCommitHashesForTest returns the commit hashes recorded in the metadata of the blocks of the ledger
+func getCommitHashesForTest(t *testing.T, l lgr.PeerLedger) [][]byte {
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	var commitHashes [][]byte
+	for blockNum := uint64(0); blockNum < bcInfo.Height; blockNum++ {
+		block, err := l.GetBlockByNumber(blockNum)
+		assert.NoError(t, err)
+		commitHashes = append(commitHashes, putils.GetCommitHashFromBlock(block))
+	}
+	return commitHashes
+}
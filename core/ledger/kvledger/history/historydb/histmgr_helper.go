@@ -24,6 +24,8 @@ import (
 
 var compositeKeySep = []byte{0x00}
 
+const hashedDataNsJoiner = "$$h"
+
 //ConstructCompositeHistoryKey builds the History Key of namespace~key~blocknum~trannum
 // using an order preserving encoding so that history query results are ordered by height
 func ConstructCompositeHistoryKey(ns string, key string, blocknum uint64, trannum uint64) []byte {
@@ -53,6 +55,12 @@ func ConstructPartialCompositeHistoryKey(ns string, key string, endkey bool) []b
 	return compositeKey
 }
 
+//DeriveHashedDataNamespace returns the namespace under which the history of the hashed
+// writes of a collection is recorded. The namespace never collides with the one of a chaincode
+func DeriveHashedDataNamespace(ns string, collection string) string {
+	return ns + hashedDataNsJoiner + collection
+}
+
 //SplitCompositeHistoryKey splits the key bytes using a separator
 func SplitCompositeHistoryKey(bytesToSplit []byte, separator []byte) ([]byte, []byte) {
 	split := bytes.SplitN(bytesToSplit, separator, 2)
@@ -46,3 +46,8 @@ func TestSplitCompositeKey(t *testing.T) {
 	// second position should hold the extra bytes that were split off
 	testutil.AssertEquals(t, extraBytes, []byte("extra bytes to split"))
 }
+
+func TestDeriveHashedDataNamespace(t *testing.T) {
+	testutil.AssertEquals(t, DeriveHashedDataNamespace("ns1", "coll1"), "ns1$$hcoll1")
+	testutil.AssertNotEquals(t, DeriveHashedDataNamespace("ns1", "coll1"), DeriveHashedDataNamespace("ns1", "coll2"))
+}
@@ -35,7 +35,12 @@ type HistoryDBProvider interface {
 type HistoryDB interface {
 	NewHistoryQueryExecutor(blockStore blkstorage.BlockStore) (ledger.HistoryQueryExecutor, error)
 	Commit(block *common.Block) error
+	// BootstrapFromSnapshot sets the savepoint of an empty history DB to the last block of a snapshot,
+	// without any history record. The history prior to the snapshot is not available afterwards
+	BootstrapFromSnapshot(lastBlock *common.Block) error
 	GetLastSavepoint() (*version.Height, error)
+	// Drop removes all the history records and the savepoint of the history DB
+	Drop() error
 	ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error)
 	CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package historyleveldb
 
 import (
+	"encoding/hex"
+
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -33,6 +35,7 @@ import (
 var logger = flogging.MustGetLogger("historyleveldb")
 
 var savePointKey = []byte{0x00}
+var snapshotHeightKey = []byte{0x00, 0x01}
 var emptyValue = []byte{}
 
 // HistoryDBProvider implements interface HistoryDBProvider
@@ -48,6 +51,14 @@ func NewHistoryDBProvider() *HistoryDBProvider {
 	return &HistoryDBProvider{dbProvider}
 }
 
+// NewReadOnlyHistoryDBProvider instantiates HistoryDBProvider on the existing history db at the given path,
+// which is opened read-only. The commits to the history fail
+func NewReadOnlyHistoryDBProvider(dbPath string) *HistoryDBProvider {
+	logger.Debugf("constructing read-only HistoryDBProvider dbPath=%s", dbPath)
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath, ReadOnly: true})
+	return &HistoryDBProvider{dbProvider}
+}
+
 // GetDBHandle gets the handle to a named database
 func (provider *HistoryDBProvider) GetDBHandle(dbName string) (historydb.HistoryDB, error) {
 	return newHistoryDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
@@ -156,6 +167,17 @@ func (historyDB *historyDB) Commit(block *common.Block) error {
 					// No value is required, write an empty byte array (emptyValue) since Put() of nil is not allowed
 					dbBatch.Put(compositeHistoryKey, emptyValue)
 				}
+
+				// the hashed writes of the collections are recorded under a namespace derived from the collection,
+				// so that the history of a private data key can be audited without its private data.
+				// The key hashes are hex-encoded, since a binary hash may contain the separator of the composite key
+				for _, collHashedRwSet := range nsRWSet.CollHashedRwSets {
+					hashedDataNs := historydb.DeriveHashedDataNamespace(ns, collHashedRwSet.CollectionName)
+					for _, kvWriteHash := range collHashedRwSet.HashedRwSet.HashedWrites {
+						compositeHistoryKey := historydb.ConstructCompositeHistoryKey(hashedDataNs, hex.EncodeToString(kvWriteHash.KeyHash), blockNo, tranNo)
+						dbBatch.Put(compositeHistoryKey, emptyValue)
+					}
+				}
 			}
 
 		} else {
@@ -177,6 +199,33 @@ func (historyDB *historyDB) Commit(block *common.Block) error {
 	return nil
 }
 
+// BootstrapFromSnapshot implements method in HistoryDB interface
+func (historyDB *historyDB) BootstrapFromSnapshot(lastBlock *common.Block) error {
+	blockNo := lastBlock.Header.Number
+	logger.Infof("Channel [%s]: Bootstrapping history database from snapshot at blockNo [%d]", historyDB.dbName, blockNo)
+	height := version.NewHeight(blockNo, uint64(len(lastBlock.Data.Data)))
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	dbBatch.Put(snapshotHeightKey, height.ToBytes())
+	dbBatch.Put(savePointKey, height.ToBytes())
+	return historyDB.db.WriteBatch(dbBatch, true)
+}
+
+// Drop implements method in HistoryDB interface
+func (historyDB *historyDB) Drop() error {
+	logger.Infof("Channel [%s]: Dropping the history database", historyDB.dbName)
+	return historyDB.db.DeleteAll()
+}
+
+// getSnapshotHeight returns the height of the snapshot the history DB was bootstrapped from, if any
+func (historyDB *historyDB) getSnapshotHeight() (*version.Height, error) {
+	heightBytes, err := historyDB.db.Get(snapshotHeightKey)
+	if err != nil || heightBytes == nil {
+		return nil, err
+	}
+	height, _ := version.NewHeightFromBytes(heightBytes)
+	return height, nil
+}
+
 // NewHistoryQueryExecutor implements method in HistoryDB interface
 func (historyDB *historyDB) NewHistoryQueryExecutor(blockStore blkstorage.BlockStore) (ledger.HistoryQueryExecutor, error) {
 	return &LevelHistoryDBQueryExecutor{historyDB, blockStore}, nil
@@ -204,7 +253,9 @@ func (historyDB *historyDB) ShouldRecover(lastAvailableBlock uint64) (bool, uint
 	if savepoint == nil {
 		return true, 0, nil
 	}
-	return savepoint.BlockNum != lastAvailableBlock, savepoint.BlockNum + 1, nil
+	// The history database may be ahead of the block storage by the block that was being committed
+	// to both in parallel, in which case there is nothing to recover
+	return savepoint.BlockNum < lastAvailableBlock, savepoint.BlockNum + 1, nil
 }
 
 // CommitLostBlock implements method in interface kvledger.Recoverer
@@ -17,11 +17,19 @@ limitations under the License.
 package historyleveldb
 
 import (
+	"bytes"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
@@ -39,21 +47,192 @@ type LevelHistoryDBQueryExecutor struct {
 
 // GetHistoryForKey implements method in interface `ledger.HistoryQueryExecutor`
 func (q *LevelHistoryDBQueryExecutor) GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error) {
+	return q.GetHistoryForKeyWithOptions(namespace, key, 0, 0, false, 0, "")
+}
+
+// GetHistoryForKeyWithOptions implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForKeyWithOptions(namespace string, key string, startBlock uint64, endBlock uint64,
+	reverse bool, pageSize int32, bookmark string) (ledger.HistoryResultsIterator, error) {
+	getModification := func(tranEnvelope *common.Envelope) (commonledger.QueryResult, error) {
+		return getKeyModificationFromTran(tranEnvelope, namespace, key)
+	}
+	return q.newHistoryScanner(namespace, key, startBlock, endBlock, reverse, pageSize, bookmark, getModification)
+}
+
+// GetHistoryForKeySince implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForKeySince(namespace string, key string, since *timestamp.Timestamp,
+	reverse bool, pageSize int32, bookmark string) (ledger.HistoryResultsIterator, error) {
+	if since == nil {
+		return nil, errors.New("the time since which to retrieve the history is not set")
+	}
+	startBlock, err := q.getStartBlockSince(since)
+	if err != nil {
+		return nil, err
+	}
+	// the timestamps of the transactions are set by their clients, and don't increase along the blocks,
+	// so all the history records are scanned and the modifications of the transactions created before
+	// the given time are skipped
+	getModification := func(tranEnvelope *common.Envelope) (commonledger.QueryResult, error) {
+		queryResult, err := getKeyModificationFromTran(tranEnvelope, namespace, key)
+		if err != nil || isBefore(queryResult.(*queryresult.KeyModification).Timestamp, since) {
+			return nil, err
+		}
+		return queryResult, nil
+	}
+	return q.newHistoryScanner(namespace, key, startBlock, 0, reverse, pageSize, bookmark, getModification)
+}
+
+// getStartBlockSince returns the first available block of the block storage. If the ledger was bootstrapped
+// from a snapshot and the first available block was already created after the given time, as given by the
+// timestamp of its last transaction, the last block of the snapshot is returned, since the history of the
+// blocks of the snapshot, which may include modifications after that time, is not available
+func (q *LevelHistoryDBQueryExecutor) getStartBlockSince(since *timestamp.Timestamp) (uint64, error) {
+	snapshotHeight, err := q.historyDB.getSnapshotHeight()
+	if err != nil || snapshotHeight == nil {
+		return 0, err
+	}
+	firstAvailableBlock := snapshotHeight.BlockNum + 1
+	bcInfo, err := q.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return 0, err
+	}
+	if firstAvailableBlock >= bcInfo.Height {
+		return firstAvailableBlock, nil
+	}
+	blockTime, err := q.getBlockTime(firstAvailableBlock)
+	if err != nil {
+		return 0, err
+	}
+	if !isBefore(blockTime, since) {
+		return snapshotHeight.BlockNum, nil
+	}
+	return firstAvailableBlock, nil
+}
+
+// getBlockTime returns the timestamp of the last transaction of a block
+func (q *LevelHistoryDBQueryExecutor) getBlockTime(blockNum uint64) (*timestamp.Timestamp, error) {
+	block, err := q.blockStore.RetrieveBlockByNumber(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if len(block.Data.Data) == 0 {
+		return nil, fmt.Errorf("block [%d] has no transactions", blockNum)
+	}
+	env, err := putils.GetEnvelopeFromBlock(block.Data.Data[len(block.Data.Data)-1])
+	if err != nil {
+		return nil, err
+	}
+	payload, err := putils.GetPayload(env)
+	if err != nil {
+		return nil, err
+	}
+	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	return chdr.Timestamp, nil
+}
+
+// isBefore returns whether the timestamp ts is before the timestamp since, where a nil timestamp
+// is the earliest one
+func isBefore(ts *timestamp.Timestamp, since *timestamp.Timestamp) bool {
+	if ts.GetSeconds() != since.GetSeconds() {
+		return ts.GetSeconds() < since.GetSeconds()
+	}
+	return ts.GetNanos() < since.GetNanos()
+}
+
+// GetPrivateDataHashHistoryForKey implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetPrivateDataHashHistoryForKey(namespace string, collection string, keyHash []byte) (commonledger.ResultsIterator, error) {
+	getModification := func(tranEnvelope *common.Envelope) (commonledger.QueryResult, error) {
+		return getHashedKeyModificationFromTran(tranEnvelope, namespace, collection, keyHash)
+	}
+	hashedDataNs := historydb.DeriveHashedDataNamespace(namespace, collection)
+	return q.newHistoryScanner(hashedDataNs, hex.EncodeToString(keyHash), 0, 0, false, 0, "", getModification)
+}
+
+// newHistoryScanner range scans the history records of namespace~key
+// which are between the start and the end block and are not before the bookmark
+func (q *LevelHistoryDBQueryExecutor) newHistoryScanner(namespace string, key string, startBlock uint64, endBlock uint64,
+	reverse bool, pageSize int32, bookmark string, getModification modificationGetter) (*historyScanner, error) {
 
 	if ledgerconfig.IsHistoryDBEnabled() == false {
 		return nil, errors.New("History tracking not enabled - historyDatabase is false")
 	}
+	if endBlock != 0 && startBlock > endBlock {
+		return nil, fmt.Errorf("start block [%d] is after end block [%d]", startBlock, endBlock)
+	}
+	// the ledger bootstrapped from a snapshot has no history records of the blocks of the snapshot,
+	// hence a scan of these blocks fails rather than returning a partial history
+	snapshotHeight, err := q.historyDB.getSnapshotHeight()
+	if err != nil {
+		return nil, err
+	}
+	if snapshotHeight != nil && startBlock <= snapshotHeight.BlockNum {
+		return nil, fmt.Errorf("the history prior to block [%d] is not available, the ledger was bootstrapped from a snapshot at block [%d]",
+			snapshotHeight.BlockNum+1, snapshotHeight.BlockNum)
+	}
 
-	var compositeStartKey []byte
+	compositePartialKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	compositeStartKey := historydb.ConstructCompositeHistoryKey(namespace, key, startBlock, 0)
 	var compositeEndKey []byte
-	compositeStartKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
-	compositeEndKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, true)
+	if endBlock == 0 || endBlock == math.MaxUint64 {
+		compositeEndKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, true)
+	} else {
+		compositeEndKey = historydb.ConstructCompositeHistoryKey(namespace, key, endBlock+1, 0)
+	}
+
+	// the history database is committed in parallel with the block storage, so the history records
+	// of the block being committed are skipped until the block storage contains their transactions
+	bcInfo, err := q.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+	heightKey := historydb.ConstructCompositeHistoryKey(namespace, key, bcInfo.Height, 0)
+	if bytes.Compare(heightKey, compositeEndKey) < 0 {
+		compositeEndKey = heightKey
+	}
+
+	if bookmark != "" {
+		blockNum, tranNum, err := decodeBookmark(bookmark)
+		if err != nil {
+			return nil, err
+		}
+		bookmarkKey := historydb.ConstructCompositeHistoryKey(namespace, key, blockNum, tranNum)
+		if reverse {
+			// the record of the bookmark is the first one of the page, so the end key follows it
+			bookmarkKey = append(bookmarkKey, 0x00)
+			if bytes.Compare(bookmarkKey, compositeEndKey) < 0 {
+				compositeEndKey = bookmarkKey
+			}
+		} else if bytes.Compare(bookmarkKey, compositeStartKey) > 0 {
+			compositeStartKey = bookmarkKey
+		}
+	}
+
+	// a page is limited like any other query, see ledgerconfig.GetTotalQueryLimit
+	if totalQueryLimit := ledgerconfig.GetTotalQueryLimit(); pageSize > 0 && int(pageSize) > totalQueryLimit {
+		pageSize = int32(totalQueryLimit)
+	}
 
 	// range scan to find any history records starting with namespace~key
 	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
-	return newHistoryScanner(compositeStartKey, namespace, key, dbItr, q.blockStore), nil
+	return &historyScanner{
+		compositePartialKey: compositePartialKey,
+		namespace:           namespace,
+		key:                 key,
+		dbItr:               dbItr,
+		blockStore:          q.blockStore,
+		reverse:             reverse,
+		pageSize:            pageSize,
+		getModification:     getModification,
+	}, nil
 }
 
+// modificationGetter gets the modification of the scanned key from the transaction of a history record,
+// or nil if the modification is to be skipped
+type modificationGetter func(tranEnvelope *common.Envelope) (commonledger.QueryResult, error)
+
 //historyScanner implements ResultsIterator for iterating through history results
 type historyScanner struct {
 	compositePartialKey []byte //compositePartialKey includes namespace~key
@@ -61,95 +240,194 @@ type historyScanner struct {
 	key                 string
 	dbItr               iterator.Iterator
 	blockStore          blkstorage.BlockStore
+	reverse             bool
+	pageSize            int32
+	getModification     modificationGetter
+	started             bool
+	exhausted           bool
+	fetchedCount        int32
 }
 
-func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
-	dbItr iterator.Iterator, blockStore blkstorage.BlockStore) *historyScanner {
-	return &historyScanner{compositePartialKey, namespace, key, dbItr, blockStore}
+// moveNext moves the db iterator to the next history record in the order of the scan
+func (scanner *historyScanner) moveNext() bool {
+	if scanner.exhausted {
+		return false
+	}
+	var valid bool
+	switch {
+	case !scanner.reverse:
+		valid = scanner.dbItr.Next()
+	case !scanner.started:
+		valid = scanner.dbItr.Last()
+	default:
+		valid = scanner.dbItr.Prev()
+	}
+	scanner.started = true
+	scanner.exhausted = !valid
+	return valid
 }
 
 func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
-	if !scanner.dbItr.Next() {
+	if scanner.pageSize > 0 && scanner.fetchedCount >= scanner.pageSize {
 		return nil, nil
 	}
+	for scanner.moveNext() {
+		blockNum, tranNum := scanner.currentBlockNumTranNum()
+		logger.Debugf("Found history record for namespace:%s key:%s at blockNumTranNum %v:%v\n",
+			scanner.namespace, scanner.key, blockNum, tranNum)
+
+		// Get the transaction from block storage that is associated with this history record
+		tranEnvelope, err := scanner.blockStore.RetrieveTxByBlockNumTranNum(blockNum, tranNum)
+		if err != nil {
+			return nil, err
+		}
+
+		// Get the txid, key write value, timestamp, and delete indicator associated with this transaction
+		queryResult, err := scanner.getModification(tranEnvelope)
+		if err != nil {
+			return nil, err
+		}
+		if queryResult == nil {
+			continue
+		}
+		scanner.fetchedCount++
+		logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s\n",
+			scanner.namespace, scanner.key, queryResult.(*queryresult.KeyModification).TxId)
+		return queryResult, nil
+	}
+	return nil, nil
+}
+
+// currentBlockNumTranNum returns the block number and the transaction number of the current history record
+func (scanner *historyScanner) currentBlockNumTranNum() (uint64, uint64) {
 	historyKey := scanner.dbItr.Key() // history key is in the form namespace~key~blocknum~trannum
 
 	// SplitCompositeKey(namespace~key~blocknum~trannum, namespace~key~) will return the blocknum~trannum in second position
 	_, blockNumTranNumBytes := historydb.SplitCompositeHistoryKey(historyKey, scanner.compositePartialKey)
 	blockNum, bytesConsumed := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes[0:])
 	tranNum, _ := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes[bytesConsumed:])
-	logger.Debugf("Found history record for namespace:%s key:%s at blockNumTranNum %v:%v\n",
-		scanner.namespace, scanner.key, blockNum, tranNum)
-
-	// Get the transaction from block storage that is associated with this history record
-	tranEnvelope, err := scanner.blockStore.RetrieveTxByBlockNumTranNum(blockNum, tranNum)
-	if err != nil {
-		return nil, err
-	}
+	return blockNum, tranNum
+}
 
-	// Get the txid, key write value, timestamp, and delete indicator associated with this transaction
-	queryResult, err := getKeyModificationFromTran(tranEnvelope, scanner.namespace, scanner.key)
-	if err != nil {
-		return nil, err
+// GetBookmarkAndClose implements method in interface `ledger.HistoryResultsIterator`
+func (scanner *historyScanner) GetBookmarkAndClose() string {
+	defer scanner.Close()
+	if scanner.pageSize <= 0 || scanner.fetchedCount < scanner.pageSize || !scanner.moveNext() {
+		return ""
 	}
-	logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s\n",
-		scanner.namespace, scanner.key, queryResult.(*queryresult.KeyModification).TxId)
-	return queryResult, nil
+	return encodeBookmark(scanner.currentBlockNumTranNum())
 }
 
 func (scanner *historyScanner) Close() {
 	scanner.dbItr.Release()
 }
 
+// encodeBookmark encodes the height of the first history record of a page as the bookmark of the page
+func encodeBookmark(blockNum uint64, tranNum uint64) string {
+	return fmt.Sprintf("%d:%d", blockNum, tranNum)
+}
+
+func decodeBookmark(bookmark string) (uint64, uint64, error) {
+	heights := strings.Split(bookmark, ":")
+	if len(heights) != 2 {
+		return 0, 0, fmt.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	blockNum, err := strconv.ParseUint(heights[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	tranNum, err := strconv.ParseUint(heights[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	return blockNum, tranNum, nil
+}
+
 // getTxIDandKeyWriteValueFromTran inspects a transaction for writes to a given key
 func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (commonledger.QueryResult, error) {
 	logger.Debugf("Entering getKeyModificationFromTran()\n", namespace, key)
 
+	txID, timestamp, txRWSet, err := getTxRWSetFromTran(tranEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	// look for the namespace and key by looping through the transaction's ReadWriteSets
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.NameSpace == namespace {
+			// got the correct namespace, now find the key write
+			for _, kvWrite := range nsRWSet.KvRwSet.Writes {
+				if kvWrite.Key == key {
+					return &queryresult.KeyModification{TxId: txID, Value: kvWrite.Value,
+						Timestamp: timestamp, IsDelete: kvWrite.IsDelete}, nil
+				}
+			} // end keys loop
+			return nil, errors.New("Key not found in namespace's writeset")
+		} // end if
+	} //end namespaces loop
+	return nil, errors.New("Namespace not found in transaction's ReadWriteSets")
+
+}
+
+// getHashedKeyModificationFromTran inspects a transaction for hashed writes to a given key hash of a collection
+func getHashedKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, collection string, keyHash []byte) (commonledger.QueryResult, error) {
+	logger.Debugf("Entering getHashedKeyModificationFromTran()\n", namespace, collection)
+
+	txID, timestamp, txRWSet, err := getTxRWSetFromTran(tranEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.NameSpace != namespace {
+			continue
+		}
+		for _, collHashedRwSet := range nsRWSet.CollHashedRwSets {
+			if collHashedRwSet.CollectionName != collection {
+				continue
+			}
+			for _, kvWriteHash := range collHashedRwSet.HashedRwSet.HashedWrites {
+				if bytes.Equal(kvWriteHash.KeyHash, keyHash) {
+					return &queryresult.KeyModification{TxId: txID, Value: kvWriteHash.ValueHash,
+						Timestamp: timestamp, IsDelete: kvWriteHash.IsDelete}, nil
+				}
+			}
+			return nil, errors.New("Key hash not found in collection's hashed writeset")
+		}
+		return nil, errors.New("Collection not found in namespace's hashed ReadWriteSets")
+	}
+	return nil, errors.New("Namespace not found in transaction's ReadWriteSets")
+}
+
+// getTxRWSetFromTran returns the txid, the timestamp and the read-write set of a transaction
+func getTxRWSetFromTran(tranEnvelope *common.Envelope) (string, *timestamp.Timestamp, *rwsetutil.TxRwSet, error) {
 	// extract action from the envelope
 	payload, err := putils.GetPayload(tranEnvelope)
 	if err != nil {
-		return nil, err
+		return "", nil, nil, err
 	}
 
 	tx, err := putils.GetTransaction(payload.Data)
 	if err != nil {
-		return nil, err
+		return "", nil, nil, err
 	}
 
 	_, respPayload, err := putils.GetPayloads(tx.Actions[0])
 	if err != nil {
-		return nil, err
+		return "", nil, nil, err
 	}
 
 	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
 	if err != nil {
-		return nil, err
+		return "", nil, nil, err
 	}
 
-	txID := chdr.TxId
-	timestamp := chdr.Timestamp
-
 	txRWSet := &rwsetutil.TxRwSet{}
 
 	// Get the Result from the Action and then Unmarshal
 	// it into a TxReadWriteSet using custom unmarshalling
 	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
-		return nil, err
+		return "", nil, nil, err
 	}
-
-	// look for the namespace and key by looping through the transaction's ReadWriteSets
-	for _, nsRWSet := range txRWSet.NsRwSets {
-		if nsRWSet.NameSpace == namespace {
-			// got the correct namespace, now find the key write
-			for _, kvWrite := range nsRWSet.KvRwSet.Writes {
-				if kvWrite.Key == key {
-					return &queryresult.KeyModification{TxId: txID, Value: kvWrite.Value,
-						Timestamp: timestamp, IsDelete: kvWrite.IsDelete}, nil
-				}
-			} // end keys loop
-			return nil, errors.New("Key not found in namespace's writeset")
-		} // end if
-	} //end namespaces loop
-	return nil, errors.New("Namespace not found in transaction's ReadWriteSets")
-
+	return chdr.TxId, chdr.Timestamp, txRWSet, nil
 }
@@ -17,18 +17,23 @@ limitations under the License.
 package historyleveldb
 
 import (
+	"encoding/hex"
 	"os"
 	"strconv"
 	"testing"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
 )
 
@@ -79,6 +84,11 @@ func TestSavepoint(t *testing.T) {
 	testutil.AssertEquals(t, status, false)
 	testutil.AssertEquals(t, blockNum, uint64(2))
 
+	// Should Recover should return false when the history db is ahead of the block storage
+	status, _, err = env.testHistoryDB.ShouldRecover(0)
+	testutil.AssertNoError(t, err, "Error upon historyDatabase.ShouldRecover()")
+	testutil.AssertEquals(t, status, false)
+
 	// create the next block (block 2)
 	txid = util2.GenerateUUID()
 	simulator, _ = env.txmgr.NewTxSimulator(txid)
@@ -244,6 +254,280 @@ func TestHistoryForInvalidTran(t *testing.T) {
 	testutil.AssertNil(t, kmod)
 }
 
+func TestHistoryWithOptions(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	testutil.AssertNoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	testutil.AssertNoError(t, store1.AddBlock(gb), "")
+	testutil.AssertNoError(t, env.testHistoryDB.Commit(gb), "")
+
+	// blocks 1 to 5 write value1 to value5 to key7, and block 3 also writes value3b in a second transaction
+	var blocks []*common.Block
+	for i := 1; i <= 5; i++ {
+		values := []string{"value" + strconv.Itoa(i)}
+		if i == 3 {
+			values = append(values, "value3b")
+		}
+		simulationResults := [][]byte{}
+		for _, value := range values {
+			simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+			simulator.SetState("ns1", "key7", []byte(value))
+			simulator.Done()
+			simRes, _ := simulator.GetTxSimulationResults()
+			pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+			simulationResults = append(simulationResults, pubSimResBytes)
+		}
+		block := bg.NextBlock(simulationResults)
+		testutil.AssertNoError(t, store1.AddBlock(block), "")
+		testutil.AssertNoError(t, env.testHistoryDB.Commit(block), "")
+		blocks = append(blocks, block)
+	}
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	testutil.AssertNoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	testCases := []struct {
+		name           string
+		startBlock     uint64
+		endBlock       uint64
+		reverse        bool
+		expectedValues []string
+	}{
+		{"all", 0, 0, false, []string{"value1", "value2", "value3", "value3b", "value4", "value5"}},
+		{"range", 2, 3, false, []string{"value2", "value3", "value3b"}},
+		{"from-block", 4, 0, false, []string{"value4", "value5"}},
+		{"single-block", 3, 3, false, []string{"value3", "value3b"}},
+		{"past-last-block", 6, 0, false, nil},
+		{"reverse", 0, 0, true, []string{"value5", "value4", "value3b", "value3", "value2", "value1"}},
+		{"reverse-range", 2, 4, true, []string{"value4", "value3b", "value3", "value2"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			itr, err := qhistory.GetHistoryForKeyWithOptions("ns1", "key7", testCase.startBlock, testCase.endBlock, testCase.reverse, 0, "")
+			testutil.AssertNoError(t, err, "Error upon GetHistoryForKeyWithOptions()")
+			testutil.AssertEquals(t, retrieveHistoryValues(t, itr), testCase.expectedValues)
+			testutil.AssertEquals(t, itr.GetBookmarkAndClose(), "")
+		})
+	}
+
+	// pages of the history are retrieved with the bookmark of the previous page, in both orders
+	for _, reverse := range []bool{false, true} {
+		var pages [][]string
+		bookmark := ""
+		for {
+			itr, err := qhistory.GetHistoryForKeyWithOptions("ns1", "key7", 2, 0, reverse, 2, bookmark)
+			testutil.AssertNoError(t, err, "Error upon GetHistoryForKeyWithOptions()")
+			pages = append(pages, retrieveHistoryValues(t, itr))
+			if bookmark = itr.GetBookmarkAndClose(); bookmark == "" {
+				break
+			}
+		}
+		if reverse {
+			testutil.AssertEquals(t, pages, [][]string{{"value5", "value4"}, {"value3b", "value3"}, {"value2"}})
+		} else {
+			testutil.AssertEquals(t, pages, [][]string{{"value2", "value3"}, {"value3b", "value4"}, {"value5"}})
+		}
+	}
+
+	// the history since the time the second transaction of block 3 was created
+	// skips the modification of the first one
+	env3, err := putils.GetEnvelopeFromBlock(blocks[2].Data.Data[1])
+	testutil.AssertNoError(t, err, "")
+	chdr3, err := putils.ChannelHeader(env3)
+	testutil.AssertNoError(t, err, "")
+	afterLastBlock := &timestamp.Timestamp{Seconds: chdr3.Timestamp.Seconds + 3600}
+	sinceTestCases := []struct {
+		name           string
+		since          *timestamp.Timestamp
+		reverse        bool
+		expectedValues []string
+	}{
+		{"since-beginning", &timestamp.Timestamp{}, false, []string{"value1", "value2", "value3", "value3b", "value4", "value5"}},
+		{"since-transaction", chdr3.Timestamp, false, []string{"value3b", "value4", "value5"}},
+		{"reverse-since-transaction", chdr3.Timestamp, true, []string{"value5", "value4", "value3b"}},
+		{"since-after-last-block", afterLastBlock, false, nil},
+	}
+	for _, testCase := range sinceTestCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			itr, err := qhistory.GetHistoryForKeySince("ns1", "key7", testCase.since, testCase.reverse, 0, "")
+			testutil.AssertNoError(t, err, "Error upon GetHistoryForKeySince()")
+			testutil.AssertEquals(t, retrieveHistoryValues(t, itr), testCase.expectedValues)
+			testutil.AssertEquals(t, itr.GetBookmarkAndClose(), "")
+		})
+	}
+	itr, err := qhistory.GetHistoryForKeySince("ns1", "key7", chdr3.Timestamp, false, 2, "")
+	testutil.AssertNoError(t, err, "Error upon GetHistoryForKeySince()")
+	testutil.AssertEquals(t, retrieveHistoryValues(t, itr), []string{"value3b", "value4"})
+	bookmark := itr.GetBookmarkAndClose()
+	itr, err = qhistory.GetHistoryForKeySince("ns1", "key7", chdr3.Timestamp, false, 2, bookmark)
+	testutil.AssertNoError(t, err, "Error upon GetHistoryForKeySince()")
+	testutil.AssertEquals(t, retrieveHistoryValues(t, itr), []string{"value5"})
+	testutil.AssertEquals(t, itr.GetBookmarkAndClose(), "")
+	_, err = qhistory.GetHistoryForKeySince("ns1", "key7", nil, false, 0, "")
+	testutil.AssertError(t, err, "Error should have been returned when the time isn't set")
+
+	_, err = qhistory.GetHistoryForKeyWithOptions("ns1", "key7", 3, 2, false, 0, "")
+	testutil.AssertError(t, err, "Error should have been returned when the start block is after the end block")
+	_, err = qhistory.GetHistoryForKeyWithOptions("ns1", "key7", 0, 0, false, 2, "not-a-bookmark")
+	testutil.AssertError(t, err, "Error should have been returned for an invalid bookmark")
+}
+
+func TestHistorySinceUnorderedTimestamps(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	testutil.AssertNoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	testutil.AssertNoError(t, store1.AddBlock(gb), "")
+	testutil.AssertNoError(t, env.testHistoryDB.Commit(gb), "")
+
+	// the client of the transaction of block 1 set its timestamp an hour ahead of the following blocks
+	var later *timestamp.Timestamp
+	for i := 1; i <= 3; i++ {
+		simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+		simulator.SetState("ns1", "key7", []byte("value"+strconv.Itoa(i)))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimResBytes})
+		if i == 1 {
+			later = setTxTimestamp(t, block, 3600)
+		}
+		testutil.AssertNoError(t, store1.AddBlock(block), "")
+		testutil.AssertNoError(t, env.testHistoryDB.Commit(block), "")
+	}
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	testutil.AssertNoError(t, err, "Error upon NewHistoryQueryExecutor")
+	since := &timestamp.Timestamp{Seconds: later.Seconds - 1800}
+	itr, err := qhistory.GetHistoryForKeySince("ns1", "key7", since, false, 0, "")
+	testutil.AssertNoError(t, err, "Error upon GetHistoryForKeySince()")
+	testutil.AssertEquals(t, retrieveHistoryValues(t, itr), []string{"value1"})
+
+	// the pages are capped at the total query limit
+	defer viper.Set("ledger.state.totalQueryLimit", viper.Get("ledger.state.totalQueryLimit"))
+	viper.Set("ledger.state.totalQueryLimit", 1)
+	itr, err = qhistory.GetHistoryForKeyWithOptions("ns1", "key7", 0, 0, false, 2, "")
+	testutil.AssertNoError(t, err, "Error upon GetHistoryForKeyWithOptions()")
+	testutil.AssertEquals(t, retrieveHistoryValues(t, itr), []string{"value1"})
+	testutil.AssertNotEquals(t, itr.GetBookmarkAndClose(), "")
+}
+
+// setTxTimestamp moves the timestamp of the first transaction of the block by the given number of seconds,
+// and returns the new timestamp
+func setTxTimestamp(t *testing.T, block *common.Block, seconds int64) *timestamp.Timestamp {
+	env, err := putils.GetEnvelopeFromBlock(block.Data.Data[0])
+	testutil.AssertNoError(t, err, "")
+	payload, err := putils.GetPayload(env)
+	testutil.AssertNoError(t, err, "")
+	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	testutil.AssertNoError(t, err, "")
+	chdr.Timestamp.Seconds += seconds
+	payload.Header.ChannelHeader = putils.MarshalOrPanic(chdr)
+	env.Payload = putils.MarshalOrPanic(payload)
+	block.Data.Data[0] = putils.MarshalOrPanic(env)
+	return chdr.Timestamp
+}
+
+func TestPrivateDataHashHistory(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	testutil.AssertNoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	testutil.AssertNoError(t, store1.AddBlock(gb), "")
+	testutil.AssertNoError(t, env.testHistoryDB.Commit(gb), "")
+
+	//block1 writes the private data key, and block2 deletes it
+	simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+	simulator.SetState("ns1", "key1", []byte("value1"))
+	simulator.SetPrivateData("ns1", "coll1", "key1", []byte("pvtValue1"))
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+	pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+	block1 := bg.NextBlock([][]byte{pubSimResBytes})
+	testutil.AssertNoError(t, store1.AddBlock(block1), "")
+	testutil.AssertNoError(t, env.testHistoryDB.Commit(block1), "")
+
+	simulator, _ = env.txmgr.NewTxSimulator(util2.GenerateUUID())
+	simulator.DeletePrivateData("ns1", "coll1", "key1")
+	simulator.Done()
+	simRes, _ = simulator.GetTxSimulationResults()
+	pubSimResBytes, _ = simRes.GetPubSimulationBytes()
+	block2 := bg.NextBlock([][]byte{pubSimResBytes})
+	testutil.AssertNoError(t, store1.AddBlock(block2), "")
+	testutil.AssertNoError(t, env.testHistoryDB.Commit(block2), "")
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	testutil.AssertNoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	itr, err := qhistory.GetPrivateDataHashHistoryForKey("ns1", "coll1", util.ComputeStringHash("key1"))
+	testutil.AssertNoError(t, err, "Error upon GetPrivateDataHashHistoryForKey()")
+	defer itr.Close()
+	kmod, err := itr.Next()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, kmod.(*queryresult.KeyModification).Value, util.ComputeHash([]byte("pvtValue1")))
+	testutil.AssertEquals(t, kmod.(*queryresult.KeyModification).IsDelete, false)
+	kmod, err = itr.Next()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, kmod.(*queryresult.KeyModification).IsDelete, true)
+	kmod, err = itr.Next()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNil(t, kmod)
+
+	// the history of the public key isn't mixed with the history of the hashed key
+	pubItr, err := qhistory.GetHistoryForKey("ns1", "key1")
+	testutil.AssertNoError(t, err, "Error upon GetHistoryForKey()")
+	defer pubItr.Close()
+	kmod, _ = pubItr.Next()
+	testutil.AssertEquals(t, kmod.(*queryresult.KeyModification).Value, []byte("value1"))
+	kmod, _ = pubItr.Next()
+	testutil.AssertNil(t, kmod)
+
+	// no history is recorded for the hash of a key of another collection
+	itr2, err := qhistory.GetPrivateDataHashHistoryForKey("ns1", "coll2", util.ComputeStringHash("key1"))
+	testutil.AssertNoError(t, err, "Error upon GetPrivateDataHashHistoryForKey()")
+	defer itr2.Close()
+	kmod, _ = itr2.Next()
+	testutil.AssertNil(t, kmod)
+
+	// the key hash is hex-encoded in the history records, so that a binary hash
+	// containing the separator of the composite key is not mistaken for another one
+	hashedDataNs := historydb.DeriveHashedDataNamespace("ns1", "coll1")
+	hexKeyHash := hex.EncodeToString(util.ComputeStringHash("key1"))
+	dbItr := env.testHistoryDB.(*historyDB).db.GetIterator(
+		historydb.ConstructPartialCompositeHistoryKey(hashedDataNs, hexKeyHash, false),
+		historydb.ConstructPartialCompositeHistoryKey(hashedDataNs, hexKeyHash, true))
+	defer dbItr.Release()
+	testutil.AssertEquals(t, dbItr.Next(), true)
+}
+
+func retrieveHistoryValues(t *testing.T, itr commonledger.ResultsIterator) []string {
+	var values []string
+	for {
+		kmod, err := itr.Next()
+		testutil.AssertNoError(t, err, "")
+		if kmod == nil {
+			return values
+		}
+		values = append(values, string(kmod.(*queryresult.KeyModification).Value))
+	}
+}
+
 //TestSavepoint tests that save points get written after each block and get returned via GetBlockNumfromSavepoint
 func TestHistoryDisabled(t *testing.T) {
 	env := newTestHistoryEnv(t)
@@ -29,25 +29,39 @@ var logger = flogging.MustGetLogger("kvledger")
 // KVLedger provides an implementation of `ledger.PeerLedger`.
 // This implementation provides a key-value based data model
 type kvLedger struct {
-	ledgerID   string
-	blockStore *ledgerstorage.Store
-	txtmgmt    txmgr.TxMgr
-	historyDB  historydb.HistoryDB
+	ledgerID    string
+	blockStore  *ledgerstorage.Store
+	versionedDB privacyenabledstate.DB
+	txtmgmt     txmgr.TxMgr
+	historyDB   historydb.HistoryDB
+	// stateHashStore is nil if the state hash is not maintained
+	stateHashStore *stateHashStore
 }
 
 // NewKVLedger constructs new `KVLedger`
 func newKVLedger(ledgerID string, blockStore *ledgerstorage.Store,
-	versionedDB privacyenabledstate.DB, historyDB historydb.HistoryDB) (*kvLedger, error) {
+	versionedDB privacyenabledstate.DB, historyDB historydb.HistoryDB, stateHashStore *stateHashStore) (*kvLedger, error) {
 
 	logger.Debugf("Creating KVLedger ledgerID=%s: ", ledgerID)
 
+	// The state hash is a diagnostic aid, hence the ledger is still usable if it cannot be maintained
+	if stateHashStore != nil {
+		hashingDB, err := newStateHashingDB(versionedDB, stateHashStore)
+		if err != nil {
+			logger.Warningf("Channel [%s]: The state hash is not maintained: %s", ledgerID, err)
+			stateHashStore = nil
+		} else {
+			versionedDB = hashingDB
+		}
+	}
+
 	//Initialize transaction manager using state database
 	var txmgmt txmgr.TxMgr
 	txmgmt = lockbasedtxmgr.NewLockBasedTxMgr(versionedDB)
 
 	// Create a kvLedger for this chain/ledger, which encasulates the underlying
 	// id store, blockstore, txmgr (state database), history database
-	l := &kvLedger{ledgerID, blockStore, txmgmt, historyDB}
+	l := &kvLedger{ledgerID, blockStore, versionedDB, txmgmt, historyDB, stateHashStore}
 
 	//Recover both state DB and history DB if they are out of sync with block storage
 	if err := l.recoverDBs(); err != nil {
@@ -206,8 +220,30 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 		return err
 	}
 
+	if err = l.addCommitHash(block); err != nil {
+		return err
+	}
+
+	// The history database is committed in parallel with the block storage, and the state database in parallel with
+	// the history database once the block storage is committed. The state database must never be ahead of the block
+	// storage, otherwise a block received again after a crash would be validated against a state which already contains
+	// its updates. The history database may be one block ahead of the block storage instead, as recommitting a block to
+	// the history database writes the same entries again
+	historyDBErr := make(chan error, 1)
+	if ledgerconfig.IsHistoryDBEnabled() {
+		go func() {
+			logger.Debugf("Channel [%s]: Committing block [%d] transactions to history database", l.ledgerID, blockNo)
+			historyDBErr <- l.historyDB.Commit(block)
+		}()
+	} else {
+		historyDBErr <- nil
+	}
+
 	logger.Debugf("Channel [%s]: Committing block [%d] to storage", l.ledgerID, blockNo)
 	if err = l.blockStore.CommitWithPvtData(pvtdataAndBlock); err != nil {
+		if historyErr := <-historyDBErr; historyErr != nil {
+			panic(fmt.Errorf(`Error during commit to history db:%s`, historyErr))
+		}
 		return err
 	}
 	logger.Infof("Channel [%s]: Created block [%d] with %d transaction(s)", l.ledgerID, block.Header.Number, len(block.Data.Data))
@@ -217,12 +253,8 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 		panic(fmt.Errorf(`Error during commit to txmgr:%s`, err))
 	}
 
-	// History database could be written in parallel with state and/or async as a future optimization
-	if ledgerconfig.IsHistoryDBEnabled() {
-		logger.Debugf("Channel [%s]: Committing block [%d] transactions to history database", l.ledgerID, blockNo)
-		if err := l.historyDB.Commit(block); err != nil {
-			panic(fmt.Errorf(`Error during commit to history db:%s`, err))
-		}
+	if err = <-historyDBErr; err != nil {
+		panic(fmt.Errorf(`Error during commit to history db:%s`, err))
 	}
 	return nil
 }
@@ -251,6 +283,14 @@ func (l *kvLedger) PrivateDataMinBlockNum() (uint64, error) {
 	return 0, fmt.Errorf("not yet implemented")
 }
 
+// GetStateHash implements method in interface ledger.PeerLedger
+func (l *kvLedger) GetStateHash(blockNum uint64) (*ledger.StateHash, error) {
+	if l.stateHashStore == nil {
+		return nil, fmt.Errorf("The state hash of ledger [%s] is not maintained", l.ledgerID)
+	}
+	return l.stateHashStore.getStateHash(blockNum)
+}
+
 // Close closes `KVLedger`
 func (l *kvLedger) Close() {
 	l.blockStore.Shutdown()
@@ -32,8 +32,9 @@ var (
 	// ErrLedgerNotOpened is thrown by a CloseLedger call if a ledger with the given id has not been opened
 	ErrLedgerNotOpened = errors.New("Ledger is not opened yet")
 
-	underConstructionLedgerKey = []byte("underConstructionLedgerKey")
-	ledgerKeyPrefix            = []byte("l")
+	underConstructionLedgerKey   = []byte("underConstructionLedgerKey")
+	underConstructionSnapshotKey = []byte("underConstructionSnapshotKey")
+	ledgerKeyPrefix              = []byte("l")
 )
 
 // Provider implements interface ledger.PeerLedgerProvider
@@ -42,6 +43,7 @@ type Provider struct {
 	ledgerStoreProvider *ledgerstorage.Provider
 	vdbProvider         privacyenabledstate.DBProvider
 	historydbProvider   historydb.HistoryDBProvider
+	stateHashProvider   *leveldbhelper.Provider
 }
 
 // NewProvider instantiates a new Provider.
@@ -65,8 +67,14 @@ func NewProvider() (ledger.PeerLedgerProvider, error) {
 	var historydbProvider historydb.HistoryDBProvider
 	historydbProvider = historyleveldb.NewHistoryDBProvider()
 
+	// Initialize the state hash store, if the state hash is maintained
+	var stateHashProvider *leveldbhelper.Provider
+	if ledgerconfig.IsStateHashEnabled() {
+		stateHashProvider = leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: ledgerconfig.GetStateHashLevelDBPath()})
+	}
+
 	logger.Info("ledger provider Initialized")
-	provider := &Provider{idStore, ledgerStoreProvider, vdbProvider, historydbProvider}
+	provider := &Provider{idStore, ledgerStoreProvider, vdbProvider, historydbProvider, stateHashProvider}
 	provider.recoverUnderConstructionLedger()
 	return provider, nil
 }
@@ -108,6 +116,44 @@ func (provider *Provider) Create(genesisBlock *common.Block) (ledger.PeerLedger,
 	return lgr, nil
 }
 
+// CreateFromSnapshot implements the corresponding method from interface ledger.PeerLedgerProvider
+// Similar to the function 'Create', this sets a under construction flag, which additionally records that the
+// ledger is created from a snapshot. The block store is bootstrapped last, hence if a crash happens in between,
+// the 'recoverUnderConstructionLedger' function can tell from the height of the blockchain whether the import completed
+func (provider *Provider) CreateFromSnapshot(snapshotDir string) (ledger.PeerLedger, error) {
+	s, err := openSnapshot(snapshotDir)
+	if err != nil {
+		return nil, err
+	}
+	ledgerID := s.manifest.ChannelID
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrLedgerIDExists
+	}
+	if err = provider.idStore.setUnderConstructionFromSnapshotFlag(ledgerID); err != nil {
+		return nil, err
+	}
+	lgr, err := provider.openInternal(ledgerID)
+	if err != nil {
+		logger.Errorf("Error in opening a new empty ledger. Unsetting under construction flag. Err: %s", err)
+		panicOnErr(provider.runCleanup(ledgerID), "Error while running cleanup for ledger id [%s]", ledgerID)
+		panicOnErr(provider.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
+		return nil, err
+	}
+	if err := lgr.(*kvLedger).importSnapshot(s); err != nil {
+		logger.Errorf("Error in importing the snapshot into ledger [%s]. Cleaning up the imported data. Err: %s", ledgerID, err)
+		lgr.Close()
+		panicOnErr(provider.runCleanup(ledgerID), "Error while running cleanup for ledger id [%s]", ledgerID)
+		panicOnErr(provider.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
+		return nil, err
+	}
+	panicOnErr(provider.idStore.createLedgerID(ledgerID, s.configBlock), "Error while marking ledger as created")
+	return lgr, nil
+}
+
 // Open implements the corresponding method from interface ledger.PeerLedgerProvider
 func (provider *Provider) Open(ledgerID string) (ledger.PeerLedger, error) {
 	logger.Debugf("Open() opening kvledger: %s", ledgerID)
@@ -141,9 +187,14 @@ func (provider *Provider) openInternal(ledgerID string) (ledger.PeerLedger, erro
 		return nil, err
 	}
 
+	var hashStore *stateHashStore
+	if provider.stateHashProvider != nil {
+		hashStore = &stateHashStore{db: provider.stateHashProvider.GetDBHandle(ledgerID), retention: ledgerconfig.GetStateHashRetention()}
+	}
+
 	// Create a kvLedger for this chain/ledger, which encasulates the underlying data stores
-	// (id store, blockstore, state database, history database)
-	l, err := newKVLedger(ledgerID, blockStore, vDB, historyDB)
+	// (id store, blockstore, state database, history database, state hash store)
+	l, err := newKVLedger(ledgerID, blockStore, vDB, historyDB, hashStore)
 	if err != nil {
 		return nil, err
 	}
@@ -166,12 +217,16 @@ func (provider *Provider) Close() {
 	provider.ledgerStoreProvider.Close()
 	provider.vdbProvider.Close()
 	provider.historydbProvider.Close()
+	if provider.stateHashProvider != nil {
+		provider.stateHashProvider.Close()
+	}
 }
 
 // recoverUnderConstructionLedger checks whether the under construction flag is set - this would be the case
 // if a crash had happened during creation of ledger and the ledger creation could have been left in intermediate
-// state. Recovery checks if the ledger was created and the genesis block was committed successfully then it completes
-// the last step of adding the ledger id to the list of created ledgers. Else, it clears the under construction flag
+// state. Recovery checks if the ledger was created and the genesis block (or the last block of the snapshot the
+// ledger is created from) was committed successfully then it completes the last step of adding the ledger id to
+// the list of created ledgers. Else, it clears the under construction flag
 func (provider *Provider) recoverUnderConstructionLedger() {
 	logger.Debugf("Recovering under construction ledger")
 	ledgerID, err := provider.idStore.getUnderConstructionFlag()
@@ -181,18 +236,29 @@ func (provider *Provider) recoverUnderConstructionLedger() {
 		return
 	}
 	logger.Infof("ledger [%s] found as under construction", ledgerID)
+	fromSnapshot, err := provider.idStore.isUnderConstructionFromSnapshot()
+	panicOnErr(err, "Error while checking whether the ledger [%s] is created from a snapshot", ledgerID)
 	ledger, err := provider.openInternal(ledgerID)
 	panicOnErr(err, "Error while opening under construction ledger [%s]", ledgerID)
 	bcInfo, err := ledger.GetBlockchainInfo()
 	panicOnErr(err, "Error while getting blockchain info for the under construction ledger [%s]", ledgerID)
 	ledger.Close()
 
-	switch bcInfo.Height {
-	case 0:
+	switch {
+	case bcInfo.Height == 0:
 		logger.Infof("Genesis block was not committed. Hence, the peer ledger not created. unsetting the under construction flag")
 		panicOnErr(provider.runCleanup(ledgerID), "Error while running cleanup for ledger id [%s]", ledgerID)
 		panicOnErr(provider.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
-	case 1:
+	case fromSnapshot:
+		logger.Infof("Snapshot was imported. Hence, marking the peer ledger as created")
+		lastBlock, err := ledger.GetBlockByNumber(bcInfo.Height - 1)
+		panicOnErr(err, "Error while retrieving last block from blockchain for ledger [%s]", ledgerID)
+		configBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+		panicOnErr(err, "Error while retrieving last config index from last block of ledger [%s]", ledgerID)
+		configBlock, err := ledger.GetBlockByNumber(configBlockNum)
+		panicOnErr(err, "Error while retrieving config block from blockchain for ledger [%s]", ledgerID)
+		panicOnErr(provider.idStore.createLedgerID(ledgerID, configBlock), "Error while adding ledgerID [%s] to created list", ledgerID)
+	case bcInfo.Height == 1:
 		logger.Infof("Genesis block was committed. Hence, marking the peer ledger as created")
 		genesisBlock, err := ledger.GetBlockByNumber(0)
 		panicOnErr(err, "Error while retrieving genesis block from blockchain for ledger [%s]", ledgerID)
@@ -205,15 +271,22 @@ func (provider *Provider) recoverUnderConstructionLedger() {
 	return
 }
 
-// runCleanup cleans up blockstorage, statedb, and historydb for what
-// may have got created during in-complete ledger creation
+// runCleanup cleans up the statedb and the historydb for what may have got created during in-complete
+// ledger creation, such as the data of a partially imported snapshot.
+// The blockstorage is not cleaned up, as it is written last and hence remains empty
 func (provider *Provider) runCleanup(ledgerID string) error {
-	// TODO - though, not having this is harmless for kv ledger.
-	// If we want, following could be done:
-	// - blockstorage could remove empty folders
-	// - couchdb backed statedb could delete the database if got created
-	// - leveldb backed statedb and history db need not perform anything as it uses a single db shared across ledgers
-	return nil
+	vDB, err := provider.vdbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	if err := vDB.Drop(); err != nil {
+		return err
+	}
+	historyDB, err := provider.historydbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	return historyDB.Drop()
 }
 
 func panicOnErr(err error, mgsFormat string, args ...interface{}) {
@@ -241,8 +314,19 @@ func (s *idStore) setUnderConstructionFlag(ledgerID string) error {
 	return s.db.Put(underConstructionLedgerKey, []byte(ledgerID), true)
 }
 
+// setUnderConstructionFromSnapshotFlag sets the under construction flag, and records that the ledger is created from a snapshot
+func (s *idStore) setUnderConstructionFromSnapshotFlag(ledgerID string) error {
+	batch := &leveldb.Batch{}
+	batch.Put(underConstructionLedgerKey, []byte(ledgerID))
+	batch.Put(underConstructionSnapshotKey, []byte(ledgerID))
+	return s.db.WriteBatch(batch, true)
+}
+
 func (s *idStore) unsetUnderConstructionFlag() error {
-	return s.db.Delete(underConstructionLedgerKey, true)
+	batch := &leveldb.Batch{}
+	batch.Delete(underConstructionLedgerKey)
+	batch.Delete(underConstructionSnapshotKey)
+	return s.db.WriteBatch(batch, true)
 }
 
 func (s *idStore) getUnderConstructionFlag() (string, error) {
@@ -253,6 +337,14 @@ func (s *idStore) getUnderConstructionFlag() (string, error) {
 	return string(val), nil
 }
 
+func (s *idStore) isUnderConstructionFromSnapshot() (bool, error) {
+	val, err := s.db.Get(underConstructionSnapshotKey)
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
 func (s *idStore) createLedgerID(ledgerID string, gb *common.Block) error {
 	key := s.encodeLedgerKey(ledgerID)
 	var val []byte
@@ -269,6 +361,7 @@ func (s *idStore) createLedgerID(ledgerID string, gb *common.Block) error {
 	batch := &leveldb.Batch{}
 	batch.Put(key, val)
 	batch.Delete(underConstructionLedgerKey)
+	batch.Delete(underConstructionSnapshotKey)
 	return s.db.WriteBatch(batch, true)
 }
 
@@ -287,7 +380,8 @@ func (s *idStore) getAllLedgerIds() ([]string, error) {
 	itr := s.db.GetIterator(nil, nil)
 	itr.First()
 	for itr.Valid() {
-		if bytes.Equal(itr.Key(), underConstructionLedgerKey) {
+		if bytes.Equal(itr.Key(), underConstructionLedgerKey) || bytes.Equal(itr.Key(), underConstructionSnapshotKey) {
+			itr.Next()
 			continue
 		}
 		id := string(s.decodeLedgerID(itr.Key()))
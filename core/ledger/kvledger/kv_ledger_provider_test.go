@@ -232,7 +232,7 @@ func TestLedgerBackup(t *testing.T) {
 	block2Hash := block2.Header.Hash()
 	bcInfo, _ := ledger.GetBlockchainInfo()
 	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
-		Height: 3, CurrentBlockHash: block2Hash, PreviousBlockHash: block1Hash})
+		Height: 3, CurrentBlockHash: block2Hash, PreviousBlockHash: block1Hash, CommitHash: putils.GetCommitHashFromBlock(block2)})
 
 	b0, _ := ledger.GetBlockByHash(gbHash)
 	testutil.AssertEquals(t, b0, gb)
@@ -58,7 +58,7 @@ func TestKVLedgerBlockStorage(t *testing.T) {
 
 	bcInfo, _ := ledger.GetBlockchainInfo()
 	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
-		Height: 1, CurrentBlockHash: gbHash, PreviousBlockHash: nil})
+		Height: 1, CurrentBlockHash: gbHash, PreviousBlockHash: nil, CommitHash: putils.GetCommitHashFromBlock(gb)})
 	txid := util.GenerateUUID()
 	simulator, _ := ledger.NewTxSimulator(txid)
 	simulator.SetState("ns1", "key1", []byte("value1"))
@@ -73,7 +73,7 @@ func TestKVLedgerBlockStorage(t *testing.T) {
 	bcInfo, _ = ledger.GetBlockchainInfo()
 	block1Hash := block1.Header.Hash()
 	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
-		Height: 2, CurrentBlockHash: block1Hash, PreviousBlockHash: gbHash})
+		Height: 2, CurrentBlockHash: block1Hash, PreviousBlockHash: gbHash, CommitHash: putils.GetCommitHashFromBlock(block1)})
 
 	txid = util.GenerateUUID()
 	simulator, _ = ledger.NewTxSimulator(txid)
@@ -89,7 +89,7 @@ func TestKVLedgerBlockStorage(t *testing.T) {
 	bcInfo, _ = ledger.GetBlockchainInfo()
 	block2Hash := block2.Header.Hash()
 	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
-		Height: 3, CurrentBlockHash: block2Hash, PreviousBlockHash: block1Hash})
+		Height: 3, CurrentBlockHash: block2Hash, PreviousBlockHash: block1Hash, CommitHash: putils.GetCommitHashFromBlock(block2)})
 
 	b0, _ := ledger.GetBlockByHash(gbHash)
 	testutil.AssertEquals(t, b0, gb)
@@ -141,7 +141,7 @@ func TestKVLedgerBlockStorageWithPvtdata(t *testing.T) {
 
 	bcInfo, _ := ledger.GetBlockchainInfo()
 	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
-		Height: 1, CurrentBlockHash: gbHash, PreviousBlockHash: nil})
+		Height: 1, CurrentBlockHash: gbHash, PreviousBlockHash: nil, CommitHash: putils.GetCommitHashFromBlock(gb)})
 	txid := util.GenerateUUID()
 	simulator, _ := ledger.NewTxSimulator(txid)
 	simulator.SetState("ns1", "key1", []byte("value1"))
@@ -156,7 +156,7 @@ func TestKVLedgerBlockStorageWithPvtdata(t *testing.T) {
 	bcInfo, _ = ledger.GetBlockchainInfo()
 	block1Hash := block1.Header.Hash()
 	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
-		Height: 2, CurrentBlockHash: block1Hash, PreviousBlockHash: gbHash})
+		Height: 2, CurrentBlockHash: block1Hash, PreviousBlockHash: gbHash, CommitHash: putils.GetCommitHashFromBlock(block1)})
 
 	txid = util.GenerateUUID()
 	simulator, _ = ledger.NewTxSimulator(txid)
@@ -172,7 +172,7 @@ func TestKVLedgerBlockStorageWithPvtdata(t *testing.T) {
 	bcInfo, _ = ledger.GetBlockchainInfo()
 	block2Hash := block2.Header.Hash()
 	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
-		Height: 3, CurrentBlockHash: block2Hash, PreviousBlockHash: block1Hash})
+		Height: 3, CurrentBlockHash: block2Hash, PreviousBlockHash: block1Hash, CommitHash: putils.GetCommitHashFromBlock(block2)})
 
 	pvtdataAndBlock, _ := ledger.GetPvtDataAndBlockByNum(0, nil)
 	testutil.AssertEquals(t, pvtdataAndBlock.Block, gb)
@@ -201,7 +201,7 @@ func TestKVLedgerDBRecovery(t *testing.T) {
 	gbHash := gb.Header.Hash()
 	checkBCSummaryForTest(t, ledger,
 		&bcSummary{
-			bcInfo: &common.BlockchainInfo{Height: 1, CurrentBlockHash: gbHash, PreviousBlockHash: nil},
+			bcInfo: &common.BlockchainInfo{Height: 1, CurrentBlockHash: gbHash, PreviousBlockHash: nil, CommitHash: putils.GetCommitHashFromBlock(gb)},
 		},
 	)
 
@@ -214,7 +214,8 @@ func TestKVLedgerDBRecovery(t *testing.T) {
 		&bcSummary{
 			bcInfo: &common.BlockchainInfo{Height: 2,
 				CurrentBlockHash:  blockAndPvtdata1.Block.Header.Hash(),
-				PreviousBlockHash: gbHash},
+				PreviousBlockHash: gbHash,
+				CommitHash:        putils.GetCommitHashFromBlock(blockAndPvtdata1.Block)},
 		},
 	)
 
@@ -234,7 +235,8 @@ func TestKVLedgerDBRecovery(t *testing.T) {
 		&bcSummary{
 			bcInfo: &common.BlockchainInfo{Height: 3,
 				CurrentBlockHash:  blockAndPvtdata2.Block.Header.Hash(),
-				PreviousBlockHash: blockAndPvtdata1.Block.Header.Hash()},
+				PreviousBlockHash: blockAndPvtdata1.Block.Header.Hash(),
+				CommitHash:        putils.GetCommitHashFromBlock(blockAndPvtdata2.Block)},
 
 			stateDBSavePoint: uint64(1),
 			stateDBKVs:       map[string]string{"key1": "value1.1", "key2": "value2.1", "key3": "value3.1"},
@@ -283,7 +285,8 @@ func TestKVLedgerDBRecovery(t *testing.T) {
 		&bcSummary{
 			bcInfo: &common.BlockchainInfo{Height: 4,
 				CurrentBlockHash:  blockAndPvtdata3.Block.Header.Hash(),
-				PreviousBlockHash: blockAndPvtdata2.Block.Header.Hash()},
+				PreviousBlockHash: blockAndPvtdata2.Block.Header.Hash(),
+				CommitHash:        putils.GetCommitHashFromBlock(blockAndPvtdata3.Block)},
 
 			stateDBSavePoint: uint64(3),
 			stateDBKVs:       map[string]string{"key1": "value1.3", "key2": "value2.3", "key3": "value3.3"},
@@ -332,7 +335,8 @@ func TestKVLedgerDBRecovery(t *testing.T) {
 		&bcSummary{
 			bcInfo: &common.BlockchainInfo{Height: 5,
 				CurrentBlockHash:  blockAndPvtdata4.Block.Header.Hash(),
-				PreviousBlockHash: blockAndPvtdata3.Block.Header.Hash()},
+				PreviousBlockHash: blockAndPvtdata3.Block.Header.Hash(),
+				CommitHash:        putils.GetCommitHashFromBlock(blockAndPvtdata4.Block)},
 
 			stateDBSavePoint: uint64(3),
 			stateDBKVs:       map[string]string{"key1": "value1.3", "key2": "value2.3", "key3": "value3.3"},
@@ -361,6 +365,60 @@ func TestKVLedgerDBRecovery(t *testing.T) {
 			historyVals:        []string{"value1.1", "value1.2", "value1.3", "value1.4"},
 		},
 	)
+
+	//======================================================================================
+	// SCENARIO 4: peer fails after committing the fifth block to the history DB, which is
+	// committed in parallel with the block storage, but before committing to the block storage
+	//======================================================================================
+	blockAndPvtdata5 := prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk5",
+		map[string]string{"key1": "value1.5", "key2": "value2.5", "key3": "value3.5"},
+		map[string]string{"key1": "pvtValue1.5", "key2": "pvtValue2.5", "key3": "pvtValue3.5"},
+	)
+	assert.NoError(t, ledger.(*kvLedger).txtmgmt.ValidateAndPrepare(blockAndPvtdata5, true))
+	assert.NoError(t, ledger.(*kvLedger).historyDB.Commit(blockAndPvtdata5.Block))
+	ledger.Close()
+	provider.Close()
+
+	// we assume here that the peer comes online and calls NewKVLedger to get a handler for the ledger
+	// nothing should be recovered, the history DB being ahead of the block storage, and
+	// the history of the fifth block should not be returned until the block storage contains it
+	provider, _ = NewProvider()
+	ledger, _ = provider.Open(testLedgerid)
+	checkBCSummaryForTest(t, ledger,
+		&bcSummary{
+			bcInfo: &common.BlockchainInfo{Height: 5,
+				CurrentBlockHash:  blockAndPvtdata4.Block.Header.Hash(),
+				PreviousBlockHash: blockAndPvtdata3.Block.Header.Hash(),
+				CommitHash:        putils.GetCommitHashFromBlock(blockAndPvtdata4.Block)},
+
+			stateDBSavePoint: uint64(4),
+			stateDBKVs:       map[string]string{"key1": "value1.4", "key2": "value2.4", "key3": "value3.4"},
+			stateDBPvtKVs:    map[string]string{"key1": "pvtValue1.4", "key2": "pvtValue2.4", "key3": "pvtValue3.4"},
+
+			historyDBSavePoint: uint64(5),
+			historyKey:         "key1",
+			historyVals:        []string{"value1.1", "value1.2", "value1.3", "value1.4"},
+		},
+	)
+
+	// the fifth block is received again, its history is written again without duplicates
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata5))
+	checkBCSummaryForTest(t, ledger,
+		&bcSummary{
+			bcInfo: &common.BlockchainInfo{Height: 6,
+				CurrentBlockHash:  blockAndPvtdata5.Block.Header.Hash(),
+				PreviousBlockHash: blockAndPvtdata4.Block.Header.Hash(),
+				CommitHash:        putils.GetCommitHashFromBlock(blockAndPvtdata5.Block)},
+
+			stateDBSavePoint: uint64(5),
+			stateDBKVs:       map[string]string{"key1": "value1.5", "key2": "value2.5", "key3": "value3.5"},
+			stateDBPvtKVs:    map[string]string{"key1": "pvtValue1.5", "key2": "pvtValue2.5", "key3": "pvtValue3.5"},
+
+			historyDBSavePoint: uint64(5),
+			historyKey:         "key1",
+			historyVals:        []string{"value1.1", "value1.2", "value1.3", "value1.4", "value1.5"},
+		},
+	)
 }
 
 func TestLedgerWithCouchDbEnabledWithBinaryAndJSONData(t *testing.T) {
@@ -382,7 +440,7 @@ func TestLedgerWithCouchDbEnabledWithBinaryAndJSONData(t *testing.T) {
 
 	bcInfo, _ := ledger.GetBlockchainInfo()
 	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
-		Height: 1, CurrentBlockHash: gbHash, PreviousBlockHash: nil})
+		Height: 1, CurrentBlockHash: gbHash, PreviousBlockHash: nil, CommitHash: putils.GetCommitHashFromBlock(gb)})
 
 	txid := util.GenerateUUID()
 	simulator, _ := ledger.NewTxSimulator(txid)
@@ -400,7 +458,7 @@ func TestLedgerWithCouchDbEnabledWithBinaryAndJSONData(t *testing.T) {
 	bcInfo, _ = ledger.GetBlockchainInfo()
 	block1Hash := block1.Header.Hash()
 	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
-		Height: 2, CurrentBlockHash: block1Hash, PreviousBlockHash: gbHash})
+		Height: 2, CurrentBlockHash: block1Hash, PreviousBlockHash: gbHash, CommitHash: putils.GetCommitHashFromBlock(block1)})
 
 	simulationResults := [][]byte{}
 	txid = util.GenerateUUID()
@@ -431,7 +489,7 @@ func TestLedgerWithCouchDbEnabledWithBinaryAndJSONData(t *testing.T) {
 	bcInfo, _ = ledger.GetBlockchainInfo()
 	block2Hash := block2.Header.Hash()
 	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
-		Height: 3, CurrentBlockHash: block2Hash, PreviousBlockHash: block1Hash})
+		Height: 3, CurrentBlockHash: block2Hash, PreviousBlockHash: block1Hash, CommitHash: putils.GetCommitHashFromBlock(block2)})
 
 	b0, _ := ledger.GetBlockByHash(gbHash)
 	testutil.AssertEquals(t, b0, gb)
@@ -0,0 +1,602 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// snapshotImportBatchSize is the number of state entries applied to the state db at once during an import
+const snapshotImportBatchSize = 10000
+
+// maxSnapshotKVLength bounds the length of a state entry read from a snapshot file,
+// so that a corrupted length prefix doesn't cause an arbitrarily large allocation
+const maxSnapshotKVLength = 128 * 1024 * 1024
+
+// ExportSnapshot implements method in interface ledger.PeerLedger
+// The commits to the ledger are blocked while the snapshot is being exported
+func (l *kvLedger) ExportSnapshot(snapshotDir string) error {
+	empty, err := util.CreateDirIfMissing(snapshotDir)
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return fmt.Errorf("Snapshot directory [%s] is not empty", snapshotDir)
+	}
+
+	// Holding a query executor prevents the state db from being updated, the block store
+	// may however be one block ahead, hence the last block is determined by the state db savepoint
+	qe, err := l.txtmgmt.NewQueryExecutor("")
+	if err != nil {
+		return err
+	}
+	defer qe.Done()
+
+	savepoint, err := l.versionedDB.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if savepoint == nil {
+		return fmt.Errorf("Cannot export a snapshot of an empty ledger")
+	}
+	lastBlock, err := l.blockStore.RetrieveBlockByNumber(savepoint.BlockNum)
+	if err != nil {
+		return err
+	}
+	configBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return err
+	}
+	configBlock, err := l.blockStore.RetrieveBlockByNumber(configBlockNum)
+	if err != nil {
+		return err
+	}
+	logger.Infof("Channel [%s]: Exporting snapshot at block [%d] to [%s]", l.ledgerID, savepoint.BlockNum, snapshotDir)
+
+	manifest := &ledger.SnapshotManifest{
+		ChannelID:         l.ledgerID,
+		LastBlockNumber:   lastBlock.Header.Number,
+		LastBlockHash:     hex.EncodeToString(lastBlock.Header.Hash()),
+		ConfigBlockNumber: configBlock.Header.Number,
+		Files:             make(map[string]string),
+	}
+	if err := l.exportState(snapshotDir, manifest.Files); err != nil {
+		return err
+	}
+	for fileName, block := range map[string]*common.Block{
+		ledger.SnapshotLastBlockFile:   lastBlock,
+		ledger.SnapshotConfigBlockFile: configBlock,
+	} {
+		if err := exportBlock(snapshotDir, fileName, block, manifest.Files); err != nil {
+			return err
+		}
+	}
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(snapshotDir, ledger.SnapshotManifestFile), manifestBytes, 0644)
+}
+
+// exportState writes the public state and the hashes of the private state to their snapshot files
+func (l *kvLedger) exportState(snapshotDir string, fileHashes map[string]string) error {
+	pubWriter, err := newSnapshotFileWriter(snapshotDir, ledger.SnapshotPublicStateFile)
+	if err != nil {
+		return err
+	}
+	defer pubWriter.close()
+	hashesWriter, err := newSnapshotFileWriter(snapshotDir, ledger.SnapshotPvtStateHashesFile)
+	if err != nil {
+		return err
+	}
+	defer hashesWriter.close()
+
+	itr, err := l.versionedDB.GetSnapshotIterator()
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if res == nil {
+			break
+		}
+		kv := res.(*privacyenabledstate.SnapshotKV)
+		writer := pubWriter
+		if kv.CollectionName != "" {
+			writer = hashesWriter
+		}
+		if err := writer.writeKV(kv); err != nil {
+			return err
+		}
+	}
+
+	for _, writer := range []*snapshotFileWriter{pubWriter, hashesWriter} {
+		if fileHashes[writer.fileName], err = writer.done(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportBlock(snapshotDir, fileName string, block *common.Block, fileHashes map[string]string) error {
+	blockBytes, err := proto.Marshal(block)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(snapshotDir, fileName), blockBytes, 0644); err != nil {
+		return err
+	}
+	fileHash := sha256.Sum256(blockBytes)
+	fileHashes[fileName] = hex.EncodeToString(fileHash[:])
+	return nil
+}
+
+// snapshot holds the content of a snapshot directory,
+// except for the state entries which are read lazily
+type snapshot struct {
+	dir         string
+	manifest    *ledger.SnapshotManifest
+	lastBlock   *common.Block
+	configBlock *common.Block
+}
+
+// openSnapshot reads the manifest of a snapshot, and verifies
+// that the files of the snapshot are consistent with it
+func openSnapshot(snapshotDir string) (*snapshot, error) {
+	manifest, _, err := ReadSnapshotManifest(snapshotDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, fileName := range []string{
+		ledger.SnapshotPublicStateFile,
+		ledger.SnapshotPvtStateHashesFile,
+		ledger.SnapshotLastBlockFile,
+		ledger.SnapshotConfigBlockFile,
+	} {
+		if err := verifySnapshotFile(snapshotDir, fileName, manifest.Files[fileName]); err != nil {
+			return nil, err
+		}
+	}
+
+	s := &snapshot{dir: snapshotDir, manifest: manifest}
+	if s.lastBlock, err = readSnapshotBlock(snapshotDir, ledger.SnapshotLastBlockFile); err != nil {
+		return nil, err
+	}
+	if s.configBlock, err = readSnapshotBlock(snapshotDir, ledger.SnapshotConfigBlockFile); err != nil {
+		return nil, err
+	}
+
+	if s.lastBlock.Header.Number != manifest.LastBlockNumber ||
+		hex.EncodeToString(s.lastBlock.Header.Hash()) != manifest.LastBlockHash {
+		return nil, fmt.Errorf("Last block of the snapshot doesn't match block [%d] with hash [%s] of the manifest",
+			manifest.LastBlockNumber, manifest.LastBlockHash)
+	}
+	configBlockNum, err := utils.GetLastConfigIndexFromBlock(s.lastBlock)
+	if err != nil {
+		return nil, err
+	}
+	if s.configBlock.Header.Number != configBlockNum || configBlockNum != manifest.ConfigBlockNumber {
+		return nil, fmt.Errorf("Config block [%d] of the snapshot is not the last config block [%d] of its last block",
+			s.configBlock.Header.Number, configBlockNum)
+	}
+	chainID, err := utils.GetChainIDFromBlock(s.configBlock)
+	if err != nil {
+		return nil, err
+	}
+	if chainID != manifest.ChannelID {
+		return nil, fmt.Errorf("Config block of the snapshot is for channel [%s] instead of [%s]", chainID, manifest.ChannelID)
+	}
+	return s, nil
+}
+
+// ReadSnapshotManifest reads the manifest of the snapshot in the given directory.
+// It returns the manifest along with its raw bytes, as signed by the exporter of the snapshot
+func ReadSnapshotManifest(snapshotDir string) (*ledger.SnapshotManifest, []byte, error) {
+	manifestBytes, err := ioutil.ReadFile(filepath.Join(snapshotDir, ledger.SnapshotManifestFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	manifest := &ledger.SnapshotManifest{}
+	if err := json.Unmarshal(manifestBytes, manifest); err != nil {
+		return nil, nil, fmt.Errorf("Error while unmarshaling snapshot manifest: %s", err)
+	}
+	return manifest, manifestBytes, nil
+}
+
+func verifySnapshotFile(snapshotDir, fileName, expectedHash string) error {
+	if expectedHash == "" {
+		return fmt.Errorf("File [%s] is missing from the snapshot manifest", fileName)
+	}
+	f, err := os.Open(filepath.Join(snapshotDir, fileName))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if hex.EncodeToString(h.Sum(nil)) != expectedHash {
+		return fmt.Errorf("Hash of file [%s] doesn't match the snapshot manifest", fileName)
+	}
+	return nil
+}
+
+func readSnapshotBlock(snapshotDir, fileName string) (*common.Block, error) {
+	blockBytes, err := ioutil.ReadFile(filepath.Join(snapshotDir, fileName))
+	if err != nil {
+		return nil, err
+	}
+	block := &common.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		return nil, err
+	}
+	if block.Header == nil {
+		return nil, fmt.Errorf("Block in file [%s] has no header", fileName)
+	}
+	return block, nil
+}
+
+// CompareSnapshots compares the state of the snapshots in the given directories, which are expected to be snapshots
+// of the ledger of the same channel at the same block. It calls the given function for every key whose value or version
+// differs between the snapshots, in the order of the keys, with the entries of the key in the first and in the second
+// snapshot. The entry of a snapshot is nil if the key does not exist in it. It returns the number of differing keys.
+// The values are compared in canonical form, like the state hash, so that the snapshots of peers with different
+// state dbs can be compared
+func CompareSnapshots(snapshotDir1, snapshotDir2 string, onDiff func(kv1, kv2 *privacyenabledstate.SnapshotKV) error) (int, error) {
+	s1, err := openSnapshot(snapshotDir1)
+	if err != nil {
+		return 0, err
+	}
+	s2, err := openSnapshot(snapshotDir2)
+	if err != nil {
+		return 0, err
+	}
+	if s1.manifest.ChannelID != s2.manifest.ChannelID {
+		return 0, fmt.Errorf("Snapshots are of channels [%s] and [%s]", s1.manifest.ChannelID, s2.manifest.ChannelID)
+	}
+	if s1.manifest.LastBlockNumber != s2.manifest.LastBlockNumber {
+		return 0, fmt.Errorf("Snapshots are at blocks [%d] and [%d]", s1.manifest.LastBlockNumber, s2.manifest.LastBlockNumber)
+	}
+	diffs := 0
+	for _, fileName := range []string{ledger.SnapshotPublicStateFile, ledger.SnapshotPvtStateHashesFile} {
+		if s1.manifest.Files[fileName] == s2.manifest.Files[fileName] {
+			continue
+		}
+		fileDiffs, err := compareSnapshotFiles(snapshotDir1, snapshotDir2, fileName, onDiff)
+		if err != nil {
+			return 0, err
+		}
+		diffs += fileDiffs
+	}
+	return diffs, nil
+}
+
+// compareSnapshotFiles merges the ordered state entries of the given file of both snapshots
+func compareSnapshotFiles(snapshotDir1, snapshotDir2, fileName string, onDiff func(kv1, kv2 *privacyenabledstate.SnapshotKV) error) (int, error) {
+	var readers [2]*orderedSnapshotFileReader
+	for i, snapshotDir := range []string{snapshotDir1, snapshotDir2} {
+		reader, err := newSnapshotFileReader(snapshotDir, fileName)
+		if err != nil {
+			return 0, err
+		}
+		defer reader.close()
+		readers[i] = &orderedSnapshotFileReader{snapshotFileReader: reader, snapshotDir: snapshotDir}
+	}
+	kv1, err := readers[0].readKV()
+	if err != nil {
+		return 0, err
+	}
+	kv2, err := readers[1].readKV()
+	if err != nil {
+		return 0, err
+	}
+	diffs := 0
+	for kv1 != nil || kv2 != nil {
+		c := compareSnapshotKeys(kv1, kv2)
+		var diffKV1, diffKV2 *privacyenabledstate.SnapshotKV
+		switch {
+		case c < 0:
+			diffKV1 = kv1
+		case c > 0:
+			diffKV2 = kv2
+		case !bytes.Equal(canonicalValue(kv1.Value), canonicalValue(kv2.Value)) || !version.AreSame(kv1.Version, kv2.Version):
+			diffKV1, diffKV2 = kv1, kv2
+		}
+		if diffKV1 != nil || diffKV2 != nil {
+			if err := onDiff(diffKV1, diffKV2); err != nil {
+				return 0, err
+			}
+			diffs++
+		}
+		if c <= 0 {
+			if kv1, err = readers[0].readKV(); err != nil {
+				return 0, err
+			}
+		}
+		if c >= 0 {
+			if kv2, err = readers[1].readKV(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return diffs, nil
+}
+
+// importSnapshot populates the state db, the history db and the block store of an empty ledger.
+// The block store is bootstrapped last, as its height tells whether the import completed
+func (l *kvLedger) importSnapshot(s *snapshot) error {
+	logger.Infof("Channel [%s]: Importing snapshot at block [%d] from [%s]", l.ledgerID, s.lastBlock.Header.Number, s.dir)
+	savepoint := snapshotSavepoint(s.lastBlock)
+	for _, fileName := range []string{ledger.SnapshotPublicStateFile, ledger.SnapshotPvtStateHashesFile} {
+		if err := l.importState(s.dir, fileName, savepoint); err != nil {
+			return err
+		}
+	}
+	if ledgerconfig.IsHistoryDBEnabled() {
+		if err := l.historyDB.BootstrapFromSnapshot(s.lastBlock); err != nil {
+			return err
+		}
+	}
+	return l.blockStore.BootstrapFromSnapshot(s.lastBlock, s.configBlock)
+}
+
+// snapshotSavepoint returns the savepoint of the state imported from a snapshot
+// taken at the given last block, the same as the one of a commit of that block
+func snapshotSavepoint(lastBlock *common.Block) *version.Height {
+	var lastTxNum uint64
+	if numTxs := len(lastBlock.Data.Data); numTxs > 0 {
+		lastTxNum = uint64(numTxs - 1)
+	}
+	return version.NewHeight(lastBlock.Header.Number, lastTxNum)
+}
+
+func (l *kvLedger) importState(snapshotDir, fileName string, savepoint *version.Height) error {
+	reader, err := newSnapshotFileReader(snapshotDir, fileName)
+	if err != nil {
+		return err
+	}
+	defer reader.close()
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	batchSize := 0
+	for {
+		kv, err := reader.readKV()
+		if err != nil {
+			return err
+		}
+		if kv == nil {
+			break
+		}
+		if kv.CollectionName == "" {
+			batch.PubUpdates.Put(kv.Namespace, kv.Key, kv.Value, kv.Version)
+		} else {
+			batch.HashUpdates.Put(kv.Namespace, kv.CollectionName, []byte(kv.Key), kv.Value, kv.Version)
+		}
+		batchSize++
+		if batchSize == snapshotImportBatchSize {
+			if err := l.versionedDB.ApplyPrivacyAwareUpdates(batch, savepoint); err != nil {
+				return err
+			}
+			batch = privacyenabledstate.NewUpdateBatch()
+			batchSize = 0
+		}
+	}
+	return l.versionedDB.ApplyPrivacyAwareUpdates(batch, savepoint)
+}
+
+// snapshotFileWriter writes length prefixed state entries to
+// a snapshot file, and computes the hash of its content
+type snapshotFileWriter struct {
+	fileName string
+	file     *os.File
+	buffer   *bufio.Writer
+	hash     hash.Hash
+}
+
+func newSnapshotFileWriter(snapshotDir, fileName string) (*snapshotFileWriter, error) {
+	file, err := os.OpenFile(filepath.Join(snapshotDir, fileName), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	return &snapshotFileWriter{
+		fileName: fileName,
+		file:     file,
+		buffer:   bufio.NewWriter(io.MultiWriter(file, h)),
+		hash:     h,
+	}, nil
+}
+
+func (w *snapshotFileWriter) writeKV(kv *privacyenabledstate.SnapshotKV) error {
+	kvBytes, err := encodeSnapshotKV(kv)
+	if err != nil {
+		return err
+	}
+	lenBytes := proto.EncodeVarint(uint64(len(kvBytes)))
+	if _, err := w.buffer.Write(lenBytes); err != nil {
+		return err
+	}
+	_, err = w.buffer.Write(kvBytes)
+	return err
+}
+
+// done flushes the file and returns the hex encoded hash of its content
+func (w *snapshotFileWriter) done() (string, error) {
+	if err := w.buffer.Flush(); err != nil {
+		return "", err
+	}
+	if err := w.file.Sync(); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(w.hash.Sum(nil)), nil
+}
+
+func (w *snapshotFileWriter) close() {
+	w.file.Close()
+}
+
+// snapshotFileReader reads the state entries written by a snapshotFileWriter
+type snapshotFileReader struct {
+	file   *os.File
+	buffer *bufio.Reader
+}
+
+func newSnapshotFileReader(snapshotDir, fileName string) (*snapshotFileReader, error) {
+	file, err := os.Open(filepath.Join(snapshotDir, fileName))
+	if err != nil {
+		return nil, err
+	}
+	return &snapshotFileReader{file, bufio.NewReader(file)}, nil
+}
+
+// readKV returns the next state entry, or nil if the end of the file is reached
+func (r *snapshotFileReader) readKV() (*privacyenabledstate.SnapshotKV, error) {
+	kvLen, err := binary.ReadUvarint(r.buffer)
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if kvLen > maxSnapshotKVLength {
+		return nil, fmt.Errorf("Length [%d] of a snapshot state entry exceeds the maximum length [%d]", kvLen, maxSnapshotKVLength)
+	}
+	kvBytes := make([]byte, kvLen)
+	if _, err := io.ReadFull(r.buffer, kvBytes); err != nil {
+		return nil, err
+	}
+	return decodeSnapshotKV(kvBytes)
+}
+
+func (r *snapshotFileReader) close() {
+	r.file.Close()
+}
+
+// orderedSnapshotFileReader reads the state entries of a snapshot
+// file, and verifies that they are ordered by namespace and key
+type orderedSnapshotFileReader struct {
+	*snapshotFileReader
+	snapshotDir string
+	lastKV      *privacyenabledstate.SnapshotKV
+}
+
+func (r *orderedSnapshotFileReader) readKV() (*privacyenabledstate.SnapshotKV, error) {
+	kv, err := r.snapshotFileReader.readKV()
+	if err != nil || kv == nil {
+		return nil, err
+	}
+	if r.lastKV != nil && compareSnapshotKeys(r.lastKV, kv) >= 0 {
+		return nil, fmt.Errorf("State entries of snapshot [%s] are not ordered by namespace and key", r.snapshotDir)
+	}
+	r.lastKV = kv
+	return kv, nil
+}
+
+// compareSnapshotKeys compares the keys of the given state entries in the order
+// of a snapshot, a nil entry standing for the end of the snapshot
+func compareSnapshotKeys(kv1, kv2 *privacyenabledstate.SnapshotKV) int {
+	switch {
+	case kv1 == nil:
+		return 1
+	case kv2 == nil:
+		return -1
+	case kv1.Namespace != kv2.Namespace:
+		return strings.Compare(kv1.Namespace, kv2.Namespace)
+	case kv1.CollectionName != kv2.CollectionName:
+		return strings.Compare(kv1.CollectionName, kv2.CollectionName)
+	}
+	return strings.Compare(kv1.Key, kv2.Key)
+}
+
+func encodeSnapshotKV(kv *privacyenabledstate.SnapshotKV) ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeStringBytes(kv.Namespace); err != nil {
+		return nil, err
+	}
+	if err := buffer.EncodeStringBytes(kv.CollectionName); err != nil {
+		return nil, err
+	}
+	if err := buffer.EncodeStringBytes(kv.Key); err != nil {
+		return nil, err
+	}
+	if err := buffer.EncodeRawBytes(kv.Value); err != nil {
+		return nil, err
+	}
+	if err := buffer.EncodeRawBytes(kv.Version.ToBytes()); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func decodeSnapshotKV(b []byte) (*privacyenabledstate.SnapshotKV, error) {
+	buffer := proto.NewBuffer(b)
+	kv := &privacyenabledstate.SnapshotKV{}
+	var err error
+	if kv.Namespace, err = buffer.DecodeStringBytes(); err != nil {
+		return nil, err
+	}
+	if kv.CollectionName, err = buffer.DecodeStringBytes(); err != nil {
+		return nil, err
+	}
+	if kv.Key, err = buffer.DecodeStringBytes(); err != nil {
+		return nil, err
+	}
+	if kv.Value, err = buffer.DecodeRawBytes(true); err != nil {
+		return nil, err
+	}
+	versionBytes, err := buffer.DecodeRawBytes(false)
+	if err != nil {
+		return nil, err
+	}
+	if kv.Version, err = decodeSnapshotVersion(versionBytes); err != nil {
+		return nil, err
+	}
+	return kv, nil
+}
+
+// decodeSnapshotVersion decodes the version of a state entry. The version is made of two order
+// preserving varints, which are checked first as version.NewHeightFromBytes panics on malformed bytes
+func decodeSnapshotVersion(b []byte) (*version.Height, error) {
+	n := 0
+	for i := 0; i < 2; i++ {
+		if n >= len(b) || b[n] > 8 || n+1+int(b[n]) > len(b) {
+			return nil, fmt.Errorf("Malformed version [%x] in snapshot state entry", b)
+		}
+		n += 1 + int(b[n])
+	}
+	h, consumed := version.NewHeightFromBytes(b)
+	if consumed != len(b) {
+		return nil, fmt.Errorf("Malformed version [%x] in snapshot state entry", b)
+	}
+	return h, nil
+}
@@ -0,0 +1,439 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// exportTestSnapshot creates a ledger with two blocks on top of the genesis block,
+// and exports a snapshot of it. It returns the snapshot directory, the genesis block,
+// the last block, and the block generator, for generating blocks after the snapshot
+func exportTestSnapshot(t *testing.T, ledgerID string) (string, *common.Block, *common.Block, *testutil.BlockGenerator) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+
+	blockAndPvtdata1 := prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk1",
+		map[string]string{"key1": "value1.1", "key2": "value2.1"},
+		map[string]string{"key1": "pvtValue1.1"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata1))
+	blockAndPvtdata2 := prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk2",
+		map[string]string{"key1": "value1.2"}, map[string]string{"key2": "pvtValue2.2"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata2))
+
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	assert.NoError(t, ledger.ExportSnapshot(snapshotDir))
+
+	// Exporting to the same directory again fails, as it is not empty anymore
+	assert.Error(t, ledger.ExportSnapshot(snapshotDir))
+	return snapshotDir, gb, blockAndPvtdata2.Block, bg
+}
+
+func TestSnapshotExportImport(t *testing.T) {
+	snapshotDir, gb, lastBlock, bg := exportTestSnapshot(t, "testLedger")
+	defer os.RemoveAll(snapshotDir)
+
+	manifest, _, err := ReadSnapshotManifest(snapshotDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "testLedger", manifest.ChannelID)
+	assert.Equal(t, uint64(2), manifest.LastBlockNumber)
+	assert.Equal(t, hex.EncodeToString(lastBlock.Header.Hash()), manifest.LastBlockHash)
+	assert.Equal(t, uint64(0), manifest.ConfigBlockNumber)
+	assert.Len(t, manifest.Files, 4)
+
+	// Import the snapshot in a new environment
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	ledger, err := provider.CreateFromSnapshot(snapshotDir)
+	assert.NoError(t, err)
+
+	checkBCSummaryForTest(t, ledger,
+		&bcSummary{
+			bcInfo: &common.BlockchainInfo{Height: 3,
+				CurrentBlockHash:  lastBlock.Header.Hash(),
+				PreviousBlockHash: lastBlock.Header.PreviousHash,
+				CommitHash:        putils.GetCommitHashFromBlock(lastBlock)},
+			stateDBSavePoint:   uint64(2),
+			stateDBKVs:         map[string]string{"key1": "value1.2", "key2": "value2.1"},
+			historyDBSavePoint: uint64(2),
+		},
+	)
+
+	// The private data is not part of the snapshot, only its hashes are
+	vv, err := ledger.(*kvLedger).versionedDB.GetValueHash("ns", "coll", util.ComputeStringHash("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeStringHash("pvtValue1.1"), vv.Value)
+	assert.Equal(t, version.NewHeight(1, 0), vv.Version)
+	pvtVal, err := ledger.(*kvLedger).versionedDB.GetPrivateData("ns", "coll", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, pvtVal)
+
+	// Only the last block and the config block are available
+	b, err := ledger.GetBlockByNumber(2)
+	assert.NoError(t, err)
+	assert.Equal(t, lastBlock, b)
+	b, err = ledger.GetBlockByNumber(0)
+	assert.NoError(t, err)
+	assert.Equal(t, gb, b)
+	_, err = ledger.GetBlockByNumber(1)
+	assert.Error(t, err)
+	_, err = ledger.GetBlocksIterator(0)
+	assert.Error(t, err)
+
+	// Blocks are committed on top of the snapshot
+	blockAndPvtdata3 := prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk3",
+		map[string]string{"key2": "value2.3"}, map[string]string{"key3": "pvtValue3.3"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata3))
+	checkBCSummaryForTest(t, ledger,
+		&bcSummary{
+			bcInfo: &common.BlockchainInfo{Height: 4,
+				CurrentBlockHash:  blockAndPvtdata3.Block.Header.Hash(),
+				PreviousBlockHash: lastBlock.Header.Hash(),
+				CommitHash:        putils.GetCommitHashFromBlock(blockAndPvtdata3.Block)},
+			stateDBKVs: map[string]string{"key1": "value1.2", "key2": "value2.3"},
+		},
+	)
+
+	// The history starts after the snapshot, and the queries of the history of the snapshot fail
+	qhistory, err := ledger.NewHistoryQueryExecutor()
+	assert.NoError(t, err)
+	_, err = qhistory.GetHistoryForKey("ns", "key2")
+	assert.EqualError(t, err, "the history prior to block [3] is not available, the ledger was bootstrapped from a snapshot at block [2]")
+	_, err = qhistory.GetHistoryForKeyWithOptions("ns", "key2", 2, 0, false, 0, "")
+	assert.Error(t, err)
+	_, err = qhistory.GetHistoryForKeySince("ns", "key2", &timestamp.Timestamp{}, false, 0, "")
+	assert.Error(t, err)
+	itr, err := qhistory.GetHistoryForKeyWithOptions("ns", "key2", 3, 0, false, 0, "")
+	assert.NoError(t, err)
+	kmod, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value2.3"), kmod.(*queryresult.KeyModification).Value)
+	kmod, err = itr.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, kmod)
+	itr.Close()
+
+	// The ledger is listed, and can be reopened
+	ledger.Close()
+	ids, err := provider.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"testLedger"}, ids)
+	_, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.Equal(t, ErrLedgerIDExists, err)
+	provider.Close()
+
+	provider, _ = NewProvider()
+	defer provider.Close()
+	ledger, err = provider.Open("testLedger")
+	assert.NoError(t, err)
+	defer ledger.Close()
+	checkBCSummaryForTest(t, ledger,
+		&bcSummary{
+			bcInfo: &common.BlockchainInfo{Height: 4,
+				CurrentBlockHash:  blockAndPvtdata3.Block.Header.Hash(),
+				PreviousBlockHash: lastBlock.Header.Hash(),
+				CommitHash:        putils.GetCommitHashFromBlock(blockAndPvtdata3.Block)},
+			stateDBKVs: map[string]string{"key1": "value1.2", "key2": "value2.3"},
+		},
+	)
+}
+
+func TestSnapshotImportTampered(t *testing.T) {
+	snapshotDir, _, _, _ := exportTestSnapshot(t, "testLedger")
+	defer os.RemoveAll(snapshotDir)
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	defer provider.Close()
+
+	stateFile := filepath.Join(snapshotDir, lgr.SnapshotPublicStateFile)
+	stateBytes, err := ioutil.ReadFile(stateFile)
+	assert.NoError(t, err)
+	stateBytes[len(stateBytes)-1]++
+	assert.NoError(t, ioutil.WriteFile(stateFile, stateBytes, 0644))
+
+	_, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.EqualError(t, err, "Hash of file [public_state.data] doesn't match the snapshot manifest")
+	exists, err := provider.Exists("testLedger")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestSnapshotImportRecovery(t *testing.T) {
+	snapshotDir, gb, _, _ := exportTestSnapshot(t, "testLedger")
+	defer os.RemoveAll(snapshotDir)
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	s, err := openSnapshot(snapshotDir)
+	assert.NoError(t, err)
+
+	// Assume a crash happens after the snapshot is imported, but before the ledger is marked as created
+	assert.NoError(t, provider.(*Provider).idStore.setUnderConstructionFromSnapshotFlag("testLedger"))
+	ledger, err := provider.(*Provider).openInternal("testLedger")
+	assert.NoError(t, err)
+	assert.NoError(t, ledger.(*kvLedger).importSnapshot(s))
+	ledger.Close()
+	provider.Close()
+
+	// construct a new provider to invoke recovery
+	provider, err = NewProvider()
+	assert.NoError(t, err)
+	defer provider.Close()
+	flag, err := provider.(*Provider).idStore.getUnderConstructionFlag()
+	assert.NoError(t, err)
+	assert.Equal(t, "", flag)
+	fromSnapshot, err := provider.(*Provider).idStore.isUnderConstructionFromSnapshot()
+	assert.NoError(t, err)
+	assert.False(t, fromSnapshot)
+	ledger, err = provider.Open("testLedger")
+	assert.NoError(t, err)
+	defer ledger.Close()
+	b, err := ledger.GetBlockByNumber(0)
+	assert.NoError(t, err)
+	assert.Equal(t, gb, b)
+}
+
+func TestSnapshotImportFailureCleanup(t *testing.T) {
+	snapshotDir, _, _, _ := exportTestSnapshot(t, "testLedger")
+	defer os.RemoveAll(snapshotDir)
+
+	// Append a state entry with an oversized length prefix to the hashes of the private state,
+	// and update the manifest accordingly, so that the import fails after the public state is imported
+	hashesFile := filepath.Join(snapshotDir, lgr.SnapshotPvtStateHashesFile)
+	hashesBytes, err := ioutil.ReadFile(hashesFile)
+	assert.NoError(t, err)
+	hashesBytes = append(hashesBytes, proto.EncodeVarint(maxSnapshotKVLength+1)...)
+	assert.NoError(t, ioutil.WriteFile(hashesFile, hashesBytes, 0644))
+	manifest, _, err := ReadSnapshotManifest(snapshotDir)
+	assert.NoError(t, err)
+	hashesHash := sha256.Sum256(hashesBytes)
+	manifest.Files[lgr.SnapshotPvtStateHashesFile] = hex.EncodeToString(hashesHash[:])
+	manifestBytes, err := json.Marshal(manifest)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, lgr.SnapshotManifestFile), manifestBytes, 0644))
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	defer provider.Close()
+
+	_, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.EqualError(t, err, fmt.Sprintf("Length [%d] of a snapshot state entry exceeds the maximum length [%d]",
+		maxSnapshotKVLength+1, maxSnapshotKVLength))
+	exists, err := provider.Exists("testLedger")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	flag, err := provider.(*Provider).idStore.getUnderConstructionFlag()
+	assert.NoError(t, err)
+	assert.Equal(t, "", flag)
+	checkEmptyStateAndHistoryForTest(t, provider.(*Provider), "testLedger")
+}
+
+func TestSnapshotImportCrashRecovery(t *testing.T) {
+	snapshotDir, gb, _, _ := exportTestSnapshot(t, "testLedger")
+	defer os.RemoveAll(snapshotDir)
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+
+	// Assume a crash happens after the public state is imported, but before the block store is bootstrapped
+	assert.NoError(t, provider.(*Provider).idStore.setUnderConstructionFromSnapshotFlag("testLedger"))
+	ledger, err := provider.(*Provider).openInternal("testLedger")
+	assert.NoError(t, err)
+	assert.NoError(t, ledger.(*kvLedger).importState(snapshotDir, lgr.SnapshotPublicStateFile, version.NewHeight(2, 0)))
+	ledger.Close()
+	provider.Close()
+
+	// construct a new provider to invoke recovery, which cleans up the imported state
+	provider, err = NewProvider()
+	assert.NoError(t, err)
+	defer provider.Close()
+	flag, err := provider.(*Provider).idStore.getUnderConstructionFlag()
+	assert.NoError(t, err)
+	assert.Equal(t, "", flag)
+	checkEmptyStateAndHistoryForTest(t, provider.(*Provider), "testLedger")
+
+	// The channel can be joined from its genesis block afterwards
+	ledger, err = provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+	bcInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), bcInfo.Height)
+	qe, err := ledger.NewQueryExecutor()
+	assert.NoError(t, err)
+	defer qe.Done()
+	val, err := qe.GetState("ns", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, val)
+}
+
+func checkEmptyStateAndHistoryForTest(t *testing.T, provider *Provider, ledgerID string) {
+	vDB, err := provider.vdbProvider.GetDBHandle(ledgerID)
+	assert.NoError(t, err)
+	savepoint, err := vDB.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Nil(t, savepoint)
+	vv, err := vDB.GetState("ns", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+	itr, err := vDB.GetSnapshotIterator()
+	assert.NoError(t, err)
+	defer itr.Close()
+	res, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	historyDB, err := provider.historydbProvider.GetDBHandle(ledgerID)
+	assert.NoError(t, err)
+	savepoint, err = historyDB.GetLastSavepoint()
+	assert.NoError(t, err)
+	assert.Nil(t, savepoint)
+}
+
+func TestSnapshotKVEncoding(t *testing.T) {
+	for _, kv := range []*privacyenabledstate.SnapshotKV{
+		{Namespace: "ns", Key: "key", Value: []byte("value"), Version: version.NewHeight(1, 2)},
+		{Namespace: "ns", CollectionName: "coll", Key: "keyHash", Value: []byte{}, Version: version.NewHeight(3, 4)},
+	} {
+		kvBytes, err := encodeSnapshotKV(kv)
+		assert.NoError(t, err)
+		decodedKV, err := decodeSnapshotKV(kvBytes)
+		assert.NoError(t, err)
+		assert.Equal(t, kv, decodedKV)
+	}
+
+	kvBytes, err := encodeSnapshotKV(&privacyenabledstate.SnapshotKV{Namespace: "ns", Key: "key", Version: version.NewHeight(1, 2)})
+	assert.NoError(t, err)
+	for _, malformedKVBytes := range [][]byte{
+		kvBytes[:len(kvBytes)-1],
+		append(kvBytes[:len(kvBytes)-5:len(kvBytes)-5], 4, 9, 1, 2, 3),
+		append(kvBytes[:len(kvBytes)-5:len(kvBytes)-5], 4, 1, 1, 2, 5),
+		append(kvBytes[:len(kvBytes)-5:len(kvBytes)-5], 4, 1, 1, 0, 0),
+	} {
+		_, err := decodeSnapshotKV(malformedKVBytes)
+		assert.Error(t, err)
+	}
+}
+
+func TestSnapshotSavepoint(t *testing.T) {
+	block := &common.Block{Header: &common.BlockHeader{Number: 5}, Data: &common.BlockData{Data: [][]byte{{1}, {2}, {3}}}}
+	assert.Equal(t, version.NewHeight(5, 2), snapshotSavepoint(block))
+	// A block without transactions doesn't make the transaction number underflow
+	block.Data.Data = nil
+	assert.Equal(t, version.NewHeight(5, 0), snapshotSavepoint(block))
+}
+
+func TestCompareSnapshots(t *testing.T) {
+	snapshotDir1, _, _, _ := exportTestSnapshot(t, "testLedger")
+	defer os.RemoveAll(snapshotDir1)
+	snapshotDir2, _, _, _ := exportTestSnapshot(t, "testLedger")
+	defer os.RemoveAll(snapshotDir2)
+	snapshotDir3, _, _, _ := exportTestSnapshot(t, "otherLedger")
+	defer os.RemoveAll(snapshotDir3)
+
+	// Export a snapshot of a ledger whose public state diverges at block 2
+	env := newTestEnv(t)
+	provider, _ := NewProvider()
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	assert.NoError(t, ledger.CommitWithPvtData(prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk1",
+		map[string]string{"key1": "value1.1", "key2": "value2.1"}, map[string]string{"key1": "pvtValue1.1"})))
+	assert.NoError(t, ledger.CommitWithPvtData(prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk2",
+		map[string]string{"key1": "value1.9", "key3": "value3.2"}, map[string]string{"key2": "pvtValue2.2"})))
+	snapshotDir4, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir4)
+	assert.NoError(t, ledger.ExportSnapshot(snapshotDir4))
+	ledger.Close()
+	provider.Close()
+	env.cleanup()
+
+	var diffs [][2]*privacyenabledstate.SnapshotKV
+	onDiff := func(kv1, kv2 *privacyenabledstate.SnapshotKV) error {
+		diffs = append(diffs, [2]*privacyenabledstate.SnapshotKV{kv1, kv2})
+		return nil
+	}
+	numDiffs, err := CompareSnapshots(snapshotDir1, snapshotDir2, onDiff)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, numDiffs)
+	assert.Empty(t, diffs)
+
+	numDiffs, err = CompareSnapshots(snapshotDir1, snapshotDir4, onDiff)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, numDiffs)
+	assert.Equal(t, [][2]*privacyenabledstate.SnapshotKV{
+		{
+			{Namespace: "ns", Key: "key1", Value: []byte("value1.2"), Version: version.NewHeight(2, 0)},
+			{Namespace: "ns", Key: "key1", Value: []byte("value1.9"), Version: version.NewHeight(2, 0)},
+		},
+		{
+			nil,
+			{Namespace: "ns", Key: "key3", Value: []byte("value3.2"), Version: version.NewHeight(2, 0)},
+		},
+	}, diffs)
+
+	_, err = CompareSnapshots(snapshotDir1, snapshotDir3, onDiff)
+	assert.EqualError(t, err, "Snapshots are of channels [testLedger] and [otherLedger]")
+}
+
+func TestCompareSnapshotKeys(t *testing.T) {
+	kvs := []*privacyenabledstate.SnapshotKV{
+		{Namespace: "ns1", Key: "key1"},
+		{Namespace: "ns1", Key: "key2"},
+		{Namespace: "ns1", CollectionName: "coll1", Key: "key1"},
+		{Namespace: "ns1", CollectionName: "coll2", Key: "key0"},
+		{Namespace: "ns2", Key: "key0"},
+		nil,
+	}
+	for i := range kvs {
+		for j := range kvs {
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			if kvs[i] != nil || kvs[j] != nil {
+				assert.Equal(t, expected, compareSnapshotKeys(kvs[i], kvs[j]))
+			}
+		}
+	}
+}
@@ -0,0 +1,467 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+)
+
+var (
+	stateHashSavepointKey  = []byte("s")
+	stateHashFirstBlockKey = []byte("f")
+	stateHashNsKeyPrefix   = []byte("n")
+	stateHashKeyPrefix     = []byte("h")
+	stateHashKeySep        = []byte{0x00}
+
+	// stateHashModulus is the modulus of the sums of the hashes of the state entries
+	stateHashModulus = new(big.Int).Lsh(big.NewInt(1), 256)
+)
+
+// stateHashRebuildLogInterval is the number of state entries between two progress logs of the rebuild of the store
+const stateHashRebuildLogInterval = 100000
+
+// stateHashStore maintains, for every namespace of a ledger, a hash of its state, that is, of its public data
+// and of the hashes of its private data. The hash of a namespace is the sum, modulo 2^256, of the SHA256 hashes
+// of its entries, hence a block updates it by subtracting the hashes of the previous entries of the keys it
+// updates and by adding the hashes of their new entries. The hash of a namespace is stored for every block that
+// updates the namespace, so that the state hash of the latest blocks is available. The hashes which are only
+// needed for blocks older than the retention are removed as new blocks are committed
+type stateHashStore struct {
+	db *leveldbhelper.DBHandle
+	// retention is the number of latest blocks whose state hash is kept, 0 keeps the state hash of every block
+	retention uint64
+}
+
+// getSavepoint returns the height of the last block whose updates were hashed, or nil if the store is empty
+func (s *stateHashStore) getSavepoint() (*version.Height, error) {
+	savepointBytes, err := s.db.Get(stateHashSavepointKey)
+	if err != nil || savepointBytes == nil {
+		return nil, err
+	}
+	savepoint, _ := version.NewHeightFromBytes(savepointBytes)
+	return savepoint, nil
+}
+
+// getFirstBlock returns the number of the first block whose state hash is available
+func (s *stateHashStore) getFirstBlock() (uint64, error) {
+	firstBlockBytes, err := s.db.Get(stateHashFirstBlockKey)
+	if err != nil || firstBlockBytes == nil {
+		return 0, err
+	}
+	firstBlock, _ := util.DecodeOrderPreservingVarUint64(firstBlockBytes)
+	return firstBlock, nil
+}
+
+// getNsHash returns the hash of the given namespace after the commit of the given block
+func (s *stateHashStore) getNsHash(ns string, blockNum uint64) (*big.Int, error) {
+	itr := s.db.GetIterator(encodeStateHashKey(ns, nil), encodeStateHashKey(ns, util.EncodeOrderPreservingVarUint64(blockNum+1)))
+	defer itr.Release()
+	if !itr.Last() {
+		return new(big.Int), itr.Error()
+	}
+	return new(big.Int).SetBytes(itr.Value()), nil
+}
+
+// getNamespaces returns the namespaces which have been hashed, in order
+func (s *stateHashStore) getNamespaces() ([]string, error) {
+	itr := s.db.GetIterator(stateHashNsKeyPrefix, []byte{stateHashNsKeyPrefix[0] + 1})
+	defer itr.Release()
+	var namespaces []string
+	for itr.Next() {
+		namespaces = append(namespaces, string(itr.Key()[len(stateHashNsKeyPrefix):]))
+	}
+	return namespaces, itr.Error()
+}
+
+// commit stores the given hashes of namespaces as the hashes after the commit of the block of the given height
+func (s *stateHashStore) commit(nsHashes map[string]*big.Int, height *version.Height) error {
+	savepoint, err := s.getSavepoint()
+	if err != nil {
+		return err
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	for ns, nsHash := range nsHashes {
+		batch.Put(encodeStateHashKey(ns, util.EncodeOrderPreservingVarUint64(height.BlockNum)), encodeNsHash(nsHash))
+		batch.Put(append(append([]byte{}, stateHashNsKeyPrefix...), ns...), []byte{})
+	}
+	batch.Put(stateHashSavepointKey, height.ToBytes())
+	if savepoint == nil {
+		batch.Put(stateHashFirstBlockKey, util.EncodeOrderPreservingVarUint64(height.BlockNum))
+	} else if err := s.prune(batch, height.BlockNum); err != nil {
+		return err
+	}
+	return s.db.WriteBatch(batch, true)
+}
+
+// prune adds to the given batch the removal of the hashes which are no longer needed once the given block is
+// committed, that is, for every namespace, the hashes which precede its last hash before the first retained block
+func (s *stateHashStore) prune(batch *leveldbhelper.UpdateBatch, blockNum uint64) error {
+	if s.retention == 0 || blockNum < s.retention {
+		return nil
+	}
+	firstBlock, err := s.getFirstBlock()
+	if err != nil {
+		return err
+	}
+	newFirstBlock := blockNum - s.retention + 1
+	if newFirstBlock <= firstBlock {
+		return nil
+	}
+	namespaces, err := s.getNamespaces()
+	if err != nil {
+		return err
+	}
+	for _, ns := range namespaces {
+		// the last hash of the namespace up to the new first block is its hash at that block, hence it is kept
+		itr := s.db.GetIterator(encodeStateHashKey(ns, nil), encodeStateHashKey(ns, util.EncodeOrderPreservingVarUint64(newFirstBlock+1)))
+		var previousKey []byte
+		for itr.Next() {
+			if previousKey != nil {
+				batch.Delete(previousKey)
+			}
+			previousKey = append([]byte{}, itr.Key()...)
+		}
+		err := itr.Error()
+		itr.Release()
+		if err != nil {
+			return err
+		}
+	}
+	batch.Put(stateHashFirstBlockKey, util.EncodeOrderPreservingVarUint64(newFirstBlock))
+	return nil
+}
+
+// clear removes all the hashes, along with the savepoint
+func (s *stateHashStore) clear() error {
+	itr := s.db.GetIterator(nil, nil)
+	defer itr.Release()
+	batch := leveldbhelper.NewUpdateBatch()
+	for itr.Next() {
+		batch.Delete(append([]byte{}, itr.Key()...))
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+	return s.db.WriteBatch(batch, true)
+}
+
+// getStateHash returns the hashes of the namespaces after the commit of the given block, omitting the namespaces
+// with no entries, along with the hash of the ledger, which is the SHA256 hash of the hashes of the namespaces
+func (s *stateHashStore) getStateHash(blockNum uint64) (*ledger.StateHash, error) {
+	savepoint, err := s.getSavepoint()
+	if err != nil {
+		return nil, err
+	}
+	if savepoint == nil {
+		return nil, fmt.Errorf("State hash of block [%d] is not available, no block has been hashed yet", blockNum)
+	}
+	firstBlock, err := s.getFirstBlock()
+	if err != nil {
+		return nil, err
+	}
+	if blockNum < firstBlock || blockNum > savepoint.BlockNum {
+		return nil, fmt.Errorf("State hash of block [%d] is not available, the state hashes of blocks [%d] to [%d] are",
+			blockNum, firstBlock, savepoint.BlockNum)
+	}
+	namespaces, err := s.getNamespaces()
+	if err != nil {
+		return nil, err
+	}
+	stateHash := &ledger.StateHash{BlockNumber: blockNum, Namespaces: make(map[string]string)}
+	h := sha256.New()
+	for _, ns := range namespaces {
+		nsHash, err := s.getNsHash(ns, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		if nsHash.Sign() == 0 {
+			continue
+		}
+		nsHashBytes := encodeNsHash(nsHash)
+		stateHash.Namespaces[ns] = hex.EncodeToString(nsHashBytes)
+		h.Write(proto.EncodeVarint(uint64(len(ns))))
+		h.Write([]byte(ns))
+		h.Write(nsHashBytes)
+	}
+	stateHash.Hash = hex.EncodeToString(h.Sum(nil))
+	return stateHash, nil
+}
+
+func encodeStateHashKey(ns string, blockNumBytes []byte) []byte {
+	key := append(append([]byte{}, stateHashKeyPrefix...), ns...)
+	key = append(key, stateHashKeySep...)
+	return append(key, blockNumBytes...)
+}
+
+// encodeNsHash encodes the hash of a namespace on 32 bytes
+func encodeNsHash(nsHash *big.Int) []byte {
+	nsHashBytes := make([]byte, sha256.Size)
+	b := nsHash.Bytes()
+	copy(nsHashBytes[len(nsHashBytes)-len(b):], b)
+	return nsHashBytes
+}
+
+// stateHashingDB wraps the state db of a ledger, and hashes the updates before applying them to the state db.
+// In case of a crash in between, the updates of the block are applied again during the recovery of the
+// state db, but they are not hashed again, since the hashes of the previous entries are no longer available
+type stateHashingDB struct {
+	privacyenabledstate.DB
+	store *stateHashStore
+}
+
+// newStateHashingDB wraps the given state db. If the state hash store is not in sync with the state db, for
+// instance if the state hash was not maintained so far, the hashes are computed from the content of the state db.
+// This scans the whole state db before the ledger is opened, which takes a while for a large state
+func newStateHashingDB(db privacyenabledstate.DB, store *stateHashStore) (*stateHashingDB, error) {
+	stateSavepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	hashSavepoint, err := store.getSavepoint()
+	if err != nil {
+		return nil, err
+	}
+	hashingDB := &stateHashingDB{db, store}
+	switch {
+	case stateSavepoint == nil:
+		// the state db is empty, the state hash starts along with it
+		if hashSavepoint != nil {
+			return hashingDB, store.clear()
+		}
+	case hashSavepoint == nil || hashSavepoint.BlockNum < stateSavepoint.BlockNum || hashSavepoint.BlockNum > stateSavepoint.BlockNum+1:
+		if err := hashingDB.rebuildStore(stateSavepoint); err != nil {
+			return nil, err
+		}
+	}
+	return hashingDB, nil
+}
+
+// rebuildStore computes the hashes of the namespaces from the content of the state db, logging its progress
+func (db *stateHashingDB) rebuildStore(stateSavepoint *version.Height) error {
+	logger.Infof("Computing the state hash from the state db at block [%d], this scans the whole state db", stateSavepoint.BlockNum)
+	if err := db.store.clear(); err != nil {
+		return err
+	}
+	itr, err := db.GetSnapshotIterator()
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	nsHashes := make(map[string]*big.Int)
+	numEntries := 0
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if res == nil {
+			break
+		}
+		numEntries++
+		if numEntries%stateHashRebuildLogInterval == 0 {
+			logger.Infof("Computing the state hash at block [%d]: %d entries hashed so far", stateSavepoint.BlockNum, numEntries)
+		}
+		kv := res.(*privacyenabledstate.SnapshotKV)
+		nsHash, ok := nsHashes[kv.Namespace]
+		if !ok {
+			nsHash = new(big.Int)
+			nsHashes[kv.Namespace] = nsHash
+		}
+		if err := addStateEntryHash(nsHash, kv, false); err != nil {
+			return err
+		}
+	}
+	logger.Infof("Computed the state hash from the %d entries of the state db at block [%d]", numEntries, stateSavepoint.BlockNum)
+	return db.store.commit(nsHashes, stateSavepoint)
+}
+
+// ApplyPrivacyAwareUpdates overrides the function of the wrapped state db, in order to hash the updates first
+func (db *stateHashingDB) ApplyPrivacyAwareUpdates(updates *privacyenabledstate.UpdateBatch, height *version.Height) error {
+	hashed, err := db.isHashed(height)
+	if err != nil {
+		return err
+	}
+	if !hashed {
+		if err := db.hashUpdates(updates, height); err != nil {
+			return err
+		}
+	}
+	return db.DB.ApplyPrivacyAwareUpdates(updates, height)
+}
+
+// isHashed tells whether the updates of the given height were hashed but not applied to the state db
+func (db *stateHashingDB) isHashed(height *version.Height) (bool, error) {
+	hashSavepoint, err := db.store.getSavepoint()
+	if err != nil || hashSavepoint == nil || hashSavepoint.Compare(height) < 0 {
+		return false, err
+	}
+	stateSavepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return false, err
+	}
+	return stateSavepoint == nil || stateSavepoint.Compare(height) < 0, nil
+}
+
+// hashUpdates updates the hashes of the namespaces with the public updates and the hashed updates.
+// The updates to the private data are not hashed, since peers are not expected to hold the same private data.
+// The previous entries of the updated keys are read in bulk, a namespace or a collection at a time
+func (db *stateHashingDB) hashUpdates(updates *privacyenabledstate.UpdateBatch, height *version.Height) error {
+	nsHashes := make(map[string]*big.Int)
+	updateNsHash := func(kv *privacyenabledstate.SnapshotKV, previous *statedb.VersionedValue) error {
+		nsHash, ok := nsHashes[kv.Namespace]
+		if !ok {
+			var err error
+			if nsHash, err = db.store.getNsHash(kv.Namespace, height.BlockNum); err != nil {
+				return err
+			}
+			nsHashes[kv.Namespace] = nsHash
+		}
+		if previous != nil {
+			previousKV := *kv
+			previousKV.Value, previousKV.Version = previous.Value, previous.Version
+			if err := addStateEntryHash(nsHash, &previousKV, true); err != nil {
+				return err
+			}
+		}
+		if kv.Value == nil {
+			return nil
+		}
+		return addStateEntryHash(nsHash, kv, false)
+	}
+
+	for _, ns := range updates.PubUpdates.GetUpdatedNamespaces() {
+		nsUpdates := updates.PubUpdates.GetUpdates(ns)
+		keys := make([]string, 0, len(nsUpdates))
+		for key := range nsUpdates {
+			keys = append(keys, key)
+		}
+		previousValues, err := db.GetStateMultipleKeys(ns, keys)
+		if err != nil {
+			return err
+		}
+		for i, key := range keys {
+			vv := nsUpdates[key]
+			kv := &privacyenabledstate.SnapshotKV{Namespace: ns, Key: key, Value: vv.Value, Version: vv.Version}
+			if err := updateNsHash(kv, previousValues[i]); err != nil {
+				return err
+			}
+		}
+	}
+	for ns, nsBatch := range updates.HashUpdates.UpdateMap {
+		for _, coll := range nsBatch.GetCollectionNames() {
+			collUpdates := nsBatch.GetUpdates(coll)
+			keyHashes := make([][]byte, 0, len(collUpdates))
+			for keyHash := range collUpdates {
+				keyHashes = append(keyHashes, []byte(keyHash))
+			}
+			previousValues, err := db.GetValueHashMultipleKeys(ns, coll, keyHashes)
+			if err != nil {
+				return err
+			}
+			for i, keyHash := range keyHashes {
+				vv := collUpdates[string(keyHash)]
+				kv := &privacyenabledstate.SnapshotKV{Namespace: ns, CollectionName: coll, Key: string(keyHash), Value: vv.Value, Version: vv.Version}
+				if err := updateNsHash(kv, previousValues[i]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return db.store.commit(nsHashes, height)
+}
+
+// addStateEntryHash adds the SHA256 hash of the given state entry, as encoded in a snapshot with its value
+// in canonical form, to the given hash of a namespace, or subtracts it if the entry is removed from the state
+func addStateEntryHash(nsHash *big.Int, kv *privacyenabledstate.SnapshotKV, remove bool) error {
+	canonicalKV := *kv
+	canonicalKV.Value = canonicalValue(kv.Value)
+	kvBytes, err := encodeSnapshotKV(&canonicalKV)
+	if err != nil {
+		return err
+	}
+	kvHash := sha256.Sum256(kvBytes)
+	if remove {
+		nsHash.Sub(nsHash, new(big.Int).SetBytes(kvHash[:]))
+	} else {
+		nsHash.Add(nsHash, new(big.Int).SetBytes(kvHash[:]))
+	}
+	nsHash.Mod(nsHash, stateHashModulus)
+	return nil
+}
+
+// canonicalValue returns the given value re-encoded in canonical form if it is a JSON object, that is, with sorted
+// keys, without insignificant whitespace and with its numbers in canonical form, and the value itself otherwise.
+// CouchDB stores JSON objects as documents, hence the value written by a transaction and the one read back from
+// the state db only match in canonical form
+func canonicalValue(value []byte) []byte {
+	// the value is decoded like couchdb.IsJSON does to tell whether it is stored as a JSON document,
+	// but with its numbers kept as they are written
+	var jsonObject map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	if err := decoder.Decode(&jsonObject); err != nil || jsonObject == nil {
+		return value
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return value
+	}
+	canonicalNumbers(jsonObject)
+	canonical, err := json.Marshal(jsonObject)
+	if err != nil {
+		return value
+	}
+	return canonical
+}
+
+// canonicalNumbers replaces the numbers of the given decoded JSON value by their canonical form, and returns it.
+// CouchDB keeps integers as they are, but decodes the other numbers as float64, and formats them its own way,
+// such as 2.5 for 2.50 and 100.0 for 1e2. Hence integers are kept as they are, so that large integers remain
+// distinct, while the other numbers are rounded to float64 and formatted by Go
+func canonicalNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, elem := range v {
+			v[key] = canonicalNumbers(elem)
+		}
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = canonicalNumbers(elem)
+		}
+	case json.Number:
+		if !strings.ContainsAny(string(v), ".eE") {
+			if v == "-0" {
+				return json.Number("0")
+			}
+			return v
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return v
+		}
+		canonical, err := json.Marshal(f)
+		if err != nil {
+			return v
+		}
+		return json.Number(canonical)
+	}
+	return value
+}
@@ -0,0 +1,289 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateHash(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	enabled := ledgerconfig.IsStateHashEnabled()
+	defer viper.Set("ledger.state.enableStateHash", enabled)
+	viper.Set("ledger.state.enableStateHash", true)
+	provider, _ := NewProvider()
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+
+	blockAndPvtdata1 := prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk1",
+		map[string]string{"key1": "value1.1", "key2": "value2.1"},
+		map[string]string{"key1": "pvtValue1.1"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata1))
+	blockAndPvtdata2 := prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk2",
+		map[string]string{"key1": "value1.2"}, map[string]string{"key2": "pvtValue2.2"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata2))
+
+	stateHash1, err := ledger.GetStateHash(1)
+	assert.NoError(t, err)
+	stateHash2, err := ledger.GetStateHash(2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), stateHash2.BlockNumber)
+	assert.Contains(t, stateHash2.Namespaces, "ns")
+	assert.NotEqual(t, stateHash1.Namespaces["ns"], stateHash2.Namespaces["ns"])
+	assert.NotEqual(t, stateHash1.Hash, stateHash2.Hash)
+	_, err = ledger.GetStateHash(3)
+	assert.EqualError(t, err, "State hash of block [3] is not available, the state hashes of blocks [0] to [2] are")
+
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+	assert.NoError(t, ledger.ExportSnapshot(snapshotDir))
+	ledger.Close()
+	provider.Close()
+
+	// The state hash computed from the state db matches the state hash maintained along with the commits
+	assert.NoError(t, os.RemoveAll(ledgerconfig.GetStateHashLevelDBPath()))
+	provider, _ = NewProvider()
+	ledger, err = provider.Open("testLedger")
+	assert.NoError(t, err)
+	stateHash, err := ledger.GetStateHash(2)
+	assert.NoError(t, err)
+	assert.Equal(t, stateHash2, stateHash)
+	_, err = ledger.GetStateHash(1)
+	assert.EqualError(t, err, "State hash of block [1] is not available, the state hashes of blocks [2] to [2] are")
+	ledger.Close()
+	provider.Close()
+
+	// A ledger created from a snapshot has the state hash of the ledger the snapshot was exported from
+	env = createTestEnv(t, "/tmp/fabric/ledgertests/kvledger/snapshot")
+	defer env.cleanup()
+	provider, _ = NewProvider()
+	defer provider.Close()
+	ledger, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.NoError(t, err)
+	defer ledger.Close()
+	stateHash, err = ledger.GetStateHash(2)
+	assert.NoError(t, err)
+	assert.Equal(t, stateHash2, stateHash)
+}
+
+func TestStateHashDisabled(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	enabled := ledgerconfig.IsStateHashEnabled()
+	defer viper.Set("ledger.state.enableStateHash", enabled)
+	viper.Set("ledger.state.enableStateHash", false)
+	provider, _ := NewProvider()
+	defer provider.Close()
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+
+	_, err = ledger.GetStateHash(0)
+	assert.EqualError(t, err, "The state hash of ledger [testLedger] is not maintained")
+	exists, _, err := util.FileExists(ledgerconfig.GetStateHashLevelDBPath())
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestStateHashingDB(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	vdbProvider, err := privacyenabledstate.NewCommonStorageDBProvider()
+	assert.NoError(t, err)
+	defer vdbProvider.Close()
+	vdb, err := vdbProvider.GetDBHandle("testLedger")
+	assert.NoError(t, err)
+	storeProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: ledgerconfig.GetStateHashLevelDBPath()})
+	defer storeProvider.Close()
+	store := &stateHashStore{db: storeProvider.GetDBHandle("testLedger")}
+
+	db, err := newStateHashingDB(vdb, store)
+	assert.NoError(t, err)
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	batch.PubUpdates.Put("ns2", "key2", []byte("value2"), version.NewHeight(1, 0))
+	batch.HashUpdates.Put("ns1", "coll", []byte("keyHash"), []byte("valueHash"), version.NewHeight(1, 0))
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 0)))
+	stateHash1, err := store.getStateHash(1)
+	assert.NoError(t, err)
+	assert.Len(t, stateHash1.Namespaces, 2)
+
+	// A crash happens once the updates of block 2 are hashed, and before they are applied to the state db.
+	// They are not hashed again when they are applied to the state db during the recovery
+	batch = privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1.2"), version.NewHeight(2, 0))
+	batch.PubUpdates.Delete("ns2", "key2", version.NewHeight(2, 0))
+	batch.HashUpdates.Delete("ns1", "coll", []byte("keyHash"), version.NewHeight(2, 0))
+	assert.NoError(t, db.hashUpdates(batch, version.NewHeight(2, 0)))
+	stateHash2, err := store.getStateHash(2)
+	assert.NoError(t, err)
+	db, err = newStateHashingDB(vdb, store)
+	assert.NoError(t, err)
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(2, 0)))
+	stateHash, err := store.getStateHash(2)
+	assert.NoError(t, err)
+	assert.Equal(t, stateHash2, stateHash)
+	assert.Len(t, stateHash2.Namespaces, 1)
+	assert.NotEqual(t, stateHash1.Namespaces["ns1"], stateHash2.Namespaces["ns1"])
+
+	// The state hash computed from the state db matches the state hash maintained along with the updates
+	rebuiltStore := &stateHashStore{db: storeProvider.GetDBHandle("rebuiltLedger")}
+	_, err = newStateHashingDB(vdb, rebuiltStore)
+	assert.NoError(t, err)
+	stateHash, err = rebuiltStore.getStateHash(2)
+	assert.NoError(t, err)
+	assert.Equal(t, stateHash2, stateHash)
+
+	// Removing the last entry of a namespace removes it from the state hash
+	batch = privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Delete("ns1", "key1", version.NewHeight(3, 0))
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(3, 0)))
+	stateHash3, err := store.getStateHash(3)
+	assert.NoError(t, err)
+	emptyHash := sha256.Sum256(nil)
+	assert.Equal(t, hex.EncodeToString(emptyHash[:]), stateHash3.Hash)
+	assert.Empty(t, stateHash3.Namespaces)
+
+	// The state hashes of the previous blocks are still available
+	stateHash, err = store.getStateHash(1)
+	assert.NoError(t, err)
+	assert.Equal(t, stateHash1, stateHash)
+
+	// If the state db is empty, for instance once it is dropped to be rebuilt, the state hash starts over
+	emptyDB, err := vdbProvider.GetDBHandle("emptyLedger")
+	assert.NoError(t, err)
+	_, err = newStateHashingDB(emptyDB, store)
+	assert.NoError(t, err)
+	_, err = store.getStateHash(1)
+	assert.EqualError(t, err, "State hash of block [1] is not available, no block has been hashed yet")
+}
+
+// TestStateHashOfJSONValues checks that the state hash doesn't depend on the state db, although CouchDB
+// re-encodes the JSON values it stores: a value written in non-canonical form is updated twice, which
+// subtracts the hash of the value read back from the state db
+func TestStateHashOfJSONValues(t *testing.T) {
+	defer viper.Set("ledger.state.stateDatabase", "goleveldb")
+	vdbEnvs := []privacyenabledstate.TestEnv{&privacyenabledstate.LevelDBCommonStorageTestEnv{}, &privacyenabledstate.CouchDBCommonStorageTestEnv{}}
+	stateHashes := make(map[string]*ledger.StateHash)
+	for _, vdbEnv := range vdbEnvs {
+		t.Run(vdbEnv.GetName(), func(t *testing.T) {
+			env := newTestEnv(t)
+			defer env.cleanup()
+			vdbEnv.Init(t)
+			defer vdbEnv.Cleanup()
+			vdb := vdbEnv.GetDBHandle("testledger")
+			storeProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: ledgerconfig.GetStateHashLevelDBPath()})
+			defer storeProvider.Close()
+			store := &stateHashStore{db: storeProvider.GetDBHandle("testledger")}
+
+			db, err := newStateHashingDB(vdb, store)
+			assert.NoError(t, err)
+			for i, value := range []string{`{ "size" : 1, "color":"blue" }`, `{"size": 2.50,  "color" : "red"}`, `{"color":"green","size":3,"id":12345678901234567891}`} {
+				height := version.NewHeight(uint64(i+1), 0)
+				batch := privacyenabledstate.NewUpdateBatch()
+				batch.PubUpdates.Put("ns1", "key1", []byte(value), height)
+				assert.NoError(t, db.ApplyPrivacyAwareUpdates(batch, height))
+			}
+			stateHash, err := store.getStateHash(3)
+			assert.NoError(t, err)
+
+			// The hashes of the previous values cancelled out, since the state hash computed from the state db matches
+			rebuiltStore := &stateHashStore{db: storeProvider.GetDBHandle("rebuiltledger")}
+			_, err = newStateHashingDB(vdb, rebuiltStore)
+			assert.NoError(t, err)
+			rebuiltStateHash, err := rebuiltStore.getStateHash(3)
+			assert.NoError(t, err)
+			assert.Equal(t, stateHash, rebuiltStateHash)
+			stateHashes[vdbEnv.GetName()] = stateHash
+		})
+	}
+	assert.Equal(t, stateHashes[vdbEnvs[0].GetName()], stateHashes[vdbEnvs[1].GetName()])
+}
+
+func TestCanonicalValue(t *testing.T) {
+	for _, test := range []struct {
+		value, canonical string
+	}{
+		{`{ "size" : 1, "color":"blue" }`, `{"color":"blue","size":1}`},
+		// CouchDB formats 2.50 as 2.5, and 1e2 as 100.0
+		{`{"size": 2.50}`, `{"size":2.5}`},
+		{`{"size": 1e2, "sizes": [100.0, 100, -0]}`, `{"size":100,"sizes":[100,100,0]}`},
+		// Integers are not rounded, so that large integers remain distinct
+		{`{"id": 12345678901234567891, "ids": {"id": 12345678901234567892}}`, `{"id":12345678901234567891,"ids":{"id":12345678901234567892}}`},
+		// Values which are not JSON objects are kept as they are
+		{`[1.50]`, `[1.50]`},
+		{`{"size": 1} {}`, `{"size": 1} {}`},
+		{`null`, `null`},
+		{`value1`, `value1`},
+	} {
+		assert.Equal(t, test.canonical, string(canonicalValue([]byte(test.value))), test.value)
+	}
+}
+
+func TestStateHashRetention(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	vdbProvider, err := privacyenabledstate.NewCommonStorageDBProvider()
+	assert.NoError(t, err)
+	defer vdbProvider.Close()
+	vdb, err := vdbProvider.GetDBHandle("testLedger")
+	assert.NoError(t, err)
+	storeProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: ledgerconfig.GetStateHashLevelDBPath()})
+	defer storeProvider.Close()
+	store := &stateHashStore{db: storeProvider.GetDBHandle("testLedger"), retention: 2}
+	db, err := newStateHashingDB(vdb, store)
+	assert.NoError(t, err)
+
+	// ns1 is updated by every block, ns2 by the first block only
+	stateHashes := make(map[uint64]*ledger.StateHash)
+	for blockNum := uint64(1); blockNum <= 4; blockNum++ {
+		batch := privacyenabledstate.NewUpdateBatch()
+		batch.PubUpdates.Put("ns1", "key1", []byte(fmt.Sprintf("value1.%d", blockNum)), version.NewHeight(blockNum, 0))
+		if blockNum == 1 {
+			batch.PubUpdates.Put("ns2", "key2", []byte("value2"), version.NewHeight(blockNum, 0))
+		}
+		assert.NoError(t, db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(blockNum, 0)))
+		stateHashes[blockNum], err = store.getStateHash(blockNum)
+		assert.NoError(t, err)
+	}
+
+	// Only the state hashes of the last 2 blocks are kept, including the hash of ns2 which was set by block 1
+	_, err = store.getStateHash(2)
+	assert.EqualError(t, err, "State hash of block [2] is not available, the state hashes of blocks [3] to [4] are")
+	for _, blockNum := range []uint64{3, 4} {
+		stateHash, err := store.getStateHash(blockNum)
+		assert.NoError(t, err)
+		assert.Equal(t, stateHashes[blockNum], stateHash)
+		assert.Len(t, stateHash.Namespaces, 2)
+	}
+	itr := store.db.GetIterator(encodeStateHashKey("ns1", nil), encodeStateHashKey("ns1", []byte{0xff}))
+	defer itr.Release()
+	numHashes := 0
+	for itr.Next() {
+		numHashes++
+	}
+	assert.Equal(t, 2, numHashes)
+}
@@ -9,10 +9,10 @@ package privacyenabledstate
 import (
 	"encoding/base64"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
-	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
-	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/library"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 )
@@ -30,14 +30,9 @@ type CommonStorageDBProvider struct {
 
 // NewCommonStorageDBProvider constructs an instance of DBProvider
 func NewCommonStorageDBProvider() (DBProvider, error) {
-	var vdbProvider statedb.VersionedDBProvider
-	var err error
-	if ledgerconfig.IsCouchDBEnabled() {
-		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(); err != nil {
-			return nil, err
-		}
-	} else {
-		vdbProvider = stateleveldb.NewVersionedDBProvider()
+	vdbProvider, err := library.NewVersionedDBProvider(ledgerconfig.GetStateDatabase())
+	if err != nil {
+		return nil, err
 	}
 	return &CommonStorageDBProvider{vdbProvider}, nil
 }
@@ -135,6 +130,18 @@ func (s *CommonStorageDB) GetKeyHashVersion(namespace, collection string, keyHas
 	return s.GetVersion(deriveHashedDataNs(namespace, collection), keyHashStr)
 }
 
+// GetValueHashMultipleKeys implements corresponding function in interface DB
+func (s *CommonStorageDB) GetValueHashMultipleKeys(namespace, collection string, keyHashes [][]byte) ([]*statedb.VersionedValue, error) {
+	keyHashStrs := make([]string, len(keyHashes))
+	for i, keyHash := range keyHashes {
+		keyHashStrs[i] = string(keyHash)
+		if !s.BytesKeySuppoted() {
+			keyHashStrs[i] = base64.StdEncoding.EncodeToString(keyHash)
+		}
+	}
+	return s.GetStateMultipleKeys(deriveHashedDataNs(namespace, collection), keyHashStrs)
+}
+
 // GetPrivateDataMultipleKeys implements corresponding function in interface DB
 func (s *CommonStorageDB) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([]*statedb.VersionedValue, error) {
 	return s.GetStateMultipleKeys(derivePvtDataNs(namespace, collection), keys)
@@ -163,6 +170,43 @@ func (s *CommonStorageDB) ApplyPrivacyAwareUpdates(updates *UpdateBatch, height
 	return s.VersionedDB.ApplyUpdates(updates.PubUpdates.UpdateBatch, height)
 }
 
+// GetSnapshotIterator implements corresponding function in interface DB
+func (s *CommonStorageDB) GetSnapshotIterator() (statedb.ResultsIterator, error) {
+	fullScannable, ok := s.VersionedDB.(statedb.FullScannable)
+	if !ok {
+		return nil, fmt.Errorf("Exporting a snapshot is not supported by the state database")
+	}
+	itr, err := fullScannable.GetFullScanIterator()
+	if err != nil {
+		return nil, err
+	}
+	return &snapshotIterator{itr, !s.BytesKeySuppoted()}, nil
+}
+
+// Drop implements corresponding function in interface DB
+func (s *CommonStorageDB) Drop() error {
+	droppable, ok := s.VersionedDB.(statedb.Droppable)
+	if !ok {
+		return fmt.Errorf("Dropping the data is not supported by the state database")
+	}
+	return droppable.Drop()
+}
+
+// IsIndexCapable implements corresponding function in interface DB
+func (s *CommonStorageDB) IsIndexCapable() bool {
+	_, ok := s.VersionedDB.(statedb.IndexCapable)
+	return ok
+}
+
+// CreateIndex implements corresponding function in interface DB
+func (s *CommonStorageDB) CreateIndex(namespace string, indexDefinition []byte) error {
+	indexCapable, ok := s.VersionedDB.(statedb.IndexCapable)
+	if !ok {
+		return fmt.Errorf("Creating indexes is not supported by the state database")
+	}
+	return indexCapable.CreateIndex(namespace, indexDefinition)
+}
+
 func derivePvtDataNs(namespace, collection string) string {
 	return namespace + nsJoiner + pvtDataPrefix + collection
 }
@@ -171,6 +215,16 @@ func deriveHashedDataNs(namespace, collection string) string {
 	return namespace + nsJoiner + hashDataPrefix + collection
 }
 
+// splitDerivedNs returns the namespace and the collection a namespace was derived from,
+// along with the prefix that tells apart the private data from the hashed data
+func splitDerivedNs(derivedNs string) (namespace, prefix, collection string) {
+	split := strings.SplitN(derivedNs, nsJoiner, 2)
+	if len(split) < 2 || len(split[1]) == 0 {
+		return derivedNs, "", ""
+	}
+	return split[0], split[1][:1], split[1][1:]
+}
+
 func addPvtUpdates(pubUpdateBatch *PubUpdateBatch, pvtUpdateBatch *PvtUpdateBatch) {
 	for ns, nsBatch := range pvtUpdateBatch.UpdateMap {
 		for _, coll := range nsBatch.GetCollectionNames() {
@@ -193,3 +247,45 @@ func addHashedUpdates(pubUpdateBatch *PubUpdateBatch, hashedUpdateBatch *HashedU
 		}
 	}
 }
+
+// snapshotIterator wraps a full scan iterator of the wrapped db. It skips the private data,
+// and returns the hashed data along with the namespace and the collection it belongs to
+type snapshotIterator struct {
+	itr       statedb.ResultsIterator
+	base64Key bool
+}
+
+func (itr *snapshotIterator) Next() (statedb.QueryResult, error) {
+	for {
+		res, err := itr.itr.Next()
+		if err != nil || res == nil {
+			return nil, err
+		}
+		kv := res.(*statedb.VersionedKV)
+		ns, prefix, coll := splitDerivedNs(kv.Namespace)
+		key := kv.Key
+		switch prefix {
+		case pvtDataPrefix:
+			continue
+		case hashDataPrefix:
+			if itr.base64Key {
+				keyHash, err := base64.StdEncoding.DecodeString(key)
+				if err != nil {
+					return nil, err
+				}
+				key = string(keyHash)
+			}
+		}
+		return &SnapshotKV{
+			Namespace:      ns,
+			CollectionName: coll,
+			Key:            key,
+			Value:          kv.Value,
+			Version:        kv.Version,
+		}, nil
+	}
+}
+
+func (itr *snapshotIterator) Close() {
+	itr.itr.Close()
+}
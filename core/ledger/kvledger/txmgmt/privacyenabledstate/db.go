@@ -28,10 +28,21 @@ type DB interface {
 	GetPrivateData(namespace, collection, key string) (*statedb.VersionedValue, error)
 	GetValueHash(namespace, collection string, keyHash []byte) (*statedb.VersionedValue, error)
 	GetKeyHashVersion(namespace, collection string, keyHash []byte) (*version.Height, error)
+	GetValueHashMultipleKeys(namespace, collection string, keyHashes [][]byte) ([]*statedb.VersionedValue, error)
 	GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([]*statedb.VersionedValue, error)
 	GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey string) (statedb.ResultsIterator, error)
 	ExecuteQueryOnPrivateData(namespace, collection, query string) (statedb.ResultsIterator, error)
 	ApplyPrivacyAwareUpdates(updates *UpdateBatch, height *version.Height) error
+	// GetSnapshotIterator returns an iterator over the public data and the hashes of the private data.
+	// The private data itself is not included. The returned ResultsIterator contains results of type *SnapshotKV
+	GetSnapshotIterator() (statedb.ResultsIterator, error)
+	// Drop removes the public data, the private data and the hashes of the private data, along with the savepoint
+	Drop() error
+	// IsIndexCapable returns whether the state database maintains the indexes created by CreateIndex
+	IsIndexCapable() bool
+	// CreateIndex creates the index of the public data of the given namespace which is described by the given
+	// index definition, see statedb.IndexCapable, and fails if the state database isn't index capable
+	CreateIndex(namespace string, indexDefinition []byte) error
 }
 
 // HashedCompositeKey encloses Namespace, CollectionName and KeyHash components
@@ -41,6 +52,16 @@ type HashedCompositeKey struct {
 	KeyHash        string
 }
 
+// SnapshotKV encloses an entry of the public data or of the hashes of the private data, as exported to a snapshot.
+// For the hashes of the private data, the Key and the Value are the hashes of the key and of the value of the private data
+type SnapshotKV struct {
+	Namespace      string
+	CollectionName string
+	Key            string
+	Value          []byte
+	Version        *version.Height
+}
+
 // UpdateBatch encapsulates the updates to Public, Private, and Hashed data.
 // This is expected to contain a consistent set of updates
 type UpdateBatch struct {
@@ -172,6 +172,17 @@ func testGetStateMultipleKeys(t *testing.T, env TestEnv) {
 			&statedb.VersionedValue{Value: []byte("pvt_value3"), Version: version.NewHeight(1, 6)},
 		},
 		pvtVersionedVals)
+
+	hashedVersionedVals, err := db.GetValueHashMultipleKeys("ns1", "coll1",
+		[][]byte{util.ComputeStringHash("key1"), util.ComputeStringHash("key4"), util.ComputeStringHash("key3")})
+	assert.NoError(t, err)
+	assert.Equal(t,
+		[]*statedb.VersionedValue{
+			&statedb.VersionedValue{Value: util.ComputeStringHash("pvt_value1"), Version: version.NewHeight(1, 4)},
+			nil,
+			&statedb.VersionedValue{Value: util.ComputeStringHash("pvt_value3"), Version: version.NewHeight(1, 6)},
+		},
+		hashedVersionedVals)
 }
 
 func TestGetStateRangeScanIterator(t *testing.T) {
@@ -355,3 +366,59 @@ func deletePvtUpdates(t *testing.T, updates *UpdateBatch, ns, coll, key string,
 	updates.PvtUpdates.Delete(ns, coll, key, ver)
 	updates.HashUpdates.Delete(ns, coll, util.ComputeStringHash(key), ver)
 }
+
+func TestSnapshotIterator(t *testing.T) {
+	for _, env := range testEnvs {
+		_, ok := env.(*LevelDBCommonStorageTestEnv)
+		if !ok {
+			continue
+		}
+		t.Run(env.GetName(), func(t *testing.T) {
+			testSnapshotIterator(t, env)
+		})
+	}
+}
+
+func testSnapshotIterator(t *testing.T, env TestEnv) {
+	env.Init(t)
+	defer env.Cleanup()
+	db := env.GetDBHandle("test-ledger-id")
+
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	updates.PubUpdates.Put("ns2", "key2", []byte("value2"), version.NewHeight(1, 2))
+	putPvtUpdates(t, updates, "ns1", "coll1", "key1", []byte("pvt_value1"), version.NewHeight(1, 3))
+	db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(1, 3))
+
+	itr, err := db.GetSnapshotIterator()
+	assert.NoError(t, err)
+	defer itr.Close()
+	var results []*SnapshotKV
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			break
+		}
+		results = append(results, res.(*SnapshotKV))
+	}
+
+	// The private data itself is not exported, only its hashes
+	assert.Equal(t, []*SnapshotKV{
+		{Namespace: "ns1", Key: "key1", Value: []byte("value1"), Version: version.NewHeight(1, 1)},
+		{Namespace: "ns1", CollectionName: "coll1", Key: string(util.ComputeStringHash("key1")),
+			Value: util.ComputeStringHash("pvt_value1"), Version: version.NewHeight(1, 3)},
+		{Namespace: "ns2", Key: "key2", Value: []byte("value2"), Version: version.NewHeight(1, 2)},
+	}, results)
+}
+
+func TestSplitDerivedNs(t *testing.T) {
+	ns, prefix, coll := splitDerivedNs(deriveHashedDataNs("ns1", "coll1"))
+	assert.Equal(t, []string{"ns1", hashDataPrefix, "coll1"}, []string{ns, prefix, coll})
+
+	ns, prefix, coll = splitDerivedNs(derivePvtDataNs("ns1", "coll1"))
+	assert.Equal(t, []string{"ns1", pvtDataPrefix, "coll1"}, []string{ns, prefix, coll})
+
+	ns, prefix, coll = splitDerivedNs("ns1")
+	assert.Equal(t, []string{"ns1", "", ""}, []string{ns, prefix, coll})
+}
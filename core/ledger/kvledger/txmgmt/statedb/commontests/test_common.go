@@ -17,6 +17,7 @@ limitations under the License.
 package commontests
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -25,6 +26,29 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 )
 
+// TestConformance runs the tests every state database is expected to pass
+// against the given provider. State databases which support rich queries
+// are expected to pass TestQuery as well
+func TestConformance(t *testing.T, dbProvider statedb.VersionedDBProvider) {
+	tests := []struct {
+		name string
+		test func(*testing.T, statedb.VersionedDBProvider)
+	}{
+		{"GetStateMultipleKeys", TestGetStateMultipleKeys},
+		{"BasicRW", TestBasicRW},
+		{"MultiDBBasicRW", TestMultiDBBasicRW},
+		{"Deletes", TestDeletes},
+		{"Iterator", TestIterator},
+		{"GetVersion", TestGetVersion},
+		{"ReadAfterUpdates", TestReadAfterUpdates},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.test(t, dbProvider)
+		})
+	}
+}
+
 // TestGetStateMultipleKeys tests read for given multiple keys
 func TestGetStateMultipleKeys(t *testing.T, dbProvider statedb.VersionedDBProvider) {
 	db, err := dbProvider.GetDBHandle("testgetmultiplekeys")
@@ -546,3 +570,69 @@ func TestGetVersion(t *testing.T, dbProvider statedb.VersionedDBProvider) {
 
 	}
 }
+
+// TestReadAfterUpdates tests that reads of keys reflect the updates applied after the keys were read
+func TestReadAfterUpdates(t *testing.T, dbProvider statedb.VersionedDBProvider) {
+	db, err := dbProvider.GetDBHandle("testreadafterupdates")
+	testutil.AssertNoError(t, err, "")
+
+	// read non-existent keys before they are created
+	vv, err := db.GetState("ns", "key1")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNil(t, vv)
+	ver, err := db.GetVersion("ns", "key2")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNil(t, ver)
+
+	batch := statedb.NewUpdateBatch()
+	vv1 := statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}
+	vv2 := statedb.VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 2)}
+	batch.Put("ns", "key1", vv1.Value, vv1.Version)
+	batch.Put("ns", "key2", vv2.Value, vv2.Version)
+	testutil.AssertNoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 2)), "")
+
+	vv, _ = db.GetState("ns", "key1")
+	testutil.AssertEquals(t, vv, &vv1)
+	ver, _ = db.GetVersion("ns", "key2")
+	testutil.AssertEquals(t, ver, vv2.Version)
+
+	// update a key and delete another key, after both were read
+	batch = statedb.NewUpdateBatch()
+	vv3 := statedb.VersionedValue{Value: []byte("value3"), Version: version.NewHeight(2, 1)}
+	batch.Put("ns", "key1", vv3.Value, vv3.Version)
+	batch.Delete("ns", "key2", version.NewHeight(2, 2))
+	testutil.AssertNoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 2)), "")
+
+	if bulkdb, ok := db.(statedb.BulkOptimizable); ok {
+		bulkdb.ClearCachedVersions()
+	}
+
+	vv, _ = db.GetState("ns", "key1")
+	testutil.AssertEquals(t, vv, &vv3)
+	ver, _ = db.GetVersion("ns", "key1")
+	testutil.AssertEquals(t, ver, vv3.Version)
+	vv, _ = db.GetState("ns", "key2")
+	testutil.AssertNil(t, vv)
+	ver, _ = db.GetVersion("ns", "key2")
+	testutil.AssertNil(t, ver)
+}
+
+// BenchmarkGetState benchmarks repeated reads of the committed state of a set of keys
+func BenchmarkGetState(b *testing.B, dbProvider statedb.VersionedDBProvider) {
+	db, err := dbProvider.GetDBHandle("benchmarkgetstate")
+	testutil.AssertNoError(b, err, "")
+
+	numKeys := 100
+	batch := statedb.NewUpdateBatch()
+	for i := 0; i < numKeys; i++ {
+		batch.Put("ns", fmt.Sprintf("key%d", i), []byte(fmt.Sprintf(`{"value":%d}`, i)), version.NewHeight(1, uint64(i)))
+	}
+	testutil.AssertNoError(b, db.ApplyUpdates(batch, version.NewHeight(1, uint64(numKeys))), "")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vv, err := db.GetState("ns", fmt.Sprintf("key%d", i%numKeys))
+		testutil.AssertNoError(b, err, "")
+		testutil.AssertNotNil(b, vv)
+	}
+}
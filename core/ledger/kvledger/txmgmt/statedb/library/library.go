@@ -0,0 +1,37 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package library
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+)
+
+// ProviderLibrary is used to assert how to create the state databases
+// the peer can be configured with.
+// A state database is registered by adding a method to ProviderLibrary that
+// returns a statedb.VersionedDBProvider and an error, named after the state database
+// with its first letter capitalized. It is then selected by setting
+// ledger.state.stateDatabase in core.yaml to the name of the state database,
+// in any case. The state databases are compiled into the peer, there is no
+// support for loading them from Go plugins.
+// Every state database is expected to pass the conformance suite in statedb/commontests
+type ProviderLibrary struct {
+}
+
+// Goleveldb creates the default VersionedDBProvider,
+// which stores the state in an embedded LevelDB
+func (l *ProviderLibrary) Goleveldb() (statedb.VersionedDBProvider, error) {
+	return stateleveldb.NewVersionedDBProvider(), nil
+}
+
+// CouchDB creates a VersionedDBProvider which stores the state in CouchDB,
+// and supports rich queries over JSON values
+func (l *ProviderLibrary) CouchDB() (statedb.VersionedDBProvider, error) {
+	return statecouchdb.NewVersionedDBProvider()
+}
@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package library
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+)
+
+const defaultStateDatabase = "goleveldb"
+
+// NewVersionedDBProvider creates the VersionedDBProvider of the given state database,
+// using the method of ProviderLibrary the state database is registered by, whose name
+// matches the one of the state database case-insensitively. The default state database
+// is used if none is given.
+// Only the state databases compiled into the peer are supported, state databases
+// aren't loaded from Go plugins
+func NewVersionedDBProvider(stateDatabase string) (statedb.VersionedDBProvider, error) {
+	if stateDatabase == "" {
+		stateDatabase = defaultStateDatabase
+	}
+
+	library := reflect.ValueOf(&ProviderLibrary{})
+	for i := 0; i < library.NumMethod(); i++ {
+		methodName := library.Type().Method(i).Name
+		if !strings.EqualFold(methodName, stateDatabase) {
+			continue
+		}
+		factory, isFactory := library.Method(i).Interface().(func() (statedb.VersionedDBProvider, error))
+		if !isFactory {
+			return nil, fmt.Errorf("method %s of ProviderLibrary doesn't create a VersionedDBProvider", methodName)
+		}
+		return factory()
+	}
+	return nil, fmt.Errorf("state database %s isn't registered: no method of ProviderLibrary is named after it", stateDatabase)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package library
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/ledgertests/kvledger/txmgmt/statedb/library")
+	os.Exit(m.Run())
+}
+
+func TestNewVersionedDBProvider(t *testing.T) {
+	for _, stateDatabase := range []string{"", "goleveldb", "GoLevelDB"} {
+		dbProvider, err := NewVersionedDBProvider(stateDatabase)
+		assert.NoError(t, err)
+		_, isLevelDB := dbProvider.(*stateleveldb.VersionedDBProvider)
+		assert.True(t, isLevelDB, "state database %q should be goleveldb", stateDatabase)
+		dbProvider.Close()
+	}
+	os.RemoveAll(ledgerconfig.GetStateLevelDBPath())
+}
+
+func TestNewVersionedDBProviderNotRegistered(t *testing.T) {
+	dbProvider, err := NewVersionedDBProvider("rocksdb")
+	assert.Nil(t, dbProvider)
+	assert.EqualError(t, err, "state database rocksdb isn't registered: no method of ProviderLibrary is named after it")
+}
+
+// TestConformance runs the conformance suite against the configured state database,
+// which is goleveldb unless ledger.state.stateDatabase is set
+func TestConformance(t *testing.T) {
+	stateDatabase := ledgerconfig.GetStateDatabase()
+	t.Logf("Running the conformance suite against state database %q", stateDatabase)
+	os.RemoveAll(ledgerconfig.GetStateLevelDBPath())
+	defer os.RemoveAll(ledgerconfig.GetStateLevelDBPath())
+	dbProvider, err := NewVersionedDBProvider(stateDatabase)
+	assert.NoError(t, err)
+	defer dbProvider.Close()
+	commontests.TestConformance(t, dbProvider)
+}
@@ -0,0 +1,283 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package richquery
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	selectorKey = "selector"
+	fieldsKey   = "fields"
+	sortKey     = "sort"
+)
+
+// Operators are the operators of the Mango query language.
+// Keys of a selector which are operators don't name fields of the queried documents
+var Operators = []string{"$and", "$or", "$not", "$nor", "$all", "$elemMatch",
+	"$lt", "$lte", "$eq", "$ne", "$gte", "$gt", "$exists", "$type", "$in", "$nin",
+	"$size", "$mod", "$regex"}
+
+// comparisonOperators are the operators which compare the value of a field,
+// and are supported by Parse along with $and, $or and $not
+var comparisonOperators = []string{"$eq", "$ne", "$lt", "$lte", "$gt", "$gte"}
+
+// rangeOperators are the comparison operators which depend on the order of the values
+// rather than on their equality, and are rejected by Parse when they compare strings
+var rangeOperators = []string{"$lt", "$lte", "$gt", "$gte"}
+
+// IsOperator returns whether the given key of a selector is an operator
+func IsOperator(key string) bool {
+	return contains(Operators, key)
+}
+
+// Decode decodes the given JSON object, such as a query or a queried document, into a generic map.
+// Numbers are decoded as json.Number, so that large integers aren't transformed into floats
+func Decode(queryString string) (map[string]interface{}, error) {
+	jsonQueryMap := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewBuffer([]byte(queryString)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&jsonQueryMap); err != nil {
+		return nil, err
+	}
+	return jsonQueryMap, nil
+}
+
+// SortField is a field the results of a query are sorted by
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// Condition is a comparison of the value of a field, such as {"$gt": 5}
+type Condition struct {
+	Operator string
+	Value    interface{}
+}
+
+// Query is a parsed Mango query, which supports the subset of the query language
+// consisting of the comparison operators, $and, $or and $not, along with the
+// "fields" and "sort" of the query. Other keys of the query, such as "limit" and
+// "use_index", are left to the state database which executes the query.
+// Strings are compared by their bytes rather than by the ICU collation of CouchDB, see Compare,
+// hence Parse rejects the range conditions on strings, and CheckSort rejects the documents
+// whose sort fields hold strings
+type Query struct {
+	selector *selector
+	Fields   []string
+	Sort     []SortField
+}
+
+// Parse parses the given query, and fails if it uses operators which aren't supported,
+// or compares a field with $lt, $lte, $gt or $gte to a string, or to an array or an object
+// which contains strings
+func Parse(queryString string) (*Query, error) {
+	jsonQueryMap, err := Decode(queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &Query{selector: &selector{operator: "$and"}}
+	if jsonSelector, ok := jsonQueryMap[selectorKey]; ok {
+		selectorMap, isMap := jsonSelector.(map[string]interface{})
+		if !isMap {
+			return nil, fmt.Errorf("selector must be a JSON object, but is %v", jsonSelector)
+		}
+		if query.selector, err = parseSelector(selectorMap); err != nil {
+			return nil, err
+		}
+	}
+
+	if jsonFields, ok := jsonQueryMap[fieldsKey]; ok {
+		fields, isArray := jsonFields.([]interface{})
+		if !isArray {
+			return nil, fmt.Errorf("fields must be an array, but is %v", jsonFields)
+		}
+		for _, field := range fields {
+			fieldName, isString := field.(string)
+			if !isString {
+				return nil, fmt.Errorf("fields must be strings, but %v isn't", field)
+			}
+			query.Fields = append(query.Fields, fieldName)
+		}
+	}
+
+	if jsonSort, ok := jsonQueryMap[sortKey]; ok {
+		if query.Sort, err = ParseSortFields(jsonSort); err != nil {
+			return nil, err
+		}
+	}
+	return query, nil
+}
+
+// ParseSortFields parses the fields of a sort, or of an index definition,
+// which are either field names or objects mapping a field name to "asc" or "desc"
+func ParseSortFields(jsonSort interface{}) ([]SortField, error) {
+	sortFields, isArray := jsonSort.([]interface{})
+	if !isArray {
+		return nil, fmt.Errorf("sort must be an array, but is %v", jsonSort)
+	}
+	var fields []SortField
+	for _, sortField := range sortFields {
+		switch sortField := sortField.(type) {
+		case string:
+			fields = append(fields, SortField{Field: sortField})
+		case map[string]interface{}:
+			if len(sortField) != 1 {
+				return nil, fmt.Errorf("sort field %v must have a single field name", sortField)
+			}
+			for field, direction := range sortField {
+				switch direction {
+				case "asc":
+					fields = append(fields, SortField{Field: field})
+				case "desc":
+					fields = append(fields, SortField{Field: field, Descending: true})
+				default:
+					return nil, fmt.Errorf("sort direction of field %s must be asc or desc, but is %v", field, direction)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("sort field %v must be a string or a JSON object", sortField)
+		}
+	}
+	return fields, nil
+}
+
+// Matches returns whether the given document satisfies the selector of the query
+func (q *Query) Matches(doc map[string]interface{}) bool {
+	return q.selector.matches(doc)
+}
+
+// Conditions returns the conditions on the given field that every document
+// matching the query satisfies, which can be used to look documents up in an index
+func (q *Query) Conditions(field string) []Condition {
+	return q.selector.conditions(field)
+}
+
+// Less returns whether the first document precedes the second in the sort order of the query.
+// Documents which lack a sort field precede those which have it
+func (q *Query) Less(doc1, doc2 map[string]interface{}) bool {
+	for _, sortField := range q.Sort {
+		value1, exists1 := Lookup(doc1, sortField.Field)
+		value2, exists2 := Lookup(doc2, sortField.Field)
+		var result int
+		switch {
+		case !exists1 && !exists2:
+			continue
+		case !exists1:
+			result = -1
+		case !exists2:
+			result = 1
+		default:
+			result = Compare(value1, value2)
+		}
+		if result == 0 {
+			continue
+		}
+		if sortField.Descending {
+			return result > 0
+		}
+		return result < 0
+	}
+	return false
+}
+
+// CheckSort fails if a sort field of the given document holds a string, or an array or
+// an object, which contains strings. The order of strings differs from the ICU collation
+// of CouchDB, see Compare, so such a sort would give other results than on CouchDB
+func (q *Query) CheckSort(doc map[string]interface{}) error {
+	for _, sortField := range q.Sort {
+		if value, exists := Lookup(doc, sortField.Field); exists && containsString(value) {
+			return fmt.Errorf("sort field %s holds strings, which can't be sorted like CouchDB sorts them", sortField.Field)
+		}
+	}
+	return nil
+}
+
+// containsString returns whether the given JSON value is, or contains, a string.
+// The keys of an object are strings, so any non-empty object contains strings
+func containsString(value interface{}) bool {
+	switch value := value.(type) {
+	case string:
+		return true
+	case []interface{}:
+		for _, element := range value {
+			if containsString(element) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		return len(value) > 0
+	}
+	return false
+}
+
+// Project returns the part of the given document which consists of the fields of the query,
+// or the whole document if the query doesn't list fields
+func (q *Query) Project(doc map[string]interface{}) map[string]interface{} {
+	if len(q.Fields) == 0 {
+		return doc
+	}
+	projection := make(map[string]interface{})
+	for _, field := range q.Fields {
+		value, exists := Lookup(doc, field)
+		if !exists {
+			continue
+		}
+		path := strings.Split(field, ".")
+		parent := projection
+		for _, name := range path[:len(path)-1] {
+			child, isMap := parent[name].(map[string]interface{})
+			if !isMap {
+				child = make(map[string]interface{})
+				parent[name] = child
+			}
+			parent = child
+		}
+		parent[path[len(path)-1]] = value
+	}
+	return projection
+}
+
+// Lookup returns the value of the given field of a document,
+// where the names of nested fields are separated by dots
+func Lookup(doc map[string]interface{}, field string) (interface{}, bool) {
+	var value interface{} = doc
+	for _, name := range strings.Split(field, ".") {
+		fields, isMap := value.(map[string]interface{})
+		if !isMap {
+			return nil, false
+		}
+		var exists bool
+		if value, exists = fields[name]; !exists {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func contains(sourceArray []string, selectItem string) bool {
+	for _, s := range sourceArray {
+		if s == selectItem {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(jsonMap map[string]interface{}) []string {
+	keys := make([]string, 0, len(jsonMap))
+	for key := range jsonMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
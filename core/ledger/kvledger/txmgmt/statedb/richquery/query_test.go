@@ -0,0 +1,157 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package richquery
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeDoc(t *testing.T, value string) map[string]interface{} {
+	doc, err := Decode(value)
+	assert.NoError(t, err)
+	return doc
+}
+
+func TestMatches(t *testing.T) {
+	doc := decodeDoc(t, `{"asset_name":"marble1","color":"blue","size":1000007,"owner":{"name":"tom","age":30},"tags":["a","b"]}`)
+	tests := []struct {
+		query   string
+		matches bool
+	}{
+		{`{}`, true},
+		{`{"selector":{}}`, true},
+		{`{"selector":{"color":"blue"}}`, true},
+		{`{"selector":{"color":"red"}}`, false},
+		{`{"selector":{"color":{"$eq":"blue"}}}`, true},
+		{`{"selector":{"color":{"$ne":"blue"}}}`, false},
+		{`{"selector":{"size":1000007}}`, true},
+		{`{"selector":{"size":1000007.0}}`, true},
+		{`{"selector":{"size":{"$gt":1000006,"$lte":1000007}}}`, true},
+		{`{"selector":{"size":{"$lt":1000007}}}`, false},
+		{`{"selector":{"size":{"$gt":[1]}}}`, false},
+		{`{"selector":{"size":{"$gt":true}}}`, true},
+		{`{"selector":{"color":{"$lt":[1]}}}`, true},
+		{`{"selector":{"owner.name":"tom"}}`, true},
+		{`{"selector":{"owner":{"name":"tom","age":{"$gte":30}}}}`, true},
+		{`{"selector":{"owner":{"age":{"$gt":30}}}}`, false},
+		{`{"selector":{"tags":["a","b"]}}`, true},
+		{`{"selector":{"tags":"a"}}`, false},
+		{`{"selector":{"missing":{"$ne":"blue"}}}`, false},
+		{`{"selector":{"$and":[{"color":"blue"},{"size":{"$gt":5}}]}}`, true},
+		{`{"selector":{"$or":[{"color":"red"},{"owner.name":"tom"}]}}`, true},
+		{`{"selector":{"$or":[{"color":"red"},{"owner.name":"jerry"}]}}`, false},
+		{`{"selector":{"color":"blue","$not":{"size":1000007}}}`, false},
+		{`{"selector":{"$not":{"missing":1}}}`, true},
+	}
+	for _, test := range tests {
+		query, err := Parse(test.query)
+		assert.NoError(t, err, test.query)
+		assert.Equal(t, test.matches, query.Matches(doc), test.query)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		`this is an invalid query string`:                   "invalid character 'h' in literal true (expecting 'r')",
+		`{"selector":"blue"}`:                               "selector must be a JSON object, but is blue",
+		`{"selector":{"color":{"$regex":"^bl"}}}`:           "operator $regex isn't supported",
+		`{"selector":{"$nor":[{"color":"blue"}]}}`:          "operator $nor isn't supported",
+		`{"selector":{"$and":{"color":"blue"}}}`:            "operator $and must be given an array of selectors, but is given map[color:blue]",
+		`{"selector":{"$not":"blue"}}`:                      "operator $not must be given a selector, but is given blue",
+		`{"selector":{"color":"blue"},"fields":"color"}`:    "fields must be an array, but is color",
+		`{"selector":{"color":"blue"},"sort":[{"size":1}]}`: "sort direction of field size must be asc or desc, but is 1",
+		`{"selector":{"color":{"$gt":"blue"}}}`:             "operator $gt of field color compares strings, which can't be ordered like CouchDB orders them",
+		`{"selector":{"size":{"$lte":[1,"a"]}}}`:            "operator $lte of field size compares strings, which can't be ordered like CouchDB orders them",
+	}
+	for queryString, expectedErr := range tests {
+		query, err := Parse(queryString)
+		assert.Nil(t, query)
+		assert.EqualError(t, err, expectedErr, queryString)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// values in the collation order of CouchDB
+	values := []string{`null`, `false`, `true`, `-3.5`, `0`, `2`, `10`, `"a"`, `"ab"`, `"b"`, `[]`, `[1]`, `[1,2]`, `[2]`, `{}`, `{"a":1}`, `{"a":2}`, `{"b":0}`}
+	decoded := make([]interface{}, len(values))
+	for i, value := range values {
+		doc := decodeDoc(t, `{"value":`+value+`}`)
+		decoded[i] = doc["value"]
+	}
+	for i := range decoded {
+		for j := range decoded {
+			result := Compare(decoded[i], decoded[j])
+			switch {
+			case i < j:
+				assert.True(t, result < 0, "%s should precede %s", values[i], values[j])
+			case i > j:
+				assert.True(t, result > 0, "%s should follow %s", values[i], values[j])
+			default:
+				assert.Equal(t, 0, result, "%s should equal itself", values[i])
+			}
+		}
+	}
+	assert.Equal(t, 0, Compare(json.Number("2"), 2.0))
+	assert.Equal(t, 0, Compare(json.Number("-0"), json.Number("0")))
+
+	// strings are compared by their bytes, unlike the ICU collation of CouchDB where "a" < "B" < "é" < "z"
+	assert.True(t, Compare("B", "a") < 0)
+	assert.True(t, Compare("z", "é") < 0)
+}
+
+func TestSortAndProject(t *testing.T) {
+	query, err := Parse(`{"selector":{"color":"blue"},"fields":["asset_name","owner.name"],"sort":[{"size":"desc"},"asset_name"]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []SortField{{Field: "size", Descending: true}, {Field: "asset_name"}}, query.Sort)
+
+	doc1 := decodeDoc(t, `{"asset_name":"marble1","size":1,"owner":{"name":"tom","age":30}}`)
+	doc2 := decodeDoc(t, `{"asset_name":"marble2","size":2}`)
+	doc3 := decodeDoc(t, `{"asset_name":"marble3","size":2}`)
+	doc4 := decodeDoc(t, `{"asset_name":"marble4"}`)
+	assert.True(t, query.Less(doc2, doc1))
+	assert.True(t, query.Less(doc2, doc3))
+	assert.False(t, query.Less(doc3, doc2))
+	assert.False(t, query.Less(doc2, doc2))
+	// documents which lack a sort field follow the others when the sort is descending
+	assert.True(t, query.Less(doc1, doc4))
+
+	projection, err := json.Marshal(query.Project(doc1))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"asset_name":"marble1","owner":{"name":"tom"}}`, string(projection))
+	projection, err = json.Marshal(query.Project(doc4))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"asset_name":"marble4"}`, string(projection))
+}
+
+func TestCheckSort(t *testing.T) {
+	query, err := Parse(`{"selector":{"color":"blue"},"sort":["size","owner"]}`)
+	assert.NoError(t, err)
+	assert.NoError(t, query.CheckSort(decodeDoc(t, `{"size":1,"owner":null}`)))
+	assert.NoError(t, query.CheckSort(decodeDoc(t, `{"size":[1,true],"owner":{}}`)))
+	assert.NoError(t, query.CheckSort(decodeDoc(t, `{"color":"blue"}`)))
+	assert.EqualError(t, query.CheckSort(decodeDoc(t, `{"size":"large"}`)),
+		"sort field size holds strings, which can't be sorted like CouchDB sorts them")
+	assert.Error(t, query.CheckSort(decodeDoc(t, `{"size":[1,"large"]}`)))
+	assert.Error(t, query.CheckSort(decodeDoc(t, `{"size":1,"owner":{"age":30}}`)))
+}
+
+func TestConditions(t *testing.T) {
+	query, err := Parse(`{"selector":{"size":{"$gt":5},"$and":[{"size":{"$lt":8}},{"color":"blue"}],"$or":[{"size":6},{"size":7}]}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Condition{{Operator: "$lt", Value: json.Number("8")}, {Operator: "$gt", Value: json.Number("5")}}, query.Conditions("size"))
+	assert.Equal(t, []Condition{{Operator: "$eq", Value: "blue"}}, query.Conditions("color"))
+	assert.Empty(t, query.Conditions("owner"))
+}
+
+func TestIsOperator(t *testing.T) {
+	assert.True(t, IsOperator("$and"))
+	assert.True(t, IsOperator("$exists"))
+	assert.False(t, IsOperator("owner"))
+}
@@ -0,0 +1,250 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package richquery
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// selector is a node of a parsed selector, which either combines its children
+// with $and, $or or $not, or compares the value of a field using a comparison operator
+type selector struct {
+	operator string
+	field    string
+	value    interface{}
+	children []*selector
+}
+
+// parseSelector parses a JSON selector, whose entries are implicitly combined with $and
+func parseSelector(selectorMap map[string]interface{}) (*selector, error) {
+	conjunction := &selector{operator: "$and"}
+	for _, key := range sortedKeys(selectorMap) {
+		child, err := parseSelectorEntry(key, selectorMap[key])
+		if err != nil {
+			return nil, err
+		}
+		conjunction.children = append(conjunction.children, child)
+	}
+	return conjunction, nil
+}
+
+func parseSelectorEntry(key string, value interface{}) (*selector, error) {
+	switch key {
+	case "$and", "$or":
+		selectors, isArray := value.([]interface{})
+		if !isArray {
+			return nil, fmt.Errorf("operator %s must be given an array of selectors, but is given %v", key, value)
+		}
+		combination := &selector{operator: key}
+		for _, childSelector := range selectors {
+			childMap, isMap := childSelector.(map[string]interface{})
+			if !isMap {
+				return nil, fmt.Errorf("operator %s must be given an array of selectors, but is given %v", key, childSelector)
+			}
+			child, err := parseSelector(childMap)
+			if err != nil {
+				return nil, err
+			}
+			combination.children = append(combination.children, child)
+		}
+		return combination, nil
+	case "$not":
+		childMap, isMap := value.(map[string]interface{})
+		if !isMap {
+			return nil, fmt.Errorf("operator $not must be given a selector, but is given %v", value)
+		}
+		child, err := parseSelector(childMap)
+		if err != nil {
+			return nil, err
+		}
+		return &selector{operator: key, children: []*selector{child}}, nil
+	}
+	if strings.HasPrefix(key, "$") {
+		return nil, fmt.Errorf("operator %s isn't supported", key)
+	}
+	return parseFieldCondition(key, value)
+}
+
+// parseFieldCondition parses the condition on the value of a field, which is either
+// a value the field equals to, or an object of comparison operators and nested fields
+func parseFieldCondition(field string, value interface{}) (*selector, error) {
+	conditionMap, isMap := value.(map[string]interface{})
+	if !isMap || len(conditionMap) == 0 {
+		return &selector{operator: "$eq", field: field, value: value}, nil
+	}
+	conjunction := &selector{operator: "$and"}
+	for _, key := range sortedKeys(conditionMap) {
+		if !strings.HasPrefix(key, "$") {
+			child, err := parseFieldCondition(field+"."+key, conditionMap[key])
+			if err != nil {
+				return nil, err
+			}
+			conjunction.children = append(conjunction.children, child)
+			continue
+		}
+		if !contains(comparisonOperators, key) {
+			return nil, fmt.Errorf("operator %s isn't supported", key)
+		}
+		if contains(rangeOperators, key) && containsString(conditionMap[key]) {
+			return nil, fmt.Errorf("operator %s of field %s compares strings, which can't be ordered like CouchDB orders them", key, field)
+		}
+		conjunction.children = append(conjunction.children, &selector{operator: key, field: field, value: conditionMap[key]})
+	}
+	return conjunction, nil
+}
+
+func (s *selector) matches(doc map[string]interface{}) bool {
+	switch s.operator {
+	case "$and":
+		for _, child := range s.children {
+			if !child.matches(doc) {
+				return false
+			}
+		}
+		return true
+	case "$or":
+		for _, child := range s.children {
+			if child.matches(doc) {
+				return true
+			}
+		}
+		return false
+	case "$not":
+		return !s.children[0].matches(doc)
+	}
+
+	value, exists := Lookup(doc, s.field)
+	if !exists {
+		return false
+	}
+	result := Compare(value, s.value)
+	switch s.operator {
+	case "$eq":
+		return result == 0
+	case "$ne":
+		return result != 0
+	case "$lt":
+		return result < 0
+	case "$lte":
+		return result <= 0
+	case "$gt":
+		return result > 0
+	case "$gte":
+		return result >= 0
+	}
+	return false
+}
+
+func (s *selector) conditions(field string) []Condition {
+	switch s.operator {
+	case "$and":
+		var conditions []Condition
+		for _, child := range s.children {
+			conditions = append(conditions, child.conditions(field)...)
+		}
+		return conditions
+	case "$or", "$not":
+		return nil
+	}
+	if s.field != field {
+		return nil
+	}
+	return []Condition{{Operator: s.operator, Value: s.value}}
+}
+
+// CollationRank returns the rank of the type of the given JSON value in the collation
+// order of CouchDB, where null < false < true < numbers < strings < arrays < objects
+func CollationRank(value interface{}) int {
+	switch value := value.(type) {
+	case nil:
+		return 0
+	case bool:
+		if value {
+			return 2
+		}
+		return 1
+	case json.Number, float64:
+		return 3
+	case string:
+		return 4
+	case []interface{}:
+		return 5
+	case map[string]interface{}:
+		return 6
+	}
+	return 7
+}
+
+// Compare compares two JSON values in the collation order of CouchDB, and returns
+// a negative number, zero or a positive number if the first value precedes, equals
+// or follows the second.
+//
+// Unlike CouchDB, which collates strings with ICU according to the Unicode Collation
+// Algorithm, strings are compared by their UTF-8 bytes, that is, by code point. The
+// string equality is the same, but the string order differs: CouchDB orders letters
+// case-insensitively first, with lowercase before uppercase ("a" < "B" < "b"), and
+// ignores accents and punctuation at first, while the byte order puts every uppercase
+// ASCII letter before every lowercase one ("B" < "a" < "b") and accented letters after
+// "z". Hence the range conditions comparing strings would give other results than on
+// CouchDB when strings differ in case, accents, punctuation or script, so they are
+// rejected by Parse, and sorts on string fields are rejected, see Query.CheckSort
+func Compare(value1, value2 interface{}) int {
+	rank1, rank2 := CollationRank(value1), CollationRank(value2)
+	if rank1 != rank2 {
+		return rank1 - rank2
+	}
+	switch value1 := value1.(type) {
+	case json.Number, float64:
+		return toBigFloat(value1).Cmp(toBigFloat(value2))
+	case string:
+		return strings.Compare(value1, value2.(string))
+	case []interface{}:
+		value2 := value2.([]interface{})
+		for i := 0; i < len(value1) && i < len(value2); i++ {
+			if result := Compare(value1[i], value2[i]); result != 0 {
+				return result
+			}
+		}
+		return len(value1) - len(value2)
+	case map[string]interface{}:
+		value2 := value2.(map[string]interface{})
+		keys1, keys2 := sortedKeys(value1), sortedKeys(value2)
+		for i := 0; i < len(keys1) && i < len(keys2); i++ {
+			if result := strings.Compare(keys1[i], keys2[i]); result != 0 {
+				return result
+			}
+			if result := Compare(value1[keys1[i]], value2[keys2[i]]); result != 0 {
+				return result
+			}
+		}
+		return len(keys1) - len(keys2)
+	}
+	return 0
+}
+
+// ToFloat64 returns the given JSON number as a float64, which is
+// rounded to the nearest float64 if it can't be represented exactly
+func ToFloat64(number interface{}) float64 {
+	f, _ := toBigFloat(number).Float64()
+	return f
+}
+
+func toBigFloat(number interface{}) *big.Float {
+	switch number := number.(type) {
+	case json.Number:
+		f, _, err := big.ParseFloat(string(number), 10, 256, big.ToNearestEven)
+		if err == nil {
+			return f
+		}
+	case float64:
+		return big.NewFloat(number)
+	}
+	return new(big.Float)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+)
+
+// cachedDoc is the committed state of a key, as stored in CouchDB.
+// A nil version means the key doesn't exist in the state database.
+// hasValue is false for documents of which only the metadata was retrieved
+type cachedDoc struct {
+	value    []byte
+	version  *version.Height
+	revision string
+	hasValue bool
+}
+
+type cacheEntry struct {
+	key statedb.CompositeKey
+	doc *cachedDoc
+}
+
+// stateCache is a bounded cache of the committed state of a channel,
+// which evicts the least recently used keys first.
+// A nil stateCache caches nothing
+type stateCache struct {
+	size       int
+	lock       sync.Mutex
+	entries    map[statedb.CompositeKey]*list.Element
+	lru        *list.List
+	generation uint64
+}
+
+// newStateCache returns a stateCache which holds up to the given number of keys,
+// or nil if the size isn't positive
+func newStateCache(size int) *stateCache {
+	if size <= 0 {
+		return nil
+	}
+	return &stateCache{
+		size:    size,
+		entries: make(map[statedb.CompositeKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// get returns the cached document of the given key, or false if it isn't cached
+func (c *stateCache) get(key statedb.CompositeKey) (*cachedDoc, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	return element.Value.(*cacheEntry).doc, true
+}
+
+// currentGeneration returns the generation of the cache,
+// which advances whenever keys are invalidated
+func (c *stateCache) currentGeneration() uint64 {
+	if c == nil {
+		return 0
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.generation
+}
+
+// put caches the document of the given key, which was read from CouchDB while the cache
+// was at the given generation. Documents read before keys were invalidated are discarded,
+// since they may predate the updates the keys were invalidated for
+func (c *stateCache) put(key statedb.CompositeKey, doc *cachedDoc, generation uint64) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	if element, exists := c.entries[key]; exists {
+		element.Value.(*cacheEntry).doc = doc
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, doc: doc})
+	if c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// invalidate evicts the given keys from the cache
+func (c *stateCache) invalidate(keys []statedb.CompositeKey) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.generation++
+	for _, key := range keys {
+		if element, exists := c.entries[key]; exists {
+			c.lru.Remove(element)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// clear evicts all the keys from the cache
+func (c *stateCache) clear() {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.generation++
+	c.entries = make(map[statedb.CompositeKey]*list.Element)
+	c.lru.Init()
+}
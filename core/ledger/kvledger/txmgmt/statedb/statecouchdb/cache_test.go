@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateCacheDisabled(t *testing.T) {
+	cache := newStateCache(0)
+	assert.Nil(t, cache)
+
+	key := statedb.CompositeKey{Namespace: "ns", Key: "key1"}
+	cache.put(key, &cachedDoc{hasValue: true}, cache.currentGeneration())
+	_, cached := cache.get(key)
+	assert.False(t, cached)
+	cache.invalidate([]statedb.CompositeKey{key})
+	cache.clear()
+}
+
+func TestStateCacheEviction(t *testing.T) {
+	cache := newStateCache(2)
+	key1 := statedb.CompositeKey{Namespace: "ns1", Key: "key"}
+	key2 := statedb.CompositeKey{Namespace: "ns2", Key: "key"}
+	key3 := statedb.CompositeKey{Namespace: "ns3", Key: "key"}
+	doc1 := &cachedDoc{value: []byte("value1"), version: version.NewHeight(1, 1), revision: "1-a", hasValue: true}
+	doc2 := &cachedDoc{value: []byte("value2"), version: version.NewHeight(1, 2), revision: "1-b", hasValue: true}
+	doc3 := &cachedDoc{version: version.NewHeight(1, 3), revision: "1-c"}
+
+	cache.put(key1, doc1, cache.currentGeneration())
+	cache.put(key2, doc2, cache.currentGeneration())
+
+	// keys of different namespaces are cached separately
+	doc, cached := cache.get(key1)
+	assert.True(t, cached)
+	assert.Equal(t, doc1, doc)
+
+	// key2 is the least recently used key, and is evicted first
+	cache.put(key3, doc3, cache.currentGeneration())
+	_, cached = cache.get(key2)
+	assert.False(t, cached)
+	doc, cached = cache.get(key1)
+	assert.True(t, cached)
+	assert.Equal(t, doc1, doc)
+	doc, cached = cache.get(key3)
+	assert.True(t, cached)
+	assert.Equal(t, doc3, doc)
+
+	// updating a cached key doesn't evict other keys
+	cache.put(key3, doc2, cache.currentGeneration())
+	doc, _ = cache.get(key3)
+	assert.Equal(t, doc2, doc)
+	_, cached = cache.get(key1)
+	assert.True(t, cached)
+}
+
+func TestStateCacheInvalidation(t *testing.T) {
+	cache := newStateCache(10)
+	key1 := statedb.CompositeKey{Namespace: "ns", Key: "key1"}
+	key2 := statedb.CompositeKey{Namespace: "ns", Key: "key2"}
+	doc := &cachedDoc{value: []byte("value"), version: version.NewHeight(1, 1), hasValue: true}
+
+	cache.put(key1, doc, cache.currentGeneration())
+	cache.put(key2, doc, cache.currentGeneration())
+
+	cache.invalidate([]statedb.CompositeKey{key1})
+	_, cached := cache.get(key1)
+	assert.False(t, cached)
+	_, cached = cache.get(key2)
+	assert.True(t, cached)
+
+	// documents read before keys were invalidated aren't cached
+	generation := cache.currentGeneration()
+	cache.invalidate([]statedb.CompositeKey{key2})
+	cache.put(key1, doc, generation)
+	_, cached = cache.get(key1)
+	assert.False(t, cached)
+
+	cache.put(key1, doc, cache.currentGeneration())
+	_, cached = cache.get(key1)
+	assert.True(t, cached)
+}
+
+func TestStateCacheClear(t *testing.T) {
+	cache := newStateCache(10)
+	key := statedb.CompositeKey{Namespace: "ns", Key: "key"}
+	doc := &cachedDoc{value: []byte("value"), version: version.NewHeight(1, 1), hasValue: true}
+
+	cache.put(key, doc, cache.currentGeneration())
+	generation := cache.currentGeneration()
+	cache.clear()
+	_, cached := cache.get(key)
+	assert.False(t, cached)
+
+	// documents read before the cache was cleared aren't cached
+	cache.put(key, doc, generation)
+	_, cached = cache.get(key)
+	assert.False(t, cached)
+}
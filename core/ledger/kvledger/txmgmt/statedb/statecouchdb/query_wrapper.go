@@ -17,10 +17,11 @@ limitations under the License.
 package statecouchdb
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"reflect"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/richquery"
 )
 
 const dataWrapper = "data"
@@ -30,10 +31,6 @@ const jsonQueryUseIndex = "use_index"
 const jsonQueryLimit = "limit"
 const jsonQuerySkip = "skip"
 
-var validOperators = []string{"$and", "$or", "$not", "$nor", "$all", "$elemMatch",
-	"$lt", "$lte", "$eq", "$ne", "$gte", "$gt", "$exits", "$type", "$in", "$nin",
-	"$size", "$mod", "$regex"}
-
 /*
 ApplyQueryWrapper parses the query string passed to CouchDB
 the wrapper prepends the wrapper "data." to all fields specified in the query
@@ -63,13 +60,8 @@ Result Wrapped Query:
 */
 func ApplyQueryWrapper(namespace, queryString string, queryLimit, querySkip int) (string, error) {
 
-	//create a generic map for the query json
-	jsonQueryMap := make(map[string]interface{})
-
-	//unmarshal the selected json into the generic map
-	decoder := json.NewDecoder(bytes.NewBuffer([]byte(queryString)))
-	decoder.UseNumber()
-	err := decoder.Decode(&jsonQueryMap)
+	//unmarshal the selected json into a generic map, the same way the query is parsed by other state databases
+	jsonQueryMap, err := richquery.Decode(queryString)
 	if err != nil {
 		return "", err
 	}
@@ -226,7 +218,7 @@ func processInterfaceMap(jsonFragment map[string]interface{}) {
 	for keyVal, itemVal := range bufferFragment {
 
 		//check to see if the key is an operator
-		if arrayContains(validOperators, keyVal) {
+		if richquery.IsOperator(keyVal) {
 
 			//if this is an operator, traverse the next level of the json query
 			processAndWrapQuery(jsonFragment)
@@ -251,14 +243,3 @@ func wrapFieldName(jsonFragment map[string]interface{}, key string, value interf
 	jsonFragment[fmt.Sprintf("%v.%v", dataWrapper, key)] = value
 
 }
-
-//arrayContains is a function to detect if a source array of strings contains the selected string
-//for this application, it is used to determine if a string is a valid CouchDB operator
-func arrayContains(sourceArray []string, selectItem string) bool {
-	set := make(map[string]struct{}, len(sourceArray))
-	for _, s := range sourceArray {
-		set[s] = struct{}{}
-	}
-	_, ok := set[selectItem]
-	return ok
-}
@@ -91,6 +91,7 @@ type VersionedDB struct {
 	db                 *couchdb.CouchDatabase
 	dbName             string
 	committedDataCache *CommittedVersions // Used as a local cache during bulk processing of a block.
+	stateCache         *stateCache        // Caches the committed state across blocks, nil if disabled.
 }
 
 // newVersionedDB constructs an instance of VersionedDB
@@ -105,7 +106,7 @@ func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string) (*Versi
 
 	committedDataCache := &CommittedVersions{committedVersions: versionMap, revisionNumbers: revMap}
 
-	return &VersionedDB{db, dbName, committedDataCache}, nil
+	return &VersionedDB{db, dbName, committedDataCache, newStateCache(ledgerconfig.GetStateCacheSize())}, nil
 }
 
 // Open implements method in VersionedDB interface
@@ -136,19 +137,30 @@ func (vdb *VersionedDB) BytesKeySuppoted() bool {
 func (vdb *VersionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
 	logger.Debugf("GetState(). ns=%s, key=%s", namespace, key)
 
+	cacheKey := statedb.CompositeKey{Namespace: namespace, Key: key}
+	if doc, cached := vdb.stateCache.get(cacheKey); cached && doc.hasValue {
+		if doc.version == nil {
+			return nil, nil
+		}
+		return &statedb.VersionedValue{Value: doc.value, Version: doc.version}, nil
+	}
+	generation := vdb.stateCache.currentGeneration()
+
 	compositeKey := constructCompositeKey(namespace, key)
 
-	couchDoc, _, err := vdb.db.ReadDoc(string(compositeKey))
+	couchDoc, revision, err := vdb.db.ReadDoc(string(compositeKey))
 	if err != nil {
 		return nil, err
 	}
 	if couchDoc == nil {
+		vdb.stateCache.put(cacheKey, &cachedDoc{hasValue: true}, generation)
 		return nil, nil
 	}
 
 	// remove the data wrapper and return the value and version
 	returnValue, returnVersion := removeDataWrapper(couchDoc.JSONValue, couchDoc.Attachments)
 
+	vdb.stateCache.put(cacheKey, &cachedDoc{value: returnValue, version: returnVersion, revision: revision, hasValue: true}, generation)
 	return &statedb.VersionedValue{Value: returnValue, Version: returnVersion}, nil
 }
 
@@ -162,15 +174,22 @@ func (vdb *VersionedDB) GetVersion(namespace string, key string) (*version.Heigh
 	// checks during validation should find the version here
 	returnVersion, keyFound := vdb.committedDataCache.committedVersions[compositeKey]
 
-	// If the version was not found in the committed data cache, retrieve it from statedb.
+	// If the version was not found in the committed data cache, retrieve it from
+	// the state cache, or from statedb if it isn't cached either.
 	if !keyFound {
 
+		if doc, cached := vdb.stateCache.get(compositeKey); cached {
+			return doc.version, nil
+		}
+		generation := vdb.stateCache.currentGeneration()
+
 		couchDBCompositeKey := constructCompositeKey(namespace, key)
-		couchDoc, _, err := vdb.db.ReadDoc(string(couchDBCompositeKey))
+		couchDoc, revision, err := vdb.db.ReadDoc(string(couchDBCompositeKey))
 		if err != nil {
 			return nil, err
 		}
 		if couchDoc == nil {
+			vdb.stateCache.put(compositeKey, &cachedDoc{hasValue: true}, generation)
 			return nil, nil
 		}
 
@@ -185,6 +204,7 @@ func (vdb *VersionedDB) GetVersion(namespace string, key string) (*version.Heigh
 			return nil, nil
 		}
 		returnVersion = createVersionHeightFromVersionString(docMetadata.Version)
+		vdb.stateCache.put(compositeKey, &cachedDoc{version: returnVersion, revision: revision}, generation)
 	}
 
 	return returnVersion, nil
@@ -288,6 +308,12 @@ func (vdb *VersionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIt
 	return newQueryScanner(*queryResult), nil
 }
 
+// GetFullScanIterator implements method in FullScannable interface.
+// The documents are read a page of queryLimit documents at a time
+func (vdb *VersionedDB) GetFullScanIterator() (statedb.ResultsIterator, error) {
+	return newFullScanner(vdb.db, ledgerconfig.GetQueryLimit()), nil
+}
+
 // ApplyUpdates implements method in VersionedDB interface
 func (vdb *VersionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
 
@@ -303,10 +329,12 @@ func (vdb *VersionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 	// If the key is missing in the cache, then add the key to missingKeys
 	// A bulk read will then add the missing revisions to the cache
 	namespaces := batch.GetUpdatedNamespaces()
+	var updatedKeys []statedb.CompositeKey
 	for _, ns := range namespaces {
 		nsUpdates := batch.GetUpdates(ns)
 		for k := range nsUpdates {
 			compositeKey := statedb.CompositeKey{Namespace: ns, Key: k}
+			updatedKeys = append(updatedKeys, compositeKey)
 
 			// check the cache to see if the key is missing
 			_, keyFound := vdb.committedDataCache.revisionNumbers[compositeKey]
@@ -319,6 +347,10 @@ func (vdb *VersionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 		}
 	}
 
+	// The cached state of the updated keys becomes stale once they are written to couchdb,
+	// or may already be stale if the write fails partway through
+	defer vdb.stateCache.invalidate(updatedKeys)
+
 	// if there are missing keys, add them to the committed data cache
 	if len(missingKeys) > 0 {
 
@@ -450,7 +482,7 @@ func printCompositeKeys(keys []*statedb.CompositeKey) string {
 }
 
 // LoadCommittedVersions populates committedVersions and revisionNumbers into cache.
-// A bulk retrieve from couchdb is used to populate the cache.
+// A bulk retrieve from couchdb is used to populate the cache, for keys missing in the state cache.
 // committedVersions cache will be used for state validation of readsets
 // revisionNumbers cache will be used during commit phase for couchdb bulk updates
 func (vdb *VersionedDB) LoadCommittedVersions(keys []*statedb.CompositeKey) {
@@ -459,15 +491,25 @@ func (vdb *VersionedDB) LoadCommittedVersions(keys []*statedb.CompositeKey) {
 	versionMap := vdb.committedDataCache.committedVersions
 	revMap := vdb.committedDataCache.revisionNumbers
 
+	generation := vdb.stateCache.currentGeneration()
 	keysToRetrieve := []string{}
+	retrievedKeys := []statedb.CompositeKey{}
 	for _, key := range keys {
 
+		compositeKey := statedb.CompositeKey{Namespace: key.Namespace, Key: key.Key}
+
+		// take the version and revision from the state cache if the key is cached
+		if doc, cached := vdb.stateCache.get(compositeKey); cached {
+			versionMap[compositeKey] = doc.version
+			revMap[compositeKey] = doc.revision
+			continue
+		}
+
 		// create composite key for couchdb
 		compositeDBKey := constructCompositeKey(key.Namespace, key.Key)
 		// add the composite key to the list of required keys
 		keysToRetrieve = append(keysToRetrieve, string(compositeDBKey))
-
-		compositeKey := statedb.CompositeKey{Namespace: key.Namespace, Key: key.Key}
+		retrievedKeys = append(retrievedKeys, compositeKey)
 
 		// initialize empty values for each key (revision numbers will not be in couchdb for new creates)
 		versionMap[compositeKey] = nil
@@ -475,7 +517,11 @@ func (vdb *VersionedDB) LoadCommittedVersions(keys []*statedb.CompositeKey) {
 
 	}
 
-	documentMetadataArray, _ := vdb.db.BatchRetrieveDocumentMetadata(keysToRetrieve)
+	if len(keysToRetrieve) == 0 {
+		return
+	}
+
+	documentMetadataArray, err := vdb.db.BatchRetrieveDocumentMetadata(keysToRetrieve)
 
 	for _, documentMetadata := range documentMetadataArray {
 
@@ -487,6 +533,15 @@ func (vdb *VersionedDB) LoadCommittedVersions(keys []*statedb.CompositeKey) {
 			revMap[compositeKey] = documentMetadata.Rev
 		}
 	}
+
+	// keys missing in the response don't exist in couchdb, unless the bulk retrieve failed
+	if err != nil {
+		return
+	}
+	for _, compositeKey := range retrievedKeys {
+		committedVersion := versionMap[compositeKey]
+		vdb.stateCache.put(compositeKey, &cachedDoc{version: committedVersion, revision: revMap[compositeKey], hasValue: committedVersion == nil}, generation)
+	}
 }
 
 func createVersionHeightFromVersionString(encodedVersion string) *version.Height {
@@ -613,6 +668,19 @@ func (vdb *VersionedDB) recordSavepoint(height *version.Height) error {
 	return nil
 }
 
+// Drop implements method in Droppable interface.
+// The CouchDB database is deleted along with its documents and indexes, and is created again empty
+func (vdb *VersionedDB) Drop() error {
+	logger.Infof("Channel [%s]: Dropping the state database", vdb.dbName)
+	if _, err := vdb.db.DropDatabase(); err != nil {
+		return err
+	}
+	vdb.ClearCachedVersions()
+	vdb.stateCache.clear()
+	_, err := vdb.db.CreateDatabaseIfNotExist()
+	return err
+}
+
 // GetLatestSavePoint implements method in VersionedDB interface
 func (vdb *VersionedDB) GetLatestSavePoint() (*version.Height, error) {
 
@@ -716,3 +784,69 @@ func (scanner *queryScanner) Next() (statedb.QueryResult, error) {
 func (scanner *queryScanner) Close() {
 	scanner = nil
 }
+
+// fullScanner iterates over the documents of all the namespaces, in the order of their ids,
+// skipping the savepoint and the design documents. The documents of the channel are read
+// a page at a time, each page starting right after the last document of the previous one
+type fullScanner struct {
+	db       *couchdb.CouchDatabase
+	pageSize int
+	startKey string
+	cursor   int
+	results  []couchdb.QueryResult
+	lastPage bool
+}
+
+func newFullScanner(db *couchdb.CouchDatabase, pageSize int) *fullScanner {
+	return &fullScanner{db: db, pageSize: pageSize}
+}
+
+func (scanner *fullScanner) Next() (statedb.QueryResult, error) {
+	for {
+		if scanner.cursor >= len(scanner.results) {
+			if scanner.lastPage {
+				return nil, nil
+			}
+			if err := scanner.readPage(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		selectedKV := scanner.results[scanner.cursor]
+		scanner.cursor++
+		if !bytes.Contains([]byte(selectedKV.ID), compositeKeySep) {
+			continue
+		}
+
+		namespace, key := splitCompositeKey([]byte(selectedKV.ID))
+
+		// remove the data wrapper and return the value and version
+		returnValue, returnVersion := removeDataWrapper(selectedKV.Value, selectedKV.Attachments)
+
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: namespace, Key: key},
+			VersionedValue: statedb.VersionedValue{Value: returnValue, Version: returnVersion}}, nil
+	}
+}
+
+// readPage reads the next page of documents
+func (scanner *fullScanner) readPage() error {
+	queryResult, err := scanner.db.ReadDocRange(scanner.startKey, "", scanner.pageSize, querySkip)
+	if err != nil {
+		logger.Debugf("Error calling ReadDocRange(): %s\n", err.Error())
+		return err
+	}
+	scanner.results, scanner.cursor = *queryResult, 0
+	if len(scanner.results) == 0 || len(scanner.results) < scanner.pageSize {
+		scanner.lastPage = true
+		return nil
+	}
+	// the smallest id after the last one read, since start keys are inclusive
+	scanner.startKey = scanner.results[len(scanner.results)-1].ID + "\x00"
+	return nil
+}
+
+func (scanner *fullScanner) Close() {
+	scanner.results = nil
+}
@@ -160,3 +160,87 @@ func TestGetVersion(t *testing.T) {
 		commontests.TestGetVersion(t, env.DBProvider)
 	}
 }
+
+func TestReadAfterUpdates(t *testing.T) {
+	if ledgerconfig.IsCouchDBEnabled() == true {
+		env := NewTestVDBEnv(t)
+		env.Cleanup("testreadafterupdates")
+		defer env.Cleanup("testreadafterupdates")
+		commontests.TestReadAfterUpdates(t, env.DBProvider)
+	}
+}
+
+func TestReadAfterUpdatesWithoutCache(t *testing.T) {
+	if ledgerconfig.IsCouchDBEnabled() == true {
+		viper.Set("ledger.state.couchDBConfig.cacheSize", 0)
+		defer viper.Set("ledger.state.couchDBConfig.cacheSize", 10000)
+		env := NewTestVDBEnv(t)
+		env.Cleanup("testreadafterupdates")
+		defer env.Cleanup("testreadafterupdates")
+		commontests.TestReadAfterUpdates(t, env.DBProvider)
+	}
+}
+
+func TestFullScanIterator(t *testing.T) {
+	if ledgerconfig.IsCouchDBEnabled() == true {
+		// pages of 2 documents
+		viper.Set("ledger.state.couchDBConfig.queryLimit", 2)
+		defer viper.Set("ledger.state.couchDBConfig.queryLimit", 10000)
+		env := NewTestVDBEnv(t)
+		env.Cleanup("testfullscan")
+		defer env.Cleanup("testfullscan")
+		db, err := env.DBProvider.GetDBHandle("testfullscan")
+		testutil.AssertNoError(t, err, "")
+
+		batch := statedb.NewUpdateBatch()
+		batch.Put("ns2", "key1", []byte(`{"asset_name":"marble1"}`), version.NewHeight(1, 4))
+		batch.Put("ns1", "key3", []byte("value3"), version.NewHeight(1, 3))
+		batch.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 2))
+		batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+		testutil.AssertNoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 4)), "")
+
+		itr, err := db.(statedb.FullScannable).GetFullScanIterator()
+		testutil.AssertNoError(t, err, "")
+		defer itr.Close()
+		var results []*statedb.VersionedKV
+		for {
+			res, err := itr.Next()
+			testutil.AssertNoError(t, err, "")
+			if res == nil {
+				break
+			}
+			results = append(results, res.(*statedb.VersionedKV))
+		}
+		// The savepoint is skipped, and the results are ordered by namespace and key across the pages
+		testutil.AssertEquals(t, results, []*statedb.VersionedKV{
+			{CompositeKey: statedb.CompositeKey{Namespace: "ns1", Key: "key1"},
+				VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}},
+			{CompositeKey: statedb.CompositeKey{Namespace: "ns1", Key: "key2"},
+				VersionedValue: statedb.VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 2)}},
+			{CompositeKey: statedb.CompositeKey{Namespace: "ns1", Key: "key3"},
+				VersionedValue: statedb.VersionedValue{Value: []byte("value3"), Version: version.NewHeight(1, 3)}},
+			{CompositeKey: statedb.CompositeKey{Namespace: "ns2", Key: "key1"},
+				VersionedValue: statedb.VersionedValue{Value: []byte(`{"asset_name":"marble1"}`), Version: version.NewHeight(1, 4)}},
+		})
+	}
+}
+
+func BenchmarkGetStateWithCache(b *testing.B) {
+	if ledgerconfig.IsCouchDBEnabled() == true {
+		env := NewTestVDBEnv(b)
+		env.Cleanup("benchmarkgetstate")
+		defer env.Cleanup("benchmarkgetstate")
+		commontests.BenchmarkGetState(b, env.DBProvider)
+	}
+}
+
+func BenchmarkGetStateWithoutCache(b *testing.B) {
+	if ledgerconfig.IsCouchDBEnabled() == true {
+		viper.Set("ledger.state.couchDBConfig.cacheSize", 0)
+		defer viper.Set("ledger.state.couchDBConfig.cacheSize", 10000)
+		env := NewTestVDBEnv(b)
+		env.Cleanup("benchmarkgetstate")
+		defer env.Cleanup("benchmarkgetstate")
+		commontests.BenchmarkGetState(b, env.DBProvider)
+	}
+}
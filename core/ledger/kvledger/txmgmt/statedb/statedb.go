@@ -61,6 +61,30 @@ type BulkOptimizable interface {
 	ClearCachedVersions()
 }
 
+//FullScannable interface provides an additional function for
+//databases capable of iterating over the keys of all the namespaces
+type FullScannable interface {
+	// GetFullScanIterator returns an iterator over all the key-values of the db, ordered by namespace and key.
+	// The returned ResultsIterator contains results of type *VersionedKV
+	GetFullScanIterator() (ResultsIterator, error)
+}
+
+//Droppable interface provides an additional function for
+//databases capable of removing all their data
+type Droppable interface {
+	// Drop removes all the key-values and the savepoint of the db, which can be used afterwards as an empty db
+	Drop() error
+}
+
+//IndexCapable interface provides an additional function for
+//databases capable of maintaining indexes on the fields of JSON values
+type IndexCapable interface {
+	// CreateIndex creates the index of the given namespace which is described by
+	// the given index definition, in the format of the CouchDB index definitions,
+	// or replaces the index of the namespace with the same name
+	CreateIndex(namespace string, indexDefinition []byte) error
+}
+
 // CompositeKey encloses Namespace and Key components
 type CompositeKey struct {
 	Namespace string
@@ -0,0 +1,246 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/richquery"
+)
+
+// The definitions and the entries of the indexes are stored under keys prefixed
+// by the save point key, so that they don't collide with the keys of the namespaces
+var indexDefinitionKeyPrefix = append(append([]byte{}, savePointKey...), 'd')
+var indexEntryKeyPrefix = append(append([]byte{}, savePointKey...), 'i')
+
+// index is a secondary index of the JSON values of a namespace on the first field of its definition,
+// which is the field a CouchDB index can be used for on its own. The entries of the index map
+// the encoded value of the field, followed by the key of the value, to the key of the value
+type index struct {
+	name  string
+	field string
+}
+
+// indexDefinition is the definition of an index, in the format of the CouchDB index definitions
+type indexDefinition struct {
+	Index struct {
+		Fields []interface{} `json:"fields"`
+	} `json:"index"`
+	Name string `json:"name"`
+}
+
+func parseIndexDefinition(definition []byte) (*index, error) {
+	def := &indexDefinition{}
+	if err := json.Unmarshal(definition, def); err != nil {
+		return nil, fmt.Errorf("invalid index definition: %s", err)
+	}
+	if def.Name == "" {
+		return nil, errors.New("invalid index definition: the index must have a name")
+	}
+	fields, err := richquery.ParseSortFields(def.Index.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fields of index %s: %s", def.Name, err)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("index %s must have a field", def.Name)
+	}
+	return &index{name: def.Name, field: fields[0].Field}, nil
+}
+
+// CreateIndex implements method in IndexCapable interface.
+// The index is built from the values of the namespace, and is maintained as updates are applied
+func (vdb *versionedDB) CreateIndex(namespace string, indexDefinition []byte) error {
+	idx, err := parseIndexDefinition(indexDefinition)
+	if err != nil {
+		return err
+	}
+	logger.Debugf("Channel [%s]: Creating index [%s] of namespace [%s] on field [%s]", vdb.dbName, idx.name, namespace, idx.field)
+
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
+
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	entriesItr := vdb.db.GetIterator(idx.entriesStartKey(namespace), idx.entriesEndKey(namespace))
+	for entriesItr.Next() {
+		dbBatch.Delete(entriesItr.Key())
+	}
+	entriesItr.Release()
+
+	dbItr := vdb.db.GetIterator(constructNamespaceStartKey(namespace), constructNamespaceEndKey(namespace))
+	for dbItr.Next() {
+		_, key := splitCompositeKey(dbItr.Key())
+		value, _ := statedb.DecodeValue(dbItr.Value())
+		if entryKey := idx.entryKey(namespace, key, value); entryKey != nil {
+			dbBatch.Put(entryKey, []byte(key))
+		}
+	}
+	dbItr.Release()
+
+	dbBatch.Put(constructIndexDefinitionKey(namespace, idx.name), indexDefinition)
+	return vdb.db.WriteBatch(dbBatch, true)
+}
+
+// getIndexes returns the indexes of the given namespace, ordered by name
+func (vdb *versionedDB) getIndexes(namespace string) ([]*index, error) {
+	startKey := constructIndexDefinitionKey(namespace, "")
+	endKey := withSuffix(startKey[:len(startKey)-1], lastKeyIndicator)
+	dbItr := vdb.db.GetIterator(startKey, endKey)
+	defer dbItr.Release()
+
+	var indexes []*index
+	for dbItr.Next() {
+		idx, err := parseIndexDefinition(dbItr.Value())
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, idx)
+	}
+	return indexes, dbItr.Error()
+}
+
+// getIndexDefinitions returns a batch which writes again the definitions of the indexes of all the namespaces
+func (vdb *versionedDB) getIndexDefinitions() (*leveldbhelper.UpdateBatch, error) {
+	endKey := withSuffix(indexDefinitionKeyPrefix[:len(indexDefinitionKeyPrefix)-1], indexDefinitionKeyPrefix[len(indexDefinitionKeyPrefix)-1]+1)
+	dbItr := vdb.db.GetIterator(indexDefinitionKeyPrefix, endKey)
+	defer dbItr.Release()
+
+	definitions := leveldbhelper.NewUpdateBatch()
+	for dbItr.Next() {
+		definitions.Put(append([]byte{}, dbItr.Key()...), append([]byte{}, dbItr.Value()...))
+	}
+	return definitions, dbItr.Error()
+}
+
+// updateIndexEntries adds to the batch the updates of the index entries of the given key,
+// which are caused by updating the committed value of the key to the given value
+func (vdb *versionedDB) updateIndexEntries(dbBatch *leveldbhelper.UpdateBatch, namespace, key string, value []byte, indexes []*index) error {
+	dbVal, err := vdb.db.Get(constructCompositeKey(namespace, key))
+	if err != nil {
+		return err
+	}
+	var committedValue []byte
+	if dbVal != nil {
+		committedValue, _ = statedb.DecodeValue(dbVal)
+	}
+	for _, idx := range indexes {
+		if entryKey := idx.entryKey(namespace, key, committedValue); entryKey != nil {
+			dbBatch.Delete(entryKey)
+		}
+		if entryKey := idx.entryKey(namespace, key, value); entryKey != nil {
+			dbBatch.Put(entryKey, []byte(key))
+		}
+	}
+	return nil
+}
+
+// lookupRange returns the range of the entries of the index which may refer to values
+// matching the query, or false if the query has no conditions the index can be used for
+func (idx *index) lookupRange(namespace string, query *richquery.Query) ([]byte, []byte, bool) {
+	startKey, endKey := idx.entriesStartKey(namespace), idx.entriesEndKey(namespace)
+	usable := false
+	for _, condition := range query.Conditions(idx.field) {
+		encodedValue := append(idx.entryPrefix(namespace), encodeIndexValue(condition.Value)...)
+		var lowerBound, upperBound []byte
+		switch condition.Operator {
+		case "$eq":
+			lowerBound = withSuffix(encodedValue, compositeKeySep[0])
+			upperBound = withSuffix(encodedValue, lastKeyIndicator)
+		case "$gt", "$gte":
+			lowerBound = encodedValue
+		case "$lt", "$lte":
+			upperBound = withSuffix(encodedValue, lastKeyIndicator)
+		default:
+			continue
+		}
+		usable = true
+		if lowerBound != nil && bytes.Compare(lowerBound, startKey) > 0 {
+			startKey = lowerBound
+		}
+		if upperBound != nil && bytes.Compare(upperBound, endKey) < 0 {
+			endKey = upperBound
+		}
+	}
+	return startKey, endKey, usable
+}
+
+// entryKey returns the key of the index entry of the given key and value,
+// or nil if the value isn't a JSON object with the indexed field
+func (idx *index) entryKey(namespace, key string, value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+	doc, err := richquery.Decode(string(value))
+	if err != nil {
+		return nil
+	}
+	fieldValue, exists := richquery.Lookup(doc, idx.field)
+	if !exists {
+		return nil
+	}
+	entryKey := append(idx.entryPrefix(namespace), encodeIndexValue(fieldValue)...)
+	entryKey = append(entryKey, compositeKeySep...)
+	return append(entryKey, key...)
+}
+
+func (idx *index) entryPrefix(namespace string) []byte {
+	prefix := append([]byte{}, indexEntryKeyPrefix...)
+	prefix = append(append(prefix, namespace...), compositeKeySep...)
+	return append(append(prefix, idx.name...), compositeKeySep...)
+}
+
+func (idx *index) entriesStartKey(namespace string) []byte {
+	return idx.entryPrefix(namespace)
+}
+
+func (idx *index) entriesEndKey(namespace string) []byte {
+	prefix := idx.entryPrefix(namespace)
+	prefix[len(prefix)-1] = lastKeyIndicator
+	return prefix
+}
+
+// encodeIndexValue encodes the value of an indexed field, so that the encoded values
+// are ordered like the values are ordered in the collation order of CouchDB.
+// Arrays and objects are only ordered after the values of the other types
+func encodeIndexValue(value interface{}) []byte {
+	encodedValue := []byte{byte(richquery.CollationRank(value))}
+	switch value := value.(type) {
+	case json.Number, float64:
+		number := richquery.ToFloat64(value)
+		if number == 0 {
+			// -0 and 0 are equal, and are encoded alike
+			number = 0
+		}
+		bits := math.Float64bits(number)
+		if bits>>63 == 0 {
+			bits |= 1 << 63
+		} else {
+			bits = ^bits
+		}
+		encodedNumber := make([]byte, 8)
+		binary.BigEndian.PutUint64(encodedNumber, bits)
+		encodedValue = append(encodedValue, encodedNumber...)
+	case string:
+		encodedValue = append(encodedValue, value...)
+	}
+	return encodedValue
+}
+
+func constructIndexDefinitionKey(namespace, name string) []byte {
+	key := append([]byte{}, indexDefinitionKeyPrefix...)
+	key = append(append(key, namespace...), compositeKeySep...)
+	return append(key, name...)
+}
+
+func withSuffix(key []byte, suffix byte) []byte {
+	return append(append([]byte{}, key...), suffix)
+}
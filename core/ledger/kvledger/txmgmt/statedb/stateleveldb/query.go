@@ -0,0 +1,188 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/richquery"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/pkg/errors"
+)
+
+// queryResult is a value matching a query, along with the JSON document it's decoded to
+type queryResult struct {
+	kv  *statedb.VersionedKV
+	doc map[string]interface{}
+}
+
+// ExecuteQuery implements method in VersionedDB interface.
+// It supports the subset of the CouchDB query language that richquery parses.
+// The JSON values of the namespace are looked up in one of its indexes if the query
+// has conditions on the indexed field, see CreateIndex, and are scanned otherwise.
+// Like in CouchDB, the results are ordered by key unless the query is sorted,
+// and are limited by the query limit of the ledger configuration. Unlike in
+// CouchDB, strings are compared by their bytes, see richquery.Compare, hence
+// a query fails if it sorts matching values by a field which holds strings
+func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
+	parsedQuery, err := richquery.Parse(query)
+	if err != nil {
+		logger.Debugf("Error parsing query [%s]: %s", query, err)
+		return nil, err
+	}
+
+	queryLimit := ledgerconfig.GetQueryLimit()
+	var results []*queryResult
+	collect := func(key string, dbVal []byte) error {
+		dbValCopy := make([]byte, len(dbVal))
+		copy(dbValCopy, dbVal)
+		value, version := statedb.DecodeValue(dbValCopy)
+		doc, err := richquery.Decode(string(value))
+		if err != nil || !parsedQuery.Matches(doc) {
+			return nil
+		}
+		if err := parsedQuery.CheckSort(doc); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error sorting the value of key [%s]", key))
+		}
+		results = append(results, &queryResult{
+			kv: &statedb.VersionedKV{
+				CompositeKey:   statedb.CompositeKey{Namespace: namespace, Key: key},
+				VersionedValue: statedb.VersionedValue{Value: value, Version: version}},
+			doc: doc,
+		})
+		return nil
+	}
+	indexed, err := vdb.lookupIndexes(namespace, parsedQuery, collect)
+	if err != nil {
+		return nil, err
+	}
+	if !indexed {
+		// the namespace is scanned in the order of the keys, hence the scan
+		// of an unsorted query stops once the query limit is reached
+		scanned := collect
+		if len(parsedQuery.Sort) == 0 {
+			scanned = func(key string, dbVal []byte) error {
+				if err := collect(key, dbVal); err != nil {
+					return err
+				}
+				if len(results) == queryLimit {
+					return errScanComplete
+				}
+				return nil
+			}
+		}
+		if err := vdb.scanNamespace(namespace, scanned); err != nil && err != errScanComplete {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if parsedQuery.Less(results[i].doc, results[j].doc) {
+			return true
+		}
+		if parsedQuery.Less(results[j].doc, results[i].doc) {
+			return false
+		}
+		return results[i].kv.Key < results[j].kv.Key
+	})
+	if len(results) > queryLimit {
+		results = results[:queryLimit]
+	}
+
+	kvs := make([]*statedb.VersionedKV, len(results))
+	for i, result := range results {
+		if len(parsedQuery.Fields) > 0 {
+			projectedValue, err := json.Marshal(parsedQuery.Project(result.doc))
+			if err != nil {
+				return nil, err
+			}
+			result.kv.Value = projectedValue
+		}
+		kvs[i] = result.kv
+	}
+	return newQueryScanner(kvs), nil
+}
+
+// errScanComplete stops the scan of a namespace once all the values a query needs are scanned
+var errScanComplete = errors.New("scan complete")
+
+// lookupIndexes passes to the given function the keys and the values of the namespace which
+// may match the query, using the first index that the query has conditions for, and returns
+// false if there's no such index, in which case the namespace needs to be scanned
+func (vdb *versionedDB) lookupIndexes(namespace string, query *richquery.Query, f func(key string, dbVal []byte) error) (bool, error) {
+	indexes, err := vdb.getIndexes(namespace)
+	if err != nil {
+		return false, err
+	}
+	for _, idx := range indexes {
+		startKey, endKey, usable := idx.lookupRange(namespace, query)
+		if !usable {
+			continue
+		}
+		logger.Debugf("Channel [%s]: Querying namespace [%s] using index [%s]", vdb.dbName, namespace, idx.name)
+		entriesItr := vdb.db.GetIterator(startKey, endKey)
+		defer entriesItr.Release()
+		for entriesItr.Next() {
+			key := string(entriesItr.Value())
+			dbVal, err := vdb.db.Get(constructCompositeKey(namespace, key))
+			if err != nil {
+				return false, err
+			}
+			if dbVal == nil {
+				continue
+			}
+			if err := f(key, dbVal); err != nil {
+				return false, err
+			}
+		}
+		if err := entriesItr.Error(); err != nil {
+			return false, errors.Wrapf(err, "error looking up index [%s] of namespace [%s]", idx.name, namespace)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// scanNamespace passes to the given function the keys and the values of the namespace,
+// and stops at the first error of the function or of the iterator
+func (vdb *versionedDB) scanNamespace(namespace string, f func(key string, dbVal []byte) error) error {
+	dbItr := vdb.db.GetIterator(constructNamespaceStartKey(namespace), constructNamespaceEndKey(namespace))
+	defer dbItr.Release()
+	for dbItr.Next() {
+		_, key := splitCompositeKey(dbItr.Key())
+		if err := f(key, dbItr.Value()); err != nil {
+			return err
+		}
+	}
+	if err := dbItr.Error(); err != nil {
+		return errors.Wrapf(err, "error scanning namespace [%s]", namespace)
+	}
+	return nil
+}
+
+type queryScanner struct {
+	cursor  int
+	results []*statedb.VersionedKV
+}
+
+func newQueryScanner(results []*statedb.VersionedKV) *queryScanner {
+	return &queryScanner{-1, results}
+}
+
+func (scanner *queryScanner) Next() (statedb.QueryResult, error) {
+	scanner.cursor++
+	if scanner.cursor >= len(scanner.results) {
+		return nil, nil
+	}
+	return scanner.results[scanner.cursor], nil
+}
+
+func (scanner *queryScanner) Close() {
+	scanner.results = nil
+}
@@ -7,7 +7,7 @@ package stateleveldb
 
 import (
 	"bytes"
-	"errors"
+	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -36,6 +36,14 @@ func NewVersionedDBProvider() *VersionedDBProvider {
 	return &VersionedDBProvider{dbProvider}
 }
 
+// NewReadOnlyVersionedDBProvider instantiates VersionedDBProvider on the existing state db at the given path,
+// which is opened read-only. The updates to the state fail
+func NewReadOnlyVersionedDBProvider(dbPath string) *VersionedDBProvider {
+	logger.Debugf("constructing read-only VersionedDBProvider dbPath=%s", dbPath)
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath, ReadOnly: true})
+	return &VersionedDBProvider{dbProvider}
+}
+
 // GetDBHandle gets the handle to a named database
 func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
 	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
@@ -50,11 +58,13 @@ func (provider *VersionedDBProvider) Close() {
 type versionedDB struct {
 	db     *leveldbhelper.DBHandle
 	dbName string
+	// indexLock serializes the creation of indexes with the updates of the indexed values
+	indexLock sync.Mutex
 }
 
 // newVersionedDB constructs an instance of VersionedDB
 func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) *versionedDB {
-	return &versionedDB{db, dbName}
+	return &versionedDB{db: db, dbName: dbName}
 }
 
 // Open implements method in VersionedDB interface
@@ -131,20 +141,33 @@ func (vdb *versionedDB) GetStateRangeScanIterator(namespace string, startKey str
 	return newKVScanner(namespace, dbItr), nil
 }
 
-// ExecuteQuery implements method in VersionedDB interface
-func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
-	return nil, errors.New("ExecuteQuery not supported for leveldb")
+// GetFullScanIterator implements method in FullScannable interface
+func (vdb *versionedDB) GetFullScanIterator() (statedb.ResultsIterator, error) {
+	dbItr := vdb.db.GetIterator(nil, nil)
+	return newFullScanner(dbItr), nil
 }
 
 // ApplyUpdates implements method in VersionedDB interface
 func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
+
 	dbBatch := leveldbhelper.NewUpdateBatch()
 	namespaces := batch.GetUpdatedNamespaces()
 	for _, ns := range namespaces {
+		indexes, err := vdb.getIndexes(ns)
+		if err != nil {
+			return err
+		}
 		updates := batch.GetUpdates(ns)
 		for k, vv := range updates {
 			compositeKey := constructCompositeKey(ns, k)
 			logger.Debugf("Channel [%s]: Applying key(string)=[%s] key(bytes)=[%#v]", vdb.dbName, string(compositeKey), compositeKey)
+			if len(indexes) > 0 {
+				if err := vdb.updateIndexEntries(dbBatch, ns, k, vv.Value, indexes); err != nil {
+					return err
+				}
+			}
 
 			if vv.Value == nil {
 				dbBatch.Delete(compositeKey)
@@ -160,6 +183,25 @@ func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 	return nil
 }
 
+// Drop implements method in Droppable interface.
+// The definitions of the indexes are kept, so that the indexes
+// are built again as the values are applied to the empty db
+func (vdb *versionedDB) Drop() error {
+	logger.Infof("Channel [%s]: Dropping the state database", vdb.dbName)
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
+
+	definitions, err := vdb.getIndexDefinitions()
+	if err != nil {
+		return err
+	}
+
+	if err := vdb.db.DeleteAll(); err != nil {
+		return err
+	}
+	return vdb.db.WriteBatch(definitions, true)
+}
+
 // GetLatestSavePoint implements method in VersionedDB interface
 func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
 	versionBytes, err := vdb.db.Get(savePointKey)
@@ -177,6 +219,16 @@ func constructCompositeKey(ns string, key string) []byte {
 	return append(append([]byte(ns), compositeKeySep...), []byte(key)...)
 }
 
+func constructNamespaceStartKey(ns string) []byte {
+	return constructCompositeKey(ns, "")
+}
+
+func constructNamespaceEndKey(ns string) []byte {
+	endKey := constructCompositeKey(ns, "")
+	endKey[len(endKey)-1] = lastKeyIndicator
+	return endKey
+}
+
 func splitCompositeKey(compositeKey []byte) (string, string) {
 	split := bytes.SplitN(compositeKey, compositeKeySep, 2)
 	return string(split[0]), string(split[1])
@@ -209,3 +261,34 @@ func (scanner *kvScanner) Next() (statedb.QueryResult, error) {
 func (scanner *kvScanner) Close() {
 	scanner.dbItr.Release()
 }
+
+type fullScanner struct {
+	dbItr iterator.Iterator
+}
+
+func newFullScanner(dbItr iterator.Iterator) *fullScanner {
+	return &fullScanner{dbItr}
+}
+
+func (scanner *fullScanner) Next() (statedb.QueryResult, error) {
+	for scanner.dbItr.Next() {
+		dbKey := scanner.dbItr.Key()
+		// skip the save point, and the indexes which are stored under keys prefixed by it
+		if bytes.HasPrefix(dbKey, savePointKey) {
+			continue
+		}
+		dbVal := scanner.dbItr.Value()
+		dbValCopy := make([]byte, len(dbVal))
+		copy(dbValCopy, dbVal)
+		namespace, key := splitCompositeKey(dbKey)
+		value, version := statedb.DecodeValue(dbValCopy)
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: namespace, Key: key},
+			VersionedValue: statedb.VersionedValue{Value: value, Version: version}}, nil
+	}
+	return nil, nil
+}
+
+func (scanner *fullScanner) Close() {
+	scanner.dbItr.Release()
+}
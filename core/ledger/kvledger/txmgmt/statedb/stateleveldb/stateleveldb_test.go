@@ -82,26 +82,130 @@ func testCompositeKey(t *testing.T, dbName string, ns string, key string) {
 }
 
 // TestQueryOnLevelDB tests queries on levelDB.
-func TestQueryOnLevelDB(t *testing.T) {
+func TestQuery(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
-	db, err := env.DBProvider.GetDBHandle("testquery")
+	commontests.TestQuery(t, env.DBProvider)
+}
+
+func TestQueryWithIndex(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testquerywithindex")
+	testutil.AssertNoError(t, err, "")
+	indexCapableDB := db.(statedb.IndexCapable)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"asset_name":"marble1","color":"blue","size":1,"owner":"tom"}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"asset_name":"marble2","color":"red","size":2,"owner":"jerry"}`), version.NewHeight(1, 2))
+	batch.Put("ns1", "key3", []byte(`{"asset_name":"marble3","color":"blue","size":-3,"owner":"fred"}`), version.NewHeight(1, 3))
+	batch.Put("ns1", "key4", []byte("not a JSON value"), version.NewHeight(1, 4))
+	batch.Put("ns2", "key1", []byte(`{"asset_name":"marble1","color":"blue","size":1,"owner":"tom"}`), version.NewHeight(1, 5))
+	db.ApplyUpdates(batch, version.NewHeight(1, 5))
+
+	// indexes are built from the committed values
+	testutil.AssertNoError(t, indexCapableDB.CreateIndex("ns1", []byte(`{"index":{"fields":["size"]},"name":"indexSize","type":"json"}`)), "")
+	testutil.AssertNoError(t, indexCapableDB.CreateIndex("ns1", []byte(`{"index":{"fields":[{"color":"asc"},"size"]},"name":"indexColor"}`)), "")
+	testQueryResults(t, db, "ns1", `{"selector":{"size":{"$gt":0}}}`, "key1", "key2")
+	testQueryResults(t, db, "ns1", `{"selector":{"size":{"$lte":1}},"sort":["size"]}`, "key3", "key1")
+	testQueryResults(t, db, "ns1", `{"selector":{"color":"blue","size":{"$lt":5}},"sort":[{"size":"desc"}]}`, "key1", "key3")
+	testQueryResults(t, db, "ns1", `{"selector":{"color":{"$ne":"blue"}}}`, "key2")
+	testQueryResults(t, db, "ns1", `{"selector":{"color":"green"}}`)
+	testQueryResults(t, db, "ns2", `{"selector":{"color":"blue"}}`, "key1")
+
+	// indexes are maintained as values are updated and deleted
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"asset_name":"marble1","color":"green","size":10,"owner":"tom"}`), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	batch.Put("ns1", "key5", []byte(`{"asset_name":"marble5","color":"blue","size":5,"owner":"jerry"}`), version.NewHeight(2, 3))
+	db.ApplyUpdates(batch, version.NewHeight(2, 3))
+	testQueryResults(t, db, "ns1", `{"selector":{"size":{"$gt":0}}}`, "key1", "key5")
+	testQueryResults(t, db, "ns1", `{"selector":{"color":"blue"}}`, "key3", "key5")
+	testQueryResults(t, db, "ns1", `{"selector":{"color":"green","size":10}}`, "key1")
+
+	// the entries of the indexes aren't part of the state
+	itr, err := db.(statedb.FullScannable).GetFullScanIterator()
+	testutil.AssertNoError(t, err, "")
+	defer itr.Close()
+	var keys []string
+	for {
+		result, err := itr.Next()
+		testutil.AssertNoError(t, err, "")
+		if result == nil {
+			break
+		}
+		kv := result.(*statedb.VersionedKV)
+		keys = append(keys, kv.Namespace+"/"+kv.Key)
+	}
+	testutil.AssertEquals(t, keys, []string{"ns1/key1", "ns1/key3", "ns1/key4", "ns1/key5", "ns2/key1"})
+
+	// the indexes are built again once the dropped values are applied again
+	testutil.AssertNoError(t, db.(statedb.Droppable).Drop(), "")
+	testQueryResults(t, db, "ns1", `{"selector":{"size":{"$gt":0}}}`)
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"asset_name":"marble1","color":"green","size":10,"owner":"tom"}`), version.NewHeight(2, 1))
+	batch.Put("ns1", "key5", []byte(`{"asset_name":"marble5","color":"blue","size":5,"owner":"jerry"}`), version.NewHeight(2, 3))
+	db.ApplyUpdates(batch, version.NewHeight(2, 3))
+	indexes, err := db.(*versionedDB).getIndexes("ns1")
 	testutil.AssertNoError(t, err, "")
-	db.Open()
-	defer db.Close()
+	testutil.AssertEquals(t, len(indexes), 2)
+	testQueryResults(t, db, "ns1", `{"selector":{"size":{"$gt":0}}}`, "key1", "key5")
+	testQueryResults(t, db, "ns1", `{"selector":{"color":"blue"}}`, "key5")
+
+	err = indexCapableDB.CreateIndex("ns1", []byte(`{"index":{"fields":[]},"name":"indexNone"}`))
+	testutil.AssertError(t, err, "indexes without fields should be rejected")
+	err = indexCapableDB.CreateIndex("ns1", []byte(`{"index":{"fields":["size"]}}`))
+	testutil.AssertError(t, err, "indexes without a name should be rejected")
+}
+
+func TestQueryLimitAndFields(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	defer viper.Set("ledger.state.couchDBConfig.queryLimit", viper.GetInt("ledger.state.couchDBConfig.queryLimit"))
+	viper.Set("ledger.state.couchDBConfig.queryLimit", 2)
+	db, err := env.DBProvider.GetDBHandle("testquerylimitandfields")
+	testutil.AssertNoError(t, err, "")
+
 	batch := statedb.NewUpdateBatch()
-	jsonValue1 := "{\"asset_name\": \"marble1\",\"color\": \"blue\",\"size\": 1,\"owner\": \"tom\"}"
-	batch.Put("ns1", "key1", []byte(jsonValue1), version.NewHeight(1, 1))
+	batch.Put("ns1", "key1", []byte(`{"asset_name":"marble1","color":"blue","size":3}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"asset_name":"marble2","color":"blue","size":1}`), version.NewHeight(1, 2))
+	batch.Put("ns1", "key3", []byte(`{"asset_name":"marble3","color":"blue","size":2}`), version.NewHeight(1, 3))
+	db.ApplyUpdates(batch, version.NewHeight(1, 3))
+
+	testQueryResults(t, db, "ns1", `{"selector":{"color":"blue"}}`, "key1", "key2")
+	testQueryResults(t, db, "ns1", `{"selector":{"color":"blue"},"sort":["size"]}`, "key2", "key3")
 
-	savePoint := version.NewHeight(2, 22)
-	db.ApplyUpdates(batch, savePoint)
+	itr, err := db.ExecuteQuery("ns1", `{"selector":{"size":3},"fields":["asset_name"]}`)
+	testutil.AssertNoError(t, err, "")
+	result, err := itr.Next()
+	testutil.AssertNoError(t, err, "")
+	kv := result.(*statedb.VersionedKV)
+	testutil.AssertEquals(t, string(kv.Value), `{"asset_name":"marble1"}`)
+	testutil.AssertEquals(t, kv.Version, version.NewHeight(1, 1))
 
-	// query for owner=jerry, use namespace "ns1"
-	// As queries are not supported in levelDB, call to ExecuteQuery()
-	// should return a error message
-	itr, err := db.ExecuteQuery("ns1", "{\"selector\":{\"owner\":\"jerry\"}}")
-	testutil.AssertError(t, err, "ExecuteQuery not supported for leveldb")
-	testutil.AssertNil(t, itr)
+	_, err = db.ExecuteQuery("ns1", `{"selector":{"asset_name":{"$regex":"marble"}}}`)
+	testutil.AssertError(t, err, "unsupported operators should be rejected")
+	_, err = db.ExecuteQuery("ns1", `{"selector":{"color":"blue"},"sort":["asset_name"]}`)
+	testutil.AssertError(t, err, "sorts by strings should be rejected")
+}
+
+func testQueryResults(t *testing.T, db statedb.VersionedDB, namespace, query string, expectedKeys ...string) {
+	itr, err := db.ExecuteQuery(namespace, query)
+	testutil.AssertNoError(t, err, "")
+	defer itr.Close()
+	keys := []string{}
+	for {
+		result, err := itr.Next()
+		testutil.AssertNoError(t, err, "")
+		if result == nil {
+			break
+		}
+		keys = append(keys, result.(*statedb.VersionedKV).Key)
+	}
+	if expectedKeys == nil {
+		expectedKeys = []string{}
+	}
+	testutil.AssertEquals(t, keys, expectedKeys)
 }
 
 func TestGetStateMultipleKeys(t *testing.T) {
@@ -115,3 +219,57 @@ func TestGetVersion(t *testing.T) {
 	defer env.Cleanup()
 	commontests.TestGetVersion(t, env.DBProvider)
 }
+
+func TestReadAfterUpdates(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestReadAfterUpdates(t, env.DBProvider)
+}
+
+func BenchmarkGetState(b *testing.B) {
+	env := NewTestVDBEnv(b)
+	defer env.Cleanup()
+	commontests.BenchmarkGetState(b, env.DBProvider)
+}
+
+func TestFullScanIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testfullscan")
+	testutil.AssertNoError(t, err, "")
+	otherDB, err := env.DBProvider.GetDBHandle("testfullscanother")
+	testutil.AssertNoError(t, err, "")
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns2", "key1", []byte("value3"), version.NewHeight(1, 3))
+	batch.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 2))
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	db.ApplyUpdates(batch, version.NewHeight(1, 3))
+
+	// Keys of other dbs sharing the same leveldb are not returned
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "otherkey", []byte("othervalue"), version.NewHeight(1, 1))
+	otherDB.ApplyUpdates(batch, version.NewHeight(1, 1))
+
+	itr, err := db.(statedb.FullScannable).GetFullScanIterator()
+	testutil.AssertNoError(t, err, "")
+	defer itr.Close()
+	var results []*statedb.VersionedKV
+	for {
+		res, err := itr.Next()
+		testutil.AssertNoError(t, err, "")
+		if res == nil {
+			break
+		}
+		results = append(results, res.(*statedb.VersionedKV))
+	}
+	// The savepoint is skipped, and the results are ordered by namespace and key
+	testutil.AssertEquals(t, results, []*statedb.VersionedKV{
+		{CompositeKey: statedb.CompositeKey{Namespace: "ns1", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}},
+		{CompositeKey: statedb.CompositeKey{Namespace: "ns1", Key: "key2"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 2)}},
+		{CompositeKey: statedb.CompositeKey{Namespace: "ns2", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value3"), Version: version.NewHeight(1, 3)}},
+	})
+}
@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lockbasedtxmgr
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+)
+
+// lsccNamespace is the namespace in which lscc records the chaincodes instantiated on the channel,
+// under the names of the chaincodes
+const lsccNamespace = "lscc"
+
+// createChaincodeIndexes creates the indexes of the state database defined by the chaincodes which the
+// given updates instantiate or upgrade, in the ccprovider.IndexDefinitionsPath directory of their code package.
+// The code package is looked up among the chaincodes installed on the peer, hence the indexes of a chaincode
+// are only created if the chaincode is installed before it's instantiated or upgraded on the channel.
+// The updates are committed already, so the failures to create an index are logged rather than returned
+func (txmgr *LockBasedTxMgr) createChaincodeIndexes(updates *privacyenabledstate.PubUpdateBatch) {
+	if !txmgr.db.IsIndexCapable() {
+		return
+	}
+	for name, vv := range updates.GetUpdates(lsccNamespace) {
+		if vv.Value == nil {
+			continue
+		}
+		cd := &ccprovider.ChaincodeData{}
+		if err := proto.Unmarshal(vv.Value, cd); err != nil || cd.Name != name {
+			// the other entries of lscc, such as the collection configurations, don't describe a chaincode
+			continue
+		}
+		ccpack, err := ccprovider.GetChaincodeFromFS(cd.Name, cd.Version)
+		if err != nil {
+			logger.Warningf("Chaincode [%s:%s] isn't installed, the indexes it defines aren't created: %s", cd.Name, cd.Version, err)
+			continue
+		}
+		if !bytes.Equal(ccpack.GetId(), cd.Id) {
+			logger.Warningf("Chaincode [%s:%s] installed on the peer differs from the instantiated chaincode, the indexes it defines aren't created", cd.Name, cd.Version)
+			continue
+		}
+		definitions, err := ccprovider.ExtractIndexDefinitions(ccpack.GetDepSpec().CodePackage)
+		if err != nil {
+			logger.Errorf("Error extracting the index definitions of chaincode [%s:%s]: %s", cd.Name, cd.Version, err)
+			continue
+		}
+		for _, definition := range definitions {
+			logger.Infof("Creating index of chaincode [%s:%s]: %s", cd.Name, cd.Version, definition)
+			if err := txmgr.db.CreateIndex(cd.Name, definition); err != nil {
+				logger.Errorf("Error creating index of chaincode [%s:%s]: %s", cd.Name, cd.Version, err)
+			}
+		}
+	}
+}
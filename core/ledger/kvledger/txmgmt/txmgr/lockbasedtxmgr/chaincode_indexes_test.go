@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lockbasedtxmgr
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// indexRecordingDB records the indexes created on the state database
+type indexRecordingDB struct {
+	privacyenabledstate.DB
+	indexes map[string][]string
+}
+
+func (db *indexRecordingDB) CreateIndex(namespace string, indexDefinition []byte) error {
+	db.indexes[namespace] = append(db.indexes[namespace], string(indexDefinition))
+	return db.DB.CreateIndex(namespace, indexDefinition)
+}
+
+func TestCreateChaincodeIndexes(t *testing.T) {
+	env := testEnvs[0]
+	env.init(t, "testcreatechaincodeindexes")
+	defer env.cleanup()
+	db := &indexRecordingDB{DB: env.getVDB(), indexes: make(map[string][]string)}
+	txMgr := NewLockBasedTxMgr(db)
+	txMgrHelper := newTxMgrTestHelper(t, txMgr)
+
+	chaincodesPath, err := ioutil.TempDir("", "chaincodeindexes")
+	testutil.AssertNoError(t, err, "")
+	defer os.RemoveAll(chaincodesPath)
+	ccprovider.SetChaincodesPath(chaincodesPath)
+
+	indexOwner := `{"index":{"fields":["owner"]},"name":"indexOwner","type":"json"}`
+	indexSize := `{"index":{"fields":["size"]},"name":"indexSize","type":"json"}`
+	installChaincode(t, "marbles", "1.0", map[string]string{
+		"src/marbles/marbles.go":                              "package main",
+		"META-INF/statedb/couchdb/indexes/indexOwner.json":    indexOwner,
+		"META-INF/statedb/couchdb/indexes/indexSize.json":     indexSize,
+		"META-INF/statedb/couchdb/indexes/indexes-readme.txt": "the indexes of marbles",
+	})
+	installChaincode(t, "assets", "1.0", map[string]string{
+		"src/assets/assets.go":                             "package main",
+		"META-INF/statedb/couchdb/indexes/indexOwner.json": indexOwner,
+	})
+	marbles, err := ccprovider.GetChaincodeFromFS("marbles", "1.0")
+	testutil.AssertNoError(t, err, "")
+
+	s, _ := txMgr.NewTxSimulator("test_tx1")
+	s.SetState("marbles", "marble1", []byte(`{"asset_name":"marble1","color":"blue","size":1,"owner":"tom"}`))
+	s.SetState("lscc", "marbles", chaincodeData(t, &ccprovider.ChaincodeData{Name: "marbles", Version: "1.0", Id: marbles.GetId()}))
+	// the installed package of assets differs from the instantiated one, and cars isn't installed
+	s.SetState("lscc", "assets", chaincodeData(t, &ccprovider.ChaincodeData{Name: "assets", Version: "1.0", Id: []byte("another package")}))
+	s.SetState("lscc", "cars", chaincodeData(t, &ccprovider.ChaincodeData{Name: "cars", Version: "1.0", Id: []byte("cars package")}))
+	s.SetState("lscc", "marbles~collection", []byte("collection configuration"))
+	s.Done()
+	txRWSet, _ := s.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet.PubSimulationResults)
+
+	testutil.AssertEquals(t, db.indexes, map[string][]string{"marbles": {indexOwner, indexSize}})
+
+	qe, _ := txMgr.NewQueryExecutor("test_tx2")
+	defer qe.Done()
+	itr, err := qe.ExecuteQuery("marbles", `{"selector":{"owner":"tom"}}`)
+	testutil.AssertNoError(t, err, "")
+	defer itr.Close()
+	result, err := itr.Next()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNotNil(t, result)
+}
+
+func installChaincode(t *testing.T, name, version string, files map[string]string) {
+	codePackage := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(codePackage)
+	tw := tar.NewWriter(gw)
+	for fileName, content := range files {
+		testutil.AssertNoError(t, tw.WriteHeader(&tar.Header{Name: fileName, Size: int64(len(content)), Mode: 0100644}), "")
+		_, err := tw.Write([]byte(content))
+		testutil.AssertNoError(t, err, "")
+	}
+	tw.Close()
+	gw.Close()
+
+	depSpec := &pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_GOLANG,
+			ChaincodeId: &pb.ChaincodeID{Name: name, Version: version, Path: name},
+		},
+		CodePackage: codePackage.Bytes(),
+	}
+	testutil.AssertNoError(t, ccprovider.PutChaincodeIntoFS(depSpec), "")
+}
+
+func chaincodeData(t *testing.T, cd *ccprovider.ChaincodeData) []byte {
+	cdBytes, err := proto.Marshal(cd)
+	testutil.AssertNoError(t, err, "")
+	return cdBytes
+}
@@ -75,6 +75,13 @@ func (txmgr *LockBasedTxMgr) ValidateAndPrepare(blockAndPvtdata *ledger.BlockAnd
 	return err
 }
 
+// GetPreparedUpdates implements method in interface `txmgmt.TxMgr`
+// It returns the updates prepared by the last call to ValidateAndPrepare, which are applied by Commit,
+// or nil if no updates are prepared
+func (txmgr *LockBasedTxMgr) GetPreparedUpdates() *privacyenabledstate.UpdateBatch {
+	return txmgr.batch
+}
+
 // Shutdown implements method in interface `txmgmt.TxMgr`
 func (txmgr *LockBasedTxMgr) Shutdown() {
 	txmgr.db.Close()
@@ -100,6 +107,7 @@ func (txmgr *LockBasedTxMgr) Commit() error {
 		return err
 	}
 	logger.Debugf("Updates committed to state database")
+	txmgr.createChaincodeIndexes(txmgr.batch.PubUpdates)
 
 	return nil
 }
@@ -529,18 +529,14 @@ func createTestValue(i int) []byte {
 	return []byte(fmt.Sprintf("value_%03d", i))
 }
 
-//TestExecuteQueryQuery is only tested on the CouchDB testEnv
 func TestExecuteQuery(t *testing.T) {
 
 	for _, testEnv := range testEnvs {
-		// Query is only supported and tested on the CouchDB testEnv
-		if testEnv.getName() == couchDBtestEnvName {
-			t.Logf("Running test for TestEnv = %s", testEnv.getName())
-			testLedgerID := "testexecutequery"
-			testEnv.init(t, testLedgerID)
-			testExecuteQuery(t, testEnv)
-			testEnv.cleanup()
-		}
+		t.Logf("Running test for TestEnv = %s", testEnv.getName())
+		testLedgerID := "testexecutequery"
+		testEnv.init(t, testLedgerID)
+		testExecuteQuery(t, testEnv)
+		testEnv.cleanup()
 	}
 }
 
@@ -0,0 +1,142 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebasedval
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valinternal"
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// blockWrites collects the keys written by transactions of a block, valid or not, so as to tell whether
+// a transaction of the block reads any of the keys written by the transactions which precede it
+type blockWrites struct {
+	pubKeys    map[string]map[string]struct{}
+	hashedKeys map[privacyenabledstate.HashedCompositeKey]struct{}
+}
+
+func newBlockWrites() *blockWrites {
+	return &blockWrites{make(map[string]map[string]struct{}), make(map[privacyenabledstate.HashedCompositeKey]struct{})}
+}
+
+// add adds the keys written by the given transaction
+func (w *blockWrites) add(txRWSet *rwsetutil.TxRwSet) {
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		ns := nsRWSet.NameSpace
+		for _, kvWrite := range nsRWSet.KvRwSet.Writes {
+			nsKeys, ok := w.pubKeys[ns]
+			if !ok {
+				nsKeys = make(map[string]struct{})
+				w.pubKeys[ns] = nsKeys
+			}
+			nsKeys[kvWrite.Key] = struct{}{}
+		}
+		for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+			for _, hashedWrite := range collHashedRWSet.HashedRwSet.HashedWrites {
+				w.hashedKeys[privacyenabledstate.HashedCompositeKey{
+					Namespace:      ns,
+					CollectionName: collHashedRWSet.CollectionName,
+					KeyHash:        string(hashedWrite.KeyHash),
+				}] = struct{}{}
+			}
+		}
+	}
+}
+
+// overlaps returns true if the given transaction reads a key, or queries a range of keys, written by the
+// transactions added so far. The end key of a range query is always considered part of the range, as it is
+// when the iterator of the range query was not exhausted during the simulation
+func (w *blockWrites) overlaps(txRWSet *rwsetutil.TxRwSet) bool {
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		ns := nsRWSet.NameSpace
+		nsKeys := w.pubKeys[ns]
+		for _, kvRead := range nsRWSet.KvRwSet.Reads {
+			if _, ok := nsKeys[kvRead.Key]; ok {
+				return true
+			}
+		}
+		for _, rqi := range nsRWSet.KvRwSet.RangeQueriesInfo {
+			for key := range nsKeys {
+				if key >= rqi.StartKey && (rqi.EndKey == "" || key <= rqi.EndKey) {
+					return true
+				}
+			}
+		}
+		for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+			for _, hashedRead := range collHashedRWSet.HashedRwSet.HashedReads {
+				if _, ok := w.hashedKeys[privacyenabledstate.HashedCompositeKey{
+					Namespace:      ns,
+					CollectionName: collHashedRWSet.CollectionName,
+					KeyHash:        string(hashedRead.KeyHash),
+				}]; ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// findIndependentTxs returns the positions in the block of the transactions which read none of the keys written
+// by the transactions which precede them in the block. As the updates of the preceding transactions do not affect
+// their reads, these transactions can be validated against the committed state alone, in any order
+func findIndependentTxs(block *valinternal.Block) []int {
+	var independentTxs []int
+	writes := newBlockWrites()
+	for i, tx := range block.Txs {
+		if !writes.overlaps(tx.RWSet) {
+			independentTxs = append(independentTxs, i)
+		}
+		writes.add(tx.RWSet)
+	}
+	return independentTxs
+}
+
+// validateIndependentTxs concurrently validates the transactions of the block which do not depend on the preceding
+// transactions of the block, and returns their validation codes keyed by their positions in the block
+func (v *Validator) validateIndependentTxs(block *valinternal.Block) (map[int]peer.TxValidationCode, error) {
+	independentTxs := findIndependentTxs(block)
+	if len(independentTxs) < 2 {
+		return nil, nil
+	}
+	logger.Debugf("Block [%d]: validating [%d] independent transactions out of [%d] with parallelism [%d]",
+		block.Num, len(independentTxs), len(block.Txs), v.parallelism)
+
+	codes := make([]peer.TxValidationCode, len(independentTxs))
+	errs := make([]error, len(independentTxs))
+	positions := make(chan int, len(independentTxs))
+	for i := range independentTxs {
+		positions <- i
+	}
+	close(positions)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < v.parallelism && worker < len(independentTxs); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// None of the keys read by these transactions is updated by the preceding transactions,
+			// hence they are validated against empty updates, one batch per worker
+			noUpdates := valinternal.NewPubAndHashUpdates()
+			for i := range positions {
+				codes[i], errs[i] = v.validateTx(block.Txs[independentTxs[i]].RWSet, noUpdates)
+			}
+		}()
+	}
+	wg.Wait()
+
+	independentTxsCodes := make(map[int]peer.TxValidationCode, len(independentTxs))
+	for i, txPosition := range independentTxs {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		independentTxsCodes[txPosition] = codes[i]
+	}
+	return independentTxsCodes, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebasedval
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valinternal"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+)
+
+func TestParallelValidation(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	//populate db with initial data
+	batch := privacyenabledstate.NewUpdateBatch()
+	for i := 1; i <= 9; i++ {
+		batch.PubUpdates.Put("ns1", fmt.Sprintf("key%d", i), []byte(fmt.Sprintf("value%d", i)), version.NewHeight(1, uint64(i-1)))
+	}
+	batch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash("key1"), util.ComputeStringHash("value1"), version.NewHeight(1, 9))
+	batch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash("key2"), util.ComputeStringHash("value2"), version.NewHeight(1, 10))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 10))
+
+	//rwset0 should be valid and makes rwset1 invalid
+	rwsetBuilder0 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder0.AddToReadSet("ns1", "key1", version.NewHeight(1, 0))
+	rwsetBuilder0.AddToWriteSet("ns1", "key1", []byte("value1_new"))
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder1.AddToReadSet("ns1", "key1", version.NewHeight(1, 0))
+
+	//rwset2 should not be valid - stale read of a key that no preceding transaction writes
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder2.AddToReadSet("ns1", "key2", version.NewHeight(1, 0))
+
+	//rwset3 should be valid and its delete of key4 makes rwset4 invalid
+	rwsetBuilder3 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder3.AddToReadSet("ns1", "key3", version.NewHeight(1, 2))
+	rwsetBuilder3.AddToWriteSet("ns1", "key4", nil)
+	rwsetBuilder4 := rwsetutil.NewRWSetBuilder()
+	rqi4 := &kvrwset.RangeQueryInfo{StartKey: "key3", EndKey: "key5", ItrExhausted: false}
+	rqi4.SetRawReads([]*kvrwset.KVRead{
+		rwsetutil.NewKVRead("key3", version.NewHeight(1, 2)),
+		rwsetutil.NewKVRead("key4", version.NewHeight(1, 3)),
+		rwsetutil.NewKVRead("key5", version.NewHeight(1, 4))})
+	rwsetBuilder4.AddToRangeQuerySet("ns1", rqi4)
+
+	//rwset5 should be valid and makes rwset6 invalid
+	rwsetBuilder5 := rwsetutil.NewRWSetBuilder()
+	testutil.AssertNoError(t, rwsetBuilder5.AddToHashedReadSet("ns1", "coll1", "key1", version.NewHeight(1, 9)), "")
+	testutil.AssertNoError(t, rwsetBuilder5.AddToPvtAndHashedWriteSet("ns1", "coll1", "key2", []byte("value2_new")), "")
+	rwsetBuilder6 := rwsetutil.NewRWSetBuilder()
+	testutil.AssertNoError(t, rwsetBuilder6.AddToHashedReadSet("ns1", "coll1", "key2", version.NewHeight(1, 10)), "")
+
+	//rwset7 and rwset8 should be valid
+	rwsetBuilder7 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder7.AddToReadSet("ns2", "key1", nil)
+	rwsetBuilder8 := rwsetutil.NewRWSetBuilder()
+	rqi8 := &kvrwset.RangeQueryInfo{StartKey: "key6", EndKey: "key9", ItrExhausted: true}
+	rqi8.SetRawReads([]*kvrwset.KVRead{
+		rwsetutil.NewKVRead("key6", version.NewHeight(1, 5)),
+		rwsetutil.NewKVRead("key7", version.NewHeight(1, 6)),
+		rwsetutil.NewKVRead("key8", version.NewHeight(1, 7))})
+	rwsetBuilder8.AddToRangeQuerySet("ns1", rqi8)
+
+	rwsetBuilders := []*rwsetutil.RWSetBuilder{rwsetBuilder0, rwsetBuilder1, rwsetBuilder2, rwsetBuilder3,
+		rwsetBuilder4, rwsetBuilder5, rwsetBuilder6, rwsetBuilder7, rwsetBuilder8}
+	independentTxs := findIndependentTxs(getTestBlock(getTestPubSimulationRWSet(t, rwsetBuilders...)))
+	testutil.AssertEquals(t, independentTxs, []int{0, 2, 3, 5, 7, 8})
+
+	// The validation results do not depend on the parallelism
+	validator := NewValidator(db)
+	for _, parallelism := range []int{1, 2, 4, 16} {
+		validator.parallelism = parallelism
+		checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilders...), []int{1, 2, 4, 6})
+	}
+}
+
+func TestBlockWritesOverlaps(t *testing.T) {
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToWriteSet("ns1", "key2", []byte("value2"))
+	testutil.AssertNoError(t, rwsetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", []byte("value1")), "")
+	writes := newBlockWrites()
+	writes.add(getTestPubSimulationRWSet(t, rwsetBuilder)[0])
+
+	overlaps := func(rwsetBuilder *rwsetutil.RWSetBuilder) bool {
+		return writes.overlaps(getTestPubSimulationRWSet(t, rwsetBuilder)[0])
+	}
+	rangeQuery := func(ns, startKey, endKey string) *rwsetutil.RWSetBuilder {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		rwsetBuilder.AddToRangeQuerySet(ns, &kvrwset.RangeQueryInfo{StartKey: startKey, EndKey: endKey, ItrExhausted: true})
+		return rwsetBuilder
+	}
+
+	rwsetBuilder = rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToReadSet("ns1", "key2", nil)
+	testutil.AssertEquals(t, overlaps(rwsetBuilder), true)
+	rwsetBuilder = rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToReadSet("ns1", "key1", nil)
+	rwsetBuilder.AddToReadSet("ns2", "key2", nil)
+	testutil.AssertEquals(t, overlaps(rwsetBuilder), false)
+
+	testutil.AssertEquals(t, overlaps(rangeQuery("ns1", "key1", "key3")), true)
+	testutil.AssertEquals(t, overlaps(rangeQuery("ns1", "key1", "key2")), true)
+	testutil.AssertEquals(t, overlaps(rangeQuery("ns1", "key2", "")), true)
+	testutil.AssertEquals(t, overlaps(rangeQuery("ns1", "key3", "")), false)
+	testutil.AssertEquals(t, overlaps(rangeQuery("ns1", "key0", "key1")), false)
+	testutil.AssertEquals(t, overlaps(rangeQuery("ns2", "", "")), false)
+
+	rwsetBuilder = rwsetutil.NewRWSetBuilder()
+	testutil.AssertNoError(t, rwsetBuilder.AddToHashedReadSet("ns1", "coll1", "key1", nil), "")
+	testutil.AssertEquals(t, overlaps(rwsetBuilder), true)
+	rwsetBuilder = rwsetutil.NewRWSetBuilder()
+	testutil.AssertNoError(t, rwsetBuilder.AddToHashedReadSet("ns1", "coll2", "key1", nil), "")
+	testutil.AssertNoError(t, rwsetBuilder.AddToHashedReadSet("ns1", "coll1", "key2", nil), "")
+	testutil.AssertEquals(t, overlaps(rwsetBuilder), false)
+}
+
+func getTestBlock(transRWSets []*rwsetutil.TxRwSet) *valinternal.Block {
+	var trans []*valinternal.Transaction
+	for i, tranRWSet := range transRWSets {
+		trans = append(trans, &valinternal.Transaction{ID: fmt.Sprintf("txid-%d", i), IndexInBlock: i, RWSet: tranRWSet})
+	}
+	return &valinternal.Block{Num: 1, Txs: trans}
+}
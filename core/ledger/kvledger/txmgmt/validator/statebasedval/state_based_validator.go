@@ -12,6 +12,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valinternal"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
 )
@@ -22,11 +23,13 @@ var logger = flogging.MustGetLogger("statebasedval")
 // and preceding valid transactions with in the same block
 type Validator struct {
 	db privacyenabledstate.DB
+	// parallelism is the number of transactions of a block whose reads are checked concurrently
+	parallelism int
 }
 
 // NewValidator constructs StateValidator
 func NewValidator(db privacyenabledstate.DB) *Validator {
-	return &Validator{db}
+	return &Validator{db, ledgerconfig.GetValidationParallelism()}
 }
 
 // preLoadCommittedVersionOfRSet loads committed version of all keys in each
@@ -89,13 +92,25 @@ func (v *Validator) ValidateAndPrepareBatch(block *valinternal.Block, doMVCCVali
 		v.preLoadCommittedVersionOfRSet(block)
 	}
 
-	updates := valinternal.NewPubAndHashUpdates()
-	for _, tx := range block.Txs {
-		var validationCode peer.TxValidationCode
+	// The transactions which do not depend on the preceding transactions of the block are validated
+	// concurrently up front, and the other transactions one after the other below
+	var independentTxsCodes map[int]peer.TxValidationCode
+	if doMVCCValidation && v.parallelism > 1 && len(block.Txs) > 1 {
 		var err error
-		if validationCode, err = v.validateEndorserTX(tx.RWSet, doMVCCValidation, updates); err != nil {
+		if independentTxsCodes, err = v.validateIndependentTxs(block); err != nil {
 			return nil, err
 		}
+	}
+
+	updates := valinternal.NewPubAndHashUpdates()
+	for i, tx := range block.Txs {
+		validationCode, validated := independentTxsCodes[i]
+		if !validated {
+			var err error
+			if validationCode, err = v.validateEndorserTX(tx.RWSet, doMVCCValidation, updates); err != nil {
+				return nil, err
+			}
+		}
 
 		tx.ValidationCode = validationCode
 		if validationCode == peer.TxValidationCode_VALID {
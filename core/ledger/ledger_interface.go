@@ -18,6 +18,7 @@ package ledger
 
 import (
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
@@ -30,6 +31,11 @@ type PeerLedgerProvider interface {
 	// This function guarantees that the creation of ledger and committing the genesis block would an atomic action
 	// The chain id retrieved from the genesis block is treated as a ledger id
 	Create(genesisBlock *common.Block) (PeerLedger, error)
+	// CreateFromSnapshot creates a new ledger from the snapshot in the given directory, as exported by
+	// PeerLedger.ExportSnapshot. The ledger starts at the height of the snapshot, the blocks preceding its
+	// last block are not available, except for the latest config block
+	// The channel id in the manifest of the snapshot is treated as a ledger id
+	CreateFromSnapshot(snapshotDir string) (PeerLedger, error)
 	// Open opens an already created ledger
 	Open(ledgerID string) (PeerLedger, error)
 	// Exists tells whether the ledger with given id exists
@@ -82,6 +88,40 @@ type PeerLedger interface {
 	PrivateDataMinBlockNum() (uint64, error)
 	//Prune prunes the blocks/transactions that satisfy the given policy
 	Prune(policy commonledger.PrunePolicy) error
+	// ExportSnapshot exports the public state, the hashes of the private state, the latest config block
+	// and the last committed block to the given directory, along with a manifest (see SnapshotManifest)
+	ExportSnapshot(snapshotDir string) error
+	// GetStateHash returns the hashes of the state of the ledger after the commit of the given block (see StateHash)
+	GetStateHash(blockNum uint64) (*StateHash, error)
+}
+
+// StateHash holds the hashes of the state of a ledger after the commit of a block. The hash of a namespace covers its
+// public data and the hashes of its private data, and the hash of the ledger covers the hashes of the namespaces.
+// Peers with the same state at the same height have the same state hashes. The hashes are SHA256 based and hex encoded
+type StateHash struct {
+	BlockNumber uint64            `json:"block_number"`
+	Hash        string            `json:"hash"`
+	Namespaces  map[string]string `json:"namespaces"`
+}
+
+// Names of the files of a ledger snapshot
+const (
+	SnapshotPublicStateFile    = "public_state.data"
+	SnapshotPvtStateHashesFile = "pvt_state_hashes.data"
+	SnapshotConfigBlockFile    = "config.block"
+	SnapshotLastBlockFile      = "last.block"
+	SnapshotManifestFile       = "manifest.json"
+	SnapshotSignatureFile      = "manifest.sig"
+)
+
+// SnapshotManifest describes a ledger snapshot. The hashes of the block and of the
+// files are hex encoded, the hashes of the files are SHA256 hashes of their content
+type SnapshotManifest struct {
+	ChannelID         string            `json:"channel_id"`
+	LastBlockNumber   uint64            `json:"last_block_number"`
+	LastBlockHash     string            `json:"last_block_hash"`
+	ConfigBlockNumber uint64            `json:"config_block_number"`
+	Files             map[string]string `json:"files"`
 }
 
 // ValidatedLedger represents the 'final ledger' after filtering out invalid transactions from PeerLedger.
@@ -131,10 +171,39 @@ type QueryExecutor interface {
 }
 
 // HistoryQueryExecutor executes the history queries
+// The history of a ledger created from a snapshot starts after the last block of the snapshot, hence the queries
+// of the history that include the blocks of the snapshot, such as GetHistoryForKey, fail on such a ledger.
 type HistoryQueryExecutor interface {
 	// GetHistoryForKey retrieves the history of values for a key.
 	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
+	// GetHistoryForKeyWithOptions retrieves the history of values for a key which were written between
+	// the start and the end block, both inclusive. An endBlock of 0 refers to the last available block.
+	// The history is in the order of the commits, or in the reverse order if reverse is true.
+	// A positive pageSize, which is capped at ledger.state.totalQueryLimit, limits the number of results, and the bookmark returned by the iterator
+	// can be supplied to retrieve the next page. An empty bookmark refers to the first page.
+	// The returned HistoryResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
+	GetHistoryForKeyWithOptions(namespace string, key string, startBlock uint64, endBlock uint64,
+		reverse bool, pageSize int32, bookmark string) (HistoryResultsIterator, error)
+	// GetHistoryForKeySince retrieves the history of values for a key which were written by the transactions
+	// created at or after the given time, as given by the timestamp of their channel header. The history is ordered and paginated
+	// like the one of GetHistoryForKeyWithOptions. The timestamps are set by the clients of the transactions,
+	// and don't increase along the blocks, hence all the history of the key is scanned.
+	GetHistoryForKeySince(namespace string, key string, since *timestamp.Timestamp,
+		reverse bool, pageSize int32, bookmark string) (HistoryResultsIterator, error)
+	// GetPrivateDataHashHistoryForKey retrieves the history of the hashed writes of a private data key,
+	// which is identified by the hash of the key in the given collection.
+	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult,
+	// where the Value is the hash of the value that was written
+	GetPrivateDataHashHistoryForKey(namespace string, collection string, keyHash []byte) (commonledger.ResultsIterator, error)
+}
+
+// HistoryResultsIterator is an iterator over a page of the history of a key
+type HistoryResultsIterator interface {
+	commonledger.ResultsIterator
+	// GetBookmarkAndClose returns the bookmark of the next page, or an empty string
+	// if there are no more results, and closes the iterator
+	GetBookmarkAndClose() string
 }
 
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
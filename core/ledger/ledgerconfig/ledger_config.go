@@ -18,6 +18,9 @@ package ledgerconfig
 
 import (
 	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/spf13/viper"
@@ -26,12 +29,18 @@ import (
 //IsCouchDBEnabled exposes the useCouchDB variable
 func IsCouchDBEnabled() bool {
 	stateDatabase := viper.GetString("ledger.state.stateDatabase")
-	if stateDatabase == "CouchDB" {
+	// the state database is matched case-insensitively, like in statedb/library
+	if strings.EqualFold(stateDatabase, "CouchDB") {
 		return true
 	}
 	return false
 }
 
+// GetStateDatabase returns the name of the state database the peer is configured with
+func GetStateDatabase() string {
+	return viper.GetString("ledger.state.stateDatabase")
+}
+
 // GetRootPath returns the filesystem path.
 // All ledger related contents are expected to be stored under this path
 func GetRootPath() string {
@@ -54,6 +63,11 @@ func GetHistoryLevelDBPath() string {
 	return filepath.Join(GetRootPath(), "historyLeveldb")
 }
 
+// GetStateHashLevelDBPath returns the filesystem path that is used to maintain the state hashes of the ledgers
+func GetStateHashLevelDBPath() string {
+	return filepath.Join(GetRootPath(), "stateHashLeveldb")
+}
+
 // GetPvtWritesetStorePath returns the filesystem path that is used for permanent storage of privare write-sets
 func GetPvtWritesetStorePath() string {
 	return filepath.Join(GetRootPath(), "pvtWritesetStore")
@@ -69,11 +83,78 @@ func GetPvtdataStorePath() string {
 	return filepath.Join(GetRootPath(), "pvtdataStore")
 }
 
+// GetSnapshotsPath returns the filesystem path that is used for exporting and importing ledger snapshots
+func GetSnapshotsPath() string {
+	return filepath.Join(GetRootPath(), "snapshots")
+}
+
 // GetMaxBlockfileSize returns maximum size of the block file
 func GetMaxBlockfileSize() int {
 	return 64 * 1024 * 1024
 }
 
+// GetBlockCompression returns the name of the compression of the blocks added to the block files,
+// "none" or "snappy"
+func GetBlockCompression() string {
+	compression := viper.GetString("ledger.blockchain.compression")
+	// if compression was unset, default to none
+	if compression == "" {
+		compression = "none"
+	}
+	return compression
+}
+
+// BlockArchiveConfig is the configuration of the archiving of the block files of the channels
+type BlockArchiveConfig struct {
+	// Channels are the channels whose block files are archived
+	Channels []string
+	// RetentionHeight is the number of latest blocks of a channel that are kept locally
+	RetentionHeight uint64
+	// FetchArchivedBlocks tells whether archived blocks are fetched from the archive when requested
+	FetchArchivedBlocks bool
+	// CacheSize is the number of fetched block files of a channel that are cached locally
+	CacheSize int
+	// Target is the type of the archive, either "local" or "s3"
+	Target string
+	// LocalDir is the directory of a local archive
+	LocalDir string
+	// The S3 settings are the location and the credentials of an S3 compatible archive
+	S3Endpoint        string
+	S3Bucket          string
+	S3Region          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	// S3Timeout is the time limit of a request to an S3 compatible archive
+	S3Timeout time.Duration
+}
+
+// GetBlockArchiveConfig returns the configuration of the archiving of the block files,
+// or nil if the block files are not archived
+func GetBlockArchiveConfig() *BlockArchiveConfig {
+	if !viper.GetBool("ledger.blockchain.archive.enabled") {
+		return nil
+	}
+	conf := &BlockArchiveConfig{
+		Channels:            viper.GetStringSlice("ledger.blockchain.archive.channels"),
+		RetentionHeight:     uint64(viper.GetInt("ledger.blockchain.archive.retentionHeight")),
+		FetchArchivedBlocks: viper.GetBool("ledger.blockchain.archive.fetchArchivedBlocks"),
+		CacheSize:           viper.GetInt("ledger.blockchain.archive.cacheSize"),
+		Target:              viper.GetString("ledger.blockchain.archive.target"),
+		LocalDir:            config.GetPath("ledger.blockchain.archive.localDir"),
+		S3Endpoint:          viper.GetString("ledger.blockchain.archive.s3.endpoint"),
+		S3Bucket:            viper.GetString("ledger.blockchain.archive.s3.bucket"),
+		S3Region:            viper.GetString("ledger.blockchain.archive.s3.region"),
+		S3AccessKeyID:       viper.GetString("ledger.blockchain.archive.s3.accessKeyId"),
+		S3SecretAccessKey:   viper.GetString("ledger.blockchain.archive.s3.secretAccessKey"),
+		S3Timeout:           viper.GetDuration("ledger.blockchain.archive.s3.timeout"),
+	}
+	// if cacheSize was unset, default to 4
+	if !viper.IsSet("ledger.blockchain.archive.cacheSize") {
+		conf.CacheSize = 4
+	}
+	return conf
+}
+
 //GetQueryLimit exposes the queryLimit variable
 func GetQueryLimit() int {
 	queryLimit := viper.GetInt("ledger.state.couchDBConfig.queryLimit")
@@ -84,11 +165,64 @@ func GetQueryLimit() int {
 	return queryLimit
 }
 
+// GetTotalQueryLimit returns the maximum number of results returned by a query of the ledger,
+// which limits the pages of the history queries
+func GetTotalQueryLimit() int {
+	totalQueryLimit := viper.GetInt("ledger.state.totalQueryLimit")
+	// if totalQueryLimit was unset, default to 100000
+	if !viper.IsSet("ledger.state.totalQueryLimit") {
+		totalQueryLimit = 100000
+	}
+	return totalQueryLimit
+}
+
+// GetStateCacheSize returns the number of keys of each channel whose values
+// and revisions the CouchDB state database caches. 0 disables the cache
+func GetStateCacheSize() int {
+	cacheSize := viper.GetInt("ledger.state.couchDBConfig.cacheSize")
+	// if cacheSize was unset, default to 10000
+	if !viper.IsSet("ledger.state.couchDBConfig.cacheSize") {
+		cacheSize = 10000
+	}
+	return cacheSize
+}
+
 //IsHistoryDBEnabled exposes the historyDatabase variable
 func IsHistoryDBEnabled() bool {
 	return viper.GetBool("ledger.history.enableHistoryDatabase")
 }
 
+// IsStateHashEnabled tells whether the ledgers maintain a hash of their state for every block,
+// which tells whether the state of peers at the same height diverges. It defaults to false
+func IsStateHashEnabled() bool {
+	return viper.GetBool("ledger.state.enableStateHash")
+}
+
+// GetStateHashRetention returns the number of latest blocks whose state hash is kept.
+// 0 keeps the state hash of every block
+func GetStateHashRetention() uint64 {
+	retention := viper.GetInt("ledger.state.stateHashRetention")
+	// if stateHashRetention was unset, default to 1000
+	if !viper.IsSet("ledger.state.stateHashRetention") {
+		retention = 1000
+	}
+	if retention < 0 {
+		return 0
+	}
+	return uint64(retention)
+}
+
+// GetValidationParallelism returns the number of transactions of a block whose reads the MVCC validation checks
+// concurrently. It defaults to the number of CPUs, and 1 validates the transactions one after the other
+func GetValidationParallelism() int {
+	parallelism := viper.GetInt("ledger.state.validationParallelism")
+	// if validationParallelism was unset or 0, default to the number of CPUs
+	if parallelism <= 0 {
+		parallelism = runtime.NumCPU()
+	}
+	return parallelism
+}
+
 // IsQueryReadsHashingEnabled enables or disables computing of hash
 // of range query results for phantom item validation
 func IsQueryReadsHashingEnabled() bool {
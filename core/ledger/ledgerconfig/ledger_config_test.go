@@ -17,7 +17,9 @@ limitations under the License.
 package ledgerconfig
 
 import (
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
@@ -44,6 +46,17 @@ func TestIsCouchDBEnabled(t *testing.T) {
 	viper.Set("ledger.state.stateDatabase", "CouchDB")
 	updatedValue := IsCouchDBEnabled()
 	testutil.AssertEquals(t, updatedValue, true) //test config returns true
+	viper.Set("ledger.state.stateDatabase", "couchdb")
+	testutil.AssertEquals(t, IsCouchDBEnabled(), true) //test the name is matched case-insensitively
+}
+
+func TestGetStateDatabase(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.state.stateDatabase", "goleveldb")
+	testutil.AssertEquals(t, GetStateDatabase(), "goleveldb")
+	viper.Set("ledger.state.stateDatabase", "CouchDB")
+	testutil.AssertEquals(t, GetStateDatabase(), "CouchDB")
 }
 
 func TestLedgerConfigPathDefault(t *testing.T) {
@@ -60,9 +73,15 @@ func TestLedgerConfigPathDefault(t *testing.T) {
 	testutil.AssertEquals(t,
 		GetHistoryLevelDBPath(),
 		"/var/hyperledger/production/ledgersData/historyLeveldb")
+	testutil.AssertEquals(t,
+		GetStateHashLevelDBPath(),
+		"/var/hyperledger/production/ledgersData/stateHashLeveldb")
 	testutil.AssertEquals(t,
 		GetBlockStorePath(),
 		"/var/hyperledger/production/ledgersData/chains")
+	testutil.AssertEquals(t,
+		GetSnapshotsPath(),
+		"/var/hyperledger/production/ledgersData/snapshots")
 }
 
 func TestLedgerConfigPath(t *testing.T) {
@@ -81,9 +100,15 @@ func TestLedgerConfigPath(t *testing.T) {
 	testutil.AssertEquals(t,
 		GetHistoryLevelDBPath(),
 		"/tmp/hyperledger/production/ledgersData/historyLeveldb")
+	testutil.AssertEquals(t,
+		GetStateHashLevelDBPath(),
+		"/tmp/hyperledger/production/ledgersData/stateHashLeveldb")
 	testutil.AssertEquals(t,
 		GetBlockStorePath(),
 		"/tmp/hyperledger/production/ledgersData/chains")
+	testutil.AssertEquals(t,
+		GetSnapshotsPath(),
+		"/tmp/hyperledger/production/ledgersData/snapshots")
 }
 
 func TestGetQueryLimitDefault(t *testing.T) {
@@ -106,6 +131,46 @@ func TestGetQueryLimit(t *testing.T) {
 	testutil.AssertEquals(t, updatedValue, 5000) //test config returns 5000
 }
 
+func TestGetTotalQueryLimitDefault(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defaultValue := GetTotalQueryLimit()
+	testutil.AssertEquals(t, defaultValue, 100000) //test default config is 100000
+}
+
+func TestGetTotalQueryLimitUnset(t *testing.T) {
+	viper.Reset()
+	defaultValue := GetTotalQueryLimit()
+	testutil.AssertEquals(t, defaultValue, 100000) //test default config is 100000
+}
+
+func TestGetTotalQueryLimit(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.state.totalQueryLimit", 5000)
+	updatedValue := GetTotalQueryLimit()
+	testutil.AssertEquals(t, updatedValue, 5000) //test config returns 5000
+}
+
+func TestGetStateCacheSizeDefault(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defaultValue := GetStateCacheSize()
+	testutil.AssertEquals(t, defaultValue, 10000) //test default config is 10000
+}
+
+func TestGetStateCacheSizeUnset(t *testing.T) {
+	viper.Reset()
+	defaultValue := GetStateCacheSize()
+	testutil.AssertEquals(t, defaultValue, 10000) //test default config is 10000
+}
+
+func TestGetStateCacheSize(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.state.couchDBConfig.cacheSize", 0)
+	updatedValue := GetStateCacheSize()
+	testutil.AssertEquals(t, updatedValue, 0) //test config returns 0
+}
+
 func TestIsHistoryDBEnabledDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	defaultValue := IsHistoryDBEnabled()
@@ -128,7 +193,75 @@ func TestIsHistoryDBEnabledFalse(t *testing.T) {
 	testutil.AssertEquals(t, updatedValue, false) //test config returns false
 }
 
+func TestIsStateHashEnabledUnset(t *testing.T) {
+	viper.Reset()
+	testutil.AssertEquals(t, IsStateHashEnabled(), false) //test default config is false
+}
+
+func TestIsStateHashEnabled(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	testutil.AssertEquals(t, IsStateHashEnabled(), false) //test default config is false
+	viper.Set("ledger.state.enableStateHash", true)
+	testutil.AssertEquals(t, IsStateHashEnabled(), true)
+}
+
+func TestGetStateHashRetention(t *testing.T) {
+	viper.Reset()
+	testutil.AssertEquals(t, GetStateHashRetention(), uint64(1000)) //test default config is 1000
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	testutil.AssertEquals(t, GetStateHashRetention(), uint64(1000))
+	viper.Set("ledger.state.stateHashRetention", 0)
+	testutil.AssertEquals(t, GetStateHashRetention(), uint64(0))
+}
+
+func TestGetValidationParallelismUnset(t *testing.T) {
+	viper.Reset()
+	testutil.AssertEquals(t, GetValidationParallelism(), runtime.NumCPU()) //test default config is the number of CPUs
+}
+
+func TestGetValidationParallelism(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	testutil.AssertEquals(t, GetValidationParallelism(), runtime.NumCPU()) //test default config is the number of CPUs
+	viper.Set("ledger.state.validationParallelism", 1)
+	testutil.AssertEquals(t, GetValidationParallelism(), 1)
+	viper.Set("ledger.state.validationParallelism", 8)
+	testutil.AssertEquals(t, GetValidationParallelism(), 8)
+}
+
 func setUpCoreYAMLConfig() {
 	//call a helper method to load the core.yaml
 	ledgertestutil.SetupCoreYAMLConfig()
 }
+
+func TestGetBlockArchiveConfig(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	testutil.AssertNil(t, GetBlockArchiveConfig())
+
+	viper.Set("ledger.blockchain.archive.enabled", true)
+	viper.Set("ledger.blockchain.archive.channels", []string{"ch1", "ch2"})
+	viper.Set("ledger.blockchain.archive.retentionHeight", 500)
+	viper.Set("ledger.blockchain.archive.target", "s3")
+	viper.Set("ledger.blockchain.archive.s3.bucket", "blocks")
+	archiveConfig := GetBlockArchiveConfig()
+	testutil.AssertEquals(t, archiveConfig.Channels, []string{"ch1", "ch2"})
+	testutil.AssertEquals(t, archiveConfig.RetentionHeight, uint64(500))
+	testutil.AssertEquals(t, archiveConfig.FetchArchivedBlocks, true)
+	testutil.AssertEquals(t, archiveConfig.CacheSize, 4)
+	testutil.AssertEquals(t, archiveConfig.Target, "s3")
+	testutil.AssertEquals(t, archiveConfig.S3Bucket, "blocks")
+	testutil.AssertEquals(t, archiveConfig.S3Timeout, 10*time.Minute)
+}
+
+func TestGetBlockCompression(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	testutil.AssertEquals(t, GetBlockCompression(), "none")
+	viper.Set("ledger.blockchain.compression", "snappy")
+	testutil.AssertEquals(t, GetBlockCompression(), "snappy")
+	viper.Reset()
+	testutil.AssertEquals(t, GetBlockCompression(), "none")
+}
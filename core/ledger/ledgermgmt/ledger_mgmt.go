@@ -91,6 +91,32 @@ func CreateLedger(genesisBlock *common.Block) (ledger.PeerLedger, error) {
 	return l, nil
 }
 
+// CreateLedgerFromSnapshot creates a new ledger from the snapshot in the given directory.
+// The channel id in the manifest of the snapshot is treated as a ledger id
+func CreateLedgerFromSnapshot(snapshotDir string) (ledger.PeerLedger, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return nil, ErrLedgerMgmtNotInitialized
+	}
+
+	manifest, _, err := kvledger.ReadSnapshotManifest(snapshotDir)
+	if err != nil {
+		return nil, err
+	}
+	id := manifest.ChannelID
+
+	logger.Infof("Creating ledger [%s] from snapshot [%s]", id, snapshotDir)
+	l, err := ledgerProvider.CreateFromSnapshot(snapshotDir)
+	if err != nil {
+		return nil, err
+	}
+	l = wrapLedger(id, l)
+	openedLedgers[id] = l
+	logger.Infof("Created ledger [%s] from snapshot at block [%d]", id, manifest.LastBlockNumber)
+	return l, nil
+}
+
 // OpenLedger returns a ledger for the given id
 func OpenLedger(id string) (ledger.PeerLedger, error) {
 	logger.Infof("Opening ledger with id = %s", id)
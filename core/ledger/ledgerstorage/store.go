@@ -53,14 +53,54 @@ func NewProvider() *Provider {
 		blkstorage.IndexableAttrTxValidationCode,
 	}
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	compression, err := fsblkstorage.ParseCompression(ledgerconfig.GetBlockCompression())
+	if err != nil {
+		panic(fmt.Sprintf("Invalid block compression: %s", err))
+	}
 	blockStoreProvider := fsblkstorage.NewProvider(
-		fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize()),
+		fsblkstorage.NewConfWithArchiving(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize(),
+			getArchiveConfs()).WithCompression(compression),
 		indexConfig)
 
 	pvtStoreProvider := pvtdatastorage.NewProvider()
 	return &Provider{blockStoreProvider, pvtStoreProvider}
 }
 
+// getArchiveConfs returns the configuration of the archiving of the block files of each channel
+// which is configured to be archived
+func getArchiveConfs() map[string]*fsblkstorage.ArchiveConf {
+	archiveConfig := ledgerconfig.GetBlockArchiveConfig()
+	if archiveConfig == nil {
+		return nil
+	}
+	var target fsblkstorage.ArchiveTarget
+	switch archiveConfig.Target {
+	case "local":
+		target = fsblkstorage.NewLocalArchiveTarget(archiveConfig.LocalDir)
+	case "s3":
+		target = fsblkstorage.NewS3ArchiveTarget(fsblkstorage.S3Conf{
+			Endpoint:        archiveConfig.S3Endpoint,
+			Bucket:          archiveConfig.S3Bucket,
+			Region:          archiveConfig.S3Region,
+			AccessKeyID:     archiveConfig.S3AccessKeyID,
+			SecretAccessKey: archiveConfig.S3SecretAccessKey,
+			Timeout:         archiveConfig.S3Timeout,
+		})
+	default:
+		panic(fmt.Sprintf("Unknown block archive target [%s]", archiveConfig.Target))
+	}
+	archiveConfs := make(map[string]*fsblkstorage.ArchiveConf)
+	for _, channel := range archiveConfig.Channels {
+		archiveConfs[channel] = &fsblkstorage.ArchiveConf{
+			Target:              target,
+			RetentionHeight:     archiveConfig.RetentionHeight,
+			FetchArchivedBlocks: archiveConfig.FetchArchivedBlocks,
+			CacheSize:           archiveConfig.CacheSize,
+		}
+	}
+	return archiveConfs
+}
+
 // Open opens the store
 func (p *Provider) Open(ledgerid string) (*Store, error) {
 	var blockStore blkstorage.BlockStore
@@ -104,6 +144,17 @@ func (s *Store) CommitWithPvtData(blockAndPvtdata *ledger.BlockAndPvtData) error
 	return s.pvtdataStore.Commit()
 }
 
+// BootstrapFromSnapshot adds the last block of a ledger snapshot as the first block of an empty store
+// and brings the pvt data store up to that block, as if it had processed the preceding blocks with no pvt data
+func (s *Store) BootstrapFromSnapshot(lastBlock *common.Block, configBlock *common.Block) error {
+	s.rwlock.Lock()
+	defer s.rwlock.Unlock()
+	if err := s.BlockStore.BootstrapFromSnapshot(lastBlock, configBlock); err != nil {
+		return err
+	}
+	return s.pvtdataStore.InitLastCommittedBlock(lastBlock.Header.Number)
+}
+
 // GetPvtDataAndBlockByNum returns the block and the corresponding pvt data.
 // The pvt data is filtered by the list of 'collections' supplied
 func (s *Store) GetPvtDataAndBlockByNum(blockNum uint64, filter ledger.PvtNsCollFilter) (*ledger.BlockAndPvtData, error) {
@@ -39,6 +39,13 @@ func NewProvider() Provider {
 	return &provider{dbProvider: dbProvider}
 }
 
+// NewReadOnlyProvider instantiates a StoreProvider on the existing store at the given path,
+// which is opened read-only. The commits to the stores fail
+func NewReadOnlyProvider(dbPath string) Provider {
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath, ReadOnly: true})
+	return &provider{dbProvider: dbProvider}
+}
+
 // OpenStore returns a handle to a store
 func (p *provider) OpenStore(ledgerid string) (Store, error) {
 	dbHandle := p.dbProvider.GetDBHandle(ledgerid)
@@ -90,7 +90,12 @@ func SetupCoreYAMLConfig() {
 func ResetConfigToDefaultValues() {
 	//reset to defaults
 	viper.Set("ledger.state.couchDBConfig.queryLimit", 10000)
+	viper.Set("ledger.state.totalQueryLimit", 100000)
+	viper.Set("ledger.state.couchDBConfig.cacheSize", 10000)
 	viper.Set("ledger.state.stateDatabase", "goleveldb")
+	viper.Set("ledger.state.enableStateHash", false)
+	viper.Set("ledger.state.stateHashRetention", 1000)
+	viper.Set("ledger.state.validationParallelism", 0)
 	viper.Set("ledger.history.enableHistoryDatabase", false)
 	viper.Set("peer.fileSystemPath", "/var/hyperledger/production")
 }
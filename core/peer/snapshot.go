@@ -0,0 +1,243 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// ExportSnapshot exports a snapshot of the ledger of the given chain to the given directory.
+// The manifest of the snapshot must then be signed by the admins of the channel with SignSnapshot
+func ExportSnapshot(cid string, snapshotDir string) error {
+	l := GetLedger(cid)
+	if l == nil {
+		return errors.Errorf("channel %s not found", cid)
+	}
+	return errors.WithMessage(l.ExportSnapshot(snapshotDir), "failed exporting snapshot")
+}
+
+// SignSnapshot signs the manifest of the snapshot in the given directory with the signer,
+// and adds the signature to the signatures of the manifest
+func SignSnapshot(snapshotDir string, signer crypto.LocalSigner) error {
+	_, manifestBytes, err := kvledger.ReadSnapshotManifest(snapshotDir)
+	if err != nil {
+		return err
+	}
+	signatures, err := readSnapshotSignatures(snapshotDir)
+	if err != nil {
+		return err
+	}
+
+	sigHeader, err := signer.NewSignatureHeader()
+	if err != nil {
+		return err
+	}
+	sigHeaderBytes := utils.MarshalOrPanic(sigHeader)
+	signature, err := signer.Sign(util.ConcatenateBytes(manifestBytes, sigHeaderBytes))
+	if err != nil {
+		return errors.WithMessage(err, "failed signing snapshot manifest")
+	}
+	signatures.Signatures = append(signatures.Signatures, &common.MetadataSignature{
+		SignatureHeader: sigHeaderBytes,
+		Signature:       signature,
+	})
+	return ioutil.WriteFile(filepath.Join(snapshotDir, ledger.SnapshotSignatureFile), utils.MarshalOrPanic(signatures), 0644)
+}
+
+// CreateChainFromSnapshot creates a new chain from the ledger snapshot in the given directory.
+// The snapshot is verified against the trusted block, a config block of the channel which is
+// obtained out of band, such as the genesis block or the latest config block of the channel:
+// the config block of the snapshot must either be the trusted block, or be signed by the orderers
+// according to the trusted block, and the last block of the snapshot must be signed by the orderers
+// according to the config block of the snapshot. The manifest of the snapshot must be signed by
+// identities satisfying the admins policy of the application of the channel.
+// It returns the ID of the created chain
+func CreateChainFromSnapshot(snapshotDir string, trustedBlock *common.Block) (string, error) {
+	manifest, manifestBytes, err := kvledger.ReadSnapshotManifest(snapshotDir)
+	if err != nil {
+		return "", err
+	}
+	configBlock, err := readSnapshotBlock(snapshotDir, manifest, ledger.SnapshotConfigBlockFile)
+	if err != nil {
+		return "", err
+	}
+	lastBlock, err := readSnapshotBlock(snapshotDir, manifest, ledger.SnapshotLastBlockFile)
+	if err != nil {
+		return "", err
+	}
+	signatures, err := readSnapshotSignatures(snapshotDir)
+	if err != nil {
+		return "", err
+	}
+	if err := verifySnapshot(manifest, manifestBytes, signatures, configBlock, lastBlock, trustedBlock); err != nil {
+		return "", errors.WithMessage(err, "snapshot verification failed")
+	}
+
+	var l ledger.PeerLedger
+	if l, err = ledgermgmt.CreateLedgerFromSnapshot(snapshotDir); err != nil {
+		return "", errors.WithMessage(err, "cannot create ledger from snapshot")
+	}
+
+	return manifest.ChannelID, createChain(manifest.ChannelID, l, configBlock)
+}
+
+// readSnapshotBlock reads a block of a snapshot,
+// and ensures that it is the one referenced by the manifest
+func readSnapshotBlock(snapshotDir string, manifest *ledger.SnapshotManifest, fileName string) (*common.Block, error) {
+	blockBytes, err := ioutil.ReadFile(filepath.Join(snapshotDir, fileName))
+	if err != nil {
+		return nil, err
+	}
+	if hex.EncodeToString(util.ComputeSHA256(blockBytes)) != manifest.Files[fileName] {
+		return nil, errors.Errorf("%s of the snapshot doesn't match its manifest", fileName)
+	}
+	return utils.GetBlockFromBlockBytes(blockBytes)
+}
+
+// readSnapshotSignatures reads the signatures of the manifest of a snapshot, if any
+func readSnapshotSignatures(snapshotDir string) (*common.Metadata, error) {
+	signatures := &common.Metadata{}
+	sigBytes, err := ioutil.ReadFile(filepath.Join(snapshotDir, ledger.SnapshotSignatureFile))
+	if os.IsNotExist(err) {
+		return signatures, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := proto.Unmarshal(sigBytes, signatures); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling snapshot signatures")
+	}
+	return signatures, nil
+}
+
+// verifySnapshot verifies the blocks of a snapshot from the trusted block on,
+// and then the signatures of its manifest against the config block of the snapshot
+func verifySnapshot(manifest *ledger.SnapshotManifest, manifestBytes []byte, signatures *common.Metadata,
+	configBlock, lastBlock, trustedBlock *common.Block) error {
+	if trustedBlock == nil || trustedBlock.Header == nil {
+		return errors.New("no trusted block supplied")
+	}
+	// The orderers may sign the blocks of several channels, hence the channel of each block is checked
+	for _, b := range []struct {
+		name  string
+		block *common.Block
+	}{{"trusted block", trustedBlock}, {"config block", configBlock}, {"last block", lastBlock}} {
+		chainID, err := utils.GetChainIDFromBlock(b.block)
+		if err != nil {
+			return errors.WithMessage(err, "invalid "+b.name)
+		}
+		if chainID != manifest.ChannelID {
+			return errors.Errorf("%s is a block of channel %s, not of channel %s", b.name, chainID, manifest.ChannelID)
+		}
+	}
+	trustedBundle, err := configBundle(trustedBlock)
+	if err != nil {
+		return errors.WithMessage(err, "invalid trusted block")
+	}
+
+	// The config block of the snapshot is the trusted block, or follows it
+	if configBlock.Header.Number != manifest.ConfigBlockNumber {
+		return errors.Errorf("config block [%d] of the snapshot doesn't match its manifest", configBlock.Header.Number)
+	}
+	if trustedBlock.Header.Number > configBlock.Header.Number {
+		return errors.Errorf("trusted block [%d] is after the config block [%d] of the snapshot",
+			trustedBlock.Header.Number, configBlock.Header.Number)
+	}
+	if err := verifySnapshotBlock(configBlock, trustedBlock, trustedBundle); err != nil {
+		return errors.WithMessage(err, "invalid config block")
+	}
+	bundle, err := configBundle(configBlock)
+	if err != nil {
+		return errors.WithMessage(err, "invalid config block")
+	}
+
+	// The last block of the snapshot is signed by the orderers of the config block of the snapshot
+	if lastBlock.Header.Number != manifest.LastBlockNumber || hex.EncodeToString(lastBlock.Header.Hash()) != manifest.LastBlockHash {
+		return errors.Errorf("last block [%d] of the snapshot doesn't match its manifest", lastBlock.Header.Number)
+	}
+	lastConfigIndex, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return err
+	}
+	if lastConfigIndex != configBlock.Header.Number {
+		return errors.Errorf("last config block of the last block [%d] is block [%d], not the config block [%d] of the snapshot",
+			lastBlock.Header.Number, lastConfigIndex, configBlock.Header.Number)
+	}
+	if err := verifySnapshotBlock(lastBlock, configBlock, bundle); err != nil {
+		return errors.WithMessage(err, "invalid last block")
+	}
+
+	// The state of the snapshot is vouched for by the admins of the channel
+	policy, ok := bundle.PolicyManager().GetPolicy(policies.ChannelApplicationAdmins)
+	if !ok {
+		return errors.Errorf("policy %s not found in the config block of the snapshot", policies.ChannelApplicationAdmins)
+	}
+	signatureSet := []*common.SignedData{}
+	for _, sig := range signatures.Signatures {
+		sigHeader, err := utils.GetSignatureHeader(sig.SignatureHeader)
+		if err != nil {
+			return err
+		}
+		signatureSet = append(signatureSet, &common.SignedData{
+			Data:      util.ConcatenateBytes(manifestBytes, sig.SignatureHeader),
+			Identity:  sigHeader.Creator,
+			Signature: sig.Signature,
+		})
+	}
+	return errors.WithMessage(policy.Evaluate(signatureSet),
+		"snapshot signatures don't satisfy the channel application admins policy")
+}
+
+// verifySnapshotBlock verifies that the block is the given verified config block,
+// or that it is signed according to the block validation policy of the config
+func verifySnapshotBlock(block, configBlock *common.Block, config channelconfig.Resources) error {
+	if block.Header == nil || block.Data == nil || !bytes.Equal(block.Data.Hash(), block.Header.DataHash) {
+		return errors.New("block data doesn't match the block header")
+	}
+	if block.Header.Number == configBlock.Header.Number {
+		if !bytes.Equal(block.Header.Hash(), configBlock.Header.Hash()) {
+			return errors.Errorf("block [%d] is not the verified config block", block.Header.Number)
+		}
+		return nil
+	}
+	return verifyBlockSignatures(block, config)
+}
+
+// verifyBlockSignatures evaluates the signatures of the block against
+// the block validation policy of the given config
+func verifyBlockSignatures(block *common.Block, config channelconfig.Resources) error {
+	policy, ok := config.PolicyManager().GetPolicy(policies.BlockValidation)
+	if !ok {
+		return errors.Errorf("policy %s not found", policies.BlockValidation)
+	}
+	return utils.VerifyBlockSignatures(block, policy)
+}
+
+// configBundle returns the config bundle of the given config block
+func configBundle(block *common.Block) (*channelconfig.Bundle, error) {
+	envelopeConfig, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, err
+	}
+	return channelconfig.NewBundleFromEnvelope(envelopeConfig)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/localmsp"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/provisional"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// makeSnapshotConfigBlock creates a config block of the given channel, where
+// the sample org is both the orderer org and the application org
+func makeSnapshotConfigBlock(t *testing.T, chainID string) *common.Block {
+	conf := genesisconfig.Load(genesisconfig.SampleSingleMSPSoloProfile)
+	conf.Application = genesisconfig.Load(genesisconfig.SampleSingleMSPChannelProfile).Application
+	return provisional.New(conf).GenesisBlockForChannel(chainID)
+}
+
+// makeSnapshotLastBlock creates a block of the given channel, which is signed by the local MSP
+func makeSnapshotLastBlock(t *testing.T, chainID string, number uint64, lastConfig uint64) *common.Block {
+	signer := localmsp.NewSigner()
+	env, err := utils.CreateSignedEnvelope(common.HeaderType_MESSAGE, chainID, signer, &common.Envelope{}, 0, 0)
+	assert.NoError(t, err)
+	block := common.NewBlock(number, []byte("previous hash"))
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+	block.Header.DataHash = block.Data.Hash()
+	block.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&common.Metadata{
+		Value: utils.MarshalOrPanic(&common.LastConfig{Index: lastConfig}),
+	})
+
+	sigHeader, err := signer.NewSignatureHeader()
+	assert.NoError(t, err)
+	sigHeaderBytes := utils.MarshalOrPanic(sigHeader)
+	signature, err := signer.Sign(util.ConcatenateBytes(sigHeaderBytes, block.Header.Bytes()))
+	assert.NoError(t, err)
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&common.Metadata{
+		Signatures: []*common.MetadataSignature{{SignatureHeader: sigHeaderBytes, Signature: signature}},
+	})
+	return block
+}
+
+func TestVerifySnapshot(t *testing.T) {
+	assert.NoError(t, msptesttools.LoadMSPSetupForTesting())
+
+	configBlock := makeSnapshotConfigBlock(t, "mychannel")
+	lastBlock := makeSnapshotLastBlock(t, "mychannel", 2, 0)
+	manifest := &ledger.SnapshotManifest{
+		ChannelID:         "mychannel",
+		LastBlockNumber:   2,
+		LastBlockHash:     hex.EncodeToString(lastBlock.Header.Hash()),
+		ConfigBlockNumber: 0,
+	}
+	manifestBytes, err := json.Marshal(manifest)
+	assert.NoError(t, err)
+
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, ledger.SnapshotManifestFile), manifestBytes, 0644))
+
+	// The snapshot isn't signed yet
+	signatures, err := readSnapshotSignatures(snapshotDir)
+	assert.NoError(t, err)
+	err = verifySnapshot(manifest, manifestBytes, signatures, configBlock, lastBlock, configBlock)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "channel application admins policy")
+
+	// The local MSP is an admin of the channel
+	assert.NoError(t, SignSnapshot(snapshotDir, localmsp.NewSigner()))
+	signatures, err = readSnapshotSignatures(snapshotDir)
+	assert.NoError(t, err)
+	assert.Len(t, signatures.Signatures, 1)
+	assert.NoError(t, verifySnapshot(manifest, manifestBytes, signatures, configBlock, lastBlock, configBlock))
+
+	// The trusted block is supplied, and is a config block of the channel of the snapshot
+	err = verifySnapshot(manifest, manifestBytes, signatures, configBlock, lastBlock, nil)
+	assert.EqualError(t, err, "no trusted block supplied")
+	err = verifySnapshot(manifest, manifestBytes, signatures, configBlock, lastBlock, makeSnapshotConfigBlock(t, "otherchannel"))
+	assert.EqualError(t, err, "trusted block is a block of channel otherchannel, not of channel mychannel")
+	err = verifySnapshot(manifest, manifestBytes, signatures, configBlock, lastBlock, makeSnapshotLastBlock(t, "mychannel", 0, 0))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid trusted block")
+
+	// The trusted block cannot be replaced by another config block with the same number
+	err = verifySnapshot(manifest, manifestBytes, signatures, configBlock, lastBlock, makeSnapshotConfigBlock(t, "mychannel"))
+	assert.EqualError(t, err, "invalid config block: block [0] is not the verified config block")
+
+	// The last block must be the one of the manifest, and be signed by the orderers
+	err = verifySnapshot(manifest, manifestBytes, signatures, configBlock, makeSnapshotLastBlock(t, "mychannel", 2, 0), configBlock)
+	assert.EqualError(t, err, "last block [2] of the snapshot doesn't match its manifest")
+	unsignedBlock := proto.Clone(lastBlock).(*common.Block)
+	unsignedBlock.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&common.Metadata{})
+	err = verifySnapshot(manifest, manifestBytes, signatures, configBlock, unsignedBlock, configBlock)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "block validation policy")
+	otherConfigBlock := makeSnapshotLastBlock(t, "mychannel", 2, 1)
+	manifest.LastBlockHash = hex.EncodeToString(otherConfigBlock.Header.Hash())
+	err = verifySnapshot(manifest, manifestBytes, signatures, configBlock, otherConfigBlock, configBlock)
+	assert.EqualError(t, err, "last config block of the last block [2] is block [1], not the config block [0] of the snapshot")
+}
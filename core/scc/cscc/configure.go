@@ -24,12 +24,15 @@ package cscc
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/policy"
 	"github.com/hyperledger/fabric/events/producer"
@@ -50,9 +53,11 @@ var cnflogger = flogging.MustGetLogger("cscc")
 
 // These are function names from Invoke first parameter
 const (
-	JoinChain      string = "JoinChain"
-	GetConfigBlock string = "GetConfigBlock"
-	GetChannels    string = "GetChannels"
+	JoinChain           string = "JoinChain"
+	JoinChainBySnapshot string = "JoinChainBySnapshot"
+	ExportSnapshot      string = "ExportSnapshot"
+	GetConfigBlock      string = "GetConfigBlock"
+	GetChannels         string = "GetChannels"
 )
 
 // Init is called once per chain when the chain is created.
@@ -79,7 +84,10 @@ func (e *PeerConfiger) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # args[0] is the function name, which must be JoinChain, GetConfigBlock or
 // UpdateConfigBlock
 // # args[1] is a configuration Block if args[0] is JoinChain or
-// UpdateConfigBlock; otherwise it is the chain id
+// UpdateConfigBlock, the name of a snapshot if args[0] is JoinChainBySnapshot;
+// otherwise it is the chain id
+// # args[2] is the name of the snapshot if args[0] is ExportSnapshot, and the
+// trusted config block the snapshot is verified against if args[0] is JoinChainBySnapshot
 // TODO: Improve the scc interface to avoid marshal/unmarshal args
 func (e *PeerConfiger) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
@@ -132,6 +140,45 @@ func (e *PeerConfiger) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		}
 
 		return joinChain(cid, block)
+	case JoinChainBySnapshot:
+		if len(args) < 3 {
+			return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
+		}
+		snapshotDir, err := getSnapshotDir(string(args[1]))
+		if err != nil {
+			return shim.Error(fmt.Sprintf("\"JoinChainBySnapshot\" request failed: %s", err))
+		}
+
+		trustedBlock, err := utils.GetBlockFromBlockBytes(args[2])
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to reconstruct the trusted block, %s", err))
+		}
+
+		// 2. check local MSP Admins policy
+		if err = e.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+			return shim.Error(fmt.Sprintf("\"JoinChainBySnapshot\" request failed authorization check: [%s]", err))
+		}
+
+		return joinChainBySnapshot(snapshotDir, trustedBlock)
+	case ExportSnapshot:
+		if len(args) < 3 {
+			return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
+		}
+		snapshotDir, err := getSnapshotDir(string(args[2]))
+		if err != nil {
+			return shim.Error(fmt.Sprintf("\"ExportSnapshot\" request failed: %s", err))
+		}
+
+		// 2. check local MSP Admins policy
+		if err = e.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+			return shim.Error(fmt.Sprintf("\"ExportSnapshot\" request failed authorization check "+
+				"for channel [%s]: [%s]", args[1], err))
+		}
+
+		if err := peer.ExportSnapshot(string(args[1]), snapshotDir); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
 	case GetConfigBlock:
 		// 2. check the channel reader policy
 		if err = e.policyChecker.CheckPolicy(string(args[1]), policies.ChannelApplicationReaders, sp); err != nil {
@@ -201,6 +248,29 @@ func joinChain(chainID string, block *common.Block) pb.Response {
 	return shim.Success(nil)
 }
 
+// getSnapshotDir returns the directory of the snapshot with the given name,
+// which must be a plain name within the snapshots directory of the peer
+func getSnapshotDir(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid snapshot name [%s]", name)
+	}
+	return filepath.Join(ledgerconfig.GetSnapshotsPath(), name), nil
+}
+
+// joinChainBySnapshot will join the chain of the snapshot in the given directory,
+// once it is verified against the trusted config block. The ledger starts at the
+// height of the snapshot, and blocks after it are pulled from the ordering service as usual
+func joinChainBySnapshot(snapshotDir string, trustedBlock *common.Block) pb.Response {
+	chainID, err := peer.CreateChainFromSnapshot(snapshotDir, trustedBlock)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	peer.InitChain(chainID)
+
+	return shim.Success(nil)
+}
+
 // Return the current configuration block for the specified chainID. If the
 // peer doesn't belong to the chain, return error
 func getConfigBlock(chainID []byte) pb.Response {
@@ -161,6 +161,46 @@ func TestConfigerInvokeJoinChainWrongParams(t *testing.T) {
 	}
 }
 
+func TestConfigerInvokeSnapshotWrongParams(t *testing.T) {
+	e := new(PeerConfiger)
+	stub := shim.NewMockStub("PeerConfiger", e)
+
+	res := stub.MockInit("1", nil)
+	assert.Equal(t, res.Status, int32(shim.OK), "Init failed")
+
+	args := [][]byte{[]byte("ExportSnapshot"), []byte("testChainID")}
+	res = stub.MockInvoke("2", args)
+	assert.Equal(t, res.Status, int32(shim.ERROR), "CSCC invoke expected to fail missing snapshot name")
+	assert.Equal(t, res.Message, "Incorrect number of arguments, 2")
+
+	args = [][]byte{[]byte("JoinChainBySnapshot"), []byte("snapshot")}
+	res = stub.MockInvoke("2", args)
+	assert.Equal(t, res.Status, int32(shim.ERROR), "CSCC invoke expected to fail missing trusted block")
+	assert.Equal(t, res.Message, "Incorrect number of arguments, 2")
+
+	args = [][]byte{[]byte("JoinChainBySnapshot"), []byte("snapshot"), []byte("not a block")}
+	res = stub.MockInvoke("2", args)
+	assert.Equal(t, res.Status, int32(shim.ERROR), "CSCC invoke expected to fail with invalid trusted block")
+	assert.Contains(t, res.Message, "Failed to reconstruct the trusted block")
+
+	for _, name := range []string{"", "..", "../snapshot", "dir/snapshot"} {
+		args = [][]byte{[]byte("JoinChainBySnapshot"), []byte(name), {}}
+		res = stub.MockInvoke("3", args)
+		assert.Equal(t, res.Status, int32(shim.ERROR), "CSCC invoke expected to fail with invalid snapshot name")
+		assert.Contains(t, res.Message, "invalid snapshot name")
+
+		args = [][]byte{[]byte("ExportSnapshot"), []byte("testChainID"), []byte(name)}
+		res = stub.MockInvoke("4", args)
+		assert.Equal(t, res.Status, int32(shim.ERROR), "CSCC invoke expected to fail with invalid snapshot name")
+		assert.Contains(t, res.Message, "invalid snapshot name")
+	}
+
+	args = [][]byte{[]byte("JoinChainBySnapshot"), []byte("snapshot"), {}}
+	res = stub.MockInvokeWithSignedProposal("5", args, nil)
+	assert.Equal(t, res.Status, int32(shim.ERROR), "CSCC invoke expected to fail no signed proposal provided")
+	assert.Contains(t, res.Message, "failed authorization check")
+}
+
 func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{})
 
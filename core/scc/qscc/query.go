@@ -17,6 +17,7 @@ limitations under the License.
 package qscc
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -37,6 +38,7 @@ import (
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetStateHash returns the state hashes after a block
 type LedgerQuerier struct {
 	policyChecker policy.PolicyChecker
 }
@@ -50,6 +52,7 @@ const (
 	GetBlockByHash     string = "GetBlockByHash"
 	GetTransactionByID string = "GetTransactionByID"
 	GetBlockByTxID     string = "GetBlockByTxID"
+	GetStateHash       string = "GetStateHash"
 )
 
 // Init is called once per chain when the chain is created.
@@ -71,10 +74,11 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // Invoke is called with args[0] contains the query function name, args[1]
 // contains the chain ID, which is temporary for now until it is part of stub.
 // Each function requires additional parameters as described below:
-// # GetChainInfo: Return a BlockchainInfo object marshalled in bytes
+// # GetChainInfo: Return a BlockchainInfo object marshalled in bytes, along with the commit hash of the last block
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetStateHash: Return the state hashes after the block specified by number in args[2] as JSON
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -118,6 +122,8 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetStateHash:
+		return getStateHash(targetLedger, args[2])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -216,3 +222,23 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 
 	return shim.Success(bytes)
 }
+
+func getStateHash(vledger ledger.PeerLedger, number []byte) pb.Response {
+	if number == nil {
+		return shim.Error("Block number must not be nil.")
+	}
+	bnum, err := strconv.ParseUint(string(number), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse block number with error %s", err))
+	}
+	stateHash, err := vledger.GetStateHash(bnum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get state hash of block number %d, error %s", bnum, err))
+	}
+	bytes, err := json.Marshal(stateHash)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
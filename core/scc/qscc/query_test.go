@@ -16,11 +16,13 @@ limitations under the License.
 package qscc
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
@@ -61,6 +63,9 @@ func TestQueryGetChainInfo(t *testing.T) {
 	args := [][]byte{[]byte(GetChainInfo), []byte(chainid)}
 	res := stub.MockInvoke("1", args)
 	assert.Equal(t, int32(shim.OK), res.Status, "GetChainInfo failed with err: %s", res.Message)
+	bcInfo := &common.BlockchainInfo{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, bcInfo))
+	assert.Len(t, bcInfo.CommitHash, 32, "GetChainInfo should have returned the commit hash of the last block")
 
 	args = [][]byte{[]byte(GetChainInfo)}
 	res = stub.MockInvoke("2", args)
@@ -151,6 +156,44 @@ func TestQueryGetBlockByTxID(t *testing.T) {
 	assert.Equal(t, int32(shim.ERROR), res.Status, "GetBlockByTxID should have failed with blank txId.")
 }
 
+func TestQueryGetStateHash(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := "/var/hyperledger/test9/"
+	defer viper.Set("ledger.state.enableStateHash", viper.GetBool("ledger.state.enableStateHash"))
+	viper.Set("ledger.state.enableStateHash", true)
+	stub, err := setupTestLedger(chainid, path)
+	defer os.RemoveAll(path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	args := [][]byte{[]byte(GetStateHash), []byte(chainid), []byte("0")}
+	res := stub.MockInvoke("1", args)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetStateHash should have succeeded for block number: 0")
+	stateHash0 := &ledger2.StateHash{}
+	assert.NoError(t, json.Unmarshal(res.Payload, stateHash0))
+
+	addBlockForTesting(t, chainid)
+	args = [][]byte{[]byte(GetStateHash), []byte(chainid), []byte("1")}
+	res = stub.MockInvoke("2", args)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetStateHash should have succeeded for block number: 1")
+	stateHash1 := &ledger2.StateHash{}
+	assert.NoError(t, json.Unmarshal(res.Payload, stateHash1))
+	assert.Equal(t, uint64(1), stateHash1.BlockNumber)
+	assert.Contains(t, stateHash1.Namespaces, "ns1")
+	assert.Contains(t, stateHash1.Namespaces, "ns2")
+	assert.NotEqual(t, stateHash0.Hash, stateHash1.Hash)
+
+	// block number 2 should not be present in the ledger
+	args = [][]byte{[]byte(GetStateHash), []byte(chainid), []byte("2")}
+	res = stub.MockInvoke("3", args)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateHash should have failed with invalid number: 2")
+
+	args = [][]byte{[]byte(GetStateHash), []byte(chainid), []byte(nil)}
+	res = stub.MockInvoke("4", args)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateHash should have failed with nil block number")
+}
+
 func TestFailingAccessControl(t *testing.T) {
 	chainid := "mytestchainid6"
 	path := "/var/hyperledger/test6/"
@@ -205,6 +205,14 @@ func (*mockStub) GetHistoryForKey(key string) (shim.HistoryQueryIteratorInterfac
 	panic("implement me")
 }
 
+func (*mockStub) GetHistoryForKeyWithOptions(key string, startBlock uint64, endBlock uint64, reverse bool, pageSize int32, bookmark string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	panic("implement me")
+}
+
+func (*mockStub) GetHistoryForKeySince(key string, since *timestamp.Timestamp, reverse bool, pageSize int32, bookmark string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	panic("implement me")
+}
+
 func (*mockStub) GetCreator() ([]byte, error) {
 	panic("implement me")
 }
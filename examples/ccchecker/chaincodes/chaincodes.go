@@ -158,7 +158,7 @@ func (cc *CCClient) doInvokes(ctxt context.Context, chainID string,
 		}
 
 		var pResp *pb.ProposalResponse
-		if pResp, err = chaincode.ChaincodeInvokeOrQuery(spec, chainID, true, signer, ec, bc); err != nil {
+		if pResp, err = chaincode.ChaincodeInvokeOrQuery(spec, chainID, true, signer, ec, bc, nil); err != nil {
 			cc.invokeErr = err
 			break
 		}
@@ -237,7 +237,7 @@ func (cc *CCClient) validateIter(ctxt context.Context, iter int, chainID string,
 
 		var pResp *pb.ProposalResponse
 		var err error
-		if pResp, err = chaincode.ChaincodeInvokeOrQuery(spec, chainID, false, signer, ec, bc); err != nil {
+		if pResp, err = chaincode.ChaincodeInvokeOrQuery(spec, chainID, false, signer, ec, bc, nil); err != nil {
 			cc.queryErrs[iter] = err
 			break
 		}
@@ -36,6 +36,10 @@ under the License.
 //   peer chaincode query -C myc1 -n marbles -c '{"Args":["queryMarblesByOwner","tom"]}'
 //   peer chaincode query -C myc1 -n marbles -c '{"Args":["queryMarbles","{\"selector\":{\"owner\":\"tom\"}}"]}'
 
+//The index on owner defined in META-INF/statedb/couchdb/indexes/indexOwner.json is packaged with
+//the chaincode, and is created by the peer on a goleveldb state database when the chaincode is
+//instantiated or upgraded, provided the chaincode is installed on the peer by then
+
 //The following examples demonstrate creating indexes on CouchDB
 //Example hostname:port configurations
 //
@@ -27,6 +27,13 @@ type SecurityAdvisor interface {
 	// This method does not validate peerIdentity.
 	// This validation is supposed to be done appropriately during the execution flow.
 	OrgByPeerIdentity(PeerIdentityType) OrgIdentityType
+
+	// VerifyByOrgAdmin checks that signature is a valid signature of message
+	// under the verification key of adminIdentity, and that adminIdentity is
+	// an admin of the organization of peerIdentity, according to the MSP of
+	// a channel.
+	// If the verification succeeded, VerifyByOrgAdmin returns nil.
+	VerifyByOrgAdmin(peerIdentity, adminIdentity PeerIdentityType, signature, message []byte) error
 }
 
 // ChannelNotifier is implemented by the gossip component and is used for the peer
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package api
 
 import (
+	"crypto/tls"
 	"time"
 
 	"github.com/hyperledger/fabric/gossip/common"
@@ -71,6 +72,10 @@ type PeerSuspector func(identity PeerIdentityType) bool
 // security when communicating with remote peer endpoints
 type PeerSecureDialOpts func() []grpc.DialOption
 
+// PeerTLSCertificate returns the TLS certificate the peer currently uses,
+// which may change over time when the certificate is rotated
+type PeerTLSCertificate func() *tls.Certificate
+
 // PeerSignature defines a signature of a peer
 // on a given message
 type PeerSignature struct {
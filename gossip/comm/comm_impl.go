@@ -100,8 +100,10 @@ func NewCommInstanceWithServer(port int, idMapper identity.Mapper, peerIdentity
 	return commInst, nil
 }
 
-// NewCommInstance creates a new comm instance that binds itself to the given gRPC server
-func NewCommInstance(s *grpc.Server, cert *tls.Certificate, idStore identity.Mapper,
+// NewCommInstance creates a new comm instance that binds itself to the given gRPC server.
+// If certs is not nil, it is consulted on every handshake for the TLS certificate the peer
+// currently uses, so that certificate rotations are taken into account.
+func NewCommInstance(s *grpc.Server, certs api.PeerTLSCertificate, idStore identity.Mapper,
 	peerIdentity api.PeerIdentityType, secureDialOpts api.PeerSecureDialOpts,
 	dialOpts ...grpc.DialOption) (Comm, error) {
 
@@ -111,13 +113,14 @@ func NewCommInstance(s *grpc.Server, cert *tls.Certificate, idStore identity.Map
 		return nil, errors.WithStack(err)
 	}
 
-	if cert != nil {
+	if certs != nil {
 		inst := commInst.(*commImpl)
-		if len(cert.Certificate) == 0 {
+		if cert := certs(); cert == nil || len(cert.Certificate) == 0 {
 			inst.logger.Panic("Certificate supplied but certificate chain is empty")
 		} else {
 			inst.selfCertHash = certHashFromRawCert(cert.Certificate[0])
 		}
+		inst.tlsCerts = certs
 	}
 
 	proto.RegisterGossipServer(s, commInst.(*commImpl))
@@ -128,6 +131,7 @@ func NewCommInstance(s *grpc.Server, cert *tls.Certificate, idStore identity.Map
 type commImpl struct {
 	pubSub         *util.PubSub
 	selfCertHash   []byte
+	tlsCerts       api.PeerTLSCertificate
 	peerIdentity   api.PeerIdentityType
 	idMapper       identity.Mapper
 	logger         *logging.Logger
@@ -401,13 +405,25 @@ func extractRemoteAddress(stream stream) string {
 	return remoteAddress
 }
 
+// tlsCertHash returns the hash of the TLS certificate the peer currently
+// uses, or nil if TLS is not used
+func (c *commImpl) tlsCertHash() []byte {
+	if c.tlsCerts != nil {
+		if cert := c.tlsCerts(); cert != nil && len(cert.Certificate) != 0 {
+			return certHashFromRawCert(cert.Certificate[0])
+		}
+	}
+	return c.selfCertHash
+}
+
 func (c *commImpl) authenticateRemotePeer(stream stream) (*proto.ConnectionInfo, error) {
 	ctx := stream.Context()
 	remoteAddress := extractRemoteAddress(stream)
 	remoteCertHash := extractCertificateHashFromContext(ctx)
 	var err error
 	var cMsg *proto.SignedGossipMessage
-	useTLS := c.selfCertHash != nil
+	selfCertHash := c.tlsCertHash()
+	useTLS := selfCertHash != nil
 
 	signer := func(msg []byte) ([]byte, error) {
 		return c.idMapper.Sign(msg)
@@ -419,7 +435,7 @@ func (c *commImpl) authenticateRemotePeer(stream stream) (*proto.ConnectionInfo,
 		return nil, fmt.Errorf("No TLS certificate")
 	}
 
-	cMsg, err = c.createConnectionMsg(c.PKIID, c.selfCertHash, c.peerIdentity, signer)
+	cMsg, err = c.createConnectionMsg(c.PKIID, selfCertHash, c.peerIdentity, signer)
 	if err != nil {
 		return nil, err
 	}
@@ -346,6 +346,45 @@ func TestBasic(t *testing.T) {
 	waitForMessages(t, out, 2, "Didn't receive 2 messages")
 }
 
+func staticCert(cert tls.Certificate) api.PeerTLSCertificate {
+	return func() *tls.Certificate {
+		return &cert
+	}
+}
+
+func TestTLSCertificateRotation(t *testing.T) {
+	t.Parallel()
+	var lock sync.Mutex
+	cert := GenerateCertificatesOrPanic()
+	certs := func() *tls.Certificate {
+		lock.Lock()
+		defer lock.Unlock()
+		c := cert
+		return &c
+	}
+	srv := grpc.NewServer()
+	defer srv.Stop()
+	id := []byte("localhost:9612")
+	inst, err := NewCommInstance(srv, certs, identity.NewIdentityMapper(naiveSec, id, noopPurgeIdentity), id, nil)
+	assert.NoError(t, err)
+	defer inst.Stop()
+	comm := inst.(*commImpl)
+	assert.Equal(t, certHashFromRawCert(cert.Certificate[0]), comm.tlsCertHash())
+
+	// Rotate the certificate, the hash should follow it
+	lock.Lock()
+	cert = GenerateCertificatesOrPanic()
+	lock.Unlock()
+	assert.Equal(t, certHashFromRawCert(cert.Certificate[0]), comm.tlsCertHash())
+
+	// A certificate with an empty chain is rejected
+	assert.Panics(t, func() {
+		NewCommInstance(grpc.NewServer(), func() *tls.Certificate {
+			return &tls.Certificate{}
+		}, identity.NewIdentityMapper(naiveSec, id, noopPurgeIdentity), id, nil)
+	})
+}
+
 func TestProdConstructor(t *testing.T) {
 	t.Parallel()
 	peerIdentity := GenerateCertificatesOrPanic()
@@ -353,7 +392,9 @@ func TestProdConstructor(t *testing.T) {
 	defer srv.Stop()
 	defer lsnr.Close()
 	id := []byte("localhost:20000")
-	comm1, _ := NewCommInstance(srv, &peerIdentity, identity.NewIdentityMapper(naiveSec, id, noopPurgeIdentity), id, dialOpts)
+	comm1, _ := NewCommInstance(srv, staticCert(peerIdentity), identity.NewIdentityMapper(naiveSec, id, noopPurgeIdentity), id, dialOpts)
+	// Use the certificate of the gRPC layer instead of peerIdentity
+	comm1.(*commImpl).tlsCerts = nil
 	comm1.(*commImpl).selfCertHash = certHash
 	go srv.Serve(lsnr)
 
@@ -362,7 +403,9 @@ func TestProdConstructor(t *testing.T) {
 	defer srv.Stop()
 	defer lsnr.Close()
 	id = []byte("localhost:30000")
-	comm2, _ := NewCommInstance(srv, &peerIdentity, identity.NewIdentityMapper(naiveSec, id, noopPurgeIdentity), id, dialOpts)
+	comm2, _ := NewCommInstance(srv, staticCert(peerIdentity), identity.NewIdentityMapper(naiveSec, id, noopPurgeIdentity), id, dialOpts)
+	// Use the certificate of the gRPC layer instead of peerIdentity
+	comm2.(*commImpl).tlsCerts = nil
 	comm2.(*commImpl).selfCertHash = certHash
 	go srv.Serve(lsnr)
 	defer comm1.Stop()
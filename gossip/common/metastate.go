@@ -19,11 +19,17 @@ type NodeMetastate struct {
 
 	// Actual ledger height
 	LedgerHeight uint64
+
+	// MaxStateTransferBatchSize is the maximum amount of blocks the peer
+	// sends in response to a single state transfer request, or 0 if the peer
+	// doesn't advertise it. It is encoded after the ledger height, which is
+	// all that peers of previous versions read
+	MaxStateTransferBatchSize uint64
 }
 
 // NewNodeMetastate creates new meta data with given ledger height
 func NewNodeMetastate(height uint64) *NodeMetastate {
-	return &NodeMetastate{height}
+	return &NodeMetastate{LedgerHeight: height}
 }
 
 // Bytes decodes meta state into byte array for serialization
@@ -56,7 +62,15 @@ func FromBytes(buf []byte) (*NodeMetastate, error) {
 	// As bytes are written in the big endian to keep supporting
 	// cross platforming and for consistency reasons read also
 	// done using same order
-	err := binary.Read(reader, binary.BigEndian, &state)
+	err := binary.Read(reader, binary.BigEndian, &state.LedgerHeight)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// Peers of previous versions only encode the ledger height
+	if reader.Len() == 0 {
+		return &state, nil
+	}
+	err = binary.Read(reader, binary.BigEndian, &state.MaxStateTransferBatchSize)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -63,3 +63,28 @@ func TestNodeMetastate_FromBytes(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, updatedState.Height(), uint64(17))
 }
+
+// Check the deserialization of the metadata of peers of previous
+// versions, which only encode the ledger height
+func TestNodeMetastate_FromBytesLegacy(t *testing.T) {
+	metastate := NewNodeMetastate(17)
+	metastate.MaxStateTransferBatchSize = 100
+	bytes, err := metastate.Bytes()
+	assert.NoError(t, err)
+	assert.Len(t, bytes, 16)
+
+	state, err := FromBytes(bytes)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(17), state.Height())
+	assert.Equal(t, uint64(100), state.MaxStateTransferBatchSize)
+
+	// The ledger height alone
+	state, err = FromBytes(bytes[:8])
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(17), state.Height())
+	assert.Equal(t, uint64(0), state.MaxStateTransferBatchSize)
+
+	// A truncated batch size
+	_, err = FromBytes(bytes[:12])
+	assert.Error(t, err)
+}
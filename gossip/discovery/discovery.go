@@ -8,6 +8,7 @@ package discovery
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric/gossip/common"
 	proto "github.com/hyperledger/fabric/protos/gossip"
@@ -20,6 +21,11 @@ type CryptoService interface {
 
 	// SignMessage signs a message
 	SignMessage(m *proto.GossipMessage, internalEndpoint string) *proto.Envelope
+
+	// VerifyAlternativeEndpoints verifies that the alternative endpoints
+	// of the given member are signed by an admin of its organization,
+	// and returns them
+	VerifyAlternativeEndpoints(member *proto.Member) ([]string, error)
 }
 
 // EnvelopeFilter may or may not remove part of the Envelope
@@ -71,6 +77,10 @@ type NetworkMember struct {
 	PKIid            common.PKIidType
 	InternalEndpoint string
 	Properties       *proto.Properties
+	// AlternativeEndpoints are endpoints, other than Endpoint, which
+	// the peer can be reached at by foreign organizations.
+	// Only endpoints signed by an admin of the peer's organization are kept
+	AlternativeEndpoints []string
 }
 
 // String returns a string representation of the NetworkMember
@@ -88,12 +98,23 @@ func (n NetworkMember) PreferredEndpoint() string {
 	return n.Endpoint
 }
 
+// MemberState is a network member in the view, along with
+// whether it's considered alive and when it was last seen alive
+type MemberState struct {
+	NetworkMember
+	Alive    bool
+	LastSeen time.Time
+}
+
 // PeerIdentification encompasses a remote peer's
 // PKI-ID and whether its in the same org as the current
 // peer or not
 type PeerIdentification struct {
 	ID      common.PKIidType
 	SelfOrg bool
+	// Endpoint is the endpoint the peer was identified at,
+	// if it isn't the endpoint it was connected to
+	Endpoint string
 }
 
 type identifier func() (*PeerIdentification, error)
@@ -119,6 +140,10 @@ type Discovery interface {
 	// GetMembership returns the alive members in the view
 	GetMembership() []NetworkMember
 
+	// GetMembershipView returns the alive and the dead members in the view,
+	// along with the last time each of them was seen alive
+	GetMembershipView() []MemberState
+
 	// InitiateSync makes the instance ask a given number of peers
 	// for their membership information
 	InitiateSync(peerNum int)
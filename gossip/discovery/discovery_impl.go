@@ -163,6 +163,9 @@ func (d *gossipDiscoveryImpl) Connect(member NetworkMember, id identifier) {
 				Endpoint:         member.Endpoint,
 				PKIid:            id.ID,
 			}
+			if id.Endpoint != "" {
+				peer.InternalEndpoint, peer.Endpoint = id.Endpoint, id.Endpoint
+			}
 			m, err := d.createMembershipRequest(id.SelfOrg)
 			if err != nil {
 				d.logger.Warningf("Failed creating membership request: %+v", errors.WithStack(err))
@@ -256,10 +259,11 @@ func (d *gossipDiscoveryImpl) InitiateSync(peerNum int) {
 			internalEndpoint = aliveMembersAsSlice[i].Envelope.SecretEnvelope.InternalEndpoint()
 		}
 		netMember := &NetworkMember{
-			Endpoint:         pulledPeer.Endpoint,
-			Metadata:         pulledPeer.Metadata,
-			PKIid:            pulledPeer.PkiId,
-			InternalEndpoint: internalEndpoint,
+			Endpoint:             d.id2Member[string(pulledPeer.PkiId)].Endpoint,
+			Metadata:             pulledPeer.Metadata,
+			PKIid:                pulledPeer.PkiId,
+			InternalEndpoint:     internalEndpoint,
+			AlternativeEndpoints: d.id2Member[string(pulledPeer.PkiId)].AlternativeEndpoints,
 		}
 		peers2SendTo = append(peers2SendTo, netMember)
 	}
@@ -565,18 +569,21 @@ func (d *gossipDiscoveryImpl) resurrectMember(am *proto.SignedGossipMessage, t p
 	}
 
 	var internalEndpoint string
-	if prevNetMem := d.id2Member[string(pkiID)]; prevNetMem != nil {
+	prevNetMem := d.id2Member[string(pkiID)]
+	if prevNetMem != nil {
 		internalEndpoint = prevNetMem.InternalEndpoint
 	}
 	if am.Envelope.SecretEnvelope != nil {
 		internalEndpoint = am.Envelope.SecretEnvelope.InternalEndpoint()
 	}
 
+	alternativeEndpoints := d.alternativeEndpoints(member)
 	d.id2Member[string(pkiID)] = &NetworkMember{
-		Endpoint:         member.Endpoint,
-		Metadata:         member.Metadata,
-		PKIid:            member.PkiId,
-		InternalEndpoint: internalEndpoint,
+		Endpoint:             endpointOf(member, alternativeEndpoints, prevNetMem),
+		Metadata:             member.Metadata,
+		PKIid:                member.PkiId,
+		InternalEndpoint:     internalEndpoint,
+		AlternativeEndpoints: alternativeEndpoints,
 	}
 
 	delete(d.deadLastTS, string(pkiID))
@@ -597,6 +604,10 @@ func (d *gossipDiscoveryImpl) periodicalReconnectToDead() {
 				if d.comm.Ping(&member) {
 					d.logger.Debug(member, "is responding, sending membership request")
 					d.sendMembershipRequest(&member, true)
+				} else if endpoint := d.pingAlternativeEndpoints(member); endpoint != "" {
+					d.logger.Info(member, "is responding at its alternative endpoint", endpoint, ", sending membership request")
+					member.Endpoint, member.InternalEndpoint = endpoint, ""
+					d.sendMembershipRequest(&member, true)
 				} else {
 					d.logger.Debug(member, "is still dead")
 				}
@@ -609,6 +620,37 @@ func (d *gossipDiscoveryImpl) periodicalReconnectToDead() {
 	}
 }
 
+// pingAlternativeEndpoints probes the endpoints the given dead member announced,
+// other than the one it was pinged at, and returns the first responsive one.
+// The member is reached at that endpoint from then on
+func (d *gossipDiscoveryImpl) pingAlternativeEndpoints(member NetworkMember) string {
+	d.lock.RLock()
+	var endpoints []string
+	if am := d.deadMembership.MsgByID(member.PKIid); am != nil {
+		endpoints = []string{am.GetAliveMsg().Membership.Endpoint}
+		if netMember := d.id2Member[string(member.PKIid)]; netMember != nil {
+			endpoints = append(endpoints, netMember.AlternativeEndpoints...)
+		}
+	}
+	d.lock.RUnlock()
+
+	for _, endpoint := range endpoints {
+		if endpoint == "" || endpoint == member.PreferredEndpoint() {
+			continue
+		}
+		if !d.comm.Ping(&NetworkMember{Endpoint: endpoint, PKIid: member.PKIid}) {
+			continue
+		}
+		d.lock.Lock()
+		if netMember := d.id2Member[string(member.PKIid)]; netMember != nil {
+			netMember.Endpoint = endpoint
+		}
+		d.lock.Unlock()
+		return endpoint
+	}
+	return ""
+}
+
 func (d *gossipDiscoveryImpl) sendMembershipRequest(member *NetworkMember, includeInternalEndpoint bool) {
 	m, err := d.createMembershipRequest(includeInternalEndpoint)
 	if err != nil {
@@ -802,9 +844,11 @@ func (d *gossipDiscoveryImpl) learnExistingMembers(aliveArr []*proto.SignedGossi
 
 		// update member's data
 		member := d.id2Member[string(am.Membership.PkiId)]
-		member.Endpoint = am.Membership.Endpoint
+		alternativeEndpoints := d.alternativeEndpoints(am.Membership)
+		member.Endpoint = endpointOf(am.Membership, alternativeEndpoints, member)
 		member.Metadata = am.Membership.Metadata
 		member.InternalEndpoint = internalEndpoint
+		member.AlternativeEndpoints = alternativeEndpoints
 
 		if _, isKnownAsDead := d.deadLastTS[string(am.Membership.PkiId)]; isKnownAsDead {
 			d.logger.Warning(am.Membership, "has already expired")
@@ -884,15 +928,18 @@ func (d *gossipDiscoveryImpl) learnNewMembers(aliveMembers []*proto.SignedGossip
 				internalEndpoint = m.Envelope.SecretEnvelope.InternalEndpoint()
 			}
 
-			if prevNetMem := d.id2Member[string(member.Membership.PkiId)]; prevNetMem != nil {
+			prevNetMem := d.id2Member[string(member.Membership.PkiId)]
+			if prevNetMem != nil {
 				internalEndpoint = prevNetMem.InternalEndpoint
 			}
 
+			alternativeEndpoints := d.alternativeEndpoints(member.Membership)
 			d.id2Member[string(member.Membership.PkiId)] = &NetworkMember{
-				Endpoint:         member.Membership.Endpoint,
-				Metadata:         member.Membership.Metadata,
-				PKIid:            member.Membership.PkiId,
-				InternalEndpoint: internalEndpoint,
+				Endpoint:             endpointOf(member.Membership, alternativeEndpoints, prevNetMem),
+				Metadata:             member.Membership.Metadata,
+				PKIid:                member.Membership.PkiId,
+				InternalEndpoint:     internalEndpoint,
+				AlternativeEndpoints: alternativeEndpoints,
 			}
 		}
 	}
@@ -908,17 +955,73 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 	response := []NetworkMember{}
 	for _, m := range d.aliveMembership.ToSlice() {
 		member := m.GetAliveMsg()
+		netMember := d.id2Member[string(member.Membership.PkiId)]
 		response = append(response, NetworkMember{
-			PKIid:            member.Membership.PkiId,
-			Endpoint:         member.Membership.Endpoint,
-			Metadata:         member.Membership.Metadata,
-			InternalEndpoint: d.id2Member[string(m.GetAliveMsg().Membership.PkiId)].InternalEndpoint,
+			PKIid:                member.Membership.PkiId,
+			Endpoint:             netMember.Endpoint,
+			Metadata:             member.Membership.Metadata,
+			InternalEndpoint:     netMember.InternalEndpoint,
+			AlternativeEndpoints: netMember.AlternativeEndpoints,
 		})
 	}
 	return response
 
 }
 
+func (d *gossipDiscoveryImpl) GetMembershipView() []MemberState {
+	if d.toDie() {
+		return []MemberState{}
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	view := []MemberState{}
+	addToView := func(lastSeenMap map[string]*timestamp, alive bool) {
+		for pkiIDStr, ts := range lastSeenMap {
+			member := d.id2Member[pkiIDStr]
+			if member == nil {
+				continue
+			}
+			view = append(view, MemberState{
+				NetworkMember: *member,
+				Alive:         alive,
+				LastSeen:      ts.lastSeen,
+			})
+		}
+	}
+	addToView(d.aliveLastTS, true)
+	addToView(d.deadLastTS, false)
+	return view
+}
+
+// alternativeEndpoints returns the alternative endpoints of the given member,
+// or nil if they aren't signed by an admin of its organization
+func (d *gossipDiscoveryImpl) alternativeEndpoints(member *proto.Member) []string {
+	if member.AlternativeEndpoints == nil {
+		return nil
+	}
+	endpoints, err := d.crypt.VerifyAlternativeEndpoints(member)
+	if err != nil {
+		d.logger.Debugf("Ignoring alternative endpoints of %v: %+v", member, err)
+		return nil
+	}
+	return endpoints
+}
+
+// endpointOf returns the endpoint to reach the given member at, which is the endpoint
+// it announces, unless it was last reached at one of its alternative endpoints
+func endpointOf(member *proto.Member, alternativeEndpoints []string, prevNetMem *NetworkMember) string {
+	if prevNetMem == nil {
+		return member.Endpoint
+	}
+	for _, endpoint := range alternativeEndpoints {
+		if endpoint == prevNetMem.Endpoint {
+			return endpoint
+		}
+	}
+	return member.Endpoint
+}
+
 func tsToTime(ts uint64) time.Time {
 	return time.Unix(int64(0), int64(ts))
 }
@@ -938,10 +1041,11 @@ func (d *gossipDiscoveryImpl) UpdateEndpoint(endpoint string) {
 
 func (d *gossipDiscoveryImpl) Self() NetworkMember {
 	return NetworkMember{
-		Endpoint:         d.self.Endpoint,
-		Metadata:         d.self.Metadata,
-		PKIid:            d.self.PKIid,
-		InternalEndpoint: d.self.InternalEndpoint,
+		Endpoint:             d.self.Endpoint,
+		Metadata:             d.self.Metadata,
+		PKIid:                d.self.PKIid,
+		InternalEndpoint:     d.self.InternalEndpoint,
+		AlternativeEndpoints: d.self.AlternativeEndpoints,
 	}
 }
 
@@ -8,6 +8,7 @@ package discovery
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
@@ -56,6 +57,8 @@ type dummyCommModule struct {
 	lastSeqs     map[string]uint64
 	shouldGossip bool
 	mock         *mock.Mock
+	// alternativeEndpoints are put in the alive messages signed
+	alternativeEndpoints *proto.SignedEndpoints
 }
 
 type gossipInstance struct {
@@ -74,6 +77,11 @@ func (comm *dummyCommModule) ValidateAliveMsg(am *proto.SignedGossipMessage) boo
 }
 
 func (comm *dummyCommModule) SignMessage(am *proto.GossipMessage, internalEndpoint string) *proto.Envelope {
+	if am.IsAliveMsg() {
+		comm.lock.RLock()
+		am.GetAliveMsg().Membership.AlternativeEndpoints = comm.alternativeEndpoints
+		comm.lock.RUnlock()
+	}
 	am.NoopSign()
 
 	secret := &proto.Secret{
@@ -90,6 +98,24 @@ func (comm *dummyCommModule) SignMessage(am *proto.GossipMessage, internalEndpoi
 	return env
 }
 
+// VerifyAlternativeEndpoints accepts alternative endpoints signed by "admin",
+// where a signature is the message itself
+func (comm *dummyCommModule) VerifyAlternativeEndpoints(member *proto.Member) ([]string, error) {
+	endpoints, err := member.AlternativeEndpoints.Verify(func(admin, sig, msg []byte) error {
+		if string(admin) != "admin" || !bytes.Equal(sig, msg) {
+			return errors.New("bad signature")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(endpoints.PeerIdentity, member.PkiId) {
+		return nil, errors.New("endpoints of another peer")
+	}
+	return endpoints.Endpoints, nil
+}
+
 func (comm *dummyCommModule) Gossip(msg *proto.SignedGossipMessage) {
 	if !comm.shouldGossip {
 		return
@@ -1245,3 +1271,130 @@ func portOfEndpoint(endpoint string) int {
 	port, _ := strconv.ParseInt(strings.Split(endpoint, ":")[1], 10, 64)
 	return int(port)
 }
+
+func TestAlternativeEndpointsPropagation(t *testing.T) {
+	t.Parallel()
+	inst1 := createDiscoveryInstance(14611, "d1", []string{bootPeer(14611)})
+	inst2 := createDiscoveryInstance(14612, "d2", []string{bootPeer(14611)})
+	defer inst1.Stop()
+	defer inst2.Stop()
+
+	sign := func(msg []byte) ([]byte, error) {
+		return msg, nil
+	}
+	// Endpoints signed by someone who isn't an admin are ignored
+	forged, _ := proto.NewSignedEndpoints([]byte(bootPeer(14612)), []string{"peer2-forged:7051"}, []byte("someone"), sign)
+	inst2.comm.lock.Lock()
+	inst2.comm.alternativeEndpoints = forged
+	inst2.comm.lock.Unlock()
+
+	waitUntilOrFail(t, func() bool {
+		return len(inst1.GetMembership()) == 1
+	})
+	time.Sleep(getAliveTimeInterval() * 2)
+	assert.Empty(t, inst1.GetMembership()[0].AlternativeEndpoints)
+
+	signed, _ := proto.NewSignedEndpoints([]byte(bootPeer(14612)), []string{"peer2-alt:7051"}, []byte("admin"), sign)
+	inst2.comm.lock.Lock()
+	inst2.comm.alternativeEndpoints = signed
+	inst2.comm.lock.Unlock()
+
+	waitUntilOrFail(t, func() bool {
+		members := inst1.GetMembership()
+		return len(members) == 1 && len(members[0].AlternativeEndpoints) == 1 &&
+			members[0].AlternativeEndpoints[0] == "peer2-alt:7051"
+	})
+	assert.Equal(t, bootPeer(14612), inst1.GetMembership()[0].Endpoint)
+}
+
+// pingingComm is a CommService which only responds to pings at some endpoints
+type pingingComm struct {
+	CommService
+	responsive map[string]bool
+	pinged     []string
+}
+
+func (pc *pingingComm) Ping(peer *NetworkMember) bool {
+	pc.pinged = append(pc.pinged, peer.PreferredEndpoint())
+	return pc.responsive[peer.PreferredEndpoint()]
+}
+
+func TestPingAlternativeEndpoints(t *testing.T) {
+	member := &proto.Member{
+		Endpoint: "peer1:7051",
+		PkiId:    common.PKIidType("p1"),
+	}
+	alternativeEndpoints := []string{"peer1-alt:7051", "peer1-alt2:7051"}
+	assert.Equal(t, "peer1:7051", endpointOf(member, alternativeEndpoints, nil))
+	assert.Equal(t, "peer1:7051", endpointOf(member, alternativeEndpoints, &NetworkMember{Endpoint: "peer1-old:7051"}))
+	assert.Equal(t, "peer1-alt2:7051", endpointOf(member, alternativeEndpoints, &NetworkMember{Endpoint: "peer1-alt2:7051"}))
+	assert.Equal(t, "peer1:7051", endpointOf(member, nil, &NetworkMember{Endpoint: "peer1-alt2:7051"}))
+
+	comm := &pingingComm{responsive: map[string]bool{"peer1-alt2:7051": true}}
+	d := &gossipDiscoveryImpl{
+		lock:           &sync.RWMutex{},
+		comm:           comm,
+		deadMembership: util.NewMembershipStore(),
+		id2Member: map[string]*NetworkMember{
+			"p1": {Endpoint: "peer1:7051", PKIid: member.PkiId, AlternativeEndpoints: alternativeEndpoints},
+		},
+	}
+	d.deadMembership.Put(member.PkiId, &proto.SignedGossipMessage{GossipMessage: &proto.GossipMessage{
+		Content: &proto.GossipMessage_AliveMsg{AliveMsg: &proto.AliveMessage{Membership: member}},
+	}})
+
+	// The dead member is reached at the first responsive endpoint it announced,
+	// other than the one it was already pinged at
+	assert.Equal(t, "peer1-alt2:7051", d.pingAlternativeEndpoints(*d.id2Member["p1"]))
+	assert.Equal(t, []string{"peer1-alt:7051", "peer1-alt2:7051"}, comm.pinged)
+	assert.Equal(t, "peer1-alt2:7051", d.id2Member["p1"].Endpoint)
+
+	// Once reached at an alternative endpoint, the endpoint it announced is an alternative as well
+	comm.responsive = map[string]bool{"peer1:7051": true}
+	assert.Equal(t, "peer1:7051", d.pingAlternativeEndpoints(*d.id2Member["p1"]))
+	assert.Equal(t, "peer1:7051", d.id2Member["p1"].Endpoint)
+
+	comm.responsive = map[string]bool{}
+	assert.Equal(t, "", d.pingAlternativeEndpoints(*d.id2Member["p1"]))
+	assert.Equal(t, "peer1:7051", d.id2Member["p1"].Endpoint)
+}
+
+func TestMembershipView(t *testing.T) {
+	t.Parallel()
+	inst1 := createDiscoveryInstance(14613, "d1", []string{bootPeer(14613)})
+	inst2 := createDiscoveryInstance(14614, "d2", []string{bootPeer(14613)})
+	inst3 := createDiscoveryInstance(14615, "d3", []string{bootPeer(14613)})
+	defer inst1.Stop()
+	defer inst2.Stop()
+
+	assertMembership(t, []*gossipInstance{inst1, inst2, inst3}, 2)
+	view := inst1.GetMembershipView()
+	assert.Len(t, view, 2)
+	for _, member := range view {
+		assert.True(t, member.Alive)
+		assert.False(t, member.LastSeen.IsZero())
+	}
+
+	stopTime := time.Now()
+	waitUntilOrFailBlocking(t, inst3.Stop)
+	waitUntilOrFail(t, func() bool {
+		for _, member := range inst1.GetMembershipView() {
+			if !member.Alive {
+				return true
+			}
+		}
+		return false
+	})
+
+	view = inst1.GetMembershipView()
+	assert.Len(t, view, 2)
+	for _, member := range view {
+		if member.Endpoint == bootPeer(14615) {
+			assert.False(t, member.Alive)
+			assert.True(t, member.LastSeen.Before(stopTime.Add(time.Second)))
+			continue
+		}
+		assert.True(t, member.Alive)
+		assert.Equal(t, bootPeer(14614), member.Endpoint)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/op/go-logging"
 	"github.com/spf13/viper"
@@ -67,6 +68,10 @@ import (
 // 	If a proposal message from a peer with an ID lower
 // 	than yourself was received, return.
 //	Else, declare yourself a leader
+//
+// Which ID is "lower" is decided by the LeaderSelectionStrategy, which by default
+// compares the IDs themselves. A strategy may also let a follower that receives
+// a leadership declaration from a peer with a higher ID take over the leadership
 
 // LeaderElectionAdapter is used by the leader election module
 // to send and receive messages and to get membership information
@@ -121,7 +126,14 @@ func noopCallback(_ bool) {
 }
 
 // NewLeaderElectionService returns a new LeaderElectionService
+// which prefers the peer with the lowest ID as the leader
 func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback leadershipCallback) LeaderElectionService {
+	return NewLeaderElectionServiceWithStrategy(adapter, id, callback, NewLowestIDStrategy())
+}
+
+// NewLeaderElectionServiceWithStrategy returns a new LeaderElectionService
+// which selects the leader according to the given strategy
+func NewLeaderElectionServiceWithStrategy(adapter LeaderElectionAdapter, id string, callback leadershipCallback, strategy LeaderSelectionStrategy) LeaderElectionService {
 	if len(id) == 0 {
 		panic("Empty id")
 	}
@@ -133,6 +145,7 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 		interruptChan: make(chan struct{}, 1),
 		logger:        util.GetLogger(util.LoggingElectionModule, ""),
 		callback:      noopCallback,
+		strategy:      strategy,
 	}
 
 	if callback != nil {
@@ -145,8 +158,12 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 
 // leaderElectionSvcImpl is an implementation of a LeaderElectionService
 type leaderElectionSvcImpl struct {
-	id        peerID
-	proposals *util.Set
+	// noPreemptUntil is the time, in nanoseconds since the Unix epoch, until
+	// which this peer doesn't take over the leadership, after it yielded.
+	// It is accessed atomically, hence it is first to be 64-bit aligned
+	noPreemptUntil int64
+	id             peerID
+	proposals      *util.Set
 	sync.Mutex
 	stopChan      chan struct{}
 	interruptChan chan struct{}
@@ -160,6 +177,10 @@ type leaderElectionSvcImpl struct {
 	logger        *logging.Logger
 	callback      leadershipCallback
 	yieldTimer    *time.Timer
+	strategy      LeaderSelectionStrategy
+	// preempt is set when this peer should take over the leadership
+	// from a leader the strategy prefers it over
+	preempt int32
 }
 
 func (le *leaderElectionSvcImpl) start() {
@@ -205,8 +226,11 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 		if le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if le.prefers(msg.SenderID(), le.id) && le.IsLeader() {
 			le.stopBeingLeader()
+		} else if !le.IsLeader() && !le.isYielding() && time.Now().UnixNano() > atomic.LoadInt64(&le.noPreemptUntil) &&
+			le.strategy.Preempts(common.PKIidType(le.id), common.PKIidType(msg.SenderID())) {
+			atomic.StoreInt32(&le.preempt, int32(1))
 		}
 	} else {
 		// We shouldn't get here
@@ -253,6 +277,11 @@ func (le *leaderElectionSvcImpl) run() {
 		if le.shouldStop() {
 			return
 		}
+		if !le.IsLeader() && atomic.CompareAndSwapInt32(&le.preempt, int32(1), int32(0)) {
+			le.logger.Info(le.id, ": Taking over the leadership from a less preferred leader")
+			le.beLeader()
+			atomic.StoreInt32(&le.leaderExists, int32(1))
+		}
 		if le.IsLeader() {
 			le.leader()
 		} else {
@@ -288,7 +317,7 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	// for being a leader
 	for _, o := range le.proposals.ToArray() {
 		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
+		if le.prefers(peerID(id), le.id) {
 			return
 		}
 	}
@@ -360,6 +389,12 @@ func (le *leaderElectionSvcImpl) isAlive(id peerID) bool {
 	return false
 }
 
+// prefers returns whether the peer with the first ID is a
+// better candidate for the leadership than the second
+func (le *leaderElectionSvcImpl) prefers(id1, id2 peerID) bool {
+	return le.strategy.Prefers(common.PKIidType(id1), common.PKIidType(id2))
+}
+
 func (le *leaderElectionSvcImpl) isLeaderExists() bool {
 	return atomic.LoadInt32(&le.leaderExists) == int32(1)
 }
@@ -407,14 +442,18 @@ func (le *leaderElectionSvcImpl) Yield() {
 	if !le.IsLeader() || le.isYielding() {
 		return
 	}
+	yieldTimeout := getLeaderAliveThreshold() * 6
 	// Turn on the yield flag
 	atomic.StoreInt32(&le.yield, int32(1))
+	// Don't take the leadership back from the next leader until the yield times out
+	atomic.StoreInt32(&le.preempt, int32(0))
+	atomic.StoreInt64(&le.noPreemptUntil, time.Now().Add(yieldTimeout).UnixNano())
 	// Stop being a leader
 	le.stopBeingLeader()
 	// Clear the leader exists flag since it could be that we are the leader
 	atomic.StoreInt32(&le.leaderExists, int32(0))
 	// Clear the yield flag in any case afterwards
-	le.yieldTimer = time.AfterFunc(getLeaderAliveThreshold()*6, func() {
+	le.yieldTimer = time.AfterFunc(yieldTimeout, func() {
 		atomic.StoreInt32(&le.yield, int32(0))
 	})
 }
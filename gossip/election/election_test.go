@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -143,11 +144,15 @@ func (p *peer) isCallbackInvoked() bool {
 }
 
 func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
+	return createPeersWithStrategy(spawnInterval, NewLowestIDStrategy(), ids...)
+}
+
+func createPeersWithStrategy(spawnInterval time.Duration, strategy LeaderSelectionStrategy, ids ...int) []*peer {
 	peers := make([]*peer, len(ids))
 	peerMap := make(map[string]*peer)
 	l := &sync.RWMutex{}
 	for i, id := range ids {
-		p := createPeer(id, peerMap, l)
+		p := createPeer(id, peerMap, l, strategy)
 		if spawnInterval != 0 {
 			time.Sleep(spawnInterval)
 		}
@@ -156,11 +161,11 @@ func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
 	return peers
 }
 
-func createPeer(id int, peerMap map[string]*peer, l *sync.RWMutex) *peer {
+func createPeer(id int, peerMap map[string]*peer, l *sync.RWMutex, strategy LeaderSelectionStrategy) *peer {
 	idStr := fmt.Sprintf("p%d", id)
 	c := make(chan Msg, 100)
 	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
-	p.LeaderElectionService = NewLeaderElectionService(p, idStr, p.leaderCallback)
+	p.LeaderElectionService = NewLeaderElectionServiceWithStrategy(p, idStr, p.leaderCallback, strategy)
 	l.Lock()
 	peerMap[idStr] = p
 	l.Unlock()
@@ -417,6 +422,93 @@ func TestPartition(t *testing.T) {
 
 }
 
+func TestStaticLeaderTakeover(t *testing.T) {
+	t.Parallel()
+	// Scenario: Peers spawn one by one in descending order,
+	// and the leadership is pinned to the last peer that spawns.
+	// Expected outcome: the pinned peer takes over the leadership from the first peer
+	// and keeps it, and when it yields, the first peer takes over until the yield times out
+	isPinned := func(id common.PKIidType) bool {
+		return string(id) == "p2"
+	}
+	peers := createPeersWithStrategy(getStartupGracePeriod()+getLeadershipDeclarationInterval(),
+		NewStaticStrategy(isPinned, NewLowestIDStrategy()), 5, 4, 3, 2)
+	ensureLeader := func(id string) func() bool {
+		return func() bool {
+			leaders := waitForLeaderElection(t, peers)
+			return len(leaders) == 1 && leaders[0] == id
+		}
+	}
+	waitForBoolFunc(t, ensureLeader("p2"), true)
+	time.Sleep(getLeaderAliveThreshold() * 2)
+	waitForBoolFunc(t, ensureLeader("p2"), true)
+
+	peers[3].Yield()
+	waitForBoolFunc(t, ensureLeader("p3"), true)
+	waitForBoolFunc(t, ensureLeader("p2"), true)
+}
+
+func TestConsistentHashTakeover(t *testing.T) {
+	t.Parallel()
+	// Scenario: Peers spawn one by one, and the leader is selected by consistent hashing.
+	// Expected outcome: the most preferred peer ends up as the leader, although it spawned last
+	strategy := NewConsistentHashStrategy(common.ChainID("mychannel"))
+	ids := []int{0, 1, 2, 3}
+	best := 0
+	for _, id := range ids {
+		if strategy.Prefers(common.PKIidType(fmt.Sprintf("p%d", id)), common.PKIidType(fmt.Sprintf("p%d", best))) {
+			best = id
+		}
+	}
+	// Spawn the most preferred peer last
+	ids[best], ids[len(ids)-1] = ids[len(ids)-1], ids[best]
+	peers := createPeersWithStrategy(getStartupGracePeriod()+getLeadershipDeclarationInterval(), strategy, ids...)
+	waitForBoolFunc(t, func() bool {
+		leaders := waitForLeaderElection(t, peers)
+		return len(leaders) == 1 && leaders[0] == fmt.Sprintf("p%d", best)
+	}, true)
+}
+
+func TestLeaderSelectionStrategies(t *testing.T) {
+	lowestID := NewLowestIDStrategy()
+	assert.True(t, lowestID.Prefers(common.PKIidType("p0"), common.PKIidType("p1")))
+	assert.False(t, lowestID.Prefers(common.PKIidType("p1"), common.PKIidType("p0")))
+	assert.False(t, lowestID.Preempts(common.PKIidType("p0"), common.PKIidType("p1")))
+
+	// Consistent hashing ranks peers the same way on a channel, and spreads
+	// the leadership of many channels over the peers
+	peerIDs := []common.PKIidType{common.PKIidType("p0"), common.PKIidType("p1"), common.PKIidType("p2"), common.PKIidType("p3")}
+	leadership := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		strategy := NewConsistentHashStrategy(common.ChainID(fmt.Sprintf("channel%d", i)))
+		best := peerIDs[0]
+		for _, id := range peerIDs[1:] {
+			assert.NotEqual(t, strategy.Prefers(id, best), strategy.Prefers(best, id))
+			assert.Equal(t, strategy.Prefers(id, best), strategy.Preempts(id, best))
+			if strategy.Prefers(id, best) {
+				best = id
+			}
+		}
+		leadership[string(best)]++
+	}
+	assert.Len(t, leadership, len(peerIDs))
+	for _, channels := range leadership {
+		assert.True(t, channels > 10, "leadership isn't spread over the peers: %v", leadership)
+	}
+
+	// The pinned peer is preferred over and preempts the others,
+	// which are ranked by the fallback strategy
+	static := NewStaticStrategy(func(id common.PKIidType) bool {
+		return string(id) == "p3"
+	}, lowestID)
+	assert.True(t, static.Prefers(common.PKIidType("p3"), common.PKIidType("p0")))
+	assert.False(t, static.Prefers(common.PKIidType("p0"), common.PKIidType("p3")))
+	assert.True(t, static.Prefers(common.PKIidType("p0"), common.PKIidType("p1")))
+	assert.True(t, static.Preempts(common.PKIidType("p3"), common.PKIidType("p0")))
+	assert.False(t, static.Preempts(common.PKIidType("p0"), common.PKIidType("p3")))
+	assert.False(t, static.Preempts(common.PKIidType("p0"), common.PKIidType("p1")))
+}
+
 func TestConfigFromFile(t *testing.T) {
 	preStartupGracePeriod := getStartupGracePeriod()
 	preMembershipSampleInterval := getMembershipSampleInterval()
@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package election
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/hyperledger/fabric/gossip/common"
+)
+
+// LeaderSelectionStrategy decides which peers of the organization
+// are preferred for the leadership of a channel
+type LeaderSelectionStrategy interface {
+	// Prefers returns whether the peer with the first PKI-ID is a better
+	// candidate for the leadership than the peer with the second PKI-ID
+	Prefers(id1, id2 common.PKIidType) bool
+
+	// Preempts returns whether the peer with the first PKI-ID takes over the
+	// leadership from the current leader with the second PKI-ID, instead of
+	// waiting for the current leader to go away
+	Preempts(id, leaderID common.PKIidType) bool
+}
+
+// NewLowestIDStrategy returns a LeaderSelectionStrategy which prefers the peer
+// with the lowest PKI-ID. It doesn't preempt an existing leader, so the leadership
+// stays with the peer which acquired it
+func NewLowestIDStrategy() LeaderSelectionStrategy {
+	return &lowestIDStrategy{}
+}
+
+type lowestIDStrategy struct{}
+
+func (*lowestIDStrategy) Prefers(id1, id2 common.PKIidType) bool {
+	return bytes.Compare(id1, id2) < 0
+}
+
+func (*lowestIDStrategy) Preempts(id, leaderID common.PKIidType) bool {
+	return false
+}
+
+// NewConsistentHashStrategy returns a LeaderSelectionStrategy which prefers the
+// peer with the lowest hash of the channel and its PKI-ID. Every peer ranks the
+// candidates the same way, but differently on every channel, so the leadership
+// of many channels spreads over the alive peers of the organization. The best
+// candidate preempts an existing leader, so the leadership rebalances when
+// peers come back
+func NewConsistentHashStrategy(channel common.ChainID) LeaderSelectionStrategy {
+	return &consistentHashStrategy{channel: channel}
+}
+
+type consistentHashStrategy struct {
+	channel common.ChainID
+}
+
+func (chs *consistentHashStrategy) hash(id common.PKIidType) []byte {
+	h := sha256.New()
+	h.Write(chs.channel)
+	h.Write(id)
+	return h.Sum(nil)
+}
+
+func (chs *consistentHashStrategy) Prefers(id1, id2 common.PKIidType) bool {
+	if cmp := bytes.Compare(chs.hash(id1), chs.hash(id2)); cmp != 0 {
+		return cmp < 0
+	}
+	return bytes.Compare(id1, id2) < 0
+}
+
+func (chs *consistentHashStrategy) Preempts(id, leaderID common.PKIidType) bool {
+	return chs.Prefers(id, leaderID)
+}
+
+// NewStaticStrategy returns a LeaderSelectionStrategy which prefers the peer
+// the leadership of the channel is pinned to, which preempts any other leader.
+// The other peers are ranked, and preempt each other, by the given strategy
+func NewStaticStrategy(isPinned func(common.PKIidType) bool, fallback LeaderSelectionStrategy) LeaderSelectionStrategy {
+	return &staticStrategy{isPinned: isPinned, fallback: fallback}
+}
+
+type staticStrategy struct {
+	isPinned func(common.PKIidType) bool
+	fallback LeaderSelectionStrategy
+}
+
+func (ss *staticStrategy) Prefers(id1, id2 common.PKIidType) bool {
+	pinned1, pinned2 := ss.isPinned(id1), ss.isPinned(id2)
+	if pinned1 != pinned2 {
+		return pinned1
+	}
+	return ss.fallback.Prefers(id1, id2)
+}
+
+func (ss *staticStrategy) Preempts(id, leaderID common.PKIidType) bool {
+	pinned, leaderPinned := ss.isPinned(id), ss.isPinned(leaderID)
+	if pinned != leaderPinned {
+		return pinned
+	}
+	return ss.fallback.Preempts(id, leaderID)
+}
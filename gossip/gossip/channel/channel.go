@@ -48,6 +48,10 @@ type GossipChannel interface {
 	// GetPeers returns a list of peers with metadata as published by them
 	GetPeers() []discovery.NetworkMember
 
+	// StateInfoOf returns the StateInfo message the given peer published
+	// in the channel, or nil if it isn't known
+	StateInfoOf(pkiID common.PKIidType) *proto.StateInfo
+
 	// PeerFilter receives a SubChannelSelectionCriteria and returns a RoutingFilter that selects
 	// only peer identities that match the given criteria
 	PeerFilter(api.SubChannelSelectionCriteria) filter.RoutingFilter
@@ -311,6 +315,16 @@ func (gc *gossipChannel) GetPeers() []discovery.NetworkMember {
 	return members
 }
 
+// StateInfoOf returns the StateInfo message the given peer published
+// in the channel, or nil if it isn't known
+func (gc *gossipChannel) StateInfoOf(pkiID common.PKIidType) *proto.StateInfo {
+	stateInf := gc.stateInfoMsgStore.MsgByID(pkiID)
+	if stateInf == nil {
+		return nil
+	}
+	return stateInf.GetStateInfo()
+}
+
 func (gc *gossipChannel) requestStateInfo() {
 	req, err := gc.createStateInfoRequest()
 	if err != nil {
@@ -7,7 +7,6 @@ SPDX-License-Identifier: Apache-2.0
 package gossip
 
 import (
-	"crypto/tls"
 	"time"
 
 	"github.com/hyperledger/fabric/gossip/api"
@@ -34,6 +33,11 @@ type Gossip interface {
 	// and also subscribed to the channel given
 	PeersOfChannel(common.ChainID) []discovery.NetworkMember
 
+	// MembershipView returns the alive and the dead members of the given channel,
+	// along with the last time each of them was seen alive and the properties
+	// each of them published about its channel-related state
+	MembershipView(common.ChainID) []discovery.MemberState
+
 	// UpdateMetadata updates the self metadata of the discovery layer
 	// the peer publishes to other peers
 	UpdateMetadata(metadata []byte)
@@ -100,11 +104,12 @@ type Config struct {
 
 	SkipBlockVerification bool // Should we skip verifying block messages or not
 
-	PublishCertPeriod        time.Duration    // Time from startup certificates are included in Alive messages
-	PublishStateInfoInterval time.Duration    // Determines frequency of pushing state info messages to peers
-	RequestStateInfoInterval time.Duration    // Determines frequency of pulling state info messages from peers
-	TLSServerCert            *tls.Certificate // TLS certificate of the peer
+	PublishCertPeriod        time.Duration          // Time from startup certificates are included in Alive messages
+	PublishStateInfoInterval time.Duration          // Determines frequency of pushing state info messages to peers
+	RequestStateInfoInterval time.Duration          // Determines frequency of pulling state info messages from peers
+	TLSServerCert            api.PeerTLSCertificate // Returns the TLS certificate of the peer
 
-	InternalEndpoint string // Endpoint we publish to peers in our organization
-	ExternalEndpoint string // Peer publishes this endpoint instead of SelfEndpoint to foreign organizations
+	InternalEndpoint     string   // Endpoint we publish to peers in our organization
+	ExternalEndpoint     string   // Peer publishes this endpoint instead of SelfEndpoint to foreign organizations
+	AlternativeEndpoints *proto.SignedEndpoints // Endpoints foreign organizations reach the peer at when the ExternalEndpoint isn't reachable, signed by an admin of the peer's organization
 }
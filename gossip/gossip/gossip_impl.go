@@ -8,13 +8,13 @@ package gossip
 
 import (
 	"bytes"
-	"crypto/tls"
 	"fmt"
 	"reflect"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	pb "github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
@@ -140,10 +140,11 @@ func (g *gossipServiceImpl) newStateInfoMsgStore() msgstore.MessageStore {
 
 func (g *gossipServiceImpl) selfNetworkMember() discovery.NetworkMember {
 	self := discovery.NetworkMember{
-		Endpoint:         g.conf.ExternalEndpoint,
-		PKIid:            g.comm.GetPKIid(),
-		Metadata:         []byte{},
-		InternalEndpoint: g.conf.InternalEndpoint,
+		Endpoint:             g.conf.ExternalEndpoint,
+		PKIid:                g.comm.GetPKIid(),
+		Metadata:             []byte{},
+		InternalEndpoint:     g.conf.InternalEndpoint,
+		AlternativeEndpoints: g.selfAlternativeEndpoints(),
 	}
 	if g.disc != nil {
 		self.Metadata = g.disc.Self().Metadata
@@ -151,6 +152,20 @@ func (g *gossipServiceImpl) selfNetworkMember() discovery.NetworkMember {
 	return self
 }
 
+// selfAlternativeEndpoints returns the endpoints of the signed alternative
+// endpoints in the configuration, without verifying them
+func (g *gossipServiceImpl) selfAlternativeEndpoints() []string {
+	if g.conf.AlternativeEndpoints == nil {
+		return nil
+	}
+	endpoints := &proto.Endpoints{}
+	if err := pb.Unmarshal(g.conf.AlternativeEndpoints.Payload, endpoints); err != nil {
+		g.logger.Warningf("Failed unmarshaling alternative endpoints: %+v", errors.WithStack(err))
+		return nil
+	}
+	return endpoints.Endpoints
+}
+
 func newChannelState(g *gossipServiceImpl) *channelState {
 	return &channelState{
 		stopping: int32(0),
@@ -159,9 +174,9 @@ func newChannelState(g *gossipServiceImpl) *channelState {
 	}
 }
 
-func createCommWithoutServer(s *grpc.Server, cert *tls.Certificate, idStore identity.Mapper,
+func createCommWithoutServer(s *grpc.Server, certs api.PeerTLSCertificate, idStore identity.Mapper,
 	identity api.PeerIdentityType, secureDialOpts api.PeerSecureDialOpts) (comm.Comm, error) {
-	return comm.NewCommInstance(s, cert, idStore, identity, secureDialOpts)
+	return comm.NewCommInstance(s, certs, idStore, identity, secureDialOpts)
 }
 
 // NewGossipServiceWithServer creates a new gossip instance with a gRPC server
@@ -245,11 +260,15 @@ func (g *gossipServiceImpl) learnAnchorPeers(orgOfAnchorPeers api.OrgIdentityTyp
 			continue
 		}
 		identifier := func() (*discovery.PeerIdentification, error) {
+			var reachedAt string
 			remotePeerIdentity, err := g.comm.Handshake(&comm.RemotePeer{Endpoint: endpoint})
 			if err != nil {
 				err = errors.WithStack(err)
 				g.logger.Warningf("Deep probe of %s failed: %+v", endpoint, err)
-				return nil, err
+				remotePeerIdentity, reachedAt = g.handshakeAlternativeEndpoints(endpoint)
+				if remotePeerIdentity == nil {
+					return nil, err
+				}
 			}
 			isAnchorPeerInMyOrg := bytes.Equal(g.selfOrg, g.secAdvisor.OrgByPeerIdentity(remotePeerIdentity))
 			if bytes.Equal(orgOfAnchorPeers, g.selfOrg) && !isAnchorPeerInMyOrg {
@@ -262,8 +281,9 @@ func (g *gossipServiceImpl) learnAnchorPeers(orgOfAnchorPeers api.OrgIdentityTyp
 				return nil, errors.Errorf("Wasn't able to extract PKI-ID of remote peer with identity of %v", remotePeerIdentity)
 			}
 			return &discovery.PeerIdentification{
-				ID:      pkiID,
-				SelfOrg: isAnchorPeerInMyOrg,
+				ID:       pkiID,
+				SelfOrg:  isAnchorPeerInMyOrg,
+				Endpoint: reachedAt,
 			}, nil
 		}
 
@@ -272,6 +292,37 @@ func (g *gossipServiceImpl) learnAnchorPeers(orgOfAnchorPeers api.OrgIdentityTyp
 	}
 }
 
+// handshakeAlternativeEndpoints handshakes with the alternative endpoints announced by
+// the members known to be reachable at the given endpoint, and returns the identity
+// of the first member which responds at one of them, along with that endpoint
+func (g *gossipServiceImpl) handshakeAlternativeEndpoints(endpoint string) (api.PeerIdentityType, string) {
+	for _, member := range g.disc.GetMembership() {
+		var reachable bool
+		var alternativeEndpoints []string
+		for _, e := range append([]string{member.Endpoint}, member.AlternativeEndpoints...) {
+			switch {
+			case e == endpoint:
+				reachable = true
+			case e != "":
+				alternativeEndpoints = append(alternativeEndpoints, e)
+			}
+		}
+		if !reachable {
+			continue
+		}
+		for _, alternativeEndpoint := range alternativeEndpoints {
+			remotePeerIdentity, err := g.comm.Handshake(&comm.RemotePeer{Endpoint: alternativeEndpoint, PKIID: member.PKIid})
+			if err != nil {
+				g.logger.Debugf("Deep probe of alternative endpoint %s of %s failed: %+v", alternativeEndpoint, endpoint, err)
+				continue
+			}
+			g.logger.Infof("Reached %s at its alternative endpoint %s", endpoint, alternativeEndpoint)
+			return remotePeerIdentity, alternativeEndpoint
+		}
+	}
+	return nil, ""
+}
+
 func (g *gossipServiceImpl) handlePresumedDead() {
 	defer g.logger.Debug("Exiting")
 	g.stopSignal.Add(1)
@@ -703,6 +754,50 @@ func (g *gossipServiceImpl) PeersOfChannel(channel common.ChainID) []discovery.N
 	return gc.GetPeers()
 }
 
+// MembershipView returns the alive and the dead members of the given channel,
+// this peer included, along with the last time each of them was seen alive and
+// the properties each of them published about its channel-related state.
+// The properties of dead members whose state has expired are left unset
+func (g *gossipServiceImpl) MembershipView(channel common.ChainID) []discovery.MemberState {
+	gc := g.chanState.getGossipChannelByChainID(channel)
+	if gc == nil {
+		g.logger.Debug("No such channel", channel)
+		return nil
+	}
+
+	self := discovery.MemberState{
+		NetworkMember: g.selfNetworkMember(),
+		Alive:         true,
+		LastSeen:      time.Now(),
+	}
+	view := []discovery.MemberState{}
+	for _, member := range append(g.disc.GetMembershipView(), self) {
+		isSelf := bytes.Equal(member.PKIid, self.PKIid)
+		if !isSelf && !gc.IsMemberInChan(member.NetworkMember) {
+			continue
+		}
+		stateInf := gc.StateInfoOf(member.PKIid)
+		if stateInf.GetProperties().GetLeftChannel() {
+			continue
+		}
+		if stateInf == nil {
+			// Alive peers which didn't publish their state haven't joined the channel,
+			// while the state of dead peers expires, hence only the last time they
+			// were seen alive is known
+			if member.Alive && !isSelf {
+				continue
+			}
+			member.Metadata = nil
+			view = append(view, member)
+			continue
+		}
+		member.Metadata = stateInf.Metadata
+		member.Properties = stateInf.Properties
+		view = append(view, member)
+	}
+	return view
+}
+
 // PeerFilter receives a SubChannelSelectionCriteria and returns a RoutingFilter that selects
 // only peer identities that match the given criteria, and that they published their channel participation
 func (g *gossipServiceImpl) PeerFilter(channel common.ChainID, messagePredicate api.SubChannelSelectionCriteria) (filter.RoutingFilter, error) {
@@ -909,6 +1004,7 @@ func (da *discoveryAdapter) CloseConn(peer *discovery.NetworkMember) {
 
 type discoverySecurityAdapter struct {
 	identity              api.PeerIdentityType
+	alternativeEndpoints  *proto.SignedEndpoints
 	includeIdentityPeriod time.Time
 	idMapper              identity.Mapper
 	sa                    api.SecurityAdvisor
@@ -926,6 +1022,7 @@ func (g *gossipServiceImpl) newDiscoverySecurityAdapter() *discoverySecurityAdap
 		logger:                g.logger,
 		includeIdentityPeriod: g.includeIdentityPeriod,
 		identity:              g.selfIdentity,
+		alternativeEndpoints:  g.conf.AlternativeEndpoints,
 	}
 }
 
@@ -971,6 +1068,9 @@ func (sa *discoverySecurityAdapter) SignMessage(m *proto.GossipMessage, internal
 	if m.IsAliveMsg() && time.Now().Before(sa.includeIdentityPeriod) {
 		m.GetAliveMsg().Identity = sa.identity
 	}
+	if m.IsAliveMsg() {
+		m.GetAliveMsg().Membership.AlternativeEndpoints = sa.alternativeEndpoints
+	}
 	sMsg := &proto.SignedGossipMessage{
 		GossipMessage: m,
 	}
@@ -991,6 +1091,32 @@ func (sa *discoverySecurityAdapter) SignMessage(m *proto.GossipMessage, internal
 	return e
 }
 
+// VerifyAlternativeEndpoints verifies that the alternative endpoints
+// of the given member are signed by an admin of its organization,
+// and returns them
+func (sa *discoverySecurityAdapter) VerifyAlternativeEndpoints(member *proto.Member) ([]string, error) {
+	if member.AlternativeEndpoints == nil {
+		return nil, nil
+	}
+	identity, err := sa.idMapper.Get(member.PkiId)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	verifier := func(adminIdentity []byte, signature, message []byte) error {
+		return sa.sa.VerifyByOrgAdmin(identity, api.PeerIdentityType(adminIdentity), signature, message)
+	}
+	endpoints, err := member.AlternativeEndpoints.Verify(verifier)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// The endpoints are signed for a specific peer,
+	// so that they can't be announced by other peers of the organization
+	if !bytes.Equal(endpoints.PeerIdentity, identity) {
+		return nil, errors.Errorf("alternative endpoints of %v are signed for another peer", member.PkiId)
+	}
+	return endpoints.Endpoints, nil
+}
+
 func (sa *discoverySecurityAdapter) validateAliveMsgSignature(m *proto.SignedGossipMessage, identity api.PeerIdentityType) bool {
 	am := m.GetAliveMsg()
 	// At this point we got the certificate of the peer, proceed to verifying the AliveMessage
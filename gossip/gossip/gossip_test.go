@@ -50,6 +50,8 @@ var tests = []func(t *testing.T){
 	TestConfidentiality,
 	TestAnchorPeer,
 	TestBootstrapPeerMisConfiguration,
+	TestAlternativeEndpoints,
+	TestMembershipView,
 }
 
 func init() {
@@ -139,6 +141,12 @@ func (*orgCryptoService) OrgByPeerIdentity(identity api.PeerIdentityType) api.Or
 	return orgInChannelA
 }
 
+// VerifyByOrgAdmin verifies a signature of an admin of the organization
+// of the given peer
+func (*orgCryptoService) VerifyByOrgAdmin(_, _ api.PeerIdentityType, _, _ []byte) error {
+	return nil
+}
+
 // Verify verifies a JoinChanMessage, returns nil on success,
 // and an error on failure
 func (*orgCryptoService) Verify(joinChanMsg api.JoinChannelMessage) error {
@@ -317,6 +325,61 @@ func TestLeaveChannel(t *testing.T) {
 
 }
 
+func TestMembershipView(t *testing.T) {
+	t.Parallel()
+	defer testWG.Done()
+	portPrefix := 14620
+	// Scenario: Have 3 peers in a channel, each with a different ledger height,
+	// and a peer that isn't in the channel. Ensure the membership view of the channel
+	// contains only the peers in the channel, the peer itself included, along with
+	// their ledger heights, and that a peer that stopped is kept in the view and is
+	// eventually considered dead.
+
+	p0 := newGossipInstance(portPrefix, 0, 100)
+	p0.JoinChan(&joinChanMsg{}, common.ChainID("A"))
+	p0.UpdateChannelMetadata(createMetadata(5), common.ChainID("A"))
+	defer p0.Stop()
+
+	p1 := newGossipInstance(portPrefix, 1, 100, 0)
+	p1.JoinChan(&joinChanMsg{}, common.ChainID("A"))
+	p1.UpdateChannelMetadata(createMetadata(7), common.ChainID("A"))
+	defer p1.Stop()
+
+	p2 := newGossipInstance(portPrefix, 2, 100, 0)
+	p2.JoinChan(&joinChanMsg{}, common.ChainID("A"))
+	p2.UpdateChannelMetadata(createMetadata(9), common.ChainID("A"))
+
+	p3 := newGossipInstance(portPrefix, 3, 100, 0)
+	defer p3.Stop()
+
+	assert.Nil(t, p0.MembershipView(common.ChainID("B")))
+
+	waitUntilOrFail(t, func() bool {
+		return len(p0.PeersOfChannel(common.ChainID("A"))) == 2
+	})
+	heights := make(map[string]uint64)
+	for _, member := range p0.MembershipView(common.ChainID("A")) {
+		assert.True(t, member.Alive)
+		assert.False(t, member.LastSeen.IsZero())
+		heights[member.InternalEndpoint] = member.Properties.GetLedgerHeight()
+	}
+	assert.Equal(t, map[string]uint64{
+		fmt.Sprintf("localhost:%d", portPrefix):   5,
+		fmt.Sprintf("localhost:%d", portPrefix+1): 7,
+		fmt.Sprintf("localhost:%d", portPrefix+2): 9,
+	}, heights)
+
+	p2.Stop()
+	waitUntilOrFail(t, func() bool {
+		for _, member := range p0.MembershipView(common.ChainID("A")) {
+			if member.InternalEndpoint == fmt.Sprintf("localhost:%d", portPrefix+2) {
+				return !member.Alive && !member.LastSeen.IsZero()
+			}
+		}
+		return false
+	})
+}
+
 func TestPull(t *testing.T) {
 	t.Parallel()
 	defer testWG.Done()
@@ -53,6 +53,16 @@ func (c *configurableCryptoService) OrgByPeerIdentity(identity api.PeerIdentityT
 	return org
 }
 
+// VerifyByOrgAdmin verifies a signature of an admin of the organization
+// of the given peer. The identity of the admin of an organization
+// is the name of the organization prefixed by "admin@".
+func (c *configurableCryptoService) VerifyByOrgAdmin(peerIdentity, adminIdentity api.PeerIdentityType, signature, message []byte) error {
+	if string(adminIdentity) != "admin@"+string(c.OrgByPeerIdentity(peerIdentity)) {
+		return fmt.Errorf("%s is not an admin of the organization of %s", adminIdentity, peerIdentity)
+	}
+	return c.Verify(adminIdentity, signature, message)
+}
+
 // VerifyByChannel verifies a peer's signature on a message in the context
 // of a specific channel
 func (c *configurableCryptoService) VerifyByChannel(_ common.ChainID, identity api.PeerIdentityType, _, _ []byte) error {
@@ -94,6 +104,10 @@ func (*configurableCryptoService) Verify(peerIdentity api.PeerIdentityType, sign
 }
 
 func newGossipInstanceWithExternalEndpoint(portPrefix int, id int, mcs *configurableCryptoService, externalEndpoint string, boot ...int) Gossip {
+	return newGossipInstanceWithAlternativeEndpoints(portPrefix, id, mcs, externalEndpoint, nil, boot...)
+}
+
+func newGossipInstanceWithAlternativeEndpoints(portPrefix int, id int, mcs *configurableCryptoService, externalEndpoint string, alternativeEndpoints *proto.SignedEndpoints, boot ...int) Gossip {
 	port := id + portPrefix
 	conf := &Config{
 		BindPort:                   port,
@@ -108,6 +122,7 @@ func newGossipInstanceWithExternalEndpoint(portPrefix int, id int, mcs *configur
 		PullPeerNum:                5,
 		InternalEndpoint:           fmt.Sprintf("localhost:%d", port),
 		ExternalEndpoint:           externalEndpoint,
+		AlternativeEndpoints:       alternativeEndpoints,
 		PublishCertPeriod:          time.Duration(4) * time.Second,
 		PublishStateInfoInterval:   time.Duration(1) * time.Second,
 		RequestStateInfoInterval:   time.Duration(1) * time.Second,
@@ -437,6 +452,76 @@ func TestConfidentiality(t *testing.T) {
 	atomic.StoreInt32(&finished, int32(1))
 }
 
+func TestAlternativeEndpoints(t *testing.T) {
+	t.Parallel()
+	defer testWG.Done()
+	// Scenario: create 2 organizations with a peer each, where the peer of
+	// the first organization announces an alternative endpoint.
+	// Ensure the peer of the second organization learns the alternative endpoint,
+	// and reaches the peer of the first organization at it instead of the
+	// endpoint it's known to be reachable at.
+	cs := &configurableCryptoService{m: make(map[string]api.OrgIdentityType)}
+	portPrefix := 14610
+	endpoint0 := fmt.Sprintf("localhost:%d", portPrefix)
+	alternativeEndpoint0 := fmt.Sprintf("127.0.0.1:%d", portPrefix)
+	endpoint1 := fmt.Sprintf("localhost:%d", portPrefix+1)
+	cs.putInOrg(portPrefix, "orgA")
+	cs.putInOrg(portPrefix+1, "orgB")
+	signedEndpoints := func(peer string, admin string, endpoints ...string) *proto.SignedEndpoints {
+		se, err := proto.NewSignedEndpoints([]byte(peer), endpoints, []byte(admin), cs.Sign)
+		assert.NoError(t, err)
+		return se
+	}
+	p0 := newGossipInstanceWithAlternativeEndpoints(portPrefix, 0, cs, endpoint0, signedEndpoints(endpoint0, "admin@orgA", alternativeEndpoint0))
+	p1 := newGossipInstanceWithExternalEndpoint(portPrefix, 1, cs, endpoint1)
+	defer p0.Stop()
+	defer p1.Stop()
+
+	// Only the peer of the first organization is an anchor peer,
+	// so that the peers don't connect to each other simultaneously
+	jcm := &joinChanMsg{
+		members2AnchorPeers: map[string][]api.AnchorPeer{
+			"orgA": {{Host: "localhost", Port: portPrefix}},
+			"orgB": {},
+		},
+	}
+	p0.JoinChan(jcm, common.ChainID("A"))
+	p1.JoinChan(jcm, common.ChainID("A"))
+
+	waitUntilOrFail(t, func() bool {
+		peers := p1.Peers()
+		return len(peers) == 1 && len(peers[0].AlternativeEndpoints) == 1
+	})
+	assert.Equal(t, endpoint0, p1.Peers()[0].Endpoint)
+	assert.Equal(t, []string{alternativeEndpoint0}, p1.Peers()[0].AlternativeEndpoints)
+
+	identity, reachedAt := p1.(*gossipServiceImpl).handshakeAlternativeEndpoints(endpoint0)
+	assert.Equal(t, api.PeerIdentityType(endpoint0), identity)
+	assert.Equal(t, alternativeEndpoint0, reachedAt)
+
+	// No member is known to be reachable at an unknown endpoint
+	identity, reachedAt = p1.(*gossipServiceImpl).handshakeAlternativeEndpoints(fmt.Sprintf("localhost:%d", portPrefix+2))
+	assert.Nil(t, identity)
+	assert.Empty(t, reachedAt)
+
+	// Alternative endpoints which aren't signed for the peer by an admin of its organization are rejected
+	disSecAdap := p1.(*gossipServiceImpl).disSecAdap
+	member := &proto.Member{PkiId: common.PKIidType(endpoint0)}
+	member.AlternativeEndpoints = signedEndpoints(endpoint0, "admin@orgA", alternativeEndpoint0)
+	endpoints, err := disSecAdap.VerifyAlternativeEndpoints(member)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{alternativeEndpoint0}, endpoints)
+	member.AlternativeEndpoints = signedEndpoints(endpoint0, "admin@orgB", alternativeEndpoint0)
+	_, err = disSecAdap.VerifyAlternativeEndpoints(member)
+	assert.Error(t, err)
+	member.AlternativeEndpoints = signedEndpoints(endpoint1, "admin@orgA", alternativeEndpoint0)
+	_, err = disSecAdap.VerifyAlternativeEndpoints(member)
+	assert.Error(t, err)
+	member.AlternativeEndpoints.Signature = []byte{1, 2, 3}
+	_, err = disSecAdap.VerifyAlternativeEndpoints(member)
+	assert.Error(t, err)
+}
+
 func expectedMembershipSize(peersInOrg, externalEndpointsInOrg int, org string, hasExternalEndpoint bool) int {
 	// x <-- peersInOrg
 	// y <-- externalEndpointsInOrg
@@ -8,14 +8,18 @@ package integration
 
 import (
 	"crypto/tls"
+	"io/ioutil"
 	"net"
 	"strconv"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	corecomm "github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/hyperledger/fabric/gossip/util"
+	gproto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
@@ -35,13 +39,32 @@ func newConfig(selfEndpoint string, externalEndpoint string, bootPeers ...string
 		return nil, errors.Wrapf(err, "misconfigured endpoint %s, failed to parse port number", selfEndpoint)
 	}
 
-	var cert *tls.Certificate
+	var certs api.PeerTLSCertificate
 	if viper.GetBool("peer.tls.enabled") {
-		certTmp, err := tls.LoadX509KeyPair(config.GetPath("peer.tls.cert.file"), config.GetPath("peer.tls.key.file"))
+		cert, err := tls.LoadX509KeyPair(config.GetPath("peer.tls.cert.file"), config.GetPath("peer.tls.key.file"))
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to load certificates")
 		}
-		cert = &certTmp
+		certs = func() *tls.Certificate {
+			// The client certificate is updated when the TLS certificate is reloaded
+			if clientCert := corecomm.GetCASupport().ClientCertificate(); clientCert != nil {
+				return clientCert
+			}
+			return &cert
+		}
+	}
+
+	var alternativeEndpoints *gproto.SignedEndpoints
+	if viper.GetString("peer.gossip.alternativeEndpointsFile") != "" {
+		file := config.GetPath("peer.gossip.alternativeEndpointsFile")
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read alternative endpoints from %s", file)
+		}
+		alternativeEndpoints = &gproto.SignedEndpoints{}
+		if err := proto.Unmarshal(raw, alternativeEndpoints); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal alternative endpoints from %s", file)
+		}
 	}
 
 	return &gossip.Config{
@@ -57,11 +80,12 @@ func newConfig(selfEndpoint string, externalEndpoint string, bootPeers ...string
 		PullPeerNum:                util.GetIntOrDefault("peer.gossip.pullPeerNum", 3),
 		InternalEndpoint:           selfEndpoint,
 		ExternalEndpoint:           externalEndpoint,
+		AlternativeEndpoints:       alternativeEndpoints,
 		PublishCertPeriod:          util.GetDurationOrDefault("peer.gossip.publishCertPeriod", 10*time.Second),
 		RequestStateInfoInterval:   util.GetDurationOrDefault("peer.gossip.requestStateInfoInterval", 4*time.Second),
 		PublishStateInfoInterval:   util.GetDurationOrDefault("peer.gossip.publishStateInfoInterval", 4*time.Second),
 		SkipBlockVerification:      viper.GetBool("peer.gossip.skipBlockVerification"),
-		TLSServerCert:              cert,
+		TLSServerCert:              certs,
 	}, nil
 }
 
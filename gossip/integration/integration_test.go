@@ -101,6 +101,10 @@ func (sa *secAdviser) OrgByPeerIdentity(api.PeerIdentityType) api.OrgIdentityTyp
 	return api.OrgIdentityType("DEFAULT")
 }
 
+func (sa *secAdviser) VerifyByOrgAdmin(_, _ api.PeerIdentityType, _, _ []byte) error {
+	return nil
+}
+
 type cryptoService struct {
 }
 
@@ -7,7 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package service
 
 import (
+	"bytes"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 
@@ -53,6 +55,9 @@ type GossipService interface {
 	GetBlock(chainID string, index uint64) *common.Block
 	// AddPayload appends message payload to for given chain
 	AddPayload(chainID string, payload *gproto.Payload) error
+	// YieldLeadership makes the peer yield its leadership of the given chain
+	// to another peer of its organization
+	YieldLeadership(chainID string) error
 }
 
 // DeliveryServiceFactory factory to create and initialize delivery service instance
@@ -67,11 +72,13 @@ type deliveryFactoryImpl struct {
 // Returns an instance of delivery client
 func (*deliveryFactoryImpl) Service(g GossipService, endpoints []string, mcs api.MessageCryptoService) (deliverclient.DeliverService, error) {
 	return deliverclient.NewDeliverService(&deliverclient.Config{
-		CryptoSvc:   mcs,
-		Gossip:      g,
-		Endpoints:   endpoints,
-		ConnFactory: deliverclient.DefaultConnectionFactory,
-		ABCFactory:  deliverclient.DefaultABCFactory,
+		CryptoSvc:          mcs,
+		Gossip:             g,
+		Endpoints:          endpoints,
+		PreferredEndpoints: viper.GetStringSlice("peer.deliveryclient.preferredOrdererEndpoints"),
+		StallTimeout:       viper.GetDuration("peer.deliveryclient.stallTimeout"),
+		ConnFactory:        deliverclient.DefaultConnectionFactory,
+		ABCFactory:         deliverclient.DefaultABCFactory,
 	})
 }
 
@@ -96,6 +103,7 @@ type gossipServiceImpl struct {
 	mcs             api.MessageCryptoService
 	peerIdentity    []byte
 	secAdv          api.SecurityAdvisor
+	endpoint        string
 }
 
 // This is an implementation of api.JoinChannelMessage.
@@ -163,6 +171,7 @@ func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, endpoint string
 			deliveryFactory: factory,
 			peerIdentity:    peerIdentity,
 			secAdv:          secAdv,
+			endpoint:        endpoint,
 		}
 	})
 	return errors.WithStack(err)
@@ -324,7 +333,74 @@ func (g *gossipServiceImpl) Stop() {
 func (g *gossipServiceImpl) newLeaderElectionComponent(chainID string, callback func(bool)) election.LeaderElectionService {
 	PKIid := g.mcs.GetPKIidOfCert(g.peerIdentity)
 	adapter := election.NewAdapter(g, PKIid, gossipCommon.ChainID(chainID))
-	return election.NewLeaderElectionService(adapter, string(PKIid), callback)
+	strategy := g.leaderSelectionStrategy(chainID, PKIid)
+	return election.NewLeaderElectionServiceWithStrategy(adapter, string(PKIid), callback, strategy)
+}
+
+// leaderSelectionStrategy returns the strategy which selects the leader of the given chain,
+// configured by peer.gossip.election.strategy. If peer.gossip.election.staticLeaders pins the
+// leadership of the chain to a peer, the other peers are ranked by that strategy
+func (g *gossipServiceImpl) leaderSelectionStrategy(chainID string, PKIid gossipCommon.PKIidType) election.LeaderSelectionStrategy {
+	var strategy election.LeaderSelectionStrategy
+	switch name := viper.GetString("peer.gossip.election.strategy"); name {
+	case "", "lowestid":
+		strategy = election.NewLowestIDStrategy()
+	case "consistenthash":
+		strategy = election.NewConsistentHashStrategy(gossipCommon.ChainID(chainID))
+	default:
+		logger.Panicf("Unknown leader election strategy %s, aborting execution", name)
+	}
+
+	leaderEndpoint := viper.GetStringMapString("peer.gossip.election.staticLeaders")[chainID]
+	if leaderEndpoint == "" {
+		return strategy
+	}
+	logger.Info("Leadership of channel", chainID, "is pinned to", leaderEndpoint)
+	// pinnedPeer returns the PKI-ID of the peer the leadership is pinned to,
+	// or nil if that peer isn't in the membership view of this peer
+	pinnedPeer := func() gossipCommon.PKIidType {
+		if g.endpoint == leaderEndpoint {
+			return PKIid
+		}
+		for _, member := range g.Peers() {
+			if member.Endpoint == leaderEndpoint || member.InternalEndpoint == leaderEndpoint {
+				return member.PKIid
+			}
+		}
+		return nil
+	}
+	var missing int32
+	isPinned := func(id gossipCommon.PKIidType) bool {
+		pinned := pinnedPeer()
+		if pinned == nil {
+			// No peer is considered pinned, so that this peer doesn't preempt the leader
+			// on behalf of a peer it doesn't know
+			if atomic.CompareAndSwapInt32(&missing, 0, 1) {
+				logger.Warningf("Leadership of channel %s is pinned to %s, which isn't in the membership view, falling back to the leader election strategy", chainID, leaderEndpoint)
+			}
+			return false
+		}
+		atomic.StoreInt32(&missing, 0)
+		return bytes.Equal(id, pinned)
+	}
+	return election.NewStaticStrategy(isPinned, strategy)
+}
+
+// YieldLeadership makes the peer yield its leadership of the given chain
+// to another peer of its organization
+func (g *gossipServiceImpl) YieldLeadership(chainID string) error {
+	g.lock.RLock()
+	le, exists := g.leaderElection[chainID]
+	g.lock.RUnlock()
+	if !exists {
+		return errors.Errorf("leader election isn't used for channel %s", chainID)
+	}
+	if !le.IsLeader() {
+		return errors.Errorf("peer isn't the leader of channel %s", chainID)
+	}
+	logger.Info("Yielding the leadership of channel", chainID)
+	le.Yield()
+	return nil
 }
 
 func (g *gossipServiceImpl) amIinChannel(myOrg string, config Config) bool {
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/core/transientstore"
 	"github.com/hyperledger/fabric/gossip/api"
 	gossipCommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/election"
 	"github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/hyperledger/fabric/gossip/state"
@@ -676,6 +677,12 @@ func (*orgCryptoService) OrgByPeerIdentity(identity api.PeerIdentityType) api.Or
 	return orgInChannelA
 }
 
+// VerifyByOrgAdmin verifies a signature of an admin of the organization
+// of the given peer
+func (*orgCryptoService) VerifyByOrgAdmin(_, _ api.PeerIdentityType, _, _ []byte) error {
+	return nil
+}
+
 // Verify verifies a JoinChanMessage, returns nil on success,
 // and an error on failure
 func (*orgCryptoService) Verify(joinChanMsg api.JoinChannelMessage) error {
@@ -786,3 +793,88 @@ func TestChannelConfig(t *testing.T) {
 	gService.configUpdated(mc)
 	assert.True(t, gService.amIinChannel(string(orgInChannelA), mc))
 }
+
+func TestLeaderSelectionStrategy(t *testing.T) {
+	defer viper.Set("peer.gossip.election.strategy", "")
+	defer viper.Set("peer.gossip.election.staticLeaders", nil)
+	p1, p2, p3 := gossipCommon.PKIidType("p1"), gossipCommon.PKIidType("p2"), gossipCommon.PKIidType("p3")
+	gMock := &gossipMock{}
+	gMock.On("Peers").Return([]discovery.NetworkMember{
+		{PKIid: p2, Endpoint: "peer2:7051"},
+		{PKIid: p3, Endpoint: "peer3:7051", InternalEndpoint: "peer3.internal:7051"},
+	})
+	g := &gossipServiceImpl{gossipSvc: gMock, endpoint: "peer1:7051"}
+
+	// The lowest PKI-ID is preferred by default
+	strategy := g.leaderSelectionStrategy("A", p1)
+	assert.True(t, strategy.Prefers(p1, p2))
+	assert.False(t, strategy.Preempts(p1, p2))
+
+	viper.Set("peer.gossip.election.strategy", "consistenthash")
+	strategy = g.leaderSelectionStrategy("A", p1)
+	consistentHash := election.NewConsistentHashStrategy(gossipCommon.ChainID("A"))
+	assert.Equal(t, consistentHash.Prefers(p1, p2), strategy.Prefers(p1, p2))
+	assert.Equal(t, consistentHash.Prefers(p2, p3), strategy.Prefers(p2, p3))
+
+	// The peer the leadership is pinned to is preferred, whether it's
+	// this peer or another peer, identified by any of its endpoints
+	viper.Set("peer.gossip.election.staticLeaders", map[string]string{
+		"a": "peer3.internal:7051",
+		"b": "peer1:7051",
+	})
+	strategy = g.leaderSelectionStrategy("a", p1)
+	assert.True(t, strategy.Prefers(p3, p1))
+	assert.True(t, strategy.Prefers(p3, p2))
+	assert.True(t, strategy.Preempts(p3, p2))
+	assert.False(t, strategy.Preempts(p1, p3))
+	strategy = g.leaderSelectionStrategy("b", p1)
+	assert.True(t, strategy.Prefers(p1, p2))
+	assert.True(t, strategy.Preempts(p1, p3))
+	strategy = g.leaderSelectionStrategy("c", p1)
+	consistentHash = election.NewConsistentHashStrategy(gossipCommon.ChainID("c"))
+	assert.Equal(t, consistentHash.Prefers(p1, p2), strategy.Prefers(p1, p2))
+	assert.Equal(t, consistentHash.Prefers(p2, p3), strategy.Prefers(p2, p3))
+
+	// If the peer the leadership is pinned to isn't in the membership view,
+	// no peer preempts the leader on its behalf
+	viper.Set("peer.gossip.election.staticLeaders", map[string]string{
+		"d": "peer4:7051",
+	})
+	strategy = g.leaderSelectionStrategy("d", p1)
+	consistentHash = election.NewConsistentHashStrategy(gossipCommon.ChainID("d"))
+	assert.Equal(t, consistentHash.Prefers(p1, p2), strategy.Prefers(p1, p2))
+	assert.Equal(t, consistentHash.Preempts(p1, p2), strategy.Preempts(p1, p2))
+	assert.Equal(t, consistentHash.Preempts(p2, p1), strategy.Preempts(p2, p1))
+
+	viper.Set("peer.gossip.election.strategy", "roundrobin")
+	assert.Panics(t, func() {
+		g.leaderSelectionStrategy("A", p1)
+	})
+}
+
+type mockLeaderElection struct {
+	election.LeaderElectionService
+	isLeader bool
+	yielded  bool
+}
+
+func (le *mockLeaderElection) IsLeader() bool {
+	return le.isLeader
+}
+
+func (le *mockLeaderElection) Yield() {
+	le.yielded = true
+}
+
+func TestYieldLeadership(t *testing.T) {
+	le := &mockLeaderElection{}
+	g := &gossipServiceImpl{leaderElection: map[string]election.LeaderElectionService{"A": le}}
+
+	assert.EqualError(t, g.YieldLeadership("B"), "leader election isn't used for channel B")
+	assert.EqualError(t, g.YieldLeadership("A"), "peer isn't the leader of channel A")
+	assert.False(t, le.yielded)
+
+	le.isLeader = true
+	assert.NoError(t, g.YieldLeadership("A"))
+	assert.True(t, le.yielded)
+}
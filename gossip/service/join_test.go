@@ -36,6 +36,10 @@ func (s *secAdvMock) OrgByPeerIdentity(identity api.PeerIdentityType) api.OrgIde
 	return api.OrgIdentityType(identity)
 }
 
+func (s *secAdvMock) VerifyByOrgAdmin(_, _ api.PeerIdentityType, _, _ []byte) error {
+	return nil
+}
+
 type gossipMock struct {
 	mock.Mock
 }
@@ -52,14 +56,18 @@ func (*gossipMock) Send(msg *proto.GossipMessage, peers ...*comm.RemotePeer) {
 	panic("implement me")
 }
 
-func (*gossipMock) Peers() []discovery.NetworkMember {
-	panic("implement me")
+func (g *gossipMock) Peers() []discovery.NetworkMember {
+	return g.Called().Get(0).([]discovery.NetworkMember)
 }
 
 func (*gossipMock) PeersOfChannel(common.ChainID) []discovery.NetworkMember {
 	panic("implement me")
 }
 
+func (*gossipMock) MembershipView(common.ChainID) []discovery.MemberState {
+	panic("implement me")
+}
+
 func (*gossipMock) UpdateMetadata(metadata []byte) {
 	panic("implement me")
 }
@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"sync"
+
+	proto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+// batchSizer adapts the amount of blocks requested in a single
+// state transfer request to the size of the blocks of the channel,
+// such that responses stay around a configured amount of bytes
+type batchSizer struct {
+	sync.Mutex
+	size        uint64
+	min         uint64
+	max         uint64
+	targetBytes uint64
+}
+
+// newBatchSizer creates a batchSizer that starts with the given
+// batch size, and keeps it within the bounds [min...max]
+func newBatchSizer(initial, min, max, targetBytes uint64) *batchSizer {
+	b := &batchSizer{
+		min:         min,
+		max:         max,
+		targetBytes: targetBytes,
+	}
+	b.size = b.bound(initial)
+	return b
+}
+
+// Size returns the amount of blocks to request in the next batch
+func (b *batchSizer) Size() uint64 {
+	b.Lock()
+	defer b.Unlock()
+	return b.size
+}
+
+// Update adjusts the batch size according to the average size
+// of the payloads received in a state transfer response
+func (b *batchSizer) Update(payloads []*proto.Payload) {
+	if len(payloads) == 0 {
+		return
+	}
+	total := uint64(0)
+	for _, payload := range payloads {
+		total += uint64(len(payload.Data))
+		for _, pvtData := range payload.PrivateData {
+			total += uint64(len(pvtData))
+		}
+	}
+	avg := total / uint64(len(payloads))
+
+	ideal := b.max
+	if avg > 0 {
+		ideal = b.targetBytes / avg
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	// Move half way towards the ideal size, to smooth out
+	// the effect of blocks with exceptional sizes
+	ideal = b.bound(ideal)
+	if ideal > b.size {
+		b.size = (b.size + ideal + 1) / 2
+	} else {
+		b.size = (b.size + ideal) / 2
+	}
+}
+
+// Shrink halves the batch size, it is used when a peer
+// didn't respond to a state transfer request in time
+func (b *batchSizer) Shrink() {
+	b.Lock()
+	defer b.Unlock()
+	b.size = b.bound(b.size / 2)
+}
+
+func (b *batchSizer) bound(size uint64) uint64 {
+	if size < b.min {
+		return b.min
+	}
+	if size > b.max {
+		return b.max
+	}
+	return size
+}
@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"testing"
+
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func payloadsOfSize(count int, size int) []*proto.Payload {
+	var payloads []*proto.Payload
+	for i := 0; i < count; i++ {
+		payloads = append(payloads, &proto.Payload{
+			SeqNum: uint64(i),
+			Data:   make([]byte, size),
+		})
+	}
+	return payloads
+}
+
+func TestBatchSizerBounds(t *testing.T) {
+	b := newBatchSizer(1000, 1, 100, 1000)
+	assert.Equal(t, uint64(100), b.Size())
+
+	b = newBatchSizer(0, 1, 100, 1000)
+	assert.Equal(t, uint64(1), b.Size())
+}
+
+func TestBatchSizerUpdate(t *testing.T) {
+	b := newBatchSizer(10, 1, 100, 1000)
+
+	// No payloads, nothing to learn from
+	b.Update(nil)
+	assert.Equal(t, uint64(10), b.Size())
+
+	// Small blocks grow the batch size towards the maximum
+	for i := 0; i < 10; i++ {
+		b.Update(payloadsOfSize(10, 1))
+	}
+	assert.Equal(t, uint64(100), b.Size())
+
+	// Large blocks shrink the batch size towards the target of 1000 bytes
+	for i := 0; i < 10; i++ {
+		b.Update(payloadsOfSize(10, 200))
+	}
+	assert.Equal(t, uint64(5), b.Size())
+
+	// Private data is accounted for as well
+	b.Update([]*proto.Payload{{Data: make([]byte, 500), PrivateData: [][]byte{make([]byte, 500)}}})
+	assert.Equal(t, uint64(3), b.Size())
+
+	// Empty blocks don't cause a division by zero
+	b.Update(payloadsOfSize(10, 0))
+	assert.Equal(t, uint64(52), b.Size())
+}
+
+func TestBatchSizerShrink(t *testing.T) {
+	b := newBatchSizer(10, 1, 100, 1000)
+	b.Shrink()
+	assert.Equal(t, uint64(5), b.Size())
+	b.Shrink()
+	b.Shrink()
+	b.Shrink()
+	assert.Equal(t, uint64(1), b.Size())
+}
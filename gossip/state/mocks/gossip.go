@@ -47,6 +47,11 @@ func (g *GossipMock) PeersOfChannel(chainID common.ChainID) []discovery.NetworkM
 	return args.Get(0).([]discovery.NetworkMember)
 }
 
+func (g *GossipMock) MembershipView(chainID common.ChainID) []discovery.MemberState {
+	args := g.Called(chainID)
+	return args.Get(0).([]discovery.MemberState)
+}
+
 func (g *GossipMock) UpdateMetadata(metadata []byte) {
 	g.Called(metadata)
 }
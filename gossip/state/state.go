@@ -9,7 +9,6 @@ package state
 import (
 	"bytes"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	pb "github.com/golang/protobuf/proto"
@@ -44,6 +43,27 @@ const (
 	defAntiEntropyStateResponseTimeout = 3 * time.Second
 	defAntiEntropyBatchSize            = 10
 
+	// The bounds of the amount of blocks requested in a single
+	// state transfer request, and the size in bytes of the response
+	// the amount of requested blocks is adapted to
+	defAntiEntropyMinBatchSize        = 1
+	defAntiEntropyMaxBatchSize        = 100
+	defAntiEntropyTargetResponseBytes = 10 * 1024 * 1024
+
+	// The maximum amount of blocks requested from a peer which doesn't
+	// advertise the amount of blocks it sends in a single response,
+	// since peers of previous versions ignore requests for more blocks
+	defAntiEntropyLegacyMaxBatchSize = 10
+
+	// The maximum amount of state transfer requests
+	// sent in parallel to different peers
+	defAntiEntropyMaxParallelRequests = 4
+
+	// The maximum distance between the next block to commit and the
+	// blocks requested by state transfer, this bounds the amount of
+	// blocks held in memory while waiting for previous blocks to arrive
+	defAntiEntropyMaxBlockDistance = 2 * defAntiEntropyMaxParallelRequests * defAntiEntropyMaxBatchSize
+
 	defChannelBufferSize     = 100
 	defAntiEntropyMaxRetries = 3
 
@@ -141,7 +161,13 @@ type GossipStateProviderImpl struct {
 
 	ledger ledgerResources
 
-	stateResponseCh chan proto.ReceivedMessage
+	// Channels of state requests awaiting
+	// a response, indexed by the request nonce
+	pendingResponses map[uint64]chan proto.ReceivedMessage
+
+	pendingLock sync.Mutex
+
+	batchSizer *batchSizer
 
 	stateRequestCh chan proto.ReceivedMessage
 
@@ -150,8 +176,6 @@ type GossipStateProviderImpl struct {
 	done sync.WaitGroup
 
 	once sync.Once
-
-	stateTransferActive int32
 }
 
 var logger *logging.Logger // package-level logger
@@ -222,18 +246,20 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 
 		ledger: ledger,
 
-		stateResponseCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
+		pendingResponses: make(map[uint64]chan proto.ReceivedMessage),
+
+		batchSizer: newBatchSizer(defAntiEntropyBatchSize, defAntiEntropyMinBatchSize,
+			defAntiEntropyMaxBatchSize, defAntiEntropyTargetResponseBytes),
 
 		stateRequestCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
 
 		stopCh: make(chan struct{}, 1),
 
-		stateTransferActive: 0,
-
 		once: sync.Once{},
 	}
 
 	nodeMetastate := common2.NewNodeMetastate(height - 1)
+	nodeMetastate.MaxStateTransferBatchSize = defAntiEntropyMaxBatchSize
 
 	logger.Infof("Updating node metadata information, "+
 		"current ledger sequence is at = %d, next expected block is = %d", nodeMetastate.LedgerHeight, s.payloads.Next())
@@ -354,11 +380,17 @@ func (s *GossipStateProviderImpl) directMessage(msg proto.ReceivedMessage) {
 			s.stateRequestCh <- msg
 		}
 	} else if incoming.GetStateResponse() != nil {
-		// If no state transfer procedure activate there is
-		// no reason to process the message
-		if atomic.LoadInt32(&s.stateTransferActive) == 1 {
+		// If no state request awaits the response there
+		// is no reason to process the message
+		s.pendingLock.Lock()
+		responseCh, exists := s.pendingResponses[incoming.Nonce]
+		s.pendingLock.Unlock()
+		if exists {
 			// Send signal of state response message
-			s.stateResponseCh <- msg
+			select {
+			case responseCh <- msg:
+			default:
+			}
 		}
 	}
 }
@@ -386,9 +418,9 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 	request := msg.GetGossipMessage().GetStateRequest()
 
 	batchSize := request.EndSeqNum - request.StartSeqNum
-	if batchSize > defAntiEntropyBatchSize {
+	if batchSize > defAntiEntropyMaxBatchSize {
 		logger.Errorf("Requesting blocks batchSize size (%d) greater than configured allowed"+
-			" (%d) batching for anti-entropy. Ignoring request...", batchSize, defAntiEntropyBatchSize)
+			" (%d) batching for anti-entropy. Ignoring request...", batchSize, defAntiEntropyMaxBatchSize)
 		return
 	}
 
@@ -459,6 +491,9 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 	})
 }
 
+// Verify the payloads of a state response and push them into the payloads buffer.
+// Since blocks are committed from the buffer, verifying the blocks of a response
+// is pipelined with committing the blocks of the previous responses.
 func (s *GossipStateProviderImpl) handleStateResponse(msg proto.ReceivedMessage) (uint64, error) {
 	max := uint64(0)
 	// Send signal that response for given nonce has been received
@@ -496,7 +531,6 @@ func (s *GossipStateProviderImpl) Stop() {
 		// Close all resources
 		s.ledger.Close()
 		close(s.stateRequestCh)
-		close(s.stateResponseCh)
 		close(s.stopCh)
 	})
 }
@@ -615,64 +649,226 @@ func (s *GossipStateProviderImpl) maxAvailableLedgerHeight() uint64 {
 	return max
 }
 
-// GetBlocksInRange capable to acquire blocks with sequence
-// numbers in the range [start...end].
-func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64) {
-	atomic.StoreInt32(&s.stateTransferActive, 1)
-	defer atomic.StoreInt32(&s.stateTransferActive, 0)
+// stateTransfer tracks the progress of a single anti-entropy round, in
+// which batches of blocks are requested in parallel from different peers
+type stateTransfer struct {
+	sync.Mutex
+	// next is the sequence number of the first block
+	// which wasn't requested yet
+	next    uint64
+	end     uint64
+	aborted bool
+	// Amount of outstanding requests per peer endpoint
+	load map[string]int
+}
 
-	for prev := start; prev <= end; {
-		next := min(end, prev+defAntiEntropyBatchSize)
+// nextBatch returns the next range of at most size blocks to request,
+// or false if all blocks were requested or the transfer was aborted
+func (t *stateTransfer) nextBatch(size uint64) (uint64, uint64, bool) {
+	t.Lock()
+	defer t.Unlock()
+	if t.aborted || t.next > t.end {
+		return 0, 0, false
+	}
+	start := t.next
+	end := min(t.end, start+size-1)
+	t.next = end + 1
+	return start, end, true
+}
 
-		gossipMsg := s.stateRequestMessage(prev, next)
+// pending returns the sequence number of the first block
+// which wasn't requested yet
+func (t *stateTransfer) pending() uint64 {
+	t.Lock()
+	defer t.Unlock()
+	return t.next
+}
 
-		responseReceived := false
-		tryCounts := 0
+func (t *stateTransfer) abort() {
+	t.Lock()
+	defer t.Unlock()
+	t.aborted = true
+}
 
-		for !responseReceived {
-			if tryCounts > defAntiEntropyMaxRetries {
-				logger.Warningf("Wasn't  able to get blocks in range [%d...%d], after %d retries",
-					prev, next, tryCounts)
-				return
-			}
-			// Select peers to ask for blocks
-			peer, err := s.selectPeerToRequestFrom(next)
-			if err != nil {
-				logger.Warningf("Cannot send state request for blocks in range [%d...%d], due to %+v",
-					prev, next, errors.WithStack(err))
-				return
-			}
+func (t *stateTransfer) isAborted() bool {
+	t.Lock()
+	defer t.Unlock()
+	return t.aborted
+}
 
-			logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
-				"for chainID %s", peer.Endpoint, prev, next, s.chainID)
+// acquirePeer selects out of the given peers the one with the least
+// outstanding requests, so that the batches are spread across peers
+func (t *stateTransfer) acquirePeer(peers []*comm.RemotePeer) *comm.RemotePeer {
+	t.Lock()
+	defer t.Unlock()
+	var selected *comm.RemotePeer
+	// Start from a random peer, so that ties are broken randomly
+	offset := util.RandomInt(len(peers))
+	for i := range peers {
+		peer := peers[(offset+i)%len(peers)]
+		if selected == nil || t.load[peer.Endpoint] < t.load[selected.Endpoint] {
+			selected = peer
+		}
+	}
+	t.load[selected.Endpoint]++
+	return selected
+}
+
+func (t *stateTransfer) releasePeer(peer *comm.RemotePeer) {
+	t.Lock()
+	defer t.Unlock()
+	t.load[peer.Endpoint]--
+}
+
+// requestBlocksInRange capable to acquire blocks with sequence
+// numbers in the range [start...end]. The range is split into batches,
+// which are requested in parallel from the peers that advertise
+// the required ledger height.
+func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64) {
+	transfer := &stateTransfer{
+		next: start,
+		end:  end,
+		load: make(map[string]int),
+	}
+
+	parallelism := len(s.filterPeers(s.hasRequiredHeight(start)))
+	if parallelism > defAntiEntropyMaxParallelRequests {
+		parallelism = defAntiEntropyMaxParallelRequests
+	}
+	if parallelism == 0 {
+		// Still make a single attempt, which reports the lack of peers
+		parallelism = 1
+	}
 
-			s.mediator.Send(gossipMsg, peer)
-			tryCounts++
+	var wg sync.WaitGroup
+	wg.Add(parallelism)
+	for i := 0; i < parallelism; i++ {
+		go func() {
+			defer wg.Done()
+			s.requestBatches(transfer)
+		}()
+	}
+	wg.Wait()
+}
 
-			// Wait until timeout or response arrival
+// requestBatches requests batches of blocks of the given state
+// transfer one after the other, until none are left to request
+func (s *GossipStateProviderImpl) requestBatches(transfer *stateTransfer) {
+	for {
+		// Hold off requesting more blocks while too many of them
+		// are either in flight or waiting in the buffer to be committed
+		for transfer.pending() > s.payloads.Next()+defAntiEntropyMaxBlockDistance {
+			if transfer.isAborted() {
+				return
+			}
 			select {
-			case msg := <-s.stateResponseCh:
-				if msg.GetGossipMessage().Nonce != gossipMsg.Nonce {
-					continue
-				}
-				// Got corresponding response for state request, can continue
-				index, err := s.handleStateResponse(msg)
-				if err != nil {
-					logger.Warningf("Wasn't able to process state response for "+
-						"blocks [%d...%d], due to %+v", prev, next, errors.WithStack(err))
-					continue
-				}
-				prev = index + 1
-				responseReceived = true
-			case <-time.After(defAntiEntropyStateResponseTimeout):
 			case <-s.stopCh:
 				s.stopCh <- struct{}{}
+				transfer.abort()
 				return
+			case <-time.After(enqueueRetryInterval):
 			}
 		}
+
+		start, end, ok := transfer.nextBatch(s.batchSizer.Size())
+		if !ok {
+			return
+		}
+		if !s.requestBatch(transfer, start, end) {
+			// There is no point in requesting blocks which
+			// can't be committed due to the missing batch
+			transfer.abort()
+			return
+		}
 	}
 }
 
+// requestBatch requests blocks in the range [start...end], and returns
+// whether all of them were received
+func (s *GossipStateProviderImpl) requestBatch(transfer *stateTransfer, start uint64, end uint64) bool {
+	tryCounts := 0
+
+	for start <= end {
+		if tryCounts > defAntiEntropyMaxRetries {
+			logger.Warningf("Wasn't  able to get blocks in range [%d...%d], after %d retries",
+				start, end, tryCounts)
+			return false
+		}
+		if transfer.isAborted() {
+			return false
+		}
+
+		// Select peers to ask for blocks
+		peers := s.filterPeers(s.hasRequiredHeight(end))
+		if len(peers) == 0 {
+			logger.Warningf("Cannot send state request for blocks in range [%d...%d], due to %+v",
+				start, end, errors.New("there are no peers to ask for missing blocks from"))
+			return false
+		}
+		peer := transfer.acquirePeer(peers)
+
+		// Ask the peer for no more blocks than it sends in a single response,
+		// the rest of them are requested in the next round
+		requestEnd := min(end, start+s.maxBatchSizeOf(peer)-1)
+		gossipMsg := s.stateRequestMessage(start, requestEnd)
+		responseCh := s.awaitResponse(gossipMsg.Nonce)
+
+		logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
+			"for chainID %s", peer.Endpoint, start, requestEnd, s.chainID)
+
+		s.mediator.Send(gossipMsg, peer)
+		tryCounts++
+
+		// Wait until timeout or response arrival
+		select {
+		case msg := <-responseCh:
+			// Got corresponding response for state request, can continue
+			index, err := s.handleStateResponse(msg)
+			if err != nil {
+				logger.Warningf("Wasn't able to process state response for "+
+					"blocks [%d...%d], due to %+v", start, requestEnd, errors.WithStack(err))
+				break
+			}
+			s.batchSizer.Update(msg.GetGossipMessage().GetStateResponse().GetPayloads())
+			if index >= start {
+				// The peer might have sent only part of the blocks,
+				// the rest of them are requested in the next round
+				start = index + 1
+				tryCounts = 0
+			}
+		case <-time.After(defAntiEntropyStateResponseTimeout):
+			// The peer might not be able to send that many blocks
+			// in time, hence ask for less of them in the next batches
+			s.batchSizer.Shrink()
+		case <-s.stopCh:
+			s.stopCh <- struct{}{}
+			s.stopAwaitingResponse(gossipMsg.Nonce)
+			transfer.releasePeer(peer)
+			return false
+		}
+
+		s.stopAwaitingResponse(gossipMsg.Nonce)
+		transfer.releasePeer(peer)
+	}
+	return true
+}
+
+// awaitResponse returns a channel on which the response
+// to the state request with the given nonce is sent
+func (s *GossipStateProviderImpl) awaitResponse(nonce uint64) <-chan proto.ReceivedMessage {
+	responseCh := make(chan proto.ReceivedMessage, 1)
+	s.pendingLock.Lock()
+	defer s.pendingLock.Unlock()
+	s.pendingResponses[nonce] = responseCh
+	return responseCh
+}
+
+func (s *GossipStateProviderImpl) stopAwaitingResponse(nonce uint64) {
+	s.pendingLock.Lock()
+	defer s.pendingLock.Unlock()
+	delete(s.pendingResponses, nonce)
+}
+
 // Generate state request message for given blocks in range [beginSeq...endSeq]
 func (s *GossipStateProviderImpl) stateRequestMessage(beginSeq uint64, endSeq uint64) *proto.GossipMessage {
 	return &proto.GossipMessage{
@@ -688,20 +884,6 @@ func (s *GossipStateProviderImpl) stateRequestMessage(beginSeq uint64, endSeq ui
 	}
 }
 
-// Select peer which has required blocks to ask missing blocks from
-func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64) (*comm.RemotePeer, error) {
-	// Filter peers which posses required range of missing blocks
-	peers := s.filterPeers(s.hasRequiredHeight(height))
-
-	n := len(peers)
-	if n == 0 {
-		return nil, errors.New("there are no peers to ask for missing blocks from")
-	}
-
-	// Select peers to ask for blocks
-	return peers[util.RandomInt(n)], nil
-}
-
 // filterPeers return list of peers which aligns the predicate provided
 func (s *GossipStateProviderImpl) filterPeers(predicate func(peer discovery.NetworkMember) bool) []*comm.RemotePeer {
 	var peers []*comm.RemotePeer
@@ -715,6 +897,22 @@ func (s *GossipStateProviderImpl) filterPeers(predicate func(peer discovery.Netw
 	return peers
 }
 
+// maxBatchSizeOf returns the maximum amount of blocks the given peer sends in response
+// to a single state transfer request, as advertised in its channel metadata
+func (s *GossipStateProviderImpl) maxBatchSizeOf(peer *comm.RemotePeer) uint64 {
+	for _, member := range s.mediator.PeersOfChannel(common2.ChainID(s.chainID)) {
+		if !bytes.Equal(member.PKIid, peer.PKIID) {
+			continue
+		}
+		nodeMetastate, err := common2.FromBytes(member.Metadata)
+		if err != nil || nodeMetastate.MaxStateTransferBatchSize == 0 {
+			break
+		}
+		return min(nodeMetastate.MaxStateTransferBatchSize, defAntiEntropyMaxBatchSize)
+	}
+	return defAntiEntropyLegacyMaxBatchSize
+}
+
 // hasRequiredHeight returns predicate which is capable to filter peers with ledger height above than indicated
 // by provided input parameter
 func (s *GossipStateProviderImpl) hasRequiredHeight(height uint64) func(peer discovery.NetworkMember) bool {
@@ -787,6 +985,7 @@ func (s *GossipStateProviderImpl) commitBlock(block *common.Block, pvtData util.
 
 	// Update ledger level within node metadata
 	nodeMetastate := common2.NewNodeMetastate(block.Header.Number)
+	nodeMetastate.MaxStateTransferBatchSize = defAntiEntropyMaxBatchSize
 	// Decode nodeMetastate to byte array
 	b, err := nodeMetastate.Bytes()
 	if err == nil {
@@ -85,6 +85,12 @@ func (*orgCryptoService) OrgByPeerIdentity(identity api.PeerIdentityType) api.Or
 	return orgID
 }
 
+// VerifyByOrgAdmin verifies a signature of an admin of the organization
+// of the given peer
+func (*orgCryptoService) VerifyByOrgAdmin(_, _ api.PeerIdentityType, _, _ []byte) error {
+	return nil
+}
+
 // Verify verifies a JoinChannelMessage, returns nil on success,
 // and an error on failure
 func (*orgCryptoService) Verify(joinChanMsg api.JoinChannelMessage) error {
@@ -655,6 +661,49 @@ func TestMetadataCompatibility(t *testing.T) {
 	wg.Wait()
 }
 
+func TestMaxBatchSizeOf(t *testing.T) {
+	// Scenario: Peers of previous versions don't advertise the amount of blocks
+	// they send in a single state response, hence they are asked for no more
+	// blocks than they used to send, while the rest of the peers are asked
+	// for at most the amount they advertise
+	member := func(id string, metastate *common.NodeMetastate) discovery.NetworkMember {
+		b, _ := metastate.Bytes()
+		return discovery.NetworkMember{
+			InternalEndpoint: id,
+			PKIid:            common.PKIidType(id),
+			Metadata:         b,
+		}
+	}
+	legacyPeer := member("legacyPeer", &common.NodeMetastate{LedgerHeight: 10})
+	advertisingPeer := member("advertisingPeer", &common.NodeMetastate{LedgerHeight: 10, MaxStateTransferBatchSize: 50})
+	greedyPeer := member("greedyPeer", &common.NodeMetastate{LedgerHeight: 10, MaxStateTransferBatchSize: 1000})
+	peerWithoutMetadata := discovery.NetworkMember{
+		InternalEndpoint: "peerWithoutMetadata",
+		PKIid:            common.PKIidType("peerWithoutMetadata"),
+		Properties: &proto.Properties{
+			LedgerHeight: 10,
+		},
+	}
+
+	g := &mocks.GossipMock{}
+	g.On("PeersOfChannel", mock.Anything).Return([]discovery.NetworkMember{
+		legacyPeer, advertisingPeer, greedyPeer, peerWithoutMetadata,
+	})
+	s := &GossipStateProviderImpl{
+		chainID:  util.GetTestChainID(),
+		mediator: &ServicesMediator{GossipAdapter: g},
+	}
+
+	remotePeer := func(member discovery.NetworkMember) *comm.RemotePeer {
+		return &comm.RemotePeer{Endpoint: member.InternalEndpoint, PKIID: member.PKIid}
+	}
+	assert.Equal(t, uint64(defAntiEntropyLegacyMaxBatchSize), s.maxBatchSizeOf(remotePeer(legacyPeer)))
+	assert.Equal(t, uint64(50), s.maxBatchSizeOf(remotePeer(advertisingPeer)))
+	assert.Equal(t, uint64(defAntiEntropyMaxBatchSize), s.maxBatchSizeOf(remotePeer(greedyPeer)))
+	assert.Equal(t, uint64(defAntiEntropyLegacyMaxBatchSize), s.maxBatchSizeOf(remotePeer(peerWithoutMetadata)))
+	assert.Equal(t, uint64(defAntiEntropyLegacyMaxBatchSize), s.maxBatchSizeOf(&comm.RemotePeer{PKIID: common.PKIidType("unknownPeer")}))
+}
+
 func TestAccessControl(t *testing.T) {
 	viper.Set("peer.fileSystemPath", "/tmp/tests/ledger/node")
 	ledgermgmt.InitializeTestEnv()
@@ -0,0 +1,246 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/state/mocks"
+	gutil "github.com/hyperledger/fabric/gossip/util"
+	pcomm "github.com/hyperledger/fabric/protos/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// remotePeers simulates peers of the channel which have all blocks up to
+// the given height, and respond to state requests after a given latency
+type remotePeers struct {
+	sync.Mutex
+	*mocks.GossipMock
+	commChannel chan proto.ReceivedMessage
+	blocks      map[uint64][]byte
+	// Amount of state requests each peer received
+	requests map[string]int
+}
+
+func newRemotePeers(peerCount int, height uint64, blockSize int, latency time.Duration) *remotePeers {
+	rp := &remotePeers{
+		GossipMock:  &mocks.GossipMock{},
+		commChannel: make(chan proto.ReceivedMessage),
+		blocks:      make(map[uint64][]byte),
+		requests:    make(map[string]int),
+	}
+
+	for seqNum := uint64(1); seqNum < height; seqNum++ {
+		block := pcomm.NewBlock(seqNum, []byte{})
+		block.Data.Data = [][]byte{make([]byte, blockSize)}
+		rp.blocks[seqNum], _ = pb.Marshal(block)
+	}
+
+	metaBytes, _ := common.NewNodeMetastate(height - 1).Bytes()
+	var members []discovery.NetworkMember
+	for i := 0; i < peerCount; i++ {
+		members = append(members, discovery.NetworkMember{
+			PKIid:    common.PKIidType([]byte{byte(i)}),
+			Endpoint: fmt.Sprintf("peer%d:7051", i),
+			Metadata: metaBytes,
+		})
+	}
+
+	rp.On("Accept", mock.Anything, false).Return(make(<-chan *proto.GossipMessage), nil)
+	rp.On("Accept", mock.Anything, true).Return(nil, (<-chan proto.ReceivedMessage)(rp.commChannel))
+	rp.On("PeersOfChannel", mock.Anything).Return(members)
+	rp.On("Send", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		request := args.Get(0).(*proto.GossipMessage)
+		peer := args.Get(1).([]*comm.RemotePeer)[0]
+
+		rp.Lock()
+		rp.requests[peer.Endpoint]++
+		rp.Unlock()
+
+		go func() {
+			time.Sleep(latency)
+			stateRequest := request.GetStateRequest()
+			response := &proto.RemoteStateResponse{}
+			for seqNum := stateRequest.StartSeqNum; seqNum <= stateRequest.EndSeqNum; seqNum++ {
+				response.Payloads = append(response.Payloads, &proto.Payload{
+					SeqNum: seqNum,
+					Data:   rp.blocks[seqNum],
+				})
+			}
+			msg, _ := (&proto.GossipMessage{
+				Nonce:   request.Nonce,
+				Tag:     proto.GossipMessage_CHAN_OR_ORG,
+				Channel: request.Channel,
+				Content: &proto.GossipMessage_StateResponse{StateResponse: response},
+			}).NoopSign()
+			receivedMsg := new(receivedMessageMock)
+			receivedMsg.On("GetGossipMessage").Return(msg)
+			rp.commChannel <- receivedMsg
+		}()
+	})
+	return rp
+}
+
+// committingLedger is a ledger of the given height, which sends the sequence
+// numbers of the committed blocks to a channel. It doesn't use a mock.Mock,
+// since formatting the arguments of each call would dominate the benchmark
+type committingLedger struct {
+	height    uint64
+	committed chan uint64
+}
+
+func newCommittingLedger(height uint64, capacity int) *committingLedger {
+	return &committingLedger{
+		height:    height,
+		committed: make(chan uint64, capacity),
+	}
+}
+
+func (l *committingLedger) StoreBlock(block *pcomm.Block, _ gutil.PvtDataCollections) error {
+	l.committed <- block.Header.Number
+	return nil
+}
+
+func (l *committingLedger) StorePvtData(string, *rwset.TxPvtReadWriteSet) error {
+	return nil
+}
+
+func (l *committingLedger) GetPvtDataAndBlockByNum(uint64) (*pcomm.Block, gutil.PvtDataCollections, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+func (l *committingLedger) GetBlockByNum(uint64) (*pcomm.Block, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (l *committingLedger) LedgerHeight() (uint64, error) {
+	return l.height, nil
+}
+
+func (l *committingLedger) Close() {
+}
+
+func TestParallelStateTransfer(t *testing.T) {
+	// Scenario: A peer with only the genesis block requests blocks
+	// from 3 peers, which all have 100 blocks. Ensure the blocks are
+	// requested from all of the peers, and committed in order.
+	height := uint64(101)
+	rp := newRemotePeers(3, height, 10, 10*time.Millisecond)
+	ledger := newCommittingLedger(1, int(height))
+
+	mediator := &ServicesMediator{GossipAdapter: rp, MCSAdapter: &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor}}
+	s := NewGossipStateProvider(util.GetTestChainID(), mediator, ledger).(*GossipStateProviderImpl)
+	defer s.Stop()
+
+	s.requestBlocksInRange(1, height-1)
+
+	for expected := uint64(1); expected < height; expected++ {
+		select {
+		case seqNum := <-ledger.committed:
+			assert.Equal(t, expected, seqNum)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Didn't commit block %d in time", expected)
+		}
+	}
+
+	rp.Lock()
+	defer rp.Unlock()
+	assert.Len(t, rp.requests, 3)
+}
+
+func TestStateTransferNoPeers(t *testing.T) {
+	// Scenario: No peer advertises the required height,
+	// hence the state transfer gives up without sending requests
+	rp := newRemotePeers(2, 5, 10, 0)
+	ledger := newCommittingLedger(1, 0)
+
+	mediator := &ServicesMediator{GossipAdapter: rp, MCSAdapter: &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor}}
+	s := NewGossipStateProvider(util.GetTestChainID(), mediator, ledger).(*GossipStateProviderImpl)
+	defer s.Stop()
+
+	s.requestBlocksInRange(1, 10)
+	rp.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestStateTransferNextBatch(t *testing.T) {
+	transfer := &stateTransfer{next: 1, end: 25, load: make(map[string]int)}
+
+	start, end, ok := transfer.nextBatch(10)
+	assert.True(t, ok)
+	assert.Equal(t, []uint64{1, 10}, []uint64{start, end})
+
+	start, end, ok = transfer.nextBatch(10)
+	assert.True(t, ok)
+	assert.Equal(t, []uint64{11, 20}, []uint64{start, end})
+
+	start, end, ok = transfer.nextBatch(10)
+	assert.True(t, ok)
+	assert.Equal(t, []uint64{21, 25}, []uint64{start, end})
+
+	_, _, ok = transfer.nextBatch(10)
+	assert.False(t, ok)
+
+	transfer = &stateTransfer{next: 1, end: 25, load: make(map[string]int)}
+	transfer.abort()
+	_, _, ok = transfer.nextBatch(10)
+	assert.False(t, ok)
+}
+
+func TestStateTransferAcquirePeer(t *testing.T) {
+	transfer := &stateTransfer{load: make(map[string]int)}
+	peers := []*comm.RemotePeer{{Endpoint: "p1"}, {Endpoint: "p2"}, {Endpoint: "p3"}}
+
+	// Every peer is selected once before any peer is selected twice
+	selected := make(map[string]bool)
+	for i := 0; i < len(peers); i++ {
+		selected[transfer.acquirePeer(peers).Endpoint] = true
+	}
+	assert.Len(t, selected, 3)
+
+	transfer.releasePeer(peers[1])
+	assert.Equal(t, "p2", transfer.acquirePeer(peers).Endpoint)
+}
+
+func BenchmarkStateTransfer(b *testing.B) {
+	for _, peerCount := range []int{1, 2, 4} {
+		b.Run(fmt.Sprintf("%dPeers", peerCount), func(b *testing.B) {
+			benchmarkStateTransfer(b, peerCount, 500)
+		})
+	}
+}
+
+func benchmarkStateTransfer(b *testing.B, peerCount int, blockCount uint64) {
+	height := blockCount + 1
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		rp := newRemotePeers(peerCount, height, 1024, 5*time.Millisecond)
+		ledger := newCommittingLedger(1, int(height))
+		mediator := &ServicesMediator{GossipAdapter: rp, MCSAdapter: &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor}}
+		s := NewGossipStateProvider(util.GetTestChainID(), mediator, ledger).(*GossipStateProviderImpl)
+		b.StartTimer()
+
+		s.requestBlocksInRange(1, blockCount)
+		for j := uint64(0); j < blockCount; j++ {
+			<-ledger.committed
+		}
+
+		b.StopTimer()
+		s.Stop()
+	}
+}
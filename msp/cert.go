@@ -81,7 +81,12 @@ func sanitizeECDSASignedCert(cert *x509.Certificate, parentCert *x509.Certificat
 		return nil, errors.New("Parent certificate must be different from nil.")
 	}
 
-	expectedSig, err := sw.SignatureToLowS(parentCert.PublicKey.(*ecdsa.PublicKey), cert.Signature)
+	parentPK, ok := parentCert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("Parent certificate's public key must be an ECDSA key, got [%T]", parentCert.PublicKey)
+	}
+
+	expectedSig, err := sw.SignatureToLowS(parentPK, cert.Signature)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +113,10 @@ func SetupBCCSPKeystoreConfig(bccspConfig *factory.FactoryOpts, keystoreDir stri
 		if bccspConfig.SwOpts.FileKeystore == nil ||
 			bccspConfig.SwOpts.FileKeystore.KeyStorePath == "" {
 			bccspConfig.SwOpts.Ephemeral = false
-			bccspConfig.SwOpts.FileKeystore = &factory.FileKeystoreOpts{KeyStorePath: keystoreDir}
+			if bccspConfig.SwOpts.FileKeystore == nil {
+				bccspConfig.SwOpts.FileKeystore = &factory.FileKeystoreOpts{}
+			}
+			bccspConfig.SwOpts.FileKeystore.KeyStorePath = keystoreDir
 		}
 	}
 
@@ -0,0 +1,30 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/x509"
+
+	"github.com/hyperledger/fabric/bccsp/utils"
+)
+
+// isEd25519SignedCert returns true if cert is signed using Ed25519.
+// The signature algorithm is read from the raw certificate, since
+// crypto/x509 only recognizes Ed25519 from Go 1.13 on.
+func isEd25519SignedCert(cert *x509.Certificate) bool {
+	c, err := certFromX509Cert(cert)
+	if err != nil {
+		return false
+	}
+	return c.SignatureAlgorithm.Algorithm.Equal(utils.OIDPublicKeyEd25519)
+}
+
+// hasEd25519PublicKey returns true if the public key of cert is an Ed25519 key
+func hasEd25519PublicKey(cert *x509.Certificate) bool {
+	_, err := utils.DERToEd25519PublicKey(cert.RawSubjectPublicKeyInfo)
+	return err == nil
+}
@@ -0,0 +1,25 @@
+// +build !go1.13
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/x509"
+	"errors"
+)
+
+// checkEd25519Supported checks that the validation chains of the passed
+// CA cert can be verified. crypto/x509 doesn't verify Ed25519 signatures
+// before Go 1.13, so CA certs which are signed using Ed25519 or hold an
+// Ed25519 key are rejected
+func checkEd25519Supported(cert *x509.Certificate) error {
+	if isEd25519SignedCert(cert) || hasEd25519PublicKey(cert) {
+		return errors.New("Ed25519 CA certificates require a peer built with Go 1.13 or later")
+	}
+	return nil
+}
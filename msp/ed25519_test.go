@@ -0,0 +1,295 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	m "github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ed25519"
+)
+
+// ed25519Cert issues an Ed25519 certificate for pub, signed by
+// parentKey. If parent is nil, the certificate is self-signed.
+// The certificate is assembled by hand, since crypto/x509 cannot
+// create Ed25519 certificates before Go 1.13.
+func ed25519Cert(t *testing.T, cn string, isCA bool, pub ed25519.PublicKey, parent *x509.Certificate, parentKey ed25519.PrivateKey) *x509.Certificate {
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	assert.NoError(t, err)
+
+	subject, err := asn1.Marshal(pkix.Name{CommonName: cn, Organization: []string{"ed25519.example.com"}}.ToRDNSequence())
+	assert.NoError(t, err)
+	issuer := subject
+	if parent != nil {
+		issuer = parent.RawSubject
+	}
+
+	spki, err := utils.PublicKeyToDER(pub)
+	assert.NoError(t, err)
+	var pki publicKeyInfo
+	_, err = asn1.Unmarshal(spki, &pki)
+	assert.NoError(t, err)
+
+	// digitalSignature, plus keyCertSign and cRLSign for CAs
+	keyUsage := asn1.BitString{Bytes: []byte{0x80}, BitLength: 7}
+	if isCA {
+		keyUsage.Bytes[0] |= 0x06
+	}
+	keyUsageExt, err := asn1.Marshal(keyUsage)
+	assert.NoError(t, err)
+	extensions := []pkix.Extension{{Id: asn1.ObjectIdentifier{2, 5, 29, 15}, Critical: true, Value: keyUsageExt}}
+	if isCA {
+		basicConstraintsExt, err := asn1.Marshal(struct{ IsCA bool }{true})
+		assert.NoError(t, err)
+		skiExt, err := asn1.Marshal([]byte(cn))
+		assert.NoError(t, err)
+		extensions = append(extensions,
+			pkix.Extension{Id: asn1.ObjectIdentifier{2, 5, 29, 19}, Critical: true, Value: basicConstraintsExt},
+			pkix.Extension{Id: asn1.ObjectIdentifier{2, 5, 29, 14}, Value: skiExt})
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	tbs := tbsCertificate{
+		Version:            2,
+		SerialNumber:       serial,
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: utils.OIDPublicKeyEd25519},
+		Issuer:             asn1.RawValue{FullBytes: issuer},
+		Validity:           validity{NotBefore: now.Add(-time.Hour), NotAfter: now.Add(time.Hour)},
+		Subject:            asn1.RawValue{FullBytes: subject},
+		PublicKey:          pki,
+		Extensions:         extensions,
+	}
+	tbs.Raw, err = asn1.Marshal(tbs)
+	assert.NoError(t, err)
+
+	signature := ed25519.Sign(parentKey, tbs.Raw)
+	raw, err := asn1.Marshal(certificate{
+		TBSCertificate:     tbs,
+		SignatureAlgorithm: tbs.SignatureAlgorithm,
+		SignatureValue:     asn1.BitString{Bytes: signature, BitLength: 8 * len(signature)},
+	})
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	assert.NoError(t, err)
+	return cert
+}
+
+func ed25519PEM(t *testing.T, cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+// getEd25519MSP returns an MSP whose CA and signing identity use Ed25519 keys,
+// along with the private key of its signing identity
+func getEd25519MSP(t *testing.T, mspID string) (MSP, ed25519.PrivateKey) {
+	caPub, caKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	caCert := ed25519Cert(t, "ca", true, caPub, nil, caKey)
+
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	cert := ed25519Cert(t, "signer", false, pub, caCert, caKey)
+
+	keyPEM, err := utils.PrivateKeyToPEM(key, nil)
+	assert.NoError(t, err)
+
+	fmspconf := &m.FabricMSPConfig{
+		Name:      mspID,
+		RootCerts: [][]byte{ed25519PEM(t, caCert)},
+		Admins:    [][]byte{ed25519PEM(t, cert)},
+		SigningIdentity: &m.SigningIdentityInfo{
+			PublicSigner: ed25519PEM(t, cert),
+			PrivateSigner: &m.KeyInfo{
+				KeyMaterial: keyPEM,
+			},
+		},
+		CryptoConfig: &m.FabricCryptoConfig{
+			SignatureHashFamily:            bccsp.SHA2,
+			IdentityIdentifierHashFunction: bccsp.SHA256,
+		},
+	}
+	raw, err := proto.Marshal(fmspconf)
+	assert.NoError(t, err)
+
+	msp, err := NewBccspMsp()
+	assert.NoError(t, err)
+	err = msp.Setup(&m.MSPConfig{Config: raw, Type: int32(FABRIC)})
+	assert.NoError(t, err)
+	return msp, key
+}
+
+func TestEd25519MSP(t *testing.T) {
+	msp, key := getEd25519MSP(t, "Ed25519MSP")
+
+	id, err := msp.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	assert.NoError(t, id.Validate())
+
+	msg := []byte("Hello World")
+	sig, err := id.Sign(msg)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Verify(msg, sig))
+	assert.Error(t, id.Verify([]byte("Goodbye World"), sig))
+
+	// Signatures are interoperable with the ones of other Ed25519 implementations,
+	// which sign the message itself
+	assert.True(t, ed25519.Verify(key.Public().(ed25519.PublicKey), msg, sig))
+	assert.NoError(t, id.Verify(msg, ed25519.Sign(key, msg)))
+
+	serialized, err := id.Serialize()
+	assert.NoError(t, err)
+	id2, err := msp.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.NoError(t, id2.Validate())
+	assert.NoError(t, id2.Verify(msg, sig))
+
+	// An Ed25519 identity issued by another CA is not valid
+	otherMSP, _ := getEd25519MSP(t, "Ed25519MSP")
+	otherID, err := otherMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	serialized, err = otherID.Serialize()
+	assert.NoError(t, err)
+	id3, err := msp.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.Error(t, id3.Validate())
+}
+
+func TestSanitizeCertWithEd25519Parent(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	cert := ed25519Cert(t, "ca", true, pub, nil, key)
+	assert.False(t, isECDSASignedCert(cert))
+
+	_, err = sanitizeECDSASignedCert(cert, cert)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Parent certificate's public key must be an ECDSA key")
+}
+
+func TestEd25519ValidationChains(t *testing.T) {
+	rootPub, rootKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	root := ed25519Cert(t, "root", true, rootPub, nil, rootKey)
+	interPub, interKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	inter := ed25519Cert(t, "intermediate", true, interPub, root, rootKey)
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	leaf := ed25519Cert(t, "leaf", false, pub, inter, interKey)
+	assert.True(t, isEd25519SignedCert(leaf))
+
+	msp := &bccspmsp{}
+	msp.setVerifyOpts([]*x509.Certificate{root}, []*x509.Certificate{inter})
+	assert.NoError(t, checkEd25519Supported(root))
+
+	chain, err := msp.getUniqueValidationChain(leaf, msp.getValidityOptsForCert(leaf))
+	assert.NoError(t, err)
+	assert.Equal(t, []*x509.Certificate{leaf, inter, root}, chain)
+
+	chain, err = msp.getUniqueValidationChain(root, msp.getValidityOptsForCert(root))
+	assert.NoError(t, err)
+	assert.Equal(t, []*x509.Certificate{root}, chain)
+
+	// The leaf is not valid anymore
+	opts := msp.getValidityOptsForCert(leaf)
+	opts.CurrentTime = leaf.NotAfter.Add(time.Second)
+	_, err = msp.getUniqueValidationChain(leaf, opts)
+	assert.Error(t, err)
+
+	// Without the intermediate CA, there is no chain to the root
+	msp.setVerifyOpts([]*x509.Certificate{root}, nil)
+	_, err = msp.getUniqueValidationChain(leaf, msp.getValidityOptsForCert(leaf))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "certificate signed by unknown authority")
+
+	// A certificate signed by another key is rejected
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	forged := ed25519Cert(t, "forged", false, pub, root, otherKey)
+	_, err = msp.getUniqueValidationChain(forged, msp.getValidityOptsForCert(forged))
+	assert.Error(t, err)
+}
+
+// ecdsaCert issues an ECDSA certificate from template, signed by
+// parentKey. If parent is nil, the certificate is self-signed.
+func ecdsaCert(t *testing.T, template *x509.Certificate, key *ecdsa.PrivateKey, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) *x509.Certificate {
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	assert.NoError(t, err)
+	template.SerialNumber = serial
+	if parent == nil {
+		parent = template
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	assert.NoError(t, err)
+	return cert
+}
+
+func TestECDSAValidationChainsWithEd25519CA(t *testing.T) {
+	ed25519Pub, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	ed25519Root := ed25519Cert(t, "ed25519 root", true, ed25519Pub, nil, ed25519Key)
+
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	now := time.Now()
+	root := ecdsaCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "ecdsa root"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		PermittedDNSDomains:   []string{"example.com"},
+	}, rootKey, nil, rootKey)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	leaf := func(notAfter time.Time, dnsName string) *x509.Certificate {
+		return ecdsaCert(t, &x509.Certificate{
+			Subject:   pkix.Name{CommonName: "leaf"},
+			NotBefore: now.Add(-time.Hour),
+			NotAfter:  notAfter,
+			KeyUsage:  x509.KeyUsageDigitalSignature,
+			DNSNames:  []string{dnsName},
+		}, key, root, rootKey)
+	}
+
+	msp := &bccspmsp{}
+	msp.setVerifyOpts([]*x509.Certificate{ed25519Root, root}, nil)
+
+	valid := leaf(now.Add(time.Hour), "peer0.example.com")
+	chain, err := msp.getUniqueValidationChain(valid, msp.getValidityOptsForCert(valid))
+	assert.NoError(t, err)
+	assert.Equal(t, []*x509.Certificate{valid, root}, chain)
+
+	// ECDSA certificates are rejected by crypto/x509 as usual,
+	// even though the MSP has an Ed25519 CA
+	expired := leaf(now.Add(-time.Minute), "peer0.example.com")
+	opts := msp.getValidityOptsForCert(expired)
+	opts.CurrentTime = now
+	_, err = msp.getUniqueValidationChain(expired, opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "expired")
+
+	notPermitted := leaf(now.Add(time.Hour), "peer0.example.org")
+	_, err = msp.getUniqueValidationChain(notPermitted, msp.getValidityOptsForCert(notPermitted))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "peer0.example.org")
+}
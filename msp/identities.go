@@ -149,14 +149,9 @@ func (id *identity) Verify(msg []byte, sig []byte) error {
 	// mspIdentityLogger.Infof("Verifying signature")
 
 	// Compute Hash
-	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
+	digest, err := id.getDigestToSign(msg)
 	if err != nil {
-		return fmt.Errorf("Failed getting hash function options [%s]", err)
-	}
-
-	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
-	if err != nil {
-		return fmt.Errorf("Failed computing digest [%s]", err)
+		return err
 	}
 
 	if mspIdentityLogger.IsEnabledFor(logging.DEBUG) {
@@ -194,6 +189,26 @@ func (id *identity) Serialize() ([]byte, error) {
 	return idBytes, nil
 }
 
+// getDigestToSign returns what the key of this identity signs for msg.
+// Ed25519 keys sign the message itself (PureEdDSA, RFC 8032),
+// other keys sign its digest.
+func (id *identity) getDigestToSign(msg []byte) ([]byte, error) {
+	if hasEd25519PublicKey(id.cert) {
+		return msg, nil
+	}
+
+	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting hash function options [%s]", err)
+	}
+
+	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
+	if err != nil {
+		return nil, fmt.Errorf("Failed computing digest [%s]", err)
+	}
+	return digest, nil
+}
+
 func (id *identity) getHashOpt(hashFamily string) (bccsp.HashOpts, error) {
 	switch hashFamily {
 	case bccsp.SHA2:
@@ -226,14 +241,9 @@ func (id *signingidentity) Sign(msg []byte) ([]byte, error) {
 	//mspIdentityLogger.Infof("Signing message")
 
 	// Compute Hash
-	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
+	digest, err := id.getDigestToSign(msg)
 	if err != nil {
-		return nil, fmt.Errorf("Failed getting hash function options [%s]", err)
-	}
-
-	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
-	if err != nil {
-		return nil, fmt.Errorf("Failed computing digest [%s]", err)
+		return nil, err
 	}
 
 	if len(msg) < 32 {
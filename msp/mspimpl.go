@@ -146,9 +146,13 @@ func (msp *bccspmsp) getSigningIdentityFromConf(sidInfo *m.SigningIdentityInfo)
 		}
 
 		pemKey, _ := pem.Decode(sidInfo.PrivateSigner.KeyMaterial)
-		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
+		var opts bccsp.KeyImportOpts = &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true}
+		if hasEd25519PublicKey(idPub.(*identity).cert) {
+			opts = &bccsp.ED25519PrivateKeyImportOpts{Temporary: true}
+		}
+		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, opts)
 		if err != nil {
-			return nil, fmt.Errorf("getIdentityFromBytes error: Failed to import EC private key, err %s", err)
+			return nil, fmt.Errorf("getIdentityFromBytes error: Failed to import %s private key, err %s", opts.Algorithm(), err)
 		}
 	}
 
@@ -650,21 +654,28 @@ func (msp *bccspmsp) setupCAs(conf *m.FabricMSPConfig) error {
 	// Recall that sanitization is applied also to root CA and intermediate
 	// CA certificates. After their sanitization is done, the opts
 	// will be recreated using the sanitized certs.
-	msp.opts = &x509.VerifyOptions{Roots: x509.NewCertPool(), Intermediates: x509.NewCertPool()}
+	var roots, intermediates []*x509.Certificate
 	for _, v := range conf.RootCerts {
 		cert, err := msp.getCertFromPem(v)
 		if err != nil {
 			return err
 		}
-		msp.opts.Roots.AddCert(cert)
+		if err := checkEd25519Supported(cert); err != nil {
+			return err
+		}
+		roots = append(roots, cert)
 	}
 	for _, v := range conf.IntermediateCerts {
 		cert, err := msp.getCertFromPem(v)
 		if err != nil {
 			return err
 		}
-		msp.opts.Intermediates.AddCert(cert)
+		if err := checkEd25519Supported(cert); err != nil {
+			return err
+		}
+		intermediates = append(intermediates, cert)
 	}
+	msp.setVerifyOpts(roots, intermediates)
 
 	// Load root and intermediate CA identities
 	// Recall that when an identity is created, its certificate gets sanitized
@@ -690,13 +701,14 @@ func (msp *bccspmsp) setupCAs(conf *m.FabricMSPConfig) error {
 	}
 
 	// root CA and intermediate CA certificates are sanitized, they can be reimported
-	msp.opts = &x509.VerifyOptions{Roots: x509.NewCertPool(), Intermediates: x509.NewCertPool()}
+	roots, intermediates = nil, nil
 	for _, id := range msp.rootCerts {
-		msp.opts.Roots.AddCert(id.(*identity).cert)
+		roots = append(roots, id.(*identity).cert)
 	}
 	for _, id := range msp.intermediateCerts {
-		msp.opts.Intermediates.AddCert(id.(*identity).cert)
+		intermediates = append(intermediates, id.(*identity).cert)
 	}
+	msp.setVerifyOpts(roots, intermediates)
 
 	// make and fill the set of admin certs (if present)
 	msp.admins = make([]Identity, len(conf.Admins))
@@ -712,6 +724,18 @@ func (msp *bccspmsp) setupCAs(conf *m.FabricMSPConfig) error {
 	return nil
 }
 
+// setVerifyOpts creates the verify options for MSP members out of
+// the passed root and intermediate CA certs
+func (msp *bccspmsp) setVerifyOpts(roots, intermediates []*x509.Certificate) {
+	msp.opts = &x509.VerifyOptions{Roots: x509.NewCertPool(), Intermediates: x509.NewCertPool()}
+	for _, cert := range roots {
+		msp.opts.Roots.AddCert(cert)
+	}
+	for _, cert := range intermediates {
+		msp.opts.Intermediates.AddCert(cert)
+	}
+}
+
 func (msp *bccspmsp) setupAdmins(conf *m.FabricMSPConfig) error {
 	// make and fill the set of admin certs (if present)
 	msp.admins = make([]Identity, len(conf.Admins))
@@ -923,6 +947,8 @@ func (msp *bccspmsp) setupTLSCAs(conf *m.FabricMSPConfig) error {
 // sanitizeCert ensures that x509 certificates signed using ECDSA
 // do have signatures in Low-S. If this is not the case, the certificate
 // is regenerated to have a Low-S signature.
+// Certificates signed using Ed25519 are returned as they are, since
+// Ed25519 signatures are not malleable.
 func (msp *bccspmsp) sanitizeCert(cert *x509.Certificate) (*x509.Certificate, error) {
 	if isECDSASignedCert(cert) {
 		// Lookup for a parent certificate to perform the sanitization
@@ -9,7 +9,9 @@ package broadcast
 import (
 	"io"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -45,6 +47,9 @@ type ChannelSupportRegistrar interface {
 type ChannelSupport interface {
 	msgprocessor.Processor
 	Consenter
+
+	// ChannelConfig returns the config of the channel
+	ChannelConfig() channelconfig.Channel
 }
 
 // Consenter provides methods to send messages through consensus
@@ -90,6 +95,13 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_INTERNAL_SERVER_ERROR, Info: err.Error()})
 		}
 
+		if processor.ChannelConfig().Capabilities().TLSBinding() {
+			if err := comm.VerifyTLSBinding(srv.Context(), chdr); err != nil {
+				logger.Warningf("[channel: %s] Rejecting broadcast from %s because of TLS binding: %s", chdr.ChannelId, addr, err)
+				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_FORBIDDEN, Info: err.Error()})
+			}
+		}
+
 		if !isConfig {
 			logger.Debugf("[channel: %s] Broadcast is processing normal message from %s with txid '%s' of type %s", chdr.ChannelId, addr, chdr.TxId, cb.HeaderType_name[chdr.Type])
 
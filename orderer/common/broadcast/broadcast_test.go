@@ -12,7 +12,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -109,6 +111,7 @@ type mockSupport struct {
 	ProcessConfigSeq uint64
 	ProcessErr       error
 	rejectEnqueue    bool
+	tlsBinding       bool
 }
 
 // Order sends a message for ordering
@@ -124,6 +127,12 @@ func (ms *mockSupport) Configure(config *cb.Envelope, configSeq uint64) error {
 	return ms.Order(config, configSeq)
 }
 
+func (ms *mockSupport) ChannelConfig() channelconfig.Channel {
+	return &mockconfig.Channel{
+		CapabilitiesVal: &mockconfig.ChannelCapabilities{TLSBindingVal: ms.tlsBinding},
+	}
+}
+
 func (ms *mockSupport) ClassifyMsg(chdr *cb.ChannelHeader) msgprocessor.Classification {
 	panic("UNIMPLMENTED")
 }
@@ -279,3 +288,18 @@ func TestBadStreamSend(t *testing.T) {
 	m := &erroneousSendMockB{recvVal: nil}
 	assert.Error(t, bh.Handle(m), "Should catch unexpected stream error")
 }
+
+func TestTLSBinding(t *testing.T) {
+	mm := getMockSupportManager()
+	mm.MsgProcessorVal.tlsBinding = true
+	bh := NewHandlerImpl(mm)
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	// The mock stream has no TLS client certificate
+	m.recvChan <- nil
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_FORBIDDEN, reply.Status)
+	assert.Contains(t, reply.Info, "client didn't send a TLS certificate")
+}
@@ -19,8 +19,10 @@ package deliver
 import (
 	"io"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/orderer/common/ledger"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -63,6 +65,9 @@ type Support interface {
 
 	// Errored returns a channel which closes when the backing consenter has errored
 	Errored() <-chan struct{}
+
+	// ChannelConfig returns the config of the channel
+	ChannelConfig() channelconfig.Channel
 }
 
 type deliverServer struct {
@@ -136,6 +141,13 @@ func (ds *deliverServer) deliverBlocks(srv ab.AtomicBroadcast_DeliverServer, env
 
 	}
 
+	if chain.ChannelConfig().Capabilities().TLSBinding() {
+		if err := comm.VerifyTLSBinding(srv.Context(), chdr); err != nil {
+			logger.Warningf("[channel: %s] Rejecting deliver request from %s because of TLS binding: %s", chdr.ChannelId, addr, err)
+			return sendStatusReply(srv, cb.Status_FORBIDDEN)
+		}
+	}
+
 	lastConfigSequence := chain.Sequence()
 
 	sf := msgprocessor.NewSigFilter(policies.ChannelReaders, chain.PolicyManager())
@@ -17,15 +17,20 @@ limitations under the License.
 package deliver
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/provisional"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/orderer/common/ledger"
 	ramledger "github.com/hyperledger/fabric/orderer/common/ledger/ram"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -34,6 +39,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
 )
 
@@ -124,6 +130,7 @@ type mockSupport struct {
 	policyManager *mockpolicies.Manager
 	erroredChan   chan struct{}
 	configSeq     uint64
+	tlsBinding    bool
 }
 
 func (mcs *mockSupport) Errored() <-chan struct{} {
@@ -142,6 +149,12 @@ func (mcs *mockSupport) Reader() ledger.Reader {
 	return mcs.ledger
 }
 
+func (mcs *mockSupport) ChannelConfig() channelconfig.Channel {
+	return &mockconfig.Channel{
+		CapabilitiesVal: &mockconfig.ChannelCapabilities{TLSBindingVal: mcs.tlsBinding},
+	}
+}
+
 func NewRAMLedger() ledger.ReadWriter {
 	rlf := ramledger.New(ledgerSize + 1)
 	rl, _ := rlf.GetOrCreate(provisional.TestChainID)
@@ -682,3 +695,79 @@ func TestMissingSeekPosition(t *testing.T) {
 		t.Fatalf("Timed out waiting to get all blocks")
 	}
 }
+
+// tlsMockD is a mockD whose client presented a TLS certificate
+type tlsMockD struct {
+	*mockD
+	cert *x509.Certificate
+}
+
+func (m *tlsMockD) Context() context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{m.cert}}},
+	})
+}
+
+func TestTLSBindingSeek(t *testing.T) {
+	mm := newMockMultichainManager()
+	mm.chains[systemChainID].tlsBinding = true
+	ds := NewHandlerImpl(mm)
+
+	seekInfo := &ab.SeekInfo{Start: seekSpecified(uint64(0)), Stop: seekSpecified(uint64(0)), Behavior: ab.SeekInfo_BLOCK_UNTIL_READY}
+	boundSeek := func(tlsCertHash []byte) *cb.Envelope {
+		return &cb.Envelope{
+			Payload: utils.MarshalOrPanic(&cb.Payload{
+				Header: &cb.Header{
+					ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+						ChannelId:   systemChainID,
+						TlsCertHash: tlsCertHash,
+					}),
+					SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{}),
+				},
+				Data: utils.MarshalOrPanic(seekInfo),
+			}),
+		}
+	}
+
+	t.Run("NoClientCertificate", func(t *testing.T) {
+		m := newMockD()
+		defer close(m.recvChan)
+		go ds.Handle(m)
+
+		m.recvChan <- makeSeek(systemChainID, seekInfo)
+		select {
+		case deliverReply := <-m.sendChan:
+			assert.Equal(t, cb.Status_FORBIDDEN, deliverReply.GetStatus())
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for the reply")
+		}
+	})
+
+	t.Run("WrongHash", func(t *testing.T) {
+		m := &tlsMockD{mockD: newMockD(), cert: &x509.Certificate{Raw: []byte("certificate")}}
+		defer close(m.recvChan)
+		go ds.Handle(m)
+
+		m.recvChan <- boundSeek(util.ComputeSHA256([]byte("another certificate")))
+		select {
+		case deliverReply := <-m.sendChan:
+			assert.Equal(t, cb.Status_FORBIDDEN, deliverReply.GetStatus())
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for the reply")
+		}
+	})
+
+	t.Run("Bound", func(t *testing.T) {
+		m := &tlsMockD{mockD: newMockD(), cert: &x509.Certificate{Raw: []byte("certificate")}}
+		defer close(m.recvChan)
+		go ds.Handle(m)
+
+		m.recvChan <- boundSeek(util.ComputeSHA256(m.cert.Raw))
+		select {
+		case deliverReply := <-m.sendChan:
+			assert.NotNil(t, deliverReply.GetBlock(), "Expected a block")
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for the block")
+		}
+	})
+}
@@ -61,11 +61,12 @@ func init() {
 // modify the default mapping, see the "Unmarshal"
 // section of https://github.com/spf13/viper for more info
 type TopLevel struct {
-	General    General
-	FileLedger FileLedger
-	RAMLedger  RAMLedger
-	Kafka      Kafka
-	Debug      Debug
+	General     General
+	FileLedger  FileLedger
+	RAMLedger   RAMLedger
+	Kafka       Kafka
+	Debug       Debug
+	Replication Replication
 }
 
 // General contains config which should be common among all orderer types.
@@ -94,6 +95,7 @@ type TLS struct {
 	RootCAs           []string
 	ClientAuthEnabled bool
 	ClientRootCAs     []string
+	ReloadInterval    time.Duration // Only used for the orderer's server TLS
 }
 
 // Profile contains configuration for Go pprof profiling.
@@ -170,6 +172,15 @@ type Debug struct {
 	DeliverTraceDir   string
 }
 
+// Replication contains configuration for onboarding the orderer by pulling
+// the blocks of all channels from the existing orderers.
+type Replication struct {
+	Enabled     bool
+	ConfigBlock string
+	Endpoints   []string
+	Timeout     time.Duration
+}
+
 var defaults = TopLevel{
 	General: General{
 		LedgerType:     "file",
@@ -229,6 +240,10 @@ var defaults = TopLevel{
 		BroadcastTraceDir: "",
 		DeliverTraceDir:   "",
 	},
+	Replication: Replication{
+		Enabled: false,
+		Timeout: 10 * time.Second,
+	},
 }
 
 // Load parses the orderer.yaml file and environment, producing a struct suitable for config use
@@ -267,6 +282,7 @@ func (c *TopLevel) completeInitialization(configDir string) {
 		cf.TranslatePathInPlace(configDir, &c.General.TLS.Certificate)
 		cf.TranslatePathInPlace(configDir, &c.General.GenesisFile)
 		cf.TranslatePathInPlace(configDir, &c.General.LocalMSPDir)
+		cf.TranslatePathInPlace(configDir, &c.Replication.ConfigBlock)
 	}()
 
 	for {
@@ -361,6 +377,12 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("Kafka.Retry.Consumer.RetryBackoff unset, setting to %v", defaults.Kafka.Retry.Consumer.RetryBackoff)
 			c.Kafka.Retry.Consumer.RetryBackoff = defaults.Kafka.Retry.Consumer.RetryBackoff
 
+		case c.Replication.Enabled && c.Replication.ConfigBlock == "":
+			logger.Panicf("Replication.ConfigBlock must be set if Replication.Enabled is set to true.")
+		case c.Replication.Timeout == 0*time.Second:
+			logger.Infof("Replication.Timeout unset, setting to %v", defaults.Replication.Timeout)
+			c.Replication.Timeout = defaults.Replication.Timeout
+
 		case c.Kafka.Version == sarama.KafkaVersion{}:
 			logger.Infof("Kafka.Version unset, setting to %v", defaults.Kafka.Version)
 			c.Kafka.Version = defaults.Kafka.Version
@@ -117,6 +117,16 @@ func TestKafkaTLSConfig(t *testing.T) {
 	}
 }
 
+func TestReplicationConfig(t *testing.T) {
+	uconf := &TopLevel{Replication: Replication{Enabled: true}}
+	assert.Panics(t, func() { uconf.completeInitialization(DummyPath) }, "should panic")
+
+	uconf = &TopLevel{Replication: Replication{Enabled: true, ConfigBlock: "config.block"}}
+	assert.NotPanics(t, func() { uconf.completeInitialization(DummyPath) }, "should not panic")
+	assert.Equal(t, filepath.Join(DummyPath, "config.block"), uconf.Replication.ConfigBlock)
+	assert.Equal(t, defaults.Replication.Timeout, uconf.Replication.Timeout)
+}
+
 func TestSystemChannel(t *testing.T) {
 	conf := Load()
 	assert.Equal(t, provisional.TestChainID, conf.General.SystemChannel, "System channel ID should be '%s' by default", provisional.TestChainID)
@@ -0,0 +1,171 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replication
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// deliverSource pulls blocks from the Deliver service of a list of orderers,
+// moving on to the next orderer whenever one fails
+type deliverSource struct {
+	endpoints   []string
+	dialOpts    []grpc.DialOption
+	signer      crypto.LocalSigner
+	tlsCertHash []byte
+	timeout     time.Duration
+}
+
+// NewDeliverSource returns a BlockSource which pulls blocks from the Deliver
+// service of the given orderers. Requests are signed by the given signer and
+// bound to the given TLS client certificate hash, if any. The timeout bounds
+// both connecting to an orderer and waiting for each of its blocks
+func NewDeliverSource(endpoints []string, dialOpts []grpc.DialOption, signer crypto.LocalSigner, tlsCertHash []byte, timeout time.Duration) BlockSource {
+	return &deliverSource{
+		endpoints:   endpoints,
+		dialOpts:    dialOpts,
+		signer:      signer,
+		tlsCertHash: tlsCertHash,
+		timeout:     timeout,
+	}
+}
+
+// Height returns the height of the given channel at the first orderer which responds
+func (ds *deliverSource) Height(channelID string) (uint64, error) {
+	seekNewest := &ab.SeekPosition{Type: &ab.SeekPosition_Newest{Newest: &ab.SeekNewest{}}}
+	var lastErr error
+	for _, endpoint := range ds.endpoints {
+		var height uint64
+		err := ds.deliver(endpoint, channelID, seekNewest, seekNewest, ab.SeekInfo_FAIL_IF_NOT_READY, func(block *cb.Block) (bool, error) {
+			if block.Header == nil {
+				return false, errors.New("block header is missing")
+			}
+			height = block.Header.Number + 1
+			return false, nil
+		})
+		if err == nil {
+			return height, nil
+		}
+		logger.Warningf("Failed getting the height of channel %s from %s: %s", channelID, endpoint, err)
+		lastErr = err
+	}
+	return 0, errors.WithMessage(lastErr, "failed getting the height of channel "+channelID)
+}
+
+// PullBlocks pulls the given range of blocks, resuming from another orderer
+// whenever an orderer fails or f rejects one of its blocks
+func (ds *deliverSource) PullBlocks(channelID string, start, end uint64, f func(*cb.Block) error) error {
+	next := start
+	var lastErr error
+	for _, endpoint := range ds.endpoints {
+		err := ds.deliver(endpoint, channelID, seekSpecified(next), seekSpecified(end), ab.SeekInfo_BLOCK_UNTIL_READY, func(block *cb.Block) (bool, error) {
+			if err := f(block); err != nil {
+				return false, err
+			}
+			next++
+			return next <= end, nil
+		})
+		if err == nil && next > end {
+			return nil
+		}
+		if err == nil {
+			err = errors.Errorf("stream ended at block [%d]", next)
+		}
+		logger.Warningf("Failed pulling blocks of channel %s from %s: %s", channelID, endpoint, err)
+		lastErr = err
+	}
+	return errors.WithMessage(lastErr, "failed pulling blocks of channel "+channelID)
+}
+
+// deliver requests the given range of blocks from the given orderer, and passes
+// them to f until f returns false or an error
+func (ds *deliverSource) deliver(endpoint, channelID string, start, stop *ab.SeekPosition, behavior ab.SeekInfo_SeekBehavior, f func(*cb.Block) (bool, error)) error {
+	dialOpts := append([]grpc.DialOption{grpc.WithBlock(), grpc.WithTimeout(ds.timeout)}, ds.dialOpts...)
+	conn, err := grpc.Dial(endpoint, dialOpts...)
+	if err != nil {
+		return errors.Wrap(err, "failed connecting")
+	}
+	defer conn.Close()
+
+	// The stream is canceled whenever the orderer doesn't send a block in time
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timer := time.AfterFunc(ds.timeout, cancel)
+	defer timer.Stop()
+
+	stream, err := ab.NewAtomicBroadcastClient(conn).Deliver(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed opening deliver stream")
+	}
+	env, err := utils.CreateSignedEnvelopeWithTLSBinding(cb.HeaderType_DELIVER_SEEK_INFO, channelID, ds.signer, &ab.SeekInfo{
+		Start:    start,
+		Stop:     stop,
+		Behavior: behavior,
+	}, int32(0), uint64(0), ds.tlsCertHash)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating seek request")
+	}
+	if err := stream.Send(env); err != nil {
+		return errors.Wrap(err, "failed sending seek request")
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return errors.Wrap(err, "failed receiving block")
+		}
+		switch t := resp.Type.(type) {
+		case *ab.DeliverResponse_Block:
+			timer.Reset(ds.timeout)
+			more, err := f(t.Block)
+			if err != nil || !more {
+				return err
+			}
+		case *ab.DeliverResponse_Status:
+			return errors.Errorf("got status %s before all blocks were received", t.Status)
+		default:
+			return errors.Errorf("got unexpected response type %T", t)
+		}
+	}
+}
+
+func seekSpecified(number uint64) *ab.SeekPosition {
+	return &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: number}}}
+}
+
+// OrdererTLSRootCAs returns the TLS root and intermediate certificates
+// of the orderer organizations of the given config
+func OrdererTLSRootCAs(config channelconfig.Resources) [][]byte {
+	ordererConfig, ok := config.OrdererConfig()
+	if !ok {
+		return nil
+	}
+	msps, err := config.MSPManager().GetMSPs()
+	if err != nil {
+		logger.Warningf("Failed getting the MSPs of the config: %s", err)
+		return nil
+	}
+	var rootCAs [][]byte
+	for _, org := range ordererConfig.Organizations() {
+		orgMSP, exists := msps[org.MSPID()]
+		if !exists {
+			continue
+		}
+		rootCAs = append(rootCAs, orgMSP.GetTLSRootCerts()...)
+		rootCAs = append(rootCAs, orgMSP.GetTLSIntermediateCerts()...)
+	}
+	return rootCAs
+}
@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replication
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/localmsp"
+	ramledger "github.com/hyperledger/fabric/orderer/common/ledger/ram"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// mockOrderer serves the blocks of its chains over the Deliver API
+type mockOrderer struct {
+	chains   map[string][]*cb.Block
+	requests chan *cb.ChannelHeader
+}
+
+func (mo *mockOrderer) Broadcast(srv ab.AtomicBroadcast_BroadcastServer) error {
+	return errors.New("not implemented")
+}
+
+func (mo *mockOrderer) Deliver(srv ab.AtomicBroadcast_DeliverServer) error {
+	env, err := srv.Recv()
+	if err != nil {
+		return err
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return err
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return err
+	}
+	mo.requests <- chdr
+	seekInfo := &ab.SeekInfo{}
+	if _, err := utils.UnmarshalEnvelopeOfType(env, cb.HeaderType_DELIVER_SEEK_INFO, seekInfo); err != nil {
+		return err
+	}
+
+	blocks := mo.chains[chdr.ChannelId]
+	position := func(pos *ab.SeekPosition) uint64 {
+		if pos.GetNewest() != nil {
+			return uint64(len(blocks)) - 1
+		}
+		return pos.GetSpecified().Number
+	}
+	for i := position(seekInfo.Start); i <= position(seekInfo.Stop); i++ {
+		if err := srv.Send(&ab.DeliverResponse{Type: &ab.DeliverResponse_Block{Block: blocks[i]}}); err != nil {
+			return err
+		}
+	}
+	return srv.Send(&ab.DeliverResponse{Type: &ab.DeliverResponse_Status{Status: cb.Status_SUCCESS}})
+}
+
+func startMockOrderer(t *testing.T, chains map[string][]*cb.Block) (*mockOrderer, string, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	mo := &mockOrderer{chains: chains, requests: make(chan *cb.ChannelHeader, 100)}
+	ab.RegisterAtomicBroadcastServer(server, mo)
+	go server.Serve(lis)
+	return mo, lis.Addr().String(), server.Stop
+}
+
+func TestDeliverSource(t *testing.T) {
+	tn := newTestNetwork(t)
+	mo, endpoint, stop := startMockOrderer(t, tn.source().chains)
+	defer stop()
+
+	// The first orderer is unreachable, so blocks are pulled from the second one
+	unreachable, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	unreachableEndpoint := unreachable.Addr().String()
+	unreachable.Close()
+
+	source := NewDeliverSource([]string{unreachableEndpoint, endpoint}, []grpc.DialOption{grpc.WithInsecure()},
+		localmsp.NewSigner(), []byte{1, 2, 3}, time.Second)
+	height, err := source.Height(systemChannelID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), height)
+	chdr := <-mo.requests
+	assert.Equal(t, int32(cb.HeaderType_DELIVER_SEEK_INFO), chdr.Type)
+	assert.Equal(t, []byte{1, 2, 3}, chdr.TlsCertHash)
+
+	var pulled []*cb.Block
+	err = source.PullBlocks(systemChannelID, 1, 3, func(block *cb.Block) error {
+		pulled = append(pulled, block)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, tn.systemChain.blocks()[1:4], pulled)
+
+	// Blocks rejected by the caller are pulled again from the next orderer,
+	// and pulling fails once no orderer is left
+	err = source.PullBlocks(systemChannelID, 1, 3, func(block *cb.Block) error {
+		return errors.New("bad block")
+	})
+	assert.EqualError(t, err, "failed pulling blocks of channel testsystemchannel: bad block")
+
+	source = NewDeliverSource([]string{unreachableEndpoint}, []grpc.DialOption{grpc.WithInsecure()},
+		localmsp.NewSigner(), nil, 100*time.Millisecond)
+	_, err = source.Height(systemChannelID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed getting the height of channel testsystemchannel")
+}
+
+func TestReplicateOverDeliver(t *testing.T) {
+	tn := newTestNetwork(t)
+	_, endpoint, stop := startMockOrderer(t, tn.source().chains)
+	defer stop()
+
+	source := NewDeliverSource([]string{endpoint}, []grpc.DialOption{grpc.WithInsecure()}, localmsp.NewSigner(), nil, time.Second)
+	lf := ramledger.New(100)
+	r := &Replicator{LedgerFactory: lf, Source: source, ConfigBlock: tn.configBlock}
+	assert.NoError(t, r.Replicate())
+	assertReplicated(t, lf, systemChannelID, tn.systemChain.blocks())
+	assertReplicated(t, lf, channelID, tn.channel.blocks())
+}
@@ -0,0 +1,336 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package replication onboards a new orderer by pulling the blocks of all
+// channels from the existing orderers, instead of copying their ledgers
+// out-of-band.
+package replication
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/ledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const pkgLogID = "orderer/common/replication"
+
+var logger = flogging.MustGetLogger(pkgLogID)
+
+// BlockSource pulls the blocks of channels from the ordering service
+type BlockSource interface {
+	// Height returns the height of the given channel at the ordering service
+	Height(channelID string) (uint64, error)
+
+	// PullBlocks pulls the blocks of the given channel in the range [start, end],
+	// and passes them in order to the given function. A block is pulled again
+	// from another orderer if the function returns an error for it
+	PullBlocks(channelID string, start, end uint64, f func(*cb.Block) error) error
+}
+
+// Replicator replicates the system channel, and all the channels created by it,
+// to the local ledgers
+type Replicator struct {
+	// LedgerFactory creates the local ledgers
+	LedgerFactory ledger.Factory
+
+	// Source is where the blocks are pulled from
+	Source BlockSource
+
+	// ConfigBlock is a config block of the system channel which the trust in the
+	// replicated blocks is anchored to. The blocks of the system channel up to it
+	// must chain to it, and the blocks after it are verified against its block
+	// validation policy, until a later config block updates it
+	ConfigBlock *cb.Block
+}
+
+// Replicate pulls the blocks of all channels which are missing from the local
+// ledgers, and appends them after verifying them. Replication resumes from the
+// local ledgers, so it can be repeated after a failure
+func (r *Replicator) Replicate() error {
+	if r.ConfigBlock == nil || r.ConfigBlock.Header == nil || !utils.IsConfigBlock(r.ConfigBlock) {
+		return errors.New("block to replicate from is not a config block")
+	}
+	config, err := channelconfig.NewBundleFromEnvelope(configEnvelope(r.ConfigBlock))
+	if err != nil {
+		return errors.WithMessage(err, "failed creating config from config block")
+	}
+	if _, ok := config.ConsortiumsConfig(); !ok {
+		return errors.New("config block is not a config block of the system channel")
+	}
+
+	systemChannelID := config.ConfigtxManager().ChainID()
+	createdChannels, err := r.replicateSystemChannel(systemChannelID, config)
+	if err != nil {
+		return errors.WithMessage(err, "failed replicating the system channel")
+	}
+
+	channelIDs := make([]string, 0, len(createdChannels))
+	for channelID := range createdChannels {
+		channelIDs = append(channelIDs, channelID)
+	}
+	sort.Strings(channelIDs)
+	for _, channelID := range channelIDs {
+		if err := r.replicateChannel(channelID, createdChannels[channelID]); err != nil {
+			return errors.WithMessage(err, "failed replicating channel "+channelID)
+		}
+	}
+
+	logger.Infof("Replicated the system channel %s and %d channels", systemChannelID, len(channelIDs))
+	return nil
+}
+
+// replicateSystemChannel replicates the system channel, and returns the
+// config envelopes of the channels created by it, by channel ID
+func (r *Replicator) replicateSystemChannel(channelID string, anchorConfig channelconfig.Resources) (map[string]*cb.Envelope, error) {
+	rl, err := r.LedgerFactory.GetOrCreate(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	anchorNumber := r.ConfigBlock.Header.Number
+	anchorHash := r.ConfigBlock.Header.Hash()
+	createdChannels := make(map[string]*cb.Envelope)
+	config := anchorConfig
+	var prevBlock *cb.Block
+
+	matchAnchor := func(block *cb.Block) error {
+		if block.Header.Number == anchorNumber && !bytes.Equal(block.Header.Hash(), anchorHash) {
+			return errors.Errorf("block [%d] doesn't match the config block", anchorNumber)
+		}
+		return nil
+	}
+
+	// accept records a block of the system channel which is verified or
+	// already replicated, and updates the config once past the anchor
+	accept := func(block *cb.Block) error {
+		number := block.Header.Number
+		newChannelID, newChannelConfig, err := channelCreation(block)
+		if err != nil {
+			return errors.WithMessage(err, "failed extracting channel creation from block")
+		}
+		if newChannelID != "" {
+			createdChannels[newChannelID] = newChannelConfig
+		}
+		if env := configEnvelope(block); env != nil && number > anchorNumber {
+			if config, err = channelconfig.NewBundleFromEnvelope(env); err != nil {
+				return errors.WithMessage(err, "failed creating config from config block")
+			}
+		}
+		prevBlock = block
+		return nil
+	}
+
+	height := rl.Height()
+	for number := uint64(0); number < height; number++ {
+		block := ledger.GetBlock(rl, number)
+		if block == nil {
+			return nil, errors.Errorf("failed reading block [%d] from the local ledger", number)
+		}
+		if err := matchAnchor(block); err != nil {
+			return nil, err
+		}
+		if err := accept(block); err != nil {
+			return nil, err
+		}
+	}
+
+	target, err := r.Source.Height(channelID)
+	if err != nil {
+		return nil, err
+	}
+	if target <= anchorNumber {
+		return nil, errors.Errorf("ordering service has %d blocks, but the config block is block [%d]", target, anchorNumber)
+	}
+	if target <= height {
+		return createdChannels, nil
+	}
+	logger.Infof("Replicating blocks [%d, %d] of system channel %s", height, target-1, channelID)
+
+	// The blocks up to the config block may be signed by orderers which its config doesn't trust
+	// anymore, hence they are authenticated by the chain of hashes which ends at the config block.
+	// As the blocks are pulled in ascending order, their hashes are pulled and verified first,
+	// and the blocks are pulled again to be appended if their hashes match
+	if height <= anchorNumber {
+		hashes, err := r.pullAnchoredHashes(channelID, height, prevBlock)
+		if err != nil {
+			return nil, err
+		}
+		err = r.Source.PullBlocks(channelID, height, anchorNumber, func(block *cb.Block) error {
+			expected := uint64(0)
+			if prevBlock != nil {
+				expected = prevBlock.Header.Number + 1
+			}
+			if err := verifyBlockHeader(block, expected, nil); err != nil {
+				return err
+			}
+			if !bytes.Equal(block.Header.Hash(), hashes[expected-height]) {
+				return errors.Errorf("block [%d] doesn't chain to the config block", expected)
+			}
+			return appendBlock(rl, block, accept)
+		})
+		if err != nil {
+			return nil, err
+		}
+		height = anchorNumber + 1
+	}
+	if target <= height {
+		return createdChannels, nil
+	}
+
+	// The blocks after the config block are verified against the block validation policy
+	// of the config they are created with, starting with the one of the config block
+	err = r.Source.PullBlocks(channelID, height, target-1, func(block *cb.Block) error {
+		if err := VerifyBlock(block, prevBlock.Header.Number+1, prevBlock, config); err != nil {
+			return err
+		}
+		return appendBlock(rl, block, accept)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return createdChannels, nil
+}
+
+// pullAnchoredHashes returns the header hashes of the blocks of the system channel from the given
+// block up to the config block, which is the last one. The blocks are pulled, and each block must
+// chain to the previous one, starting with the given local block (if any), and the config block
+// must chain to the last of them
+func (r *Replicator) pullAnchoredHashes(channelID string, start uint64, prevBlock *cb.Block) ([][]byte, error) {
+	anchor := r.ConfigBlock.Header
+	var prevHash []byte
+	if prevBlock != nil {
+		prevHash = prevBlock.Header.Hash()
+	}
+	var hashes [][]byte
+	if start < anchor.Number {
+		err := r.Source.PullBlocks(channelID, start, anchor.Number-1, func(block *cb.Block) error {
+			if err := verifyBlockHeader(block, start+uint64(len(hashes)), prevHash); err != nil {
+				return err
+			}
+			prevHash = block.Header.Hash()
+			hashes = append(hashes, prevHash)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !bytes.Equal(anchor.PreviousHash, prevHash) {
+		return nil, errors.Errorf("block [%d] doesn't match the config block", anchor.Number)
+	}
+	return append(hashes, anchor.Hash()), nil
+}
+
+// replicateChannel replicates a channel created by the system channel
+// with the given config envelope
+func (r *Replicator) replicateChannel(channelID string, genesisConfig *cb.Envelope) error {
+	rl, err := r.LedgerFactory.GetOrCreate(channelID)
+	if err != nil {
+		return err
+	}
+
+	var config channelconfig.Resources
+	var prevBlock *cb.Block
+	height := rl.Height()
+	if height > 0 {
+		if prevBlock = ledger.GetBlock(rl, height-1); prevBlock == nil {
+			return errors.Errorf("failed reading block [%d] from the local ledger", height-1)
+		}
+		if config, err = lastConfig(rl, prevBlock); err != nil {
+			return err
+		}
+	}
+
+	target, err := r.Source.Height(channelID)
+	if err != nil {
+		return err
+	}
+	if target <= height {
+		return nil
+	}
+	logger.Infof("Replicating blocks [%d, %d] of channel %s", height, target-1, channelID)
+
+	return r.Source.PullBlocks(channelID, height, target-1, func(block *cb.Block) error {
+		var newConfig channelconfig.Resources
+		if prevBlock == nil {
+			if err := verifyGenesisBlock(block, genesisConfig); err != nil {
+				return err
+			}
+			if newConfig, err = channelconfig.NewBundleFromEnvelope(genesisConfig); err != nil {
+				return errors.WithMessage(err, "failed creating config from genesis block")
+			}
+		} else {
+			if err := VerifyBlock(block, prevBlock.Header.Number+1, prevBlock, config); err != nil {
+				return err
+			}
+			if env := configEnvelope(block); env != nil {
+				if newConfig, err = channelconfig.NewBundleFromEnvelope(env); err != nil {
+					return errors.WithMessage(err, "failed creating config from config block")
+				}
+			}
+		}
+		if err := rl.Append(block); err != nil {
+			return errors.WithMessage(err, "failed appending block to the local ledger")
+		}
+		prevBlock = block
+		if newConfig != nil {
+			config = newConfig
+		}
+		return nil
+	})
+}
+
+// verifyGenesisBlock checks that the given block is the genesis block
+// of a channel created with the given config envelope
+func verifyGenesisBlock(block *cb.Block, genesisConfig *cb.Envelope) error {
+	if block == nil || block.Header == nil || block.Data == nil {
+		return errors.New("genesis block is malformed")
+	}
+	if block.Header.Number != 0 {
+		return errors.Errorf("expected block [0] but got block [%d]", block.Header.Number)
+	}
+	if !bytes.Equal(block.Data.Hash(), block.Header.DataHash) {
+		return errors.New("header data hash of genesis block doesn't match its data")
+	}
+	if len(block.Data.Data) != 1 {
+		return errors.Errorf("genesis block has %d transactions", len(block.Data.Data))
+	}
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return err
+	}
+	if !proto.Equal(env, genesisConfig) {
+		return errors.New("genesis block doesn't match the channel creation transaction of the system channel")
+	}
+	return nil
+}
+
+// lastConfig returns the config of the local ledger as of the given block
+func lastConfig(rl ledger.Reader, block *cb.Block) (channelconfig.Resources, error) {
+	index, err := utils.GetLastConfigIndexFromBlock(block)
+	if err != nil {
+		return nil, err
+	}
+	configBlock := ledger.GetBlock(rl, index)
+	if configBlock == nil || !utils.IsConfigBlock(configBlock) {
+		return nil, errors.Errorf("failed reading config block [%d] from the local ledger", index)
+	}
+	return channelconfig.NewBundleFromEnvelope(configEnvelope(configBlock))
+}
+
+func appendBlock(rl ledger.Writer, block *cb.Block, accept func(*cb.Block) error) error {
+	if err := rl.Append(block); err != nil {
+		return errors.WithMessage(err, "failed appending block to the local ledger")
+	}
+	return accept(block)
+}
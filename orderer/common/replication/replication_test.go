@@ -0,0 +1,336 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replication
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/localmsp"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/provisional"
+	"github.com/hyperledger/fabric/common/util"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/orderer/common/ledger"
+	ramledger "github.com/hyperledger/fabric/orderer/common/ledger/ram"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	systemChannelID = "testsystemchannel"
+	channelID       = "testchannel"
+)
+
+func TestMain(m *testing.M) {
+	if err := msptesttools.LoadMSPSetupForTesting(); err != nil {
+		panic(fmt.Sprintf("Failed loading MSP: %s", err))
+	}
+	os.Exit(m.Run())
+}
+
+// testChain builds a chain of blocks signed by the local MSP
+type testChain struct {
+	t          *testing.T
+	rl         ledger.ReadWriter
+	lastConfig uint64
+}
+
+func newTestChain(t *testing.T, genesisBlock *cb.Block) *testChain {
+	rl, err := ramledger.New(100).GetOrCreate("chain")
+	assert.NoError(t, err)
+	assert.NoError(t, rl.Append(genesisBlock))
+	return &testChain{t: t, rl: rl}
+}
+
+func (tc *testChain) append(env *cb.Envelope) *cb.Block {
+	block := ledger.CreateNextBlock(tc.rl, []*cb.Envelope{env})
+	if utils.IsConfigBlock(block) {
+		tc.lastConfig = block.Header.Number
+	}
+	block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: utils.MarshalOrPanic(&cb.LastConfig{Index: tc.lastConfig}),
+	})
+
+	signer := localmsp.NewSigner()
+	sigHeader, err := signer.NewSignatureHeader()
+	assert.NoError(tc.t, err)
+	sigHeaderBytes := utils.MarshalOrPanic(sigHeader)
+	signature, err := signer.Sign(util.ConcatenateBytes(nil, sigHeaderBytes, block.Header.Bytes()))
+	assert.NoError(tc.t, err)
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&cb.Metadata{
+		Signatures: []*cb.MetadataSignature{{SignatureHeader: sigHeaderBytes, Signature: signature}},
+	})
+
+	assert.NoError(tc.t, tc.rl.Append(block))
+	return block
+}
+
+func (tc *testChain) blocks() []*cb.Block {
+	var blocks []*cb.Block
+	for i := uint64(0); i < tc.rl.Height(); i++ {
+		blocks = append(blocks, ledger.GetBlock(tc.rl, i))
+	}
+	return blocks
+}
+
+func makeNormalTx(channelID string, i int) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(cb.HeaderType_MESSAGE),
+					ChannelId: channelID,
+				}),
+			},
+			Data: []byte(fmt.Sprintf("%d", i)),
+		}),
+	}
+}
+
+// mockSource serves the blocks of its chains
+type mockSource struct {
+	chains map[string][]*cb.Block
+}
+
+func (ms *mockSource) Height(channelID string) (uint64, error) {
+	return uint64(len(ms.chains[channelID])), nil
+}
+
+func (ms *mockSource) PullBlocks(channelID string, start, end uint64, f func(*cb.Block) error) error {
+	for i := start; i <= end; i++ {
+		if err := f(ms.chains[channelID][i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// testNetwork is a system channel which created a channel, and the config
+// block of the system channel to replicate from
+type testNetwork struct {
+	systemChain *testChain
+	channel     *testChain
+	configBlock *cb.Block
+}
+
+func newTestNetwork(t *testing.T) *testNetwork {
+	conf := genesisconfig.Load(genesisconfig.SampleSingleMSPSoloProfile)
+	systemGenesisBlock := provisional.New(conf).GenesisBlockForChannel(systemChannelID)
+	systemConfig := utils.ExtractEnvelopeOrPanic(systemGenesisBlock, 0)
+	channelConfig := utils.ExtractEnvelopeOrPanic(provisional.New(conf).GenesisBlockForChannel(channelID), 0)
+
+	tn := &testNetwork{systemChain: newTestChain(t, systemGenesisBlock)}
+	tn.systemChain.append(makeNormalTx(systemChannelID, 1))
+	ordererTx, err := utils.CreateSignedEnvelope(cb.HeaderType_ORDERER_TRANSACTION, systemChannelID, localmsp.NewSigner(), channelConfig, 0, 0)
+	assert.NoError(t, err)
+	tn.systemChain.append(ordererTx)
+	tn.configBlock = tn.systemChain.append(systemConfig)
+	tn.systemChain.append(makeNormalTx(systemChannelID, 4))
+
+	channelRL, err := ramledger.New(100).GetOrCreate(channelID)
+	assert.NoError(t, err)
+	channelGenesisBlock := ledger.CreateNextBlock(channelRL, []*cb.Envelope{channelConfig})
+	channelGenesisBlock.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: utils.MarshalOrPanic(&cb.LastConfig{Index: 0}),
+	})
+	tn.channel = newTestChain(t, channelGenesisBlock)
+	tn.channel.append(makeNormalTx(channelID, 1))
+	tn.channel.append(makeNormalTx(channelID, 2))
+	return tn
+}
+
+func (tn *testNetwork) source() *mockSource {
+	return &mockSource{chains: map[string][]*cb.Block{
+		systemChannelID: tn.systemChain.blocks(),
+		channelID:       tn.channel.blocks(),
+	}}
+}
+
+func assertReplicated(t *testing.T, lf ledger.Factory, channelID string, blocks []*cb.Block) {
+	rl, err := lf.GetOrCreate(channelID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(len(blocks)), rl.Height())
+	for i, block := range blocks {
+		assert.Equal(t, block, ledger.GetBlock(rl, uint64(i)))
+	}
+}
+
+func TestReplicate(t *testing.T) {
+	tn := newTestNetwork(t)
+	lf := ramledger.New(100)
+	r := &Replicator{LedgerFactory: lf, Source: tn.source(), ConfigBlock: tn.configBlock}
+	assert.NoError(t, r.Replicate())
+	chainIDs := lf.ChainIDs()
+	sort.Strings(chainIDs)
+	assert.Equal(t, []string{channelID, systemChannelID}, chainIDs)
+	assertReplicated(t, lf, systemChannelID, tn.systemChain.blocks())
+	assertReplicated(t, lf, channelID, tn.channel.blocks())
+
+	// Replication resumes from the local ledgers
+	tn.systemChain.append(makeNormalTx(systemChannelID, 5))
+	tn.channel.append(makeNormalTx(channelID, 3))
+	r.Source = tn.source()
+	assert.NoError(t, r.Replicate())
+	assertReplicated(t, lf, systemChannelID, tn.systemChain.blocks())
+	assertReplicated(t, lf, channelID, tn.channel.blocks())
+
+	// Replicating from the genesis block of the system channel works as well
+	lf = ramledger.New(100)
+	r = &Replicator{LedgerFactory: lf, Source: tn.source(), ConfigBlock: tn.systemChain.blocks()[0]}
+	assert.NoError(t, r.Replicate())
+	assertReplicated(t, lf, systemChannelID, tn.systemChain.blocks())
+	assertReplicated(t, lf, channelID, tn.channel.blocks())
+}
+
+func TestReplicateTampered(t *testing.T) {
+	tn := newTestNetwork(t)
+
+	// A block with altered data before the config block doesn't chain to it, and isn't replicated
+	source := tn.source()
+	source.chains[systemChannelID][1] = tamperedBlock(source.chains[systemChannelID][1])
+	lf := ramledger.New(100)
+	r := &Replicator{LedgerFactory: lf, Source: source, ConfigBlock: tn.configBlock}
+	err := r.Replicate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "block [2] doesn't chain to block [1]")
+	rl, _ := lf.GetOrCreate(systemChannelID)
+	assert.Equal(t, uint64(0), rl.Height())
+
+	// A block with altered data after the config block isn't signed according to its config
+	source = tn.source()
+	source.chains[systemChannelID][4] = tamperedBlock(source.chains[systemChannelID][4])
+	lf = ramledger.New(100)
+	r = &Replicator{LedgerFactory: lf, Source: source, ConfigBlock: tn.configBlock}
+	err = r.Replicate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "block signatures don't satisfy the block validation policy")
+	rl, _ = lf.GetOrCreate(systemChannelID)
+	assert.Equal(t, uint64(4), rl.Height())
+
+	// A genesis block which doesn't match the creation of the channel isn't replicated
+	source = tn.source()
+	genesisBlock := *source.chains[channelID][0]
+	genesisBlock.Data = &cb.BlockData{Data: [][]byte{utils.MarshalOrPanic(makeNormalTx(channelID, 0))}}
+	genesisBlock.Header = &cb.BlockHeader{DataHash: genesisBlock.Data.Hash()}
+	source.chains[channelID][0] = &genesisBlock
+	r = &Replicator{LedgerFactory: ramledger.New(100), Source: source, ConfigBlock: tn.configBlock}
+	err = r.Replicate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "genesis block doesn't match the channel creation transaction")
+
+	// The system channel must chain to the config block
+	otherNetwork := newTestNetwork(t)
+	r = &Replicator{LedgerFactory: ramledger.New(100), Source: tn.source(), ConfigBlock: otherNetwork.configBlock}
+	err = r.Replicate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "block [3] doesn't match the config block")
+
+	// The ordering service must have the config block
+	r = &Replicator{LedgerFactory: ramledger.New(100), Source: &mockSource{}, ConfigBlock: tn.configBlock}
+	assert.EqualError(t, r.Replicate(), "failed replicating the system channel: ordering service has 0 blocks, but the config block is block [3]")
+}
+
+func TestReplicateBlocksBeforeConfigBlock(t *testing.T) {
+	tn := newTestNetwork(t)
+
+	// The blocks before the config block are authenticated by the chain of their hashes,
+	// hence they are replicated even if they aren't signed according to the config block
+	source := tn.source()
+	for _, block := range source.chains[systemChannelID][1:3] {
+		block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&cb.Metadata{})
+	}
+	lf := ramledger.New(100)
+	r := &Replicator{LedgerFactory: lf, Source: source, ConfigBlock: tn.configBlock}
+	assert.NoError(t, r.Replicate())
+	assertReplicated(t, lf, systemChannelID, source.chains[systemChannelID])
+	assertReplicated(t, lf, channelID, tn.channel.blocks())
+
+	// Replication resumes from a local ledger which stops before the config block
+	lf = ramledger.New(100)
+	rl, err := lf.GetOrCreate(systemChannelID)
+	assert.NoError(t, err)
+	for _, block := range source.chains[systemChannelID][:2] {
+		assert.NoError(t, rl.Append(block))
+	}
+	r = &Replicator{LedgerFactory: lf, Source: source, ConfigBlock: tn.configBlock}
+	assert.NoError(t, r.Replicate())
+	assertReplicated(t, lf, systemChannelID, source.chains[systemChannelID])
+	assertReplicated(t, lf, channelID, tn.channel.blocks())
+
+	// Blocks served the second time must be the ones whose hashes were verified
+	lf = ramledger.New(100)
+	r = &Replicator{LedgerFactory: lf, Source: &switchingSource{mockSource: tn.source(), chainID: systemChannelID, block: 1}, ConfigBlock: tn.configBlock}
+	err = r.Replicate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "block [1] doesn't chain to the config block")
+}
+
+func TestReplicateBadConfigBlock(t *testing.T) {
+	tn := newTestNetwork(t)
+
+	r := &Replicator{LedgerFactory: ramledger.New(100), Source: tn.source(), ConfigBlock: tn.systemChain.blocks()[1]}
+	assert.EqualError(t, r.Replicate(), "block to replicate from is not a config block")
+
+	noConsortiumConf := genesisconfig.Load("SampleNoConsortium")
+	r.ConfigBlock = provisional.New(noConsortiumConf).GenesisBlockForChannel(channelID)
+	assert.EqualError(t, r.Replicate(), "config block is not a config block of the system channel")
+}
+
+func TestReplicateSourceFailure(t *testing.T) {
+	tn := newTestNetwork(t)
+	r := &Replicator{LedgerFactory: ramledger.New(100), Source: &failingSource{tn.source()}, ConfigBlock: tn.configBlock}
+	assert.EqualError(t, r.Replicate(), "failed replicating the system channel: orderers unavailable")
+}
+
+type failingSource struct {
+	*mockSource
+}
+
+func (fs *failingSource) Height(channelID string) (uint64, error) {
+	return 0, errors.New("orderers unavailable")
+}
+
+// tamperedBlock returns a copy of the block with altered data, and a header consistent with it
+func tamperedBlock(block *cb.Block) *cb.Block {
+	tampered := *block
+	tampered.Data = &cb.BlockData{Data: [][]byte{utils.MarshalOrPanic(makeNormalTx(systemChannelID, 100))}}
+	tampered.Header = &cb.BlockHeader{
+		Number:       block.Header.Number,
+		PreviousHash: block.Header.PreviousHash,
+		DataHash:     tampered.Data.Hash(),
+	}
+	return &tampered
+}
+
+// switchingSource serves a tampered block of a chain once the block was pulled once
+type switchingSource struct {
+	*mockSource
+	chainID string
+	block   uint64
+	pulled  bool
+}
+
+func (ss *switchingSource) PullBlocks(channelID string, start, end uint64, f func(*cb.Block) error) error {
+	for i := start; i <= end; i++ {
+		block := ss.chains[channelID][i]
+		if channelID == ss.chainID && i == ss.block {
+			if ss.pulled {
+				block = tamperedBlock(block)
+			}
+			ss.pulled = true
+		}
+		if err := f(block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
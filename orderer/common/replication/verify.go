@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replication
+
+import (
+	"bytes"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// VerifyBlock checks that the given block is the block with the given number,
+// that its header is consistent with its data and chains to the given previous
+// block (if any), and that its signatures satisfy the block validation policy
+// of the given config
+func VerifyBlock(block *cb.Block, number uint64, prevBlock *cb.Block, config channelconfig.Resources) error {
+	var prevHash []byte
+	if prevBlock != nil {
+		prevHash = prevBlock.Header.Hash()
+	}
+	if err := verifyBlockHeader(block, number, prevHash); err != nil {
+		return err
+	}
+	return verifyBlockSignatures(block, config)
+}
+
+// verifyBlockHeader checks that the given block is the block with the given number,
+// and that its header is consistent with its data and chains to the block with the
+// given hash (if any)
+func verifyBlockHeader(block *cb.Block, number uint64, prevHash []byte) error {
+	if block == nil || block.Header == nil || block.Data == nil || block.Metadata == nil {
+		return errors.New("block is malformed")
+	}
+	if block.Header.Number != number {
+		return errors.Errorf("expected block [%d] but got block [%d]", number, block.Header.Number)
+	}
+	if !bytes.Equal(block.Data.Hash(), block.Header.DataHash) {
+		return errors.Errorf("header data hash of block [%d] doesn't match its data", number)
+	}
+	if prevHash != nil && !bytes.Equal(block.Header.PreviousHash, prevHash) {
+		return errors.Errorf("block [%d] doesn't chain to block [%d]", number, number-1)
+	}
+	return nil
+}
+
+// verifyBlockSignatures evaluates the signatures of the block against
+// the block validation policy of the given config
+func verifyBlockSignatures(block *cb.Block, config channelconfig.Resources) error {
+	policy, ok := config.PolicyManager().GetPolicy(policies.BlockValidation)
+	if !ok {
+		return errors.Errorf("policy %s not found", policies.BlockValidation)
+	}
+	return utils.VerifyBlockSignatures(block, policy)
+}
+
+// configEnvelope returns the config envelope of the given block,
+// or nil if it isn't a config block
+func configEnvelope(block *cb.Block) *cb.Envelope {
+	if !utils.IsConfigBlock(block) {
+		return nil
+	}
+	return utils.ExtractEnvelopeOrPanic(block, 0)
+}
+
+// channelCreation returns the channel ID and the config envelope of the channel
+// created by the given block of the system channel, or an empty ID if the block
+// doesn't create a channel
+func channelCreation(block *cb.Block) (string, *cb.Envelope, error) {
+	if len(block.Data.Data) != 1 {
+		return "", nil, nil
+	}
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	payload, err := utils.ExtractPayload(env)
+	if err != nil {
+		return "", nil, err
+	}
+	if payload.Header == nil {
+		return "", nil, errors.New("payload header is missing")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return "", nil, err
+	}
+	if chdr.Type != int32(cb.HeaderType_ORDERER_TRANSACTION) {
+		return "", nil, nil
+	}
+	newChannelEnv, err := utils.UnmarshalEnvelope(payload.Data)
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "orderer transaction doesn't embed a config envelope")
+	}
+	channelID, err := utils.ChannelID(newChannelEnv)
+	if err != nil {
+		return "", nil, err
+	}
+	return channelID, newChannelEnv, nil
+}
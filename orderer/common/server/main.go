@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package server
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -14,16 +16,19 @@ import (
 	_ "net/http/pprof" // This is essentially the main package for the orderer
 	"os"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/provisional"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
 	"github.com/hyperledger/fabric/orderer/common/ledger"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/metadata"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/common/replication"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/orderer/consensus/kafka"
 	"github.com/hyperledger/fabric/orderer/consensus/solo"
@@ -35,6 +40,8 @@ import (
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/orderer/common/performance"
 	"github.com/op/go-logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
@@ -83,6 +90,10 @@ func Start(cmd string, conf *config.TopLevel) {
 		logger.Infof("Starting %s", metadata.GetVersionInfo())
 		initializeProfilingService(conf)
 		grpcServer := initializeGrpcServer(conf)
+		if conf.General.TLS.Enabled {
+			certWatcher := initializeTLSCertificateWatcher(conf, grpcServer)
+			defer certWatcher.Stop()
+		}
 		ab.RegisterAtomicBroadcastServer(grpcServer.Server(), server)
 		logger.Info("Beginning to serve requests")
 		grpcServer.Start()
@@ -186,6 +197,62 @@ func initializeBootstrapChannel(conf *config.TopLevel, lf ledger.Factory) {
 	}
 }
 
+// initializeReplication pulls the blocks of all channels from the existing
+// orderers, starting from the system channel of the configured config block
+func initializeReplication(conf *config.TopLevel, lf ledger.Factory, signer crypto.LocalSigner) {
+	configBlock := file.New(conf.Replication.ConfigBlock).GenesisBlock()
+	configEnv, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		logger.Fatal("Failed to extract config envelope from config block:", err)
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(configEnv)
+	if err != nil {
+		logger.Fatal("Failed to create config from config block:", err)
+	}
+
+	endpoints := conf.Replication.Endpoints
+	if len(endpoints) == 0 {
+		endpoints = bundle.ChannelConfig().OrdererAddresses()
+	}
+
+	var dialOpts []grpc.DialOption
+	var tlsCertHash []byte
+	if conf.General.TLS.Enabled {
+		rootCAs := x509.NewCertPool()
+		for _, rootCA := range replication.OrdererTLSRootCAs(bundle) {
+			rootCAs.AppendCertsFromPEM(rootCA)
+		}
+		for _, rootCAFile := range conf.General.TLS.RootCAs {
+			rootCA, err := ioutil.ReadFile(rootCAFile)
+			if err != nil {
+				logger.Fatalf("Failed to load RootCAs file '%s' (%s)", rootCAFile, err)
+			}
+			rootCAs.AppendCertsFromPEM(rootCA)
+		}
+		cert, err := tls.LoadX509KeyPair(conf.General.TLS.Certificate, conf.General.TLS.PrivateKey)
+		if err != nil {
+			logger.Fatal("Failed to load TLS certificate:", err)
+		}
+		tlsCertHash = util.ComputeSHA256(cert.Certificate[0])
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			RootCAs:      rootCAs,
+			Certificates: []tls.Certificate{cert},
+		})))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithInsecure())
+	}
+
+	logger.Infof("Replicating the ledgers from %v", endpoints)
+	replicator := &replication.Replicator{
+		LedgerFactory: lf,
+		Source:        replication.NewDeliverSource(endpoints, dialOpts, signer, tlsCertHash, conf.Replication.Timeout),
+		ConfigBlock:   configBlock,
+	}
+	if err := replicator.Replicate(); err != nil {
+		logger.Fatal("Failed to replicate the ledgers:", err)
+	}
+}
+
 func initializeGrpcServer(conf *config.TopLevel) comm.GRPCServer {
 	secureConfig := initializeSecureServerConfig(conf)
 
@@ -203,6 +270,21 @@ func initializeGrpcServer(conf *config.TopLevel) comm.GRPCServer {
 	return grpcServer
 }
 
+// initializeTLSCertificateWatcher makes the gRPC server present the TLS
+// certificate again each time its files change
+func initializeTLSCertificateWatcher(conf *config.TopLevel, grpcServer comm.GRPCServer) *comm.CertificateWatcher {
+	watcher, err := comm.NewCertificateWatcher(conf.General.TLS.Certificate, conf.General.TLS.PrivateKey)
+	if err != nil {
+		logger.Fatal("Failed to load TLS certificate:", err)
+	}
+	watcher.OnReload(grpcServer.SetServerCertificate)
+	if conf.General.TLS.ReloadInterval > 0 {
+		logger.Infof("Checking TLS certificate for changes every %s", conf.General.TLS.ReloadInterval)
+		watcher.Start(conf.General.TLS.ReloadInterval)
+	}
+	return watcher
+}
+
 func initializeLocalMsp(conf *config.TopLevel) {
 	// Load local MSP
 	err := mspmgmt.LoadLocalMsp(conf.General.LocalMSPDir, conf.General.BCCSP, conf.General.LocalMSPID)
@@ -213,8 +295,10 @@ func initializeLocalMsp(conf *config.TopLevel) {
 
 func initializeMultichannelRegistrar(conf *config.TopLevel, signer crypto.LocalSigner) *multichannel.Registrar {
 	lf, _ := createLedgerFactory(conf)
-	// Are we bootstrapping?
-	if len(lf.ChainIDs()) == 0 {
+	// Are we replicating or bootstrapping?
+	if conf.Replication.Enabled {
+		initializeReplication(conf, lf, signer)
+	} else if len(lf.ChainIDs()) == 0 {
 		initializeBootstrapChannel(conf, lf)
 	} else {
 		logger.Info("Not bootstrapping because of existing chains")
@@ -108,7 +108,8 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, args []string, invoke bool, cf *
 		invoke,
 		cf.Signer,
 		cf.EndorserClient,
-		cf.BroadcastClient)
+		cf.BroadcastClient,
+		cf.TLSCertHash)
 
 	if err != nil {
 		return fmt.Errorf("%s - %v", err, proposalResp)
@@ -230,6 +231,7 @@ type ChaincodeCmdFactory struct {
 	EndorserClient  pb.EndorserClient
 	Signer          msp.SigningIdentity
 	BroadcastClient common.BroadcastClient
+	TLSCertHash     []byte
 }
 
 // InitCmdFactory init the ChaincodeCmdFactory with default clients
@@ -268,10 +270,16 @@ func InitCmdFactory(isEndorserRequired, isOrdererRequired bool) (*ChaincodeCmdFa
 			return nil, fmt.Errorf("Error getting broadcast client: %s", err)
 		}
 	}
+
+	tlsCertHash, err := common.GetTLSCertHash()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting TLS certificate hash: %s", err)
+	}
 	return &ChaincodeCmdFactory{
 		EndorserClient:  endorserClient,
 		Signer:          signer,
 		BroadcastClient: broadcastClient,
+		TLSCertHash:     tlsCertHash,
 	}, nil
 }
 
@@ -281,6 +289,8 @@ func InitCmdFactory(isEndorserRequired, isOrdererRequired bool) (*ChaincodeCmdFa
 // whether the query result is output as raw bytes, or as a printable string.
 // The printable form is optionally (-x, --hex) a hexadecimal representation
 // of the query response. If the query response is NIL, nothing is output.
+// The proposal is bound to the TLS client certificate with hash tlsCertHash,
+// if it isn't nil.
 //
 // NOTE - Query will likely go away as all interactions with the endorser are
 // Proposal and ProposalResponses
@@ -291,6 +301,7 @@ func ChaincodeInvokeOrQuery(
 	signer msp.SigningIdentity,
 	endorserClient pb.EndorserClient,
 	bc common.BroadcastClient,
+	tlsCertHash []byte,
 ) (*pb.ProposalResponse, error) {
 	// Build the ChaincodeInvocationSpec message
 	invocation := &pb.ChaincodeInvocationSpec{ChaincodeSpec: spec}
@@ -317,7 +328,7 @@ func ChaincodeInvokeOrQuery(
 	}
 
 	var prop *pb.Proposal
-	prop, _, err = putils.CreateChaincodeProposalWithTransient(pcommon.HeaderType_ENDORSER_TRANSACTION, cID, invocation, creator, tMap)
+	prop, _, err = putils.CreateChaincodeProposalWithTLSBinding(pcommon.HeaderType_ENDORSER_TRANSACTION, cID, invocation, creator, tMap, tlsCertHash)
 	if err != nil {
 		return nil, fmt.Errorf("Error creating proposal  %s: %s", funcName, err)
 	}
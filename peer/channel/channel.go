@@ -29,13 +29,12 @@ import (
 	"github.com/spf13/pflag"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 )
 
 const (
 	channelFuncName = "channel"
-	shortDes        = "Operate a channel: create|fetch|join|list|update."
-	longDes         = "Operate a channel: create|fetch|join|list|update."
+	shortDes        = "Operate a channel: create|fetch|join|joinbysnapshot|list|signsnapshot|snapshot|update."
+	longDes         = "Operate a channel: create|fetch|join|joinbysnapshot|list|signsnapshot|snapshot|update."
 )
 
 var logger = flogging.MustGetLogger("channelCmd")
@@ -54,6 +53,10 @@ var (
 	// join related variables.
 	genesisBlockPath string
 
+	// snapshot related variables
+	snapshotName string
+	snapshotPath string
+
 	// create related variables
 	chainID                    string
 	channelTxFile              string
@@ -71,9 +74,12 @@ func Cmd(cf *ChannelCmdFactory) *cobra.Command {
 	channelCmd.AddCommand(createCmd(cf))
 	channelCmd.AddCommand(fetchCmd(cf))
 	channelCmd.AddCommand(joinCmd(cf))
+	channelCmd.AddCommand(joinBySnapshotCmd(cf))
 	channelCmd.AddCommand(listCmd(cf))
+	channelCmd.AddCommand(snapshotCmd(cf))
 	channelCmd.AddCommand(updateCmd(cf))
 	channelCmd.AddCommand(signconfigtxCmd(cf))
+	channelCmd.AddCommand(signSnapshotCmd(cf))
 
 	return channelCmd
 }
@@ -102,6 +108,8 @@ func resetFlags() {
 	flags.StringVarP(&chainID, "channelID", "c", common.UndefinedParamValue, "In case of a newChain command, the channel ID to create.")
 	flags.StringVarP(&channelTxFile, "file", "f", "", "Configuration transaction file generated by a tool such as configtxgen for submitting to orderer")
 	flags.IntVarP(&timeout, "timeout", "t", 5, "Channel creation timeout")
+	flags.StringVarP(&snapshotName, "name", "n", common.UndefinedParamValue, "Name of the ledger snapshot, within the snapshots directory of the peer")
+	flags.StringVarP(&snapshotPath, "snapshotpath", "", "", "Path to the directory of a ledger snapshot")
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
@@ -165,7 +173,7 @@ func InitCmdFactory(isEndorserRequired EndorserRequirement, isOrdererRequired Or
 		// check for TLS
 		if tls {
 			if caFile != "" {
-				creds, err := credentials.NewClientTLSFromFile(caFile, ordererTLSHostnameOverride)
+				creds, err := common.GetOrdererTLSCredentials(caFile, ordererTLSHostnameOverride)
 				if err != nil {
 					return nil, fmt.Errorf("Error connecting to %s due to %s", orderingEndpoint, err)
 				}
@@ -184,7 +192,12 @@ func InitCmdFactory(isEndorserRequired EndorserRequirement, isOrdererRequired Or
 			return nil, fmt.Errorf("Error connecting due to  %s", err)
 		}
 
-		cmdFact.DeliverClient = newDeliverClient(conn, client, chainID)
+		tlsCertHash, err := common.GetTLSCertHash()
+		if err != nil {
+			return nil, err
+		}
+
+		cmdFact.DeliverClient = newDeliverClient(conn, client, chainID, tlsCertHash)
 	}
 	logger.Infof("Endorser and orderer connections initialized")
 	return cmdFact, nil
@@ -146,7 +146,12 @@ func sanityCheckAndSignConfigTx(envConfigUpdate *cb.Envelope) (*cb.Envelope, err
 
 	configUpdateEnv.Signatures = append(configUpdateEnv.Signatures, configSig)
 
-	return utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, chainID, signer, configUpdateEnv, 0, 0)
+	tlsCertHash, err := common.GetTLSCertHash()
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.CreateSignedEnvelopeWithTLSBinding(cb.HeaderType_CONFIG_UPDATE, chainID, signer, configUpdateEnv, 0, 0, tlsCertHash)
 }
 
 func sendCreateChainTransaction(cf *ChannelCmdFactory) error {
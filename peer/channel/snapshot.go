@@ -0,0 +1,183 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	localsigner "github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/core/scc/cscc"
+	"github.com/hyperledger/fabric/peer/common"
+	pcommon "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func snapshotCmd(cf *ChannelCmdFactory) *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Exports a snapshot of the ledger of a channel.",
+		Long:  "Exports a snapshot of the ledger of a channel to the snapshots directory of the peer. The snapshot must then be signed by the admins of the channel with signsnapshot.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return snapshot(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"name",
+	}
+	attachFlags(snapshotCmd, flagList)
+
+	return snapshotCmd
+}
+
+func joinBySnapshotCmd(cf *ChannelCmdFactory) *cobra.Command {
+	joinBySnapshotCmd := &cobra.Command{
+		Use:   "joinbysnapshot",
+		Short: "Joins the peer to a chain from a ledger snapshot.",
+		Long:  "Joins the peer to a chain from a ledger snapshot in the snapshots directory of the peer. The snapshot is verified against the trusted config block of the channel supplied with '-b', such as the latest config block fetched from the ordering service. Blocks after the snapshot are then pulled from the ordering service.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return joinBySnapshot(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"name",
+		"blockpath",
+	}
+	attachFlags(joinBySnapshotCmd, flagList)
+
+	return joinBySnapshotCmd
+}
+
+func signSnapshotCmd(cf *ChannelCmdFactory) *cobra.Command {
+	signSnapshotCmd := &cobra.Command{
+		Use:   "signsnapshot",
+		Short: "Signs a ledger snapshot.",
+		Long:  "Signs the manifest of the supplied ledger snapshot directory in place on the filesystem. Requires '--snapshotpath'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return signSnapshot(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"snapshotpath",
+	}
+	attachFlags(signSnapshotCmd, flagList)
+
+	return signSnapshotCmd
+}
+
+// invokeSnapshotFunction invokes the given cscc function with the given arguments
+func invokeSnapshotFunction(cf *ChannelCmdFactory, fname string, args ...[]byte) error {
+	input := &pb.ChaincodeInput{Args: append([][]byte{[]byte(fname)}, args...)}
+	invocation := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+			ChaincodeId: &pb.ChaincodeID{Name: "cscc"},
+			Input:       input,
+		},
+	}
+
+	creator, err := cf.Signer.Serialize()
+	if err != nil {
+		return fmt.Errorf("Error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
+	}
+
+	prop, _, err := putils.CreateProposalFromCIS(pcommon.HeaderType_CONFIG, "", invocation, creator)
+	if err != nil {
+		return fmt.Errorf("Error creating proposal for %s %s", fname, err)
+	}
+
+	signedProp, err := putils.GetSignedProposal(prop, cf.Signer)
+	if err != nil {
+		return fmt.Errorf("Error creating signed proposal %s", err)
+	}
+
+	proposalResp, err := cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return ProposalFailedErr(err.Error())
+	}
+
+	if proposalResp == nil || proposalResp.Response == nil {
+		return ProposalFailedErr("nil proposal response")
+	}
+
+	if proposalResp.Response.Status != 0 && proposalResp.Response.Status != 200 {
+		return ProposalFailedErr(fmt.Sprintf("bad proposal response %d: %s", proposalResp.Response.Status, proposalResp.Response.Message))
+	}
+	return nil
+}
+
+func snapshot(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
+	if chainID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	if snapshotName == common.UndefinedParamValue {
+		return errors.New("Must supply snapshot name")
+	}
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+	if err = invokeSnapshotFunction(cf, cscc.ExportSnapshot, []byte(chainID), []byte(snapshotName)); err != nil {
+		return err
+	}
+	logger.Infof("Snapshot %s of channel %s exported!", snapshotName, chainID)
+	return nil
+}
+
+func joinBySnapshot(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
+	if snapshotName == common.UndefinedParamValue {
+		return errors.New("Must supply snapshot name")
+	}
+	if genesisBlockPath == common.UndefinedParamValue {
+		return errors.New("Must supply trusted config block file")
+	}
+	trustedBlock, err := ioutil.ReadFile(genesisBlockPath)
+	if err != nil {
+		return GBFileNotFoundErr(err.Error())
+	}
+
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+	if err = invokeSnapshotFunction(cf, cscc.JoinChainBySnapshot, []byte(snapshotName), trustedBlock); err != nil {
+		return err
+	}
+	logger.Infof("Peer joined the channel from snapshot %s!", snapshotName)
+	return nil
+}
+
+func signSnapshot(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
+	if snapshotPath == "" {
+		return errors.New("Must supply snapshot path")
+	}
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserNotRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+	if err = peer.SignSnapshot(snapshotPath, localsigner.NewSigner()); err != nil {
+		return err
+	}
+	logger.Infof("Snapshot %s signed!", snapshotPath)
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/peer/common"
+	pcommon "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockSnapshotCmdFactory(t *testing.T, status int32) *ChannelCmdFactory {
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err, "Get default signer error: %v", err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: status},
+		Endorsement: &pb.Endorsement{},
+	}
+	return &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	cmd := snapshotCmd(mockSnapshotCmdFactory(t, 200))
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", "mychannel", "-n", "mysnapshot"})
+	assert.NoError(t, cmd.Execute(), "expected snapshot command to succeed")
+
+	resetFlags()
+	cmd = snapshotCmd(mockSnapshotCmdFactory(t, 500))
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", "mychannel", "-n", "mysnapshot"})
+	err := cmd.Execute()
+	assert.Error(t, err, "expected snapshot command to fail")
+	assert.IsType(t, ProposalFailedErr(err.Error()), err, "expected error type of ProposalFailedErr")
+}
+
+func TestSnapshotMissingParams(t *testing.T) {
+	resetFlags()
+	cmd := snapshotCmd(nil)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-n", "mysnapshot"})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	resetFlags()
+	cmd = snapshotCmd(nil)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", "mychannel"})
+	assert.EqualError(t, cmd.Execute(), "Must supply snapshot name")
+
+	resetFlags()
+	cmd = joinBySnapshotCmd(nil)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-b", "config.block"})
+	assert.EqualError(t, cmd.Execute(), "Must supply snapshot name")
+
+	resetFlags()
+	cmd = joinBySnapshotCmd(nil)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-n", "mysnapshot"})
+	assert.EqualError(t, cmd.Execute(), "Must supply trusted config block file")
+
+	resetFlags()
+	cmd = joinBySnapshotCmd(nil)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-n", "mysnapshot", "-b", "/non/existent/config.block"})
+	assert.IsType(t, GBFileNotFoundErr(""), cmd.Execute())
+
+	resetFlags()
+	cmd = signSnapshotCmd(nil)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "Must supply snapshot path")
+}
+
+func TestJoinBySnapshot(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	dir, err := ioutil.TempDir("", "joinbysnapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	blockFile := filepath.Join(dir, "config.block")
+	assert.NoError(t, ioutil.WriteFile(blockFile, putils.MarshalOrPanic(&pcommon.Block{}), 0644))
+
+	cmd := joinBySnapshotCmd(mockSnapshotCmdFactory(t, 200))
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-n", "mysnapshot", "-b", blockFile})
+	assert.NoError(t, cmd.Execute(), "expected joinbysnapshot command to succeed")
+}
+
+func TestSignSnapshot(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	dir, err := ioutil.TempDir("", "signsnapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, ledger.SnapshotManifestFile), []byte(`{"channel_id":"mychannel"}`), 0644))
+
+	// Each signature is added to the signatures of the manifest
+	for i := 1; i <= 2; i++ {
+		cmd := signSnapshotCmd(mockSnapshotCmdFactory(t, 200))
+		AddFlags(cmd)
+		cmd.SetArgs([]string{"--snapshotpath", dir})
+		assert.NoError(t, cmd.Execute(), "expected signsnapshot command to succeed")
+
+		sigBytes, err := ioutil.ReadFile(filepath.Join(dir, ledger.SnapshotSignatureFile))
+		assert.NoError(t, err)
+		signatures := &pcommon.Metadata{}
+		assert.NoError(t, proto.Unmarshal(sigBytes, signatures))
+		assert.Len(t, signatures.Signatures, i)
+	}
+
+	// The snapshot must have a manifest
+	resetFlags()
+	cmd := signSnapshotCmd(mockSnapshotCmdFactory(t, 200))
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshotpath", filepath.Join(dir, "missing")})
+	assert.Error(t, cmd.Execute(), "expected signsnapshot command to fail")
+}
@@ -0,0 +1,29 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cligossip
+
+import (
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// GossipCmdFactory holds the clients used by GossipCmd
+type GossipCmdFactory struct {
+	AdminClient pb.AdminClient
+}
+
+// InitCmdFactory init the GossipCmdFactory with default admin client
+func InitCmdFactory() (*GossipCmdFactory, error) {
+	adminClient, err := common.GetAdminClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GossipCmdFactory{
+		AdminClient: adminClient,
+	}, nil
+}
@@ -0,0 +1,35 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cligossip
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/spf13/cobra"
+)
+
+const (
+	gossipFuncName = "gossip"
+	shortDes       = "Gossip: membership."
+	longDes        = "Inspect the gossip layer of the peer: membership."
+)
+
+var logger = flogging.MustGetLogger("cli/gossip")
+
+// Cmd returns the cobra command for Gossip
+func Cmd(cf *GossipCmdFactory) *cobra.Command {
+	gossipCmd.AddCommand(membershipCmd(cf))
+
+	return gossipCmd
+}
+
+var gossipCmd = &cobra.Command{
+	Use:   gossipFuncName,
+	Short: fmt.Sprint(shortDes),
+	Long:  fmt.Sprint(longDes),
+}
@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cligossip
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func membershipCmd(cf *GossipCmdFactory) *cobra.Command {
+	var channelID string
+	gossipMembershipCmd := &cobra.Command{
+		Use:   "membership",
+		Short: "Returns the gossip membership view of the peer in a channel.",
+		Long:  `Returns the alive and the dead members of a channel, as viewed by the gossip layer of the peer, along with the last time each of them was seen alive and its ledger height.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return membership(cf, cmd.OutOrStdout(), channelID)
+		},
+	}
+	gossipMembershipCmd.Flags().StringVarP(&channelID, "channelID", "c", "", "The channel to return the membership of.")
+
+	return gossipMembershipCmd
+}
+
+func membership(cf *GossipCmdFactory, out io.Writer, channelID string) error {
+	if channelID == "" {
+		return errors.New("must supply channel ID")
+	}
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory()
+		if err != nil {
+			return err
+		}
+	}
+
+	view, err := cf.AdminClient.GetGossipMembership(context.Background(), &pb.GossipMembershipRequest{ChannelId: channelID})
+	if err != nil {
+		return errors.WithMessage(err, "failed retrieving the gossip membership of channel "+channelID)
+	}
+
+	members := view.Members
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].Alive != members[j].Alive {
+			return members[i].Alive
+		}
+		return members[i].Endpoint < members[j].Endpoint
+	})
+
+	fmt.Fprintf(out, "Gossip membership of channel %s:\n", channelID)
+	for _, member := range members {
+		status := "dead"
+		if member.Alive {
+			status = "alive"
+		}
+		lastAlive := "unknown"
+		if ts := member.LastAlive; ts != nil {
+			lastAlive = time.Unix(ts.Seconds, int64(ts.Nanos)).Format(time.RFC3339)
+		}
+		// The ledger height of dead members whose state has expired is unset
+		ledgerHeight := "unknown"
+		if member.LedgerHeight > 0 {
+			ledgerHeight = strconv.FormatUint(member.LedgerHeight, 10)
+		}
+		fmt.Fprintf(out, "\tEndpoint: %s, InternalEndpoint: %s, PKI-ID: %x, Status: %s, Last alive: %s, Ledger height: %s\n",
+			member.Endpoint, member.InternalEndpoint, member.PkiId, status, lastAlive, ledgerHeight)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cligossip
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+type membershipAdminClient struct {
+	pb.AdminClient
+	membership *pb.GossipMembership
+}
+
+func (c *membershipAdminClient) GetGossipMembership(ctx context.Context, in *pb.GossipMembershipRequest, opts ...grpc.CallOption) (*pb.GossipMembership, error) {
+	if in.ChannelId != "mychannel" {
+		return nil, errors.Errorf("peer isn't a member of channel %s", in.ChannelId)
+	}
+	return c.membership, nil
+}
+
+func TestMembership(t *testing.T) {
+	lastAlive := time.Date(2017, 9, 21, 10, 0, 0, 0, time.UTC)
+	cf := &GossipCmdFactory{
+		AdminClient: &membershipAdminClient{
+			membership: &pb.GossipMembership{
+				Members: []*pb.GossipMember{
+					{
+						PkiId:     []byte{1, 2},
+						Endpoint:  "peer2:7051",
+						Alive:     false,
+						LastAlive: &timestamp.Timestamp{Seconds: lastAlive.Unix()},
+					},
+					{
+						PkiId:        []byte{3, 4},
+						Endpoint:     "peer1:7051",
+						Alive:        true,
+						LastAlive:    &timestamp.Timestamp{Seconds: lastAlive.Unix()},
+						LedgerHeight: 10,
+					},
+				},
+			},
+		},
+	}
+
+	cmd := membershipCmd(cf)
+	out := &bytes.Buffer{}
+	cmd.SetOutput(out)
+
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "must supply channel ID")
+
+	cmd.SetArgs([]string{"-c", "otherchannel"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed retrieving the gossip membership of channel otherchannel")
+
+	out.Reset()
+	cmd.SetArgs([]string{"-c", "mychannel"})
+	assert.NoError(t, cmd.Execute())
+	expectedLastAlive := time.Unix(lastAlive.Unix(), 0).Format(time.RFC3339)
+	assert.Equal(t, "Gossip membership of channel mychannel:\n"+
+		"\tEndpoint: peer1:7051, InternalEndpoint: , PKI-ID: 0304, Status: alive, Last alive: "+expectedLastAlive+", Ledger height: 10\n"+
+		"\tEndpoint: peer2:7051, InternalEndpoint: , PKI-ID: 0102, Status: dead, Last alive: "+expectedLastAlive+", Ledger height: unknown\n",
+		out.String())
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/viperutil"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/scc/cscc"
@@ -138,6 +139,17 @@ func GetDefaultSigner() (msp.SigningIdentity, error) {
 	return signer, err
 }
 
+// GetTLSCertHash returns the hash of the TLS client certificate of the cli,
+// which binds the proposals and transactions it sends to its TLS connections,
+// or nil if no client certificate is configured
+func GetTLSCertHash() ([]byte, error) {
+	clientCert, err := comm.GetClientCertificate()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error obtaining the TLS client certificate")
+	}
+	return comm.CertificateHash(clientCert), nil
+}
+
 // GetOrdererEndpointOfChain returns orderer endpoints of given chain
 func GetOrdererEndpointOfChain(chainID string, signer msp.SigningIdentity, endorserClient pb.EndorserClient) ([]string, error) {
 
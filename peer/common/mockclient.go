@@ -94,3 +94,11 @@ func (m *mockAdminClient) SetModuleLogLevel(ctx context.Context, in *pb.LogLevel
 func (m *mockAdminClient) RevertLogLevels(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return &empty.Empty{}, m.err
 }
+
+func (m *mockAdminClient) YieldLeadership(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, m.err
+}
+
+func (m *mockAdminClient) GetGossipMembership(ctx context.Context, in *pb.GossipMembershipRequest, opts ...grpc.CallOption) (*pb.GossipMembership, error) {
+	return &pb.GossipMembership{}, m.err
+}
@@ -17,10 +17,14 @@ limitations under the License.
 package common
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 	"strings"
 	"time"
 
+	"github.com/hyperledger/fabric/core/comm"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/pkg/errors"
@@ -51,7 +55,7 @@ func GetBroadcastClient(orderingEndpoint string, tlsEnabled bool, caFile string)
 	// check for TLS
 	if tlsEnabled {
 		if caFile != "" {
-			creds, err := credentials.NewClientTLSFromFile(caFile, "")
+			creds, err := GetOrdererTLSCredentials(caFile, "")
 			if err != nil {
 				return nil, errors.WithMessage(err, fmt.Sprintf("error connecting to %s due to", orderingEndpoint))
 			}
@@ -77,6 +81,29 @@ func GetBroadcastClient(orderingEndpoint string, tlsEnabled bool, caFile string)
 	return &broadcastClient{conn: conn, client: client}, nil
 }
 
+// GetOrdererTLSCredentials returns the TLS credentials used to connect to an
+// orderer whose certificate is issued by the CA in caFile. The TLS client
+// certificate of the peer, if one is configured, is presented to the orderer
+// so that the messages bound to it are accepted
+func GetOrdererTLSCredentials(caFile string, serverNameOverride string) (credentials.TransportCredentials, error) {
+	caPEM, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read CA file %s", caFile)
+	}
+	tlsConfig := &tls.Config{ServerName: serverNameOverride, RootCAs: x509.NewCertPool()}
+	if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+		return nil, errors.Errorf("failed to append the certificates of CA file %s", caFile)
+	}
+	clientCert, err := comm.GetClientCertificate()
+	if err != nil {
+		return nil, err
+	}
+	if clientCert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*clientCert}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
 func (s *broadcastClient) getAck() error {
 	msg, err := s.client.Recv()
 	if err != nil {
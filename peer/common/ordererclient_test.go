@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common_test
+
+import (
+	"crypto/x509"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockcrypto "github.com/hyperledger/fabric/common/mocks/crypto"
+	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
+	"github.com/hyperledger/fabric/common/tools/cryptogen/csp"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// tlsBindingSupport accepts every message of a channel with the
+// V1_1_TLS_BINDING capability
+type tlsBindingSupport struct{}
+
+func (tlsBindingSupport) BroadcastChannelSupport(msg *cb.Envelope) (*cb.ChannelHeader, bool, broadcast.ChannelSupport, error) {
+	chdr, err := utils.ChannelHeader(msg)
+	return chdr, false, tlsBindingSupport{}, err
+}
+
+func (tlsBindingSupport) Order(env *cb.Envelope, configSeq uint64) error {
+	return nil
+}
+
+func (tlsBindingSupport) Configure(config *cb.Envelope, configSeq uint64) error {
+	return nil
+}
+
+func (tlsBindingSupport) ChannelConfig() channelconfig.Channel {
+	return &mockconfig.Channel{
+		CapabilitiesVal: &mockconfig.ChannelCapabilities{TLSBindingVal: true},
+	}
+}
+
+func (tlsBindingSupport) ClassifyMsg(chdr *cb.ChannelHeader) msgprocessor.Classification {
+	return msgprocessor.NormalMsg
+}
+
+func (tlsBindingSupport) ProcessNormalMsg(msg *cb.Envelope) (uint64, error) {
+	return 0, nil
+}
+
+func (tlsBindingSupport) ProcessConfigUpdateMsg(msg *cb.Envelope) (*cb.Envelope, uint64, error) {
+	return msg, 0, nil
+}
+
+func (tlsBindingSupport) ProcessConfigMsg(msg *cb.Envelope) (*cb.Envelope, uint64, error) {
+	return msg, 0, nil
+}
+
+type broadcastServer struct {
+	handler broadcast.Handler
+}
+
+func (s *broadcastServer) Broadcast(srv ab.AtomicBroadcast_BroadcastServer) error {
+	return s.handler.Handle(srv)
+}
+
+func (s *broadcastServer) Deliver(srv ab.AtomicBroadcast_DeliverServer) error {
+	return nil
+}
+
+// generateTLSCertificate issues a TLS certificate for localhost and writes it
+// to dir/name-cert.pem, and its key to dir/name-key.pem
+func generateTLSCertificate(t *testing.T, tlsCA *ca.CA, dir, name string) {
+	priv, _, err := csp.GeneratePrivateKey(dir)
+	assert.NoError(t, err)
+	pub, err := csp.GetECPublicKey(priv)
+	assert.NoError(t, err)
+	_, err = tlsCA.SignCertificate(dir, name, []string{"localhost", "127.0.0.1"}, pub,
+		x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
+		[]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth})
+	assert.NoError(t, err)
+	keyFile := filepath.Join(dir, hex.EncodeToString(priv.SKI())+"_sk")
+	assert.NoError(t, os.Rename(keyFile, filepath.Join(dir, name+"-key.pem")))
+}
+
+func TestBroadcastClientTLSBinding(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsbinding")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tlsCA, err := ca.NewCA(dir, "example.com", "tlsca", "", "", "", "", "", "")
+	assert.NoError(t, err)
+	generateTLSCertificate(t, tlsCA, dir, "orderer")
+	generateTLSCertificate(t, tlsCA, dir, "client")
+
+	caPEM, err := ioutil.ReadFile(filepath.Join(dir, "tlsca-cert.pem"))
+	assert.NoError(t, err)
+	serverCert, err := ioutil.ReadFile(filepath.Join(dir, "orderer-cert.pem"))
+	assert.NoError(t, err)
+	serverKey, err := ioutil.ReadFile(filepath.Join(dir, "orderer-key.pem"))
+	assert.NoError(t, err)
+
+	server, err := comm.NewGRPCServer("127.0.0.1:0", comm.SecureServerConfig{
+		UseTLS:            true,
+		ServerCertificate: serverCert,
+		ServerKey:         serverKey,
+		RequireClientCert: true,
+		ClientRootCAs:     [][]byte{caPEM},
+	})
+	assert.NoError(t, err)
+	ab.RegisterAtomicBroadcastServer(server.Server(), &broadcastServer{handler: broadcast.NewHandlerImpl(tlsBindingSupport{})})
+	go server.Start()
+	defer server.Stop()
+
+	viper.Set("peer.tls.clientCert.file", filepath.Join(dir, "client-cert.pem"))
+	viper.Set("peer.tls.clientKey.file", filepath.Join(dir, "client-key.pem"))
+	defer viper.Set("peer.tls.clientCert.file", "")
+	defer viper.Set("peer.tls.clientKey.file", "")
+
+	tlsCertHash, err := common.GetTLSCertHash()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tlsCertHash)
+
+	bc, err := common.GetBroadcastClient(server.Address(), true, filepath.Join(dir, "tlsca-cert.pem"))
+	assert.NoError(t, err)
+	defer bc.Close()
+
+	// A message bound to the TLS client certificate of the connection is accepted
+	env, err := utils.CreateSignedEnvelopeWithTLSBinding(cb.HeaderType_MESSAGE, "mychannel", &mockcrypto.LocalSigner{}, &cb.Envelope{}, 0, 0, tlsCertHash)
+	assert.NoError(t, err)
+	assert.NoError(t, bc.Send(env))
+
+	// A message which isn't bound to it is rejected
+	env, err = utils.CreateSignedEnvelope(cb.HeaderType_MESSAGE, "mychannel", &mockcrypto.LocalSigner{}, &cb.Envelope{}, 0, 0)
+	assert.NoError(t, err)
+	err = bc.Send(env)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "FORBIDDEN")
+}
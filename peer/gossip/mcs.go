@@ -171,8 +171,12 @@ func (s *mspMessageCryptoService) VerifyBlock(chainID common.ChainID, seqNum uin
 
 	// Get block validation policy
 	policy, ok := cpm.GetPolicy(policies.BlockValidation)
-	// ok is true if it was the policy requested, or false if it is the default policy
-	mcsLogger.Debugf("Got block validation policy for channel [%s] with flag [%s]", channelID, ok)
+	// ok is false if the channel config has no block validation policy,
+	// in which case no block of the channel can be trusted
+	if !ok {
+		return fmt.Errorf("Block validation policy not found for channel [%s]", channelID)
+	}
+	mcsLogger.Debugf("Got block validation policy for channel [%s]", channelID)
 
 	// - Prepare SignedData
 	signatureSet := []*pcommon.SignedData{}
@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/localmsp"
 	mockscrypto "github.com/hyperledger/fabric/common/mocks/crypto"
+	mockspolicies "github.com/hyperledger/fabric/common/mocks/policies"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -200,6 +201,13 @@ func TestVerifyBlock(t *testing.T) {
 	assert.Error(t, msgCryptoService.VerifyBlock([]byte("A"), 42, blockRaw))
 	assert.Error(t, msgCryptoService.VerifyBlock([]byte("B"), 42, blockRaw))
 
+	// A channel without a block validation policy accepts no blocks
+	policyManagerGetter.Managers["E"] = &mockspolicies.Manager{}
+	blockRaw3, _ := mockBlock(t, "E", 42, aliceSigner, nil)
+	err = msgCryptoService.VerifyBlock([]byte("E"), 42, blockRaw3)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Block validation policy not found")
+
 	// - Prepare testing invalid block (wrong data has), Alice signs it.
 	blockRaw, msg = mockBlock(t, "C", 42, aliceSigner, []byte{0})
 	policyManagerGetter.Managers["C"].(*mocks.ChannelPolicyManager).Policy.(*mocks.Policy).Deserializer.(*mocks.IdentityDeserializer).Msg = msg
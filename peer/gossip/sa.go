@@ -20,6 +20,9 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/msp/mgmt"
+	mspproto "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 )
 
 var saLogger = flogging.MustGetLogger("peer/gossip/sa")
@@ -86,3 +89,43 @@ func (advisor *mspSecurityAdvisor) OrgByPeerIdentity(peerIdentity api.PeerIdenti
 
 	return nil
 }
+
+// VerifyByOrgAdmin checks that signature is a valid signature of message
+// under the verification key of adminIdentity, and that adminIdentity is
+// an admin of the organization of peerIdentity, according to the MSP of
+// a channel.
+// If the verification succeeded, VerifyByOrgAdmin returns nil.
+func (advisor *mspSecurityAdvisor) VerifyByOrgAdmin(peerIdentity, adminIdentity api.PeerIdentityType, signature, message []byte) error {
+	org := advisor.OrgByPeerIdentity(peerIdentity)
+	if len(org) == 0 {
+		return errors.Errorf("organization of peer identity [% x] is unknown", peerIdentity)
+	}
+	principal := &mspproto.MSPPrincipal{
+		PrincipalClassification: mspproto.MSPPrincipal_ROLE,
+		Principal: utils.MarshalOrPanic(&mspproto.MSPRole{
+			Role:          mspproto.MSPRole_ADMIN,
+			MspIdentifier: string(org),
+		}),
+	}
+
+	// Admins of an organization are only known by the MSPs of the channels
+	for chainID, mspManager := range advisor.deserializer.GetChannelDeserializers() {
+		identity, err := mspManager.DeserializeIdentity([]byte(adminIdentity))
+		if err != nil {
+			saLogger.Debugf("Failed deserialization identity [% x] on [%s]: [%s]", adminIdentity, chainID, err)
+			continue
+		}
+		if identity.GetMSPIdentifier() != string(org) {
+			return errors.Errorf("identity [% x] belongs to [%s], not to [%s]", adminIdentity, identity.GetMSPIdentifier(), org)
+		}
+		if err := identity.Validate(); err != nil {
+			return errors.Wrapf(err, "identity [% x] is not valid on [%s]", adminIdentity, chainID)
+		}
+		if err := identity.SatisfiesPrincipal(principal); err != nil {
+			return errors.Wrapf(err, "identity [% x] is not an admin of [%s] on [%s]", adminIdentity, org, chainID)
+		}
+		return identity.Verify(message, signature)
+	}
+
+	return errors.Errorf("identity [% x] cannot be deserialized by the MSP of any channel", adminIdentity)
+}
@@ -38,3 +38,22 @@ func TestMspSecurityAdvisor_OrgByPeerIdentity(t *testing.T) {
 	assert.Nil(t, advisor.OrgByPeerIdentity([]byte("Charlie")))
 	assert.Nil(t, advisor.OrgByPeerIdentity(nil))
 }
+
+func TestMspSecurityAdvisor_VerifyByOrgAdmin(t *testing.T) {
+	dm := &mocks.DeserializersManager{
+		LocalDeserializer: &mocks.IdentityDeserializer{[]byte("Alice"), []byte("msg1")},
+		ChannelDeserializers: map[string]msp.IdentityDeserializer{
+			"A": &mocks.IdentityDeserializer{[]byte("Bob"), []byte("msg2")},
+		},
+	}
+
+	advisor := NewSecurityAdvisor(dm)
+	// Bob is known to the MSP of channel A
+	assert.NoError(t, advisor.VerifyByOrgAdmin([]byte("Alice"), []byte("Bob"), []byte("msg2"), []byte("msg2")))
+	// Bad signature
+	assert.Error(t, advisor.VerifyByOrgAdmin([]byte("Alice"), []byte("Bob"), []byte("msg1"), []byte("msg2")))
+	// Admins are only looked up in the MSPs of channels
+	assert.Error(t, advisor.VerifyByOrgAdmin([]byte("Alice"), []byte("Alice"), []byte("msg1"), []byte("msg1")))
+	// Unknown peer
+	assert.Error(t, advisor.VerifyByOrgAdmin([]byte("Charlie"), []byte("Bob"), []byte("msg2"), []byte("msg2")))
+}
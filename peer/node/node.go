@@ -25,8 +25,8 @@ import (
 
 const (
 	nodeFuncName = "node"
-	shortDes     = "Operate a peer node: start|status."
-	longDes      = "Operate a peer node: start|status."
+	shortDes     = "Operate a peer node: start|status|yield|signendpoints."
+	longDes      = "Operate a peer node: start|status|yield|signendpoints."
 )
 
 var logger = flogging.MustGetLogger("nodeCmd")
@@ -35,6 +35,8 @@ var logger = flogging.MustGetLogger("nodeCmd")
 func Cmd() *cobra.Command {
 	nodeCmd.AddCommand(startCmd())
 	nodeCmd.AddCommand(statusCmd())
+	nodeCmd.AddCommand(yieldCmd())
+	nodeCmd.AddCommand(signEndpointsCmd())
 
 	return nodeCmd
 }
@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	gproto "github.com/hyperledger/fabric/protos/gossip"
+	mspproto "github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	signEndpointsPeerCert string
+	signEndpoints         []string
+	signEndpointsOutput   string
+)
+
+func signEndpointsCmd() *cobra.Command {
+	flags := nodeSignEndpointsCmd.Flags()
+	flags.StringVarP(&signEndpointsPeerCert, "peerCert", "p", "", "Path to the PEM-encoded certificate of the peer the endpoints are of.")
+	flags.StringSliceVarP(&signEndpoints, "endpoints", "e", nil, "The alternative endpoints of the peer.")
+	flags.StringVarP(&signEndpointsOutput, "output", "o", "", "Path to write the signed endpoints to.")
+	return nodeSignEndpointsCmd
+}
+
+var nodeSignEndpointsCmd = &cobra.Command{
+	Use:   "signendpoints",
+	Short: "Signs alternative endpoints of a peer.",
+	Long:  `Signs alternative endpoints of a peer of the organization of the local MSP, which must be an admin of the organization. The output file is set as peer.gossip.alternativeEndpointsFile of the peer.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return signAlternativeEndpoints(signEndpointsPeerCert, signEndpoints, signEndpointsOutput)
+	},
+}
+
+func signAlternativeEndpoints(peerCertFile string, endpoints []string, outputFile string) error {
+	if peerCertFile == "" {
+		return errors.New("must supply the certificate of the peer")
+	}
+	if len(endpoints) == 0 {
+		return errors.New("must supply endpoints")
+	}
+	if outputFile == "" {
+		return errors.New("must supply an output file")
+	}
+
+	rawCert, err := ioutil.ReadFile(peerCertFile)
+	if err != nil {
+		return errors.Wrap(err, "failed reading the certificate of the peer")
+	}
+	block, _ := pem.Decode(rawCert)
+	if block == nil {
+		return errors.Errorf("no PEM-encoded certificate found in %s", peerCertFile)
+	}
+
+	admin := mspmgmt.GetLocalSigningIdentityOrPanic()
+	rawIdentity, err := proto.Marshal(&mspproto.SerializedIdentity{
+		Mspid:   admin.GetMSPIdentifier(),
+		IdBytes: pem.EncodeToMemory(block),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling the identity of the peer")
+	}
+	// The peer is identified the way its MSP serializes its identity,
+	// which may differ from the certificate in the file
+	identity, err := mspmgmt.GetLocalMSP().DeserializeIdentity(rawIdentity)
+	if err != nil {
+		return errors.Wrap(err, "failed deserializing the identity of the peer")
+	}
+	peerIdentity, err := identity.Serialize()
+	if err != nil {
+		return errors.Wrap(err, "failed serializing the identity of the peer")
+	}
+	adminIdentity, err := admin.Serialize()
+	if err != nil {
+		return errors.Wrap(err, "failed serializing the local signing identity")
+	}
+
+	signed, err := gproto.NewSignedEndpoints(peerIdentity, endpoints, adminIdentity, admin.Sign)
+	if err != nil {
+		return errors.Wrap(err, "failed signing the endpoints")
+	}
+	raw, err := proto.Marshal(signed)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling the signed endpoints")
+	}
+	if err := ioutil.WriteFile(outputFile, raw, 0644); err != nil {
+		return errors.Wrap(err, "failed writing the signed endpoints")
+	}
+	logger.Infof("Wrote the endpoints %v signed by %s to %s", endpoints, admin.GetMSPIdentifier(), outputFile)
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	gproto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignEndpoints(t *testing.T) {
+	assert.NoError(t, msptesttools.LoadMSPSetupForTesting())
+	dir, err := ioutil.TempDir("", "signendpoints")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	peerCert, err := filepath.Abs("../../sampleconfig/msp/signcerts/peer.pem")
+	assert.NoError(t, err)
+	output := filepath.Join(dir, "endpoints")
+
+	assert.EqualError(t, signAlternativeEndpoints("", []string{"peer0-alt:7051"}, output), "must supply the certificate of the peer")
+	assert.EqualError(t, signAlternativeEndpoints(peerCert, nil, output), "must supply endpoints")
+	assert.EqualError(t, signAlternativeEndpoints(peerCert, []string{"peer0-alt:7051"}, ""), "must supply an output file")
+	assert.Error(t, signAlternativeEndpoints(filepath.Join(dir, "nonexistent.pem"), []string{"peer0-alt:7051"}, output))
+
+	cmd := signEndpointsCmd()
+	cmd.SetArgs([]string{"-p", peerCert, "-e", "peer0-alt:7051,peer0-alt2:7051", "-o", output})
+	assert.NoError(t, cmd.Execute())
+
+	raw, err := ioutil.ReadFile(output)
+	assert.NoError(t, err)
+	signed := &gproto.SignedEndpoints{}
+	assert.NoError(t, proto.Unmarshal(raw, signed))
+
+	admin := mspmgmt.GetLocalSigningIdentityOrPanic()
+	endpoints, err := signed.Verify(func(adminIdentity, signature, message []byte) error {
+		identity, err := mspmgmt.GetLocalMSP().DeserializeIdentity(adminIdentity)
+		if err != nil {
+			return err
+		}
+		return identity.Verify(message, signature)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"peer0-alt:7051", "peer0-alt2:7051"}, endpoints.Endpoints)
+	// The sample peer certificate is the one of the local signing identity,
+	// so the endpoints are signed for the identity the peer serializes
+	selfIdentity, err := admin.Serialize()
+	assert.NoError(t, err)
+	assert.Equal(t, selfIdentity, endpoints.PeerIdentity)
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package node
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -153,6 +154,14 @@ func serve(args []string) error {
 		grpclog.Fatalf("Failed to create ehub server: %v", err)
 	}
 
+	if secureConfig.UseTLS {
+		certWatcher, err := watchTLSCertificate(peerServer, ehubGrpcServer)
+		if err != nil {
+			logger.Fatalf("Failed to watch TLS certificate: %s", err)
+		}
+		defer certWatcher.Stop()
+	}
+
 	// enable the cache of chaincode info
 	ccprovider.EnableCCInfoCache()
 
@@ -296,6 +305,31 @@ func serve(args []string) error {
 	return <-serve
 }
 
+// watchTLSCertificate reloads the TLS certificate of the peer when its files
+// change, and makes the gRPC servers, gossip and the deliver service present
+// the new certificate on new connections
+func watchTLSCertificate(peerServer, ehubGrpcServer comm.GRPCServer) (*comm.CertificateWatcher, error) {
+	watcher, err := comm.NewCertificateWatcher(config.GetPath("peer.tls.cert.file"),
+		config.GetPath("peer.tls.key.file"))
+	if err != nil {
+		return nil, err
+	}
+	caSupport := comm.GetCASupport()
+	caSupport.SetClientCertificate(watcher.Certificate())
+	watcher.OnReload(func(cert tls.Certificate) {
+		peerServer.SetServerCertificate(cert)
+		if ehubGrpcServer != nil {
+			ehubGrpcServer.SetServerCertificate(cert)
+		}
+		caSupport.SetClientCertificate(cert)
+	})
+	if interval := viper.GetDuration("peer.tls.reloadInterval"); interval > 0 {
+		logger.Infof("Checking TLS certificate for changes every %s", interval)
+		watcher.Start(interval)
+	}
+	return watcher, nil
+}
+
 //create a CC listener using peer.chaincodeListenAddress (and if that's not set use peer.peerAddress)
 func createChaincodeServer(caCert []byte, peerHostname string) (comm.GRPCServer, ccEndpointFunc) {
 	cclistenAddress := viper.GetString(chaincodeListenAddrKey)
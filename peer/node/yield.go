@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var yieldChannelID string
+
+func yieldCmd() *cobra.Command {
+	nodeYieldCmd.Flags().StringVarP(&yieldChannelID, "channelID", "c", "", "The channel to yield the leadership of.")
+	return nodeYieldCmd
+}
+
+var nodeYieldCmd = &cobra.Command{
+	Use:   "yield",
+	Short: "Makes the node yield its leadership of a channel.",
+	Long:  `Makes the running node yield its leadership of a channel to another peer of its organization. The request is signed with the local MSP, which must be an admin of the node. The yield is temporary: the node stays out of the leader election for 6 times peer.gossip.election.leaderAliveThreshold, after which it takes the leadership back if the consistenthash strategy selects it or the leadership of the channel is pinned to it.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return yield(yieldChannelID)
+	},
+}
+
+func yield(channelID string) error {
+	if channelID == "" {
+		return errors.New("must supply channel ID")
+	}
+
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_MESSAGE, channelID, localmsp.NewSigner(), &pb.LeadershipRequest{ChannelId: channelID}, 0, 0)
+	if err != nil {
+		return errors.WithMessage(err, "failed signing the leadership request")
+	}
+
+	adminClient, err := common.GetAdminClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = adminClient.YieldLeadership(context.Background(), env)
+	if err != nil {
+		return errors.WithMessage(err, "failed yielding the leadership of channel "+channelID)
+	}
+	logger.Infof("Yielded the leadership of channel %s", channelID)
+	return nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/core"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/peer"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+type yieldingAdminServer struct {
+	*core.ServerAdmin
+	yielded chan string
+}
+
+func (s *yieldingAdminServer) YieldLeadership(ctx context.Context, env *cb.Envelope) (*empty.Empty, error) {
+	request := &pb.LeadershipRequest{}
+	if _, err := utils.UnmarshalEnvelopeOfType(env, cb.HeaderType_MESSAGE, request); err != nil {
+		return nil, err
+	}
+	if request.ChannelId != "mychannel" {
+		return nil, errors.Errorf("leader election isn't used for channel %s", request.ChannelId)
+	}
+	s.yielded <- request.ChannelId
+	return &empty.Empty{}, nil
+}
+
+func TestYield(t *testing.T) {
+	assert.NoError(t, msptesttools.LoadMSPSetupForTesting())
+	viper.Set("peer.address", "localhost:7074")
+	peerServer, err := peer.CreatePeerServer("localhost:7074", comm.SecureServerConfig{})
+	assert.NoError(t, err)
+	adminServer := &yieldingAdminServer{ServerAdmin: core.NewAdminServer(), yielded: make(chan string, 1)}
+	pb.RegisterAdminServer(peerServer.Server(), adminServer)
+	go peerServer.Start()
+	defer peerServer.Stop()
+
+	assert.EqualError(t, yield(""), "must supply channel ID")
+
+	err = yield("otherchannel")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed yielding the leadership of channel otherchannel")
+	assert.Contains(t, err.Error(), "leader election isn't used for channel otherchannel")
+
+	cmd := yieldCmd()
+	cmd.SetArgs([]string{"-c", "mychannel"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "mychannel", <-adminServer.yielded)
+}
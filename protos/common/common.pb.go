@@ -149,6 +149,7 @@ const (
 	BlockMetadataIndex_LAST_CONFIG         BlockMetadataIndex = 1
 	BlockMetadataIndex_TRANSACTIONS_FILTER BlockMetadataIndex = 2
 	BlockMetadataIndex_ORDERER             BlockMetadataIndex = 3
+	BlockMetadataIndex_COMMIT_HASH         BlockMetadataIndex = 4
 )
 
 var BlockMetadataIndex_name = map[int32]string{
@@ -156,12 +157,14 @@ var BlockMetadataIndex_name = map[int32]string{
 	1: "LAST_CONFIG",
 	2: "TRANSACTIONS_FILTER",
 	3: "ORDERER",
+	4: "COMMIT_HASH",
 }
 var BlockMetadataIndex_value = map[string]int32{
 	"SIGNATURES":          0,
 	"LAST_CONFIG":         1,
 	"TRANSACTIONS_FILTER": 2,
 	"ORDERER":             3,
+	"COMMIT_HASH":         4,
 }
 
 func (x BlockMetadataIndex) String() string {
@@ -286,6 +289,9 @@ type ChannelHeader struct {
 	Epoch uint64 `protobuf:"varint,6,opt,name=epoch" json:"epoch,omitempty"`
 	// Extension that may be attached based on the header type
 	Extension []byte `protobuf:"bytes,7,opt,name=extension,proto3" json:"extension,omitempty"`
+	// If mutual TLS is employed, this represents
+	// the hash of the client's TLS certificate
+	TlsCertHash []byte `protobuf:"bytes,8,opt,name=tls_cert_hash,json=tlsCertHash,proto3" json:"tls_cert_hash,omitempty"`
 }
 
 func (m *ChannelHeader) Reset()                    { *m = ChannelHeader{} }
@@ -342,6 +348,13 @@ func (m *ChannelHeader) GetExtension() []byte {
 	return nil
 }
 
+func (m *ChannelHeader) GetTlsCertHash() []byte {
+	if m != nil {
+		return m.TlsCertHash
+	}
+	return nil
+}
+
 type SignatureHeader struct {
 	// Creator of the message, specified as a certificate chain
 	Creator []byte `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
@@ -546,62 +559,63 @@ func init() {
 func init() { proto.RegisterFile("common/common.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 900 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xdf, 0x6e, 0xe3, 0xc4,
-	0x1b, 0xad, 0xe3, 0xfc, 0x69, 0xbe, 0x34, 0xad, 0x3b, 0xd9, 0xfe, 0xd6, 0xbf, 0xc2, 0x6a, 0x23,
-	0xc3, 0xa2, 0xd2, 0x4a, 0x89, 0x28, 0x37, 0x70, 0xe9, 0xd8, 0x93, 0xd6, 0x6a, 0xd6, 0x2e, 0x63,
-	0x67, 0x11, 0xbb, 0x48, 0x96, 0x93, 0x4c, 0x13, 0x8b, 0xc4, 0x8e, 0x6c, 0xa7, 0x6a, 0x5f, 0x02,
-	0x21, 0xc1, 0x0d, 0x17, 0xbc, 0x00, 0x4f, 0xc2, 0x5b, 0xf0, 0x12, 0x48, 0xdc, 0xa2, 0xf1, 0x8c,
-	0xbd, 0x49, 0x59, 0x89, 0xab, 0xcc, 0x39, 0x73, 0x3c, 0xdf, 0x99, 0xf3, 0x7d, 0xb1, 0xa1, 0x33,
-	0x8d, 0x57, 0xab, 0x38, 0xea, 0xf3, 0x9f, 0xde, 0x3a, 0x89, 0xb3, 0x18, 0xd5, 0x39, 0x3a, 0x7d,
-	0x39, 0x8f, 0xe3, 0xf9, 0x92, 0xf6, 0x73, 0x76, 0xb2, 0xb9, 0xeb, 0x67, 0xe1, 0x8a, 0xa6, 0x59,
-	0xb0, 0x5a, 0x73, 0xa1, 0xa6, 0x01, 0x8c, 0x82, 0x34, 0x33, 0xe2, 0xe8, 0x2e, 0x9c, 0xa3, 0x67,
-	0x50, 0x0b, 0xa3, 0x19, 0x7d, 0x50, 0xa5, 0xae, 0x74, 0x56, 0x25, 0x1c, 0x68, 0xef, 0x60, 0xff,
-	0x35, 0xcd, 0x82, 0x59, 0x90, 0x05, 0x4c, 0x71, 0x1f, 0x2c, 0x37, 0x34, 0x57, 0x1c, 0x10, 0x0e,
-	0xd0, 0xd7, 0x00, 0x69, 0x38, 0x8f, 0x82, 0x6c, 0x93, 0xd0, 0x54, 0xad, 0x74, 0xe5, 0xb3, 0xd6,
-	0xe5, 0xff, 0x7b, 0xc2, 0x51, 0xf1, 0xac, 0x5b, 0x28, 0xc8, 0x96, 0x58, 0xfb, 0x1e, 0x8e, 0xff,
-	0x25, 0x40, 0x9f, 0x83, 0x52, 0x4a, 0xfc, 0x05, 0x0d, 0x66, 0x34, 0x11, 0x05, 0x8f, 0x4a, 0xfe,
-	0x3a, 0xa7, 0xd1, 0xc7, 0xd0, 0x2c, 0x29, 0xb5, 0x92, 0x6b, 0xde, 0x13, 0xda, 0x5b, 0xa8, 0x0b,
-	0xdd, 0x2b, 0x38, 0x9c, 0x2e, 0x82, 0x28, 0xa2, 0xcb, 0xdd, 0x03, 0xdb, 0x82, 0x15, 0xb2, 0x0f,
-	0x55, 0xae, 0x7c, 0xb0, 0xb2, 0xf6, 0xa7, 0x04, 0x6d, 0x63, 0xe7, 0x61, 0x04, 0xd5, 0xec, 0x71,
-	0xcd, 0xb3, 0xa9, 0x91, 0x7c, 0x8d, 0x54, 0x68, 0xdc, 0xd3, 0x24, 0x0d, 0xe3, 0x28, 0x3f, 0xa7,
-	0x46, 0x0a, 0x88, 0xbe, 0x82, 0x66, 0xd9, 0x0d, 0x55, 0xee, 0x4a, 0x67, 0xad, 0xcb, 0xd3, 0x1e,
-	0xef, 0x57, 0xaf, 0xe8, 0x57, 0xcf, 0x2b, 0x14, 0xe4, 0xbd, 0x18, 0xbd, 0x00, 0x28, 0xee, 0x12,
-	0xce, 0xd4, 0x6a, 0x57, 0x3a, 0x6b, 0x92, 0xa6, 0x60, 0xac, 0x19, 0xea, 0x40, 0x2d, 0x7b, 0x60,
-	0x3b, 0xb5, 0x7c, 0xa7, 0x9a, 0x3d, 0x58, 0x33, 0xd6, 0x38, 0xba, 0x8e, 0xa7, 0x0b, 0xb5, 0xce,
-	0x5b, 0x9b, 0x03, 0x96, 0x1e, 0x7d, 0xc8, 0x68, 0x94, 0xfb, 0x6b, 0xf0, 0xf4, 0x4a, 0x42, 0xd3,
-	0xe1, 0xc8, 0x7d, 0x12, 0xb7, 0x0a, 0x8d, 0x69, 0x42, 0x83, 0x2c, 0x2e, 0xf2, 0x2b, 0x20, 0x2b,
-	0x10, 0xc5, 0xd1, 0xb4, 0x68, 0x02, 0x07, 0x1a, 0x86, 0xc6, 0x6d, 0xf0, 0xb8, 0x8c, 0x83, 0x19,
-	0xfa, 0x0c, 0xea, 0x5b, 0xc9, 0xb7, 0x2e, 0x0f, 0x8b, 0x01, 0xe1, 0x47, 0x13, 0xb1, 0xcb, 0x52,
-	0x64, 0xd3, 0x20, 0xce, 0xc9, 0xd7, 0xda, 0x00, 0xf6, 0x71, 0x74, 0x4f, 0x97, 0x31, 0x4f, 0x74,
-	0xcd, 0x8f, 0x2c, 0x2c, 0x08, 0xf8, 0x1f, 0xb3, 0xf0, 0xa3, 0x04, 0xb5, 0xc1, 0x32, 0x9e, 0xfe,
-	0x80, 0x2e, 0x9e, 0x38, 0xe9, 0x14, 0x4e, 0xf2, 0xed, 0x27, 0x76, 0x5e, 0x6d, 0xd9, 0x69, 0x5d,
-	0x1e, 0xef, 0x48, 0xcd, 0x20, 0x0b, 0xb8, 0x43, 0xf4, 0x05, 0xec, 0xaf, 0xc4, 0x1c, 0x8b, 0x66,
-	0x9e, 0xec, 0x48, 0x8b, 0x21, 0x27, 0xa5, 0x4c, 0x9b, 0x43, 0x6b, 0xab, 0x20, 0xfa, 0x1f, 0xd4,
-	0xa3, 0xcd, 0x6a, 0x22, 0x5c, 0x55, 0x89, 0x40, 0xe8, 0x13, 0x68, 0xaf, 0x13, 0x7a, 0x1f, 0xc6,
-	0x9b, 0xd4, 0x5f, 0x04, 0xe9, 0x42, 0xdc, 0xec, 0xa0, 0x20, 0xaf, 0x83, 0x74, 0x81, 0x3e, 0x82,
-	0x26, 0x3b, 0x93, 0x0b, 0xe4, 0x5c, 0xb0, 0xcf, 0x08, 0xb6, 0xa9, 0xbd, 0x84, 0x66, 0x69, 0xb7,
-	0x8c, 0x57, 0xea, 0xca, 0x65, 0xbc, 0x17, 0xd0, 0xde, 0x31, 0x89, 0x4e, 0xb7, 0x6e, 0xc3, 0x85,
-	0x25, 0x3e, 0xff, 0x5d, 0x82, 0xba, 0x9b, 0x05, 0xd9, 0x26, 0x45, 0x2d, 0x68, 0x8c, 0xed, 0x1b,
-	0xdb, 0xf9, 0xd6, 0x56, 0xf6, 0xd0, 0x01, 0x34, 0xdc, 0xb1, 0x61, 0x60, 0xd7, 0x55, 0xfe, 0x90,
-	0x90, 0x02, 0xad, 0x81, 0x6e, 0xfa, 0x04, 0x7f, 0x33, 0xc6, 0xae, 0xa7, 0xfc, 0x24, 0xa3, 0x43,
-	0x68, 0x0e, 0x1d, 0x32, 0xb0, 0x4c, 0x13, 0xdb, 0xca, 0xcf, 0x39, 0xb6, 0x1d, 0xcf, 0x1f, 0x3a,
-	0x63, 0xdb, 0x54, 0x7e, 0x91, 0xd1, 0x0b, 0x50, 0x85, 0xda, 0xc7, 0xb6, 0x67, 0x79, 0xdf, 0xf9,
-	0x9e, 0xe3, 0xf8, 0x23, 0x9d, 0x5c, 0x61, 0xe5, 0x37, 0x19, 0x9d, 0xc2, 0x89, 0x65, 0x7b, 0x98,
-	0xd8, 0xfa, 0xc8, 0x77, 0x31, 0x79, 0x83, 0x89, 0x8f, 0x09, 0x71, 0x88, 0xf2, 0x97, 0x8c, 0x54,
-	0xe8, 0x30, 0xca, 0x32, 0xb0, 0x3f, 0xb6, 0xf5, 0x37, 0xba, 0x35, 0xd2, 0x07, 0x23, 0xac, 0xfc,
-	0x2d, 0x9f, 0xff, 0x2a, 0x01, 0xf0, 0x7c, 0x3d, 0xf6, 0x6f, 0x6c, 0x41, 0xe3, 0x35, 0x76, 0x5d,
-	0xfd, 0x0a, 0x2b, 0x7b, 0x08, 0xa0, 0x6e, 0x38, 0xf6, 0xd0, 0xba, 0x52, 0x24, 0x74, 0x0c, 0x6d,
-	0xbe, 0xf6, 0xc7, 0xb7, 0xa6, 0xee, 0x61, 0xa5, 0x82, 0x54, 0x78, 0x86, 0x6d, 0xd3, 0x21, 0x2e,
-	0x26, 0xbe, 0x47, 0x74, 0xdb, 0xd5, 0x0d, 0xcf, 0x72, 0x6c, 0x45, 0x46, 0xcf, 0xa1, 0xe3, 0x10,
-	0x13, 0x93, 0x27, 0x1b, 0x55, 0x74, 0x02, 0xc7, 0x26, 0x1e, 0x59, 0xcc, 0x9b, 0x8b, 0xf1, 0x8d,
-	0x6f, 0xd9, 0x43, 0x47, 0xa9, 0x31, 0xda, 0xb8, 0xd6, 0x2d, 0xdb, 0x70, 0x4c, 0xec, 0xdf, 0xea,
-	0xc6, 0x0d, 0xab, 0x5f, 0x3f, 0x7f, 0x07, 0x68, 0x27, 0x75, 0x8b, 0xbd, 0x6d, 0xd1, 0x21, 0x80,
-	0x6b, 0x5d, 0xd9, 0xba, 0x37, 0x26, 0xd8, 0x55, 0xf6, 0xd0, 0x11, 0xb4, 0x46, 0xba, 0xeb, 0xf9,
-	0xa5, 0xd5, 0xe7, 0xd0, 0xd9, 0xaa, 0xea, 0xfa, 0x43, 0x6b, 0xe4, 0x61, 0xa2, 0x54, 0xd8, 0xe5,
-	0x84, 0x2d, 0x45, 0x1e, 0xb8, 0xf0, 0x69, 0x9c, 0xcc, 0x7b, 0x8b, 0xc7, 0x35, 0x4d, 0x96, 0x74,
-	0x36, 0xa7, 0x49, 0xef, 0x2e, 0x98, 0x24, 0xe1, 0x94, 0xbf, 0x5b, 0x52, 0x31, 0x9c, 0x6f, 0x2f,
-	0xe6, 0x61, 0xb6, 0xd8, 0x4c, 0x18, 0xec, 0x6f, 0x89, 0xfb, 0x5c, 0xcc, 0x3f, 0x1c, 0xa9, 0xf8,
-	0xb8, 0x4c, 0xea, 0x39, 0xfc, 0xf2, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xa1, 0xcb, 0xe1, 0xb4,
-	0x74, 0x06, 0x00, 0x00,
+	// 928 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0x41, 0x6f, 0xe3, 0x44,
+	0x18, 0x5d, 0xc7, 0x89, 0xd3, 0x7c, 0x6e, 0x5a, 0x77, 0xb2, 0x65, 0x4d, 0x61, 0xb5, 0x95, 0x61,
+	0x51, 0x69, 0xa5, 0x44, 0x94, 0x0b, 0x1c, 0x1d, 0x7b, 0xd2, 0x58, 0x4d, 0xed, 0x32, 0x76, 0x16,
+	0xb1, 0x20, 0x59, 0x4e, 0x32, 0x4d, 0x22, 0x1c, 0x3b, 0xb2, 0x27, 0x55, 0x7b, 0xe6, 0x8e, 0x90,
+	0xe0, 0xc2, 0x81, 0x3f, 0xc0, 0x2f, 0xe1, 0x07, 0x21, 0x71, 0x45, 0xf6, 0xd8, 0xde, 0xa4, 0xac,
+	0xc4, 0x29, 0x7e, 0x6f, 0xde, 0x7c, 0xdf, 0x9b, 0xef, 0x4d, 0x6c, 0xe8, 0x4c, 0xe3, 0xd5, 0x2a,
+	0x8e, 0x7a, 0xfc, 0xa7, 0xbb, 0x4e, 0x62, 0x16, 0x23, 0x89, 0xa3, 0x93, 0x57, 0xf3, 0x38, 0x9e,
+	0x87, 0xb4, 0x97, 0xb3, 0x93, 0xcd, 0x5d, 0x8f, 0x2d, 0x57, 0x34, 0x65, 0xc1, 0x6a, 0xcd, 0x85,
+	0x9a, 0x06, 0x30, 0x0a, 0x52, 0x66, 0xc4, 0xd1, 0xdd, 0x72, 0x8e, 0x9e, 0x43, 0x63, 0x19, 0xcd,
+	0xe8, 0x83, 0x2a, 0x9c, 0x0a, 0x67, 0x75, 0xc2, 0x81, 0xf6, 0x3d, 0xec, 0xdd, 0x50, 0x16, 0xcc,
+	0x02, 0x16, 0x64, 0x8a, 0xfb, 0x20, 0xdc, 0xd0, 0x5c, 0xb1, 0x4f, 0x38, 0x40, 0x5f, 0x03, 0xa4,
+	0xcb, 0x79, 0x14, 0xb0, 0x4d, 0x42, 0x53, 0xb5, 0x76, 0x2a, 0x9e, 0xc9, 0x97, 0x1f, 0x76, 0x0b,
+	0x47, 0xe5, 0x5e, 0xb7, 0x54, 0x90, 0x2d, 0xb1, 0xf6, 0x03, 0x1c, 0xfd, 0x47, 0x80, 0x3e, 0x07,
+	0xa5, 0x92, 0xf8, 0x0b, 0x1a, 0xcc, 0x68, 0x52, 0x34, 0x3c, 0xac, 0xf8, 0x61, 0x4e, 0xa3, 0x8f,
+	0xa1, 0x55, 0x51, 0x6a, 0x2d, 0xd7, 0xbc, 0x23, 0xb4, 0xb7, 0x20, 0x15, 0xba, 0xd7, 0x70, 0x30,
+	0x5d, 0x04, 0x51, 0x44, 0xc3, 0xdd, 0x82, 0xed, 0x82, 0x2d, 0x64, 0xef, 0xeb, 0x5c, 0x7b, 0x6f,
+	0x67, 0xed, 0xa7, 0x1a, 0xb4, 0x8d, 0x9d, 0xcd, 0x08, 0xea, 0xec, 0x71, 0xcd, 0x67, 0xd3, 0x20,
+	0xf9, 0x33, 0x52, 0xa1, 0x79, 0x4f, 0x93, 0x74, 0x19, 0x47, 0x79, 0x9d, 0x06, 0x29, 0x21, 0xfa,
+	0x0a, 0x5a, 0x55, 0x1a, 0xaa, 0x78, 0x2a, 0x9c, 0xc9, 0x97, 0x27, 0x5d, 0x9e, 0x57, 0xb7, 0xcc,
+	0xab, 0xeb, 0x95, 0x0a, 0xf2, 0x4e, 0x8c, 0x5e, 0x02, 0x94, 0x67, 0x59, 0xce, 0xd4, 0xfa, 0xa9,
+	0x70, 0xd6, 0x22, 0xad, 0x82, 0xb1, 0x66, 0xa8, 0x03, 0x0d, 0xf6, 0x90, 0xad, 0x34, 0xf2, 0x95,
+	0x3a, 0x7b, 0xb0, 0x66, 0x59, 0x70, 0x74, 0x1d, 0x4f, 0x17, 0xaa, 0xc4, 0xa3, 0xcd, 0x41, 0x36,
+	0x3d, 0xfa, 0xc0, 0x68, 0x94, 0xfb, 0x6b, 0xf2, 0xe9, 0x55, 0x04, 0xd2, 0xa0, 0xcd, 0xc2, 0xd4,
+	0x9f, 0xd2, 0x84, 0xf9, 0x8b, 0x20, 0x5d, 0xa8, 0x7b, 0xb9, 0x42, 0x66, 0x61, 0x6a, 0xd0, 0x84,
+	0x0d, 0x83, 0x74, 0xa1, 0xe9, 0x70, 0xe8, 0x3e, 0x89, 0x44, 0x85, 0xe6, 0x34, 0xa1, 0x01, 0x8b,
+	0xcb, 0x19, 0x97, 0x30, 0x33, 0x11, 0xc5, 0xd1, 0xb4, 0x0c, 0x8a, 0x03, 0x0d, 0x43, 0xf3, 0x36,
+	0x78, 0x0c, 0xe3, 0x60, 0x86, 0x3e, 0x03, 0x69, 0x2b, 0x1d, 0xf9, 0xf2, 0xa0, 0xbc, 0x44, 0xbc,
+	0x34, 0x91, 0x16, 0xd5, 0xa4, 0xb3, 0x1b, 0x53, 0xd4, 0xc9, 0x9f, 0xb5, 0x3e, 0xec, 0xe1, 0xe8,
+	0x9e, 0x86, 0x31, 0x9f, 0xfa, 0x9a, 0x97, 0x2c, 0x2d, 0x14, 0xf0, 0x7f, 0xee, 0xcb, 0xcf, 0x02,
+	0x34, 0xfa, 0x61, 0x3c, 0xfd, 0x11, 0x5d, 0x3c, 0x71, 0xd2, 0x29, 0x9d, 0xe4, 0xcb, 0x4f, 0xec,
+	0xbc, 0xde, 0xb2, 0x23, 0x5f, 0x1e, 0xed, 0x48, 0xcd, 0x80, 0x05, 0xdc, 0x21, 0xfa, 0x02, 0xf6,
+	0x56, 0xc5, 0x5d, 0x2f, 0x02, 0x3f, 0xde, 0x91, 0x96, 0x7f, 0x04, 0x52, 0xc9, 0xb4, 0x39, 0xc8,
+	0x5b, 0x0d, 0xd1, 0x07, 0x20, 0x45, 0x9b, 0xd5, 0xa4, 0x70, 0x55, 0x27, 0x05, 0x42, 0x9f, 0x40,
+	0x7b, 0x9d, 0xd0, 0xfb, 0x65, 0xbc, 0x49, 0x79, 0x52, 0xfc, 0x64, 0xfb, 0x25, 0x99, 0x45, 0x85,
+	0x3e, 0x82, 0x56, 0x56, 0x93, 0x0b, 0xc4, 0x5c, 0xb0, 0x97, 0x11, 0x79, 0x8e, 0xaf, 0xa0, 0x55,
+	0xd9, 0xad, 0xc6, 0x2b, 0x9c, 0x8a, 0xd5, 0x78, 0x2f, 0xa0, 0xbd, 0x63, 0x12, 0x9d, 0x6c, 0x9d,
+	0x86, 0x0b, 0x2b, 0x7c, 0xfe, 0xa7, 0x00, 0x92, 0xcb, 0x02, 0xb6, 0x49, 0x91, 0x0c, 0xcd, 0xb1,
+	0x7d, 0x6d, 0x3b, 0xdf, 0xda, 0xca, 0x33, 0xb4, 0x0f, 0x4d, 0x77, 0x6c, 0x18, 0xd8, 0x75, 0x95,
+	0xbf, 0x04, 0xa4, 0x80, 0xdc, 0xd7, 0x4d, 0x9f, 0xe0, 0x6f, 0xc6, 0xd8, 0xf5, 0x94, 0x5f, 0x44,
+	0x74, 0x00, 0xad, 0x81, 0x43, 0xfa, 0x96, 0x69, 0x62, 0x5b, 0xf9, 0x35, 0xc7, 0xb6, 0xe3, 0xf9,
+	0x03, 0x67, 0x6c, 0x9b, 0xca, 0x6f, 0x22, 0x7a, 0x09, 0x6a, 0xa1, 0xf6, 0xb1, 0xed, 0x59, 0xde,
+	0x77, 0xbe, 0xe7, 0x38, 0xfe, 0x48, 0x27, 0x57, 0x58, 0xf9, 0x43, 0x44, 0x27, 0x70, 0x6c, 0xd9,
+	0x1e, 0x26, 0xb6, 0x3e, 0xf2, 0x5d, 0x4c, 0xde, 0x60, 0xe2, 0x63, 0x42, 0x1c, 0xa2, 0xfc, 0x2d,
+	0x22, 0x15, 0x3a, 0x19, 0x65, 0x19, 0xd8, 0x1f, 0xdb, 0xfa, 0x1b, 0xdd, 0x1a, 0xe9, 0xfd, 0x11,
+	0x56, 0xfe, 0x11, 0xcf, 0x7f, 0x17, 0x00, 0xf8, 0x7c, 0xbd, 0xec, 0x1f, 0x2b, 0x43, 0xf3, 0x06,
+	0xbb, 0xae, 0x7e, 0x85, 0x95, 0x67, 0x08, 0x40, 0x32, 0x1c, 0x7b, 0x60, 0x5d, 0x29, 0x02, 0x3a,
+	0x82, 0x36, 0x7f, 0xf6, 0xc7, 0xb7, 0xa6, 0xee, 0x61, 0xa5, 0x86, 0x54, 0x78, 0x8e, 0x6d, 0xd3,
+	0x21, 0x2e, 0x26, 0xbe, 0x47, 0x74, 0xdb, 0xd5, 0x0d, 0xcf, 0x72, 0x6c, 0x45, 0x44, 0x2f, 0xa0,
+	0xe3, 0x10, 0x13, 0x93, 0x27, 0x0b, 0x75, 0x74, 0x0c, 0x47, 0x26, 0x1e, 0x59, 0x99, 0x37, 0x17,
+	0xe3, 0x6b, 0xdf, 0xb2, 0x07, 0x8e, 0xd2, 0xc8, 0x68, 0x63, 0xa8, 0x5b, 0xb6, 0xe1, 0x98, 0xd8,
+	0xbf, 0xd5, 0x8d, 0xeb, 0xac, 0xbf, 0x74, 0x1e, 0x02, 0xda, 0x99, 0xba, 0x95, 0xbd, 0x91, 0xd1,
+	0x01, 0x80, 0x6b, 0x5d, 0xd9, 0xba, 0x37, 0x26, 0xd8, 0x55, 0x9e, 0xa1, 0x43, 0x90, 0x47, 0xba,
+	0xeb, 0xf9, 0x95, 0xd5, 0x17, 0xd0, 0xd9, 0xea, 0xea, 0xfa, 0x03, 0x6b, 0xe4, 0x61, 0xa2, 0xd4,
+	0xb2, 0xc3, 0x15, 0xb6, 0x14, 0x31, 0xdb, 0x66, 0x38, 0x37, 0x37, 0x96, 0xe7, 0x0f, 0x75, 0x77,
+	0xa8, 0xd4, 0xfb, 0x2e, 0x7c, 0x1a, 0x27, 0xf3, 0xee, 0xe2, 0x71, 0x4d, 0x93, 0x90, 0xce, 0xe6,
+	0x34, 0xe9, 0xde, 0x05, 0x93, 0x64, 0x39, 0xe5, 0x2f, 0xa4, 0xb4, 0xb8, 0xad, 0x6f, 0x2f, 0xe6,
+	0x4b, 0xb6, 0xd8, 0x4c, 0x32, 0xd8, 0xdb, 0x12, 0xf7, 0xb8, 0x98, 0x7f, 0x6d, 0xd2, 0xe2, 0x8b,
+	0x34, 0x91, 0x72, 0xf8, 0xe5, 0xbf, 0x03, 0x00, 0x23, 0xd4, 0x52, 0x12, 0xa9, 0x06, 0x00, 0x00,
 }
@@ -18,6 +18,9 @@ type BlockchainInfo struct {
 	Height            uint64 `protobuf:"varint,1,opt,name=height" json:"height,omitempty"`
 	CurrentBlockHash  []byte `protobuf:"bytes,2,opt,name=currentBlockHash,proto3" json:"currentBlockHash,omitempty"`
 	PreviousBlockHash []byte `protobuf:"bytes,3,opt,name=previousBlockHash,proto3" json:"previousBlockHash,omitempty"`
+	// The commit hash of the current block, that is, the hash computed by the peer over the
+	// validation results and the state updates of the blocks up to the current block
+	CommitHash []byte `protobuf:"bytes,4,opt,name=commitHash,proto3" json:"commitHash,omitempty"`
 }
 
 func (m *BlockchainInfo) Reset()                    { *m = BlockchainInfo{} }
@@ -46,6 +49,13 @@ func (m *BlockchainInfo) GetPreviousBlockHash() []byte {
 	return nil
 }
 
+func (m *BlockchainInfo) GetCommitHash() []byte {
+	if m != nil {
+		return m.CommitHash
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*BlockchainInfo)(nil), "common.BlockchainInfo")
 }
@@ -53,17 +63,18 @@ func init() {
 func init() { proto.RegisterFile("common/ledger.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 186 bytes of a gzipped FileDescriptorProto
+	// 198 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x4e, 0xce, 0xcf, 0xcd,
 	0xcd, 0xcf, 0xd3, 0xcf, 0x49, 0x4d, 0x49, 0x4f, 0x2d, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17,
-	0x62, 0x83, 0x08, 0x2a, 0x35, 0x31, 0x72, 0xf1, 0x39, 0xe5, 0xe4, 0x27, 0x67, 0x27, 0x67, 0x24,
-	0x66, 0xe6, 0x79, 0xe6, 0xa5, 0xe5, 0x0b, 0x89, 0x71, 0xb1, 0x65, 0xa4, 0x66, 0xa6, 0x67, 0x94,
-	0x48, 0x30, 0x2a, 0x30, 0x6a, 0xb0, 0x04, 0x41, 0x79, 0x42, 0x5a, 0x5c, 0x02, 0xc9, 0xa5, 0x45,
-	0x45, 0xa9, 0x79, 0x25, 0x60, 0x0d, 0x1e, 0x89, 0xc5, 0x19, 0x12, 0x4c, 0x0a, 0x8c, 0x1a, 0x3c,
-	0x41, 0x18, 0xe2, 0x42, 0x3a, 0x5c, 0x82, 0x05, 0x45, 0xa9, 0x65, 0x99, 0xf9, 0xa5, 0xc5, 0x08,
-	0xc5, 0xcc, 0x60, 0xc5, 0x98, 0x12, 0x4e, 0xc1, 0x5c, 0x2a, 0xf9, 0x45, 0xe9, 0x7a, 0x19, 0x95,
-	0x05, 0xa9, 0x45, 0x50, 0x57, 0xa6, 0x25, 0x26, 0x15, 0x65, 0x26, 0x43, 0x1c, 0x5b, 0xac, 0x07,
-	0x71, 0x6c, 0x94, 0x76, 0x7a, 0x66, 0x49, 0x46, 0x69, 0x12, 0x88, 0xab, 0x8f, 0xa4, 0x58, 0x1f,
-	0xa2, 0x58, 0x1f, 0xa2, 0x58, 0x1f, 0xa2, 0x38, 0x89, 0x0d, 0xcc, 0x35, 0x06, 0x04, 0x00, 0x00,
-	0xff, 0xff, 0x9f, 0xcc, 0x05, 0xd1, 0xff, 0x00, 0x00, 0x00,
+	0x62, 0x83, 0x08, 0x2a, 0x2d, 0x62, 0xe4, 0xe2, 0x73, 0xca, 0xc9, 0x4f, 0xce, 0x4e, 0xce, 0x48,
+	0xcc, 0xcc, 0xf3, 0xcc, 0x4b, 0xcb, 0x17, 0x12, 0xe3, 0x62, 0xcb, 0x48, 0xcd, 0x4c, 0xcf, 0x28,
+	0x91, 0x60, 0x54, 0x60, 0xd4, 0x60, 0x09, 0x82, 0xf2, 0x84, 0xb4, 0xb8, 0x04, 0x92, 0x4b, 0x8b,
+	0x8a, 0x52, 0xf3, 0x4a, 0xc0, 0x1a, 0x3c, 0x12, 0x8b, 0x33, 0x24, 0x98, 0x14, 0x18, 0x35, 0x78,
+	0x82, 0x30, 0xc4, 0x85, 0x74, 0xb8, 0x04, 0x0b, 0x8a, 0x52, 0xcb, 0x32, 0xf3, 0x4b, 0x8b, 0x11,
+	0x8a, 0x99, 0xc1, 0x8a, 0x31, 0x25, 0x84, 0xe4, 0xb8, 0xb8, 0x40, 0xce, 0xc9, 0x2c, 0x01, 0x2b,
+	0x63, 0x01, 0x2b, 0x43, 0x12, 0x71, 0x0a, 0xe6, 0x52, 0xc9, 0x2f, 0x4a, 0xd7, 0xcb, 0xa8, 0x2c,
+	0x48, 0x2d, 0x82, 0xfa, 0x22, 0x2d, 0x31, 0xa9, 0x28, 0x33, 0x19, 0xe2, 0x99, 0x62, 0x3d, 0x88,
+	0x67, 0xa2, 0xb4, 0xd3, 0x33, 0x4b, 0x32, 0x4a, 0x93, 0x40, 0x5c, 0x7d, 0x24, 0xc5, 0xfa, 0x10,
+	0xc5, 0xfa, 0x10, 0xc5, 0xfa, 0x10, 0xc5, 0x49, 0x6c, 0x60, 0xae, 0x31, 0x60, 0x00, 0xfa, 0x3e,
+	0x97, 0xbd, 0x1f, 0x01, 0x00, 0x00,
 }
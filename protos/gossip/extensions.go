@@ -451,6 +451,48 @@ func (e *Envelope) ToGossipMessage() (*SignedGossipMessage, error) {
 	}, nil
 }
 
+// NewSignedEndpoints creates SignedEndpoints out of the given endpoints of the
+// peer with the given identity, signed with the given Signer of the admin with
+// the given identity.
+func NewSignedEndpoints(peerIdentity []byte, endpoints []string, adminIdentity []byte, signer Signer) (*SignedEndpoints, error) {
+	payload, err := proto.Marshal(&Endpoints{
+		PeerIdentity: peerIdentity,
+		Endpoints:    endpoints,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedEndpoints{
+		Payload:       payload,
+		AdminIdentity: adminIdentity,
+		Signature:     sig,
+	}, nil
+}
+
+// Verify verifies the signature of the admin over the SignedEndpoints
+// with a given Verifier, and un-marshals the endpoints out of them.
+// Returns an error if the verification or the un-marshaling fails.
+func (se *SignedEndpoints) Verify(verify Verifier) (*Endpoints, error) {
+	if len(se.Payload) == 0 {
+		return nil, errors.New("Empty payload")
+	}
+	if len(se.Signature) == 0 {
+		return nil, errors.New("Empty signature")
+	}
+	if err := verify(se.AdminIdentity, se.Signature, se.Payload); err != nil {
+		return nil, err
+	}
+	endpoints := &Endpoints{}
+	if err := proto.Unmarshal(se.Payload, endpoints); err != nil {
+		return nil, fmt.Errorf("Failed unmarshaling Endpoints: %v", err)
+	}
+	return endpoints, nil
+}
+
 // SignSecret signs the secret payload and creates
 // a secret envelope out of it.
 func (e *Envelope) SignSecret(signer Signer, secret *Secret) error {
@@ -17,6 +17,7 @@ limitations under the License.
 package gossip
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"testing"
@@ -795,6 +796,38 @@ func TestEnvelope_SignSecret(t *testing.T) {
 	assert.Equal(t, env.SecretEnvelope.InternalEndpoint(), "localhost:5050")
 }
 
+func TestSignedEndpoints(t *testing.T) {
+	signer := func(msg []byte) ([]byte, error) {
+		return append([]byte("admin"), msg...), nil
+	}
+	verifier := func(identity []byte, signature, message []byte) error {
+		if !bytes.Equal(signature, append(identity, message...)) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+
+	se, err := NewSignedEndpoints([]byte("peer"), []string{"peer0-alt:7051"}, []byte("admin"), signer)
+	assert.NoError(t, err)
+	endpoints, err := se.Verify(verifier)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("peer"), endpoints.PeerIdentity)
+	assert.Equal(t, []string{"peer0-alt:7051"}, endpoints.Endpoints)
+
+	// Signed by another identity
+	se.AdminIdentity = []byte("member")
+	_, err = se.Verify(verifier)
+	assert.EqualError(t, err, "invalid signature")
+
+	_, err = (&SignedEndpoints{Payload: se.Payload, AdminIdentity: []byte("admin")}).Verify(verifier)
+	assert.EqualError(t, err, "Empty signature")
+
+	_, err = NewSignedEndpoints([]byte("peer"), []string{"peer0-alt:7051"}, []byte("admin"), func([]byte) ([]byte, error) {
+		return nil, errors.New("failed signing")
+	})
+	assert.EqualError(t, err, "failed signing")
+}
+
 func envelopes() []*Envelope {
 	return []*Envelope{
 		{Payload: []byte{2, 2, 2},
@@ -41,6 +41,8 @@ It has these top-level messages:
 	PvtDataElement
 	PvtDataPayload
 	Acknowledgement
+	SignedEndpoints
+	Endpoints
 */
 package gossip
 
@@ -1600,6 +1602,10 @@ type Member struct {
 	Endpoint string `protobuf:"bytes,1,opt,name=endpoint" json:"endpoint,omitempty"`
 	Metadata []byte `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	PkiId    []byte `protobuf:"bytes,3,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
+	// Endpoints, other than the endpoint, which the
+	// peer can be reached at by foreign organizations,
+	// signed by an admin of the peer's organization
+	AlternativeEndpoints *SignedEndpoints `protobuf:"bytes,4,opt,name=alternative_endpoints,json=alternativeEndpoints" json:"alternative_endpoints,omitempty"`
 }
 
 func (m *Member) Reset()                    { *m = Member{} }
@@ -1628,6 +1634,13 @@ func (m *Member) GetPkiId() []byte {
 	return nil
 }
 
+func (m *Member) GetAlternativeEndpoints() *SignedEndpoints {
+	if m != nil {
+		return m.AlternativeEndpoints
+	}
+	return nil
+}
+
 // Empty is used for pinging and in tests
 type Empty struct {
 }
@@ -1835,6 +1848,65 @@ func (m *Acknowledgement) GetError() string {
 	return ""
 }
 
+// SignedEndpoints holds endpoints of a peer, signed
+// by an admin of the organization of the peer
+type SignedEndpoints struct {
+	Payload       []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	AdminIdentity []byte `protobuf:"bytes,2,opt,name=admin_identity,json=adminIdentity,proto3" json:"admin_identity,omitempty"`
+	Signature     []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignedEndpoints) Reset()                    { *m = SignedEndpoints{} }
+func (m *SignedEndpoints) String() string            { return proto.CompactTextString(m) }
+func (*SignedEndpoints) ProtoMessage()               {}
+func (*SignedEndpoints) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *SignedEndpoints) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *SignedEndpoints) GetAdminIdentity() []byte {
+	if m != nil {
+		return m.AdminIdentity
+	}
+	return nil
+}
+
+func (m *SignedEndpoints) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// Endpoints are endpoints a peer can be reached at
+type Endpoints struct {
+	PeerIdentity []byte   `protobuf:"bytes,1,opt,name=peer_identity,json=peerIdentity,proto3" json:"peer_identity,omitempty"`
+	Endpoints    []string `protobuf:"bytes,2,rep,name=endpoints" json:"endpoints,omitempty"`
+}
+
+func (m *Endpoints) Reset()                    { *m = Endpoints{} }
+func (m *Endpoints) String() string            { return proto.CompactTextString(m) }
+func (*Endpoints) ProtoMessage()               {}
+func (*Endpoints) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *Endpoints) GetPeerIdentity() []byte {
+	if m != nil {
+		return m.PeerIdentity
+	}
+	return nil
+}
+
+func (m *Endpoints) GetEndpoints() []string {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Envelope)(nil), "gossip.Envelope")
 	proto.RegisterType((*SecretEnvelope)(nil), "gossip.SecretEnvelope")
@@ -1869,6 +1941,8 @@ func init() {
 	proto.RegisterType((*PvtDataElement)(nil), "gossip.PvtDataElement")
 	proto.RegisterType((*PvtDataPayload)(nil), "gossip.PvtDataPayload")
 	proto.RegisterType((*Acknowledgement)(nil), "gossip.Acknowledgement")
+	proto.RegisterType((*SignedEndpoints)(nil), "gossip.SignedEndpoints")
+	proto.RegisterType((*Endpoints)(nil), "gossip.Endpoints")
 	proto.RegisterEnum("gossip.PullMsgType", PullMsgType_name, PullMsgType_value)
 	proto.RegisterEnum("gossip.GossipMessage_Tag", GossipMessage_Tag_name, GossipMessage_Tag_value)
 }
@@ -2018,116 +2092,121 @@ var _Gossip_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1773 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdb, 0x6e, 0xe3, 0xc6,
-	0x19, 0x16, 0xad, 0x23, 0x7f, 0x1d, 0x2c, 0x8f, 0xbd, 0xbb, 0x8c, 0x93, 0xa6, 0x0e, 0xdb, 0x4d,
-	0xb6, 0x75, 0x62, 0x2f, 0x9c, 0x16, 0x0d, 0x90, 0xb6, 0x0b, 0xdb, 0x52, 0x2c, 0x21, 0x2b, 0xaf,
-	0x4b, 0x7b, 0xd1, 0xba, 0x37, 0xc4, 0x98, 0x1c, 0x53, 0xac, 0xc9, 0x21, 0xcd, 0x19, 0x6f, 0xec,
-	0xcb, 0xa2, 0x17, 0x05, 0x7a, 0xd7, 0x47, 0xe8, 0xb3, 0xf4, 0xc5, 0x8a, 0x99, 0xe1, 0x51, 0xb2,
-	0x16, 0xd8, 0x05, 0x7a, 0xa7, 0xff, 0x3c, 0xf3, 0xcd, 0x7f, 0xa2, 0x60, 0xcb, 0x8b, 0x18, 0xf3,
-	0xe3, 0xfd, 0x90, 0x30, 0x86, 0x3d, 0xb2, 0x17, 0x27, 0x11, 0x8f, 0x50, 0x4b, 0x71, 0xcd, 0x7f,
-	0x68, 0xd0, 0x19, 0xd3, 0x77, 0x24, 0x88, 0x62, 0x82, 0x0c, 0x68, 0xc7, 0xf8, 0x21, 0x88, 0xb0,
-	0x6b, 0x68, 0x3b, 0xda, 0x8b, 0x9e, 0x95, 0x91, 0xe8, 0x33, 0xd0, 0x99, 0xef, 0x51, 0xcc, 0xef,
-	0x12, 0x62, 0xac, 0x49, 0x59, 0xc1, 0x40, 0xaf, 0x60, 0x9d, 0x11, 0x27, 0x21, 0xdc, 0x26, 0xa9,
-	0x2b, 0xa3, 0xbe, 0xa3, 0xbd, 0xe8, 0x1e, 0x3c, 0xdd, 0x53, 0x61, 0xf6, 0xce, 0xa5, 0x38, 0x0b,
-	0x64, 0x0d, 0x58, 0x85, 0x36, 0x27, 0x30, 0xa8, 0x6a, 0x7c, 0xec, 0x51, 0xcc, 0x43, 0x68, 0x29,
-	0x4f, 0xe8, 0x6b, 0x18, 0xfa, 0x94, 0x93, 0x84, 0xe2, 0x60, 0x4c, 0xdd, 0x38, 0xf2, 0x29, 0x97,
-	0xae, 0xf4, 0x49, 0xcd, 0x5a, 0x92, 0x1c, 0xe9, 0xd0, 0x76, 0x22, 0xca, 0x09, 0xe5, 0xe6, 0x3f,
-	0xbb, 0xd0, 0x3f, 0x91, 0xc7, 0x9e, 0x29, 0xc8, 0xd0, 0x16, 0x34, 0x69, 0x44, 0x1d, 0x22, 0xed,
-	0x1b, 0x96, 0x22, 0xc4, 0x11, 0x9d, 0x39, 0xa6, 0x94, 0x04, 0xe9, 0x31, 0x32, 0x12, 0xed, 0x42,
-	0x9d, 0x63, 0x4f, 0x62, 0x30, 0x38, 0xf8, 0x24, 0xc3, 0xa0, 0xe2, 0x73, 0xef, 0x02, 0x7b, 0x96,
-	0xd0, 0x42, 0xdf, 0x82, 0x8e, 0x03, 0xff, 0x1d, 0xb1, 0x43, 0xe6, 0x19, 0x4d, 0x09, 0xdb, 0x56,
-	0x66, 0x72, 0x28, 0x04, 0xa9, 0xc5, 0xa4, 0x66, 0x75, 0xa4, 0xe2, 0x8c, 0x79, 0xe8, 0x37, 0xd0,
-	0x0e, 0x49, 0x68, 0x27, 0xe4, 0xd6, 0x68, 0x49, 0x93, 0x3c, 0xca, 0x8c, 0x84, 0x57, 0x24, 0x61,
-	0x73, 0x3f, 0xb6, 0xc8, 0xed, 0x1d, 0x61, 0x7c, 0x52, 0xb3, 0x5a, 0x21, 0x09, 0x2d, 0x72, 0x8b,
-	0x7e, 0x9b, 0x59, 0x31, 0xa3, 0x2d, 0xad, 0xb6, 0x1f, 0xb3, 0x62, 0x71, 0x44, 0x19, 0xc9, 0xcd,
-	0x18, 0x7a, 0x09, 0x1d, 0x17, 0x73, 0x2c, 0x0f, 0xd8, 0x91, 0x76, 0x9b, 0x99, 0xdd, 0x08, 0x73,
-	0x5c, 0x9c, 0xaf, 0x2d, 0xd4, 0xc4, 0xf1, 0x76, 0xa1, 0x39, 0x27, 0x41, 0x10, 0x19, 0x7a, 0x55,
-	0x5d, 0x41, 0x30, 0x11, 0xa2, 0x49, 0xcd, 0x52, 0x3a, 0x68, 0x3f, 0x75, 0xef, 0xfa, 0x9e, 0x01,
-	0x52, 0x1f, 0x95, 0xdd, 0x8f, 0x7c, 0x4f, 0xdd, 0x42, 0x7a, 0x1f, 0xf9, 0x5e, 0x7e, 0x1e, 0x71,
-	0xfb, 0xee, 0xf2, 0x79, 0x8a, 0x7b, 0x4b, 0x0b, 0x75, 0xf1, 0xae, 0xb4, 0xb8, 0x8b, 0x5d, 0xcc,
-	0x89, 0xd1, 0x5b, 0x8e, 0xf2, 0x56, 0x4a, 0x26, 0x35, 0x0b, 0xdc, 0x9c, 0x42, 0xcf, 0xa1, 0x49,
-	0xc2, 0x98, 0x3f, 0x18, 0x7d, 0x69, 0xd0, 0xcf, 0x0c, 0xc6, 0x82, 0x29, 0x2e, 0x20, 0xa5, 0x68,
-	0x17, 0x1a, 0x4e, 0x44, 0xa9, 0x31, 0x90, 0x5a, 0x4f, 0x32, 0xad, 0xe3, 0x88, 0xd2, 0x31, 0xe3,
-	0xf8, 0x2a, 0xf0, 0xd9, 0x7c, 0x52, 0xb3, 0xa4, 0x12, 0x3a, 0x00, 0x60, 0x1c, 0x73, 0x62, 0xfb,
-	0xf4, 0x3a, 0x32, 0xd6, 0xa5, 0xc9, 0x46, 0x5e, 0x26, 0x42, 0x32, 0xa5, 0xd7, 0x02, 0x1d, 0x9d,
-	0x65, 0x04, 0x3a, 0x82, 0x81, 0xb2, 0x61, 0x14, 0xc7, 0x6c, 0x1e, 0x71, 0x63, 0x58, 0x7d, 0xf4,
-	0xdc, 0xee, 0x3c, 0x55, 0x98, 0xd4, 0xac, 0xbe, 0x34, 0xc9, 0x18, 0x68, 0x06, 0x9b, 0x45, 0x5c,
-	0x3b, 0xbe, 0x0b, 0x02, 0x89, 0xdf, 0x86, 0x74, 0xf4, 0xd9, 0x92, 0xa3, 0xb3, 0xbb, 0x20, 0x28,
-	0x80, 0x1c, 0xb2, 0x05, 0x3e, 0x3a, 0x04, 0xe5, 0x5f, 0x38, 0x11, 0x4a, 0x06, 0xaa, 0x26, 0x94,
-	0x45, 0xc2, 0x88, 0x13, 0xe9, 0xae, 0x70, 0xd3, 0x63, 0x25, 0x1a, 0x8d, 0xb2, 0x5b, 0x25, 0x69,
-	0xca, 0x19, 0x9b, 0xd2, 0xc7, 0xa7, 0x8f, 0xfa, 0xc8, 0xb3, 0xb2, 0xcf, 0xca, 0x0c, 0x81, 0x4d,
-	0x40, 0xb0, 0xab, 0x92, 0x57, 0xa6, 0xe8, 0x56, 0x15, 0x9b, 0xd7, 0xb9, 0xb4, 0x48, 0xd4, 0x7e,
-	0x61, 0x22, 0xd2, 0xf5, 0x7b, 0xe8, 0xc7, 0x84, 0x24, 0xb6, 0xef, 0x12, 0xca, 0x7d, 0xfe, 0x60,
-	0x3c, 0xa9, 0x96, 0xe1, 0x19, 0x21, 0xc9, 0x34, 0x95, 0x89, 0x6b, 0xc4, 0x25, 0x5a, 0x14, 0x3b,
-	0x76, 0x6e, 0x8c, 0xa7, 0xd2, 0xe4, 0x59, 0x5e, 0xb9, 0xce, 0x0d, 0x8d, 0x7e, 0x0a, 0x88, 0xeb,
-	0x91, 0x90, 0x50, 0x71, 0x79, 0xa1, 0x85, 0xfe, 0x08, 0x10, 0x27, 0xfe, 0x3b, 0x85, 0x82, 0xf1,
-	0xac, 0x0a, 0xbe, 0xba, 0xef, 0xd9, 0x3b, 0x5e, 0xcd, 0xe2, 0x92, 0x05, 0x7a, 0x55, 0xb2, 0x67,
-	0x86, 0x21, 0xed, 0x7f, 0xb6, 0xc2, 0x3e, 0x47, 0xac, 0x64, 0x82, 0x5e, 0x41, 0x2f, 0xa5, 0x6c,
-	0x91, 0xe8, 0xc6, 0x27, 0xd5, 0x67, 0x3b, 0x53, 0xb2, 0x6a, 0x59, 0x77, 0xe3, 0x82, 0x6b, 0xda,
-	0x50, 0xbf, 0xc0, 0x1e, 0xea, 0x83, 0xfe, 0xf6, 0x74, 0x34, 0xfe, 0x61, 0x7a, 0x3a, 0x1e, 0x0d,
-	0x6b, 0x48, 0x87, 0xe6, 0x78, 0x76, 0x76, 0x71, 0x39, 0xd4, 0x50, 0x0f, 0x3a, 0x6f, 0xac, 0x13,
-	0xfb, 0xcd, 0xe9, 0xeb, 0xcb, 0xe1, 0x9a, 0xd0, 0x3b, 0x9e, 0x1c, 0x9e, 0x2a, 0xb2, 0x8e, 0x86,
-	0xd0, 0x93, 0xe4, 0xe1, 0xe9, 0xc8, 0x7e, 0x63, 0x9d, 0x0c, 0x1b, 0x68, 0x1d, 0xba, 0x4a, 0xc1,
-	0x92, 0x8c, 0x66, 0xb9, 0x13, 0xff, 0x57, 0x03, 0x3d, 0xcf, 0x48, 0xb4, 0x0d, 0x9d, 0x90, 0x70,
-	0x2c, 0x8f, 0xad, 0x66, 0x42, 0x4e, 0xa3, 0x3d, 0xd0, 0xb9, 0x1f, 0x12, 0xc6, 0x71, 0x18, 0xcb,
-	0x6e, 0xdc, 0x3d, 0x18, 0x96, 0x5f, 0xef, 0xc2, 0x0f, 0x89, 0x55, 0xa8, 0xa0, 0x27, 0xd0, 0x8a,
-	0x6f, 0x7c, 0xdb, 0x77, 0x65, 0x93, 0xee, 0x59, 0xcd, 0xf8, 0xc6, 0x9f, 0xba, 0xe8, 0xe7, 0xd0,
-	0x4d, 0x7b, 0xb8, 0x3d, 0x3b, 0x3c, 0x36, 0x1a, 0x52, 0x06, 0x29, 0x6b, 0x76, 0x78, 0x2c, 0xaa,
-	0x37, 0x4e, 0xa2, 0x98, 0x24, 0xdc, 0x27, 0x2c, 0xed, 0xd6, 0xa8, 0x00, 0x2f, 0x93, 0x58, 0x25,
-	0x2d, 0xf3, 0x02, 0xa0, 0x90, 0xa0, 0x5f, 0x40, 0x5f, 0x66, 0x45, 0x62, 0xcf, 0x89, 0xef, 0xcd,
-	0x79, 0x3a, 0x53, 0x7a, 0x8a, 0x39, 0x91, 0x3c, 0xf4, 0x05, 0xf4, 0x02, 0x72, 0xcd, 0xed, 0xf2,
-	0x7c, 0xe9, 0x58, 0x5d, 0xc1, 0x3b, 0x56, 0x2c, 0xf3, 0x10, 0x36, 0x96, 0xaa, 0x1e, 0x7d, 0x0d,
-	0x1d, 0x12, 0xc8, 0x84, 0x63, 0x86, 0xb6, 0x53, 0x2f, 0xa3, 0x90, 0xcf, 0xde, 0x5c, 0xc3, 0xfc,
-	0x1d, 0x6c, 0x3d, 0x56, 0xef, 0x8b, 0x28, 0x68, 0x8b, 0x28, 0x98, 0xd7, 0xd0, 0xaf, 0x34, 0xb7,
-	0x12, 0x9c, 0x5a, 0x19, 0xce, 0x6d, 0xe8, 0xe4, 0x25, 0xa5, 0x46, 0x64, 0x4e, 0x23, 0x13, 0xfa,
-	0x3c, 0x60, 0xb6, 0x43, 0x12, 0x6e, 0xcf, 0x31, 0x9b, 0xa7, 0x0f, 0xd1, 0xe5, 0x01, 0x3b, 0x26,
-	0x09, 0x9f, 0x60, 0x36, 0x37, 0xdf, 0x42, 0xaf, 0x5c, 0x7a, 0xab, 0xc2, 0x20, 0x68, 0x08, 0x37,
-	0x69, 0x08, 0xf9, 0xbb, 0x92, 0x2c, 0xf5, 0x6a, 0xb2, 0x98, 0x21, 0x74, 0x4b, 0x15, 0xb6, 0x7a,
-	0xba, 0xbb, 0x72, 0xf2, 0x30, 0x63, 0x6d, 0xa7, 0xfe, 0x42, 0xb7, 0x32, 0x12, 0xed, 0x41, 0x27,
-	0x64, 0x9e, 0xcd, 0x1f, 0xd2, 0x35, 0x67, 0x50, 0x8c, 0x1f, 0x81, 0xe2, 0x8c, 0x79, 0x17, 0x0f,
-	0x31, 0xb1, 0xda, 0xa1, 0xfa, 0x61, 0x46, 0xd0, 0x2d, 0xcd, 0xbd, 0x15, 0xe1, 0xca, 0xe7, 0x5d,
-	0x5b, 0x4a, 0xee, 0x0f, 0x0b, 0x78, 0x0f, 0x50, 0x8c, 0xb4, 0x15, 0xf1, 0x7e, 0x09, 0x8d, 0x34,
-	0xd6, 0xe3, 0x59, 0xd2, 0xf8, 0xa8, 0xc8, 0x81, 0x8a, 0xac, 0x46, 0xf6, 0xff, 0x1d, 0xd8, 0xef,
-	0xd4, 0x3b, 0x66, 0x5b, 0xda, 0xaf, 0xaa, 0x2b, 0x63, 0xf7, 0x60, 0x3d, 0xb7, 0x56, 0xec, 0x7c,
-	0x87, 0x34, 0x7f, 0x00, 0xb4, 0xdc, 0xe9, 0xd0, 0xcb, 0x45, 0x07, 0x4f, 0x17, 0xda, 0xe2, 0x92,
-	0x9f, 0x4b, 0x68, 0xa7, 0x3c, 0xf4, 0x0c, 0xda, 0x8c, 0xdc, 0xda, 0xf4, 0x2e, 0x4c, 0xaf, 0xdb,
-	0x62, 0xe4, 0xf6, 0xf4, 0x2e, 0x14, 0xd9, 0x59, 0x7a, 0x55, 0x85, 0xeb, 0x17, 0x0b, 0x5d, 0xb8,
-	0xbe, 0x53, 0x17, 0xb9, 0x5f, 0xee, 0xb3, 0xff, 0xd6, 0x60, 0x50, 0x0d, 0x8b, 0xbe, 0x82, 0x75,
-	0x27, 0x0a, 0x02, 0xe2, 0x70, 0x3f, 0xa2, 0x36, 0xc5, 0xa1, 0x42, 0x56, 0xb7, 0x06, 0x05, 0xfb,
-	0x14, 0x87, 0x44, 0xac, 0xc8, 0x42, 0xca, 0x62, 0xec, 0xa8, 0x15, 0x59, 0xb7, 0x0a, 0x06, 0xda,
-	0x84, 0x26, 0xbf, 0xcf, 0x5a, 0x9f, 0x6e, 0x35, 0xf8, 0xfd, 0xd4, 0x15, 0x6d, 0x29, 0x3b, 0x51,
-	0xf2, 0x13, 0x23, 0x3c, 0xed, 0x7d, 0xd9, 0x31, 0x2d, 0xc1, 0x33, 0xff, 0xa5, 0x41, 0xaf, 0xbc,
-	0x92, 0xa2, 0x3d, 0x80, 0x30, 0xdf, 0x1c, 0x53, 0xd0, 0x06, 0xd5, 0x9d, 0xd2, 0x2a, 0x69, 0x7c,
-	0x70, 0x9b, 0x2e, 0x37, 0x90, 0x46, 0xb5, 0x81, 0x98, 0x7f, 0xd7, 0x60, 0x63, 0x69, 0xb6, 0xaf,
-	0x6a, 0x11, 0x1f, 0x1a, 0xf8, 0x39, 0x0c, 0x7c, 0x66, 0xbb, 0xc4, 0x09, 0x70, 0x82, 0x05, 0xae,
-	0x12, 0xac, 0x8e, 0xd5, 0xf7, 0xd9, 0xa8, 0x60, 0x9a, 0xbf, 0x87, 0x4e, 0x66, 0x2d, 0x12, 0xc0,
-	0xa7, 0x4e, 0x39, 0x01, 0x7c, 0xea, 0x88, 0x04, 0x28, 0x65, 0xc6, 0x5a, 0x39, 0x33, 0xcc, 0x6b,
-	0xd8, 0x58, 0xda, 0xd6, 0xd1, 0xf7, 0x30, 0x64, 0x24, 0xb8, 0x96, 0x6b, 0x5a, 0x12, 0xaa, 0xd8,
-	0x5a, 0xf5, 0xc0, 0x79, 0x91, 0xae, 0x0b, 0xcd, 0x69, 0xa1, 0x28, 0x2a, 0x4e, 0xac, 0x1d, 0x54,
-	0x56, 0x56, 0xcf, 0x52, 0x84, 0x79, 0x05, 0x68, 0x79, 0xbf, 0x47, 0x5f, 0x42, 0x53, 0x7e, 0x4e,
-	0xac, 0x1c, 0x14, 0x4a, 0x2c, 0x3b, 0x05, 0xc1, 0xee, 0x7b, 0x3a, 0x05, 0xc1, 0xae, 0xf9, 0x67,
-	0x68, 0xa9, 0x18, 0xe2, 0xcd, 0x48, 0xe5, 0x7b, 0xcb, 0xca, 0xe9, 0xf7, 0x76, 0xb9, 0xc7, 0x47,
-	0xb2, 0xd9, 0x86, 0xa6, 0x5c, 0xb7, 0xcd, 0xbf, 0x00, 0x5a, 0x5e, 0x2a, 0xc5, 0x18, 0x61, 0x1c,
-	0x27, 0xdc, 0xae, 0x16, 0x5f, 0x57, 0x32, 0xcf, 0x55, 0x05, 0x7e, 0x0e, 0x5d, 0x42, 0x5d, 0xbb,
-	0xfa, 0x08, 0x3a, 0xa1, 0xae, 0x92, 0x9b, 0x47, 0xb0, 0xf9, 0xc8, 0xaa, 0x89, 0x76, 0xa1, 0x93,
-	0xd6, 0x79, 0x36, 0x4c, 0x97, 0x1a, 0x4a, 0xae, 0x60, 0x9e, 0xc0, 0xd6, 0x63, 0xeb, 0x1b, 0xda,
-	0x2f, 0xba, 0x9d, 0xf2, 0x91, 0x7f, 0x1e, 0xa4, 0x8a, 0xaa, 0x57, 0xe6, 0x4d, 0xd0, 0xfc, 0x8f,
-	0x06, 0xfd, 0x8a, 0xa8, 0xa8, 0x57, 0xad, 0x54, 0xaf, 0xef, 0x2f, 0xf1, 0xcf, 0x01, 0x8a, 0x96,
-	0x90, 0xd6, 0x79, 0x89, 0x83, 0x3e, 0x05, 0xfd, 0x2a, 0x88, 0x9c, 0x1b, 0x81, 0x89, 0x2c, 0xac,
-	0x86, 0xd5, 0x91, 0x8c, 0x73, 0x72, 0x8b, 0x76, 0xa0, 0x27, 0xa0, 0xf2, 0xa9, 0x2d, 0x59, 0x72,
-	0xcb, 0x69, 0x58, 0xc0, 0xc8, 0xed, 0x94, 0x1e, 0x09, 0x8e, 0xf9, 0x23, 0x3c, 0x79, 0x74, 0xd7,
-	0x44, 0x07, 0x4b, 0xfb, 0xc7, 0xd3, 0x85, 0xeb, 0x8e, 0x95, 0xb8, 0xb4, 0x85, 0x5c, 0xc2, 0xa0,
-	0x2a, 0x43, 0xdf, 0x40, 0x4b, 0xa1, 0x91, 0x26, 0xfe, 0x0a, 0xc8, 0x52, 0xa5, 0xf2, 0x5f, 0x05,
-	0x2a, 0xed, 0xf3, 0xf6, 0xfc, 0xa7, 0xdc, 0x75, 0xd6, 0x42, 0x9f, 0xc3, 0x3a, 0xbf, 0xb7, 0x2b,
-	0xd7, 0x4b, 0xf7, 0x2f, 0x7e, 0x7f, 0x9e, 0x5f, 0xb0, 0xea, 0xb2, 0xfc, 0xef, 0x83, 0xf9, 0x15,
-	0xac, 0x2f, 0xac, 0xf6, 0xa2, 0xe8, 0x48, 0x92, 0x44, 0x49, 0xfa, 0x3e, 0x8a, 0xf8, 0xf5, 0x1f,
-	0xa0, 0x5b, 0x1a, 0x5a, 0x8b, 0xfb, 0x72, 0x1f, 0xf4, 0xa3, 0xd7, 0x6f, 0x8e, 0x7f, 0xb4, 0x67,
-	0xe7, 0x27, 0x43, 0x4d, 0xac, 0xc5, 0xd3, 0xd1, 0xf8, 0xf4, 0x62, 0x7a, 0x71, 0x29, 0x39, 0x6b,
-	0x07, 0x7f, 0x83, 0x96, 0x5a, 0x1a, 0xd0, 0x77, 0xd0, 0x53, 0xbf, 0xce, 0x79, 0x42, 0x70, 0x88,
-	0x96, 0x2a, 0x70, 0x7b, 0x89, 0x63, 0xd6, 0x5e, 0x68, 0x2f, 0x35, 0xf4, 0x25, 0x34, 0xce, 0x7c,
-	0xea, 0xa1, 0xea, 0x77, 0xeb, 0x76, 0x95, 0x34, 0x6b, 0x47, 0xdf, 0xfc, 0x75, 0xd7, 0xf3, 0xf9,
-	0xfc, 0xee, 0x6a, 0xcf, 0x89, 0xc2, 0xfd, 0xf9, 0x43, 0x4c, 0x12, 0xb5, 0x8d, 0xee, 0x5f, 0xe3,
-	0xab, 0xc4, 0x77, 0xf6, 0xe5, 0x5f, 0x46, 0x6c, 0x5f, 0x99, 0x5d, 0xb5, 0x24, 0xf9, 0xed, 0xff,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0xb4, 0xb4, 0x9a, 0xc5, 0x59, 0x12, 0x00, 0x00,
+	// 1856 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5b, 0x6f, 0xdc, 0xc6,
+	0x15, 0x5e, 0x6a, 0xaf, 0x3c, 0x7b, 0xf5, 0x48, 0xb2, 0x19, 0xc5, 0x4d, 0x55, 0xa6, 0x4e, 0xdc,
+	0x2a, 0x91, 0x0c, 0xa5, 0x45, 0x03, 0xa4, 0xad, 0x21, 0x69, 0x37, 0xda, 0x45, 0xbc, 0x6b, 0x95,
+	0x92, 0x81, 0xaa, 0x2f, 0xc4, 0x68, 0x39, 0xe2, 0xb2, 0x22, 0x87, 0x14, 0x67, 0xe4, 0x48, 0x8f,
+	0x45, 0x1f, 0x0a, 0xf4, 0xad, 0x3f, 0xa1, 0x0f, 0xfd, 0x25, 0xfd, 0x63, 0xc5, 0xcc, 0xf0, 0xba,
+	0xab, 0x35, 0xe0, 0x00, 0x7d, 0xe3, 0xb9, 0xce, 0xcc, 0x99, 0x73, 0xbe, 0x73, 0x86, 0xb0, 0xe5,
+	0x86, 0x8c, 0x79, 0xd1, 0x41, 0x40, 0x18, 0xc3, 0x2e, 0xd9, 0x8f, 0xe2, 0x90, 0x87, 0xa8, 0xa1,
+	0xb8, 0xe6, 0xdf, 0x35, 0x68, 0x8d, 0xe8, 0x7b, 0xe2, 0x87, 0x11, 0x41, 0x06, 0x34, 0x23, 0xfc,
+	0xe0, 0x87, 0xd8, 0x31, 0xb4, 0x5d, 0xed, 0x65, 0xc7, 0x4a, 0x49, 0xf4, 0x1c, 0x74, 0xe6, 0xb9,
+	0x14, 0xf3, 0xbb, 0x98, 0x18, 0x1b, 0x52, 0x96, 0x33, 0xd0, 0x6b, 0xe8, 0x33, 0x32, 0x8f, 0x09,
+	0xb7, 0x49, 0xe2, 0xca, 0xa8, 0xee, 0x6a, 0x2f, 0xdb, 0x87, 0x4f, 0xf7, 0xd5, 0x32, 0xfb, 0xe7,
+	0x52, 0x9c, 0x2e, 0x64, 0xf5, 0x58, 0x89, 0x36, 0xc7, 0xd0, 0x2b, 0x6b, 0xfc, 0xd4, 0xad, 0x98,
+	0x47, 0xd0, 0x50, 0x9e, 0xd0, 0x57, 0x30, 0xf0, 0x28, 0x27, 0x31, 0xc5, 0xfe, 0x88, 0x3a, 0x51,
+	0xe8, 0x51, 0x2e, 0x5d, 0xe9, 0xe3, 0x8a, 0xb5, 0x22, 0x39, 0xd6, 0xa1, 0x39, 0x0f, 0x29, 0x27,
+	0x94, 0x9b, 0xff, 0x68, 0x43, 0xf7, 0x54, 0x6e, 0x7b, 0xaa, 0x42, 0x86, 0xb6, 0xa0, 0x4e, 0x43,
+	0x3a, 0x27, 0xd2, 0xbe, 0x66, 0x29, 0x42, 0x6c, 0x71, 0xbe, 0xc0, 0x94, 0x12, 0x3f, 0xd9, 0x46,
+	0x4a, 0xa2, 0x3d, 0xa8, 0x72, 0xec, 0xca, 0x18, 0xf4, 0x0e, 0x3f, 0x49, 0x63, 0x50, 0xf2, 0xb9,
+	0x7f, 0x81, 0x5d, 0x4b, 0x68, 0xa1, 0x6f, 0x40, 0xc7, 0xbe, 0xf7, 0x9e, 0xd8, 0x01, 0x73, 0x8d,
+	0xba, 0x0c, 0xdb, 0x56, 0x6a, 0x72, 0x24, 0x04, 0x89, 0xc5, 0xb8, 0x62, 0xb5, 0xa4, 0xe2, 0x94,
+	0xb9, 0xe8, 0x37, 0xd0, 0x0c, 0x48, 0x60, 0xc7, 0xe4, 0xd6, 0x68, 0x48, 0x93, 0x6c, 0x95, 0x29,
+	0x09, 0xae, 0x48, 0xcc, 0x16, 0x5e, 0x64, 0x91, 0xdb, 0x3b, 0xc2, 0xf8, 0xb8, 0x62, 0x35, 0x02,
+	0x12, 0x58, 0xe4, 0x16, 0xfd, 0x36, 0xb5, 0x62, 0x46, 0x53, 0x5a, 0xed, 0x3c, 0x66, 0xc5, 0xa2,
+	0x90, 0x32, 0x92, 0x99, 0x31, 0xf4, 0x0a, 0x5a, 0x0e, 0xe6, 0x58, 0x6e, 0xb0, 0x25, 0xed, 0x36,
+	0x53, 0xbb, 0x21, 0xe6, 0x38, 0xdf, 0x5f, 0x53, 0xa8, 0x89, 0xed, 0xed, 0x41, 0x7d, 0x41, 0x7c,
+	0x3f, 0x34, 0xf4, 0xb2, 0xba, 0x0a, 0xc1, 0x58, 0x88, 0xc6, 0x15, 0x4b, 0xe9, 0xa0, 0x83, 0xc4,
+	0xbd, 0xe3, 0xb9, 0x06, 0x48, 0x7d, 0x54, 0x74, 0x3f, 0xf4, 0x5c, 0x75, 0x0a, 0xe9, 0x7d, 0xe8,
+	0xb9, 0xd9, 0x7e, 0xc4, 0xe9, 0xdb, 0xab, 0xfb, 0xc9, 0xcf, 0x2d, 0x2d, 0xd4, 0xc1, 0xdb, 0xd2,
+	0xe2, 0x2e, 0x72, 0x30, 0x27, 0x46, 0x67, 0x75, 0x95, 0x77, 0x52, 0x32, 0xae, 0x58, 0xe0, 0x64,
+	0x14, 0x7a, 0x01, 0x75, 0x12, 0x44, 0xfc, 0xc1, 0xe8, 0x4a, 0x83, 0x6e, 0x6a, 0x30, 0x12, 0x4c,
+	0x71, 0x00, 0x29, 0x45, 0x7b, 0x50, 0x9b, 0x87, 0x94, 0x1a, 0x3d, 0xa9, 0xb5, 0x9d, 0x6a, 0x9d,
+	0x84, 0x94, 0x8e, 0x18, 0xc7, 0x57, 0xbe, 0xc7, 0x16, 0xe3, 0x8a, 0x25, 0x95, 0xd0, 0x21, 0x00,
+	0xe3, 0x98, 0x13, 0xdb, 0xa3, 0xd7, 0xa1, 0xd1, 0x97, 0x26, 0x4f, 0xb2, 0x32, 0x11, 0x92, 0x09,
+	0xbd, 0x16, 0xd1, 0xd1, 0x59, 0x4a, 0xa0, 0x63, 0xe8, 0x29, 0x1b, 0x46, 0x71, 0xc4, 0x16, 0x21,
+	0x37, 0x06, 0xe5, 0x4b, 0xcf, 0xec, 0xce, 0x13, 0x85, 0x71, 0xc5, 0xea, 0x4a, 0x93, 0x94, 0x81,
+	0xa6, 0xb0, 0x99, 0xaf, 0x6b, 0x47, 0x77, 0xbe, 0x2f, 0xe3, 0xf7, 0x44, 0x3a, 0x7a, 0xbe, 0xe2,
+	0xe8, 0xec, 0xce, 0xf7, 0xf3, 0x40, 0x0e, 0xd8, 0x12, 0x1f, 0x1d, 0x81, 0xf2, 0x6f, 0xc7, 0x4a,
+	0xc9, 0x40, 0xe5, 0x84, 0xb2, 0x48, 0x10, 0x72, 0x22, 0xdd, 0xe5, 0x6e, 0x3a, 0xac, 0x40, 0xa3,
+	0x61, 0x7a, 0xaa, 0x38, 0x49, 0x39, 0x63, 0x53, 0xfa, 0xf8, 0xf4, 0x51, 0x1f, 0x59, 0x56, 0x76,
+	0x59, 0x91, 0x21, 0x62, 0xe3, 0x13, 0xec, 0xa8, 0xe4, 0x95, 0x29, 0xba, 0x55, 0x8e, 0xcd, 0x9b,
+	0x4c, 0x9a, 0x27, 0x6a, 0x37, 0x37, 0x11, 0xe9, 0xfa, 0x1d, 0x74, 0x23, 0x42, 0x62, 0xdb, 0x73,
+	0x08, 0xe5, 0x1e, 0x7f, 0x30, 0xb6, 0xcb, 0x65, 0x78, 0x46, 0x48, 0x3c, 0x49, 0x64, 0xe2, 0x18,
+	0x51, 0x81, 0x16, 0xc5, 0x8e, 0xe7, 0x37, 0xc6, 0x53, 0x69, 0xf2, 0x2c, 0xab, 0xdc, 0xf9, 0x0d,
+	0x0d, 0x7f, 0xf4, 0x89, 0xe3, 0x92, 0x80, 0x50, 0x71, 0x78, 0xa1, 0x85, 0xfe, 0x08, 0x10, 0xc5,
+	0xde, 0x7b, 0x15, 0x05, 0xe3, 0x59, 0x39, 0xf8, 0xea, 0xbc, 0x67, 0xef, 0x79, 0x39, 0x8b, 0x0b,
+	0x16, 0xe8, 0x75, 0xc1, 0x9e, 0x19, 0x86, 0xb4, 0xff, 0xd9, 0x1a, 0xfb, 0x2c, 0x62, 0x05, 0x13,
+	0xf4, 0x1a, 0x3a, 0x09, 0x65, 0x8b, 0x44, 0x37, 0x3e, 0x29, 0x5f, 0xdb, 0x99, 0x92, 0x95, 0xcb,
+	0xba, 0x1d, 0xe5, 0x5c, 0xd3, 0x86, 0xea, 0x05, 0x76, 0x51, 0x17, 0xf4, 0x77, 0xb3, 0xe1, 0xe8,
+	0xfb, 0xc9, 0x6c, 0x34, 0x1c, 0x54, 0x90, 0x0e, 0xf5, 0xd1, 0xf4, 0xec, 0xe2, 0x72, 0xa0, 0xa1,
+	0x0e, 0xb4, 0xde, 0x5a, 0xa7, 0xf6, 0xdb, 0xd9, 0x9b, 0xcb, 0xc1, 0x86, 0xd0, 0x3b, 0x19, 0x1f,
+	0xcd, 0x14, 0x59, 0x45, 0x03, 0xe8, 0x48, 0xf2, 0x68, 0x36, 0xb4, 0xdf, 0x5a, 0xa7, 0x83, 0x1a,
+	0xea, 0x43, 0x5b, 0x29, 0x58, 0x92, 0x51, 0x2f, 0x22, 0xf1, 0x7f, 0x35, 0xd0, 0xb3, 0x8c, 0x44,
+	0x3b, 0xd0, 0x0a, 0x08, 0xc7, 0x72, 0xdb, 0xaa, 0x27, 0x64, 0x34, 0xda, 0x07, 0x9d, 0x7b, 0x01,
+	0x61, 0x1c, 0x07, 0x91, 0x44, 0xe3, 0xf6, 0xe1, 0xa0, 0x78, 0x7b, 0x17, 0x5e, 0x40, 0xac, 0x5c,
+	0x05, 0x6d, 0x43, 0x23, 0xba, 0xf1, 0x6c, 0xcf, 0x91, 0x20, 0xdd, 0xb1, 0xea, 0xd1, 0x8d, 0x37,
+	0x71, 0xd0, 0xcf, 0xa1, 0x9d, 0x60, 0xb8, 0x3d, 0x3d, 0x3a, 0x31, 0x6a, 0x52, 0x06, 0x09, 0x6b,
+	0x7a, 0x74, 0x22, 0xaa, 0x37, 0x8a, 0xc3, 0x88, 0xc4, 0xdc, 0x23, 0xcc, 0xa8, 0x97, 0x71, 0xe4,
+	0x2c, 0x93, 0x58, 0x05, 0x2d, 0xf3, 0x02, 0x20, 0x97, 0xa0, 0xcf, 0xa1, 0x2b, 0xb3, 0x22, 0xb6,
+	0x17, 0xc4, 0x73, 0x17, 0x3c, 0xe9, 0x29, 0x1d, 0xc5, 0x1c, 0x4b, 0x1e, 0xfa, 0x05, 0x74, 0x7c,
+	0x72, 0xcd, 0xed, 0x62, 0x7f, 0x69, 0x59, 0x6d, 0xc1, 0x3b, 0x51, 0x2c, 0xf3, 0x08, 0x9e, 0xac,
+	0x54, 0x3d, 0xfa, 0x0a, 0x5a, 0xc4, 0x97, 0x09, 0xc7, 0x0c, 0x6d, 0xb7, 0x5a, 0x8c, 0x42, 0xd6,
+	0x7b, 0x33, 0x0d, 0xf3, 0x77, 0xb0, 0xf5, 0x58, 0xbd, 0x2f, 0x47, 0x41, 0x5b, 0x8e, 0x82, 0x79,
+	0x0d, 0xdd, 0x12, 0xb8, 0x15, 0xc2, 0xa9, 0x15, 0xc3, 0xb9, 0x03, 0xad, 0xac, 0xa4, 0x54, 0x8b,
+	0xcc, 0x68, 0x64, 0x42, 0x97, 0xfb, 0xcc, 0x9e, 0x93, 0x98, 0xdb, 0x0b, 0xcc, 0x16, 0xc9, 0x45,
+	0xb4, 0xb9, 0xcf, 0x4e, 0x48, 0xcc, 0xc7, 0x98, 0x2d, 0xcc, 0x77, 0xd0, 0x29, 0x96, 0xde, 0xba,
+	0x65, 0x10, 0xd4, 0x84, 0x9b, 0x64, 0x09, 0xf9, 0x5d, 0x4a, 0x96, 0x6a, 0x39, 0x59, 0xcc, 0x00,
+	0xda, 0x85, 0x0a, 0x5b, 0xdf, 0xdd, 0x1d, 0xd9, 0x79, 0x98, 0xb1, 0xb1, 0x5b, 0x7d, 0xa9, 0x5b,
+	0x29, 0x89, 0xf6, 0xa1, 0x15, 0x30, 0xd7, 0xe6, 0x0f, 0xc9, 0x98, 0xd3, 0xcb, 0xdb, 0x8f, 0x88,
+	0xe2, 0x94, 0xb9, 0x17, 0x0f, 0x11, 0xb1, 0x9a, 0x81, 0xfa, 0x30, 0x43, 0x68, 0x17, 0xfa, 0xde,
+	0x9a, 0xe5, 0x8a, 0xfb, 0xdd, 0x58, 0x49, 0xee, 0x8f, 0x5b, 0xf0, 0x1e, 0x20, 0x6f, 0x69, 0x6b,
+	0xd6, 0xfb, 0x25, 0xd4, 0x92, 0xb5, 0x1e, 0xcf, 0x92, 0xda, 0x4f, 0x5a, 0xd9, 0x07, 0xc8, 0x5b,
+	0xf6, 0xff, 0x3d, 0xb0, 0xdf, 0x42, 0xbb, 0x00, 0x54, 0xe8, 0x57, 0xe5, 0x91, 0xb1, 0x7d, 0xd8,
+	0xcf, 0xac, 0x15, 0x3b, 0x9b, 0x21, 0xcd, 0xef, 0x01, 0xad, 0x22, 0x1d, 0x7a, 0xb5, 0xec, 0xe0,
+	0xe9, 0x12, 0x2c, 0xae, 0xf8, 0xb9, 0x84, 0x66, 0xc2, 0x43, 0xcf, 0xa0, 0xc9, 0xc8, 0xad, 0x4d,
+	0xef, 0x82, 0xe4, 0xb8, 0x0d, 0x46, 0x6e, 0x67, 0x77, 0x81, 0xc8, 0xce, 0xc2, 0xad, 0xca, 0x6f,
+	0x51, 0xdf, 0x25, 0x14, 0xae, 0xee, 0x56, 0x45, 0xee, 0x17, 0x71, 0xf6, 0x5f, 0x1a, 0xf4, 0xca,
+	0xcb, 0xa2, 0x2f, 0xa1, 0x3f, 0x0f, 0x7d, 0x9f, 0xcc, 0xb9, 0x17, 0x52, 0x9b, 0xe2, 0x40, 0x45,
+	0x56, 0xb7, 0x7a, 0x39, 0x7b, 0x86, 0x03, 0x22, 0x46, 0x64, 0x21, 0x65, 0x11, 0x9e, 0xab, 0x11,
+	0x59, 0xb7, 0x72, 0x06, 0xda, 0x84, 0x3a, 0xbf, 0x4f, 0xa1, 0x4f, 0xb7, 0x6a, 0xfc, 0x7e, 0xe2,
+	0x08, 0x58, 0x4a, 0x77, 0x14, 0xff, 0xc8, 0x08, 0x4f, 0xb0, 0x2f, 0xdd, 0xa6, 0x25, 0x78, 0xe6,
+	0x3f, 0x35, 0xe8, 0x14, 0x47, 0x52, 0xb4, 0x0f, 0x10, 0x64, 0x93, 0x63, 0x12, 0xb4, 0x5e, 0x79,
+	0xa6, 0xb4, 0x0a, 0x1a, 0x1f, 0x0d, 0xd3, 0x45, 0x00, 0xa9, 0x95, 0x01, 0xc4, 0xfc, 0x9b, 0x06,
+	0x4f, 0x56, 0x7a, 0xfb, 0x3a, 0x88, 0xf8, 0xd8, 0x85, 0x5f, 0x40, 0xcf, 0x63, 0xb6, 0x43, 0xe6,
+	0x3e, 0x8e, 0xb1, 0x88, 0xab, 0x0c, 0x56, 0xcb, 0xea, 0x7a, 0x6c, 0x98, 0x33, 0xcd, 0xdf, 0x43,
+	0x2b, 0xb5, 0x16, 0x09, 0xe0, 0xd1, 0x79, 0x31, 0x01, 0x3c, 0x3a, 0x17, 0x09, 0x50, 0xc8, 0x8c,
+	0x8d, 0x62, 0x66, 0x98, 0xd7, 0xf0, 0x64, 0x65, 0x5a, 0x47, 0xdf, 0xc1, 0x80, 0x11, 0xff, 0x5a,
+	0x8e, 0x69, 0x71, 0xa0, 0xd6, 0xd6, 0x76, 0xb5, 0x47, 0x8b, 0xb4, 0x2f, 0x34, 0x27, 0xb9, 0xa2,
+	0xa8, 0x38, 0x31, 0x76, 0x50, 0x59, 0x59, 0x1d, 0x4b, 0x11, 0xe6, 0x15, 0xa0, 0xd5, 0xf9, 0x1e,
+	0x7d, 0x01, 0x75, 0xf9, 0x9c, 0x58, 0xdb, 0x28, 0x94, 0x58, 0x22, 0x05, 0xc1, 0xce, 0x07, 0x90,
+	0x82, 0x60, 0xc7, 0xfc, 0x8f, 0x06, 0x0d, 0xb5, 0x88, 0xb8, 0x34, 0x52, 0x7a, 0x70, 0x59, 0x19,
+	0xfd, 0x41, 0x98, 0x5b, 0xd3, 0x93, 0xdf, 0xc0, 0x36, 0xf6, 0xe5, 0x6b, 0x8d, 0x8b, 0x57, 0x52,
+	0xea, 0x8a, 0x19, 0xb5, 0xf2, 0xc4, 0x75, 0xee, 0xb9, 0x94, 0x38, 0xe9, 0x83, 0x8e, 0x59, 0x5b,
+	0x05, 0xab, 0x8c, 0x6b, 0x36, 0xa1, 0x2e, 0xa7, 0x77, 0xf3, 0xcf, 0x80, 0x56, 0x67, 0x54, 0xd1,
+	0x95, 0x18, 0xc7, 0x31, 0xb7, 0xcb, 0xb5, 0xdc, 0x96, 0xcc, 0x73, 0x55, 0xd0, 0x9f, 0x41, 0x9b,
+	0x50, 0xc7, 0x2e, 0xdf, 0xa9, 0x4e, 0xa8, 0xa3, 0xe4, 0xe6, 0x31, 0x6c, 0x3e, 0x32, 0xb9, 0xa2,
+	0x3d, 0x68, 0x25, 0xb0, 0x91, 0xf6, 0xe6, 0x15, 0x7c, 0xca, 0x14, 0xcc, 0x53, 0xd8, 0x7a, 0x6c,
+	0x1a, 0x44, 0x07, 0x39, 0x78, 0x2a, 0x1f, 0xd9, 0x6b, 0x23, 0x51, 0x54, 0xd0, 0x9b, 0x61, 0xaa,
+	0xf9, 0x6f, 0x0d, 0xba, 0x25, 0x51, 0x5e, 0xfe, 0x5a, 0xa1, 0xfc, 0x3f, 0x8c, 0x18, 0x9f, 0x01,
+	0xe4, 0x08, 0x93, 0xc0, 0x46, 0x81, 0x83, 0x3e, 0x05, 0xfd, 0xca, 0x0f, 0xe7, 0x37, 0x22, 0x26,
+	0xf2, 0x5a, 0x6a, 0x56, 0x4b, 0x32, 0xce, 0xc9, 0x2d, 0xda, 0x85, 0x8e, 0x08, 0x95, 0x47, 0x6d,
+	0xc9, 0x92, 0x43, 0x53, 0xcd, 0x02, 0x46, 0x6e, 0x27, 0xf4, 0x58, 0x70, 0xcc, 0x1f, 0x60, 0xfb,
+	0xd1, 0xd1, 0x15, 0x1d, 0xae, 0x8c, 0x33, 0x4f, 0x97, 0x8e, 0x3b, 0x52, 0xe2, 0xc2, 0x50, 0x73,
+	0x09, 0xbd, 0xb2, 0x0c, 0x7d, 0x0d, 0x0d, 0x15, 0x8d, 0xa4, 0x8e, 0xd6, 0x84, 0x2c, 0x51, 0x2a,
+	0xfe, 0x79, 0x50, 0x55, 0x94, 0x92, 0xe6, 0x9f, 0x32, 0xd7, 0x29, 0x22, 0xbf, 0x80, 0x3e, 0xbf,
+	0xb7, 0x4b, 0xc7, 0x4b, 0xc6, 0x39, 0x7e, 0x7f, 0x9e, 0x1d, 0xb0, 0xec, 0xb2, 0xf8, 0x33, 0xc3,
+	0xfc, 0x12, 0xfa, 0x4b, 0x2f, 0x05, 0x51, 0xc3, 0x24, 0x8e, 0xc3, 0x38, 0xb9, 0x1f, 0x45, 0x98,
+	0x11, 0xf4, 0x97, 0x12, 0xfc, 0x03, 0xbf, 0x48, 0x5e, 0x40, 0x0f, 0x3b, 0x81, 0x47, 0xed, 0xa5,
+	0xe9, 0xab, 0x2b, 0xb9, 0xd9, 0x38, 0x55, 0xfa, 0x93, 0x52, 0x5d, 0xfe, 0x93, 0x32, 0x03, 0x3d,
+	0x5f, 0xeb, 0xf3, 0xe5, 0x17, 0x92, 0x96, 0xb4, 0x87, 0xe2, 0x78, 0xf6, 0x1c, 0xf4, 0xbc, 0x3a,
+	0x55, 0x6f, 0xcf, 0x19, 0xbf, 0xfe, 0x03, 0xb4, 0x0b, 0x5d, 0x7c, 0xf9, 0x01, 0xd1, 0x05, 0xfd,
+	0xf8, 0xcd, 0xdb, 0x93, 0x1f, 0xec, 0xe9, 0xf9, 0xe9, 0x40, 0x13, 0xef, 0x84, 0xc9, 0x70, 0x34,
+	0xbb, 0x98, 0x5c, 0x5c, 0x4a, 0xce, 0xc6, 0xe1, 0x5f, 0xa1, 0xa1, 0xa6, 0x28, 0xf4, 0x2d, 0x74,
+	0xd4, 0xd7, 0x39, 0x8f, 0x09, 0x0e, 0xd0, 0x0a, 0x24, 0xed, 0xac, 0x70, 0xcc, 0xca, 0x4b, 0xed,
+	0x95, 0x86, 0xbe, 0x80, 0xda, 0x99, 0x47, 0x5d, 0x54, 0x7e, 0xc8, 0xef, 0x94, 0x49, 0xb3, 0x72,
+	0xfc, 0xf5, 0x5f, 0xf6, 0x5c, 0x8f, 0x2f, 0xee, 0xae, 0xf6, 0xe7, 0x61, 0x70, 0xb0, 0x78, 0x88,
+	0x48, 0xac, 0xc6, 0xf3, 0x83, 0x6b, 0x7c, 0x15, 0x7b, 0xf3, 0x03, 0xf9, 0x0f, 0x8d, 0x1d, 0x28,
+	0xb3, 0xab, 0x86, 0x24, 0xbf, 0xf9, 0xdf, 0x00, 0xaa, 0xef, 0xce, 0xa0, 0x6a, 0x13, 0x00, 0x00,
 }
@@ -23,6 +23,10 @@ It has these top-level messages:
 	ServerStatus
 	LogLevelRequest
 	LogLevelResponse
+	LeadershipRequest
+	GossipMembershipRequest
+	GossipMember
+	GossipMembership
 	ChaincodeID
 	ChaincodeInput
 	ChaincodeSpec
@@ -77,6 +81,8 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import google_protobuf "github.com/golang/protobuf/ptypes/empty"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/timestamp"
+import common "github.com/hyperledger/fabric/protos/common"
 
 import (
 	context "golang.org/x/net/context"
@@ -191,10 +197,121 @@ func (m *LogLevelResponse) GetLogLevel() string {
 	return ""
 }
 
+// LeadershipRequest is sent in the payload of a signed envelope of type MESSAGE,
+// whose channel header names the same channel
+type LeadershipRequest struct {
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+}
+
+func (m *LeadershipRequest) Reset()                    { *m = LeadershipRequest{} }
+func (m *LeadershipRequest) String() string            { return proto.CompactTextString(m) }
+func (*LeadershipRequest) ProtoMessage()               {}
+func (*LeadershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *LeadershipRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+type GossipMembershipRequest struct {
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+}
+
+func (m *GossipMembershipRequest) Reset()                    { *m = GossipMembershipRequest{} }
+func (m *GossipMembershipRequest) String() string            { return proto.CompactTextString(m) }
+func (*GossipMembershipRequest) ProtoMessage()               {}
+func (*GossipMembershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *GossipMembershipRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+// GossipMember is a member of the gossip membership view of a peer
+type GossipMember struct {
+	PkiId            []byte                      `protobuf:"bytes,1,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
+	Endpoint         string                      `protobuf:"bytes,2,opt,name=endpoint" json:"endpoint,omitempty"`
+	InternalEndpoint string                      `protobuf:"bytes,3,opt,name=internal_endpoint,json=internalEndpoint" json:"internal_endpoint,omitempty"`
+	Alive            bool                        `protobuf:"varint,4,opt,name=alive" json:"alive,omitempty"`
+	LastAlive        *google_protobuf1.Timestamp `protobuf:"bytes,5,opt,name=last_alive,json=lastAlive" json:"last_alive,omitempty"`
+	LedgerHeight     uint64                      `protobuf:"varint,6,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
+}
+
+func (m *GossipMember) Reset()                    { *m = GossipMember{} }
+func (m *GossipMember) String() string            { return proto.CompactTextString(m) }
+func (*GossipMember) ProtoMessage()               {}
+func (*GossipMember) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *GossipMember) GetPkiId() []byte {
+	if m != nil {
+		return m.PkiId
+	}
+	return nil
+}
+
+func (m *GossipMember) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *GossipMember) GetInternalEndpoint() string {
+	if m != nil {
+		return m.InternalEndpoint
+	}
+	return ""
+}
+
+func (m *GossipMember) GetAlive() bool {
+	if m != nil {
+		return m.Alive
+	}
+	return false
+}
+
+func (m *GossipMember) GetLastAlive() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.LastAlive
+	}
+	return nil
+}
+
+func (m *GossipMember) GetLedgerHeight() uint64 {
+	if m != nil {
+		return m.LedgerHeight
+	}
+	return 0
+}
+
+type GossipMembership struct {
+	Members []*GossipMember `protobuf:"bytes,1,rep,name=members" json:"members,omitempty"`
+}
+
+func (m *GossipMembership) Reset()                    { *m = GossipMembership{} }
+func (m *GossipMembership) String() string            { return proto.CompactTextString(m) }
+func (*GossipMembership) ProtoMessage()               {}
+func (*GossipMembership) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *GossipMembership) GetMembers() []*GossipMember {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ServerStatus)(nil), "protos.ServerStatus")
 	proto.RegisterType((*LogLevelRequest)(nil), "protos.LogLevelRequest")
 	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
+	proto.RegisterType((*LeadershipRequest)(nil), "protos.LeadershipRequest")
+	proto.RegisterType((*GossipMembershipRequest)(nil), "protos.GossipMembershipRequest")
+	proto.RegisterType((*GossipMember)(nil), "protos.GossipMember")
+	proto.RegisterType((*GossipMembership)(nil), "protos.GossipMembership")
 	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
 }
 
@@ -215,6 +332,11 @@ type AdminClient interface {
 	GetModuleLogLevel(ctx context.Context, in *LogLevelRequest, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	SetModuleLogLevel(ctx context.Context, in *LogLevelRequest, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	RevertLogLevels(ctx context.Context, in *google_protobuf.Empty, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// Make the peer yield its leadership of a channel to another peer of its organization.
+	// The envelope carries a LeadershipRequest and must be signed by an admin of the peer's local MSP.
+	YieldLeadership(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// Return the gossip membership view of the peer in a channel.
+	GetGossipMembership(ctx context.Context, in *GossipMembershipRequest, opts ...grpc.CallOption) (*GossipMembership, error)
 }
 
 type adminClient struct {
@@ -270,6 +392,24 @@ func (c *adminClient) RevertLogLevels(ctx context.Context, in *google_protobuf.E
 	return out, nil
 }
 
+func (c *adminClient) YieldLeadership(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/protos.Admin/YieldLeadership", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetGossipMembership(ctx context.Context, in *GossipMembershipRequest, opts ...grpc.CallOption) (*GossipMembership, error) {
+	out := new(GossipMembership)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetGossipMembership", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Admin service
 
 type AdminServer interface {
@@ -279,6 +419,11 @@ type AdminServer interface {
 	GetModuleLogLevel(context.Context, *LogLevelRequest) (*LogLevelResponse, error)
 	SetModuleLogLevel(context.Context, *LogLevelRequest) (*LogLevelResponse, error)
 	RevertLogLevels(context.Context, *google_protobuf.Empty) (*google_protobuf.Empty, error)
+	// Make the peer yield its leadership of a channel to another peer of its organization.
+	// The envelope carries a LeadershipRequest and must be signed by an admin of the peer's local MSP.
+	YieldLeadership(context.Context, *common.Envelope) (*google_protobuf.Empty, error)
+	// Return the gossip membership view of the peer in a channel.
+	GetGossipMembership(context.Context, *GossipMembershipRequest) (*GossipMembership, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -375,6 +520,42 @@ func _Admin_RevertLogLevels_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_YieldLeadership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).YieldLeadership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/YieldLeadership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).YieldLeadership(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetGossipMembership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GossipMembershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetGossipMembership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetGossipMembership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetGossipMembership(ctx, req.(*GossipMembershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -399,6 +580,14 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RevertLogLevels",
 			Handler:    _Admin_RevertLogLevels_Handler,
 		},
+		{
+			MethodName: "YieldLeadership",
+			Handler:    _Admin_YieldLeadership_Handler,
+		},
+		{
+			MethodName: "GetGossipMembership",
+			Handler:    _Admin_GetGossipMembership_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "peer/admin.proto",
@@ -407,32 +596,46 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 418 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xc7, 0x9b, 0x42, 0x0b, 0x79, 0x1b, 0xcc, 0x58, 0x08, 0xaa, 0x4e, 0x08, 0x94, 0x13, 0x5c,
-	0x1c, 0x69, 0x1c, 0x38, 0x20, 0x0e, 0xdd, 0x12, 0x06, 0x62, 0x4b, 0x23, 0x67, 0x15, 0x02, 0x09,
-	0x4d, 0x49, 0xf3, 0xe6, 0x55, 0x38, 0x73, 0xb0, 0x9d, 0x4a, 0xfb, 0x3a, 0x7c, 0x2e, 0x3e, 0x0c,
-	0x4a, 0xdc, 0x68, 0x13, 0xb0, 0x03, 0x82, 0x93, 0xf3, 0xde, 0xfb, 0xff, 0xff, 0x71, 0x7e, 0xb1,
-	0x81, 0xd4, 0x88, 0x3a, 0xcc, 0xcb, 0x6a, 0x75, 0xc1, 0x6a, 0xad, 0xac, 0xa2, 0xe3, 0x6e, 0x31,
-	0xd3, 0x5d, 0xa1, 0x94, 0x90, 0x18, 0x76, 0x65, 0xd1, 0x9c, 0x85, 0x58, 0xd5, 0xf6, 0xd2, 0x89,
-	0x82, 0xef, 0x1e, 0x6c, 0x67, 0xa8, 0xd7, 0xa8, 0x33, 0x9b, 0xdb, 0xc6, 0xd0, 0x57, 0x30, 0x36,
-	0xdd, 0xd3, 0xc4, 0x7b, 0xe6, 0x3d, 0xbf, 0xbf, 0xf7, 0xd4, 0x09, 0x0d, 0xbb, 0xae, 0x62, 0x6e,
-	0x39, 0x50, 0x25, 0xf2, 0x8d, 0x3c, 0xf8, 0x04, 0x70, 0xd5, 0xa5, 0xf7, 0xc0, 0x5f, 0x24, 0x51,
-	0xfc, 0xf6, 0x7d, 0x12, 0x47, 0x64, 0x40, 0xb7, 0xe0, 0x4e, 0x76, 0x32, 0xe3, 0x27, 0x71, 0x44,
-	0x3c, 0x57, 0xcc, 0xd3, 0x34, 0x8e, 0xc8, 0x90, 0x02, 0x8c, 0xd3, 0xd9, 0x22, 0x8b, 0x23, 0x72,
-	0x8b, 0xfa, 0x30, 0x8a, 0x39, 0x9f, 0x73, 0x72, 0xbb, 0xd5, 0x2c, 0x92, 0x0f, 0xc9, 0xfc, 0x63,
-	0x42, 0x46, 0xc1, 0x31, 0xec, 0x1c, 0x29, 0x71, 0x84, 0x6b, 0x94, 0x1c, 0xbf, 0x35, 0x68, 0x2c,
-	0x7d, 0x02, 0x20, 0x95, 0x38, 0xad, 0x54, 0xd9, 0x48, 0xec, 0xb6, 0xea, 0x73, 0x5f, 0x2a, 0x71,
-	0xdc, 0x35, 0xe8, 0x2e, 0xb4, 0xc5, 0xa9, 0x6c, 0x2d, 0x93, 0x61, 0x37, 0xbd, 0x2b, 0x37, 0x11,
-	0x41, 0x02, 0xe4, 0x2a, 0xce, 0xd4, 0xea, 0xc2, 0xe0, 0xbf, 0xe4, 0xed, 0xfd, 0x18, 0xc2, 0x68,
-	0xd6, 0x82, 0xa7, 0xaf, 0xc1, 0x3f, 0x44, 0xbb, 0x21, 0xf9, 0x88, 0x39, 0xf0, 0xac, 0x07, 0xcf,
-	0xe2, 0x16, 0xfc, 0xf4, 0xe1, 0x9f, 0x88, 0x06, 0x03, 0xfa, 0x06, 0xb6, 0x32, 0x9b, 0x6b, 0xeb,
-	0xda, 0x7f, 0x6d, 0x7f, 0x07, 0x0f, 0x0e, 0xd1, 0xba, 0xfd, 0xf6, 0x9f, 0x47, 0x1f, 0xf7, 0xe2,
-	0x5f, 0xf8, 0x4d, 0x27, 0xbf, 0x0f, 0x1c, 0x09, 0x97, 0x94, 0xfd, 0x9f, 0xa4, 0x03, 0xd8, 0xe1,
-	0xb8, 0x46, 0x6d, 0xfb, 0xd9, 0xcd, 0x54, 0x6e, 0xe8, 0x07, 0x83, 0xfd, 0x2f, 0x10, 0x28, 0x2d,
-	0xd8, 0xf9, 0x65, 0x8d, 0x5a, 0x62, 0x29, 0x50, 0xb3, 0xb3, 0xbc, 0xd0, 0xab, 0x65, 0xff, 0xe2,
-	0xf6, 0xe4, 0xef, 0x6f, 0x77, 0x7f, 0x20, 0xcd, 0x97, 0x5f, 0x73, 0x81, 0x9f, 0x5f, 0x88, 0x95,
-	0x3d, 0x6f, 0x0a, 0xb6, 0x54, 0x55, 0x78, 0xcd, 0x18, 0x3a, 0xa3, 0xbb, 0x0a, 0x26, 0x6c, 0x8d,
-	0x85, 0xbb, 0x26, 0x2f, 0x7f, 0x06, 0x00, 0x00, 0xff, 0xff, 0x6e, 0xe6, 0xef, 0xb7, 0x41, 0x03,
-	0x00, 0x00,
+	// 654 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xdf, 0x4e, 0xdb, 0x48,
+	0x14, 0xc6, 0x31, 0x21, 0x81, 0x9c, 0x84, 0xc5, 0x0c, 0xec, 0x12, 0x19, 0xad, 0x88, 0xbc, 0x37,
+	0x59, 0x55, 0xb2, 0xa5, 0xf4, 0xa2, 0xad, 0xaa, 0x5e, 0x04, 0xe2, 0x06, 0x54, 0x08, 0xd1, 0x04,
+	0x54, 0x51, 0xa9, 0x8a, 0x9c, 0xf8, 0xe0, 0x8c, 0x18, 0x7b, 0x5c, 0x7b, 0x12, 0x89, 0xd7, 0xe9,
+	0xb3, 0xf5, 0xbe, 0xaf, 0x50, 0xd9, 0x93, 0x29, 0x11, 0x7f, 0x2a, 0xa1, 0xf6, 0x6a, 0x7c, 0xbe,
+	0xf3, 0xfb, 0x8e, 0x46, 0xe3, 0x6f, 0x06, 0xcc, 0x04, 0x31, 0x75, 0xfd, 0x20, 0x62, 0xb1, 0x93,
+	0xa4, 0x42, 0x0a, 0x52, 0x29, 0x96, 0xcc, 0xda, 0x0f, 0x85, 0x08, 0x39, 0xba, 0x45, 0x39, 0x9e,
+	0x5d, 0xbb, 0x18, 0x25, 0xf2, 0x56, 0x41, 0xd6, 0xc1, 0xfd, 0xa6, 0x64, 0x11, 0x66, 0xd2, 0x8f,
+	0x92, 0x05, 0xb0, 0x33, 0x11, 0x51, 0x24, 0x62, 0x57, 0x2d, 0x4a, 0xb4, 0xbf, 0x1a, 0x50, 0x1f,
+	0x62, 0x3a, 0xc7, 0x74, 0x28, 0x7d, 0x39, 0xcb, 0xc8, 0x2b, 0xa8, 0x64, 0xc5, 0x57, 0xc3, 0x68,
+	0x1a, 0xad, 0xbf, 0xda, 0x07, 0x0a, 0xcc, 0x9c, 0x65, 0xca, 0x51, 0xcb, 0x91, 0x08, 0x90, 0x2e,
+	0x70, 0xfb, 0x0a, 0xe0, 0x4e, 0x25, 0x9b, 0x50, 0xbd, 0xec, 0x77, 0xbd, 0xf7, 0x27, 0x7d, 0xaf,
+	0x6b, 0xae, 0x90, 0x1a, 0xac, 0x0f, 0x2f, 0x3a, 0xf4, 0xc2, 0xeb, 0x9a, 0x86, 0x2a, 0xce, 0x07,
+	0x03, 0xaf, 0x6b, 0xae, 0x12, 0x80, 0xca, 0xa0, 0x73, 0x39, 0xf4, 0xba, 0x66, 0x89, 0x54, 0xa1,
+	0xec, 0x51, 0x7a, 0x4e, 0xcd, 0xb5, 0x9c, 0xb9, 0xec, 0x7f, 0xe8, 0x9f, 0x7f, 0xec, 0x9b, 0x65,
+	0xfb, 0x0c, 0xb6, 0x4e, 0x45, 0x78, 0x8a, 0x73, 0xe4, 0x14, 0xbf, 0xcc, 0x30, 0x93, 0xe4, 0x5f,
+	0x00, 0x2e, 0xc2, 0x51, 0x24, 0x82, 0x19, 0xc7, 0x62, 0xab, 0x55, 0x5a, 0xe5, 0x22, 0x3c, 0x2b,
+	0x04, 0xb2, 0x0f, 0x79, 0x31, 0xe2, 0xb9, 0xa5, 0xb1, 0x5a, 0x74, 0x37, 0xf8, 0x62, 0x84, 0xdd,
+	0x07, 0xf3, 0x6e, 0x5c, 0x96, 0x88, 0x38, 0xc3, 0xdf, 0x9a, 0xd7, 0x86, 0xed, 0x53, 0xf4, 0x03,
+	0x4c, 0xb3, 0x29, 0x4b, 0x96, 0x36, 0x38, 0x99, 0xfa, 0x71, 0x8c, 0x7c, 0xc4, 0x02, 0x3d, 0x70,
+	0xa1, 0x9c, 0x04, 0xf6, 0x6b, 0xd8, 0xeb, 0x89, 0x2c, 0x63, 0xc9, 0x19, 0x46, 0xe3, 0x67, 0x39,
+	0xbf, 0x19, 0x50, 0x5f, 0xb6, 0x92, 0xbf, 0xa1, 0x92, 0xdc, 0x30, 0xcd, 0xd6, 0x69, 0x39, 0xb9,
+	0x61, 0x27, 0x01, 0xb1, 0x60, 0x03, 0xe3, 0x20, 0x11, 0x2c, 0x96, 0x7a, 0xc7, 0xba, 0x26, 0x2f,
+	0x60, 0x9b, 0xc5, 0x12, 0xd3, 0xd8, 0xe7, 0xa3, 0x9f, 0x50, 0xa9, 0x80, 0x4c, 0xdd, 0xf0, 0x34,
+	0xbc, 0x0b, 0x65, 0x9f, 0xb3, 0x39, 0x36, 0xd6, 0x9a, 0x46, 0x6b, 0x83, 0xaa, 0x82, 0xbc, 0x01,
+	0xe0, 0x7e, 0x26, 0x47, 0xaa, 0x55, 0x6e, 0x1a, 0xad, 0x5a, 0xdb, 0x72, 0x54, 0x06, 0x1d, 0x9d,
+	0x41, 0xe7, 0x42, 0x67, 0x90, 0x56, 0x73, 0xba, 0x53, 0x58, 0xff, 0x83, 0x4d, 0x8e, 0x41, 0x88,
+	0xe9, 0x68, 0x8a, 0x2c, 0x9c, 0xca, 0x46, 0xa5, 0x69, 0xb4, 0xd6, 0x68, 0x5d, 0x89, 0xc7, 0x85,
+	0x66, 0x1f, 0x82, 0x79, 0xff, 0x80, 0x88, 0x03, 0xeb, 0x91, 0xaa, 0x1a, 0x46, 0xb3, 0xd4, 0xaa,
+	0xb5, 0x77, 0x75, 0x38, 0x97, 0x51, 0xaa, 0xa1, 0xf6, 0xf7, 0x12, 0x94, 0x3b, 0xf9, 0x3d, 0x22,
+	0x6f, 0xa1, 0xda, 0x43, 0xb9, 0x88, 0xf8, 0x3f, 0x0f, 0xb6, 0xe9, 0xe5, 0xf7, 0xc8, 0xda, 0x7d,
+	0x2c, 0xea, 0xf6, 0x0a, 0x79, 0x07, 0xb5, 0xa1, 0xf4, 0x53, 0xa9, 0xe4, 0x67, 0xdb, 0x8f, 0x61,
+	0xbb, 0x87, 0x52, 0x05, 0x49, 0xe7, 0x8e, 0xec, 0x69, 0xf8, 0x5e, 0xb0, 0xad, 0xc6, 0xc3, 0x86,
+	0x8a, 0xa8, 0x9a, 0x34, 0xfc, 0x33, 0x93, 0x8e, 0x60, 0x8b, 0xe2, 0x1c, 0x53, 0xa9, 0x7b, 0x4f,
+	0x9f, 0xca, 0x13, 0x7a, 0x71, 0x2e, 0x5b, 0x57, 0x0c, 0x79, 0x70, 0x17, 0x7e, 0x62, 0x3a, 0x8b,
+	0xd7, 0xc5, 0x8b, 0xe7, 0xc8, 0x45, 0x82, 0xbf, 0xb0, 0x53, 0xd8, 0xe9, 0xa1, 0x7c, 0xf0, 0x93,
+	0x0f, 0x1e, 0xfb, 0xa7, 0x4b, 0xf7, 0xc3, 0x6a, 0x3c, 0x05, 0xd8, 0x2b, 0x87, 0x9f, 0xc1, 0x16,
+	0x69, 0xe8, 0x4c, 0x6f, 0x13, 0x4c, 0x55, 0x9c, 0x9c, 0x6b, 0x7f, 0x9c, 0xb2, 0x89, 0xf6, 0x24,
+	0x88, 0xe9, 0x61, 0xbd, 0x08, 0xc5, 0xc0, 0x9f, 0xdc, 0xf8, 0x21, 0x7e, 0xfa, 0x3f, 0x64, 0x72,
+	0x3a, 0x1b, 0xe7, 0x3b, 0x77, 0x97, 0x8c, 0xae, 0x32, 0xaa, 0xf7, 0x34, 0x73, 0x73, 0xe3, 0x58,
+	0x3d, 0xc4, 0x2f, 0x7f, 0x0c, 0x00, 0xec, 0x78, 0x74, 0xc4, 0xa3, 0x05, 0x00, 0x00,
 }
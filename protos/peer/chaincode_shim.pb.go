@@ -214,7 +214,13 @@ func (m *GetQueryResult) GetQuery() string {
 }
 
 type GetHistoryForKey struct {
-	Key string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Key        string                      `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	StartBlock uint64                      `protobuf:"varint,2,opt,name=start_block,json=startBlock" json:"start_block,omitempty"`
+	EndBlock   uint64                      `protobuf:"varint,3,opt,name=end_block,json=endBlock" json:"end_block,omitempty"`
+	Reverse    bool                        `protobuf:"varint,4,opt,name=reverse" json:"reverse,omitempty"`
+	PageSize   int32                       `protobuf:"varint,5,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+	Bookmark   string                      `protobuf:"bytes,6,opt,name=bookmark" json:"bookmark,omitempty"`
+	StartTime  *google_protobuf1.Timestamp `protobuf:"bytes,7,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
 }
 
 func (m *GetHistoryForKey) Reset()                    { *m = GetHistoryForKey{} }
@@ -229,6 +235,48 @@ func (m *GetHistoryForKey) GetKey() string {
 	return ""
 }
 
+func (m *GetHistoryForKey) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *GetHistoryForKey) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *GetHistoryForKey) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
+func (m *GetHistoryForKey) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *GetHistoryForKey) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
+func (m *GetHistoryForKey) GetStartTime() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
 type QueryStateNext struct {
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 }
@@ -278,9 +326,10 @@ func (m *QueryResultBytes) GetResultBytes() []byte {
 }
 
 type QueryResponse struct {
-	Results []*QueryResultBytes `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
-	HasMore bool                `protobuf:"varint,2,opt,name=has_more,json=hasMore" json:"has_more,omitempty"`
-	Id      string              `protobuf:"bytes,3,opt,name=id" json:"id,omitempty"`
+	Results  []*QueryResultBytes `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+	HasMore  bool                `protobuf:"varint,2,opt,name=has_more,json=hasMore" json:"has_more,omitempty"`
+	Id       string              `protobuf:"bytes,3,opt,name=id" json:"id,omitempty"`
+	Metadata []byte              `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
 }
 
 func (m *QueryResponse) Reset()                    { *m = QueryResponse{} }
@@ -309,6 +358,37 @@ func (m *QueryResponse) GetId() string {
 	return ""
 }
 
+func (m *QueryResponse) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+type QueryResponseMetadata struct {
+	FetchedRecordsCount int32  `protobuf:"varint,1,opt,name=fetched_records_count,json=fetchedRecordsCount" json:"fetched_records_count,omitempty"`
+	Bookmark            string `protobuf:"bytes,2,opt,name=bookmark" json:"bookmark,omitempty"`
+}
+
+func (m *QueryResponseMetadata) Reset()                    { *m = QueryResponseMetadata{} }
+func (m *QueryResponseMetadata) String() string            { return proto.CompactTextString(m) }
+func (*QueryResponseMetadata) ProtoMessage()               {}
+func (*QueryResponseMetadata) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{9} }
+
+func (m *QueryResponseMetadata) GetFetchedRecordsCount() int32 {
+	if m != nil {
+		return m.FetchedRecordsCount
+	}
+	return 0
+}
+
+func (m *QueryResponseMetadata) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeMessage)(nil), "protos.ChaincodeMessage")
 	proto.RegisterType((*PutStateInfo)(nil), "protos.PutStateInfo")
@@ -319,6 +399,7 @@ func init() {
 	proto.RegisterType((*QueryStateClose)(nil), "protos.QueryStateClose")
 	proto.RegisterType((*QueryResultBytes)(nil), "protos.QueryResultBytes")
 	proto.RegisterType((*QueryResponse)(nil), "protos.QueryResponse")
+	proto.RegisterType((*QueryResponseMetadata)(nil), "protos.QueryResponseMetadata")
 	proto.RegisterEnum("protos.ChaincodeMessage_Type", ChaincodeMessage_Type_name, ChaincodeMessage_Type_value)
 }
 
@@ -429,54 +510,63 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 771 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0x6f, 0x6f, 0xe2, 0x46,
-	0x10, 0xc6, 0x8f, 0x3f, 0x49, 0x60, 0x20, 0xb0, 0xb7, 0xb9, 0xa6, 0x3e, 0xa4, 0xaa, 0xd4, 0xaa,
-	0x2a, 0xfa, 0xc6, 0xb4, 0xb4, 0xaa, 0xfa, 0xae, 0x22, 0xb0, 0x21, 0x56, 0x88, 0xcd, 0xad, 0x9d,
-	0xd3, 0xd1, 0x37, 0x96, 0x03, 0x1b, 0x63, 0x15, 0x58, 0x77, 0x77, 0x39, 0x9d, 0x3f, 0x61, 0xbf,
-	0x4c, 0x3f, 0xc4, 0x69, 0x6d, 0x4c, 0xb8, 0x9c, 0xf2, 0xca, 0x7e, 0x66, 0x7e, 0xf3, 0xcc, 0xec,
-	0x6a, 0x35, 0xf0, 0x36, 0x61, 0x4c, 0xf4, 0x17, 0xab, 0x30, 0xde, 0x2e, 0xf8, 0x92, 0x05, 0x72,
-	0x15, 0x6f, 0xac, 0x44, 0x70, 0xc5, 0xf1, 0x69, 0xf6, 0x91, 0x9d, 0xce, 0x33, 0x84, 0x7d, 0x64,
-	0x5b, 0x95, 0x33, 0x9d, 0x8b, 0x2c, 0x97, 0x08, 0x9e, 0x70, 0x19, 0xae, 0xf7, 0xc1, 0xef, 0x23,
-	0xce, 0xa3, 0x35, 0xeb, 0x67, 0xea, 0x61, 0xf7, 0xd8, 0x57, 0xf1, 0x86, 0x49, 0x15, 0x6e, 0x92,
-	0x1c, 0x30, 0xff, 0xaf, 0x02, 0x1a, 0x15, 0x7e, 0x77, 0x4c, 0xca, 0x30, 0x62, 0xf8, 0x57, 0xa8,
-	0xaa, 0x34, 0x61, 0x46, 0xa9, 0x5b, 0xea, 0xb5, 0x06, 0xdf, 0xe5, 0xa8, 0xb4, 0x9e, 0x73, 0x96,
-	0x9f, 0x26, 0x8c, 0x66, 0x28, 0xfe, 0x13, 0xea, 0x07, 0x6b, 0xa3, 0xdc, 0x2d, 0xf5, 0x1a, 0x83,
-	0x8e, 0x95, 0x37, 0xb7, 0x8a, 0xe6, 0x96, 0x5f, 0x10, 0xf4, 0x09, 0xc6, 0x06, 0x9c, 0x25, 0x61,
-	0xba, 0xe6, 0xe1, 0xd2, 0xa8, 0x74, 0x4b, 0xbd, 0x26, 0x2d, 0x24, 0xc6, 0x50, 0x55, 0x9f, 0xe2,
-	0xa5, 0x51, 0xed, 0x96, 0x7a, 0x75, 0x9a, 0xfd, 0xe3, 0x01, 0xd4, 0x8a, 0x23, 0x1a, 0x27, 0x59,
-	0x9b, 0xcb, 0x62, 0x3c, 0x2f, 0x8e, 0xb6, 0x6c, 0x39, 0xdb, 0x67, 0xe9, 0x81, 0xc3, 0x7f, 0x41,
-	0xfb, 0xd9, 0x95, 0x19, 0xa7, 0x5f, 0x96, 0x1e, 0x4e, 0x46, 0x74, 0x96, 0xb6, 0x16, 0x5f, 0x68,
-	0xf3, 0xbf, 0x32, 0x54, 0xf5, 0x59, 0xf1, 0x39, 0xd4, 0xef, 0x9d, 0x31, 0xb9, 0xb6, 0x1d, 0x32,
-	0x46, 0xaf, 0x70, 0x13, 0x6a, 0x94, 0x4c, 0x6c, 0xcf, 0x27, 0x14, 0x95, 0x70, 0x0b, 0xa0, 0x50,
-	0x64, 0x8c, 0xca, 0xb8, 0x06, 0x55, 0xdb, 0xb1, 0x7d, 0x54, 0xc1, 0x75, 0x38, 0xa1, 0x64, 0x38,
-	0x9e, 0xa3, 0x2a, 0x6e, 0x43, 0xc3, 0xa7, 0x43, 0xc7, 0x1b, 0x8e, 0x7c, 0xdb, 0x75, 0xd0, 0x89,
-	0xb6, 0x1c, 0xb9, 0x77, 0xb3, 0x29, 0xf1, 0xc9, 0x18, 0x9d, 0x6a, 0x94, 0x50, 0xea, 0x52, 0x74,
-	0xa6, 0x33, 0x13, 0xe2, 0x07, 0x9e, 0x3f, 0xf4, 0x09, 0xaa, 0x69, 0x39, 0xbb, 0x2f, 0x64, 0x5d,
-	0xcb, 0x31, 0x99, 0xee, 0x25, 0xe0, 0x37, 0x80, 0x6c, 0xe7, 0xbd, 0x7b, 0x4b, 0x82, 0xd1, 0xcd,
-	0xd0, 0x76, 0x46, 0xee, 0x98, 0xa0, 0x46, 0x3e, 0xa0, 0x37, 0x73, 0x1d, 0x8f, 0xa0, 0x73, 0x7c,
-	0x09, 0xf8, 0x60, 0x18, 0x5c, 0xcd, 0x03, 0x3a, 0x74, 0x26, 0x04, 0xb5, 0x74, 0xad, 0x8e, 0xbf,
-	0xbb, 0x27, 0x74, 0x1e, 0x50, 0xe2, 0xdd, 0x4f, 0x7d, 0xd4, 0xd6, 0xd1, 0x3c, 0x92, 0xf3, 0x0e,
-	0xf9, 0xe0, 0x23, 0x84, 0xbf, 0x81, 0xd7, 0xc7, 0xd1, 0xd1, 0xd4, 0xf5, 0x08, 0x7a, 0xad, 0xa7,
-	0xb9, 0x25, 0x64, 0x36, 0x9c, 0xda, 0xef, 0x09, 0xc2, 0xf8, 0x5b, 0xb8, 0xd0, 0x8e, 0x37, 0xb6,
-	0xe7, 0xbb, 0x74, 0x1e, 0x5c, 0xbb, 0x34, 0xb8, 0x25, 0x73, 0x74, 0x61, 0xfe, 0x01, 0xcd, 0xd9,
-	0x4e, 0x79, 0x2a, 0x54, 0xcc, 0xde, 0x3e, 0x72, 0x8c, 0xa0, 0xf2, 0x0f, 0x4b, 0xb3, 0x87, 0x56,
-	0xa7, 0xfa, 0x17, 0xbf, 0x81, 0x93, 0x8f, 0xe1, 0x7a, 0xc7, 0xb2, 0x47, 0xd4, 0xa4, 0xb9, 0x30,
-	0x09, 0xb4, 0x27, 0x2c, 0xaf, 0xbb, 0x4a, 0x69, 0xb8, 0x8d, 0x18, 0xee, 0x40, 0x4d, 0xaa, 0x50,
-	0xa8, 0xdb, 0x43, 0xfd, 0x41, 0xe3, 0x4b, 0x38, 0x65, 0xdb, 0xa5, 0xce, 0x94, 0xb3, 0xcc, 0x5e,
-	0x99, 0x3f, 0x41, 0x6b, 0xc2, 0xd4, 0xbb, 0x1d, 0x13, 0x29, 0x65, 0x72, 0xb7, 0x56, 0xba, 0xdd,
-	0xbf, 0x5a, 0xee, 0x2d, 0x72, 0x61, 0xfe, 0x08, 0x68, 0xc2, 0xd4, 0x4d, 0x2c, 0x15, 0x17, 0xe9,
-	0x35, 0x17, 0xda, 0xf3, 0xab, 0x51, 0xcd, 0x2e, 0xb4, 0x32, 0xab, 0x6c, 0x2c, 0x87, 0x7d, 0x52,
-	0xb8, 0x05, 0xe5, 0x78, 0xb9, 0x47, 0xca, 0xf1, 0xd2, 0xfc, 0x01, 0xda, 0x4f, 0xc4, 0x68, 0xcd,
-	0x25, 0xfb, 0x0a, 0xf9, 0x1d, 0xd0, 0xd1, 0x3c, 0x57, 0xa9, 0x62, 0x12, 0x77, 0xa1, 0x21, 0x9e,
-	0x64, 0x06, 0x37, 0xe9, 0x71, 0xc8, 0xdc, 0xc2, 0x79, 0x51, 0x95, 0xf0, 0xad, 0x64, 0x78, 0x00,
-	0x67, 0x79, 0x5e, 0xe3, 0x95, 0x5e, 0x63, 0x60, 0x14, 0x6f, 0xfb, 0xb9, 0x3b, 0x2d, 0x40, 0xfc,
-	0x16, 0x6a, 0xab, 0x50, 0x06, 0x1b, 0x2e, 0xf2, 0xdb, 0xae, 0xd1, 0xb3, 0x55, 0x28, 0xef, 0xb8,
-	0x28, 0xa6, 0xac, 0x14, 0x53, 0x0e, 0x3e, 0x1c, 0x6d, 0x09, 0x6f, 0x97, 0x24, 0x5c, 0x28, 0x3c,
-	0x86, 0x1a, 0x65, 0x51, 0x2c, 0x15, 0x13, 0xd8, 0x78, 0x69, 0x47, 0x74, 0x5e, 0xcc, 0x98, 0xaf,
-	0x7a, 0xa5, 0x5f, 0x4a, 0x57, 0x2e, 0x98, 0x5c, 0x44, 0xd6, 0x2a, 0x4d, 0x98, 0x58, 0xb3, 0x65,
-	0xc4, 0x84, 0xf5, 0x18, 0x3e, 0x88, 0x78, 0x51, 0xd4, 0xe9, 0xb5, 0xf6, 0xf7, 0xcf, 0x51, 0xac,
-	0x56, 0xbb, 0x07, 0x6b, 0xc1, 0x37, 0xfd, 0x23, 0xb4, 0x9f, 0xa3, 0xf9, 0x7a, 0x93, 0x7d, 0x8d,
-	0x3e, 0xe4, 0xbb, 0xf2, 0xb7, 0xcf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xad, 0x9f, 0x80, 0x68, 0x4f,
-	0x05, 0x00, 0x00,
+	// 922 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xdd, 0x6e, 0xe2, 0x46,
+	0x14, 0x5e, 0xfe, 0x02, 0x1c, 0x58, 0x32, 0x3b, 0xd9, 0xa4, 0x5e, 0xaa, 0x6a, 0xa9, 0x2f, 0x2a,
+	0x7a, 0x03, 0x2d, 0xad, 0xaa, 0xf6, 0xaa, 0x22, 0x30, 0x21, 0x28, 0x89, 0x61, 0xc7, 0xce, 0x6a,
+	0xd3, 0x1b, 0xcb, 0xe0, 0x13, 0x63, 0x05, 0x18, 0xd7, 0x1e, 0xa2, 0x65, 0x1f, 0xa1, 0x2f, 0xd6,
+	0x97, 0xe9, 0x45, 0x1f, 0xa1, 0x9a, 0x31, 0x26, 0x3f, 0xab, 0x55, 0xaf, 0xec, 0xef, 0x7c, 0xdf,
+	0xf9, 0xf5, 0x19, 0x0f, 0xbc, 0x89, 0x10, 0xe3, 0xee, 0x7c, 0xe1, 0x85, 0xeb, 0xb9, 0xf0, 0xd1,
+	0x4d, 0x16, 0xe1, 0xaa, 0x13, 0xc5, 0x42, 0x0a, 0x7a, 0xa0, 0x1f, 0x49, 0xb3, 0xf9, 0x4c, 0x82,
+	0xf7, 0xb8, 0x96, 0xa9, 0xa6, 0x79, 0xa4, 0xb9, 0x28, 0x16, 0x91, 0x48, 0xbc, 0xe5, 0xce, 0xf8,
+	0x36, 0x10, 0x22, 0x58, 0x62, 0x57, 0xa3, 0xd9, 0xe6, 0xb6, 0x2b, 0xc3, 0x15, 0x26, 0xd2, 0x5b,
+	0x45, 0xa9, 0xc0, 0xfc, 0xa7, 0x08, 0x64, 0x90, 0xc5, 0xbb, 0xc2, 0x24, 0xf1, 0x02, 0xa4, 0x3f,
+	0x42, 0x51, 0x6e, 0x23, 0x34, 0x72, 0xad, 0x5c, 0xbb, 0xd1, 0xfb, 0x26, 0x95, 0x26, 0x9d, 0xe7,
+	0xba, 0x8e, 0xb3, 0x8d, 0x90, 0x6b, 0x29, 0xfd, 0x15, 0xaa, 0xfb, 0xd0, 0x46, 0xbe, 0x95, 0x6b,
+	0xd7, 0x7a, 0xcd, 0x4e, 0x9a, 0xbc, 0x93, 0x25, 0xef, 0x38, 0x99, 0x82, 0x3f, 0x88, 0xa9, 0x01,
+	0xe5, 0xc8, 0xdb, 0x2e, 0x85, 0xe7, 0x1b, 0x85, 0x56, 0xae, 0x5d, 0xe7, 0x19, 0xa4, 0x14, 0x8a,
+	0xf2, 0x63, 0xe8, 0x1b, 0xc5, 0x56, 0xae, 0x5d, 0xe5, 0xfa, 0x9d, 0xf6, 0xa0, 0x92, 0xb5, 0x68,
+	0x94, 0x74, 0x9a, 0x93, 0xac, 0x3c, 0x3b, 0x0c, 0xd6, 0xe8, 0x4f, 0x77, 0x2c, 0xdf, 0xeb, 0xe8,
+	0xef, 0x70, 0xf8, 0x6c, 0x64, 0xc6, 0xc1, 0x53, 0xd7, 0x7d, 0x67, 0x4c, 0xb1, 0xbc, 0x31, 0x7f,
+	0x82, 0xcd, 0xbf, 0xf3, 0x50, 0x54, 0xbd, 0xd2, 0x97, 0x50, 0xbd, 0xb6, 0x86, 0xec, 0x6c, 0x6c,
+	0xb1, 0x21, 0x79, 0x41, 0xeb, 0x50, 0xe1, 0x6c, 0x34, 0xb6, 0x1d, 0xc6, 0x49, 0x8e, 0x36, 0x00,
+	0x32, 0xc4, 0x86, 0x24, 0x4f, 0x2b, 0x50, 0x1c, 0x5b, 0x63, 0x87, 0x14, 0x68, 0x15, 0x4a, 0x9c,
+	0xf5, 0x87, 0x37, 0xa4, 0x48, 0x0f, 0xa1, 0xe6, 0xf0, 0xbe, 0x65, 0xf7, 0x07, 0xce, 0x78, 0x62,
+	0x91, 0x92, 0x0a, 0x39, 0x98, 0x5c, 0x4d, 0x2f, 0x99, 0xc3, 0x86, 0xe4, 0x40, 0x49, 0x19, 0xe7,
+	0x13, 0x4e, 0xca, 0x8a, 0x19, 0x31, 0xc7, 0xb5, 0x9d, 0xbe, 0xc3, 0x48, 0x45, 0xc1, 0xe9, 0x75,
+	0x06, 0xab, 0x0a, 0x0e, 0xd9, 0xe5, 0x0e, 0x02, 0x7d, 0x0d, 0x64, 0x6c, 0xbd, 0x9f, 0x5c, 0x30,
+	0x77, 0x70, 0xde, 0x1f, 0x5b, 0x83, 0xc9, 0x90, 0x91, 0x5a, 0x5a, 0xa0, 0x3d, 0x9d, 0x58, 0x36,
+	0x23, 0x2f, 0xe9, 0x09, 0xd0, 0x7d, 0x40, 0xf7, 0xf4, 0xc6, 0xe5, 0x7d, 0x6b, 0xc4, 0x48, 0x43,
+	0xf9, 0x2a, 0xfb, 0xbb, 0x6b, 0xc6, 0x6f, 0x5c, 0xce, 0xec, 0xeb, 0x4b, 0x87, 0x1c, 0x2a, 0x6b,
+	0x6a, 0x49, 0xf5, 0x16, 0xfb, 0xe0, 0x10, 0x42, 0x8f, 0xe1, 0xd5, 0x63, 0xeb, 0xe0, 0x72, 0x62,
+	0x33, 0xf2, 0x4a, 0x55, 0x73, 0xc1, 0xd8, 0xb4, 0x7f, 0x39, 0x7e, 0xcf, 0x08, 0xa5, 0x5f, 0xc1,
+	0x91, 0x8a, 0x78, 0x3e, 0xb6, 0x9d, 0x09, 0xbf, 0x71, 0xcf, 0x26, 0xdc, 0xbd, 0x60, 0x37, 0xe4,
+	0xc8, 0xfc, 0x05, 0xea, 0xd3, 0x8d, 0xb4, 0xa5, 0x27, 0x71, 0xbc, 0xbe, 0x15, 0x94, 0x40, 0xe1,
+	0x0e, 0xb7, 0x7a, 0xd1, 0xaa, 0x5c, 0xbd, 0xd2, 0xd7, 0x50, 0xba, 0xf7, 0x96, 0x1b, 0xd4, 0x4b,
+	0x54, 0xe7, 0x29, 0x30, 0x19, 0x1c, 0x8e, 0x30, 0xf5, 0x3b, 0xdd, 0x72, 0x6f, 0x1d, 0x20, 0x6d,
+	0x42, 0x25, 0x91, 0x5e, 0x2c, 0x2f, 0xf6, 0xfe, 0x7b, 0x4c, 0x4f, 0xe0, 0x00, 0xd7, 0xbe, 0x62,
+	0xf2, 0x9a, 0xd9, 0x21, 0xf3, 0x3b, 0x68, 0x8c, 0x50, 0xbe, 0xdb, 0x60, 0xbc, 0xe5, 0x98, 0x6c,
+	0x96, 0x52, 0xa5, 0xfb, 0x53, 0xc1, 0x5d, 0x88, 0x14, 0x98, 0xff, 0xe6, 0x80, 0x8c, 0x50, 0x9e,
+	0x87, 0x89, 0x14, 0xf1, 0xf6, 0x4c, 0xc4, 0x2a, 0xe8, 0xe7, 0xb5, 0xbe, 0x85, 0x9a, 0x4e, 0xe9,
+	0xce, 0x96, 0x62, 0x7e, 0xa7, 0x73, 0x15, 0x39, 0x68, 0xd3, 0xa9, 0xb2, 0xd0, 0xaf, 0xa1, 0x8a,
+	0x6b, 0x7f, 0x47, 0x17, 0x34, 0x5d, 0xc1, 0xb5, 0x9f, 0x92, 0x06, 0x94, 0x63, 0xbc, 0xc7, 0x38,
+	0x41, 0xbd, 0xe1, 0x15, 0x9e, 0x41, 0xe5, 0x16, 0x79, 0x01, 0xba, 0x49, 0xf8, 0x09, 0xf5, 0x96,
+	0x97, 0x78, 0x45, 0x19, 0xec, 0xf0, 0x93, 0xee, 0x7b, 0x26, 0xc4, 0xdd, 0xca, 0x8b, 0xef, 0xf4,
+	0x1a, 0x57, 0xf9, 0x1e, 0xd3, 0xdf, 0x20, 0xcd, 0xee, 0xaa, 0xe3, 0x65, 0x94, 0xff, 0xff, 0x18,
+	0x6a, 0xb5, 0xc2, 0x66, 0x0b, 0x1a, 0x7a, 0x2e, 0x7a, 0xc6, 0x16, 0x7e, 0x94, 0xb4, 0x01, 0xf9,
+	0xd0, 0xdf, 0xb5, 0x9b, 0x0f, 0x7d, 0xf3, 0x5b, 0x38, 0x7c, 0x50, 0x0c, 0x96, 0x22, 0xc1, 0xcf,
+	0x24, 0x3f, 0x03, 0x79, 0x34, 0xdc, 0xd3, 0xad, 0xc4, 0x84, 0xb6, 0xa0, 0x16, 0x3f, 0x40, 0x2d,
+	0xae, 0xf3, 0xc7, 0x26, 0xf3, 0xaf, 0x1c, 0xbc, 0xcc, 0xdc, 0x22, 0xb1, 0x4e, 0x90, 0xf6, 0xa0,
+	0x9c, 0x0a, 0x94, 0xbe, 0xd0, 0xae, 0xf5, 0x8c, 0xec, 0xa4, 0x3e, 0x0f, 0xcf, 0x33, 0x21, 0x7d,
+	0x03, 0x95, 0x85, 0x97, 0xb8, 0x2b, 0x11, 0xa7, 0xbb, 0x53, 0xe1, 0xe5, 0x85, 0x97, 0x5c, 0x89,
+	0x38, 0x2b, 0xb3, 0x90, 0x95, 0xa9, 0x46, 0xb8, 0x42, 0xe9, 0xf9, 0x9e, 0xf4, 0xf4, 0xe8, 0xeb,
+	0x7c, 0x8f, 0xcd, 0x00, 0x8e, 0x9f, 0xd4, 0x72, 0xb5, 0x23, 0x68, 0x0f, 0x8e, 0x6f, 0x51, 0xce,
+	0x17, 0xe8, 0xbb, 0x31, 0xce, 0x45, 0xec, 0x27, 0xee, 0x5c, 0x6c, 0xd6, 0x52, 0x77, 0x54, 0xe2,
+	0x47, 0x3b, 0x92, 0xa7, 0xdc, 0x40, 0x51, 0x4f, 0xbe, 0x55, 0xfe, 0xe9, 0xb7, 0xea, 0x7d, 0x78,
+	0xf4, 0xe3, 0xb5, 0x37, 0x51, 0x24, 0x62, 0x49, 0x87, 0x50, 0xe1, 0x18, 0x84, 0x89, 0xc4, 0x98,
+	0x1a, 0x5f, 0xfa, 0xed, 0x36, 0xbf, 0xc8, 0x98, 0x2f, 0xda, 0xb9, 0x1f, 0x72, 0xa7, 0x13, 0x30,
+	0x45, 0x1c, 0x74, 0x16, 0xdb, 0x08, 0xe3, 0x25, 0xfa, 0x01, 0xc6, 0x9d, 0x5b, 0x6f, 0x16, 0x87,
+	0xf3, 0xcc, 0x4f, 0xdd, 0x14, 0x7f, 0x7c, 0x1f, 0x84, 0x72, 0xb1, 0x99, 0x75, 0xe6, 0x62, 0xd5,
+	0x7d, 0x24, 0xed, 0xa6, 0xd2, 0xf4, 0xc6, 0x48, 0xba, 0x4a, 0x3a, 0x4b, 0xaf, 0x9f, 0x9f, 0xfe,
+	0x1b, 0x00, 0xdb, 0x3d, 0x72, 0xf9, 0xa2, 0x06, 0x00, 0x00,
 }
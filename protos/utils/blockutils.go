@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 )
 
@@ -101,6 +102,62 @@ func GetLastConfigIndexFromBlockOrPanic(block *cb.Block) uint64 {
 	return index
 }
 
+// GetCommitHashFromBlock retrieves the commit hash recorded in the block metadata by the peer which committed the block,
+// or nil if the block has no commit hash. Like the transactions filter, the commit hash is stored as is in the block metadata
+func GetCommitHashFromBlock(block *cb.Block) []byte {
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(cb.BlockMetadataIndex_COMMIT_HASH) ||
+		len(block.Metadata.Metadata[cb.BlockMetadataIndex_COMMIT_HASH]) == 0 {
+		return nil
+	}
+	return block.Metadata.Metadata[cb.BlockMetadataIndex_COMMIT_HASH]
+}
+
+// SetCommitHashInBlock records the commit hash in the block metadata, adding the
+// missing metadata positions of a block created with fewer metadata positions
+func SetCommitHashInBlock(block *cb.Block, commitHash []byte) {
+	InitBlockMetadata(block)
+	for len(block.Metadata.Metadata) <= int(cb.BlockMetadataIndex_COMMIT_HASH) {
+		block.Metadata.Metadata = append(block.Metadata.Metadata, []byte{})
+	}
+	block.Metadata.Metadata[cb.BlockMetadataIndex_COMMIT_HASH] = commitHash
+}
+
+// signaturePolicy evaluates a set of signatures, like policies.Policy
+// which can't be referred to here, as the policies package imports this one
+type signaturePolicy interface {
+	Evaluate(signatureSet []*cb.SignedData) error
+}
+
+// VerifyBlockSignatures evaluates the signatures of the block, which sign the header of
+// the block along with the signatures metadata, against the given block validation policy
+func VerifyBlockSignatures(block *cb.Block, policy signaturePolicy) error {
+	if block.Header == nil || block.Metadata == nil || len(block.Metadata.Metadata) <= int(cb.BlockMetadataIndex_SIGNATURES) {
+		return fmt.Errorf("block has no header or no signatures metadata")
+	}
+	metadata, err := GetMetadataFromBlock(block, cb.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return fmt.Errorf("failed unmarshaling signatures of block: %s", err)
+	}
+
+	signatureSet := []*cb.SignedData{}
+	for _, metadataSignature := range metadata.Signatures {
+		shdr, err := GetSignatureHeader(metadataSignature.SignatureHeader)
+		if err != nil {
+			return fmt.Errorf("failed unmarshaling signature header of block: %s", err)
+		}
+		signatureSet = append(signatureSet, &cb.SignedData{
+			Identity:  shdr.Creator,
+			Data:      util.ConcatenateBytes(metadata.Value, metadataSignature.SignatureHeader, block.Header.Bytes()),
+			Signature: metadataSignature.Signature,
+		})
+	}
+
+	if err := policy.Evaluate(signatureSet); err != nil {
+		return fmt.Errorf("block signatures don't satisfy the block validation policy: %s", err)
+	}
+	return nil
+}
+
 // GetBlockFromBlockBytes marshals the bytes into Block
 func GetBlockFromBlockBytes(blockBytes []byte) (*cb.Block, error) {
 	block := &cb.Block{}
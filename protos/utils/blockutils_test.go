@@ -18,6 +18,7 @@ limitations under the License.
 package utils_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -215,3 +216,54 @@ func TestGetLastConfigIndexFromBlock(t *testing.T) {
 		_ = utils.GetLastConfigIndexFromBlockOrPanic(block)
 	}, "Expected panic with malformed last config metadata")
 }
+
+func TestCommitHashInBlock(t *testing.T) {
+	block := common.NewBlock(0, nil)
+	assert.Nil(t, utils.GetCommitHashFromBlock(block), "Expected no commit hash in a new block")
+	utils.SetCommitHashInBlock(block, []byte("commit hash"))
+	assert.Equal(t, []byte("commit hash"), utils.GetCommitHashFromBlock(block), "Unexpected commit hash returned from block")
+
+	// block with fewer metadata entries, as created by an orderer which does not know of the commit hash
+	block = &cb.Block{}
+	utils.InitBlockMetadata(block)
+	assert.Nil(t, utils.GetCommitHashFromBlock(block), "Expected no commit hash in a block without a commit hash entry")
+	utils.SetCommitHashInBlock(block, []byte("commit hash"))
+	assert.Equal(t, int(cb.BlockMetadataIndex_COMMIT_HASH)+1, len(block.Metadata.Metadata), "Expected the metadata entries to be added")
+	assert.Equal(t, []byte("commit hash"), utils.GetCommitHashFromBlock(block), "Unexpected commit hash returned from block")
+}
+
+type mockSignaturePolicy struct {
+	signatureSet []*cb.SignedData
+	err          error
+}
+
+func (p *mockSignaturePolicy) Evaluate(signatureSet []*cb.SignedData) error {
+	p.signatureSet = signatureSet
+	return p.err
+}
+
+func TestVerifyBlockSignatures(t *testing.T) {
+	block := common.NewBlock(1, []byte("previous hash"))
+	sigHeader := utils.MarshalOrPanic(&cb.SignatureHeader{Creator: []byte("orderer"), Nonce: []byte("nonce")})
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&cb.Metadata{
+		Value:      []byte("value"),
+		Signatures: []*cb.MetadataSignature{{SignatureHeader: sigHeader, Signature: []byte("signature")}},
+	})
+
+	policy := &mockSignaturePolicy{}
+	assert.NoError(t, utils.VerifyBlockSignatures(block, policy))
+	assert.Equal(t, []*cb.SignedData{{
+		Identity:  []byte("orderer"),
+		Data:      append(append([]byte("value"), sigHeader...), block.Header.Bytes()...),
+		Signature: []byte("signature"),
+	}}, policy.signatureSet)
+
+	policy.err = errors.New("not enough signatures")
+	assert.EqualError(t, utils.VerifyBlockSignatures(block, policy),
+		"block signatures don't satisfy the block validation policy: not enough signatures")
+
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = []byte("garbage")
+	assert.Error(t, utils.VerifyBlockSignatures(block, policy))
+	block.Metadata = nil
+	assert.EqualError(t, utils.VerifyBlockSignatures(block, policy), "block has no header or no signatures metadata")
+}
@@ -244,6 +244,14 @@ func CreateChaincodeProposal(typ common.HeaderType, chainID string, cis *peer.Ch
 // CreateChaincodeProposalWithTransient creates a proposal from given input
 // It returns the proposal and the transaction id associated to the proposal
 func CreateChaincodeProposalWithTransient(typ common.HeaderType, chainID string, cis *peer.ChaincodeInvocationSpec, creator []byte, transientMap map[string][]byte) (*peer.Proposal, string, error) {
+	return CreateChaincodeProposalWithTLSBinding(typ, chainID, cis, creator, transientMap, nil)
+}
+
+// CreateChaincodeProposalWithTLSBinding creates a proposal from given input.
+// It also includes the hash of the TLS client certificate the proposal is sent
+// with into the channel header, which is carried over to the transaction.
+// It returns the proposal and the transaction id associated to the proposal
+func CreateChaincodeProposalWithTLSBinding(typ common.HeaderType, chainID string, cis *peer.ChaincodeInvocationSpec, creator []byte, transientMap map[string][]byte, tlsCertHash []byte) (*peer.Proposal, string, error) {
 	// generate a random nonce
 	nonce, err := crypto.GetRandomNonce()
 	if err != nil {
@@ -256,11 +264,15 @@ func CreateChaincodeProposalWithTransient(typ common.HeaderType, chainID string,
 		return nil, "", err
 	}
 
-	return CreateChaincodeProposalWithTxIDNonceAndTransient(txid, typ, chainID, cis, nonce, creator, transientMap)
+	return createChaincodeProposal(txid, typ, chainID, cis, nonce, creator, transientMap, tlsCertHash)
 }
 
 // CreateChaincodeProposalWithTxIDNonceAndTransient creates a proposal from given input
 func CreateChaincodeProposalWithTxIDNonceAndTransient(txid string, typ common.HeaderType, chainID string, cis *peer.ChaincodeInvocationSpec, nonce, creator []byte, transientMap map[string][]byte) (*peer.Proposal, string, error) {
+	return createChaincodeProposal(txid, typ, chainID, cis, nonce, creator, transientMap, nil)
+}
+
+func createChaincodeProposal(txid string, typ common.HeaderType, chainID string, cis *peer.ChaincodeInvocationSpec, nonce, creator []byte, transientMap map[string][]byte, tlsCertHash []byte) (*peer.Proposal, string, error) {
 	ccHdrExt := &peer.ChaincodeHeaderExtension{ChaincodeId: cis.ChaincodeSpec.ChaincodeId}
 	ccHdrExtBytes, err := proto.Marshal(ccHdrExt)
 	if err != nil {
@@ -285,12 +297,13 @@ func CreateChaincodeProposalWithTxIDNonceAndTransient(txid string, typ common.He
 	timestamp := util.CreateUtcTimestamp()
 
 	hdr := &common.Header{ChannelHeader: MarshalOrPanic(&common.ChannelHeader{
-		Type:      int32(typ),
-		TxId:      txid,
-		Timestamp: timestamp,
-		ChannelId: chainID,
-		Extension: ccHdrExtBytes,
-		Epoch:     epoch}),
+		Type:        int32(typ),
+		TxId:        txid,
+		Timestamp:   timestamp,
+		ChannelId:   chainID,
+		Extension:   ccHdrExtBytes,
+		Epoch:       epoch,
+		TlsCertHash: tlsCertHash}),
 		SignatureHeader: MarshalOrPanic(&common.SignatureHeader{Nonce: nonce, Creator: creator})}
 
 	hdrBytes, err := proto.Marshal(hdr)
@@ -445,21 +458,33 @@ func CreateGetInstalledChaincodesProposal(creator []byte) (*peer.Proposal, strin
 
 // CreateInstallProposalFromCDS returns a install proposal given a serialized identity and a ChaincodeDeploymentSpec
 func CreateInstallProposalFromCDS(ccpack proto.Message, creator []byte) (*peer.Proposal, string, error) {
-	return createProposalFromCDS("", ccpack, creator, nil, nil, nil, "install")
+	return createProposalFromCDS("", ccpack, creator, nil, nil, nil, "install", nil)
 }
 
 // CreateDeployProposalFromCDS returns a deploy proposal given a serialized identity and a ChaincodeDeploymentSpec
 func CreateDeployProposalFromCDS(chainID string, cds *peer.ChaincodeDeploymentSpec, creator []byte, policy []byte, escc []byte, vscc []byte) (*peer.Proposal, string, error) {
-	return createProposalFromCDS(chainID, cds, creator, policy, escc, vscc, "deploy")
+	return createProposalFromCDS(chainID, cds, creator, policy, escc, vscc, "deploy", nil)
+}
+
+// CreateDeployProposalFromCDSWithTLSBinding returns a deploy proposal given a serialized identity and a ChaincodeDeploymentSpec,
+// which is bound to the TLS client certificate with the given hash
+func CreateDeployProposalFromCDSWithTLSBinding(chainID string, cds *peer.ChaincodeDeploymentSpec, creator []byte, policy []byte, escc []byte, vscc []byte, tlsCertHash []byte) (*peer.Proposal, string, error) {
+	return createProposalFromCDS(chainID, cds, creator, policy, escc, vscc, "deploy", tlsCertHash)
 }
 
 // CreateUpgradeProposalFromCDS returns a upgrade proposal given a serialized identity and a ChaincodeDeploymentSpec
 func CreateUpgradeProposalFromCDS(chainID string, cds *peer.ChaincodeDeploymentSpec, creator []byte, policy []byte, escc []byte, vscc []byte) (*peer.Proposal, string, error) {
-	return createProposalFromCDS(chainID, cds, creator, policy, escc, vscc, "upgrade")
+	return createProposalFromCDS(chainID, cds, creator, policy, escc, vscc, "upgrade", nil)
+}
+
+// CreateUpgradeProposalFromCDSWithTLSBinding returns a upgrade proposal given a serialized identity and a ChaincodeDeploymentSpec,
+// which is bound to the TLS client certificate with the given hash
+func CreateUpgradeProposalFromCDSWithTLSBinding(chainID string, cds *peer.ChaincodeDeploymentSpec, creator []byte, policy []byte, escc []byte, vscc []byte, tlsCertHash []byte) (*peer.Proposal, string, error) {
+	return createProposalFromCDS(chainID, cds, creator, policy, escc, vscc, "upgrade", tlsCertHash)
 }
 
 // createProposalFromCDS returns a deploy or upgrade proposal given a serialized identity and a ChaincodeDeploymentSpec
-func createProposalFromCDS(chainID string, msg proto.Message, creator []byte, policy []byte, escc []byte, vscc []byte, propType string) (*peer.Proposal, string, error) {
+func createProposalFromCDS(chainID string, msg proto.Message, creator []byte, policy []byte, escc []byte, vscc []byte, propType string, tlsCertHash []byte) (*peer.Proposal, string, error) {
 	//in the new mode, cds will be nil, "deploy" and "upgrade" are instantiates.
 	var ccinp *peer.ChaincodeInput
 	var b []byte
@@ -491,7 +516,7 @@ func createProposalFromCDS(chainID string, msg proto.Message, creator []byte, po
 			Input:       ccinp}}
 
 	//...and get the proposal for it
-	return CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, chainID, lsccSpec, creator)
+	return CreateChaincodeProposalWithTLSBinding(common.HeaderType_ENDORSER_TRANSACTION, chainID, lsccSpec, creator, nil, tlsCertHash)
 }
 
 // ComputeProposalTxID computes TxID as the Hash computed
@@ -223,6 +223,44 @@ func TestCDSProposals(t *testing.T) {
 
 }
 
+func TestProposalsWithTLSBinding(t *testing.T) {
+	creator := []byte("creator")
+	tlsCertHash := []byte("tlsCertHash")
+	cds := &pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type: pb.ChaincodeSpec_GOLANG,
+		},
+	}
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: "mycc"},
+		},
+	}
+	chainID := "testchainid"
+
+	checkTLSCertHash := func(prop *pb.Proposal, err error, expected []byte) {
+		assert.NoError(t, err, "Unexpected error creating proposal")
+		hdr, err := utils.GetHeader(prop.Header)
+		assert.NoError(t, err)
+		chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, chdr.TlsCertHash)
+	}
+
+	prop, _, err := utils.CreateChaincodeProposalWithTLSBinding(common.HeaderType_ENDORSER_TRANSACTION, chainID, cis, creator, nil, tlsCertHash)
+	checkTLSCertHash(prop, err, tlsCertHash)
+	prop, _, err = utils.CreateDeployProposalFromCDSWithTLSBinding(chainID, cds, creator, nil, nil, nil, tlsCertHash)
+	checkTLSCertHash(prop, err, tlsCertHash)
+	prop, _, err = utils.CreateUpgradeProposalFromCDSWithTLSBinding(chainID, cds, creator, nil, nil, nil, tlsCertHash)
+	checkTLSCertHash(prop, err, tlsCertHash)
+
+	// proposals aren't bound to a TLS certificate otherwise
+	prop, _, err = utils.CreateChaincodeProposalWithTransient(common.HeaderType_ENDORSER_TRANSACTION, chainID, cis, creator, nil)
+	checkTLSCertHash(prop, err, nil)
+	prop, _, err = utils.CreateDeployProposalFromCDS(chainID, cds, creator, nil, nil, nil)
+	checkTLSCertHash(prop, err, nil)
+}
+
 func TestComputeProposalBinding(t *testing.T) {
 	expectedDigestHex := "5093dd4f4277e964da8f4afbde0a9674d17f2a6a5961f0670fc21ae9b67f2983"
 	expectedDigest, _ := hex.DecodeString(expectedDigestHex)
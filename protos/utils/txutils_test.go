@@ -256,6 +256,21 @@ func TestCreateSignedEnvelope(t *testing.T) {
 	assert.Error(t, err, "Expected sign error")
 }
 
+func TestCreateSignedEnvelopeWithTLSBinding(t *testing.T) {
+	channelID := "mychannelID"
+	tlsCertHash := []byte("tlsCertHash")
+
+	env, err := utils.CreateSignedEnvelopeWithTLSBinding(cb.HeaderType_DELIVER_SEEK_INFO, channelID,
+		goodSigner, &cb.ConfigEnvelope{}, int32(1), uint64(1), tlsCertHash)
+	assert.NoError(t, err, "Unexpected error creating signed envelope")
+	payload := &cb.Payload{}
+	err = proto.Unmarshal(env.Payload, payload)
+	assert.NoError(t, err, "Failed to unmarshal payload")
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	assert.NoError(t, err, "Failed to unmarshal channel header")
+	assert.Equal(t, tlsCertHash, chdr.TlsCertHash, "TLS cert hash does not match expected value")
+}
+
 func TestCreateSignedEnvelopeNilSigner(t *testing.T) {
 	var env *cb.Envelope
 	channelID := "mychannelID"
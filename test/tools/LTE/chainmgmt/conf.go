@@ -19,9 +19,11 @@ package chainmgmt
 // ChainMgrConf captures the configurations meant at the level of chainMgr
 // DataDir field specifies the filesystem location where the chains data is maintained
 // NumChains field specifies the number of chains to instantiate
+// ValidationParallelism field specifies the number of transactions of a block validated concurrently (0 for the number of CPUs)
 type ChainMgrConf struct {
-	DataDir   string
-	NumChains int
+	DataDir               string
+	NumChains             int
+	ValidationParallelism int
 }
 
 // BatchConf captures the batch related configurations
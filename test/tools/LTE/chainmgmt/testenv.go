@@ -31,6 +31,7 @@ type TestEnv struct {
 // for each of the chains. For configurations options, see comments on specific configuration type
 func InitTestEnv(mgrConf *ChainMgrConf, batchConf *BatchConf, initOperation chainInitOp) *TestEnv {
 	viper.Set("peer.fileSystemPath", mgrConf.DataDir)
+	viper.Set("ledger.state.validationParallelism", mgrConf.ValidationParallelism)
 	mgr := newChainsMgr(mgrConf, batchConf, initOperation)
 	chains := mgr.createOrOpenChains()
 	for _, chain := range chains {
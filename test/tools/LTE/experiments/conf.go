@@ -68,6 +68,8 @@ func confFromTestParams(testParams []string) *configuration {
 	// chainMgrConf
 	dataDir := flags.String("DataDir", conf.chainMgrConf.DataDir, "Dir for ledger data")
 	numChains := flags.Int("NumChains", conf.chainMgrConf.NumChains, "Number of chains")
+	validationParallelism := flags.Int("ValidationParallelism", conf.chainMgrConf.ValidationParallelism,
+		"Number of Txs of a block validated concurrently (0 for the number of CPUs)")
 
 	// txConf
 	numParallelTxsPerChain := flags.Int("NumParallelTxPerChain",
@@ -94,6 +96,7 @@ func confFromTestParams(testParams []string) *configuration {
 
 	conf.chainMgrConf.DataDir = *dataDir
 	conf.chainMgrConf.NumChains = *numChains
+	conf.chainMgrConf.ValidationParallelism = *validationParallelism
 	conf.txConf.numParallelTxsPerChain = *numParallelTxsPerChain
 	conf.txConf.numTotalTxs = *numTotalTxs
 	conf.txConf.numKeysInEachTx = *numKeysInEachTx
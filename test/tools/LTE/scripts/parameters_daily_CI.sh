@@ -12,6 +12,7 @@ NumTotalTx=100000
 NumKeysInEachTx=4
 BatchSize=50
 KVSize=200
+ValidationParallelism=0
 
 # Each test consists of several test-runs, where one single parameter is varied
 # between the test-runs and rest of the parameters remain same. Each array below
@@ -26,6 +27,7 @@ KVSize=200
 # NumKeysInEachTx=4
 # BatchSize=50
 # KVSize=200
+# ValidationParallelism=0
 ArrayNumParallelTxPerChain=(1 5 10 20 50 100 500 2000)
 ArrayNumChains=(1 5 10 20 50 100 500 2000)
 ArrayNumKeysInEachTx=(1 2 5 10 20)
@@ -34,3 +36,4 @@ ArrayBatchSize=(10 20 100 500)
 ArrayNumParallelTxWithSingleChain=(1 5 10 20 50 100 500 2000)
 ArrayNumChainsWithNoParallelism=(1 5 10 20 50 100 500 2000)
 ArrayNumTxs=(100000 200000 500000 1000000)
+ArrayValidationParallelism=(1 2 4 8)
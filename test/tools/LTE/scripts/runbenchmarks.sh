@@ -77,6 +77,14 @@ function varyNumTxs {
     done
 }
 
+function varyValidationParallelism {
+    for v in "${ArrayValidationParallelism[@]}"
+    do
+        ValidationParallelism=$v
+        rm -rf $DataDir;runInsertTxs;runReadWriteTxs
+    done
+}
+
 function runLargeDataExperiment {
     NumKVs=10000000
     NumTotalTx=10000000
@@ -93,6 +101,7 @@ varyNumKeysInEachTx
 varyKVSize
 varyBatchSize
 varyNumTxs
+varyValidationParallelism
 runLargeDataExperiment\n"
 }
 
@@ -138,6 +147,8 @@ case $1 in
     varyBatchSize ;;
   varyNumTxs)
     varyNumTxs ;;
+  varyValidationParallelism)
+    varyValidationParallelism ;;
   runLargeDataExperiment)
     runLargeDataExperiment ;;
   help)
@@ -152,6 +163,7 @@ case $1 in
     varyKVSize
     varyBatchSize
     varyNumTxs
+    varyValidationParallelism
     runLargeDataExperiment ;;
   *)
     printf "Error: test name empty/incorrect!\n"  >> /dev/stderr